@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// maxAPITokensPerUser keeps forgotten tokens from piling up
+const maxAPITokensPerUser = 20
+
+// maxAPITokenExpiresInDays is the longest an expiring token can last
+const maxAPITokenExpiresInDays = 365
+
+// CreateAPIToken is the input model used to create a personal API token
+type CreateAPIToken struct {
+	Name          string               `json:"name"`
+	Scopes        []enum.APITokenScope `json:"scopes"`
+	ExpiresInDays int                  `json:"expiresInDays"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateAPIToken) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *CreateAPIToken) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 100 {
+		result.AddFieldFailure("name", "Name must have less than 100 characters.")
+	}
+
+	if len(action.Scopes) == 0 {
+		result.AddFieldFailure("scopes", "At least one scope is required.")
+	}
+
+	seen := make(map[enum.APITokenScope]bool, len(action.Scopes))
+	scopes := make([]enum.APITokenScope, 0, len(action.Scopes))
+	for _, scope := range action.Scopes {
+		if scope.String() == "" {
+			result.AddFieldFailure("scopes", "Unknown scope.")
+			continue
+		}
+		if !canUseAPITokenScope(user, scope) {
+			result.AddFieldFailure("scopes", fmt.Sprintf("You are not allowed to create tokens with the '%s' scope.", scope))
+			continue
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	action.Scopes = scopes
+
+	if action.ExpiresInDays < 0 || action.ExpiresInDays > maxAPITokenExpiresInDays {
+		result.AddFieldFailure("expiresInDays", fmt.Sprintf("Expiration must be between 1 and %d days, or 0 for tokens that never expire.", maxAPITokenExpiresInDays))
+	}
+
+	tokens := &query.ListAPITokens{UserID: user.ID}
+	if err := bus.Dispatch(ctx, tokens); err != nil {
+		return validate.Error(err)
+	}
+	if len(tokens.Result) >= maxAPITokensPerUser {
+		result.AddFieldFailure("", fmt.Sprintf("You can't have more than %d API tokens, delete the ones you no longer use.", maxAPITokensPerUser))
+	}
+
+	return result
+}
+
+// canUseAPITokenScope returns true if user is allowed to create tokens with given scope
+// Tokens never grant more than the role of their owner, the moderate scope is only offered to users with permissions
+// and the admin scope to collaborators and administrators
+func canUseAPITokenScope(user *entity.User, scope enum.APITokenScope) bool {
+	switch scope {
+	case enum.APITokenScopeAdmin:
+		return user.IsCollaborator()
+	case enum.APITokenScopeModerate:
+		return len(user.Permissions()) > 0
+	default:
+		return true
+	}
+}
+
+// apiTokenAllows returns true if the request isn't made with a personal API token, or if its token has given scope
+func apiTokenAllows(ctx context.Context, scope enum.APITokenScope) bool {
+	token, ok := ctx.Value(app.APITokenCtxKey).(*entity.APIToken)
+	return !ok || token == nil || token.HasScope(scope)
+}
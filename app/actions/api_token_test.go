@@ -0,0 +1,62 @@
+package actions_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestCreateAPIToken_Valid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAPITokens) error {
+		q.Result = []*entity.APIToken{}
+		return nil
+	})
+
+	action := &actions.CreateAPIToken{}
+	err := json.Unmarshal([]byte(`{"name":"My Bot","scopes":["read:posts","write:comments","read:posts"],"expiresInDays":30}`), action)
+	Expect(err).IsNil()
+
+	user := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	result := action.Validate(context.Background(), user)
+	ExpectSuccess(result)
+	Expect(action.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeWriteComments})
+}
+
+func TestCreateAPIToken_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAPITokens) error {
+		q.Result = []*entity.APIToken{}
+		return nil
+	})
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	moderator := &entity.User{ID: 2, Role: enum.RoleModerator}
+
+	testCases := []struct {
+		user   *entity.User
+		action *actions.CreateAPIToken
+		field  string
+	}{
+		{visitor, &actions.CreateAPIToken{Scopes: []enum.APITokenScope{enum.APITokenScopeReadPosts}}, "name"},
+		{visitor, &actions.CreateAPIToken{Name: "Bot"}, "scopes"},
+		{visitor, &actions.CreateAPIToken{Name: "Bot", Scopes: []enum.APITokenScope{0}}, "scopes"},
+		{visitor, &actions.CreateAPIToken{Name: "Bot", Scopes: []enum.APITokenScope{enum.APITokenScopeModerate}}, "scopes"},
+		{moderator, &actions.CreateAPIToken{Name: "Bot", Scopes: []enum.APITokenScope{enum.APITokenScopeAdmin}}, "scopes"},
+		{visitor, &actions.CreateAPIToken{Name: "Bot", Scopes: []enum.APITokenScope{enum.APITokenScopeReadPosts}, ExpiresInDays: 1000}, "expiresInDays"},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), testCase.user)
+		ExpectFailed(result, testCase.field)
+	}
+}
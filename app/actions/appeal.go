@@ -0,0 +1,166 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/utils"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// CreateAppeal is used by a sanctioned user to contest a warning, mute or block
+type CreateAppeal struct {
+	SanctionType   string `json:"sanctionType"`
+	SanctionID     int    `json:"sanctionId"`
+	Text           string `json:"text"`
+	SanctionReason string `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (a *CreateAppeal) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current action is valid
+func (a *CreateAppeal) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Text == "" {
+		result.AddFieldFailure("text", propertyIsRequired(ctx, "text"))
+	} else if len(a.Text) > 2000 {
+		result.AddFieldFailure("text", propertyMaxStringLen(ctx, "text", 2000))
+	}
+
+	if !entity.IsValidAppealSanction(a.SanctionType) {
+		result.AddFieldFailure("sanctionType", propertyIsInvalid(ctx, "sanctionType"))
+		return result
+	}
+
+	if a.SanctionType == entity.AppealSanctionBlock {
+		a.SanctionID = 0
+		if user.Status != enum.UserBlocked {
+			result.AddFieldFailure("sanctionType", i18n.T(ctx, "validation.custom.sanctionnotactive"))
+			return result
+		}
+	} else {
+		standing := &query.GetUserProfileStanding{UserID: user.ID}
+		if err := bus.Dispatch(ctx, standing); err != nil {
+			return validate.Error(err)
+		}
+
+		now := time.Now()
+		found := false
+		if a.SanctionType == entity.AppealSanctionWarning {
+			for _, w := range standing.Result.Warnings {
+				if w.ID == a.SanctionID && (w.ExpiresAt == nil || w.ExpiresAt.After(now)) {
+					a.SanctionReason = w.Reason
+					found = true
+				}
+			}
+		} else {
+			for _, m := range standing.Result.Mutes {
+				if m.ID == a.SanctionID && (m.ExpiresAt == nil || m.ExpiresAt.After(now)) {
+					a.SanctionReason = m.Reason
+					found = true
+				}
+			}
+		}
+
+		if !found {
+			result.AddFieldFailure("sanctionId", i18n.T(ctx, "validation.custom.sanctionnotactive"))
+			return result
+		}
+	}
+
+	appealable := &query.IsSanctionAppealable{
+		UserID:       user.ID,
+		SanctionType: a.SanctionType,
+		SanctionID:   a.SanctionID,
+	}
+	if err := bus.Dispatch(ctx, appealable); err != nil {
+		return validate.Error(err)
+	}
+	if !appealable.Result {
+		result.AddFieldFailure("sanctionId", i18n.T(ctx, "validation.custom.alreadyappealed"))
+	}
+
+	return result
+}
+
+// DecideAppeal is used by staff to uphold, reduce or lift an appealed sanction
+type DecideAppeal struct {
+	AppealID     int            `json:"appealId"`
+	Status       string         `json:"status"`
+	Note         string         `json:"note"`
+	DurationStr  string         `json:"duration"`
+	Appeal       *entity.Appeal `json:"-"`
+	ReducedUntil *time.Time     `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (a *DecideAppeal) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil || !user.HasPermission(enum.PermissionAppealDecide) {
+		return false
+	}
+
+	if a.Appeal == nil {
+		return true
+	}
+
+	// nobody decides on their own appeal and only those who can unblock users decide on blocks
+	if a.Appeal.User.ID == user.ID {
+		return false
+	}
+	if a.Appeal.SanctionType == entity.AppealSanctionBlock {
+		return user.HasPermission(enum.PermissionUserBlock)
+	}
+	return true
+}
+
+// Validate if current action is valid
+func (a *DecideAppeal) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Appeal == nil || !a.Appeal.IsPending() {
+		result.AddFieldFailure("appealId", i18n.T(ctx, "validation.custom.appealclosed"))
+		return result
+	}
+
+	if a.Status != "upheld" && a.Status != "reduced" && a.Status != "lifted" {
+		result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
+		return result
+	}
+
+	if a.Note == "" {
+		result.AddFieldFailure("note", propertyIsRequired(ctx, "note"))
+	} else if len(a.Note) > 2000 {
+		result.AddFieldFailure("note", propertyMaxStringLen(ctx, "note", 2000))
+	}
+
+	if a.Status == "reduced" {
+		if a.Appeal.SanctionType == entity.AppealSanctionBlock {
+			result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
+			return result
+		}
+
+		minutes, ok := utils.ParseDuration(a.DurationStr)
+		if !ok || minutes < 1 {
+			result.AddFieldFailure("duration", propertyIsInvalid(ctx, "duration"))
+			return result
+		}
+
+		reducedUntil := time.Now().Add(time.Duration(minutes) * time.Minute)
+		if a.Appeal.SanctionExpiresAt != nil && !reducedUntil.Before(*a.Appeal.SanctionExpiresAt) {
+			result.AddFieldFailure("duration", i18n.T(ctx, "validation.custom.reductiontoolong"))
+			return result
+		}
+		a.ReducedUntil = &reducedUntil
+	}
+
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
 )
 
@@ -17,7 +18,7 @@ type CreateCannedResponse struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *CreateCannedResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator() || user.IsModerator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 // Validate if current model is valid
@@ -56,7 +57,7 @@ type UpdateCannedResponse struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *UpdateCannedResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator() || user.IsModerator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 // Validate if current model is valid
@@ -94,7 +95,7 @@ type DeleteCannedResponse struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *DeleteCannedResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator() || user.IsModerator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 // Validate if current model is valid
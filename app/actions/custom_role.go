@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// CreateEditCustomRole is used to create a new custom role or edit existing
+type CreateEditCustomRole struct {
+	ID          int               `route:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+
+	CustomRole *entity.CustomRole
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateEditCustomRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateEditCustomRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.ID != 0 {
+		getRole := &query.GetCustomRoleByID{ID: action.ID}
+		if err := bus.Dispatch(ctx, getRole); err != nil {
+			return validate.Error(err)
+		}
+		action.CustomRole = getRole.Result
+	}
+
+	action.Name = strings.TrimSpace(action.Name)
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 50 {
+		result.AddFieldFailure("name", "Name must have less than 50 characters.")
+	} else {
+		listRoles := &query.ListCustomRoles{}
+		if err := bus.Dispatch(ctx, listRoles); err != nil {
+			return validate.Error(err)
+		}
+		for _, role := range listRoles.Result {
+			if strings.EqualFold(role.Name, action.Name) && role.ID != action.ID {
+				result.AddFieldFailure("name", "This role name is already in use.")
+			}
+		}
+	}
+
+	if len(action.Description) > 200 {
+		result.AddFieldFailure("description", "Description must have less than 200 characters.")
+	}
+
+	if len(action.Permissions) == 0 {
+		result.AddFieldFailure("permissions", "At least one permission is required.")
+	}
+	for _, permission := range action.Permissions {
+		if !permission.IsValid() {
+			result.AddFieldFailure("permissions", "Permission '"+string(permission)+"' doesn't exist.")
+		}
+	}
+
+	return result
+}
+
+// SetUserCustomRole is used to assign a custom role to a user, or remove it
+type SetUserCustomRole struct {
+	UserID       int `route:"userID"`
+	CustomRoleID int `json:"customRoleID"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SetUserCustomRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *SetUserCustomRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	getUser := &query.GetUserByID{UserID: action.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return validate.Error(err)
+	}
+
+	if action.CustomRoleID != 0 {
+		getRole := &query.GetCustomRoleByID{ID: action.CustomRoleID}
+		if err := bus.Dispatch(ctx, getRole); err != nil {
+			return validate.Error(err)
+		}
+	}
+
+	return validate.Success()
+}
@@ -0,0 +1,144 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+)
+
+func TestCreateEditCustomRole_InvalidName(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomRoles) error {
+		q.Result = []*entity.CustomRole{{ID: 1, Name: "Tag Gardener"}}
+		return nil
+	})
+
+	for _, name := range []string{
+		"",
+		"  ",
+		"tag gardener",
+		rand.String(51),
+	} {
+		action := &actions.CreateEditCustomRole{Name: name, Permissions: []enum.Permission{enum.PermissionTagManage}}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "name")
+	}
+}
+
+func TestCreateEditCustomRole_InvalidPermissions(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomRoles) error {
+		return nil
+	})
+
+	for _, permissions := range [][]enum.Permission{
+		nil,
+		{"post.fly"},
+		{enum.PermissionPostLock, "everything"},
+	} {
+		action := &actions.CreateEditCustomRole{Name: "Lockers", Permissions: permissions}
+		result := action.Validate(context.Background(), nil)
+		ExpectFailed(result, "permissions")
+	}
+}
+
+func TestCreateEditCustomRole_RenameKeepsOwnName(t *testing.T) {
+	RegisterT(t)
+
+	role := &entity.CustomRole{ID: 1, Name: "Tag Gardener", Permissions: []enum.Permission{enum.PermissionTagManage}}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomRoleByID) error {
+		q.Result = role
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListCustomRoles) error {
+		q.Result = []*entity.CustomRole{role}
+		return nil
+	})
+
+	action := &actions.CreateEditCustomRole{ID: 1, Name: "TAG GARDENER", Permissions: []enum.Permission{enum.PermissionTagManage, enum.PermissionTagAssign}}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.CustomRole).Equals(role)
+}
+
+func TestCreateEditCustomRole_OnlyAdministrators(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.CreateEditCustomRole{}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleAdministrator})).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+}
+
+func TestAssignUnassignTag_CustomRole(t *testing.T) {
+	RegisterT(t)
+
+	user := &entity.User{ID: 10, Role: enum.RoleVisitor}
+	action := &actions.AssignUnassignTag{}
+	Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionTagAssign}
+	})
+	Expect(action.IsAuthorized(context.Background(), user)).IsTrue()
+}
+
+func TestSetResponse_CustomRole(t *testing.T) {
+	RegisterT(t)
+
+	user := &entity.User{ID: 10, Role: enum.RoleVisitor}
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionPostRespond}
+	})
+
+	action := &actions.SetResponse{Number: 1, Status: enum.PostCompleted}
+	Expect(action.IsAuthorized(context.Background(), user)).IsTrue()
+	ExpectFailed(action.Validate(createTestContext(), user), "status")
+
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionPostRespond, enum.PermissionPostStatus}
+	})
+	ExpectSuccess(action.Validate(createTestContext(), user))
+
+	// the built-in moderator preset is limited the same way
+	moderator := &entity.User{ID: 11, Role: enum.RoleModerator}
+	ExpectFailed(action.Validate(createTestContext(), moderator), "status")
+}
+
+func TestDeleteComment_CustomRole(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	collaborator := &entity.User{ID: 2, Role: enum.RoleCollaborator}
+	comments := map[int]*entity.Comment{
+		1: {ID: 1, User: visitor, Content: "Comment #1"},
+		2: {ID: 2, User: collaborator, Content: "Comment #2"},
+	}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+		q.Result = comments[q.CommentID]
+		return nil
+	})
+
+	user := &entity.User{ID: 10, Role: enum.RoleVisitor}
+	action := &actions.DeleteComment{CommentID: 1}
+	Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionCommentDelete}
+	})
+	Expect(action.IsAuthorized(context.Background(), user)).IsTrue()
+
+	// comments of staff can only be deleted by collaborators and administrators
+	action = &actions.DeleteComment{CommentID: 2}
+	Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{ID: 3, Role: enum.RoleAdministrator})).IsTrue()
+}
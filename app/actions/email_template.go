@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/tpl"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// SaveEmailTemplate overrides a built-in email template in a locale
+type SaveEmailTemplate struct {
+	Name    string `route:"name"`
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SaveEmailTemplate) IsAuthorized(_ context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *SaveEmailTemplate) Validate(_ context.Context, _ *entity.User) *validate.Result {
+	result := validate.Success()
+
+	template, ok := enum.GetEmailTemplate(action.Name)
+	if !ok {
+		result.AddFieldFailure("name", "Template must be valid.")
+	}
+
+	if !i18n.IsValidLocale(action.Locale) {
+		result.AddFieldFailure("locale", "Locale must be valid.")
+	}
+
+	action.Subject = strings.TrimSpace(action.Subject)
+	if action.Subject == "" {
+		result.AddFieldFailure("subject", "Subject is required.")
+	} else if len(action.Subject) > 200 {
+		result.AddFieldFailure("subject", "Subject must have less than 200 characters.")
+	} else if strings.ContainsAny(action.Subject, "\r\n") {
+		result.AddFieldFailure("subject", "Subject must be a single line.")
+	}
+
+	if strings.TrimSpace(action.Body) == "" {
+		result.AddFieldFailure("body", "Body is required.")
+	} else if len(action.Body) > 100_000 {
+		result.AddFieldFailure("body", "Body must have less than 100 000 characters.")
+	}
+
+	if !ok || !result.Ok {
+		return result
+	}
+
+	tmpl, err := tpl.GetOverrideTemplate("/views/email/base_email.html", action.Subject, action.Body)
+	if err != nil {
+		result.AddFieldFailure("body", fmt.Sprintf("Template must be valid: %s", err.Error()))
+		return result
+	}
+
+	fields := make(map[string]bool)
+	for _, field := range tpl.Fields(tmpl, "subject", "body") {
+		fields[field] = true
+	}
+	for _, placeholder := range template.Placeholders {
+		if !fields[placeholder] {
+			result.AddFieldFailure("body", fmt.Sprintf("Template must use the {{ .%s }} placeholder.", placeholder))
+		}
+	}
+
+	return result
+}
+
+// PreviewEmailTemplate renders an email template override with sample props
+type PreviewEmailTemplate struct {
+	Name    string `route:"name"`
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *PreviewEmailTemplate) IsAuthorized(_ context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *PreviewEmailTemplate) Validate(_ context.Context, _ *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if _, ok := enum.GetEmailTemplate(action.Name); !ok {
+		result.AddFieldFailure("name", "Template must be valid.")
+	}
+
+	if !i18n.IsValidLocale(action.Locale) {
+		result.AddFieldFailure("locale", "Locale must be valid.")
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestSaveEmailTemplate_Valid(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.SaveEmailTemplate{
+		Name:    "signin_email",
+		Locale:  "en",
+		Subject: "  Sign in to {{ .siteName }}  ",
+		Body:    "<tr><td>Click {{ .link | html }} to sign in</td></tr>",
+	}
+	result := action.Validate(context.Background(), nil)
+	ExpectSuccess(result)
+	Expect(action.Subject).Equals("Sign in to {{ .siteName }}")
+}
+
+func TestSaveEmailTemplate_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		action *actions.SaveEmailTemplate
+		field  string
+	}{
+		{&actions.SaveEmailTemplate{Name: "unknown", Locale: "en", Subject: "Hi", Body: "{{ .link }}"}, "name"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "xx", Subject: "Hi", Body: "{{ .link }}"}, "locale"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "en", Subject: "", Body: "{{ .link }}"}, "subject"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "en", Subject: "Hi\nthere", Body: "{{ .link }}"}, "subject"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "en", Subject: "Hi", Body: ""}, "body"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "en", Subject: "Hi", Body: "{{ .link }"}, "body"},
+		{&actions.SaveEmailTemplate{Name: "signin_email", Locale: "en", Subject: "Hi", Body: "No link here"}, "body"},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), nil)
+		ExpectFailed(result, testCase.field)
+	}
+}
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
@@ -11,6 +12,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
@@ -21,6 +23,7 @@ type CreateEditOAuthConfig struct {
 	ID                int
 	Logo              *dto.ImageUpload `json:"logo"`
 	Provider          string           `json:"provider"`
+	Type              int              `json:"type"`
 	Status            int              `json:"status"`
 	DisplayName       string           `json:"displayName"`
 	ClientID          string           `json:"clientID"`
@@ -29,6 +32,7 @@ type CreateEditOAuthConfig struct {
 	TokenURL          string           `json:"tokenURL"`
 	Scope             string           `json:"scope"`
 	ProfileURL        string           `json:"profileURL"`
+	IssuerURL         string           `json:"issuerURL"`
 	IsTrusted         bool             `json:"isTrusted"`
 	JSONUserIDPath    string           `json:"jsonUserIDPath"`
 	JSONUserNamePath  string           `json:"jsonUserNamePath"`
@@ -113,6 +117,56 @@ func (action *CreateEditOAuthConfig) Validate(ctx context.Context, user *entity.
 		result.AddFieldFailure("clientSecret", "Client Secret must have less than 500 characters.")
 	}
 
+	if action.Type == 0 {
+		action.Type = enum.OAuthConfigTypeOAuth2
+	}
+
+	switch action.Type {
+	case enum.OAuthConfigTypeOIDC:
+		validateOIDCConfig(ctx, action, result)
+	case enum.OAuthConfigTypeOAuth2:
+		validateOAuth2Config(ctx, action, result)
+	default:
+		result.AddFieldFailure("type", "Invalid type.")
+	}
+
+	return result
+}
+
+// validateOIDCConfig checks that the issuer can be discovered, endpoints and claims don't need to be configured
+func validateOIDCConfig(ctx context.Context, action *CreateEditOAuthConfig, result *validate.Result) {
+	action.AuthorizeURL = ""
+	action.TokenURL = ""
+	action.ProfileURL = ""
+	action.JSONUserIDPath = ""
+	action.JSONUserNamePath = ""
+	action.JSONUserEmailPath = ""
+
+	action.Scope = strings.TrimSpace(action.Scope)
+	if action.Scope == "" {
+		action.Scope = "openid profile email"
+	} else if !slices.Contains(strings.Fields(action.Scope), "openid") {
+		action.Scope = "openid " + action.Scope
+	}
+	if len(action.Scope) > 100 {
+		result.AddFieldFailure("scope", "Scope must have less than 100 characters.")
+	}
+
+	action.IssuerURL = strings.TrimSpace(action.IssuerURL)
+	if action.IssuerURL == "" {
+		result.AddFieldFailure("issuerURL", "Issuer URL is required.")
+	} else if len(action.IssuerURL) > 300 {
+		result.AddFieldFailure("issuerURL", "Issuer URL must have less than 300 characters.")
+	} else if messages := validate.URL(ctx, action.IssuerURL); len(messages) > 0 {
+		result.AddFieldFailure("issuerURL", messages...)
+	} else if err := bus.Dispatch(ctx, &query.GetOIDCConfiguration{IssuerURL: action.IssuerURL}); err != nil {
+		result.AddFieldFailure("issuerURL", "Couldn't read the OpenID configuration of this issuer: "+errors.Cause(err).Error())
+	}
+}
+
+func validateOAuth2Config(ctx context.Context, action *CreateEditOAuthConfig, result *validate.Result) {
+	action.IssuerURL = ""
+
 	if action.Scope == "" {
 		result.AddFieldFailure("scope", "Scope is required.")
 	} else if len(action.Scope) > 100 {
@@ -150,6 +204,4 @@ func (action *CreateEditOAuthConfig) Validate(ctx context.Context, user *entity.
 	if len(action.JSONUserEmailPath) > 100 {
 		result.AddFieldFailure("jsonUserEmailPath", "JSON User Email Path must have less than 100 characters.")
 	}
-
-	return result
 }
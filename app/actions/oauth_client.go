@@ -0,0 +1,184 @@
+package actions
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// CreateOAuthClient is the input model used to register a third-party app
+type CreateOAuthClient struct {
+	Name           string               `json:"name"`
+	RedirectURIs   []string             `json:"redirectUris"`
+	Scopes         []enum.APITokenScope `json:"scopes"`
+	IsConfidential bool                 `json:"isConfidential"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *CreateOAuthClient) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *CreateOAuthClient) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Name = strings.TrimSpace(action.Name)
+	if action.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(action.Name) > 100 {
+		result.AddFieldFailure("name", "Name must have less than 100 characters.")
+	}
+
+	uris := make([]string, 0, len(action.RedirectURIs))
+	for _, uri := range action.RedirectURIs {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		if !isValidRedirectURI(uri) {
+			result.AddFieldFailure("redirectUris", "'"+uri+"' is not a valid redirect URI. Use https, http on localhost, or a custom scheme for native apps.")
+			continue
+		}
+		uris = append(uris, uri)
+	}
+	action.RedirectURIs = uris
+	if len(uris) == 0 && len(result.Errors) == 0 {
+		result.AddFieldFailure("redirectUris", "At least one redirect URI is required.")
+	}
+
+	seen := make(map[enum.APITokenScope]bool, len(action.Scopes))
+	scopes := make([]enum.APITokenScope, 0, len(action.Scopes))
+	for _, scope := range action.Scopes {
+		if scope.String() == "" {
+			result.AddFieldFailure("scopes", "Unknown scope.")
+			continue
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	action.Scopes = scopes
+	if len(action.Scopes) == 0 {
+		result.AddFieldFailure("scopes", "At least one scope is required.")
+	}
+
+	return result
+}
+
+// isValidRedirectURI only allows https, plain http on the loopback interface and custom schemes of native apps
+func isValidRedirectURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" || u.Fragment != "" {
+		return false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		return u.Host != ""
+	case "http":
+		host := u.Hostname()
+		if host == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	case "javascript", "data", "file", "vbscript":
+		return false
+	default:
+		return true
+	}
+}
+
+// AuthorizeOAuthClient is what a third-party app asks for when it sends a user to the consent page
+type AuthorizeOAuthClient struct {
+	ResponseType        string `json:"responseType"`
+	ClientID            string `json:"clientId"`
+	RedirectURI         string `json:"redirectUri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+	Approve             bool   `json:"approve"`
+
+	Client *entity.OAuthClient  `json:"-"`
+	Scopes []enum.APITokenScope `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *AuthorizeOAuthClient) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+// Failures of the client_id and redirect_uri fields can't be sent back to the client, all the others are redirected to it
+func (action *AuthorizeOAuthClient) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getClient := &query.GetOAuthClientByClientID{ClientID: action.ClientID}
+	if err := bus.Dispatch(ctx, getClient); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			result.AddFieldFailure("client_id", "Unknown client.")
+			return result
+		}
+		return validate.Error(err)
+	}
+	action.Client = getClient.Result
+
+	if !action.Client.HasRedirectURI(action.RedirectURI) {
+		result.AddFieldFailure("redirect_uri", "The redirect URI is not registered for this client.")
+		return result
+	}
+
+	if action.ResponseType != "code" {
+		result.AddFieldFailure("response_type", "Only the 'code' response type is supported.")
+	}
+
+	// PKCE is required for every client, so that a stolen code is useless on its own
+	if action.CodeChallengeMethod != "S256" {
+		result.AddFieldFailure("code_challenge_method", "Only the 'S256' code challenge method is supported.")
+	}
+	if len(action.CodeChallenge) < 43 || len(action.CodeChallenge) > 128 {
+		result.AddFieldFailure("code_challenge", "A code challenge of 43 to 128 characters is required.")
+	}
+
+	action.Scopes = make([]enum.APITokenScope, 0)
+	if strings.TrimSpace(action.Scope) == "" {
+		for _, scope := range action.Client.Scopes {
+			if user == nil || canUseAPITokenScope(user, scope) {
+				action.Scopes = append(action.Scopes, scope)
+			}
+		}
+	} else {
+		for _, name := range strings.Fields(action.Scope) {
+			scope, ok := enum.ParseAPITokenScope(name)
+			if !ok || !action.Client.HasScope(scope) {
+				result.AddFieldFailure("scope", "The '"+name+"' scope is not available to this client.")
+				continue
+			}
+			if user != nil && !canUseAPITokenScope(user, scope) {
+				result.AddFieldFailure("scope", "The '"+name+"' scope is not available to your account.")
+				continue
+			}
+			if !slices.Contains(action.Scopes, scope) {
+				action.Scopes = append(action.Scopes, scope)
+			}
+		}
+	}
+	if len(action.Scopes) == 0 && len(result.Errors) == 0 {
+		result.AddFieldFailure("scope", "None of the scopes are available to your account.")
+	}
+
+	return result
+}
@@ -0,0 +1,51 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestCreateOAuthClient_Valid(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.CreateOAuthClient{
+		Name:         " Roadmap Bot ",
+		RedirectURIs: []string{"https://bot.example.com/callback", "", "http://127.0.0.1:8080/cb", "com.example.app:/oauth2"},
+		Scopes:       []enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeReadPosts},
+	}
+
+	result := action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})
+	ExpectSuccess(result)
+	Expect(action.Name).Equals("Roadmap Bot")
+	Expect(action.RedirectURIs).Equals([]string{"https://bot.example.com/callback", "http://127.0.0.1:8080/cb", "com.example.app:/oauth2"})
+	Expect(action.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts})
+}
+
+func TestCreateOAuthClient_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	scopes := []enum.APITokenScope{enum.APITokenScopeReadPosts}
+	testCases := []struct {
+		action *actions.CreateOAuthClient
+		field  string
+	}{
+		{&actions.CreateOAuthClient{RedirectURIs: []string{"https://bot.example.com/callback"}, Scopes: scopes}, "name"},
+		{&actions.CreateOAuthClient{Name: "Bot", Scopes: scopes}, "redirectUris"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"http://bot.example.com/callback"}, Scopes: scopes}, "redirectUris"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"https://bot.example.com/callback#token"}, Scopes: scopes}, "redirectUris"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"javascript:alert(1)"}, Scopes: scopes}, "redirectUris"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"/callback"}, Scopes: scopes}, "redirectUris"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"https://bot.example.com/callback"}}, "scopes"},
+		{&actions.CreateOAuthClient{Name: "Bot", RedirectURIs: []string{"https://bot.example.com/callback"}, Scopes: []enum.APITokenScope{0}}, "scopes"},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), &entity.User{ID: 1, Role: enum.RoleAdministrator})
+		ExpectFailed(result, testCase.field)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
 )
 
@@ -217,3 +218,51 @@ func TestCreateEditOAuthConfig_EditNonExisting(t *testing.T) {
 	Expect(result.Err).Equals(app.ErrNotFound)
 	Expect(result.Ok).IsFalse()
 }
+
+func TestCreateEditOAuthConfig_AddNew_OIDC(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
+		q.Result = []*dto.OAuthProviderOption{}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOIDCConfiguration) error {
+		if q.IssuerURL == "https://login.provider.com" {
+			q.Result = &dto.OIDCConfiguration{Issuer: q.IssuerURL}
+			return nil
+		}
+		return errors.New("Failed to fetch OpenID configuration of '%s'. Status Code: 404", q.IssuerURL)
+	})
+
+	action := actions.NewCreateEditOAuthConfig()
+	action.Type = enum.OAuthConfigTypeOIDC
+	action.DisplayName = "My Provider"
+	action.Status = enum.OAuthConfigEnabled
+	action.ClientID = "823187ahjjfdha8fds7yfdashfjkdsa"
+	action.ClientSecret = "jijads78d76cn347768x3t4668q275@ˆ&Tnycasdgsacuyhij"
+	action.IssuerURL = " https://login.provider.com "
+	action.AuthorizeURL = "http://provider/oauth/authorize"
+	action.Scope = "email"
+	action.JSONUserIDPath = "user.id"
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{
+		IsEmailAuthAllowed: true,
+	})
+
+	result := action.Validate(ctx, nil)
+	ExpectSuccess(result)
+	Expect(action.IssuerURL).Equals("https://login.provider.com")
+	Expect(action.Scope).Equals("openid email")
+	Expect(action.AuthorizeURL).Equals("")
+	Expect(action.JSONUserIDPath).Equals("")
+
+	action = actions.NewCreateEditOAuthConfig()
+	action.Type = enum.OAuthConfigTypeOIDC
+	action.DisplayName = "My Provider"
+	action.Status = enum.OAuthConfigEnabled
+	action.ClientID = "823187ahjjfdha8fds7yfdashfjkdsa"
+	action.ClientSecret = "jijads78d76cn347768x3t4668q275@ˆ&Tnycasdgsacuyhij"
+	action.IssuerURL = "https://unknown.provider.com"
+	result = action.Validate(ctx, nil)
+	ExpectFailed(result, "issuerURL")
+}
@@ -51,7 +51,7 @@ func (input *CreateNewPost) OnPreExecute(ctx context.Context) error {
 func (action *CreateNewPost) IsAuthorized(ctx context.Context, user *entity.User) bool {
 	if user == nil {
 		return false
-	} else if env.Config.PostCreationWithTagsEnabled && (!user.IsCollaborator() || !user.IsModerator()) {
+	} else if env.Config.PostCreationWithTagsEnabled && !user.HasPermission(enum.PermissionTagPrivate) {
 		for _, tag := range action.Tags {
 			if !tag.IsPublic {
 				return false
@@ -81,7 +81,7 @@ func (action *CreateNewPost) Validate(ctx context.Context, user *entity.User) *v
 	}
 
 	if !user.IsCollaborator() && !user.IsModerator() && !user.IsAdministrator() {
-		if limit, ok := generalSettings.PostLimits[user.Role.String()]; ok && limit.Count > 0 {
+		if limit, ok := generalSettings.PostLimitFor(user); ok {
 			q := &query.GetUserPostCount{
 				UserID: user.ID,
 				Since:  time.Now().Add(-time.Duration(limit.Hours) * time.Hour),
@@ -163,8 +163,7 @@ func (input *UpdatePost) IsAuthorized(ctx context.Context, user *entity.User) bo
 		return false
 	}
 
-	// If user is collaborator or admin, they can edit any post
-	if user.IsCollaborator() || user.IsAdministrator() {
+	if canModerateContentOf(ctx, user, input.Post.User, enum.PermissionPostEdit) {
 		return true
 	}
 
@@ -172,11 +171,6 @@ func (input *UpdatePost) IsAuthorized(ctx context.Context, user *entity.User) bo
 		return false
 	}
 
-	// If user is moderator, they can only edit posts from regular users
-	if user.IsModerator() {
-		return input.Post.User.Role == enum.RoleVisitor || input.Post.User.Role == enum.RoleHelper
-	}
-
 	// Regular users can only edit their own posts within 1 hour
 	timeAgo := time.Now().UTC().Sub(input.Post.CreatedAt)
 	return input.Post.User.ID == user.ID && timeAgo <= 1*time.Hour
@@ -264,9 +258,9 @@ func (action *ToggleCommentReaction) IsAuthorized(ctx context.Context, user *ent
 		return false
 	}
 
-	// If post is locked, only collaborators and administrators can add reactions
+	// If post is locked, only those who can unlock it can add reactions
 	if action.Post.IsLocked() {
-		return user.IsCollaborator() || user.IsAdministrator()
+		return user.HasPermission(enum.PermissionPostLock) && apiTokenAllows(ctx, enum.APITokenScopeModerate)
 	}
 
 	return true
@@ -324,7 +318,7 @@ func (action *AddNewComment) Validate(ctx context.Context, user *entity.User) *v
 	}
 
 	if !user.IsCollaborator() && !user.IsModerator() && !user.IsAdministrator() {
-		if limit, ok := generalSettings.CommentLimits[user.Role.String()]; ok && limit.Count > 0 {
+		if limit, ok := generalSettings.CommentLimitFor(user); ok {
 			q := &query.GetUserCommentCount{
 				UserID: user.ID,
 				Since:  time.Now().Add(-time.Duration(limit.Hours) * time.Hour),
@@ -370,7 +364,7 @@ type SetResponse struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *SetResponse) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator() || user.IsModerator())
+	return user != nil && user.HasPermission(enum.PermissionPostRespond)
 }
 
 // Validate if current model is valid
@@ -381,7 +375,8 @@ func (action *SetResponse) Validate(ctx context.Context, user *entity.User) *val
 		result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
 	}
 
-	if user.IsModerator() && !user.IsAdministrator() && !user.IsCollaborator() && action.Status != enum.PostDuplicate {
+	// post.respond alone only allows marking posts as duplicates
+	if (user == nil || !user.HasPermission(enum.PermissionPostStatus)) && action.Status != enum.PostDuplicate {
 		result.AddFieldFailure("status", i18n.T(ctx, "validation.custom.moderatorduplicateonly"))
 	}
 
@@ -433,21 +428,7 @@ func (action *DeletePost) IsAuthorized(ctx context.Context, user *entity.User) b
 		return false
 	}
 
-	// If user is collaborator or admin, they can delete any post
-	if user.IsCollaborator() || user.IsAdministrator() {
-		return true
-	}
-
-	if action.Post.User == nil {
-		return false
-	}
-
-	// If user is moderator, they can only delete posts from regular users
-	if user.IsModerator() {
-		return action.Post.User.Role == enum.RoleVisitor || action.Post.User.Role == enum.RoleHelper
-	}
-
-	return false
+	return canModerateContentOf(ctx, user, action.Post.User, enum.PermissionPostDelete)
 }
 
 // Validate if current model is valid
@@ -490,16 +471,10 @@ func (action *EditComment) IsAuthorized(ctx context.Context, user *entity.User)
 	action.Post = postByNumber.Result
 	action.Comment = commentByID.Result
 
-	// If user is collaborator or admin, they can edit any comment
-	if user.IsCollaborator() || user.IsAdministrator() {
+	if canModerateContentOf(ctx, user, action.Comment.User, enum.PermissionCommentEdit) {
 		return true
 	}
 
-	// If user is moderator, they can only edit comments from regular users
-	if user.IsModerator() {
-		return action.Comment.User.Role == enum.RoleVisitor || action.Comment.User.Role == enum.RoleHelper
-	}
-
 	// Regular users can only edit their own comments
 	return user.ID == action.Comment.User.ID
 }
@@ -556,8 +531,7 @@ func (action *DeleteComment) IsAuthorized(ctx context.Context, user *entity.User
 		return false
 	}
 
-	// If user is collaborator or admin, they can delete any comment
-	if user.IsCollaborator() || user.IsAdministrator() {
+	if canModerateContentOf(ctx, user, commentByID.Result.User, enum.PermissionCommentDelete) {
 		return true
 	}
 
@@ -565,11 +539,6 @@ func (action *DeleteComment) IsAuthorized(ctx context.Context, user *entity.User
 		return false
 	}
 
-	// If user is moderator, they can only delete comments from regular users
-	if user.IsModerator() {
-		return commentByID.Result.User.Role == enum.RoleVisitor || commentByID.Result.User.Role == enum.RoleHelper
-	}
-
 	// Regular users can only delete their own comments
 	return user.ID == commentByID.Result.User.ID
 }
@@ -597,7 +566,7 @@ func (input *LockPost) OnPreExecute(ctx context.Context) error {
 }
 
 func (action *LockPost) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionPostLock)
 }
 
 func (action *LockPost) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -627,7 +596,7 @@ func (input *UnlockPost) OnPreExecute(ctx context.Context) error {
 }
 
 func (action *UnlockPost) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator() || user.IsModerator())
+	return user != nil && user.HasPermission(enum.PermissionPostLock)
 }
 
 func (action *UnlockPost) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -639,3 +608,17 @@ func (action *UnlockPost) Validate(ctx context.Context, user *entity.User) *vali
 
 	return result
 }
+
+// canModerateContentOf returns true if user can use given permission on content created by author
+// The permission says what staff can do and the role says whose content: collaborators and administrators
+// can act on content of anyone, other roles only on content of visitors and helpers
+// Requests made with a personal API token also need the moderate scope, whatever the route requires
+func canModerateContentOf(ctx context.Context, user, author *entity.User, permission enum.Permission) bool {
+	if user == nil || !user.HasPermission(permission) || !apiTokenAllows(ctx, enum.APITokenScopeModerate) {
+		return false
+	}
+	if user.IsCollaborator() {
+		return true
+	}
+	return author != nil && (author.Role == enum.RoleVisitor || author.Role == enum.RoleHelper)
+}
@@ -116,6 +116,11 @@ func (a *CreateReport) Validate(ctx context.Context, user *entity.User) *validat
 		maxReportsPerDay = tenant.GeneralSettings.ReportLimitsPerDay
 	}
 
+	accuracy := &query.GetUserReportAccuracy{UserID: user.ID}
+	if err := bus.Dispatch(ctx, accuracy); err == nil {
+		maxReportsPerDay = accuracy.Result.DailyReportLimit(maxReportsPerDay)
+	}
+
 	countToday := &query.CountUserReportsToday{UserID: user.ID}
 	if err := bus.Dispatch(ctx, countToday); err == nil && countToday.Result >= maxReportsPerDay {
 		result.AddFieldFailure("reportedId", i18n.T(ctx, "validation.custom.reportlimitreached"))
@@ -130,7 +135,7 @@ type AssignReport struct {
 }
 
 func (a *AssignReport) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.Role == enum.RoleModerator || user.Role == enum.RoleCollaborator || user.Role == enum.RoleAdministrator)
+	return user != nil && user.HasPermission(enum.PermissionReportView)
 }
 
 func (a *AssignReport) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -150,7 +155,7 @@ type ResolveReport struct {
 }
 
 func (a *ResolveReport) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.Role == enum.RoleModerator || user.Role == enum.RoleCollaborator || user.Role == enum.RoleAdministrator)
+	return user != nil && user.HasPermission(enum.PermissionReportResolve)
 }
 
 func (a *ResolveReport) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -172,13 +177,42 @@ func (a *ResolveReport) Validate(ctx context.Context, user *entity.User) *valida
 	return result
 }
 
+type ResolveReportCase struct {
+	CaseID         int    `json:"caseId"`
+	Status         string `json:"status"`
+	ResolutionNote string `json:"resolutionNote"`
+}
+
+func (a *ResolveReportCase) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.HasPermission(enum.PermissionReportResolve)
+}
+
+func (a *ResolveReportCase) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.CaseID <= 0 {
+		result.AddFieldFailure("caseId", propertyIsInvalid(ctx, "caseId"))
+		return result
+	}
+
+	if a.Status != "resolved" && a.Status != "dismissed" {
+		result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
+	}
+
+	if len(a.ResolutionNote) > 2000 {
+		result.AddFieldFailure("resolutionNote", propertyMaxStringLen(ctx, "resolutionNote", 2000))
+	}
+
+	return result
+}
+
 type CreateReportReason struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 }
 
 func (a *CreateReportReason) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 func (a *CreateReportReason) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -205,7 +239,7 @@ type UpdateReportReason struct {
 }
 
 func (a *UpdateReportReason) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 func (a *UpdateReportReason) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -233,7 +267,7 @@ type DeleteReportReason struct {
 }
 
 func (a *DeleteReportReason) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 func (a *DeleteReportReason) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -251,7 +285,7 @@ type ReorderReportReasons struct {
 }
 
 func (a *ReorderReportReasons) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionResponseManage)
 }
 
 func (a *ReorderReportReasons) Validate(ctx context.Context, user *entity.User) *validate.Result {
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
@@ -41,6 +42,29 @@ func (action *UpdateUserSettings) Validate(ctx context.Context, user *entity.Use
 				continue
 			}
 
+			if k == enum.DigestFrequencySettingsKey {
+				if !enum.IsValidDigestFrequency(v) {
+					result.AddFieldFailure("settings", i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": k}, i18n.Params{"value": v}))
+				}
+				continue
+			}
+
+			if k == enum.TimezoneSettingsKey {
+				if v != "" {
+					if _, err := time.LoadLocation(v); err != nil {
+						result.AddFieldFailure("settings", i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": k}, i18n.Params{"value": v}))
+					}
+				}
+				continue
+			}
+
+			if k == enum.QuietHoursStartSettingsKey || k == enum.QuietHoursEndSettingsKey {
+				if _, ok := entity.ParseClock(v); v != "" && !ok {
+					result.AddFieldFailure("settings", i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": k}, i18n.Params{"value": v}))
+				}
+				continue
+			}
+
 			ok := false
 			for _, e := range enum.AllNotificationEvents {
 				if e.UserSettingsKeyName == k {
@@ -60,6 +84,62 @@ func (action *UpdateUserSettings) Validate(ctx context.Context, user *entity.Use
 	return result
 }
 
+// NotificationPreference is the channels a user receives a notification event on
+type NotificationPreference struct {
+	Event    string                     `json:"event"`
+	Channels []enum.NotificationChannel `json:"channels"`
+}
+
+// UpdateNotificationPreferences happens when users change the channels they receive notification events on
+type UpdateNotificationPreferences struct {
+	Preferences []*NotificationPreference `json:"preferences"`
+
+	// Events are the notification events of Preferences, in the same order
+	Events []enum.NotificationEvent `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateNotificationPreferences) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UpdateNotificationPreferences) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	action.Events = make([]enum.NotificationEvent, 0, len(action.Preferences))
+	for _, preference := range action.Preferences {
+		var event *enum.NotificationEvent
+		for i, e := range enum.AllNotificationEvents {
+			if e.UserSettingsKeyName == preference.Event {
+				event = &enum.AllNotificationEvents[i]
+				break
+			}
+		}
+		if event == nil || !event.IsAvailableFor(user.Role) {
+			result.AddFieldFailure("preferences", i18n.T(ctx, "validation.custom.unknownsettings", i18n.Params{"name": preference.Event}))
+			continue
+		}
+
+		var channels enum.NotificationChannel
+		for _, channel := range preference.Channels {
+			if event.Channels&channel == 0 {
+				result.AddFieldFailure("preferences", i18n.T(ctx, "validation.invalidvalue", i18n.Params{"name": preference.Event}, i18n.Params{"value": channel.String()}))
+			}
+			channels |= channel
+		}
+
+		// email and digest are alternatives, an event is either sent right away or grouped into the digest
+		if channels&enum.NotificationChannelEmail > 0 && channels&enum.NotificationChannelDigest > 0 {
+			result.AddFieldFailure("preferences", i18n.T(ctx, "validation.custom.emailordigest", i18n.Params{"name": preference.Event}))
+		}
+
+		action.Events = append(action.Events, *event)
+	}
+
+	return result
+}
+
 // UpdateUserName happens when users updates their name
 type UpdateUserName struct {
 	Name string `json:"name"`
@@ -59,7 +59,22 @@ func TestInvalidSettings(t *testing.T) {
 			"bad_name": "3",
 		},
 		{
-			enum.NotificationEventNewComment.UserSettingsKeyName: "8",
+			enum.NotificationEventNewComment.UserSettingsKeyName: "16",
+		},
+		{
+			enum.NotificationEventNewComment.UserSettingsKeyName: "abc",
+		},
+		{
+			enum.DigestFrequencySettingsKey: "monthly",
+		},
+		{
+			enum.TimezoneSettingsKey: "Mars/Olympus",
+		},
+		{
+			enum.QuietHoursStartSettingsKey: "10pm",
+		},
+		{
+			enum.QuietHoursEndSettingsKey: "24:30",
 		},
 	} {
 		action := actions.NewUpdateUserSettings()
@@ -82,6 +97,20 @@ func TestValidSettings(t *testing.T) {
 		{
 			enum.NotificationEventNewComment.UserSettingsKeyName: enum.NotificationEventNewComment.DefaultSettingValue,
 		},
+		{
+			enum.NotificationEventNewComment.UserSettingsKeyName: "9",
+			enum.DigestFrequencySettingsKey:                      enum.DigestFrequencyWeekly,
+		},
+		{
+			enum.TimezoneSettingsKey:        "America/Sao_Paulo",
+			enum.QuietHoursStartSettingsKey: "22:00",
+			enum.QuietHoursEndSettingsKey:   "07:30",
+		},
+		{
+			enum.TimezoneSettingsKey:        "",
+			enum.QuietHoursStartSettingsKey: "",
+			enum.QuietHoursEndSettingsKey:   "",
+		},
 	} {
 		action := actions.NewUpdateUserSettings()
 		action.Settings = settings
@@ -93,3 +122,35 @@ func TestValidSettings(t *testing.T) {
 		ExpectSuccess(result)
 	}
 }
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateNotificationPreferences{
+		Preferences: []*actions.NotificationPreference{
+			{Event: enum.NotificationEventNewComment.UserSettingsKeyName, Channels: []enum.NotificationChannel{enum.NotificationChannelWeb, enum.NotificationChannelDigest}},
+			{Event: enum.NotificationEventMention.UserSettingsKeyName, Channels: []enum.NotificationChannel{}},
+		},
+	}
+	ExpectSuccess(action.Validate(context.Background(), &entity.User{Role: enum.RoleAdministrator}))
+	Expect(action.Events).HasLen(2)
+	Expect(action.Events[0].UserSettingsKeyName).Equals(enum.NotificationEventNewComment.UserSettingsKeyName)
+	Expect(action.Events[1].UserSettingsKeyName).Equals(enum.NotificationEventMention.UserSettingsKeyName)
+}
+
+func TestUpdateNotificationPreferences_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	for _, preference := range []*actions.NotificationPreference{
+		{Event: "bad_name", Channels: []enum.NotificationChannel{enum.NotificationChannelWeb}},
+		{Event: enum.NotificationEventNewPost.UserSettingsKeyName, Channels: []enum.NotificationChannel{enum.NotificationChannelWeb}},
+		{Event: enum.NotificationEventMention.UserSettingsKeyName, Channels: []enum.NotificationChannel{enum.NotificationChannelDigest}},
+		{Event: enum.NotificationEventMention.UserSettingsKeyName, Channels: []enum.NotificationChannel{0}},
+		{Event: enum.NotificationEventNewComment.UserSettingsKeyName, Channels: []enum.NotificationChannel{enum.NotificationChannelEmail, enum.NotificationChannelDigest}},
+	} {
+		action := &actions.UpdateNotificationPreferences{
+			Preferences: []*actions.NotificationPreference{preference},
+		}
+		ExpectFailed(action.Validate(context.Background(), &entity.User{Role: enum.RoleVisitor}), "preferences")
+	}
+}
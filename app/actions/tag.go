@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
@@ -31,7 +32,7 @@ type CreateEditTag struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *CreateEditTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionTagManage)
 }
 
 // Validate if current model is valid
@@ -81,7 +82,7 @@ type DeleteTag struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *DeleteTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsAdministrator() || user.IsCollaborator())
+	return user != nil && user.HasPermission(enum.PermissionTagManage)
 }
 
 // Validate if current model is valid
@@ -107,7 +108,7 @@ type AssignUnassignTag struct {
 
 // IsAuthorized returns true if current user is authorized to perform this action
 func (action *AssignUnassignTag) IsAuthorized(ctx context.Context, user *entity.User) bool {
-	return user != nil && (user.IsCollaborator() || user.IsModerator() || user.IsHelper())
+	return user != nil && user.HasPermission(enum.PermissionTagAssign)
 }
 
 // Validate if current model is valid
@@ -121,14 +122,14 @@ func (action *AssignUnassignTag) Validate(ctx context.Context, user *entity.User
 	action.Post = getPost.Result
 	action.Tag = getSlug.Result
 
-	if user.IsHelper() {
+	// Without tag.private, only public tags of recent posts can be changed
+	if !user.HasPermission(enum.PermissionTagPrivate) {
 		// Not allowed to modify private tags
 		if !action.Tag.IsPublic {
 			return validate.Unauthorized()
 		}
 
-		// Helper users cannot modify tags on posts where tags
-		// have been applied for more than 7 days or since the post was created
+		// Not allowed to modify tags on posts created more than 7 days ago
 		if time.Since(action.Post.CreatedAt) > 7*24*time.Hour {
 			return validate.Unauthorized()
 		}
@@ -159,3 +160,44 @@ func (action *AssignUnassignTag) Validate(ctx context.Context, user *entity.User
 
 	return validate.Success()
 }
+
+// SetTagPreference is used to follow, mute or reset a tag for the current user
+type SetTagPreference struct {
+	Slug       string `route:"slug"`
+	Preference string `json:"preference"`
+
+	Tag   *entity.Tag
+	Value enum.TagPreference
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *SetTagPreference) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *SetTagPreference) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	switch action.Preference {
+	case "":
+		action.Value = 0
+	case enum.TagPreferenceFollow.String():
+		action.Value = enum.TagPreferenceFollow
+	case enum.TagPreferenceMute.String():
+		action.Value = enum.TagPreferenceMute
+	default:
+		return validate.Failed("Invalid tag preference.")
+	}
+
+	getSlug := &query.GetTagBySlug{Slug: action.Slug}
+	if err := bus.Dispatch(ctx, getSlug); err != nil {
+		return validate.Error(err)
+	}
+
+	// private tags are only visible to those who can assign tags
+	if !getSlug.Result.IsPublic && !user.HasPermission(enum.PermissionTagAssign) {
+		return validate.Unauthorized()
+	}
+
+	action.Tag = getSlug.Result
+	return validate.Success()
+}
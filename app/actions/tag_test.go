@@ -7,6 +7,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 
@@ -78,3 +79,33 @@ func TestCreateEditTag_ValidInput(t *testing.T) {
 	ExpectSuccess(result)
 	Expect(action.Tag).Equals(tag)
 }
+
+func TestSetTagPreference(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetTagBySlug) error {
+		q.Result = &entity.Tag{Slug: q.Slug, Name: "Bug", IsPublic: q.Slug != "private"}
+		return nil
+	})
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+
+	action := &actions.SetTagPreference{Slug: "bug", Preference: "follow"}
+	ExpectSuccess(action.Validate(context.Background(), visitor))
+	Expect(action.Value).Equals(enum.TagPreferenceFollow)
+	Expect(action.Tag.Slug).Equals("bug")
+
+	action = &actions.SetTagPreference{Slug: "bug", Preference: "mute"}
+	ExpectSuccess(action.Validate(context.Background(), visitor))
+	Expect(action.Value).Equals(enum.TagPreferenceMute)
+
+	action = &actions.SetTagPreference{Slug: "bug", Preference: ""}
+	ExpectSuccess(action.Validate(context.Background(), visitor))
+	Expect(action.Value).Equals(enum.TagPreference(0))
+
+	action = &actions.SetTagPreference{Slug: "bug", Preference: "subscribe"}
+	ExpectFailed(action.Validate(context.Background(), visitor))
+
+	action = &actions.SetTagPreference{Slug: "private", Preference: "follow"}
+	Expect(action.Validate(context.Background(), visitor).Authorized).IsFalse()
+}
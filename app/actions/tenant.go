@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
@@ -13,6 +14,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/utils"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
 )
 
@@ -233,6 +235,32 @@ func (action *UpdateTenantEmailAuthAllowed) Validate(ctx context.Context, user *
 	return result
 }
 
+// UpdateTenantTwoFactorPolicy is used by administrators to choose which roles must use two-factor authentication
+type UpdateTenantTwoFactorPolicy struct {
+	Roles []string `json:"roles"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UpdateTenantTwoFactorPolicy) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.IsAdministrator()
+}
+
+// Validate if current model is valid
+func (action *UpdateTenantTwoFactorPolicy) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	for _, role := range action.Roles {
+		switch role {
+		case enum.RoleAdministrator.String(), enum.RoleCollaborator.String(), enum.RoleModerator.String(), enum.RoleHelper.String():
+		default:
+			result.AddFieldFailure("roles", "Two-factor authentication can only be required for staff roles")
+			return result
+		}
+	}
+
+	return result
+}
+
 type UpdateMessageBanner struct {
 	MessageBanner string `json:"messageBanner"`
 }
@@ -307,5 +335,31 @@ func (action *UpdateContentSettings) Validate(ctx context.Context, user *entity.
 		}
 	}
 
+	if action.Settings.ReportAutoHideThreshold < 0 {
+		result.AddFieldFailure("settings.reportAutoHideThreshold", "Auto-hide threshold must be non-negative")
+	}
+
+	for i, rule := range action.Settings.SanctionPolicy.Rules {
+		field := "settings.sanctionPolicy.rules." + strconv.Itoa(i)
+		if rule.Trigger != entity.SanctionTriggerWarnings && rule.Trigger != entity.SanctionTriggerMutes {
+			result.AddFieldFailure(field+".trigger", "Trigger must be either warnings or mutes")
+		}
+		if rule.Count < 1 {
+			result.AddFieldFailure(field+".count", "Count must be at least 1")
+		}
+		if rule.WindowDays < 0 {
+			result.AddFieldFailure(field+".windowDays", "Window must be non-negative")
+		}
+		switch rule.Action {
+		case entity.SanctionActionMute:
+			if minutes, ok := utils.ParseDuration(rule.Duration); !ok || minutes < 1 {
+				result.AddFieldFailure(field+".duration", "Invalid duration format. Use formats like '30m', '2h', '1d', etc.")
+			}
+		case entity.SanctionActionProposeBlock:
+		default:
+			result.AddFieldFailure(field+".action", "Action must be either mute or propose_block")
+		}
+	}
+
 	return result
 }
@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// userDataExportInterval keeps users from assembling exports over and over
+const userDataExportInterval = 24 * time.Hour
+
+// RequestUserDataExport is used by users to get a copy of their personal data by email
+type RequestUserDataExport struct {
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RequestUserDataExport) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *RequestUserDataExport) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	if user.Email == "" {
+		return validate.Failed("An email address is required to receive your data export.")
+	}
+
+	latest := &query.GetLatestUserDataExport{}
+	if err := bus.Dispatch(ctx, latest); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return validate.Success()
+		}
+		return validate.Error(err)
+	}
+
+	if time.Since(latest.Result.CreatedAt) < userDataExportInterval {
+		return validate.Failed("You have already requested a data export in the last 24 hours. Check your email or try again later.")
+	}
+
+	return validate.Success()
+}
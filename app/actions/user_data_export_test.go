@@ -0,0 +1,50 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestRequestUserDataExport_FirstRequest(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetLatestUserDataExport) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.RequestUserDataExport{}
+	user := &entity.User{ID: 1, Email: "jon.snow@got.com"}
+	ExpectSuccess(action.Validate(context.Background(), user))
+}
+
+func TestRequestUserDataExport_OncePerDay(t *testing.T) {
+	RegisterT(t)
+
+	createdAt := time.Now().Add(-2 * time.Hour)
+	bus.AddHandler(func(ctx context.Context, q *query.GetLatestUserDataExport) error {
+		q.Result = &entity.UserDataExport{ID: 1, UserID: 1, CreatedAt: createdAt}
+		return nil
+	})
+
+	action := &actions.RequestUserDataExport{}
+	user := &entity.User{ID: 1, Email: "jon.snow@got.com"}
+	ExpectFailed(action.Validate(context.Background(), user), "")
+
+	createdAt = time.Now().Add(-25 * time.Hour)
+	ExpectSuccess(action.Validate(context.Background(), user))
+}
+
+func TestRequestUserDataExport_WithoutEmail(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.RequestUserDataExport{}
+	ExpectFailed(action.Validate(context.Background(), &entity.User{ID: 1}), "")
+}
@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// RegisterPasskey is the answer of the authenticator to a passkey registration, with a name to recognize it later
+type RegisterPasskey struct {
+	Name       string          `json:"name"`
+	Credential json.RawMessage `json:"credential"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *RegisterPasskey) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *RegisterPasskey) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Name == "" {
+		result.AddFieldFailure("name", propertyIsRequired(ctx, "name"))
+	} else if len(action.Name) > 50 {
+		result.AddFieldFailure("name", propertyMaxStringLen(ctx, "name", 50))
+	}
+
+	if len(action.Credential) == 0 {
+		result.AddFieldFailure("credential", propertyIsRequired(ctx, "credential"))
+	}
+
+	return result
+}
+
+// DeletePasskey removes a passkey of the current user
+type DeletePasskey struct {
+	ID int `route:"id"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DeletePasskey) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *DeletePasskey) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	ok, err := hasOtherSignInMethod(ctx, user, "", action.ID)
+	if err != nil {
+		return validate.Error(err)
+	}
+	if !ok {
+		return validate.Failed(i18n.T(ctx, "validation.custom.lastsigninmethod"))
+	}
+
+	return validate.Success()
+}
@@ -0,0 +1,136 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// UnlinkUserProvider removes a sign-in provider from the current user
+type UnlinkUserProvider struct {
+	Provider string `route:"provider"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *UnlinkUserProvider) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *UnlinkUserProvider) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	if !user.HasProvider(action.Provider) {
+		return validate.Failed(i18n.T(ctx, "validation.custom.providernotlinked"))
+	}
+
+	ok, err := hasOtherSignInMethod(ctx, user, action.Provider, 0)
+	if err != nil {
+		return validate.Error(err)
+	}
+	if !ok {
+		return validate.Failed(i18n.T(ctx, "validation.custom.lastsigninmethod"))
+	}
+
+	return validate.Success()
+}
+
+// hasOtherSignInMethod returns true if user can still sign in without given provider or passkey,
+// either by email, with another passkey or with another linked provider that is enabled
+func hasOtherSignInMethod(ctx context.Context, user *entity.User, provider string, passkeyID int) (bool, error) {
+	tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if user.Email != "" && (tenant.IsEmailAuthAllowed || user.IsAdministrator()) {
+		return true, nil
+	}
+
+	passkeys := &query.ListUserPasskeys{UserID: user.ID}
+	if err := bus.Dispatch(ctx, passkeys); err != nil {
+		return false, err
+	}
+	for _, passkey := range passkeys.Result {
+		if passkey.ID != passkeyID {
+			return true, nil
+		}
+	}
+
+	activeProviders := &query.ListActiveOAuthProviders{}
+	if err := bus.Dispatch(ctx, activeProviders); err != nil {
+		return false, err
+	}
+
+	for _, linked := range user.Providers {
+		if linked.Name == provider {
+			continue
+		}
+		for _, active := range activeProviders.Result {
+			if active.Provider == linked.Name {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// DecideUserMergeRequest is used by staff to approve or reject a merge request
+type DecideUserMergeRequest struct {
+	Status  string                   `json:"status"`
+	Request *entity.UserMergeRequest `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DecideUserMergeRequest) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	if user == nil || !user.IsCollaborator() {
+		return false
+	}
+
+	// nobody decides on a request that involves their own account
+	if action.Request != nil && (action.Request.User.ID == user.ID || action.Request.Owner.ID == user.ID) {
+		return false
+	}
+	return true
+}
+
+// Validate if current model is valid
+func (action *DecideUserMergeRequest) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if action.Request == nil || !action.Request.IsPending() {
+		result.AddFieldFailure("status", i18n.T(ctx, "validation.custom.mergerequestclosed"))
+		return result
+	}
+
+	var status enum.UserMergeRequestStatus
+	_ = status.UnmarshalText([]byte(action.Status))
+	if status != enum.UserMergeRequestApproved && status != enum.UserMergeRequestRejected {
+		result.AddFieldFailure("status", propertyIsInvalid(ctx, "status"))
+		return result
+	}
+
+	if status == enum.UserMergeRequestApproved {
+		// either account may have changed since the request was raised
+		owner := &query.GetUserByProvider{Provider: action.Request.Provider, UID: action.Request.ProviderUID}
+		if err := bus.Dispatch(ctx, owner); err != nil && errors.Cause(err) != app.ErrNotFound {
+			return validate.Error(err)
+		}
+		if owner.Result == nil || owner.Result.ID != action.Request.Owner.ID {
+			result.AddFieldFailure("status", i18n.T(ctx, "validation.custom.mergerequestoutdated"))
+			return result
+		}
+
+		requester := &query.GetUserByID{UserID: action.Request.User.ID}
+		if err := bus.Dispatch(ctx, requester); err != nil {
+			return validate.Error(err)
+		}
+		if requester.Result.HasProvider(action.Request.Provider) {
+			result.AddFieldFailure("status", i18n.T(ctx, "validation.custom.mergerequestoutdated"))
+			return result
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,205 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestUnlinkUserProvider_NotLinked(t *testing.T) {
+	RegisterT(t)
+
+	user := &entity.User{ID: 1, Email: "jon.snow@got.com", Role: enum.RoleVisitor}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: true})
+
+	action := &actions.UnlinkUserProvider{Provider: app.GoogleProvider}
+	ExpectFailed(action.Validate(ctx, user))
+}
+
+func TestUnlinkUserProvider_LastSignInMethod(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListUserPasskeys) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
+		q.Result = []*dto.OAuthProviderOption{
+			{Provider: app.GoogleProvider},
+		}
+		return nil
+	})
+
+	user := &entity.User{
+		ID:    1,
+		Email: "jon.snow@got.com",
+		Role:  enum.RoleVisitor,
+		Providers: []*entity.UserProvider{
+			{Name: app.GoogleProvider, UID: "GO123"},
+			{Name: app.FacebookProvider, UID: "FB123"},
+		},
+	}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: false})
+
+	// facebook is no longer enabled, so google is the only way in
+	action := &actions.UnlinkUserProvider{Provider: app.GoogleProvider}
+	ExpectFailed(action.Validate(ctx, user))
+
+	action = &actions.UnlinkUserProvider{Provider: app.FacebookProvider}
+	ExpectSuccess(action.Validate(ctx, user))
+}
+
+func TestUnlinkUserProvider_Passkey(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListUserPasskeys) error {
+		q.Result = []*entity.UserPasskey{{ID: 5, UserID: q.UserID}}
+		return nil
+	})
+
+	user := &entity.User{
+		ID:    1,
+		Email: "jon.snow@got.com",
+		Role:  enum.RoleVisitor,
+		Providers: []*entity.UserProvider{
+			{Name: app.GoogleProvider, UID: "GO123"},
+		},
+	}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: false})
+
+	action := &actions.UnlinkUserProvider{Provider: app.GoogleProvider}
+	ExpectSuccess(action.Validate(ctx, user))
+}
+
+func TestDeletePasskey_LastSignInMethod(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListUserPasskeys) error {
+		q.Result = []*entity.UserPasskey{{ID: 5, UserID: q.UserID}, {ID: 6, UserID: q.UserID}}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.ListActiveOAuthProviders) error {
+		return nil
+	})
+
+	user := &entity.User{ID: 1, Email: "jon.snow@got.com", Role: enum.RoleVisitor}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: false})
+
+	// the other passkey is still a way in
+	action := &actions.DeletePasskey{ID: 5}
+	ExpectSuccess(action.Validate(ctx, user))
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListUserPasskeys) error {
+		q.Result = []*entity.UserPasskey{{ID: 5, UserID: q.UserID}}
+		return nil
+	})
+	ExpectFailed(action.Validate(ctx, user))
+
+	// email sign in is enabled again
+	ctx = context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: true})
+	ExpectSuccess(action.Validate(ctx, user))
+}
+
+func TestUnlinkUserProvider_EmailSignIn(t *testing.T) {
+	RegisterT(t)
+
+	user := &entity.User{
+		ID:    1,
+		Email: "jon.snow@got.com",
+		Role:  enum.RoleVisitor,
+		Providers: []*entity.UserProvider{
+			{Name: app.GoogleProvider, UID: "GO123"},
+		},
+	}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{IsEmailAuthAllowed: true})
+
+	action := &actions.UnlinkUserProvider{Provider: app.GoogleProvider}
+	ExpectSuccess(action.Validate(ctx, user))
+}
+
+func TestDecideUserMergeRequest_IsAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	jon := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	arya := &entity.User{ID: 2, Role: enum.RoleVisitor}
+	sansa := &entity.User{ID: 3, Role: enum.RoleCollaborator}
+	request := &entity.UserMergeRequest{ID: 1, User: arya, Owner: jon, Status: enum.UserMergeRequestPending}
+
+	action := &actions.DecideUserMergeRequest{Request: request}
+	Expect(action.IsAuthorized(context.Background(), jon)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), arya)).IsFalse()
+	Expect(action.IsAuthorized(context.Background(), sansa)).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), nil)).IsFalse()
+}
+
+func TestDecideUserMergeRequest_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	jon := &entity.User{ID: 1}
+	arya := &entity.User{ID: 2}
+
+	action := &actions.DecideUserMergeRequest{
+		Status:  "approved",
+		Request: &entity.UserMergeRequest{ID: 1, User: arya, Owner: jon, Status: enum.UserMergeRequestRejected},
+	}
+	ExpectFailed(action.Validate(context.Background(), nil), "status")
+
+	action = &actions.DecideUserMergeRequest{
+		Status:  "pending",
+		Request: &entity.UserMergeRequest{ID: 1, User: arya, Owner: jon, Status: enum.UserMergeRequestPending},
+	}
+	ExpectFailed(action.Validate(context.Background(), nil), "status")
+}
+
+func TestDecideUserMergeRequest_Outdated(t *testing.T) {
+	RegisterT(t)
+
+	jon := &entity.User{ID: 1}
+	arya := &entity.User{ID: 2}
+
+	// the identity has been moved to someone else since the request was raised
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		q.Result = &entity.User{ID: 3}
+		return nil
+	})
+
+	action := &actions.DecideUserMergeRequest{
+		Status:  "approved",
+		Request: &entity.UserMergeRequest{ID: 1, User: arya, Owner: jon, Provider: app.GoogleProvider, ProviderUID: "GO123", Status: enum.UserMergeRequestPending},
+	}
+	ExpectFailed(action.Validate(context.Background(), nil), "status")
+
+	// rejecting is always possible
+	action.Status = "rejected"
+	ExpectSuccess(action.Validate(context.Background(), nil))
+}
+
+func TestDecideUserMergeRequest_Approve(t *testing.T) {
+	RegisterT(t)
+
+	jon := &entity.User{ID: 1}
+	arya := &entity.User{ID: 2}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		q.Result = jon
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = arya
+		return nil
+	})
+
+	action := &actions.DecideUserMergeRequest{
+		Status:  "approved",
+		Request: &entity.UserMergeRequest{ID: 1, User: arya, Owner: jon, Provider: app.GoogleProvider, ProviderUID: "GO123", Status: enum.UserMergeRequestPending},
+	}
+	ExpectSuccess(action.Validate(context.Background(), nil))
+}
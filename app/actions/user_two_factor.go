@@ -0,0 +1,128 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+)
+
+// EnableTwoFactor confirms a two-factor enrollment with the first code of the authenticator app
+type EnableTwoFactor struct {
+	Code string `json:"code"`
+	Step int64  `json:"-"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *EnableTwoFactor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *EnableTwoFactor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if strings.TrimSpace(action.Code) == "" {
+		result.AddFieldFailure("code", propertyIsRequired(ctx, "code"))
+		return result
+	}
+
+	getTwoFactor := &query.GetUserTwoFactor{UserID: user.ID}
+	if err := bus.Dispatch(ctx, getTwoFactor); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			result.AddFieldFailure("code", i18n.T(ctx, "validation.custom.twofactornotstarted"))
+			return result
+		}
+		return validate.Error(err)
+	}
+
+	if getTwoFactor.Result.IsEnabled() {
+		result.AddFieldFailure("code", i18n.T(ctx, "validation.custom.twofactoralreadyenabled"))
+		return result
+	}
+
+	step, ok := totp.Validate(getTwoFactor.Result.Secret, action.Code, time.Now())
+	if !ok {
+		result.AddFieldFailure("code", i18n.T(ctx, "validation.custom.invalidtwofactorcode"))
+		return result
+	}
+
+	action.Step = step
+	return result
+}
+
+// ConfirmTwoFactor is a two-factor code typed to confirm a sensitive change, like getting new recovery codes
+type ConfirmTwoFactor struct {
+	Code string `json:"code"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *ConfirmTwoFactor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *ConfirmTwoFactor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if strings.TrimSpace(action.Code) == "" {
+		result.AddFieldFailure("code", propertyIsRequired(ctx, "code"))
+	}
+
+	return result
+}
+
+// DisableTwoFactor turns two-factor authentication off, unless the tenant requires it for the role of the user
+type DisableTwoFactor struct {
+	Code string `json:"code"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *DisableTwoFactor) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil
+}
+
+// Validate if current model is valid
+func (action *DisableTwoFactor) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	tenant := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+	if tenant.GeneralSettings.RequiresTwoFactor(user.Role) {
+		return validate.Failed(i18n.T(ctx, "validation.custom.twofactorrequired"))
+	}
+
+	if strings.TrimSpace(action.Code) == "" {
+		result.AddFieldFailure("code", propertyIsRequired(ctx, "code"))
+	}
+
+	return result
+}
+
+// VerifyTwoFactorSignIn is the second step of signing in for users who have enabled two-factor authentication
+type VerifyTwoFactorSignIn struct {
+	Code string `json:"code"`
+}
+
+// IsAuthorized returns true if current user is authorized to perform this action
+func (action *VerifyTwoFactorSignIn) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return true
+}
+
+// Validate if current model is valid
+func (action *VerifyTwoFactorSignIn) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if strings.TrimSpace(action.Code) == "" {
+		result.AddFieldFailure("code", propertyIsRequired(ctx, "code"))
+	}
+
+	return result
+}
@@ -0,0 +1,70 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+)
+
+func TestEnableTwoFactor_NotStarted(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		return app.ErrNotFound
+	})
+
+	action := &actions.EnableTwoFactor{Code: "123456"}
+	ExpectFailed(action.Validate(context.Background(), &entity.User{ID: 1}), "code")
+}
+
+func TestEnableTwoFactor_ValidCode(t *testing.T) {
+	RegisterT(t)
+
+	secret := totp.GenerateSecret()
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		q.Result = &entity.UserTwoFactor{UserID: q.UserID, Secret: secret}
+		return nil
+	})
+
+	step := totp.Step(time.Now())
+	code, _ := totp.Code(secret, step)
+
+	action := &actions.EnableTwoFactor{Code: "000000"}
+	ExpectFailed(action.Validate(context.Background(), &entity.User{ID: 1}), "code")
+
+	action = &actions.EnableTwoFactor{Code: code}
+	ExpectSuccess(action.Validate(context.Background(), &entity.User{ID: 1}))
+	Expect(action.Step).Equals(step)
+}
+
+func TestDisableTwoFactor_RequiredByTenant(t *testing.T) {
+	RegisterT(t)
+
+	tenant := &entity.Tenant{GeneralSettings: &entity.GeneralSettings{TwoFactorRequiredFor: []string{"administrator"}}}
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, tenant)
+
+	action := &actions.DisableTwoFactor{Code: "123456"}
+	ExpectFailed(action.Validate(ctx, &entity.User{ID: 1, Role: enum.RoleAdministrator}))
+	ExpectSuccess(action.Validate(ctx, &entity.User{ID: 2, Role: enum.RoleVisitor}))
+}
+
+func TestUpdateTenantTwoFactorPolicy(t *testing.T) {
+	RegisterT(t)
+
+	action := &actions.UpdateTenantTwoFactorPolicy{Roles: []string{"administrator", "moderator"}}
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleAdministrator})).IsTrue()
+	Expect(action.IsAuthorized(context.Background(), &entity.User{Role: enum.RoleCollaborator})).IsFalse()
+	ExpectSuccess(action.Validate(context.Background(), nil))
+
+	action = &actions.UpdateTenantTwoFactorPolicy{Roles: []string{"visitor"}}
+	ExpectFailed(action.Validate(context.Background(), nil), "roles")
+}
@@ -79,6 +79,41 @@ func routes(r *web.Engine) *web.Engine {
 		}
 	}
 
+	if env.IsReplyByEmailEnabled() {
+		r.Post("/webhooks/email/:provider", apiv1.IncomingReplyEmail())
+	}
+
+	if env.IsEmailEventsEnabled() {
+		r.Post("/webhooks/email/:provider/events", webhooks.IncomingEmailEvents())
+	}
+
+	// one-click unsubscribe from mail clients doesn't carry a CSRF token
+	r.Post("/unsubscribe", handlers.Unsubscribe())
+
+	// third-party apps exchange their codes and tokens from their own servers
+	r.Post("/oauth2/token", handlers.OAuth2Token())
+
+	// identity providers provision users with the SCIM token of the tenant instead of a session
+	scim := r.Group()
+	{
+		scim.Use(middlewares.RequireTenant())
+		scim.Use(middlewares.SCIMToken())
+
+		scim.Get("/scim/v2/ServiceProviderConfig", handlers.SCIMServiceProviderConfig())
+		scim.Get("/scim/v2/Users", handlers.SCIMListUsers())
+		scim.Post("/scim/v2/Users", handlers.SCIMCreateUser())
+		scim.Get("/scim/v2/Users/:id", handlers.SCIMGetUser())
+		scim.Put("/scim/v2/Users/:id", handlers.SCIMReplaceUser())
+		scim.Patch("/scim/v2/Users/:id", handlers.SCIMPatchUser())
+		scim.Delete("/scim/v2/Users/:id", handlers.SCIMDeleteUser())
+		scim.Get("/scim/v2/Groups", handlers.SCIMListGroups())
+		scim.Post("/scim/v2/Groups", handlers.SCIMCreateGroup())
+		scim.Get("/scim/v2/Groups/:id", handlers.SCIMGetGroup())
+		scim.Put("/scim/v2/Groups/:id", handlers.SCIMReplaceGroup())
+		scim.Patch("/scim/v2/Groups/:id", handlers.SCIMPatchGroup())
+		scim.Delete("/scim/v2/Groups/:id", handlers.SCIMDeleteGroup())
+	}
+
 	r.Use(middlewares.CSRF())
 
 	r.Get("/terms", handlers.LegalPage("Terms of Service", "terms.md"))
@@ -94,6 +129,8 @@ func routes(r *web.Engine) *web.Engine {
 	r.Use(middlewares.RequireTenant())
 
 	r.Get("/sitemap.xml", handlers.Sitemap())
+	r.Get("/unsubscribe", handlers.UnsubscribePage())
+	r.Get("/user/export/download", handlers.DownloadUserDataExport())
 
 	pwa := r.Group()
 	{
@@ -104,6 +141,7 @@ func routes(r *web.Engine) *web.Engine {
 
 	r.Get("/signup/verify", handlers.VerifySignUpKey())
 	r.Get("/signout", handlers.SignOut())
+
 	r.Get("/oauth/:provider/token", handlers.OAuthToken())
 	r.Get("/oauth/:provider/echo", handlers.OAuthEcho())
 
@@ -111,15 +149,32 @@ func routes(r *web.Engine) *web.Engine {
 	r.Use(middlewares.BlockPendingTenants())
 
 	r.Get("/signin", handlers.SignInPage())
+	r.Get("/oauth2/authorize", handlers.OAuth2AuthorizePage())
 	r.Get("/not-invited", handlers.NotInvitedPage())
 	r.Get("/signin/verify", handlers.VerifySignInKey(enum.EmailVerificationKindSignIn))
 	r.Get("/invite/verify", handlers.VerifySignInKey(enum.EmailVerificationKindUserInvitation))
 	r.Post("/_api/signin/complete", handlers.CompleteSignInProfile())
 	r.Post("/_api/signin", handlers.SignInByEmail())
+	r.Get("/signin/2fa", handlers.TwoFactorSignInPage())
+	r.Post("/_api/signin/2fa", handlers.VerifyTwoFactorSignIn())
+	r.Post("/_api/signin/passkey/begin", handlers.BeginPasskeySignIn())
+	r.Post("/_api/signin/passkey/finish", handlers.FinishPasskeySignIn())
 
 	// Block if it's private tenant with unauthenticated user
 	r.Use(middlewares.CheckTenantPrivacy())
 
+	// Blocked users can only appeal their block or sign out
+	appeals := r.Group()
+	{
+		appeals.Use(middlewares.IsAuthenticated())
+		appeals.Use(middlewares.BlockLockedTenants())
+		appeals.Get("/appeal", handlers.AppealPage())
+		appeals.Get("/api/v1/user/appeals", handlers.ListUserAppeals())
+		appeals.Post("/api/v1/user/appeals", handlers.CreateAppeal())
+	}
+
+	r.Use(middlewares.BlockBlockedUsers())
+
 	r.Get("/", handlers.Index())
 	r.Get("/posts/:number", handlers.PostDetails())
 	r.Get("/posts/:number/:slug", handlers.PostDetails())
@@ -167,6 +222,39 @@ func routes(r *web.Engine) *web.Engine {
 		membersApi.Post("/_api/notifications/read-all", handlers.ReadAllNotifications())
 
 		// push notifications
+		membersApi.Get("/api/v1/user/notification-preferences", apiv1.GetNotificationPreferences())
+		membersApi.Put("/api/v1/user/notification-preferences", apiv1.UpdateNotificationPreferences())
+
+		// sessions
+		membersApi.Get("/api/v1/user/sessions", apiv1.ListUserSessions())
+		membersApi.Delete("/api/v1/user/sessions", apiv1.RevokeUserSessions())
+		membersApi.Delete("/api/v1/user/sessions/:id", apiv1.RevokeUserSession())
+		membersApi.Get("/api/v1/user/tokens", apiv1.ListAPITokens())
+		membersApi.Post("/api/v1/user/tokens", apiv1.CreateAPIToken())
+		membersApi.Delete("/api/v1/user/tokens/:id", apiv1.DeleteAPIToken())
+
+		// personal data export
+		membersApi.Post("/api/v1/user/export", apiv1.RequestUserDataExport())
+
+		// linked sign-in providers
+		membersApi.Get("/oauth/:provider/link", handlers.OAuthLink())
+		membersApi.Get("/api/v1/user/providers", apiv1.ListUserProviders())
+		membersApi.Delete("/api/v1/user/providers/:provider", apiv1.UnlinkUserProvider())
+
+		// two-factor authentication
+		membersApi.Get("/api/v1/user/2fa", apiv1.GetTwoFactorStatus())
+		membersApi.Post("/api/v1/user/2fa/enroll", apiv1.StartTwoFactorEnrollment())
+		membersApi.Post("/api/v1/user/2fa/enable", apiv1.EnableTwoFactor())
+		membersApi.Post("/api/v1/user/2fa/recovery-codes", apiv1.RegenerateRecoveryCodes())
+		membersApi.Delete("/api/v1/user/2fa", apiv1.DisableTwoFactor())
+
+		// passkeys
+		membersApi.Get("/api/v1/user/passkeys", apiv1.ListUserPasskeys())
+		membersApi.Post("/api/v1/user/passkeys/begin", apiv1.BeginPasskeyRegistration())
+		membersApi.Post("/api/v1/user/passkeys", apiv1.FinishPasskeyRegistration())
+		membersApi.Delete("/api/v1/user/passkeys/:id", apiv1.DeleteUserPasskey())
+
+		membersApi.Post("/_api/oauth2/authorize", handlers.OAuth2Authorize())
 		membersApi.Post("/_api/push/subscribe", handlers.SavePushSubscription())
 		membersApi.Delete("/_api/push/subscribe", handlers.DeletePushSubscription())
 		membersApi.Get("/_api/push/status", handlers.HasPushSubscription())
@@ -189,6 +277,9 @@ func routes(r *web.Engine) *web.Engine {
 		membersApi.Post("/api/v1/posts/:number/votes/toggle", apiv1.ToggleVote())
 		membersApi.Post("/api/v1/posts/:number/subscription", apiv1.Subscribe())
 		membersApi.Delete("/api/v1/posts/:number/subscription", apiv1.Unsubscribe())
+		membersApi.Post("/api/v1/posts/:number/mute", apiv1.Mute())
+		membersApi.Get("/api/v1/tag-preferences", apiv1.ListTagPreferences())
+		membersApi.Put("/api/v1/tag-preferences/:slug", apiv1.SetTagPreference())
 
 		membersApi.Post("/api/v1/posts/:number/report", handlers.ReportPost())
 		membersApi.Post("/api/v1/posts/:number/comments/:id/report", handlers.ReportComment())
@@ -200,21 +291,132 @@ func routes(r *web.Engine) *web.Engine {
 		membersApi.Post("/api/v1/pages/:id/comments/:commentId/reactions/:reaction", apiv1.TogglePageCommentReaction())
 	}
 
-	helper := r.Group()
+	// Groups of the routes gated by a single permission, which the built-in roles and custom roles grant
+	permitted := func(permission enum.Permission) *web.Group {
+		g := r.Group()
+		g.Use(middlewares.IsAuthenticated())
+		g.Use(middlewares.HasPermission(permission))
+		g.Use(middlewares.RequireTwoFactor())
+		g.Use(middlewares.BlockLockedTenants())
+		return g
+	}
+
+	queueView := permitted(enum.PermissionQueueView)
+	{
+		queueView.Get("/admin/queue", handlers.PostQueuePage())
+		queueView.Post("/api/v1/queue/:id/heartbeat", handlers.QueuePostHeartbeat())
+		queueView.Delete("/api/mod/queue-viewing", handlers.StopViewingQueuePost())
+		queueView.Get("/api/mod/queue-events", handlers.QueueSSE())
+	}
+
+	tagAssign := permitted(enum.PermissionTagAssign)
+	{
+		tagAssign.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
+		tagAssign.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
+	}
+
+	tagManage := permitted(enum.PermissionTagManage)
+	{
+		tagManage.Use(middlewares.SetLocale("en"))
+		tagManage.Get("/admin/tags", handlers.ManageTags())
+		tagManage.Post("/api/v1/tags", apiv1.CreateEditTag())
+		tagManage.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
+		tagManage.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
+	}
+
+	postRespond := permitted(enum.PermissionPostRespond)
+	{
+		postRespond.Put("/api/v1/posts/:number/status", apiv1.SetResponse())
+	}
+
+	postDelete := permitted(enum.PermissionPostDelete)
+	{
+		postDelete.Delete("/api/v1/posts/:number", apiv1.DeletePost())
+	}
+
+	postLock := permitted(enum.PermissionPostLock)
+	{
+		postLock.Put("/api/v1/posts/:number/lock", apiv1.LockOrUnlockPost())
+		postLock.Delete("/api/v1/posts/:number/lock", apiv1.LockOrUnlockPost())
+	}
+
+	postArchive := permitted(enum.PermissionPostArchive)
+	{
+		postArchive.Use(middlewares.SetLocale("en"))
+		postArchive.Get("/admin/archive", handlers.ArchivePostsPage())
+		postArchive.Get("/api/v1/archive/posts", handlers.ListArchivablePosts())
+		postArchive.Post("/api/v1/posts/:number/archive", handlers.ArchivePost())
+		postArchive.Post("/api/v1/posts/:number/unarchive", handlers.UnarchivePost())
+		postArchive.Post("/api/v1/archive/bulk", handlers.BulkArchive())
+	}
+
+	contentModerate := permitted(enum.PermissionContentModerate)
+	{
+		contentModerate.Post("/_api/admin/moderation/posts/:id/approve", handlers.ApprovePostModeration())
+		contentModerate.Post("/_api/admin/moderation/comments/:id/approve", handlers.ApproveCommentModeration())
+		contentModerate.Post("/_api/admin/moderation/posts/:id/hide", handlers.HidePostModeration())
+		contentModerate.Post("/_api/admin/moderation/comments/:id/hide", handlers.HideCommentModeration())
+	}
+
+	reportView := permitted(enum.PermissionReportView)
+	{
+		reportView.Get("/admin/reports", handlers.ManageReportsPage())
+		reportView.Get("/api/v1/reports", handlers.ListReports())
+		reportView.Get("/api/v1/reports/:id", handlers.GetReport())
+		reportView.Get("/api/v1/reports/:id/details", handlers.GetReportDetails())
+		reportView.Post("/api/v1/reports/:id/assign", handlers.AssignReport())
+		reportView.Delete("/api/v1/reports/:id/assign", handlers.UnassignReport())
+		reportView.Post("/api/v1/reports/:id/heartbeat", handlers.ReportHeartbeat())
+		reportView.Get("/api/v1/report-cases", handlers.ListReportCases())
+		reportView.Get("/api/v1/report-cases/:id", handlers.GetReportCase())
+		reportView.Delete("/api/mod/viewing", handlers.StopViewingReport())
+		reportView.Get("/api/mod/report-events", handlers.ReportsSSE())
+	}
+
+	reportResolve := permitted(enum.PermissionReportResolve)
+	{
+		reportResolve.Put("/api/v1/reports/:id/resolve", handlers.ResolveReport())
+		reportResolve.Put("/api/v1/report-cases/:id/resolve", handlers.ResolveReportCase())
+	}
+
+	responseManage := permitted(enum.PermissionResponseManage)
+	{
+		responseManage.Use(middlewares.SetLocale("en"))
+		responseManage.Get("/admin/responses", handlers.ManageCannedResponses())
+		responseManage.Post("/api/v1/responses", apiv1.CreateCannedResponse())
+		responseManage.Put("/api/v1/responses/:id", apiv1.UpdateCannedResponse())
+		responseManage.Delete("/api/v1/responses/:id", apiv1.DeleteCannedResponse())
+		responseManage.Get("/api/v1/report-reasons/all", handlers.ListAllReportReasons())
+		responseManage.Post("/api/v1/report-reasons", handlers.CreateReportReason())
+		responseManage.Put("/api/v1/report-reasons/:id", handlers.UpdateReportReason())
+		responseManage.Delete("/api/v1/report-reasons/:id", handlers.DeleteReportReason())
+		responseManage.Put("/api/v1/admin/report-reasons-order", handlers.ReorderReportReasons())
+	}
+
+	appealDecide := permitted(enum.PermissionAppealDecide)
+	{
+		appealDecide.Get("/admin/appeals", handlers.ManageAppealsPage())
+		appealDecide.Get("/api/v1/appeals", handlers.ListAppeals())
+		appealDecide.Put("/api/v1/appeals/:id/decide", handlers.DecideAppeal())
+		appealDecide.Get("/api/mod/appeal-events", handlers.AppealsSSE())
+	}
+
+	userModerate := permitted(enum.PermissionUserModerate)
 	{
-		helper.Use(middlewares.IsAuthenticated())
-		helper.Use(middlewares.IsAuthorized(enum.RoleHelper, enum.RoleCollaborator, enum.RoleAdministrator, enum.RoleModerator))
-		helper.Use(middlewares.BlockLockedTenants())
-
-		// post queue
-		helper.Get("/admin/queue", handlers.PostQueuePage())
-		helper.Post("/api/v1/queue/:id/heartbeat", handlers.QueuePostHeartbeat())
-		helper.Delete("/api/mod/queue-viewing", handlers.StopViewingQueuePost())
-		helper.Get("/api/mod/queue-events", handlers.QueueSSE())
-
-		// tags
-		helper.Post("/api/v1/posts/:number/tags/:slug", apiv1.AssignTag())
-		helper.Delete("/api/v1/posts/:number/tags/:slug", apiv1.UnassignTag())
+		userModerate.Post("/_api/admin/users/:userID/mute", handlers.MuteUser())
+		userModerate.Post("/_api/admin/users/:userID/warn", handlers.WarnUser())
+		userModerate.Post("/_api/admin/users/:userID/warnings/:warningID/expire", handlers.ExpireWarning())
+		userModerate.Post("/_api/admin/users/:userID/mutes/:muteID/expire", handlers.ExpireMute())
+		userModerate.Post("/_api/admin/users/:userID/restrictions", handlers.RestrictUser())
+		userModerate.Post("/_api/admin/users/:userID/restrictions/:restrictionID/expire", handlers.LiftRestriction())
+	}
+
+	userBlock := permitted(enum.PermissionUserBlock)
+	{
+		userBlock.Put("/_api/admin/users/:userID/block", handlers.BlockUser())
+		userBlock.Delete("/_api/admin/users/:userID/block", handlers.UnblockUser())
+		userBlock.Delete("/_api/admin/users/:userID/block-proposal", handlers.DismissBlockProposal())
+		userBlock.Delete("/_api/admin/users/:userID/sessions", handlers.RevokeUserSessions())
 	}
 
 	// Available to both collaborators, administrators and moderators
@@ -222,6 +424,7 @@ func routes(r *web.Engine) *web.Engine {
 	{
 		staff.Use(middlewares.IsAuthenticated())
 		staff.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator, enum.RoleModerator))
+		staff.Use(middlewares.RequireTwoFactor())
 		staff.Use(middlewares.BlockLockedTenants())
 
 		// user profiles
@@ -229,37 +432,13 @@ func routes(r *web.Engine) *web.Engine {
 		staff.Post("/_api/users/:userID/name", handlers.UpdateUserName())
 		staff.Post("/_api/users/:userID/avatar", handlers.UpdateUserAvatar())
 
-		// user moderation
-		staff.Post("/_api/admin/users/:userID/mute", handlers.MuteUser())
-		staff.Post("/_api/admin/users/:userID/warn", handlers.WarnUser())
-		staff.Post("/_api/admin/users/:userID/warnings/:warningID/expire", handlers.ExpireWarning())
-		staff.Post("/_api/admin/users/:userID/mutes/:muteID/expire", handlers.ExpireMute())
+		// members
 		staff.Get("/api/v1/responses/:type", apiv1.ListCannedResponses())
 		staff.Get("/admin/members", handlers.ManageMembers())
 		staff.Get("/api/v1/users", apiv1.ListUsers())
 
 		// posts
 		staff.Get("/api/v1/posts/:number/votes", apiv1.ListVotes())
-		staff.Delete("/api/v1/posts/:number", apiv1.DeletePost())
-		staff.Put("/api/v1/posts/:number/status", apiv1.SetResponse())
-
-		// reports
-		staff.Get("/admin/reports", handlers.ManageReportsPage())
-		staff.Get("/api/v1/reports", handlers.ListReports())
-		staff.Get("/api/v1/reports/:id", handlers.GetReport())
-		staff.Get("/api/v1/reports/:id/details", handlers.GetReportDetails())
-		staff.Post("/api/v1/reports/:id/assign", handlers.AssignReport())
-		staff.Delete("/api/v1/reports/:id/assign", handlers.UnassignReport())
-		staff.Put("/api/v1/reports/:id/resolve", handlers.ResolveReport())
-		staff.Post("/api/v1/reports/:id/heartbeat", handlers.ReportHeartbeat())
-		staff.Delete("/api/mod/viewing", handlers.StopViewingReport())
-		staff.Get("/api/mod/report-events", handlers.ReportsSSE())
-
-		// content moderation
-		staff.Post("/_api/admin/moderation/posts/:id/approve", handlers.ApprovePostModeration())
-		staff.Post("/_api/admin/moderation/comments/:id/approve", handlers.ApproveCommentModeration())
-		staff.Post("/_api/admin/moderation/posts/:id/hide", handlers.HidePostModeration())
-		staff.Post("/_api/admin/moderation/comments/:id/hide", handlers.HideCommentModeration())
 	}
 
 	// Operations available only to collaborators and administrators
@@ -268,6 +447,7 @@ func routes(r *web.Engine) *web.Engine {
 		collabAdmin.Use(middlewares.SetLocale("en"))
 		collabAdmin.Use(middlewares.IsAuthenticated())
 		collabAdmin.Use(middlewares.IsAuthorized(enum.RoleCollaborator, enum.RoleAdministrator))
+		collabAdmin.Use(middlewares.RequireTwoFactor())
 		collabAdmin.Use(middlewares.BlockLockedTenants())
 
 		// admin pages
@@ -278,19 +458,6 @@ func routes(r *web.Engine) *web.Engine {
 
 		collabAdmin.Post("/_api/admin/settings/message-banner", handlers.UpdateMessageBanner())
 
-		collabAdmin.Get("/admin/responses", handlers.ManageCannedResponses())
-		collabAdmin.Post("/api/v1/responses", apiv1.CreateCannedResponse())
-		collabAdmin.Put("/api/v1/responses/:id", apiv1.UpdateCannedResponse())
-		collabAdmin.Delete("/api/v1/responses/:id", apiv1.DeleteCannedResponse())
-
-		collabAdmin.Get("/api/v1/report-reasons/all", handlers.ListAllReportReasons())
-		collabAdmin.Post("/api/v1/report-reasons", handlers.CreateReportReason())
-
-		collabAdmin.Get("/admin/archive", handlers.ArchivePostsPage())
-		collabAdmin.Get("/api/v1/archive/posts", handlers.ListArchivablePosts())
-		collabAdmin.Post("/api/v1/posts/:number/archive", handlers.ArchivePost())
-		collabAdmin.Post("/api/v1/posts/:number/unarchive", handlers.UnarchivePost())
-
 		collabAdmin.Get("/admin/pages", handlers.ManagePages())
 		collabAdmin.Get("/admin/pages/new", handlers.EditPagePage())
 		collabAdmin.Get("/admin/pages/edit/:id", handlers.EditPagePage())
@@ -299,15 +466,6 @@ func routes(r *web.Engine) *web.Engine {
 		collabAdmin.Delete("/_api/pages/:id", apiv1.DeletePage())
 		collabAdmin.Post("/_api/pages/:id/draft", apiv1.SavePageDraft())
 		collabAdmin.Get("/_api/pages/:id/draft", apiv1.GetPageDraft())
-		collabAdmin.Post("/api/v1/archive/bulk", handlers.BulkArchive())
-		collabAdmin.Put("/api/v1/report-reasons/:id", handlers.UpdateReportReason())
-		collabAdmin.Delete("/api/v1/report-reasons/:id", handlers.DeleteReportReason())
-		collabAdmin.Put("/api/v1/admin/report-reasons-order", handlers.ReorderReportReasons())
-
-		collabAdmin.Get("/admin/tags", handlers.ManageTags())
-		collabAdmin.Post("/api/v1/tags", apiv1.CreateEditTag())
-		collabAdmin.Put("/api/v1/tags/:slug", apiv1.CreateEditTag())
-		collabAdmin.Delete("/api/v1/tags/:slug", apiv1.DeleteTag())
 
 		collabAdmin.Get("/admin/webhooks", handlers.ManageWebhooks())
 		collabAdmin.Post("/_api/admin/webhook", handlers.CreateWebhook())
@@ -320,14 +478,11 @@ func routes(r *web.Engine) *web.Engine {
 		// user moderation
 		collabAdmin.Post("/_api/admin/visualroles/:visualRole/users", handlers.ChangeUserVisualRole())
 
-		collabAdmin.Put("/_api/admin/users/:userID/block", handlers.BlockUser())
-		collabAdmin.Delete("/_api/admin/users/:userID/block", handlers.UnblockUser())
+		collabAdmin.Get("/admin/merge-requests", handlers.ManageUserMergeRequestsPage())
+		collabAdmin.Put("/api/v1/admin/merge-requests/:id", handlers.DecideUserMergeRequest())
 
 		collabAdmin.Delete("/_api/admin/users/:userID/warnings/:warningID", handlers.DeleteWarning())
 		collabAdmin.Delete("/_api/admin/users/:userID/mutes/:muteID", handlers.DeleteMute())
-
-		collabAdmin.Put("/api/v1/posts/:number/lock", apiv1.LockOrUnlockPost())
-		collabAdmin.Delete("/api/v1/posts/:number/lock", apiv1.LockOrUnlockPost())
 	}
 
 	// Only available to administrators
@@ -336,6 +491,7 @@ func routes(r *web.Engine) *web.Engine {
 		adminOnly.Use(middlewares.SetLocale("en"))
 		adminOnly.Use(middlewares.IsAuthenticated())
 		adminOnly.Use(middlewares.IsAuthorized(enum.RoleAdministrator))
+		adminOnly.Use(middlewares.RequireTwoFactor())
 		adminOnly.Use(middlewares.BlockLockedTenants())
 
 		// admin pages
@@ -360,10 +516,25 @@ func routes(r *web.Engine) *web.Engine {
 		adminOnly.Post("/api/v1/invitations/send", apiv1.SendInvites())
 		adminOnly.Post("/api/v1/invitations/sample", apiv1.SendSampleInvite())
 
+		adminOnly.Get("/admin/email-templates", handlers.ManageEmailTemplates())
+		adminOnly.Put("/_api/admin/email-templates/:name", handlers.SaveEmailTemplate())
+		adminOnly.Delete("/_api/admin/email-templates/:name", handlers.DeleteEmailTemplate())
+		adminOnly.Post("/_api/admin/email-templates/:name/preview", handlers.PreviewEmailTemplate())
+
+		adminOnly.Get("/admin/email-queue", handlers.EmailQueuePage())
+		adminOnly.Post("/_api/admin/email-queue/:id/retry", handlers.RetryQueuedEmail())
+		adminOnly.Get("/admin/oauth-clients", handlers.OAuthClientsPage())
+		adminOnly.Get("/api/v1/admin/oauth-clients", apiv1.ListOAuthClients())
+		adminOnly.Post("/api/v1/admin/oauth-clients", apiv1.CreateOAuthClient())
+		adminOnly.Delete("/api/v1/admin/oauth-clients/:id", apiv1.DeleteOAuthClient())
+
 		adminOnly.Get("/admin/authentication", handlers.ManageAuthentication())
 		adminOnly.Post("/_api/admin/oauth", handlers.SaveOAuthConfig())
 		adminOnly.Get("/_api/admin/oauth/:provider", handlers.GetOAuthConfig())
 		adminOnly.Post("/_api/admin/settings/emailauth", handlers.UpdateEmailAuthAllowed())
+		adminOnly.Post("/_api/admin/settings/twofactor", handlers.UpdateTwoFactorPolicy())
+		adminOnly.Post("/_api/admin/scim/token", handlers.GenerateSCIMToken())
+		adminOnly.Delete("/_api/admin/scim/token", handlers.DeleteSCIMToken())
 
 		if env.IsBillingEnabled() {
 			adminOnly.Get("/admin/billing", handlers.ManageBilling())
@@ -384,6 +555,16 @@ func routes(r *web.Engine) *web.Engine {
 		adminOnly.Post("/api/v1/users", apiv1.CreateUser())
 		adminOnly.Post("/_api/admin/roles/:role/users", handlers.ChangeUserRole())
 
+		// custom roles
+		adminOnly.Get("/admin/roles", handlers.ManageRolesPage())
+		adminOnly.Get("/api/v1/admin/roles", handlers.ListCustomRoles())
+		adminOnly.Post("/api/v1/admin/roles", handlers.CreateEditCustomRole())
+		adminOnly.Put("/api/v1/admin/roles/:id", handlers.CreateEditCustomRole())
+		adminOnly.Delete("/api/v1/admin/roles/:id", handlers.DeleteCustomRole())
+		adminOnly.Get("/api/v1/admin/roles/:id/members", handlers.ListCustomRoleMembers())
+		adminOnly.Get("/api/v1/admin/users/:userID/custom-role", handlers.GetUserCustomRole())
+		adminOnly.Put("/api/v1/admin/users/:userID/custom-role", handlers.SetUserCustomRole())
+
 		// export
 		adminOnly.Get("/admin/export", handlers.Page("Export · Site Settings", "", "Administration/pages/Export.page"))
 		adminOnly.Get("/admin/export/posts.csv", handlers.ExportPostsToCSV())
@@ -75,6 +75,12 @@ func startJobs(ctx context.Context) {
 	_ = c.AddJob(jobs.NewJob(ctx, "RefreshPostStatsJob", jobs.RefreshPostStatsJobHandler{}))
 	_ = c.AddJob(jobs.NewJob(ctx, "RefreshCrawlerIPsJob", jobs.RefreshCrawlerIPsJobHandler{}))
 	_ = c.AddJob(jobs.NewJob(ctx, "PublishScheduledPagesJob", jobs.PublishScheduledPagesJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "DigestEmailJob", jobs.DigestEmailJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "DeferredNotificationsJob", jobs.DeferredNotificationsJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "EmailQueueJob", jobs.EmailQueueJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredUserSessionsJob", jobs.PurgeExpiredUserSessionsJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredOAuthGrantsJob", jobs.PurgeExpiredOAuthGrantsJobHandler{}))
+	_ = c.AddJob(jobs.NewJob(ctx, "PurgeExpiredUserDataExportsJob", jobs.PurgeExpiredUserDataExportsJobHandler{}))
 
 	if env.IsBillingEnabled() {
 		_ = c.AddJob(jobs.NewJob(ctx, "LockExpiredTenantsJob", jobs.LockExpiredTenantsJobHandler{}))
@@ -32,5 +32,7 @@ var (
 	TenantCtxKey      = createKey("TENANT")
 	LocaleCtxKey      = createKey("LOCALE")
 	UserCtxKey        = createKey("USER")
+	UserSessionCtxKey = createKey("USER_SESSION")
+	APITokenCtxKey    = createKey("API_TOKEN")
 	LogPropsCtxKey    = createKey("LOG_PROPS")
 )
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
@@ -14,7 +16,11 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/postcache"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/utils"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
 )
 
@@ -213,6 +219,25 @@ func UpdateEmailAuthAllowed() web.HandlerFunc {
 	}
 }
 
+// UpdateTwoFactorPolicy updates which roles must use two-factor authentication to access staff pages
+func UpdateTwoFactorPolicy() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateTenantTwoFactorPolicy)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		return c.WithTransaction(func() error {
+			if err := bus.Dispatch(c, &cmd.UpdateTenantTwoFactorPolicy{Roles: action.Roles}); err != nil {
+				return c.Failure(err)
+			}
+
+			middlewares.InvalidateTenantCache()
+			return c.Ok(web.Map{})
+		})
+	}
+}
+
 // ManageMembers is the page used by administrators to change member's role
 func ManageMembers() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -278,16 +303,52 @@ func ManageAuthentication() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		getSCIMToken := &query.GetSCIMToken{}
+		if err := bus.Dispatch(c, getSCIMToken); err != nil && errors.Cause(err) != app.ErrNotFound {
+			return c.Failure(err)
+		}
+
 		return c.Page(http.StatusOK, web.Props{
 			Page:  "Administration/pages/ManageAuthentication.page",
 			Title: "Authentication · Site Settings",
 			Data: web.Map{
 				"providers": listProviders.Result,
+				"scimToken": getSCIMToken.Result,
 			},
 		})
 	}
 }
 
+// GenerateSCIMToken replaces the token identity providers use to provision users, it is only shown once
+func GenerateSCIMToken() web.HandlerFunc {
+	return func(c *web.Context) error {
+		token, prefix := webutil.GenerateSCIMToken()
+		setToken := &cmd.SetSCIMToken{
+			TokenHash: webutil.HashSCIMToken(token),
+			Prefix:    prefix,
+		}
+		if err := bus.Dispatch(c, setToken); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"token":     token,
+			"prefix":    prefix,
+			"createdAt": time.Now(),
+		})
+	}
+}
+
+// DeleteSCIMToken stops identity providers from provisioning users
+func DeleteSCIMToken() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if err := bus.Dispatch(c, &cmd.DeleteSCIMToken{}); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(web.Map{})
+	}
+}
+
 // ManageCannedResponses is the page used by administrators to manage canned responses
 func ManageCannedResponses() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -333,6 +394,7 @@ func SaveOAuthConfig() web.HandlerFunc {
 					ID:                action.ID,
 					Logo:              action.Logo,
 					Provider:          action.Provider,
+					Type:              action.Type,
 					Status:            action.Status,
 					DisplayName:       action.DisplayName,
 					ClientID:          action.ClientID,
@@ -341,6 +403,7 @@ func SaveOAuthConfig() web.HandlerFunc {
 					TokenURL:          action.TokenURL,
 					Scope:             action.Scope,
 					ProfileURL:        action.ProfileURL,
+					IssuerURL:         action.IssuerURL,
 					IsTrusted:         action.IsTrusted,
 					JSONUserIDPath:    action.JSONUserIDPath,
 					JSONUserNamePath:  action.JSONUserNamePath,
@@ -410,7 +473,12 @@ func MuteUser() web.HandlerFunc {
 
 			c.Enqueue(tasks.NotifyAboutMute(getUser.Result, action.Reason, &expiresAt))
 
-			return c.Ok(web.Map{})
+			escalation, err := applySanctionPolicy(c, getUser.Result)
+			if err != nil {
+				return c.Failure(err)
+			}
+
+			return c.Ok(web.Map{"escalation": escalation})
 		})
 	}
 }
@@ -454,7 +522,161 @@ func WarnUser() web.HandlerFunc {
 
 			c.Enqueue(tasks.NotifyAboutWarning(getUser.Result, action.Reason, &expiresAt))
 
+			escalation, err := applySanctionPolicy(c, getUser.Result)
+			if err != nil {
+				return c.Failure(err)
+			}
+
+			return c.Ok(web.Map{"escalation": escalation})
+		})
+	}
+}
+
+// RestrictUser shadow-bans a user or puts them on probation
+func RestrictUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		userID, err := c.ParamAsInt("userID")
+		if err != nil {
+			return c.BadRequest(web.Map{
+				"message": "Invalid user ID",
+			})
+		}
+
+		action := new(actions.RestrictUser)
+		action.UserID = userID
+
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		return c.WithTransaction(func() error {
+			restrictUser := &cmd.RestrictUser{
+				UserID:    userID,
+				Kind:      action.Kind,
+				Reason:    action.Reason,
+				ExpiresAt: time.Now().Add(time.Duration(action.Duration) * time.Minute),
+			}
+			if err := bus.Dispatch(c, restrictUser); err != nil {
+				return c.Failure(err)
+			}
+
+			if action.Kind == entity.RestrictionProbation {
+				getUser := &query.GetUserByID{UserID: userID}
+				if err := bus.Dispatch(c, getUser); err != nil {
+					return c.Failure(err)
+				}
+				c.Enqueue(tasks.NotifyAboutProbation(getUser.Result, action.Reason))
+			}
+
+			return c.Ok(web.Map{"id": restrictUser.Result})
+		})
+	}
+}
+
+// LiftRestriction ends a shadow-ban or probation before it expires
+func LiftRestriction() web.HandlerFunc {
+	return func(c *web.Context) error {
+		userID, err := c.ParamAsInt("userID")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		restrictionID, err := c.ParamAsInt("restrictionID")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		action := &actions.LiftRestriction{UserID: userID, RestrictionID: restrictionID}
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		return c.WithTransaction(func() error {
+			if err := bus.Dispatch(c, &cmd.LiftRestriction{
+				UserID:        userID,
+				RestrictionID: restrictionID,
+			}); err != nil {
+				return c.Failure(err)
+			}
+
+			// revealed posts change the listings and counters
+			postcache.InvalidateTenantRankings(c.Tenant().ID)
+			postcache.InvalidateCountPerStatus(c.Tenant().ID)
+
 			return c.Ok(web.Map{})
 		})
 	}
 }
+
+// applySanctionPolicy evaluates the tenant sanctions ladder after a warning or mute was issued
+// and applies the step the user has reached, if any. It returns the applied rule or nil.
+func applySanctionPolicy(c *web.Context, user *entity.User) (*entity.SanctionRule, error) {
+	settings := c.Tenant().GeneralSettings
+	if settings == nil || !settings.SanctionPolicy.Enabled {
+		return nil, nil
+	}
+
+	getHistory := &query.GetUserSanctionHistory{UserID: user.ID}
+	if err := bus.Dispatch(c, getHistory); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	due := settings.SanctionPolicy.Due(getHistory.Result, now)
+	if due == nil {
+		return nil, nil
+	}
+
+	reason := "Automatic sanction: " + describeSanctionRule(due)
+
+	switch due.Action {
+	case entity.SanctionActionMute:
+		minutes, ok := utils.ParseDuration(due.Duration)
+		if !ok || minutes < 1 {
+			return nil, nil
+		}
+
+		// never shorten nor repeat a mute that already covers this step
+		expiresAt := now.Add(time.Duration(minutes) * time.Minute)
+		if mutedUntil := getHistory.Result.MutedUntil; mutedUntil != nil && !mutedUntil.Before(expiresAt.Add(-time.Minute)) {
+			return nil, nil
+		}
+
+		if err := bus.Dispatch(c, &cmd.MuteUser{
+			UserID:    user.ID,
+			Reason:    reason,
+			ExpiresAt: expiresAt,
+		}); err != nil {
+			return nil, err
+		}
+		c.Enqueue(tasks.NotifyAboutMute(user, reason, &expiresAt))
+
+	case entity.SanctionActionProposeBlock:
+		proposeBlock := &cmd.ProposeUserBlock{
+			UserID: user.ID,
+			Reason: reason,
+		}
+		if err := bus.Dispatch(c, proposeBlock); err != nil {
+			return nil, err
+		}
+		if !proposeBlock.Result {
+			return nil, nil
+		}
+		c.Enqueue(tasks.NotifyAboutBlockProposal(user, reason))
+
+	default:
+		return nil, nil
+	}
+
+	return due, nil
+}
+
+func describeSanctionRule(rule *entity.SanctionRule) string {
+	if rule.Trigger == entity.SanctionTriggerWarnings {
+		return fmt.Sprintf("%d active warnings", rule.Count)
+	}
+	if rule.WindowDays > 0 {
+		return fmt.Sprintf("%d mutes in %d days", rule.Count, rule.WindowDays)
+	}
+	return fmt.Sprintf("%d mutes", rule.Count)
+}
@@ -0,0 +1,82 @@
+package apiv1
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+type createdOAuthClientResponse struct {
+	*entity.OAuthClient
+	ClientSecret string `json:"clientSecret,omitempty"`
+}
+
+// ListOAuthClients returns the third-party apps registered on the site
+func ListOAuthClients() web.HandlerFunc {
+	return func(c *web.Context) error {
+		clients := &query.ListOAuthClients{}
+		if err := bus.Dispatch(c, clients); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(clients.Result)
+	}
+}
+
+// CreateOAuthClient registers a third-party app, the secret of confidential clients is only returned this once
+func CreateOAuthClient() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateOAuthClient)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		create := &cmd.CreateOAuthClient{
+			ClientID:     rand.String(32),
+			Name:         action.Name,
+			RedirectURIs: action.RedirectURIs,
+			Scopes:       action.Scopes,
+		}
+
+		var secret string
+		if action.IsConfidential {
+			secret = webutil.GenerateOAuth2Secret()
+			create.SecretHash = webutil.HashOAuth2Secret(secret)
+		}
+
+		if err := bus.Dispatch(c, create); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(&createdOAuthClientResponse{
+			OAuthClient:  create.Result,
+			ClientSecret: secret,
+		})
+	}
+}
+
+// DeleteOAuthClient removes a third-party app, its tokens stop working right away
+func DeleteOAuthClient() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteOAuthClient{ID: id}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -314,14 +314,20 @@ func AddPageComment() web.HandlerFunc {
 			}
 
 			addCmd := &cmd.AddPageComment{
-				Page:    getPage.Result,
-				Content: action.Content,
+				Page:              getPage.Result,
+				Content:           action.Content,
+				ShadowHidden:      c.User().IsShadowBanned(),
+				ModerationPending: c.User().IsOnProbation(),
 			}
 
 			if err := bus.Dispatch(c, addCmd); err != nil {
 				return c.Failure(err)
 			}
 
+			if addCmd.ModerationPending {
+				c.Enqueue(tasks.HoldForProbationReview("comment", addCmd.Result.ID))
+			}
+
 			return c.Ok(addCmd.Result)
 		})
 	}
@@ -213,8 +213,10 @@ func CreatePost() web.HandlerFunc {
 			}
 
 			newPost := &cmd.AddNewPost{
-				Title:       action.Title,
-				Description: action.Description,
+				Title:             action.Title,
+				Description:       action.Description,
+				ShadowHidden:      c.User().IsShadowBanned(),
+				ModerationPending: c.User().IsOnProbation(),
 			}
 			if err := bus.Dispatch(c, newPost); err != nil {
 				return c.Failure(err)
@@ -243,7 +245,12 @@ func CreatePost() web.HandlerFunc {
 				})
 			}
 
-			c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
+			// held and shadow-hidden posts must not reach subscribers or webhooks
+			if newPost.ModerationPending {
+				c.Enqueue(tasks.HoldForProbationReview("post", newPost.Result.ID))
+			} else if !newPost.ShadowHidden {
+				c.Enqueue(tasks.NotifyAboutNewPost(newPost.Result))
+			}
 
 			if env.IsOpenAIModerationEnabled() {
 				blobKeys := make([]string, 0)
@@ -530,60 +537,11 @@ func PostComment() web.HandlerFunc {
 		}
 
 		return c.WithTransaction(func() error {
-			if err := bus.Dispatch(c, &cmd.UploadImages{Images: action.Attachments, Folder: "attachments"}); err != nil {
-				return c.Failure(err)
-			}
-
-			contentToSave := entity.CommentString(action.Content).FormatMentionJson(func(mention entity.Mention) string {
-				nameJSON, _ := json.Marshal(mention.Name)
-				return fmt.Sprintf(`{"id":%d,"name":%s}`, mention.ID, string(nameJSON))
-			})
-
-			addNewComment := &cmd.AddNewComment{
-				Post:    getPost.Result,
-				Content: contentToSave,
-			}
-			if err := bus.Dispatch(c, addNewComment); err != nil {
-				return c.Failure(err)
-			}
-
-			if err := bus.Dispatch(c, &cmd.SetAttachments{
-				Post:        getPost.Result,
-				Comment:     addNewComment.Result,
-				Attachments: action.Attachments,
-			}); err != nil {
+			comment, err := addComment(c, getPost.Result, action)
+			if err != nil {
 				return c.Failure(err)
 			}
 
-			commentForNotification := &entity.Comment{
-				ID:      addNewComment.Result.ID,
-				Content: action.Content,
-				User:    addNewComment.Result.User,
-			}
-			c.Enqueue(tasks.NotifyAboutNewComment(commentForNotification, getPost.Result))
-
-			if env.IsOpenAIModerationEnabled() {
-				blobKeys := make([]string, 0)
-				for _, att := range action.Attachments {
-					if att.BlobKey != "" && !att.Remove {
-						blobKeys = append(blobKeys, att.BlobKey)
-					}
-				}
-				c.Enqueue(tasks.ModerateNewContent("comment", addNewComment.Result.ID, action.Content, blobKeys))
-			}
-
-			if getPost.Result.Status == enum.PostArchived {
-				unarchiveCmd := &cmd.UnarchivePost{Post: getPost.Result, Reason: "New comment"}
-				if err := bus.Dispatch(c, unarchiveCmd); err != nil {
-					return c.Failure(err)
-				}
-				postcache.InvalidateCountPerStatus(c.Tenant().ID)
-			}
-
-			postcache.InvalidateTenantRankings(c.Tenant().ID)
-
-			metrics.TotalComments.Inc()
-
 			attachmentBKeys := make([]string, 0)
 			for _, att := range action.Attachments {
 				if att.BlobKey != "" && !att.Remove {
@@ -592,13 +550,78 @@ func PostComment() web.HandlerFunc {
 			}
 
 			return c.Ok(web.Map{
-				"id":          addNewComment.Result.ID,
+				"id":          comment.ID,
 				"attachments": attachmentBKeys,
 			})
 		})
 	}
 }
 
+// addComment stores a new comment from the current user on post and schedules its notifications and moderation
+func addComment(c *web.Context, post *entity.Post, action *actions.AddNewComment) (*entity.Comment, error) {
+	if err := bus.Dispatch(c, &cmd.UploadImages{Images: action.Attachments, Folder: "attachments"}); err != nil {
+		return nil, err
+	}
+
+	contentToSave := entity.CommentString(action.Content).FormatMentionJson(func(mention entity.Mention) string {
+		nameJSON, _ := json.Marshal(mention.Name)
+		return fmt.Sprintf(`{"id":%d,"name":%s}`, mention.ID, string(nameJSON))
+	})
+
+	addNewComment := &cmd.AddNewComment{
+		Post:              post,
+		Content:           contentToSave,
+		ShadowHidden:      c.User().IsShadowBanned(),
+		ModerationPending: c.User().IsOnProbation(),
+	}
+	if err := bus.Dispatch(c, addNewComment); err != nil {
+		return nil, err
+	}
+
+	if err := bus.Dispatch(c, &cmd.SetAttachments{
+		Post:        post,
+		Comment:     addNewComment.Result,
+		Attachments: action.Attachments,
+	}); err != nil {
+		return nil, err
+	}
+
+	commentForNotification := &entity.Comment{
+		ID:      addNewComment.Result.ID,
+		Content: action.Content,
+		User:    addNewComment.Result.User,
+	}
+	if addNewComment.ModerationPending {
+		c.Enqueue(tasks.HoldForProbationReview("comment", addNewComment.Result.ID))
+	} else if !addNewComment.ShadowHidden {
+		c.Enqueue(tasks.NotifyAboutNewComment(commentForNotification, post))
+	}
+
+	if env.IsOpenAIModerationEnabled() {
+		blobKeys := make([]string, 0)
+		for _, att := range action.Attachments {
+			if att.BlobKey != "" && !att.Remove {
+				blobKeys = append(blobKeys, att.BlobKey)
+			}
+		}
+		c.Enqueue(tasks.ModerateNewContent("comment", addNewComment.Result.ID, action.Content, blobKeys))
+	}
+
+	if post.Status == enum.PostArchived {
+		unarchiveCmd := &cmd.UnarchivePost{Post: post, Reason: "New comment"}
+		if err := bus.Dispatch(c, unarchiveCmd); err != nil {
+			return nil, err
+		}
+		postcache.InvalidateCountPerStatus(c.Tenant().ID)
+	}
+
+	postcache.InvalidateTenantRankings(c.Tenant().ID)
+
+	metrics.TotalComments.Inc()
+
+	return addNewComment.Result, nil
+}
+
 // UpdateComment changes an existing comment with new content
 func UpdateComment() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -653,7 +676,9 @@ func UpdateComment() web.HandlerFunc {
 			}
 
 			// Update the content
-			c.Enqueue(tasks.NotifyAboutUpdatedComment(contentToSave, getPost.Result, action.ID))
+			if !c.User().IsShadowBanned() && !c.User().IsOnProbation() {
+				c.Enqueue(tasks.NotifyAboutUpdatedComment(contentToSave, getPost.Result, action.ID))
+			}
 
 			return c.Ok(web.Map{})
 		})
@@ -844,6 +869,15 @@ func Unsubscribe() web.HandlerFunc {
 	}
 }
 
+// Mute silences all notifications about given post for current user, until they subscribe or unsubscribe again
+func Mute() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return addOrRemove(c, func(post *entity.Post, user *entity.User) bus.Msg {
+			return &cmd.MuteSubscriber{Post: post, User: user}
+		})
+	}
+}
+
 // ListVotes returns a list of all votes on given post
 func ListVotes() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -914,6 +948,9 @@ func LockOrUnlockPost() web.HandlerFunc {
 				if err := bus.Dispatch(c, lockPost); err != nil {
 					return c.Failure(err)
 				}
+
+				c.Enqueue(tasks.NotifyAboutLockedPost(action.Post, action.LockMessage))
+
 				return c.Ok(web.Map{})
 			})
 		} else if c.Request.Method == "DELETE" {
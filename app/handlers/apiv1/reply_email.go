@@ -0,0 +1,138 @@
+package apiv1
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/replymail"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// IncomingReplyEmail receives replies to comment notifications from a mail relay and posts them as comments
+// Rejected emails are logged and acknowledged so that the relay doesn't retry them
+func IncomingReplyEmail() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if c.Tenant() == nil {
+			return c.NotFound()
+		}
+
+		key := c.QueryParam("key")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(env.Config.Email.Inbound.Secret)) != 1 {
+			return c.Unauthorized()
+		}
+
+		var raw []byte
+		switch c.Param("provider") {
+		case "mailgun":
+			content, err := replymail.FromMailgun(c.Request.GetHeader("Content-Type"), c.Request.Body)
+			if err != nil {
+				return ignoreReplyEmail(c, err.Error())
+			}
+			raw = content
+		case "ses":
+			notification, err := replymail.FromSES(c.Request.Body)
+			if err != nil {
+				return ignoreReplyEmail(c, err.Error())
+			}
+			if notification.SubscribeURL != "" {
+				if err := bus.Dispatch(c, &cmd.HTTPRequest{URL: notification.SubscribeURL, Method: "GET"}); err != nil {
+					return c.Failure(errors.Wrap(err, "failed to confirm SNS subscription"))
+				}
+				return c.Ok(web.Map{})
+			}
+			raw = notification.Content
+		case "generic":
+			raw = []byte(c.Request.Body)
+		default:
+			return c.NotFound()
+		}
+
+		msg, err := replymail.Parse(raw)
+		if err != nil {
+			return ignoreReplyEmail(c, err.Error())
+		}
+
+		token := replymail.Token(msg.Recipients, env.Config.Email.Inbound.ReplyDomain)
+		if token == "" {
+			return ignoreReplyEmail(c, "no reply address in recipients")
+		}
+
+		claims, err := jwt.DecodeReplyClaims(token)
+		if err != nil {
+			return ignoreReplyEmail(c, "invalid reply token")
+		}
+
+		getUser := &query.GetUserByID{UserID: claims.UserID}
+		if err := bus.Dispatch(c, getUser); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return ignoreReplyEmail(c, "user not found")
+			}
+			return c.Failure(err)
+		}
+
+		user := getUser.Result
+		if !strings.EqualFold(user.Email, msg.From) || user.Status != enum.UserActive {
+			return ignoreReplyEmail(c, "sender does not match the reply token")
+		}
+
+		middlewares.SetStandingChecks(c, user)
+		middlewares.SetPermissionLookup(c, user)
+		c.SetUser(user)
+
+		if user.IsMuted() {
+			return ignoreReplyEmail(c, "user is muted")
+		}
+
+		action := &actions.AddNewComment{
+			Number:  claims.PostNumber,
+			Content: replymail.StripQuoted(msg.Text),
+		}
+		if !action.IsAuthorized(c, user) {
+			return ignoreReplyEmail(c, "user is not authorized to comment")
+		}
+		if result := action.Validate(c, user); !result.Ok {
+			if result.Err != nil {
+				return c.Failure(result.Err)
+			}
+			return ignoreReplyEmail(c, "comment is invalid")
+		}
+
+		getPost := &query.GetPostByNumber{Number: action.Number}
+		if err := bus.Dispatch(c, getPost); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return ignoreReplyEmail(c, "post not found")
+			}
+			return c.Failure(err)
+		}
+
+		if getPost.Result.IsLocked() && !(user.IsCollaborator() || user.IsAdministrator()) {
+			return ignoreReplyEmail(c, "post is locked")
+		}
+
+		return c.WithTransaction(func() error {
+			if _, err := addComment(c, getPost.Result, action); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(web.Map{})
+		})
+	}
+}
+
+func ignoreReplyEmail(c *web.Context, reason string) error {
+	log.Warnf(c, "Reply email ignored: @{Reason}", dto.Props{
+		"Reason": reason,
+	})
+	return c.Ok(web.Map{})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/postcache"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/sse"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
 )
 
 // ListTags returns all tags
@@ -38,6 +39,8 @@ func AssignTag() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			c.Enqueue(tasks.NotifyAboutTaggedPost(action.Post, action.Tag))
+
 			if wasUntagged {
 				sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgQueuePostTagged, sse.QueueEventPayload{
 					PostID:         action.Post.ID,
@@ -135,3 +138,38 @@ func DeleteTag() web.HandlerFunc {
 		})
 	}
 }
+
+// ListTagPreferences returns the tags that current user follows or muted
+func ListTagPreferences() web.HandlerFunc {
+	return func(c *web.Context) error {
+		q := &query.GetTagPreferences{}
+		if err := bus.Dispatch(c, q); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(q.Result)
+	}
+}
+
+// SetTagPreference follows, mutes or resets a tag for current user
+func SetTagPreference() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetTagPreference)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		return c.WithTransaction(func() error {
+			err := bus.Dispatch(c, &cmd.SetTagPreference{
+				Tag:        action.Tag,
+				User:       c.User(),
+				Preference: action.Value,
+			})
+			if err != nil {
+				return c.Failure(err)
+			}
+
+			return c.Ok(web.Map{})
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package apiv1
 
 import (
+	"bytes"
+	"encoding/base64"
 	"strconv"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
@@ -11,7 +14,16 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/passkey"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/skip2/go-qrcode"
 )
 
 // ListUsers returns all registered users
@@ -121,7 +133,7 @@ func GetUserProfileStats() web.HandlerFunc {
 		}
 
 		// Regular users can only view their own stats
-		isPrivileged := c.User().Role == enum.RoleAdministrator || c.User().Role == enum.RoleCollaborator || c.User().Role == enum.RoleModerator
+		isPrivileged := isStaffRequest(c)
 		if !isPrivileged && c.User().ID != userID {
 			return c.NotFound()
 		}
@@ -145,7 +157,7 @@ func GetUserProfileStanding() web.HandlerFunc {
 		}
 
 		// Regular users can only view their own standing
-		isPrivileged := c.User().Role == enum.RoleAdministrator || c.User().Role == enum.RoleCollaborator || c.User().Role == enum.RoleModerator
+		isPrivileged := isStaffRequest(c)
 		if !isPrivileged && c.User().ID != userID {
 			return c.NotFound()
 		}
@@ -156,6 +168,26 @@ func GetUserProfileStanding() web.HandlerFunc {
 		if err := bus.Dispatch(c, standing); err != nil {
 			return c.Failure(err)
 		}
+
+		// a shadow-ban only works as long as the user does not know about it
+		if !isPrivileged {
+			visible := make([]*entity.UserRestriction, 0)
+			for _, restriction := range standing.Result.Restrictions {
+				if restriction.Kind != entity.RestrictionShadowBan {
+					visible = append(visible, restriction)
+				}
+			}
+			standing.Result.Restrictions = visible
+		}
+
+		if settings := c.Tenant().GeneralSettings; settings != nil && settings.SanctionPolicy.Enabled {
+			getHistory := &query.GetUserSanctionHistory{UserID: userID}
+			if err := bus.Dispatch(c, getHistory); err != nil {
+				return c.Failure(err)
+			}
+			standing.Result.NextSanction = settings.SanctionPolicy.Next(getHistory.Result, time.Now())
+		}
+
 		return c.Ok(standing.Result)
 	}
 }
@@ -169,7 +201,7 @@ func SearchUserContent() web.HandlerFunc {
 		}
 
 		// Regular users can only search their own content, admins can search any content
-		isPrivileged := c.User().Role == enum.RoleAdministrator || c.User().Role == enum.RoleCollaborator || c.User().Role == enum.RoleModerator
+		isPrivileged := isStaffRequest(c)
 		if !isPrivileged && c.User().ID != userID {
 			return c.NotFound()
 		}
@@ -211,3 +243,618 @@ func SearchUserContent() web.HandlerFunc {
 		return c.Ok(search.Result)
 	}
 }
+
+type notificationPreference struct {
+	Event string `json:"event"`
+	// Channels are all the channels of the event, Editable the ones the user can turn on or off and Enabled the ones they receive it on
+	Channels             []enum.NotificationChannel `json:"channels"`
+	Editable             []enum.NotificationChannel `json:"editable"`
+	Enabled              []enum.NotificationChannel `json:"enabled"`
+	RequiresSubscription bool                       `json:"requiresSubscription"`
+}
+
+func splitChannels(channels enum.NotificationChannel) []enum.NotificationChannel {
+	result := make([]enum.NotificationChannel, 0)
+	for _, channel := range enum.AllNotificationChannels {
+		if channels&channel > 0 {
+			result = append(result, channel)
+		}
+	}
+	return result
+}
+
+// getNotificationPreferences returns the notification events available to the current user and the channels they receive each on
+func getNotificationPreferences(c *web.Context) ([]*notificationPreference, error) {
+	settings := &query.GetCurrentUserSettings{}
+	if err := bus.Dispatch(c, settings); err != nil {
+		return nil, err
+	}
+
+	role := c.User().Role
+	preferences := make([]*notificationPreference, 0)
+	for _, event := range enum.AllNotificationEvents {
+		if !event.IsAvailableFor(role) {
+			continue
+		}
+
+		enabled, _ := strconv.Atoi(settings.Result[event.UserSettingsKeyName])
+		requiresSubscription := false
+		for _, r := range event.RequiresSubscriptionUserRoles {
+			if r == role {
+				requiresSubscription = true
+			}
+		}
+
+		preferences = append(preferences, &notificationPreference{
+			Event:                event.UserSettingsKeyName,
+			Channels:             splitChannels(event.Channels),
+			Editable:             splitChannels(event.ChannelsFor(role)),
+			Enabled:              splitChannels(enum.NotificationChannel(enabled)),
+			RequiresSubscription: requiresSubscription,
+		})
+	}
+	return preferences, nil
+}
+
+// GetNotificationPreferences returns on which channels the current user receives each notification event
+func GetNotificationPreferences() web.HandlerFunc {
+	return func(c *web.Context) error {
+		preferences, err := getNotificationPreferences(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(preferences)
+	}
+}
+
+// UpdateNotificationPreferences changes on which channels the current user receives notification events
+func UpdateNotificationPreferences() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UpdateNotificationPreferences)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		current := &query.GetCurrentUserSettings{}
+		if err := bus.Dispatch(c, current); err != nil {
+			return c.Failure(err)
+		}
+
+		settings := make(map[string]string)
+		for i, event := range action.Events {
+			var requested enum.NotificationChannel
+			for _, channel := range action.Preferences[i].Channels {
+				requested |= channel
+			}
+
+			// channels the user can't turn on or off keep their current value
+			editable := event.ChannelsFor(c.User().Role)
+			value, _ := strconv.Atoi(current.Result[event.UserSettingsKeyName])
+			channels := requested&editable | enum.NotificationChannel(value)&^editable
+			settings[event.UserSettingsKeyName] = strconv.Itoa(int(channels))
+		}
+
+		return c.WithTransaction(func() error {
+			if err := bus.Dispatch(c, &cmd.UpdateCurrentUserSettings{Settings: settings}); err != nil {
+				return c.Failure(err)
+			}
+
+			preferences, err := getNotificationPreferences(c)
+			if err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(preferences)
+		})
+	}
+}
+
+type userSessionResponse struct {
+	*entity.UserSession
+	Current bool `json:"current"`
+}
+
+// ListUserSessions returns the browsers the current user is signed in on
+func ListUserSessions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		sessions := &query.ListUserSessions{UserID: c.User().ID}
+		if err := bus.Dispatch(c, sessions); err != nil {
+			return c.Failure(err)
+		}
+
+		current := c.UserSession()
+		result := make([]*userSessionResponse, len(sessions.Result))
+		for i, session := range sessions.Result {
+			result[i] = &userSessionResponse{
+				UserSession: session,
+				Current:     current != nil && current.ID == session.ID,
+			}
+		}
+		return c.Ok(result)
+	}
+}
+
+// RevokeUserSessions signs the current user out of every browser except the one making the request
+func RevokeUserSessions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		var except string
+		if current := c.UserSession(); current != nil {
+			except = current.SessionID
+		}
+
+		if err := webutil.RevokeUserSessions(c, c.User().ID, except); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// RevokeUserSession signs the current user out of one of their browsers
+func RevokeUserSession() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := webutil.RevokeUserSession(c, c.User().ID, id); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		if current := c.UserSession(); current != nil && current.ID == id {
+			c.RemoveCookie(web.CookieAuthName)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+type createdAPITokenResponse struct {
+	*entity.APIToken
+	Token string `json:"token"`
+}
+
+// ListAPITokens returns the personal API tokens of the current user
+func ListAPITokens() web.HandlerFunc {
+	return func(c *web.Context) error {
+		tokens := &query.ListAPITokens{UserID: c.User().ID}
+		if err := bus.Dispatch(c, tokens); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(tokens.Result)
+	}
+}
+
+// CreateAPIToken creates a personal API token for the current user, the token itself is only returned this once
+func CreateAPIToken() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateAPIToken)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		token, prefix := webutil.GenerateAPIToken()
+		create := &cmd.CreateAPIToken{
+			UserID:    c.User().ID,
+			Name:      action.Name,
+			TokenHash: webutil.HashAPIToken(token),
+			Prefix:    prefix,
+			Scopes:    action.Scopes,
+		}
+		if action.ExpiresInDays > 0 {
+			expiresAt := time.Now().AddDate(0, 0, action.ExpiresInDays)
+			create.ExpiresAt = &expiresAt
+		}
+
+		if err := bus.Dispatch(c, create); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(&createdAPITokenResponse{
+			APIToken: create.Result,
+			Token:    token,
+		})
+	}
+}
+
+// DeleteAPIToken deletes one of the personal API tokens of the current user, it stops working right away
+func DeleteAPIToken() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteAPIToken{UserID: c.User().ID, ID: id}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// RequestUserDataExport starts assembling a copy of the current user's personal data, a download link is sent by email
+func RequestUserDataExport() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RequestUserDataExport)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		// an export that fails to be assembled is still purged once this expires
+		create := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(entity.UserDataExportLifetime)}
+		if err := bus.Dispatch(c, create); err != nil {
+			return c.Failure(err)
+		}
+
+		c.Enqueue(tasks.ExportUserData(create.Result))
+
+		return c.Ok(web.Map{})
+	}
+}
+
+type userProviderResponse struct {
+	Provider    string `json:"provider"`
+	DisplayName string `json:"displayName"`
+	CanUnlink   bool   `json:"canUnlink"`
+}
+
+// ListUserProviders returns the OAuth identities linked to the current user
+func ListUserProviders() web.HandlerFunc {
+	return func(c *web.Context) error {
+		providers := &query.ListAllOAuthProviders{}
+		if err := bus.Dispatch(c, providers); err != nil {
+			return c.Failure(err)
+		}
+
+		displayNames := make(map[string]string, len(providers.Result))
+		for _, p := range providers.Result {
+			displayNames[p.Provider] = p.DisplayName
+		}
+
+		result := make([]*userProviderResponse, 0, len(c.User().Providers))
+		for _, p := range c.User().Providers {
+			unlink := &actions.UnlinkUserProvider{Provider: p.Name}
+			validation := unlink.Validate(c, c.User())
+			if validation.Err != nil {
+				return c.Failure(validation.Err)
+			}
+
+			displayName := displayNames[p.Name]
+			if displayName == "" {
+				displayName = p.Name
+			}
+			result = append(result, &userProviderResponse{
+				Provider:    p.Name,
+				DisplayName: displayName,
+				CanUnlink:   validation.Ok,
+			})
+		}
+		return c.Ok(result)
+	}
+}
+
+// UnlinkUserProvider removes an OAuth identity from the current user, as long as they can still sign in some other way
+func UnlinkUserProvider() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.UnlinkUserProvider)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.UnregisterUserProvider{
+			UserID:       c.User().ID,
+			ProviderName: action.Provider,
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+type twoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
+
+// GetTwoFactorStatus returns whether the current user has enabled two-factor authentication
+func GetTwoFactorStatus() web.HandlerFunc {
+	return func(c *web.Context) error {
+		user := c.User()
+		response := &twoFactorStatusResponse{
+			Required: c.Tenant().GeneralSettings.RequiresTwoFactor(user.Role),
+		}
+
+		twoFactor, err := getTwoFactor(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if twoFactor.IsEnabled() {
+			countCodes := &query.CountUnusedRecoveryCodes{UserID: user.ID}
+			if err := bus.Dispatch(c, countCodes); err != nil {
+				return c.Failure(err)
+			}
+			response.Enabled = true
+			response.RecoveryCodesLeft = countCodes.Result
+		}
+
+		return c.Ok(response)
+	}
+}
+
+// StartTwoFactorEnrollment generates a new secret for the current user to add to their authenticator app
+// It only protects sign-ins once a code of the app has been confirmed with EnableTwoFactor
+func StartTwoFactorEnrollment() web.HandlerFunc {
+	return func(c *web.Context) error {
+		user := c.User()
+
+		twoFactor, err := getTwoFactor(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if twoFactor.IsEnabled() {
+			return c.BadRequest(web.Map{})
+		}
+
+		secret := totp.GenerateSecret()
+		if err := bus.Dispatch(c, &cmd.SaveUserTwoFactorSecret{UserID: user.ID, Secret: secret}); err != nil {
+			return c.Failure(err)
+		}
+
+		account := user.Email
+		if account == "" {
+			account = user.Name
+		}
+		url := totp.URL(c.Tenant().Name, account, secret)
+
+		png, err := qrcode.Encode(url, qrcode.Medium, 256)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"secret": secret,
+			"url":    url,
+			"qrCode": "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+		})
+	}
+}
+
+// EnableTwoFactor confirms the enrollment of the current user and returns their recovery codes, which are only shown once
+func EnableTwoFactor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.EnableTwoFactor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		codes := webutil.GenerateRecoveryCodes()
+		if err := bus.Dispatch(c, &cmd.EnableUserTwoFactor{
+			UserID:             c.User().ID,
+			Step:               action.Step,
+			RecoveryCodeHashes: webutil.HashRecoveryCodes(codes),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"recoveryCodes": codes,
+		})
+	}
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user, the previous ones stop working right away
+func RegenerateRecoveryCodes() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.ConfirmTwoFactor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if result := checkTwoFactorCode(c, action.Code); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		codes := webutil.GenerateRecoveryCodes()
+		if err := bus.Dispatch(c, &cmd.ReplaceUserRecoveryCodes{
+			UserID:             c.User().ID,
+			RecoveryCodeHashes: webutil.HashRecoveryCodes(codes),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"recoveryCodes": codes,
+		})
+	}
+}
+
+// DisableTwoFactor turns two-factor authentication off for the current user
+func DisableTwoFactor() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DisableTwoFactor)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if result := checkTwoFactorCode(c, action.Code); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DisableUserTwoFactor{UserID: c.User().ID}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// getTwoFactor returns the two-factor setup of the current user, or nil if they never started one
+func getTwoFactor(c *web.Context) (*entity.UserTwoFactor, error) {
+	getTwoFactor := &query.GetUserTwoFactor{UserID: c.User().ID}
+	if err := bus.Dispatch(c, getTwoFactor); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return getTwoFactor.Result, nil
+}
+
+// checkTwoFactorCode confirms a sensitive change with a code of the authenticator app or a recovery code
+func checkTwoFactorCode(c *web.Context, code string) *validate.Result {
+	twoFactor, err := getTwoFactor(c)
+	if err != nil {
+		return validate.Error(err)
+	}
+
+	ok, err := webutil.CheckTwoFactorCode(c, twoFactor, code)
+	if err != nil {
+		return validate.Error(err)
+	}
+	if !ok {
+		result := validate.Success()
+		result.AddFieldFailure("code", i18n.T(c, "validation.custom.invalidtwofactorcode"))
+		return result
+	}
+	return validate.Success()
+}
+
+// ListUserPasskeys returns the passkeys of the current user
+func ListUserPasskeys() web.HandlerFunc {
+	return func(c *web.Context) error {
+		passkeys := &query.ListUserPasskeys{UserID: c.User().ID}
+		if err := bus.Dispatch(c, passkeys); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(passkeys.Result)
+	}
+}
+
+// BeginPasskeyRegistration returns the options the authenticator of the current user needs to create a passkey
+func BeginPasskeyRegistration() web.HandlerFunc {
+	return func(c *web.Context) error {
+		user, err := getPasskeyUser(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		relyingParty, err := passkey.New(c.Tenant().Name, c.BaseURL())
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		exclusions := webauthn.Credentials(user.Credentials).CredentialDescriptors()
+		creation, session, err := relyingParty.BeginRegistration(user, webauthn.WithExclusions(exclusions))
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := webutil.StartPasskeyChallenge(c, session); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(creation)
+	}
+}
+
+// FinishPasskeyRegistration checks the answer of the authenticator and saves the new passkey of the current user
+func FinishPasskeyRegistration() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.RegisterPasskey)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		invalidPasskey := validate.Failed(i18n.T(c, "validation.custom.invalidpasskey"))
+
+		session := webutil.GetPasskeyChallenge(c)
+		if session == nil || !bytes.Equal(session.UserID, passkey.UserHandle(c.User().ID)) {
+			return c.HandleValidation(invalidPasskey)
+		}
+		webutil.EndPasskeyChallenge(c)
+
+		parsed, err := protocol.ParseCredentialCreationResponseBytes(action.Credential)
+		if err != nil {
+			return c.HandleValidation(invalidPasskey)
+		}
+
+		user, err := getPasskeyUser(c)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		relyingParty, err := passkey.New(c.Tenant().Name, c.BaseURL())
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		credential, err := relyingParty.CreateCredential(user, *session, parsed)
+		if err != nil {
+			return c.HandleValidation(invalidPasskey)
+		}
+
+		data, err := passkey.Marshal(credential)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		addPasskey := &cmd.AddUserPasskey{
+			UserID:       user.User.ID,
+			Name:         action.Name,
+			CredentialID: passkey.CredentialID(credential.ID),
+			Credential:   data,
+		}
+		if err := bus.Dispatch(c, addPasskey); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(addPasskey.Result)
+	}
+}
+
+// DeleteUserPasskey removes a passkey of the current user, as long as they can still sign in some other way
+func DeleteUserPasskey() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.DeletePasskey)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteUserPasskey{UserID: c.User().ID, ID: action.ID}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// getPasskeyUser returns the current user with their passkeys
+func getPasskeyUser(c *web.Context) (*passkey.User, error) {
+	passkeys := &query.ListUserPasskeys{UserID: c.User().ID}
+	if err := bus.Dispatch(c, passkeys); err != nil {
+		return nil, err
+	}
+	return passkey.NewUser(c.User(), passkeys.Result)
+}
+
+// isStaffRequest returns true if the current user is staff
+// and the request isn't made with a personal API token lacking the moderate scope
+func isStaffRequest(c *web.Context) bool {
+	isStaff := c.User().Role == enum.RoleAdministrator || c.User().Role == enum.RoleCollaborator || c.User().Role == enum.RoleModerator
+	token := c.APIToken()
+	return isStaff && (token == nil || token.HasScope(enum.APITokenScopeModerate))
+}
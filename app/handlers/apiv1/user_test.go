@@ -2,8 +2,11 @@ package apiv1_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 
@@ -15,6 +18,8 @@ import (
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
 
 func TestListUsersHandler(t *testing.T) {
@@ -186,3 +191,195 @@ func TestCreateUser_NewUser(t *testing.T) {
 	theOtherUserID := query.Int32("id")
 	Expect(theOtherUserID).Equals(userID)
 }
+
+func TestListUserSessionsHandler(t *testing.T) {
+	RegisterT(t)
+
+	current := &entity.UserSession{ID: 1, UserID: mock.JonSnow.ID, SessionID: "session-1", UserAgent: "Firefox"}
+	other := &entity.UserSession{ID: 2, UserID: mock.JonSnow.ID, SessionID: "session-2", UserAgent: "Safari"}
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListUserSessions) error {
+		Expect(q.UserID).Equals(mock.JonSnow.ID)
+		q.Result = []*entity.UserSession{current, other}
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithUserSession(current).
+		Execute(apiv1.ListUserSessions())
+
+	Expect(status).Equals(http.StatusOK)
+
+	var sessions []map[string]any
+	Expect(json.Unmarshal(response.Body.Bytes(), &sessions)).IsNil()
+	Expect(sessions).HasLen(2)
+	Expect(sessions[0]["userAgent"]).Equals("Firefox")
+	Expect(sessions[0]["current"]).Equals(true)
+	Expect(sessions[1]["current"]).Equals(false)
+	Expect(sessions[0]["sessionId"]).IsNil()
+}
+
+func TestRevokeUserSessionsHandler_KeepsCurrentSession(t *testing.T) {
+	RegisterT(t)
+
+	var revoke *cmd.RevokeUserSessions
+	bus.AddHandler(func(ctx context.Context, c *cmd.RevokeUserSessions) error {
+		revoke = c
+		c.Result = []string{"session-2"}
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithUserSession(&entity.UserSession{ID: 1, UserID: mock.JonSnow.ID, SessionID: "session-1"}).
+		Execute(apiv1.RevokeUserSessions())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(revoke.UserID).Equals(mock.JonSnow.ID)
+	Expect(revoke.ExceptSessionID).Equals("session-1")
+}
+
+func TestRevokeUserSessionHandler_CurrentSession_RemovesCookie(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RevokeUserSession) error {
+		Expect(c.UserID).Equals(mock.JonSnow.ID)
+		Expect(c.ID).Equals(1)
+		c.Result = "session-1"
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithUserSession(&entity.UserSession{ID: 1, UserID: mock.JonSnow.ID, SessionID: "session-1"}).
+		AddParam("id", 1).
+		Execute(apiv1.RevokeUserSession())
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Header().Get("Set-Cookie")).ContainsSubstring(web.CookieAuthName + "=;")
+}
+
+func TestRevokeUserSessionHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RevokeUserSession) error {
+		return app.ErrNotFound
+	})
+
+	server := mock.NewServer()
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddHeader("Accept", "application/json").
+		AddParam("id", 5).
+		Execute(apiv1.RevokeUserSession())
+
+	Expect(status).Equals(http.StatusNotFound)
+}
+
+func TestCreateAPITokenHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.ListAPITokens) error {
+		return nil
+	})
+
+	var create *cmd.CreateAPIToken
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateAPIToken) error {
+		create = c
+		c.Result = &entity.APIToken{ID: 1, UserID: c.UserID, Name: c.Name, Prefix: c.Prefix, Scopes: c.Scopes, ExpiresAt: c.ExpiresAt}
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePostAsJSON(apiv1.CreateAPIToken(), `{ "name": "My Bot", "scopes": ["read:posts"], "expiresInDays": 7 }`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(create.UserID).Equals(mock.AryaStark.ID)
+	Expect(create.Name).Equals("My Bot")
+	Expect(create.ExpiresAt).IsNotNil()
+
+	token := query.String("token")
+	Expect(strings.HasPrefix(token, create.Prefix)).IsTrue()
+	Expect(create.TokenHash).Equals(webutil.HashAPIToken(token))
+	Expect(create.TokenHash).NotEquals(token)
+	Expect(query.String("scopes[0]")).Equals("read:posts")
+}
+
+func TestDeleteAPITokenHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteAPIToken) error {
+		Expect(c.UserID).Equals(mock.AryaStark.ID)
+		return app.ErrNotFound
+	})
+
+	server := mock.NewServer()
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		AddParam("id", 2).
+		AddHeader("Accept", "application/json").
+		Execute(apiv1.DeleteAPIToken())
+
+	Expect(status).Equals(http.StatusNotFound)
+}
+
+func TestRequestUserDataExportHandler(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetLatestUserDataExport) error {
+		return app.ErrNotFound
+	})
+
+	var create *cmd.CreateUserDataExport
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserDataExport) error {
+		create = c
+		c.Result = &entity.UserDataExport{ID: 1, UserID: mock.AryaStark.ID, ExpiresAt: c.ExpiresAt}
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.RequestUserDataExport(), `{}`)
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(create).IsNotNil()
+	Expect(create.ExpiresAt.After(time.Now().Add(entity.UserDataExportLifetime - time.Minute))).IsTrue()
+}
+
+func TestRequestUserDataExportHandler_TooSoon(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetLatestUserDataExport) error {
+		q.Result = &entity.UserDataExport{ID: 1, UserID: mock.AryaStark.ID, CreatedAt: time.Now().Add(-time.Hour)}
+		return nil
+	})
+
+	created := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserDataExport) error {
+		created = true
+		return nil
+	})
+
+	server := mock.NewServer()
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		ExecutePost(apiv1.RequestUserDataExport(), `{}`)
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(created).IsFalse()
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/sse"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+)
+
+// AppealPage is where sanctioned users, including blocked ones, follow and file their appeals
+func AppealPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getAppeals := &query.GetUserAppeals{UserID: c.User().ID}
+		getStanding := &query.GetUserProfileStanding{UserID: c.User().ID}
+		if err := bus.Dispatch(c, getAppeals, getStanding); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Appeal/Appeal.page",
+			Title: "Appeals",
+			Data: web.Map{
+				"appeals":  getAppeals.Result,
+				"warnings": getStanding.Result.Warnings,
+				"mutes":    getStanding.Result.Mutes,
+				"blocked":  c.User().Status == enum.UserBlocked,
+			},
+		})
+	}
+}
+
+// ListUserAppeals returns the appeals filed by the current user
+func ListUserAppeals() web.HandlerFunc {
+	return func(c *web.Context) error {
+		getAppeals := &query.GetUserAppeals{UserID: c.User().ID}
+		if err := bus.Dispatch(c, getAppeals); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(getAppeals.Result)
+	}
+}
+
+// CreateAppeal files an appeal against one of the current user's sanctions
+func CreateAppeal() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateAppeal)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		return c.WithTransaction(func() error {
+			createAppeal := &cmd.CreateAppeal{
+				SanctionType:   action.SanctionType,
+				SanctionID:     action.SanctionID,
+				SanctionReason: action.SanctionReason,
+				Text:           action.Text,
+			}
+			if err := bus.Dispatch(c, createAppeal); err != nil {
+				return c.Failure(err)
+			}
+
+			sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgAppealNew, sse.AppealEventPayload{
+				AppealID:     createAppeal.Result,
+				UserID:       c.User().ID,
+				SanctionType: action.SanctionType,
+				Status:       enum.AppealStatusPending.String(),
+			})
+
+			return c.Ok(web.Map{"id": createAppeal.Result})
+		})
+	}
+}
+
+func ManageAppealsPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageAppeals.page",
+			Title: "Appeals - Site Settings",
+		})
+	}
+}
+
+func ListAppeals() web.HandlerFunc {
+	return func(c *web.Context) error {
+		page, _ := c.QueryParamAsInt("page")
+		perPage, _ := c.QueryParamAsInt("perPage")
+
+		if page < 1 {
+			page = 1
+		}
+		if perPage < 1 || perPage > 50 {
+			perPage = 20
+		}
+
+		var statuses []enum.AppealStatus
+		for _, sp := range strings.Split(c.QueryParam("status"), ",") {
+			sp = strings.TrimSpace(sp)
+			if sp != "" {
+				var status enum.AppealStatus
+				_ = status.UnmarshalText([]byte(sp))
+				if status != 0 {
+					statuses = append(statuses, status)
+				}
+			}
+		}
+
+		listAppeals := &query.ListAppeals{
+			Status:  statuses,
+			Page:    page,
+			PerPage: perPage,
+		}
+		if err := bus.Dispatch(c, listAppeals); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"appeals": listAppeals.Result,
+			"total":   listAppeals.Total,
+			"page":    page,
+			"perPage": perPage,
+		})
+	}
+}
+
+// DecideAppeal upholds, reduces or lifts the sanction of an appeal
+func DecideAppeal() web.HandlerFunc {
+	return func(c *web.Context) error {
+		appealID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getAppeal := &query.GetAppealByID{AppealID: appealID}
+		if err := bus.Dispatch(c, getAppeal); err != nil {
+			return c.NotFound()
+		}
+
+		action := new(actions.DecideAppeal)
+		action.AppealID = appealID
+		action.Appeal = getAppeal.Result
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		var status enum.AppealStatus
+		_ = status.UnmarshalText([]byte(action.Status))
+
+		return c.WithTransaction(func() error {
+			appeal := action.Appeal
+			decide := &cmd.DecideAppeal{
+				AppealID:     appeal.ID,
+				Status:       status,
+				Note:         action.Note,
+				ReducedUntil: action.ReducedUntil,
+			}
+
+			switch status {
+			case enum.AppealStatusLifted:
+				if err := liftAppealedSanction(c, appeal); err != nil {
+					return c.Failure(err)
+				}
+			case enum.AppealStatusReduced:
+				replacementID, err := reduceAppealedSanction(c, appeal, action)
+				if err != nil {
+					return c.Failure(err)
+				}
+				decide.ReplacementID = replacementID
+			}
+
+			if err := bus.Dispatch(c, decide); err != nil {
+				return c.Failure(err)
+			}
+
+			c.Enqueue(tasks.NotifyAboutAppealDecision(appeal, status, action.Note))
+
+			sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgAppealDecided, sse.AppealEventPayload{
+				AppealID:     appeal.ID,
+				UserID:       appeal.User.ID,
+				SanctionType: appeal.SanctionType,
+				Status:       action.Status,
+			})
+
+			return c.Ok(web.Map{})
+		})
+	}
+}
+
+func liftAppealedSanction(c *web.Context, appeal *entity.Appeal) error {
+	switch appeal.SanctionType {
+	case entity.AppealSanctionWarning:
+		return bus.Dispatch(c, &cmd.ExpireWarning{UserID: appeal.User.ID, WarningID: appeal.SanctionID})
+	case entity.AppealSanctionMute:
+		return bus.Dispatch(c, &cmd.ExpireMute{UserID: appeal.User.ID, MuteID: appeal.SanctionID})
+	default:
+		return bus.Dispatch(c, &cmd.UnblockUser{UserID: appeal.User.ID})
+	}
+}
+
+// reduceAppealedSanction expires the appealed warning or mute and issues a shorter one in its place
+func reduceAppealedSanction(c *web.Context, appeal *entity.Appeal, action *actions.DecideAppeal) (int, error) {
+	if err := liftAppealedSanction(c, appeal); err != nil {
+		return 0, err
+	}
+
+	if appeal.SanctionType == entity.AppealSanctionWarning {
+		warnUser := &cmd.WarnUser{UserID: appeal.User.ID, Reason: appeal.SanctionReason, ExpiresAt: *action.ReducedUntil}
+		if err := bus.Dispatch(c, warnUser); err != nil {
+			return 0, err
+		}
+		return warnUser.Result, nil
+	}
+
+	muteUser := &cmd.MuteUser{UserID: appeal.User.ID, Reason: appeal.SanctionReason, ExpiresAt: *action.ReducedUntil}
+	if err := bus.Dispatch(c, muteUser); err != nil {
+		return 0, err
+	}
+	return muteUser.Result, nil
+}
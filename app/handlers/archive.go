@@ -10,6 +10,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/postcache"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
 )
 
 func ArchivePostsPage() web.HandlerFunc {
@@ -124,6 +125,8 @@ func ArchivePost() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		c.Enqueue(tasks.NotifyAboutArchivedPosts([]int{getPost.Result.ID}))
+
 		postcache.InvalidateTenantRankings(c.Tenant().ID)
 		postcache.InvalidateCountPerStatus(c.Tenant().ID)
 
@@ -183,6 +186,8 @@ func BulkArchive() web.HandlerFunc {
 			return c.Failure(err)
 		}
 
+		c.Enqueue(tasks.NotifyAboutArchivedPosts(input.PostIDs))
+
 		postcache.InvalidateTenantRankings(c.Tenant().ID)
 		postcache.InvalidateCountPerStatus(c.Tenant().ID)
 
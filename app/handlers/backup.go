@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/backup"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 )
@@ -20,3 +26,35 @@ func ExportBackupZip() web.HandlerFunc {
 		return c.Attachment("backup.zip", "application/zip", file.Bytes())
 	}
 }
+
+// DownloadUserDataExport returns the personal data export a user was sent a link to by email
+// The signed link is all that's needed, so it can be opened from any browser until it expires
+func DownloadUserDataExport() web.HandlerFunc {
+	return func(c *web.Context) error {
+		claims, err := jwt.DecodeUserDataExportClaims(c.QueryParam("token"))
+		if err != nil || claims.TenantID != c.Tenant().ID {
+			return c.NotFound()
+		}
+
+		getExport := &query.GetUserDataExportByID{ID: claims.ExportID}
+		if err := bus.Dispatch(c, getExport); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		export := getExport.Result
+		if export.UserID != claims.UserID || !export.IsReady() {
+			return c.NotFound()
+		}
+
+		getBlob := &query.GetBlobByKey{Key: export.BlobKey}
+		if err := bus.Dispatch(c, getBlob); err != nil {
+			return c.Failure(err)
+		}
+
+		fileName := fmt.Sprintf("%s-data-export-%s.zip", c.Tenant().Subdomain, export.CreatedAt.Format("2006-01-02"))
+		return c.Attachment(fileName, "application/zip", getBlob.Result.Content)
+	}
+}
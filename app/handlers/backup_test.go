@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+)
+
+func userDataExportToken(exportID, userID, tenantID int) string {
+	token, _ := jwt.Encode(&jwt.UserDataExportClaims{
+		ExportID: exportID,
+		UserID:   userID,
+		TenantID: tenantID,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(time.Hour)),
+		},
+	})
+	return token
+}
+
+func addUserDataExportHandlers(export *entity.UserDataExport) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserDataExportByID) error {
+		q.Result = export
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetBlobByKey) error {
+		q.Result = &dto.Blob{Content: []byte("zip"), ContentType: "application/zip"}
+		return nil
+	})
+}
+
+func TestDownloadUserDataExportHandler(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	addUserDataExportHandlers(&entity.UserDataExport{
+		ID:        3,
+		UserID:    mock.AryaStark.ID,
+		BlobKey:   "exports/abc.zip",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC),
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/user/export/download?token=" + userDataExportToken(3, mock.AryaStark.ID, mock.DemoTenant.ID)).
+		Execute(handlers.DownloadUserDataExport())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Header().Get("Content-Type")).Equals("application/zip")
+	Expect(response.Header().Get("Content-Disposition")).ContainsSubstring("demo-data-export-2026-10-18.zip")
+	Expect(response.Body.String()).Equals("zip")
+}
+
+func TestDownloadUserDataExportHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	ready := &entity.UserDataExport{ID: 3, UserID: mock.AryaStark.ID, BlobKey: "exports/abc.zip", ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &entity.UserDataExport{ID: 3, UserID: mock.AryaStark.ID, BlobKey: "exports/abc.zip", ExpiresAt: time.Now().Add(-time.Hour)}
+	pending := &entity.UserDataExport{ID: 3, UserID: mock.AryaStark.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	testCases := []struct {
+		export *entity.UserDataExport
+		token  string
+	}{
+		{ready, "invalid"},
+		{ready, userDataExportToken(3, mock.JonSnow.ID, mock.DemoTenant.ID)},
+		{ready, userDataExportToken(3, mock.AryaStark.ID, mock.AvengersTenant.ID)},
+		{expired, userDataExportToken(3, mock.AryaStark.ID, mock.DemoTenant.ID)},
+		{pending, userDataExportToken(3, mock.AryaStark.ID, mock.DemoTenant.ID)},
+	}
+
+	for _, testCase := range testCases {
+		server := mock.NewServer()
+		addUserDataExportHandlers(testCase.export)
+		bus.AddHandler(func(ctx context.Context, q *query.GetNavigationLinks) error {
+			return nil
+		})
+
+		code, _ := server.
+			OnTenant(mock.DemoTenant).
+			WithURL("http://demo.test.fider.io/user/export/download?token=" + testCase.token).
+			Execute(handlers.DownloadUserDataExport())
+
+		Expect(code).Equals(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// builtInRoles are the roles shown as presets on the roles page
+var builtInRoles = []enum.Role{
+	enum.RoleHelper,
+	enum.RoleModerator,
+	enum.RoleCollaborator,
+	enum.RoleAdministrator,
+}
+
+// ManageRolesPage is the page used by administrators to compose custom roles from permissions
+func ManageRolesPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		listRoles := &query.ListCustomRoles{}
+		if err := bus.Dispatch(c, listRoles); err != nil {
+			return c.Failure(err)
+		}
+
+		presets := make([]web.Map, len(builtInRoles))
+		for i, role := range builtInRoles {
+			presets[i] = web.Map{
+				"role":        role,
+				"permissions": role.Permissions(),
+			}
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageRoles.page",
+			Title: "Roles · Site Settings",
+			Data: web.Map{
+				"customRoles": listRoles.Result,
+				"presets":     presets,
+				"permissions": enum.Permissions,
+			},
+		})
+	}
+}
+
+// ListCustomRoles returns the custom roles of the tenant
+func ListCustomRoles() web.HandlerFunc {
+	return func(c *web.Context) error {
+		listRoles := &query.ListCustomRoles{}
+		if err := bus.Dispatch(c, listRoles); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(listRoles.Result)
+	}
+}
+
+// CreateEditCustomRole creates a new custom role or edits an existing one
+func CreateEditCustomRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.CreateEditCustomRole)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if action.CustomRole != nil {
+			updateRole := &cmd.UpdateCustomRole{
+				ID:          action.CustomRole.ID,
+				Name:        action.Name,
+				Description: action.Description,
+				Permissions: action.Permissions,
+			}
+			if err := bus.Dispatch(c, updateRole); err != nil {
+				return c.Failure(err)
+			}
+
+			action.CustomRole.Name = action.Name
+			action.CustomRole.Description = action.Description
+			action.CustomRole.Permissions = action.Permissions
+			return c.Ok(action.CustomRole)
+		}
+
+		createRole := &cmd.CreateCustomRole{
+			Name:        action.Name,
+			Description: action.Description,
+			Permissions: action.Permissions,
+		}
+		if err := bus.Dispatch(c, createRole); err != nil {
+			return c.Failure(err)
+		}
+		return c.Ok(createRole.Result)
+	}
+}
+
+// DeleteCustomRole deletes a custom role, its members keep their built-in role
+func DeleteCustomRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteCustomRole{ID: id}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// ListCustomRoleMembers returns the users a custom role is assigned to
+func ListCustomRoleMembers() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		listMembers := &query.ListCustomRoleMembers{CustomRoleID: id}
+		if err := bus.Dispatch(c, listMembers); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(listMembers.Result)
+	}
+}
+
+// GetUserCustomRole returns the custom role assigned to a user, or null when there is none
+func GetUserCustomRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		userID, err := c.ParamAsInt("userID")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getRole := &query.GetUserCustomRole{UserID: userID}
+		if err := bus.Dispatch(c, getRole); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.Ok(nil)
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(getRole.Result)
+	}
+}
+
+// SetUserCustomRole assigns a custom role to a user, or removes it
+func SetUserCustomRole() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SetUserCustomRole)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		setRole := &cmd.SetUserCustomRole{
+			UserID:       action.UserID,
+			CustomRoleID: action.CustomRoleID,
+		}
+		if err := bus.Dispatch(c, setRole); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// EmailQueuePage shows administrators the latest emails of the outbound queue and how their delivery went
+func EmailQueuePage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		var status enum.QueuedEmailStatus
+		_ = status.UnmarshalText([]byte(c.QueryParam("status")))
+
+		emails := &query.ListQueuedEmails{Status: status}
+		counts := &query.CountQueuedEmailsByStatus{}
+		if err := bus.Dispatch(c, emails, counts); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/EmailQueue.page",
+			Title: "Email Queue · Site Settings",
+			Data: web.Map{
+				"emails": emails.Result,
+				"status": status,
+				"counts": web.Map{
+					"pending": counts.Result[enum.QueuedEmailPending],
+					"sent":    counts.Result[enum.QueuedEmailSent],
+					"failed":  counts.Result[enum.QueuedEmailFailed],
+				},
+			},
+		})
+	}
+}
+
+// RetryQueuedEmail puts a failed email back in the outbound queue
+func RetryQueuedEmail() web.HandlerFunc {
+	return func(c *web.Context) error {
+		id, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.RetryQueuedEmail{ID: id}); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
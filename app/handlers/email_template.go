@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
+)
+
+// ManageEmailTemplates is the page used by administrators to override built-in email templates
+func ManageEmailTemplates() web.HandlerFunc {
+	return func(c *web.Context) error {
+		overrides := &query.ListEmailTemplates{}
+		if err := bus.Dispatch(c, overrides); err != nil {
+			return c.Failure(err)
+		}
+
+		templates := make([]web.Map, 0, len(enum.AllEmailTemplates))
+		for _, t := range enum.AllEmailTemplates {
+			subject, body, err := email.BuiltinTemplate(t.Name)
+			if err != nil {
+				return c.Failure(err)
+			}
+			templates = append(templates, web.Map{
+				"name":         t.Name,
+				"placeholders": t.Placeholders,
+				"subject":      subject,
+				"body":         body,
+			})
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageEmailTemplates.page",
+			Title: "Email Templates · Site Settings",
+			Data: web.Map{
+				"templates": templates,
+				"overrides": overrides.Result,
+				"locale":    c.Tenant().Locale,
+			},
+		})
+	}
+}
+
+// SaveEmailTemplate creates or replaces the override of an email template in a locale
+func SaveEmailTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.SaveEmailTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		saveTemplate := &cmd.SaveEmailTemplate{
+			Name:    action.Name,
+			Locale:  action.Locale,
+			Subject: action.Subject,
+			Body:    action.Body,
+		}
+		if err := bus.Dispatch(c, saveTemplate); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(saveTemplate.Result)
+	}
+}
+
+// DeleteEmailTemplate restores the built-in email template in a locale
+func DeleteEmailTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		name, locale := c.Param("name"), c.QueryParam("locale")
+		if _, ok := enum.GetEmailTemplate(name); !ok || !i18n.IsValidLocale(locale) {
+			return c.NotFound()
+		}
+
+		if err := bus.Dispatch(c, &cmd.DeleteEmailTemplate{Name: name, Locale: locale}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
+// PreviewEmailTemplate renders an email template override with sample props
+// Errors are returned as part of the preview so that administrators can fix them
+func PreviewEmailTemplate() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.PreviewEmailTemplate)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		ctx := context.WithValue(c, app.LocaleCtxKey, action.Locale)
+		message, err := email.RenderOverride(ctx, action.Subject, action.Body, email.SampleProps(ctx, action.Name).Merge(dto.Props{
+			"noreply": true,
+		}))
+		if err != nil {
+			return c.Ok(web.Map{"error": err.Error()})
+		}
+
+		return c.Ok(web.Map{
+			"subject": message.Subject,
+			"body":    message.Body,
+		})
+	}
+}
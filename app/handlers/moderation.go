@@ -4,6 +4,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
 )
 
 func ApprovePostModeration() web.HandlerFunc {
@@ -23,6 +24,8 @@ func ApprovePostModeration() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			c.Enqueue(tasks.NotifyAboutModeratedContent("post", postID, true))
+
 			return c.Ok(web.Map{})
 		})
 	}
@@ -45,6 +48,8 @@ func ApproveCommentModeration() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			c.Enqueue(tasks.NotifyAboutModeratedContent("comment", commentID, true))
+
 			return c.Ok(web.Map{})
 		})
 	}
@@ -67,6 +72,8 @@ func HidePostModeration() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			c.Enqueue(tasks.NotifyAboutModeratedContent("post", postID, false))
+
 			return c.Ok(web.Map{})
 		})
 	}
@@ -89,6 +96,8 @@ func HideCommentModeration() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			c.Enqueue(tasks.NotifyAboutModeratedContent("comment", commentID, false))
+
 			return c.Ok(web.Map{})
 		})
 	}
@@ -4,9 +4,15 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 )
 
@@ -44,6 +50,7 @@ func GetAllNotifications() web.HandlerFunc {
 		if err := bus.Dispatch(c, q); err != nil {
 			return c.Failure(err)
 		}
+		setGroupedTitles(c, q.Result)
 
 		return c.Ok(web.Map{
 			"notifications": q.Result,
@@ -95,6 +102,7 @@ func Notifications() web.HandlerFunc {
 		if err := bus.Dispatch(c, q); err != nil {
 			return c.Failure(err)
 		}
+		setGroupedTitles(c, q.Result)
 
 		return c.Page(http.StatusOK, web.Props{
 			Page:  "MyNotifications/MyNotifications.page",
@@ -167,3 +175,102 @@ func PurgeReadNotifications() web.HandlerFunc {
 		})
 	}
 }
+
+// setGroupedTitles describes notifications that collapse several users with the latest of them, e.g. "Alice, Bob and 12 others"
+func setGroupedTitles(c *web.Context, notifications []*entity.Notification) {
+	for _, n := range notifications {
+		if !n.IsGrouped() || len(n.LatestActors) < 2 {
+			continue
+		}
+
+		params := i18n.Params{
+			"first":  n.LatestActors[0],
+			"second": n.LatestActors[1],
+			"others": n.ActorCount - 2,
+			"title":  n.PostTitle,
+		}
+		if n.ActorCount == 2 {
+			n.Title = i18n.T(c, "web."+n.Event+".grouped.two", params)
+		} else {
+			n.Title = i18n.T(c, "web."+n.Event+".grouped.many", params)
+		}
+	}
+}
+
+// UnsubscribePage asks the recipient of a notification email to confirm they want to stop receiving it
+func UnsubscribePage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		token := c.QueryParam("token")
+		claims, err := jwt.DecodeUnsubscribeClaims(token)
+		if err != nil {
+			return c.NotFound()
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "MyNotifications/Unsubscribe.page",
+			Title: "Unsubscribe",
+			Data: web.Map{
+				"token":  token,
+				"digest": claims.Event == enum.DigestFrequencySettingsKey,
+			},
+		})
+	}
+}
+
+// Unsubscribe turns off email notifications of the event in the token, or all digest emails for digest tokens
+// It's the target of the List-Unsubscribe-Post header, so it doesn't require a session
+func Unsubscribe() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if c.Tenant() == nil {
+			return c.NotFound()
+		}
+
+		claims, err := jwt.DecodeUnsubscribeClaims(c.QueryParam("token"))
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getUser := &query.GetUserByID{UserID: claims.UserID}
+		if err := bus.Dispatch(c, getUser); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return c.NotFound()
+			}
+			return c.Failure(err)
+		}
+		c.SetUser(getUser.Result)
+
+		current := &query.GetCurrentUserSettings{}
+		if err := bus.Dispatch(c, current); err != nil {
+			return c.Failure(err)
+		}
+
+		settings := make(map[string]string)
+		for _, event := range enum.AllNotificationEvents {
+			var off enum.NotificationChannel
+			if claims.Event == enum.DigestFrequencySettingsKey {
+				off = enum.NotificationChannelDigest
+			} else if claims.Event == event.UserSettingsKeyName {
+				off = enum.NotificationChannelEmail | enum.NotificationChannelDigest
+			} else {
+				continue
+			}
+
+			value, err := strconv.Atoi(current.Result[event.UserSettingsKeyName])
+			if err != nil {
+				value, _ = strconv.Atoi(event.DefaultSettingValue)
+			}
+			settings[event.UserSettingsKeyName] = strconv.Itoa(int(enum.NotificationChannel(value) &^ off))
+		}
+
+		if len(settings) == 0 {
+			return c.NotFound()
+		}
+
+		return c.WithTransaction(func() error {
+			if err := bus.Dispatch(c, &cmd.UpdateCurrentUserSettings{Settings: settings}); err != nil {
+				return c.Failure(err)
+			}
+			return c.Ok(web.Map{})
+		})
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
-	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
 
 // OAuthEcho exchanges OAuth Code for a user profile and return directly to the UI, without storing it
@@ -40,7 +39,7 @@ func OAuthEcho() web.HandlerFunc {
 			return c.Redirect("/")
 		}
 
-		rawProfile := &query.GetOAuthRawProfile{Provider: provider, Code: code}
+		rawProfile := &query.GetOAuthRawProfile{Provider: provider, Code: code, Nonce: c.QueryParam("nonce")}
 		err := bus.Dispatch(c, rawProfile)
 		if err != nil {
 			return c.Page(http.StatusOK, web.Props{
@@ -89,7 +88,7 @@ func OAuthToken() web.HandlerFunc {
 			return c.Redirect(redirectURL.String())
 		}
 
-		oauthUser := &query.GetOAuthProfile{Provider: provider, Code: code}
+		oauthUser := &query.GetOAuthProfile{Provider: provider, Code: code, Nonce: c.QueryParam("nonce")}
 		if err := bus.Dispatch(c, oauthUser); err != nil {
 			return c.Failure(err)
 		}
@@ -141,12 +140,82 @@ func OAuthToken() web.HandlerFunc {
 			}
 		}
 
-		webutil.AddAuthUserCookie(c, user)
+		return signInUser(c, user, redirectURL.String())
+	}
+}
 
-		return c.Redirect(redirectURL.String())
+// OAuthLink exchanges OAuth Code for a user profile and links it to the current user
+// If the profile already belongs to another user, a merge request is raised for staff to decide
+// The user is sent back to their settings with the outcome in the 'link' query param
+func OAuthLink() web.HandlerFunc {
+	return func(c *web.Context) error {
+		provider := c.Param("provider")
+
+		code := c.QueryParam("code")
+		if code == "" {
+			return c.Redirect("/profile#settings")
+		}
+
+		identifier := c.QueryParam("identifier")
+		if identifier == "" || identifier != c.SessionID() {
+			log.Warn(c, "OAuth identifier doesn't match with user session ID. Aborting link process.")
+			return c.Redirect("/profile#settings")
+		}
+
+		oauthUser := &query.GetOAuthProfile{Provider: provider, Code: code, Nonce: c.QueryParam("nonce")}
+		if err := bus.Dispatch(c, oauthUser); err != nil {
+			return c.Failure(err)
+		}
+
+		outcome, err := linkOAuthProfile(c, provider, oauthUser.Result)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Redirect(fmt.Sprintf("/profile?link=%s&provider=%s#settings", outcome, url.QueryEscape(provider)))
 	}
 }
 
+func linkOAuthProfile(c *web.Context, provider string, profile *dto.OAuthUserProfile) (string, error) {
+	user := c.User()
+
+	userByProvider := &query.GetUserByProvider{Provider: provider, UID: profile.ID}
+	err := bus.Dispatch(c, userByProvider)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return "", err
+	}
+
+	owner := userByProvider.Result
+	if owner != nil && owner.ID == user.ID {
+		return "already", nil
+	}
+
+	// only one identity per provider can be linked to a user
+	if user.HasProvider(provider) {
+		return "conflict", nil
+	}
+
+	if owner != nil {
+		if err := bus.Dispatch(c, &cmd.CreateUserMergeRequest{
+			OwnerID:     owner.ID,
+			Provider:    provider,
+			ProviderUID: profile.ID,
+		}); err != nil {
+			return "", err
+		}
+		return "merge", nil
+	}
+
+	if err := bus.Dispatch(c, &cmd.RegisterUserProvider{
+		UserID:       user.ID,
+		ProviderName: provider,
+		ProviderUID:  profile.ID,
+	}); err != nil {
+		return "", err
+	}
+	return "linked", nil
+}
+
 func isTrustedOAuthProvider(ctx context.Context, provider string) bool {
 	customOAuthConfigByProvider := &query.GetCustomOAuthConfigByProvider{Provider: provider}
 	err := bus.Dispatch(ctx, customOAuthConfigByProvider)
@@ -186,18 +255,19 @@ func OAuthCallback() web.HandlerFunc {
 			return c.Redirect(redirectURL.String())
 		}
 
-		//Test OAuth
-		if redirectURL.Path == fmt.Sprintf("/oauth/%s/echo", provider) {
+		//Test OAuth and linking to a signed in user
+		if redirectURL.Path == fmt.Sprintf("/oauth/%s/echo", provider) || redirectURL.Path == fmt.Sprintf("/oauth/%s/link", provider) {
 			var query = redirectURL.Query()
 			query.Set("code", code)
 			query.Set("identifier", claims.Identifier)
+			setOAuthNonce(query, claims.Nonce)
 			redirectURL.RawQuery = query.Encode()
 			return c.Redirect(redirectURL.String())
 		}
 
 		//Sign up process
 		if redirectURL.Path == "/signup" {
-			oauthUser := &query.GetOAuthProfile{Provider: provider, Code: code}
+			oauthUser := &query.GetOAuthProfile{Provider: provider, Code: code, Nonce: claims.Nonce}
 			if err := bus.Dispatch(c, oauthUser); err != nil {
 				return c.Failure(err)
 			}
@@ -228,12 +298,20 @@ func OAuthCallback() web.HandlerFunc {
 		query.Set("code", code)
 		query.Set("redirect", redirectURL.RequestURI())
 		query.Set("identifier", claims.Identifier)
+		setOAuthNonce(query, claims.Nonce)
 		redirectURL.RawQuery = query.Encode()
 		redirectURL.Path = fmt.Sprintf("/oauth/%s/token", provider)
 		return c.Redirect(redirectURL.String())
 	}
 }
 
+// setOAuthNonce passes the nonce of OpenID Connect providers on to the handler that exchanges the code
+func setOAuthNonce(query url.Values, nonce string) {
+	if nonce != "" {
+		query.Set("nonce", nonce)
+	}
+}
+
 // SignInByOAuth is responsible for redirecting the user to the OAuth authorization URL for given provider
 // A cookie is stored in user's browser with a random identifier that is later used to verify the authenticity of the request
 func SignInByOAuth() web.HandlerFunc {
@@ -255,7 +333,7 @@ func SignInByOAuth() web.HandlerFunc {
 		}
 		redirectURL.ResolveReference(c.Request.URL)
 
-		if c.IsAuthenticated() && redirectURL.Path != fmt.Sprintf("/oauth/%s/echo", provider) {
+		if c.IsAuthenticated() && redirectURL.Path != fmt.Sprintf("/oauth/%s/echo", provider) && redirectURL.Path != fmt.Sprintf("/oauth/%s/link", provider) {
 			return c.Redirect(redirect)
 		}
 
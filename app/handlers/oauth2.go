@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+// oauth2CodeExpiration is how long a third-party app has to exchange an authorization code for tokens
+const oauth2CodeExpiration = 10 * time.Minute
+
+// oauth2RefreshTokenExpiration is how long a third-party app can keep acting on behalf of a user without using its refresh token
+const oauth2RefreshTokenExpiration = 30 * 24 * time.Hour
+
+// OAuthClientsPage lists the third-party apps registered by administrators
+func OAuthClientsPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		clients := &query.ListOAuthClients{}
+		if err := bus.Dispatch(c, clients); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/OAuthClients.page",
+			Title: "OAuth Apps · Site Settings",
+			Data: web.Map{
+				"clients": clients.Result,
+			},
+		})
+	}
+}
+
+// OAuth2AuthorizePage is where third-party apps send users to ask for access to their account
+func OAuth2AuthorizePage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := &actions.AuthorizeOAuthClient{
+			ResponseType:        c.QueryParam("response_type"),
+			ClientID:            c.QueryParam("client_id"),
+			RedirectURI:         c.QueryParam("redirect_uri"),
+			Scope:               c.QueryParam("scope"),
+			State:               c.QueryParam("state"),
+			CodeChallenge:       c.QueryParam("code_challenge"),
+			CodeChallengeMethod: c.QueryParam("code_challenge_method"),
+		}
+
+		result := action.Validate(c, c.User())
+		if result.Err != nil {
+			return c.Failure(result.Err)
+		}
+
+		if !result.Ok {
+			// without a known client and redirect URI, there is nowhere safe to send the error to
+			if action.Client == nil || !action.Client.HasRedirectURI(action.RedirectURI) {
+				return c.Page(http.StatusBadRequest, web.Props{
+					Page:  "OAuth2/Authorize.page",
+					Title: "Authorize App",
+					Data: web.Map{
+						"error": result.Errors[0].Message,
+					},
+				})
+			}
+
+			code := "invalid_request"
+			if result.Errors[0].Field == "scope" {
+				code = "invalid_scope"
+			}
+			return c.Redirect(oauth2RedirectURL(action.RedirectURI, action.State, url.Values{
+				"error":             {code},
+				"error_description": {result.Errors[0].Message},
+			}))
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "OAuth2/Authorize.page",
+			Title: "Authorize " + action.Client.Name,
+			Data: web.Map{
+				"client": web.Map{
+					"name": action.Client.Name,
+				},
+				"scopes": action.Scopes,
+				"request": web.Map{
+					"responseType":        action.ResponseType,
+					"clientId":            action.ClientID,
+					"redirectUri":         action.RedirectURI,
+					"scope":               webutil.FormatOAuth2Scope(action.Scopes),
+					"state":               action.State,
+					"codeChallenge":       action.CodeChallenge,
+					"codeChallengeMethod": action.CodeChallengeMethod,
+				},
+			},
+		})
+	}
+}
+
+// OAuth2Authorize is called when the user approves or denies the app, the page then sends the user back to the app
+func OAuth2Authorize() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.AuthorizeOAuthClient)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		if !action.Approve {
+			return c.Ok(web.Map{
+				"redirectUrl": oauth2RedirectURL(action.RedirectURI, action.State, url.Values{
+					"error": {"access_denied"},
+				}),
+			})
+		}
+
+		code := webutil.GenerateOAuth2Secret()
+		if err := bus.Dispatch(c, &cmd.CreateOAuthAuthorizationCode{
+			ClientID:      action.Client.ID,
+			UserID:        c.User().ID,
+			CodeHash:      webutil.HashOAuth2Secret(code),
+			RedirectURI:   action.RedirectURI,
+			Scopes:        action.Scopes,
+			CodeChallenge: action.CodeChallenge,
+			ExpiresAt:     time.Now().Add(oauth2CodeExpiration),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"redirectUrl": oauth2RedirectURL(action.RedirectURI, action.State, url.Values{
+				"code": {code},
+			}),
+		})
+	}
+}
+
+// OAuth2Token exchanges an authorization code or a refresh token for a new access token and refresh token
+// Requests and responses follow RFC 6749, so that third-party apps can use any OAuth2 library
+func OAuth2Token() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if c.Tenant() == nil {
+			return c.NotFound()
+		}
+
+		form, err := url.ParseQuery(c.Request.Body)
+		if err != nil {
+			return oauth2Error(c, http.StatusBadRequest, "invalid_request", "The request body must be form encoded.")
+		}
+
+		clientID, clientSecret := oauth2ClientCredentials(c, form)
+		getClient := &query.GetOAuthClientByClientID{ClientID: clientID}
+		if err := bus.Dispatch(c, getClient); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return oauth2Error(c, http.StatusUnauthorized, "invalid_client", "Unknown client.")
+			}
+			return c.Failure(err)
+		}
+		client := getClient.Result
+
+		if client.IsConfidential && subtle.ConstantTimeCompare([]byte(webutil.HashOAuth2Secret(clientSecret)), []byte(client.SecretHash)) != 1 {
+			return oauth2Error(c, http.StatusUnauthorized, "invalid_client", "Client authentication failed.")
+		}
+
+		var grant *entity.OAuthGrant
+		switch form.Get("grant_type") {
+		case "authorization_code":
+			use := &cmd.UseOAuthAuthorizationCode{CodeHash: webutil.HashOAuth2Secret(form.Get("code"))}
+			if err := bus.Dispatch(c, use); err != nil {
+				if errors.Cause(err) == app.ErrNotFound {
+					return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The authorization code is invalid, has expired or was already used.")
+				}
+				return c.Failure(err)
+			}
+			grant = use.Result
+
+			if grant.ClientID != client.ID || grant.RedirectURI != form.Get("redirect_uri") {
+				return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The authorization code was issued to another client or redirect URI.")
+			}
+			if !webutil.VerifyPKCE(form.Get("code_verifier"), grant.CodeChallenge) {
+				return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The code verifier doesn't match the code challenge.")
+			}
+		case "refresh_token":
+			use := &cmd.UseOAuthRefreshToken{TokenHash: webutil.HashOAuth2Secret(form.Get("refresh_token"))}
+			if err := bus.Dispatch(c, use); err != nil {
+				if errors.Cause(err) == app.ErrNotFound {
+					return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The refresh token is invalid, has expired or was already used.")
+				}
+				return c.Failure(err)
+			}
+			grant = use.Result
+
+			if grant.ClientID != client.ID {
+				return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The refresh token was issued to another client.")
+			}
+		default:
+			return oauth2Error(c, http.StatusBadRequest, "unsupported_grant_type", "Only the 'authorization_code' and 'refresh_token' grant types are supported.")
+		}
+
+		getUser := &query.GetUserByID{UserID: grant.UserID}
+		if err := bus.Dispatch(c, getUser); err != nil {
+			return c.Failure(err)
+		}
+		if getUser.Result.Status != enum.UserActive {
+			return oauth2Error(c, http.StatusBadRequest, "invalid_grant", "The user is no longer active.")
+		}
+
+		accessToken, err := webutil.EncodeOAuth2AccessToken(c, client, grant.UserID, grant.Scopes)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		// refresh tokens are rotated, the one that was used can't be used again
+		refreshToken := webutil.GenerateOAuth2Secret()
+		if err := bus.Dispatch(c, &cmd.CreateOAuthRefreshToken{
+			ClientID:  client.ID,
+			UserID:    grant.UserID,
+			TokenHash: webutil.HashOAuth2Secret(refreshToken),
+			Scopes:    grant.Scopes,
+			ExpiresAt: time.Now().Add(oauth2RefreshTokenExpiration),
+		}); err != nil {
+			return c.Failure(err)
+		}
+
+		c.Response.Header().Set("Cache-Control", "no-store")
+		return c.JSON(http.StatusOK, web.Map{
+			"access_token":  accessToken,
+			"token_type":    "Bearer",
+			"expires_in":    int(webutil.OAuth2AccessTokenExpiration.Seconds()),
+			"refresh_token": refreshToken,
+			"scope":         webutil.FormatOAuth2Scope(grant.Scopes),
+		})
+	}
+}
+
+// oauth2ClientCredentials returns the client credentials from the HTTP Basic header, or from the form for clients that can't set it
+func oauth2ClientCredentials(c *web.Context, form url.Values) (string, string) {
+	if clientID, clientSecret, ok := c.Request.Original().BasicAuth(); ok {
+		id, _ := url.QueryUnescape(clientID)
+		secret, _ := url.QueryUnescape(clientSecret)
+		return id, secret
+	}
+	return form.Get("client_id"), form.Get("client_secret")
+}
+
+func oauth2Error(c *web.Context, status int, code, description string) error {
+	if status == http.StatusUnauthorized {
+		c.Response.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+	return c.JSON(status, web.Map{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// oauth2RedirectURL adds params and state to the query string of the redirect URI of a client
+func oauth2RedirectURL(redirectURI, state string, params url.Values) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	u.RawQuery = query.Encode()
+	return strings.TrimSuffix(u.String(), "?")
+}
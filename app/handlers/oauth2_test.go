@@ -0,0 +1,267 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+const oauth2Verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func oauth2Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func addOAuth2ClientHandler(client *entity.OAuthClient) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthClientByClientID) error {
+		if q.ClientID == client.ClientID {
+			q.Result = client
+			return nil
+		}
+		return app.ErrNotFound
+	})
+}
+
+var oauth2PublicClient = &entity.OAuthClient{
+	ID:           7,
+	ClientID:     "public-client",
+	Name:         "Roadmap Bot",
+	RedirectURIs: []string{"https://bot.example.com/callback"},
+	Scopes:       []enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeWriteComments},
+}
+
+func TestOAuth2TokenHandler_AuthorizationCode(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	var usedCodeHash string
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseOAuthAuthorizationCode) error {
+		usedCodeHash = c.CodeHash
+		c.Result = &entity.OAuthGrant{
+			ClientID:      oauth2PublicClient.ID,
+			UserID:        mock.JonSnow.ID,
+			RedirectURI:   "https://bot.example.com/callback",
+			Scopes:        []enum.APITokenScope{enum.APITokenScopeReadPosts},
+			CodeChallenge: oauth2Challenge(oauth2Verifier),
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	var newRefreshToken *cmd.CreateOAuthRefreshToken
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateOAuthRefreshToken) error {
+		newRefreshToken = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(handlers.OAuth2Token(), url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {"the-code"},
+			"redirect_uri":  {"https://bot.example.com/callback"},
+			"client_id":     {"public-client"},
+			"code_verifier": {oauth2Verifier},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(usedCodeHash).Equals(webutil.HashOAuth2Secret("the-code"))
+	Expect(query.String("token_type")).Equals("Bearer")
+	Expect(query.String("scope")).Equals("read:posts")
+	Expect(query.Int32("expires_in")).Equals(3600)
+
+	claims, err := jwt.DecodeOAuth2AccessClaims(query.String("access_token"))
+	Expect(err).IsNil()
+	Expect(claims.UserID).Equals(mock.JonSnow.ID)
+	Expect(claims.TenantID).Equals(mock.DemoTenant.ID)
+	Expect(claims.ClientID).Equals("public-client")
+
+	Expect(newRefreshToken).IsNotNil()
+	Expect(newRefreshToken.TokenHash).Equals(webutil.HashOAuth2Secret(query.String("refresh_token")))
+	Expect(newRefreshToken.UserID).Equals(mock.JonSnow.ID)
+}
+
+func TestOAuth2TokenHandler_WrongCodeVerifier(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseOAuthAuthorizationCode) error {
+		c.Result = &entity.OAuthGrant{
+			ClientID:      oauth2PublicClient.ID,
+			UserID:        mock.JonSnow.ID,
+			RedirectURI:   "https://bot.example.com/callback",
+			Scopes:        []enum.APITokenScope{enum.APITokenScopeReadPosts},
+			CodeChallenge: oauth2Challenge(oauth2Verifier),
+		}
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(handlers.OAuth2Token(), url.Values{
+			"grant_type":    {"authorization_code"},
+			"code":          {"the-code"},
+			"redirect_uri":  {"https://bot.example.com/callback"},
+			"client_id":     {"public-client"},
+			"code_verifier": {"this-is-not-the-verifier-that-was-used-for-the-challenge"},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(query.String("error")).Equals("invalid_grant")
+}
+
+func TestOAuth2TokenHandler_ConfidentialClient_WrongSecret(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(&entity.OAuthClient{
+		ID:             8,
+		ClientID:       "confidential-client",
+		RedirectURIs:   []string{"https://bot.example.com/callback"},
+		Scopes:         []enum.APITokenScope{enum.APITokenScopeReadPosts},
+		IsConfidential: true,
+		SecretHash:     webutil.HashOAuth2Secret("the-secret"),
+	})
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		AddHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("confidential-client:wrong-secret"))).
+		ExecutePostAsJSON(handlers.OAuth2Token(), url.Values{
+			"grant_type": {"authorization_code"},
+			"code":       {"the-code"},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusUnauthorized)
+	Expect(query.String("error")).Equals("invalid_client")
+}
+
+func TestOAuth2TokenHandler_UnsupportedGrantType(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(handlers.OAuth2Token(), url.Values{
+			"grant_type": {"password"},
+			"client_id":  {"public-client"},
+		}.Encode())
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(query.String("error")).Equals("unsupported_grant_type")
+}
+
+func TestOAuth2AuthorizePageHandler_UnknownClient(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+	bus.AddHandler(func(ctx context.Context, q *query.GetNavigationLinks) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, page := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("https://demo.test.fider.io/oauth2/authorize?response_type=code&client_id=unknown&redirect_uri=https://evil.example.com").
+		ExecuteAsPage(handlers.OAuth2AuthorizePage())
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(page.Data["error"]).Equals("Unknown client.")
+}
+
+func TestOAuth2AuthorizePageHandler_InvalidScope(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	server := mock.NewServer()
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("https://demo.test.fider.io/oauth2/authorize?response_type=code&client_id=public-client&redirect_uri=https://bot.example.com/callback&scope=admin&state=xyz&code_challenge_method=S256&code_challenge=" + oauth2Challenge(oauth2Verifier)).
+		Execute(handlers.OAuth2AuthorizePage())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	location, _ := url.Parse(response.Header().Get("Location"))
+	Expect(location.Host).Equals("bot.example.com")
+	Expect(location.Query().Get("error")).Equals("invalid_scope")
+	Expect(location.Query().Get("state")).Equals("xyz")
+}
+
+func TestOAuth2AuthorizeHandler_Approve(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	var newCode *cmd.CreateOAuthAuthorizationCode
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateOAuthAuthorizationCode) error {
+		newCode = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(handlers.OAuth2Authorize(), `{
+			"responseType": "code",
+			"clientId": "public-client",
+			"redirectUri": "https://bot.example.com/callback",
+			"scope": "read:posts write:comments",
+			"state": "xyz",
+			"codeChallenge": "`+oauth2Challenge(oauth2Verifier)+`",
+			"codeChallengeMethod": "S256",
+			"approve": true
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(newCode).IsNotNil()
+	Expect(newCode.ClientID).Equals(oauth2PublicClient.ID)
+	Expect(newCode.UserID).Equals(mock.JonSnow.ID)
+	Expect(newCode.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeWriteComments})
+
+	redirect, _ := url.Parse(query.String("redirectUrl"))
+	Expect(redirect.Host).Equals("bot.example.com")
+	Expect(redirect.Query().Get("state")).Equals("xyz")
+	Expect(webutil.HashOAuth2Secret(redirect.Query().Get("code"))).Equals(newCode.CodeHash)
+}
+
+func TestOAuth2AuthorizeHandler_Deny(t *testing.T) {
+	RegisterT(t)
+	addOAuth2ClientHandler(oauth2PublicClient)
+
+	server := mock.NewServer()
+	code, query := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		ExecutePostAsJSON(handlers.OAuth2Authorize(), `{
+			"responseType": "code",
+			"clientId": "public-client",
+			"redirectUri": "https://bot.example.com/callback",
+			"state": "xyz",
+			"codeChallenge": "`+oauth2Challenge(oauth2Verifier)+`",
+			"codeChallengeMethod": "S256",
+			"approve": false
+		}`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(query.String("redirectUrl")).Equals("https://bot.example.com/callback?error=access_denied&state=xyz")
+}
@@ -293,6 +293,10 @@ func TestOAuthTokenHandler_ExistingUserAndProvider(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, response := server.
 		WithURL("http://demo.test.fider.io/oauth/facebook/token?code=123&identifier=MY_SESSION_ID&redirect=/hello").
@@ -344,6 +348,10 @@ func TestOAuthTokenHandler_NewUser(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, response := server.
 		WithURL("http://demo.test.fider.io/oauth/facebook/token?code=456&identifier=MY_SESSION_ID&redirect=/hello").
@@ -364,6 +372,10 @@ func TestOAuthTokenHandler_NewUser(t *testing.T) {
 func TestOAuthTokenHandler_NewUserWithoutEmail(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	var newUser *entity.User
 	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUser) error {
@@ -438,6 +450,10 @@ func TestOAuthTokenHandler_ExistingUser_WithoutEmail(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
@@ -498,6 +514,10 @@ func TestOAuthTokenHandler_ExistingUser_NewProvider(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	code, response := server.
 		WithURL("http://demo.test.fider.io/oauth/google/token?code=123&identifier=MY_SESSION_ID&redirect=/").
@@ -562,6 +582,10 @@ func TestOAuthTokenHandler_NewUser_PrivateSite(t *testing.T) {
 func TestOAuthTokenHandler_NewUser_PrivateSite_UsingTrustedProvider(t *testing.T) {
 	RegisterT(t)
 
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
 	server := mock.NewServer()
 	mock.AvengersTenant.IsPrivate = true
 
@@ -644,6 +668,140 @@ func TestOAuthTokenHandler_InvalidIdentifier(t *testing.T) {
 	ExpectFiderAuthCookie(response, nil)
 }
 
+func TestCallbackHandler_Link(t *testing.T) {
+	RegisterT(t)
+
+	state, _ := jwt.Encode(jwt.OAuthStateClaims{
+		Redirect:   "http://avengers.test.fider.io/oauth/google/link",
+		Identifier: "888",
+	})
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://login.test.fider.io/oauth/callback?state="+state+"&code=123").
+		AddParam("provider", app.GoogleProvider).
+		Execute(handlers.OAuthCallback())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("http://avengers.test.fider.io/oauth/google/link?code=123&identifier=888")
+}
+
+func TestOAuthLinkHandler_NewProvider(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthProfile) error {
+		q.Result = &dto.OAuthUserProfile{ID: "GO123", Name: "Jon Snow", Email: "jon.snow@got.com"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		return app.ErrNotFound
+	})
+
+	var registered *cmd.RegisterUserProvider
+	bus.AddHandler(func(ctx context.Context, c *cmd.RegisterUserProvider) error {
+		registered = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io/oauth/google/link?code=123&identifier=MY_SESSION_ID").
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddCookie(web.CookieSessionName, "MY_SESSION_ID").
+		AddParam("provider", app.GoogleProvider).
+		Use(middlewares.Session()).
+		Execute(handlers.OAuthLink())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/profile?link=linked&provider=google#settings")
+	Expect(registered).IsNotNil()
+	Expect(registered.UserID).Equals(mock.JonSnow.ID)
+	Expect(registered.ProviderName).Equals(app.GoogleProvider)
+	Expect(registered.ProviderUID).Equals("GO123")
+}
+
+func TestOAuthLinkHandler_ProviderOfAnotherUser(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthProfile) error {
+		q.Result = &dto.OAuthUserProfile{ID: "GO456", Name: "Arya Stark"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		q.Result = mock.AryaStark
+		return nil
+	})
+
+	var mergeRequest *cmd.CreateUserMergeRequest
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserMergeRequest) error {
+		mergeRequest = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io/oauth/google/link?code=123&identifier=MY_SESSION_ID").
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddCookie(web.CookieSessionName, "MY_SESSION_ID").
+		AddParam("provider", app.GoogleProvider).
+		Use(middlewares.Session()).
+		Execute(handlers.OAuthLink())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/profile?link=merge&provider=google#settings")
+	Expect(mergeRequest).IsNotNil()
+	Expect(mergeRequest.OwnerID).Equals(mock.AryaStark.ID)
+	Expect(mergeRequest.Provider).Equals(app.GoogleProvider)
+	Expect(mergeRequest.ProviderUID).Equals("GO456")
+}
+
+func TestOAuthLinkHandler_AnotherIdentityOfLinkedProvider(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthProfile) error {
+		q.Result = &dto.OAuthUserProfile{ID: "FB9999", Name: "Jon Snow"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		return app.ErrNotFound
+	})
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io/oauth/facebook/link?code=123&identifier=MY_SESSION_ID").
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddCookie(web.CookieSessionName, "MY_SESSION_ID").
+		AddParam("provider", app.FacebookProvider).
+		Use(middlewares.Session()).
+		Execute(handlers.OAuthLink())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/profile?link=conflict&provider=facebook#settings")
+}
+
+func TestOAuthLinkHandler_InvalidIdentifier(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io/oauth/google/link?code=123&identifier=SOME_OTHER_ID").
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddCookie(web.CookieSessionName, "MY_SESSION_ID").
+		AddParam("provider", app.GoogleProvider).
+		Use(middlewares.Session()).
+		Execute(handlers.OAuthLink())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/profile#settings")
+}
+
 func ExpectOAuthToken(token string, expected *jwt.OAuthClaims) {
 	user, err := jwt.DecodeOAuthClaims(token)
 	Expect(err).IsNil()
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/passkey"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// BeginPasskeySignIn returns the challenge the authenticator of the user has to sign
+func BeginPasskeySignIn() web.HandlerFunc {
+	return func(c *web.Context) error {
+		relyingParty, err := passkey.New(c.Tenant().Name, c.BaseURL())
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		assertion, session, err := relyingParty.BeginDiscoverableLogin()
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := webutil.StartPasskeyChallenge(c, session); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(assertion)
+	}
+}
+
+// FinishPasskeySignIn checks the challenge signed by the authenticator and signs the owner of the passkey in
+// Passkeys require user verification, so there is no two-factor step after them
+func FinishPasskeySignIn() web.HandlerFunc {
+	return func(c *web.Context) error {
+		session := webutil.GetPasskeyChallenge(c)
+		if session == nil {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.invalidpasskey")))
+		}
+		webutil.EndPasskeyChallenge(c)
+
+		parsed, err := protocol.ParseCredentialRequestResponseBytes([]byte(c.Request.Body))
+		if err != nil {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.invalidpasskey")))
+		}
+
+		relyingParty, err := passkey.New(c.Tenant().Name, c.BaseURL())
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		var lookupErr error
+		getPasskey := &query.GetUserPasskeyByCredentialID{CredentialID: passkey.CredentialID(parsed.RawID)}
+		owner, credential, err := relyingParty.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+			if err := bus.Dispatch(c, getPasskey); err != nil {
+				if errors.Cause(err) != app.ErrNotFound {
+					lookupErr = err
+				}
+				return nil, err
+			}
+
+			userID, err := passkey.UserIDFromHandle(userHandle)
+			if err != nil || userID != getPasskey.Result.UserID {
+				return nil, errors.New("passkey '%d' does not belong to the user handle", getPasskey.Result.ID)
+			}
+
+			getUser := &query.GetUserByID{UserID: userID}
+			if err := bus.Dispatch(c, getUser); err != nil {
+				if errors.Cause(err) != app.ErrNotFound {
+					lookupErr = err
+				}
+				return nil, err
+			}
+
+			return passkey.NewUser(getUser.Result, []*entity.UserPasskey{getPasskey.Result})
+		}, *session, parsed)
+		if lookupErr != nil {
+			return c.Failure(lookupErr)
+		}
+		if err != nil || credential.Authenticator.CloneWarning {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.invalidpasskey")))
+		}
+
+		data, err := passkey.Marshal(credential)
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if err := bus.Dispatch(c, &cmd.UpdateUserPasskeyUsage{ID: getPasskey.Result.ID, Credential: data}); err != nil {
+			return c.Failure(err)
+		}
+
+		webutil.AddAuthUserCookie(c, owner.(*passkey.User).User)
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,220 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/passkey"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+var b64 = base64.RawURLEncoding
+
+// testAuthenticator signs passkey challenges like a browser would, for the demo tenant host
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+}
+
+func newTestAuthenticator() *testAuthenticator {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	return &testAuthenticator{key: key, credentialID: []byte("test-credential")}
+}
+
+func (a *testAuthenticator) storedPasskey(userID int) *entity.UserPasskey {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.PublicKey.X.FillBytes(x)
+	a.key.PublicKey.Y.FillBytes(y)
+	publicKey, _ := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+		Curve:         1,
+		XCoord:        x,
+		YCoord:        y,
+	})
+
+	data, _ := passkey.Marshal(&webauthn.Credential{ID: a.credentialID, PublicKey: publicKey, AttestationType: "none"})
+	return &entity.UserPasskey{ID: 1, UserID: userID, Name: "Laptop", CredentialID: passkey.CredentialID(a.credentialID), Credential: data}
+}
+
+func (a *testAuthenticator) assertion(challenge string, userID int) string {
+	clientData, _ := json.Marshal(map[string]string{
+		"type":      "webauthn.get",
+		"challenge": challenge,
+		"origin":    "http://demo.test.fider.io:3000",
+	})
+
+	rpIDHash := sha256.Sum256([]byte("demo.test.fider.io"))
+	authData := append(rpIDHash[:], 0x05) // user present and verified
+	authData = binary.BigEndian.AppendUint32(authData, 1)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, _ := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+
+	body, _ := json.Marshal(map[string]any{
+		"id":    b64.EncodeToString(a.credentialID),
+		"rawId": b64.EncodeToString(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64.EncodeToString(clientData),
+			"authenticatorData": b64.EncodeToString(authData),
+			"signature":         b64.EncodeToString(signature),
+			"userHandle":        b64.EncodeToString(passkey.UserHandle(userID)),
+		},
+	})
+	return string(body)
+}
+
+func beginPasskeySignIn() (string, string) {
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io:3000/_api/signin/passkey/begin").
+		OnTenant(mock.DemoTenant).
+		ExecutePost(handlers.BeginPasskeySignIn(), "{}")
+	Expect(code).Equals(http.StatusOK)
+
+	var options struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			RPID      string `json:"rpId"`
+		} `json:"publicKey"`
+	}
+	_ = json.Unmarshal(response.Body.Bytes(), &options)
+	Expect(options.PublicKey.RPID).Equals("demo.test.fider.io")
+
+	for _, c := range response.Header()["Set-Cookie"] {
+		cookie := web.ParseCookie(c)
+		if cookie.Name == web.CookiePasskeyName {
+			return options.PublicKey.Challenge, cookie.Value
+		}
+	}
+	panic("Cookie not found...")
+}
+
+func TestFinishPasskeySignIn_ValidAssertion(t *testing.T) {
+	RegisterT(t)
+
+	authenticator := newTestAuthenticator()
+	challenge, token := beginPasskeySignIn()
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserPasskeyByCredentialID) error {
+		if q.CredentialID == passkey.CredentialID(authenticator.credentialID) {
+			q.Result = authenticator.storedPasskey(mock.JonSnow.ID)
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	var usage *cmd.UpdateUserPasskeyUsage
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateUserPasskeyUsage) error {
+		usage = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
+	code, response := server.
+		WithURL("http://demo.test.fider.io:3000/_api/signin/passkey/finish").
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookiePasskeyName, token).
+		ExecutePost(handlers.FinishPasskeySignIn(), authenticator.assertion(challenge, mock.JonSnow.ID))
+
+	Expect(code).Equals(http.StatusOK)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+	Expect(usage).IsNotNil()
+	Expect(usage.ID).Equals(1)
+
+	credential, err := passkey.Unmarshal(usage.Credential)
+	Expect(err).IsNil()
+	Expect(credential.Authenticator.SignCount).Equals(uint32(1))
+}
+
+func TestFinishPasskeySignIn_UserHandleOfAnotherUser(t *testing.T) {
+	RegisterT(t)
+
+	authenticator := newTestAuthenticator()
+	challenge, token := beginPasskeySignIn()
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserPasskeyByCredentialID) error {
+		q.Result = authenticator.storedPasskey(mock.JonSnow.ID)
+		return nil
+	})
+
+	code, response := server.
+		WithURL("http://demo.test.fider.io:3000/_api/signin/passkey/finish").
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookiePasskeyName, token).
+		ExecutePost(handlers.FinishPasskeySignIn(), authenticator.assertion(challenge, mock.AryaStark.ID))
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectFiderAuthCookie(response, nil)
+}
+
+func TestFinishPasskeySignIn_WrongChallenge(t *testing.T) {
+	RegisterT(t)
+
+	authenticator := newTestAuthenticator()
+	_, token := beginPasskeySignIn()
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserPasskeyByCredentialID) error {
+		q.Result = authenticator.storedPasskey(mock.JonSnow.ID)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	code, response := server.
+		WithURL("http://demo.test.fider.io:3000/_api/signin/passkey/finish").
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookiePasskeyName, token).
+		ExecutePost(handlers.FinishPasskeySignIn(), authenticator.assertion(b64.EncodeToString([]byte("some-other-challenge")), mock.JonSnow.ID))
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectFiderAuthCookie(response, nil)
+}
+
+func TestFinishPasskeySignIn_WithoutChallenge(t *testing.T) {
+	RegisterT(t)
+
+	authenticator := newTestAuthenticator()
+	server := mock.NewServer()
+	code, response := server.
+		WithURL("http://demo.test.fider.io:3000/_api/signin/passkey/finish").
+		OnTenant(mock.DemoTenant).
+		ExecutePost(handlers.FinishPasskeySignIn(), authenticator.assertion("challenge", mock.JonSnow.ID))
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectFiderAuthCookie(response, nil)
+}
@@ -223,6 +223,7 @@ func PostDetails() web.HandlerFunc {
 		data := web.Map{
 			"comments":      getComments.Result,
 			"subscribed":    isSubscribed.Result,
+			"muted":         isSubscribed.Muted,
 			"post":          getPost.Result,
 			"tags":          getAllTags.Result,
 			"votes":         votes,
@@ -241,8 +242,9 @@ func PostDetails() web.HandlerFunc {
 				CommentIDs: commentIDs,
 			}
 			countToday := &query.CountUserReportsToday{UserID: c.User().ID}
+			accuracy := &query.GetUserReportAccuracy{UserID: c.User().ID}
 
-			if err := bus.Dispatch(c, reportedItems, countToday); err != nil {
+			if err := bus.Dispatch(c, reportedItems, countToday, accuracy); err != nil {
 				return c.Failure(err)
 			}
 
@@ -251,6 +253,7 @@ func PostDetails() web.HandlerFunc {
 			if tenant.GeneralSettings != nil && tenant.GeneralSettings.ReportLimitsPerDay > 0 {
 				dailyLimit = tenant.GeneralSettings.ReportLimitsPerDay
 			}
+			dailyLimit = accuracy.Result.DailyReportLimit(dailyLimit)
 
 			data["reportStatus"] = web.Map{
 				"hasReportedPost":    reportedItems.HasReportedPost,
@@ -53,10 +53,15 @@ func ReportPost() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			if err := autoHideReportedContent(c, createReport.ResultCaseID, enum.ReportTypePost, getPost.Result.ID); err != nil {
+				return c.Failure(err)
+			}
+
 			c.Enqueue(tasks.NotifyAboutNewReport(createReport.Result, enum.ReportTypePost, getPost.Result.ID, action.Reason))
 
 			sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgReportNew, sse.ReportEventPayload{
 				ReportID:     createReport.Result,
+				CaseID:       createReport.ResultCaseID,
 				ReportedType: "post",
 				ReportedID:   getPost.Result.ID,
 				Reason:       action.Reason,
@@ -107,10 +112,15 @@ func ReportComment() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			if err := autoHideReportedContent(c, createReport.ResultCaseID, enum.ReportTypeComment, commentID); err != nil {
+				return c.Failure(err)
+			}
+
 			c.Enqueue(tasks.NotifyAboutNewReport(createReport.Result, enum.ReportTypeComment, commentID, action.Reason))
 
 			sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgReportNew, sse.ReportEventPayload{
 				ReportID:     createReport.Result,
+				CaseID:       createReport.ResultCaseID,
 				ReportedType: "comment",
 				ReportedID:   commentID,
 				Reason:       action.Reason,
@@ -121,6 +131,37 @@ func ReportComment() web.HandlerFunc {
 	}
 }
 
+// autoHideReportedContent hides the reported item pending moderation once enough
+// distinct trusted users have reported it. Each case is only auto-hidden once.
+func autoHideReportedContent(c *web.Context, caseID int, reportedType enum.ReportType, reportedID int) error {
+	settings := c.Tenant().GeneralSettings
+	if caseID == 0 || settings == nil || settings.ReportAutoHideThreshold <= 0 {
+		return nil
+	}
+
+	countTrusted := &query.CountTrustedCaseReporters{CaseID: caseID}
+	if err := bus.Dispatch(c, countTrusted); err != nil {
+		return err
+	}
+	if countTrusted.Result < settings.ReportAutoHideThreshold {
+		return nil
+	}
+
+	markHidden := &cmd.MarkReportCaseAutoHidden{CaseID: caseID}
+	if err := bus.Dispatch(c, markHidden); err != nil {
+		return err
+	}
+	if !markHidden.Result {
+		return nil
+	}
+
+	return bus.Dispatch(c, &cmd.SetModerationPending{
+		ContentType: reportedType.String(),
+		ContentID:   reportedID,
+		Pending:     true,
+	})
+}
+
 func ListReports() web.HandlerFunc {
 	return func(c *web.Context) error {
 		page, _ := c.QueryParamAsInt("page")
@@ -328,14 +369,7 @@ func ResolveReport() web.HandlerFunc {
 
 			getReport := &query.GetReportByID{ReportID: reportID}
 			if err := bus.Dispatch(c, getReport); err == nil {
-				c.Enqueue(tasks.NotifyAboutReportResolved(
-					getReport.Result.ID,
-					status,
-					action.ResolutionNote,
-					getReport.Result.ReportedType,
-					getReport.Result.ReportedID,
-					getReport.Result.Reason,
-				))
+				c.Enqueue(tasks.NotifyAboutReportResolved(getReport.Result, status, action.ResolutionNote))
 			}
 
 			sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgReportResolved, sse.ReportEventPayload{
@@ -348,6 +382,127 @@ func ResolveReport() web.HandlerFunc {
 	}
 }
 
+func ListReportCases() web.HandlerFunc {
+	return func(c *web.Context) error {
+		page, _ := c.QueryParamAsInt("page")
+		perPage, _ := c.QueryParamAsInt("perPage")
+
+		if page < 1 {
+			page = 1
+		}
+		if perPage < 1 || perPage > 50 {
+			perPage = 20
+		}
+
+		var statuses []enum.ReportStatus
+		for _, sp := range strings.Split(c.QueryParam("status"), ",") {
+			sp = strings.TrimSpace(sp)
+			if sp != "" {
+				var status enum.ReportStatus
+				_ = status.UnmarshalText([]byte(sp))
+				if status != 0 {
+					statuses = append(statuses, status)
+				}
+			}
+		}
+
+		var reportType enum.ReportType
+		if typeParam := c.QueryParam("type"); typeParam != "" {
+			_ = reportType.UnmarshalText([]byte(typeParam))
+		}
+
+		listCases := &query.ListReportCases{
+			Status:  statuses,
+			Type:    reportType,
+			Reason:  c.QueryParam("reason"),
+			Page:    page,
+			PerPage: perPage,
+		}
+
+		if err := bus.Dispatch(c, listCases); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{
+			"cases":   listCases.Result,
+			"total":   listCases.Total,
+			"page":    page,
+			"perPage": perPage,
+		})
+	}
+}
+
+func GetReportCase() web.HandlerFunc {
+	return func(c *web.Context) error {
+		caseID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getCase := &query.GetReportCaseByID{CaseID: caseID}
+		if err := bus.Dispatch(c, getCase); err != nil {
+			return c.NotFound()
+		}
+
+		return c.Ok(getCase.Result)
+	}
+}
+
+// ResolveReportCase resolves or dismisses every open report of a case at once
+func ResolveReportCase() web.HandlerFunc {
+	return func(c *web.Context) error {
+		caseID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		action := new(actions.ResolveReportCase)
+		action.CaseID = caseID
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		var status enum.ReportStatus
+		_ = status.UnmarshalText([]byte(action.Status))
+
+		return c.WithTransaction(func() error {
+			getCase := &query.GetReportCaseByID{CaseID: caseID}
+			if err := bus.Dispatch(c, getCase); err != nil {
+				return c.NotFound()
+			}
+
+			reportCase := getCase.Result
+			if !reportCase.IsOpen() {
+				return c.BadRequest(web.Map{"message": "Report case is already closed"})
+			}
+
+			resolveCase := &cmd.ResolveReportCase{
+				CaseID:         caseID,
+				Status:         status,
+				ResolutionNote: action.ResolutionNote,
+			}
+			if err := bus.Dispatch(c, resolveCase); err != nil {
+				return c.Failure(err)
+			}
+
+			c.Enqueue(tasks.NotifyAboutReportCaseResolved(reportCase, status, action.ResolutionNote))
+
+			for _, report := range reportCase.Reports {
+				if report.Status != enum.ReportStatusPending && report.Status != enum.ReportStatusInReview {
+					continue
+				}
+				sse.GetHub().BroadcastToTenant(c.Tenant().ID, sse.MsgReportResolved, sse.ReportEventPayload{
+					ReportID: report.ID,
+					CaseID:   caseID,
+					Status:   action.Status,
+				})
+			}
+
+			return c.Ok(web.Map{})
+		})
+	}
+}
+
 func GetReportReasons() web.HandlerFunc {
 	return func(c *web.Context) error {
 		getReasons := &query.GetReportReasons{}
@@ -0,0 +1,253 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+)
+
+// setupReportPost registers the handlers needed to report post #1 of Jon Snow and returns
+// the tenant to report it on, with the auto-hide threshold set to given value
+func setupReportPost(threshold int, trustedReporters int, alreadyHidden bool) (*entity.Tenant, *bool, *cmd.SetModerationPending) {
+	post := &entity.Post{ID: 10, Number: 1, Title: "My Post Title", User: mock.JonSnow}
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByNumber) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetPostByID) error {
+		q.Result = post
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetReportReasons) error {
+		q.Result = []*entity.ReportReason{{ID: 1, Slug: "spam", Title: "Spam"}}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.HasUserReportedTarget) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserReportAccuracy) error {
+		q.Result = entity.NewReportAccuracy(0, 0)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.CountUserReportsToday) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateReport) error {
+		c.Result = 1
+		c.ResultCaseID = 5
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.CountTrustedCaseReporters) error {
+		q.Result = trustedReporters
+		return nil
+	})
+
+	marked := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.MarkReportCaseAutoHidden) error {
+		marked = true
+		c.Result = !alreadyHidden
+		return nil
+	})
+
+	pending := &cmd.SetModerationPending{}
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetModerationPending) error {
+		*pending = *c
+		return nil
+	})
+
+	tenant := *mock.DemoTenant
+	tenant.GeneralSettings = &entity.GeneralSettings{ReportAutoHideThreshold: threshold}
+	return &tenant, &marked, pending
+}
+
+func TestReportPostHandler_AutoHidesAtThreshold(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	tenant, marked, pending := setupReportPost(2, 2, false)
+
+	code, _ := server.
+		OnTenant(tenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(handlers.ReportPost(), `{ "reason": "Spam" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*marked).IsTrue()
+	Expect(pending.ContentType).Equals("post")
+	Expect(pending.ContentID).Equals(10)
+	Expect(pending.Pending).IsTrue()
+}
+
+func TestReportPostHandler_BelowThreshold(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	tenant, marked, pending := setupReportPost(3, 2, false)
+
+	code, _ := server.
+		OnTenant(tenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(handlers.ReportPost(), `{ "reason": "Spam" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*marked).IsFalse()
+	Expect(pending.Pending).IsFalse()
+}
+
+func TestReportPostHandler_CaseAlreadyAutoHidden(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	tenant, marked, pending := setupReportPost(2, 3, true)
+
+	code, _ := server.
+		OnTenant(tenant).
+		AsUser(mock.AryaStark).
+		AddParam("number", 1).
+		ExecutePost(handlers.ReportPost(), `{ "reason": "Spam" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(*marked).IsTrue()
+	Expect(pending.Pending).IsFalse()
+}
+
+func TestListReportCasesHandler(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	var listCases *query.ListReportCases
+	bus.AddHandler(func(ctx context.Context, q *query.ListReportCases) error {
+		listCases = q
+		q.Result = []*entity.ReportCase{
+			{ID: 5, ReportedType: enum.ReportTypeComment, ReportedID: 20, Status: enum.ReportStatusPending, ReportCount: 3, ReporterCount: 2},
+		}
+		q.Total = 1
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithURL("http://demo.test.fider.io/api/v1/report-cases?status=pending,in_review&type=comment&reason=Spam&perPage=100").
+		ExecuteAsJSON(handlers.ListReportCases())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(listCases.Status).Equals([]enum.ReportStatus{enum.ReportStatusPending, enum.ReportStatusInReview})
+	Expect(listCases.Type).Equals(enum.ReportTypeComment)
+	Expect(listCases.Reason).Equals("Spam")
+	Expect(listCases.PerPage).Equals(20)
+	Expect(response.Int32("total")).Equals(1)
+	Expect(response.Int32("cases[0].id")).Equals(5)
+	Expect(response.Int32("cases[0].reporterCount")).Equals(2)
+}
+
+func TestGetReportCaseHandler_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, q *query.GetNavigationLinks) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", "abc").
+		Execute(handlers.GetReportCase())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestResolveReportCaseHandler_InvalidStatus(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.ResolveReportCase) error {
+		dispatched = true
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePost(handlers.ResolveReportCase(), `{ "status": "pending" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(dispatched).IsFalse()
+}
+
+func TestResolveReportCaseHandler_AlreadyClosed(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, q *query.GetReportCaseByID) error {
+		q.Result = &entity.ReportCase{ID: q.CaseID, Status: enum.ReportStatusDismissed}
+		return nil
+	})
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.ResolveReportCase) error {
+		dispatched = true
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePost(handlers.ResolveReportCase(), `{ "status": "resolved" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(dispatched).IsFalse()
+}
+
+func TestResolveReportCaseHandler_Open(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, q *query.GetReportCaseByID) error {
+		q.Result = &entity.ReportCase{
+			ID:     q.CaseID,
+			Status: enum.ReportStatusInReview,
+			Reports: []*entity.Report{
+				{ID: 1, Status: enum.ReportStatusInReview, Reporter: mock.AryaStark},
+			},
+		}
+		return nil
+	})
+
+	var resolveCase *cmd.ResolveReportCase
+	bus.AddHandler(func(ctx context.Context, c *cmd.ResolveReportCase) error {
+		resolveCase = c
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("id", 5).
+		ExecutePost(handlers.ResolveReportCase(), `{ "status": "resolved", "resolutionNote": "Removed the spam" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(resolveCase.CaseID).Equals(5)
+	Expect(resolveCase.Status).Equals(enum.ReportStatusResolved)
+	Expect(resolveCase.ResolutionNote).Equals("Removed the spam")
+}
@@ -0,0 +1,921 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/scim"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+)
+
+// scimMaxGroupMembers is how many members of a group are listed, groups are meant for staff roles
+const scimMaxGroupMembers = 1000
+
+// scimError is a client error reported to the identity provider as a SCIM error response
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func scimBadRequest(scimType, detail string, args ...any) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(detail, args...)}
+}
+
+func scimConflict(detail string) error {
+	return &scimError{status: http.StatusConflict, scimType: "uniqueness", detail: detail}
+}
+
+// scimFailure writes the SCIM error response of given error, unexpected errors are returned to be logged
+func scimFailure(c *web.Context, err error) error {
+	if e, ok := err.(*scimError); ok {
+		return webutil.SCIMError(c, e.status, e.scimType, e.detail)
+	}
+	if errors.Cause(err) == app.ErrNotFound {
+		return webutil.SCIMError(c, http.StatusNotFound, "", "Resource not found")
+	}
+	if renderErr := webutil.SCIMError(c, http.StatusInternalServerError, "", "An error has occurred"); renderErr != nil {
+		return renderErr
+	}
+	return err
+}
+
+// scimTransaction runs fn in a transaction, which is rolled back before the SCIM error response of a failure is written
+func scimTransaction(c *web.Context, fn func() error) error {
+	if err := c.WithTransaction(fn); err != nil {
+		return scimFailure(c, err)
+	}
+	return nil
+}
+
+// scimGroup is a group identity providers can assign users to, groups map to roles and visual roles
+type scimGroup struct {
+	id          string
+	displayName string
+	role        enum.Role
+	visualRole  enum.VisualRole
+}
+
+// scimGroups are fixed, identity providers can change their members but can't create or delete them
+var scimGroups = func() []scimGroup {
+	groups := []scimGroup{
+		{id: "role-helper", displayName: "Helper", role: enum.RoleHelper},
+		{id: "role-moderator", displayName: "Moderator", role: enum.RoleModerator},
+		{id: "role-collaborator", displayName: "Collaborator", role: enum.RoleCollaborator},
+		{id: "role-administrator", displayName: "Administrator", role: enum.RoleAdministrator},
+	}
+	for _, visualRole := range enum.AllVisualRoles {
+		if visualRole != enum.VisualRoleVisitor {
+			groups = append(groups, scimGroup{
+				id:          "visual-" + strings.ToLower(visualRole.String()),
+				displayName: "Visual Role: " + visualRole.String(),
+				visualRole:  visualRole,
+			})
+		}
+	}
+	return groups
+}()
+
+func getSCIMGroup(id string) (scimGroup, error) {
+	for _, group := range scimGroups {
+		if group.id == id {
+			return group, nil
+		}
+	}
+	return scimGroup{}, app.ErrNotFound
+}
+
+func (g scimGroup) includes(user *entity.User) bool {
+	if g.role != 0 {
+		return user.Role == g.role
+	}
+	return user.VisualRole == g.visualRole
+}
+
+func (g scimGroup) location(c *web.Context) string {
+	return c.BaseURL() + "/scim/v2/Groups/" + g.id
+}
+
+func (g scimGroup) addMember(c *web.Context, user *entity.User) error {
+	if g.includes(user) {
+		return nil
+	}
+	if g.role != 0 {
+		return changeSCIMUserRole(c, user.ID, g.role)
+	}
+	return bus.Dispatch(c, &cmd.ChangeUserVisualRole{UserID: user.ID, VisualRole: g.visualRole})
+}
+
+func (g scimGroup) removeMember(c *web.Context, user *entity.User) error {
+	if !g.includes(user) {
+		return nil
+	}
+	if g.role != 0 {
+		return changeSCIMUserRole(c, user.ID, enum.RoleVisitor)
+	}
+	return bus.Dispatch(c, &cmd.ChangeUserVisualRole{UserID: user.ID, VisualRole: enum.VisualRoleNone})
+}
+
+// changeSCIMUserRole has the same effects as an administrator changing the role
+func changeSCIMUserRole(c *web.Context, userID int, role enum.Role) error {
+	if err := bus.Dispatch(c, &cmd.ChangeUserRole{UserID: userID, Role: role}); err != nil {
+		return err
+	}
+
+	if role == enum.RoleVisitor {
+		if err := webutil.RevokeUserSessions(c, userID, ""); err != nil {
+			return err
+		}
+	}
+
+	if env.Config.UserList.Enabled {
+		c.Enqueue(tasks.UserListAddOrRemoveUser(userID, role))
+	}
+	return nil
+}
+
+func (g scimGroup) members(c *web.Context) ([]*entity.SCIMUser, error) {
+	listMembers := &query.SearchSCIMUsers{Role: g.role, VisualRole: g.visualRole, Limit: scimMaxGroupMembers}
+	if err := bus.Dispatch(c, listMembers); err != nil {
+		return nil, err
+	}
+	return listMembers.Result, nil
+}
+
+func (g scimGroup) toResource(c *web.Context, withMembers bool) (*scim.Group, error) {
+	resource := &scim.Group{
+		Schemas:     []string{scim.GroupSchema},
+		ID:          g.id,
+		DisplayName: g.displayName,
+		Members:     []scim.Reference{},
+		Meta:        &scim.Meta{ResourceType: "Group", Location: g.location(c)},
+	}
+
+	if withMembers {
+		members, err := g.members(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			resource.Members = append(resource.Members, scim.Reference{
+				Value:   strconv.Itoa(member.User.ID),
+				Display: member.User.Name,
+				Ref:     scimUserLocation(c, member.User.ID),
+			})
+		}
+	}
+
+	return resource, nil
+}
+
+func scimUserLocation(c *web.Context, userID int) string {
+	return c.BaseURL() + "/scim/v2/Users/" + strconv.Itoa(userID)
+}
+
+func toSCIMUser(c *web.Context, u *entity.SCIMUser) *scim.User {
+	id := strconv.Itoa(u.User.ID)
+	active := u.User.Status != enum.UserBlocked
+
+	// users who signed in with a provider that doesn't share emails are still listed, by their id
+	userName := u.User.Email
+	if userName == "" {
+		userName = id
+	}
+
+	resource := &scim.User{
+		Schemas:     []string{scim.UserSchema},
+		ID:          id,
+		ExternalID:  u.ExternalID,
+		UserName:    userName,
+		DisplayName: u.User.Name,
+		Name:        &scim.Name{Formatted: u.User.Name},
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &u.CreatedAt,
+			Location:     scimUserLocation(c, u.User.ID),
+		},
+	}
+
+	if u.User.Email != "" {
+		resource.Emails = []scim.Email{{Value: u.User.Email, Type: "work", Primary: true}}
+	}
+
+	for _, group := range scimGroups {
+		if group.includes(u.User) {
+			resource.Groups = append(resource.Groups, scim.Reference{
+				Value:   group.id,
+				Display: group.displayName,
+				Ref:     group.location(c),
+			})
+		}
+	}
+
+	return resource
+}
+
+func getSCIMUser(c *web.Context, id string) (*entity.SCIMUser, error) {
+	userID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, app.ErrNotFound
+	}
+
+	getUser := &query.SearchSCIMUsers{UserID: userID, Limit: 1}
+	if err := bus.Dispatch(c, getUser); err != nil {
+		return nil, err
+	}
+	if len(getUser.Result) == 0 {
+		return nil, app.ErrNotFound
+	}
+	return getUser.Result[0], nil
+}
+
+// deprovisionSCIMUser blocks the user and signs them out of every browser
+// Blocked users can't use the site, nor their API tokens and the apps they authorized
+func deprovisionSCIMUser(c *web.Context, user *entity.User) error {
+	if user.Status != enum.UserBlocked {
+		if err := bus.Dispatch(c, &cmd.BlockUser{UserID: user.ID}); err != nil {
+			return err
+		}
+	}
+	return webutil.RevokeUserSessions(c, user.ID, "")
+}
+
+// scimUserChanges are the attributes of a user an identity provider asked to change, nil ones are left as they are
+type scimUserChanges struct {
+	name       *string
+	email      *string
+	externalID *string
+	active     *bool
+}
+
+func scimUserChangesFrom(input *scim.User) *scimUserChanges {
+	name, email, externalID := input.FullName(), input.PrimaryEmail(), input.ExternalID
+	return &scimUserChanges{name: &name, email: &email, externalID: &externalID, active: input.Active}
+}
+
+// set changes the attribute at given lowercase path
+// Attributes that aren't stored, like phone numbers or the enterprise extension, are ignored as identity providers send many of them
+func (ch *scimUserChanges) set(path string, value json.RawMessage) error {
+	switch {
+	case path == "active":
+		active, ok := scim.ParseBool(value)
+		if !ok {
+			return scimBadRequest("invalidValue", "active must be a boolean")
+		}
+		ch.active = &active
+	case path == "displayname" || path == "name.formatted":
+		name, ok := scim.ParseString(value)
+		if !ok {
+			return scimBadRequest("invalidValue", "%s must be a string", path)
+		}
+		ch.name = &name
+	case path == "name":
+		var name scim.Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return scimBadRequest("invalidValue", "name must be an object")
+		}
+		if fullName := (&scim.User{Name: &name}).FullName(); fullName != "" {
+			ch.name = &fullName
+		}
+	case path == "username":
+		email, ok := scim.ParseString(value)
+		if !ok {
+			return scimBadRequest("invalidValue", "userName must be a string")
+		}
+		ch.email = &email
+	case strings.HasPrefix(path, "emails"):
+		if email, ok := scim.ParseString(value); ok {
+			ch.email = &email
+			return nil
+		}
+		var emails []scim.Email
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return scimBadRequest("invalidValue", "emails must be a list of emails")
+		}
+		if email := (&scim.User{Emails: emails}).PrimaryEmail(); email != "" {
+			ch.email = &email
+		}
+	case path == "externalid":
+		externalID, ok := scim.ParseString(value)
+		if !ok {
+			return scimBadRequest("invalidValue", "externalId must be a string")
+		}
+		ch.externalID = &externalID
+	}
+	return nil
+}
+
+func scimUserChangesFromPatch(operations []scim.PatchOperation) (*scimUserChanges, error) {
+	ch := &scimUserChanges{}
+	for _, op := range operations {
+		switch op.Operation() {
+		case "add", "replace":
+			if op.Path != "" {
+				if err := ch.set(op.Attribute(), op.Value); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var values map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &values); err != nil {
+				return nil, scimBadRequest("invalidValue", "value must be an object when there is no path")
+			}
+			for path, value := range values {
+				if err := ch.set(strings.ToLower(path), value); err != nil {
+					return nil, err
+				}
+			}
+		case "remove":
+			if op.Attribute() != "externalid" {
+				return nil, scimBadRequest("mutability", "Only externalId can be removed")
+			}
+			empty := ""
+			ch.externalID = &empty
+		default:
+			return nil, scimBadRequest("invalidSyntax", "Operation '%s' is not supported", op.Op)
+		}
+	}
+	return ch, nil
+}
+
+// validate normalizes the changes and checks they can be made to the user with given id, or to a new user when it is 0
+func (ch *scimUserChanges) validate(c *web.Context, userID int) error {
+	if ch.name != nil {
+		*ch.name = strings.TrimSpace(*ch.name)
+		if *ch.name == "" || len(*ch.name) > 100 {
+			return scimBadRequest("invalidValue", "Name is required and must have less than 100 characters")
+		}
+	}
+
+	if ch.email != nil {
+		*ch.email = strings.ToLower(strings.TrimSpace(*ch.email))
+		if messages := validate.Email(c, *ch.email); len(messages) > 0 {
+			return scimBadRequest("invalidValue", "userName must be an email address: %s", messages[0])
+		}
+
+		getUser := &query.GetUserByEmail{Email: *ch.email}
+		err := bus.Dispatch(c, getUser)
+		if err == nil && getUser.Result.ID != userID {
+			return scimConflict("A user with this userName already exists")
+		}
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return err
+		}
+	}
+
+	if ch.externalID != nil && *ch.externalID != "" {
+		if len(*ch.externalID) > 200 {
+			return scimBadRequest("invalidValue", "externalId must have less than 200 characters")
+		}
+
+		getUser := &query.SearchSCIMUsers{ExternalID: *ch.externalID, Limit: 1}
+		if err := bus.Dispatch(c, getUser); err != nil {
+			return err
+		}
+		if len(getUser.Result) > 0 && getUser.Result[0].User.ID != userID {
+			return scimConflict("A user with this externalId already exists")
+		}
+	}
+
+	return nil
+}
+
+func (ch *scimUserChanges) apply(c *web.Context, user *entity.SCIMUser) error {
+	if ch.name != nil && *ch.name != user.User.Name {
+		if err := bus.Dispatch(c, &cmd.UpdateUser{UserID: user.User.ID, Name: *ch.name}); err != nil {
+			return err
+		}
+	}
+
+	if ch.email != nil && *ch.email != user.User.Email {
+		if err := bus.Dispatch(c, &cmd.ChangeUserEmail{UserID: user.User.ID, Email: *ch.email}); err != nil {
+			return err
+		}
+	}
+
+	if ch.externalID != nil && *ch.externalID != user.ExternalID {
+		if err := bus.Dispatch(c, &cmd.SetSCIMExternalID{UserID: user.User.ID, ExternalID: *ch.externalID}); err != nil {
+			return err
+		}
+	}
+
+	if ch.active != nil {
+		if !*ch.active {
+			if err := deprovisionSCIMUser(c, user.User); err != nil {
+				return err
+			}
+		} else if user.User.Status == enum.UserBlocked {
+			if err := bus.Dispatch(c, &cmd.UnblockUser{UserID: user.User.ID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applySCIMUserFilter narrows the search to the users matching filter
+// false is returned when the filter can't match any user, like when it compares the same attribute to different values
+func applySCIMUserFilter(q *query.SearchSCIMUsers, filter string) (bool, error) {
+	comparisons, err := scim.ParseFilter(filter)
+	if err != nil {
+		return false, scimBadRequest("invalidFilter", "Only eq comparisons joined by and are supported")
+	}
+
+	matchable := true
+	set := func(field *string, value string) {
+		if *field != "" && !strings.EqualFold(*field, value) {
+			matchable = false
+		}
+		*field = value
+	}
+
+	for _, comparison := range comparisons {
+		switch comparison.Attribute {
+		case "username", "emails", "emails.value":
+			set(&q.Email, comparison.Value)
+		case "externalid":
+			set(&q.ExternalID, comparison.Value)
+		case "displayname", "name.formatted":
+			set(&q.Name, comparison.Value)
+		case "id":
+			userID, err := strconv.Atoi(comparison.Value)
+			if err != nil || (q.UserID != 0 && q.UserID != userID) {
+				matchable = false
+			}
+			q.UserID = userID
+		default:
+			return false, scimBadRequest("invalidFilter", "Filtering on '%s' is not supported", comparison.Attribute)
+		}
+	}
+
+	return matchable, nil
+}
+
+// SCIMServiceProviderConfig describes which parts of the SCIM spec are supported
+func SCIMServiceProviderConfig() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return webutil.SCIMResponse(c, http.StatusOK, web.Map{
+			"schemas":        []string{scim.ServiceProviderConfigSchema},
+			"patch":          web.Map{"supported": true},
+			"bulk":           web.Map{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+			"filter":         web.Map{"supported": true, "maxResults": scim.MaxCount},
+			"changePassword": web.Map{"supported": false},
+			"sort":           web.Map{"supported": false},
+			"etag":           web.Map{"supported": false},
+			"authenticationSchemes": []web.Map{{
+				"type":        "oauthbearertoken",
+				"name":        "Bearer Token",
+				"description": "The SCIM token generated on the Authentication page of the site settings",
+				"primary":     true,
+			}},
+			"meta": web.Map{
+				"resourceType": "ServiceProviderConfig",
+				"location":     c.BaseURL() + "/scim/v2/ServiceProviderConfig",
+			},
+		})
+	}
+}
+
+// SCIMListUsers returns a page of the users matching the filter
+func SCIMListUsers() web.HandlerFunc {
+	return func(c *web.Context) error {
+		searchUsers := &query.SearchSCIMUsers{}
+		startIndex, count := scim.ParsePagination(c.QueryParam("startIndex"), c.QueryParam("count"))
+
+		if filter := c.QueryParam("filter"); filter != "" {
+			matchable, err := applySCIMUserFilter(searchUsers, filter)
+			if err != nil {
+				return scimFailure(c, err)
+			}
+			if !matchable {
+				return webutil.SCIMResponse(c, http.StatusOK, scim.NewListResponse(nil, 0, startIndex))
+			}
+		}
+
+		searchUsers.Offset = startIndex - 1
+		searchUsers.Limit = count
+		if err := bus.Dispatch(c, searchUsers); err != nil {
+			return scimFailure(c, err)
+		}
+
+		resources := make([]any, len(searchUsers.Result))
+		for i, user := range searchUsers.Result {
+			resources[i] = toSCIMUser(c, user)
+		}
+
+		return webutil.SCIMResponse(c, http.StatusOK, scim.NewListResponse(resources, searchUsers.TotalResults, startIndex))
+	}
+}
+
+// SCIMGetUser returns a single user
+func SCIMGetUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		user, err := getSCIMUser(c, c.Param("id"))
+		if err != nil {
+			return scimFailure(c, err)
+		}
+		return webutil.SCIMResponse(c, http.StatusOK, toSCIMUser(c, user))
+	}
+}
+
+// SCIMCreateUser provisions a new user, as a visitor until the identity provider adds them to a group
+func SCIMCreateUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.User)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid user"))
+		}
+
+		return scimTransaction(c, func() error {
+			changes := scimUserChangesFrom(input)
+			if err := changes.validate(c, 0); err != nil {
+				return err
+			}
+
+			user := &entity.User{
+				Tenant: c.Tenant(),
+				Name:   *changes.name,
+				Email:  *changes.email,
+				Role:   enum.RoleVisitor,
+			}
+			if err := bus.Dispatch(c, &cmd.RegisterUser{User: user}); err != nil {
+				return err
+			}
+
+			created, err := getSCIMUser(c, strconv.Itoa(user.ID))
+			if err != nil {
+				return err
+			}
+			if err := changes.apply(c, created); err != nil {
+				return err
+			}
+
+			created, err = getSCIMUser(c, strconv.Itoa(user.ID))
+			if err != nil {
+				return err
+			}
+			return webutil.SCIMResponse(c, http.StatusCreated, toSCIMUser(c, created))
+		})
+	}
+}
+
+// SCIMReplaceUser replaces the attributes of a user
+func SCIMReplaceUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.User)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid user"))
+		}
+
+		return scimTransaction(c, func() error {
+			user, err := getSCIMUser(c, c.Param("id"))
+			if err != nil {
+				return err
+			}
+			return updateSCIMUser(c, user, scimUserChangesFrom(input))
+		})
+	}
+}
+
+// SCIMPatchUser changes some attributes of a user, deactivating a user deprovisions them
+func SCIMPatchUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.PatchRequest)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid patch request"))
+		}
+
+		return scimTransaction(c, func() error {
+			user, err := getSCIMUser(c, c.Param("id"))
+			if err != nil {
+				return err
+			}
+
+			changes, err := scimUserChangesFromPatch(input.Operations)
+			if err != nil {
+				return err
+			}
+			return updateSCIMUser(c, user, changes)
+		})
+	}
+}
+
+func updateSCIMUser(c *web.Context, user *entity.SCIMUser, changes *scimUserChanges) error {
+	if err := changes.validate(c, user.User.ID); err != nil {
+		return err
+	}
+	if err := changes.apply(c, user); err != nil {
+		return err
+	}
+
+	updated, err := getSCIMUser(c, strconv.Itoa(user.User.ID))
+	if err != nil {
+		return err
+	}
+	return webutil.SCIMResponse(c, http.StatusOK, toSCIMUser(c, updated))
+}
+
+// SCIMDeleteUser deprovisions a user, their content stays and they keep being listed as inactive
+func SCIMDeleteUser() web.HandlerFunc {
+	return func(c *web.Context) error {
+		return scimTransaction(c, func() error {
+			user, err := getSCIMUser(c, c.Param("id"))
+			if err != nil {
+				return err
+			}
+			if err := deprovisionSCIMUser(c, user.User); err != nil {
+				return err
+			}
+			return c.NoContent(http.StatusNoContent)
+		})
+	}
+}
+
+// SCIMListGroups returns a page of the groups matching the filter
+func SCIMListGroups() web.HandlerFunc {
+	return func(c *web.Context) error {
+		groups := scimGroups
+		if filter := c.QueryParam("filter"); filter != "" {
+			comparisons, err := scim.ParseFilter(filter)
+			if err != nil {
+				return scimFailure(c, scimBadRequest("invalidFilter", "Only eq comparisons joined by and are supported"))
+			}
+
+			groups = make([]scimGroup, 0)
+		nextGroup:
+			for _, group := range scimGroups {
+				for _, comparison := range comparisons {
+					switch comparison.Attribute {
+					case "displayname":
+						if !strings.EqualFold(group.displayName, comparison.Value) {
+							continue nextGroup
+						}
+					case "id":
+						if group.id != comparison.Value {
+							continue nextGroup
+						}
+					default:
+						return scimFailure(c, scimBadRequest("invalidFilter", "Filtering on '%s' is not supported", comparison.Attribute))
+					}
+				}
+				groups = append(groups, group)
+			}
+		}
+
+		startIndex, count := scim.ParsePagination(c.QueryParam("startIndex"), c.QueryParam("count"))
+		page := make([]any, 0)
+		withMembers := !strings.Contains(strings.ToLower(c.QueryParam("excludedAttributes")), "members")
+		for i := startIndex - 1; i < len(groups) && len(page) < count; i++ {
+			resource, err := groups[i].toResource(c, withMembers)
+			if err != nil {
+				return scimFailure(c, err)
+			}
+			page = append(page, resource)
+		}
+
+		return webutil.SCIMResponse(c, http.StatusOK, scim.NewListResponse(page, len(groups), startIndex))
+	}
+}
+
+// SCIMGetGroup returns a single group with its members
+func SCIMGetGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		group, err := getSCIMGroup(c.Param("id"))
+		if err != nil {
+			return scimFailure(c, err)
+		}
+
+		resource, err := group.toResource(c, true)
+		if err != nil {
+			return scimFailure(c, err)
+		}
+		return webutil.SCIMResponse(c, http.StatusOK, resource)
+	}
+}
+
+// SCIMCreateGroup rejects new groups, identity providers that push groups are told which one already exists
+func SCIMCreateGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.Group)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid group"))
+		}
+
+		for _, group := range scimGroups {
+			if strings.EqualFold(group.displayName, input.DisplayName) {
+				return scimFailure(c, scimConflict(fmt.Sprintf("Group already exists with id '%s'", group.id)))
+			}
+		}
+		return scimFailure(c, scimBadRequest("invalidValue", "Groups can't be created, only the groups of the roles and visual roles exist"))
+	}
+}
+
+// SCIMDeleteGroup rejects deleting groups, they map to roles and visual roles
+func SCIMDeleteGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if _, err := getSCIMGroup(c.Param("id")); err != nil {
+			return scimFailure(c, err)
+		}
+		return scimFailure(c, scimBadRequest("mutability", "Groups can't be deleted, they map to roles and visual roles"))
+	}
+}
+
+// SCIMReplaceGroup makes the given users the only members of a group
+func SCIMReplaceGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.Group)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid group"))
+		}
+
+		return scimTransaction(c, func() error {
+			group, err := getSCIMGroup(c.Param("id"))
+			if err != nil {
+				return err
+			}
+			if input.DisplayName != "" && input.DisplayName != group.displayName {
+				return scimBadRequest("mutability", "displayName of groups can't be changed")
+			}
+
+			if err := replaceSCIMGroupMembers(c, group, input.Members); err != nil {
+				return err
+			}
+
+			resource, err := group.toResource(c, true)
+			if err != nil {
+				return err
+			}
+			return webutil.SCIMResponse(c, http.StatusOK, resource)
+		})
+	}
+}
+
+// scimMemberPathRegex matches paths to a single member, like members[value eq "2"]
+var scimMemberPathRegex = regexp.MustCompile(`(?i)^members\[value eq "([^"]*)"\]$`)
+
+// SCIMPatchGroup adds and removes members of a group
+func SCIMPatchGroup() web.HandlerFunc {
+	return func(c *web.Context) error {
+		input := new(scim.PatchRequest)
+		if err := json.Unmarshal([]byte(c.Request.Body), input); err != nil {
+			return scimFailure(c, scimBadRequest("invalidSyntax", "Request body is not a valid patch request"))
+		}
+
+		return scimTransaction(c, func() error {
+			group, err := getSCIMGroup(c.Param("id"))
+			if err != nil {
+				return err
+			}
+
+			for _, op := range input.Operations {
+				if err := patchSCIMGroup(c, group, op); err != nil {
+					return err
+				}
+			}
+			return c.NoContent(http.StatusNoContent)
+		})
+	}
+}
+
+func patchSCIMGroup(c *web.Context, group scimGroup, op scim.PatchOperation) error {
+	path, value := op.Attribute(), op.Value
+
+	// replacing without a path sends the attributes as an object
+	if path == "" && op.Operation() != "remove" {
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return scimBadRequest("invalidValue", "value must be an object when there is no path")
+		}
+		for key, v := range values {
+			switch strings.ToLower(key) {
+			case "members":
+				path, value = "members", v
+			case "displayname":
+				if name, _ := scim.ParseString(v); name != group.displayName {
+					return scimBadRequest("mutability", "displayName of groups can't be changed")
+				}
+			}
+		}
+		if path == "" {
+			return nil
+		}
+	}
+
+	if path == "displayname" {
+		if name, _ := scim.ParseString(value); op.Operation() == "remove" || name != group.displayName {
+			return scimBadRequest("mutability", "displayName of groups can't be changed")
+		}
+		return nil
+	}
+
+	if matches := scimMemberPathRegex.FindStringSubmatch(op.Path); len(matches) == 2 && op.Operation() == "remove" {
+		return removeSCIMGroupMembers(c, group, []scim.Reference{{Value: matches[1]}})
+	}
+
+	if path != "members" {
+		return scimBadRequest("invalidPath", "Only members of groups can be changed")
+	}
+
+	var members []scim.Reference
+	if len(value) > 0 && string(value) != "null" {
+		if err := json.Unmarshal(value, &members); err != nil {
+			return scimBadRequest("invalidValue", "members must be a list of members")
+		}
+	}
+
+	switch op.Operation() {
+	case "add":
+		for _, member := range members {
+			user, err := getSCIMGroupMember(c, member)
+			if err != nil {
+				return err
+			}
+			if err := group.addMember(c, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "replace":
+		return replaceSCIMGroupMembers(c, group, members)
+	case "remove":
+		if len(members) == 0 {
+			return replaceSCIMGroupMembers(c, group, nil)
+		}
+		return removeSCIMGroupMembers(c, group, members)
+	default:
+		return scimBadRequest("invalidSyntax", "Operation '%s' is not supported", op.Op)
+	}
+}
+
+func getSCIMGroupMember(c *web.Context, member scim.Reference) (*entity.User, error) {
+	user, err := getSCIMUser(c, member.Value)
+	if err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, scimBadRequest("invalidValue", "User '%s' doesn't exist", member.Value)
+		}
+		return nil, err
+	}
+	return user.User, nil
+}
+
+func removeSCIMGroupMembers(c *web.Context, group scimGroup, members []scim.Reference) error {
+	for _, member := range members {
+		user, err := getSCIMGroupMember(c, member)
+		if err != nil {
+			return err
+		}
+		if err := group.removeMember(c, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func replaceSCIMGroupMembers(c *web.Context, group scimGroup, members []scim.Reference) error {
+	keep := make(map[int]bool)
+	for _, member := range members {
+		user, err := getSCIMGroupMember(c, member)
+		if err != nil {
+			return err
+		}
+		if err := group.addMember(c, user); err != nil {
+			return err
+		}
+		keep[user.ID] = true
+	}
+
+	current, err := group.members(c)
+	if err != nil {
+		return err
+	}
+	for _, member := range current {
+		if !keep[member.User.ID] {
+			if err := group.removeMember(c, member.User); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+)
+
+func TestSCIMListUsers_Filter(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	var search *query.SearchSCIMUsers
+	bus.AddHandler(func(ctx context.Context, q *query.SearchSCIMUsers) error {
+		search = q
+		q.Result = []*entity.SCIMUser{{User: mock.JonSnow, ExternalID: "00u1", CreatedAt: time.Now()}}
+		q.TotalResults = 1
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL(`http://demo.test.fider.io/scim/v2/Users?filter=userName+eq+"jon.snow@got.com"&startIndex=1&count=10`).
+		ExecuteAsJSON(handlers.SCIMListUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(search.Email).Equals("jon.snow@got.com")
+	Expect(search.Offset).Equals(0)
+	Expect(search.Limit).Equals(10)
+	Expect(response.Int32("totalResults")).Equals(1)
+	Expect(response.Int32("itemsPerPage")).Equals(1)
+	Expect(response.String("Resources[0].userName")).Equals(mock.JonSnow.Email)
+	Expect(response.String("Resources[0].externalId")).Equals("00u1")
+	Expect(response.Contains("Resources[0].active")).IsTrue()
+	Expect(response.String("Resources[0].groups[0].value")).Equals("role-administrator")
+}
+
+func TestSCIMListUsers_InvalidFilter(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL(`http://demo.test.fider.io/scim/v2/Users?filter=userName+co+"jon"`).
+		ExecuteAsJSON(handlers.SCIMListUsers())
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(response.String("scimType")).Equals("invalidFilter")
+	Expect(response.String("status")).Equals("400")
+}
+
+func TestSCIMListUsers_FilterThatCantMatch(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, q *query.SearchSCIMUsers) error {
+		dispatched = true
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL(`http://demo.test.fider.io/scim/v2/Users?filter=externalId+eq+"a"+and+externalId+eq+"b"`).
+		ExecuteAsJSON(handlers.SCIMListUsers())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(dispatched).IsFalse()
+	Expect(response.Int32("totalResults")).Equals(0)
+}
+
+func TestSCIMGetUser_NotFound(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, q *query.SearchSCIMUsers) error {
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddParam("id", "999").
+		ExecuteAsJSON(handlers.SCIMGetUser())
+
+	Expect(code).Equals(http.StatusNotFound)
+	Expect(response.String("status")).Equals("404")
+}
+
+func TestSCIMGetGroup_Members(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	var search *query.SearchSCIMUsers
+	bus.AddHandler(func(ctx context.Context, q *query.SearchSCIMUsers) error {
+		search = q
+		q.Result = []*entity.SCIMUser{{User: mock.AryaStark}}
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddParam("id", "role-moderator").
+		ExecuteAsJSON(handlers.SCIMGetGroup())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(search.Role).Equals(enum.RoleModerator)
+	Expect(response.String("displayName")).Equals("Moderator")
+	Expect(response.String("members[0].display")).Equals(mock.AryaStark.Name)
+}
+
+func TestSCIMListGroups_FilterWithoutMembers(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, q *query.SearchSCIMUsers) error {
+		dispatched = true
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL(`http://demo.test.fider.io/scim/v2/Groups?filter=displayName+eq+"Visual+Role:+Sherpa"&excludedAttributes=members`).
+		ExecuteAsJSON(handlers.SCIMListGroups())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(dispatched).IsFalse()
+	Expect(response.Int32("totalResults")).Equals(1)
+	Expect(response.String("Resources[0].id")).Equals("visual-sherpa")
+}
+
+func TestSCIMCreateGroup_Existing(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePostAsJSON(handlers.SCIMCreateGroup(), `{ "displayName": "administrator" }`)
+
+	Expect(code).Equals(http.StatusConflict)
+	Expect(response.String("scimType")).Equals("uniqueness")
+}
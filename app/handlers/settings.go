@@ -19,6 +19,7 @@ import (
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
 
 func generateRandomUsername() string {
@@ -290,6 +291,13 @@ func ChangeUserRole() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			// demoted users are signed out of every browser
+			if action.Role == enum.RoleVisitor {
+				if err := webutil.RevokeUserSessions(c, action.UserID, ""); err != nil {
+					return c.Failure(err)
+				}
+			}
+
 			// Handle userlist
 			if env.Config.UserList.Enabled {
 				c.Enqueue(tasks.UserListAddOrRemoveUser(action.UserID, action.Role))
@@ -331,6 +339,10 @@ func DeleteUser() web.HandlerFunc {
 				return c.Failure(err)
 			}
 
+			if err := webutil.RevokeUserSessions(c, c.User().ID, ""); err != nil {
+				return c.Failure(err)
+			}
+
 			c.RemoveCookie(web.CookieAuthName)
 
 			// Handle userlist (easiest way is to demote them which will remove them from the userlist)
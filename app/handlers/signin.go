@@ -111,9 +111,7 @@ func VerifySignInKey(kind enum.EmailVerificationKind) web.HandlerFunc {
 			return err
 		}
 
-		webutil.AddAuthUserCookie(c, userByEmail.Result)
-
-		return c.Redirect(c.BaseURL())
+		return signInUser(c, userByEmail.Result, c.BaseURL())
 	}
 }
 
@@ -167,9 +165,16 @@ func CompleteSignInProfile() web.HandlerFunc {
 	}
 }
 
-// SignOut remove auth cookies
+// SignOut revokes the current session and remove auth cookies
 func SignOut() web.HandlerFunc {
 	return func(c *web.Context) error {
+		if session := c.UserSession(); session != nil {
+			err := webutil.RevokeUserSession(c, session.UserID, session.ID)
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return c.Failure(err)
+			}
+		}
+
 		c.RemoveCookie(web.CookieAuthName)
 		redirect := c.QueryParam("redirect")
 
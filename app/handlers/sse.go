@@ -68,3 +68,7 @@ func ReportsSSE() web.HandlerFunc {
 func QueueSSE() web.HandlerFunc {
 	return sseHandler(sse.ChannelQueue)
 }
+
+func AppealsSSE() web.HandlerFunc {
+	return sseHandler(sse.ChannelAppeals)
+}
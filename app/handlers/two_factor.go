@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+// signInUser starts a session for the user and redirects them,
+// users who have enabled two-factor authentication are asked for a code first
+func signInUser(c *web.Context, user *entity.User, redirect string) error {
+	getTwoFactor := &query.GetUserTwoFactor{UserID: user.ID}
+	err := bus.Dispatch(c, getTwoFactor)
+	if err != nil && errors.Cause(err) != app.ErrNotFound {
+		return c.Failure(err)
+	}
+
+	if getTwoFactor.Result.IsEnabled() {
+		webutil.StartTwoFactorChallenge(c, user, redirect)
+		return c.Redirect("/signin/2fa")
+	}
+
+	webutil.AddAuthUserCookie(c, user)
+	return c.Redirect(redirect)
+}
+
+// TwoFactorSignInPage asks for the two-factor code of the user who is signing in
+func TwoFactorSignInPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		if webutil.GetTwoFactorChallenge(c) == nil {
+			return c.Redirect("/signin")
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "SignIn/TwoFactorSignIn.page",
+			Title: "Two-factor authentication",
+		})
+	}
+}
+
+// VerifyTwoFactorSignIn checks the two-factor code of the user who is signing in and starts their session
+func VerifyTwoFactorSignIn() web.HandlerFunc {
+	return func(c *web.Context) error {
+		action := new(actions.VerifyTwoFactorSignIn)
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		challenge := webutil.GetTwoFactorChallenge(c)
+		if challenge == nil {
+			return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.twofactorexpired")))
+		}
+
+		getUser := &query.GetUserByID{UserID: challenge.UserID}
+		getTwoFactor := &query.GetUserTwoFactor{UserID: challenge.UserID}
+		if err := bus.Dispatch(c, getUser, getTwoFactor); err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				webutil.EndTwoFactorChallenge(c)
+				return c.HandleValidation(validate.Failed(i18n.T(c, "validation.custom.twofactorexpired")))
+			}
+			return c.Failure(err)
+		}
+
+		ok, err := webutil.CheckTwoFactorCode(c, getTwoFactor.Result, action.Code)
+		if err != nil {
+			return c.Failure(err)
+		}
+		if !ok {
+			return c.HandleValidation(invalidTwoFactorCode(c, getTwoFactor.Result))
+		}
+
+		webutil.EndTwoFactorChallenge(c)
+		webutil.AddAuthUserCookie(c, getUser.Result)
+
+		return c.Ok(web.Map{
+			"redirect": challenge.Redirect,
+		})
+	}
+}
+
+// invalidTwoFactorCode tells users when only recovery codes are accepted anymore
+func invalidTwoFactorCode(c *web.Context, twoFactor *entity.UserTwoFactor) *validate.Result {
+	result := validate.Success()
+	if twoFactor.FailedAttempts+1 >= webutil.MaxTwoFactorAttempts {
+		result.AddFieldFailure("code", i18n.T(c, "validation.custom.twofactorlocked"))
+	} else {
+		result.AddFieldFailure("code", i18n.T(c, "validation.custom.invalidtwofactorcode"))
+	}
+	return result
+}
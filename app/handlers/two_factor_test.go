@@ -0,0 +1,225 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+const twoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func enabledTwoFactor(userID int) *entity.UserTwoFactor {
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	return &entity.UserTwoFactor{
+		UserID:    userID,
+		Secret:    twoFactorSecret,
+		EnabledAt: &enabledAt,
+	}
+}
+
+func twoFactorChallenge(userID int, redirect string) string {
+	token, _ := jwt.Encode(jwt.TwoFactorClaims{
+		UserID:   userID,
+		TenantID: mock.DemoTenant.ID,
+		Redirect: redirect,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(5 * time.Minute)),
+		},
+	})
+	return token
+}
+
+func TestOAuthTokenHandler_TwoFactorEnabled(t *testing.T) {
+	RegisterT(t)
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthProfile) error {
+		q.Result = &dto.OAuthUserProfile{ID: "FB123", Name: "Jon Snow", Email: "jon.snow@got.com"}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByProvider) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		q.Result = enabledTwoFactor(q.UserID)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
+	code, response := server.
+		WithURL("http://demo.test.fider.io/oauth/facebook/token?code=123&identifier=MY_SESSION_ID&redirect=/hello").
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieSessionName, "MY_SESSION_ID").
+		AddParam("provider", app.FacebookProvider).
+		Use(middlewares.Session()).
+		Execute(handlers.OAuthToken())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/signin/2fa")
+	ExpectFiderAuthCookie(response, nil)
+
+	found := false
+	for _, c := range response.Header()["Set-Cookie"] {
+		cookie := web.ParseCookie(c)
+		if cookie.Name == web.CookieTwoFactorName {
+			claims, err := jwt.DecodeTwoFactorClaims(cookie.Value)
+			Expect(err).IsNil()
+			Expect(claims.UserID).Equals(mock.JonSnow.ID)
+			Expect(claims.TenantID).Equals(mock.DemoTenant.ID)
+			Expect(claims.Redirect).Equals("/hello")
+			found = true
+		}
+	}
+	Expect(found).IsTrue()
+}
+
+func TestTwoFactorSignInPage_WithoutChallenge(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		Execute(handlers.TwoFactorSignInPage())
+
+	Expect(code).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/signin")
+}
+
+func TestVerifyTwoFactorSignIn_ValidCode(t *testing.T) {
+	RegisterT(t)
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		q.Result = enabledTwoFactor(q.UserID)
+		return nil
+	})
+
+	var usedStep *cmd.UseTwoFactorStep
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseTwoFactorStep) error {
+		usedStep = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
+	totpCode, _ := totp.Code(twoFactorSecret, totp.Step(time.Now()))
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorChallenge(mock.JonSnow.ID, "/hello")).
+		ExecutePost(handlers.VerifyTwoFactorSignIn(), `{ "code": "`+totpCode+`" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(response.Body.String()).ContainsSubstring(`"redirect":"/hello"`)
+	Expect(usedStep).IsNotNil()
+	Expect(usedStep.UserID).Equals(mock.JonSnow.ID)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+}
+
+func TestVerifyTwoFactorSignIn_RecoveryCode(t *testing.T) {
+	RegisterT(t)
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		q.Result = enabledTwoFactor(q.UserID)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseRecoveryCode) error {
+		if c.CodeHash == webutil.HashRecoveryCode("abcde-fghij") {
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorChallenge(mock.JonSnow.ID, "/")).
+		ExecutePost(handlers.VerifyTwoFactorSignIn(), `{ "code": "ABCDE-FGHIJ" }`)
+
+	Expect(code).Equals(http.StatusOK)
+	ExpectFiderAuthCookie(response, mock.JonSnow)
+}
+
+func TestVerifyTwoFactorSignIn_InvalidCode(t *testing.T) {
+	RegisterT(t)
+	server := mock.NewServer()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		q.Result = enabledTwoFactor(q.UserID)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.UseRecoveryCode) error {
+		return app.ErrNotFound
+	})
+
+	failures := 0
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordTwoFactorFailure) error {
+		failures++
+		return nil
+	})
+
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieTwoFactorName, twoFactorChallenge(mock.JonSnow.ID, "/")).
+		ExecutePost(handlers.VerifyTwoFactorSignIn(), `{ "code": "wrong-code" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	Expect(failures).Equals(1)
+	ExpectFiderAuthCookie(response, nil)
+}
+
+func TestVerifyTwoFactorSignIn_WithoutChallenge(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	code, response := server.
+		OnTenant(mock.DemoTenant).
+		ExecutePost(handlers.VerifyTwoFactorSignIn(), `{ "code": "123456" }`)
+
+	Expect(code).Equals(http.StatusBadRequest)
+	ExpectFiderAuthCookie(response, nil)
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
 
 // BlockUser is used to block an existing user from using Fider
+// With ?revokeSessions=true the user is also signed out of every browser
 func BlockUser() web.HandlerFunc {
 	return func(c *web.Context) error {
 		userID, err := c.ParamAsInt("userID")
@@ -21,17 +23,41 @@ func BlockUser() web.HandlerFunc {
 			return c.NotFound()
 		}
 
+		revokeSessions, _ := c.QueryParamAsBool("revokeSessions")
+
 		return c.WithTransaction(func() error {
 			err = bus.Dispatch(c, &cmd.BlockUser{UserID: userID})
 			if err != nil {
 				return c.Failure(err)
 			}
 
+			if revokeSessions {
+				if err := webutil.RevokeUserSessions(c, userID, ""); err != nil {
+					return c.Failure(err)
+				}
+			}
+
 			return c.Ok(web.Map{})
 		})
 	}
 }
 
+// RevokeUserSessions signs an existing user out of every browser
+func RevokeUserSessions() web.HandlerFunc {
+	return func(c *web.Context) error {
+		userID, err := c.ParamAsInt("userID")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		if err := webutil.RevokeUserSessions(c, userID, ""); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // UnblockUser is used to unblock an existing user so they can use Fider again
 func UnblockUser() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -51,6 +77,26 @@ func UnblockUser() web.HandlerFunc {
 	}
 }
 
+// DismissBlockProposal closes the block proposal of the sanctions policy without blocking the user
+func DismissBlockProposal() web.HandlerFunc {
+	return func(c *web.Context) error {
+		userID, err := c.ParamAsInt("userID")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		dismiss := &cmd.DismissUserBlockProposal{UserID: userID}
+		if err := bus.Dispatch(c, dismiss); err != nil {
+			return c.Failure(err)
+		}
+		if !dismiss.Result {
+			return c.NotFound()
+		}
+
+		return c.Ok(web.Map{})
+	}
+}
+
 // ViewUserProfile handles viewing another user's profile
 func ViewUserProfile() web.HandlerFunc {
 	return func(c *web.Context) error {
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+)
+
+// ManageUserMergeRequestsPage lists the merge requests that await a staff decision
+func ManageUserMergeRequestsPage() web.HandlerFunc {
+	return func(c *web.Context) error {
+		requests := &query.ListUserMergeRequests{Status: enum.UserMergeRequestPending}
+		providers := &query.ListAllOAuthProviders{}
+		if err := bus.Dispatch(c, requests, providers); err != nil {
+			return c.Failure(err)
+		}
+
+		return c.Page(http.StatusOK, web.Props{
+			Page:  "Administration/pages/ManageMergeRequests.page",
+			Title: "Merge Requests · Site Settings",
+			Data: web.Map{
+				"requests":  requests.Result,
+				"providers": providers.Result,
+			},
+		})
+	}
+}
+
+// DecideUserMergeRequest approves or rejects a merge request
+// Approving moves the identity to the user who asked for it, the content of both accounts stays where it is
+func DecideUserMergeRequest() web.HandlerFunc {
+	return func(c *web.Context) error {
+		requestID, err := c.ParamAsInt("id")
+		if err != nil {
+			return c.NotFound()
+		}
+
+		getRequest := &query.GetUserMergeRequestByID{RequestID: requestID}
+		if err := bus.Dispatch(c, getRequest); err != nil {
+			return c.NotFound()
+		}
+
+		action := new(actions.DecideUserMergeRequest)
+		action.Request = getRequest.Result
+		if result := c.BindTo(action); !result.Ok {
+			return c.HandleValidation(result)
+		}
+
+		var status enum.UserMergeRequestStatus
+		_ = status.UnmarshalText([]byte(action.Status))
+
+		return c.WithTransaction(func() error {
+			request := action.Request
+			if status == enum.UserMergeRequestApproved {
+				if err := bus.Dispatch(c, &cmd.MoveUserProvider{
+					FromUserID:   request.Owner.ID,
+					ToUserID:     request.User.ID,
+					ProviderName: request.Provider,
+					ProviderUID:  request.ProviderUID,
+				}); err != nil {
+					return c.Failure(err)
+				}
+			}
+
+			if err := bus.Dispatch(c, &cmd.DecideUserMergeRequest{
+				RequestID: request.ID,
+				Status:    status,
+			}); err != nil {
+				return c.Failure(err)
+			}
+
+			c.Enqueue(tasks.NotifyAboutUserMergeRequestDecision(request, status))
+
+			return c.Ok(web.Map{})
+		})
+	}
+}
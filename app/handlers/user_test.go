@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/handlers"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+)
+
+func TestDismissBlockProposalHandler(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+
+	var dismiss *cmd.DismissUserBlockProposal
+	bus.AddHandler(func(ctx context.Context, c *cmd.DismissUserBlockProposal) error {
+		dismiss = c
+		c.Result = true
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", mock.AryaStark.ID).
+		Execute(handlers.DismissBlockProposal())
+
+	Expect(code).Equals(http.StatusOK)
+	Expect(dismiss.UserID).Equals(mock.AryaStark.ID)
+}
+
+func TestDismissBlockProposalHandler_NoPendingProposal(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, c *cmd.DismissUserBlockProposal) error {
+		c.Result = false
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetNavigationLinks) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", mock.AryaStark.ID).
+		Execute(handlers.DismissBlockProposal())
+
+	Expect(code).Equals(http.StatusNotFound)
+}
+
+func TestDismissBlockProposalHandler_InvalidUser(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	bus.AddHandler(func(ctx context.Context, q *query.GetNavigationLinks) error {
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.DismissUserBlockProposal) error {
+		dispatched = true
+		return nil
+	})
+
+	code, _ := server.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		AddParam("userID", "abc").
+		Execute(handlers.DismissBlockProposal())
+
+	Expect(code).Equals(http.StatusNotFound)
+	Expect(dispatched).IsFalse()
+}
@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
+)
+
+// IncomingEmailEvents receives bounces and complaints from the email provider and suppresses those addresses right away
+// EmailSupressionJob still pulls them every hour, in case some events were missed
+func IncomingEmailEvents() web.HandlerFunc {
+	return func(c *web.Context) error {
+		key := c.QueryParam("key")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(env.Config.Email.Events.Secret)) != 1 {
+			return c.Unauthorized()
+		}
+
+		var addresses []string
+		switch c.Param("provider") {
+		case "mailgun":
+			address, err := email.ParseMailgunEvent([]byte(c.Request.Body))
+			if err != nil {
+				return c.BadRequest(web.Map{"error": err.Error()})
+			}
+			if address != "" {
+				addresses = []string{address}
+			}
+		case "ses":
+			events, err := email.ParseSESEvents([]byte(c.Request.Body))
+			if err != nil {
+				return c.BadRequest(web.Map{"error": err.Error()})
+			}
+			if events.SubscribeURL != "" {
+				if err := bus.Dispatch(c, &cmd.HTTPRequest{URL: events.SubscribeURL, Method: "GET"}); err != nil {
+					return c.Failure(errors.Wrap(err, "failed to confirm SNS subscription"))
+				}
+				return c.Ok(web.Map{})
+			}
+			addresses = events.Addresses
+		default:
+			return c.NotFound()
+		}
+
+		if len(addresses) == 0 {
+			return c.Ok(web.Map{})
+		}
+
+		supress := &cmd.SupressEmail{EmailAddresses: addresses}
+		if err := bus.Dispatch(c, supress); err != nil {
+			return c.Failure(err)
+		}
+
+		log.Infof(c, "@{Count} account(s) marked with supressed email after a bounce or complaint", dto.Props{
+			"Count": supress.NumOfSupressedEmailAddresses,
+		})
+
+		return c.Ok(web.Map{})
+	}
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/webpush"
+)
+
+type DeferredNotificationsJobHandler struct {
+}
+
+func (e DeferredNotificationsJobHandler) Schedule() string {
+	return "0 */5 * * * *" // every 5 minutes
+}
+
+func (e DeferredNotificationsJobHandler) Run(ctx Context) error {
+	q := &query.GetDueDeferredNotifications{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get due deferred notifications")
+	}
+
+	// notifications that cannot be sent are logged and dropped, retrying them would not help
+	ids := make([]int, 0, len(q.Result))
+	for _, notification := range q.Result {
+		if err := releaseNotification(ctx, notification); err != nil {
+			log.Error(ctx, err)
+		}
+		ids = append(ids, notification.ID)
+	}
+
+	if err := bus.Dispatch(ctx, &cmd.DeleteDeferredNotifications{IDs: ids}); err != nil {
+		return errors.Wrap(err, "failed to delete deferred notifications")
+	}
+
+	log.Debugf(ctx, "@{Count} deferred notification(s) released", dto.Props{
+		"Count": len(ids),
+	})
+
+	return nil
+}
+
+func releaseNotification(ctx context.Context, notification *entity.DeferredNotification) error {
+	u, err := url.Parse(notification.BaseURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse deferred notification base url '%s'", notification.BaseURL)
+	}
+
+	ctx = context.WithValue(ctx, app.TenantCtxKey, notification.Tenant)
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, notification.Tenant.Locale)
+	ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: u})
+
+	switch notification.Channel {
+	case enum.NotificationChannelPush:
+		if !env.IsWebPushEnabled() {
+			return nil
+		}
+		msg := &webpush.Message{}
+		if err := json.Unmarshal([]byte(notification.Payload), msg); err != nil {
+			return errors.Wrap(err, "failed to parse deferred push notification '%d'", notification.ID)
+		}
+		if msg.Notification == nil {
+			return errors.New("deferred push notification '%d' is empty", notification.ID)
+		}
+		webpush.Deliver(ctx, []int{notification.User.ID}, msg)
+	case enum.NotificationChannelEmail:
+		mail, err := cmd.UnmarshalDeferredMail(notification.Payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse deferred email '%d'", notification.ID)
+		}
+		bus.Publish(ctx, mail)
+	default:
+		return errors.New("deferred notification '%d' has unsupported channel '%d'", notification.ID, notification.Channel)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package jobs_test
+
+import (
+	"context"
+	"html/template"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/jobs"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
+)
+
+func TestDeferredNotificationsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.DeferredNotificationsJobHandler{}
+	Expect(job.Schedule()).Equals("0 */5 * * * *")
+}
+
+func TestDeferredNotificationsJob_ShouldReleaseDueEmails(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	payload, err := (&cmd.SendMail{
+		From:         dto.Recipient{Name: "Arya Stark"},
+		To:           []dto.Recipient{dto.NewRecipient(mock.JonSnow.Name, mock.JonSnow.Email, dto.Props{})},
+		TemplateName: "new_comment",
+		Props:        dto.Props{"content": template.HTML("<p>I agree</p>")},
+	}).MarshalDeferred()
+	Expect(err).IsNil()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetDueDeferredNotifications) error {
+		q.Result = []*entity.DeferredNotification{
+			{ID: 1, Tenant: mock.DemoTenant, User: mock.JonSnow, Channel: enum.NotificationChannelEmail, Payload: payload, BaseURL: "http://demo.test.fider.io"},
+			{ID: 2, Tenant: mock.DemoTenant, User: mock.JonSnow, Channel: enum.NotificationChannelEmail, Payload: "not json", BaseURL: "http://demo.test.fider.io"},
+		}
+		return nil
+	})
+
+	var deleted *cmd.DeleteDeferredNotifications
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteDeferredNotifications) error {
+		deleted = c
+		return nil
+	})
+
+	job := &jobs.DeferredNotificationsJobHandler{}
+	err = job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("new_comment")
+	Expect(emailmock.MessageHistory[0].Tenant).Equals(mock.DemoTenant)
+	Expect(emailmock.MessageHistory[0].Props["content"]).Equals(template.HTML("<p>I agree</p>"))
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.JonSnow.Email)
+
+	Expect(deleted).IsNotNil()
+	Expect(deleted.IDs).Equals([]int{1, 2})
+}
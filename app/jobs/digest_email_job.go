@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+type DigestEmailJobHandler struct {
+}
+
+func (e DigestEmailJobHandler) Schedule() string {
+	return "0 0 * * * *" // every hour, each digest goes out at the local time of its user
+}
+
+func (e DigestEmailJobHandler) Run(ctx Context) error {
+	if env.Config.Email.DisableEmailNotifications {
+		return nil
+	}
+
+	q := &query.GetPendingDigests{
+		Frequencies: []string{enum.DigestFrequencyDaily, enum.DigestFrequencyWeekly},
+	}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get pending digests")
+	}
+
+	from := time.Now().Truncate(time.Hour)
+	sent := 0
+	for _, digest := range q.Result {
+		if !digest.IsDue(from) {
+			continue
+		}
+
+		if err := sendDigest(ctx, digest); err != nil {
+			log.Error(ctx, err)
+			continue
+		}
+		sent++
+
+		// items are cleared as soon as their digest is on its way, so a failure later in the run doesn't send them twice
+		if err := bus.Dispatch(ctx, &cmd.ClearDigestItems{ItemIDs: digest.ItemIDs()}); err != nil {
+			return errors.Wrap(err, "failed to clear digest items of user '%d'", digest.User.ID)
+		}
+	}
+
+	// the expired items are dropped even when no digest is due
+	c := &cmd.ClearDigestItems{ItemIDs: []int{}}
+	if err := bus.Dispatch(ctx, c); err != nil {
+		return errors.Wrap(err, "failed to clear expired digest items")
+	}
+
+	log.Debugf(ctx, "@{Count} digest email(s) sent, @{Cleared} expired item(s) cleared", dto.Props{
+		"Count":   sent,
+		"Cleared": c.NumOfClearedItems,
+	})
+
+	return nil
+}
+
+func sendDigest(ctx context.Context, digest *entity.Digest) error {
+	u, err := url.Parse(digest.BaseURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse digest base url '%s'", digest.BaseURL)
+	}
+
+	// the digest is rendered outside of a request, so the tenant and the url its items were queued on are used instead
+	ctx = context.WithValue(ctx, app.TenantCtxKey, digest.Tenant)
+	ctx = context.WithValue(ctx, app.LocaleCtxKey, digest.Tenant.Locale)
+	ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: u})
+
+	baseURL := web.BaseURL(ctx)
+	groups := make([]dto.Props, 0)
+	for _, group := range digest.Groups() {
+		items := make([]dto.Props, len(group.Items))
+		for i, item := range group.Items {
+			event := "new_comment"
+			if item.Event == enum.NotificationEventNewPost.UserSettingsKeyName {
+				event = "new_post"
+			}
+			items[i] = dto.Props{
+				"event":    event,
+				"userName": item.AuthorName,
+				"excerpt":  item.Excerpt,
+				"url":      baseURL + item.Link,
+			}
+		}
+		groups = append(groups, dto.Props{
+			"number": group.PostNumber,
+			"title":  group.PostTitle,
+			"url":    fmt.Sprintf("%s/posts/%d/%s", baseURL, group.PostNumber, group.PostSlug),
+			"items":  items,
+		})
+	}
+
+	to := dto.NewRecipient(digest.User.Name, digest.User.Email, dto.Props{})
+	token, err := jwt.Encode(&jwt.UnsubscribeClaims{
+		UserID: digest.User.ID,
+		Event:  enum.DigestFrequencySettingsKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode unsubscribe token of digest")
+	}
+	to.Unsubscribe = baseURL + "/unsubscribe?token=" + token
+
+	changeURL := baseURL + "/profile#settings"
+	bus.Publish(ctx, &cmd.SendMail{
+		From:         dto.Recipient{Name: digest.Tenant.Name},
+		To:           []dto.Recipient{to},
+		TemplateName: "digest",
+		Props: dto.Props{
+			"siteName": digest.Tenant.Name,
+			"groups":   groups,
+			"change":   fmt.Sprintf("<a href='%s'>%s</a>", changeURL, i18n.T(ctx, "email.subscription.change")),
+			"logo":     web.LogoURL(ctx),
+		},
+	})
+
+	return nil
+}
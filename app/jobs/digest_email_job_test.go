@@ -0,0 +1,93 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/jobs"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
+)
+
+func TestDigestEmailJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.DigestEmailJobHandler{}
+	Expect(job.Schedule()).Equals("0 0 * * * *")
+}
+
+func TestDigestEmailJob_ShouldClearExpiredItems_WhenNothingIsPending(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPendingDigests) error {
+		Expect(q.Frequencies).Equals([]string{enum.DigestFrequencyDaily, enum.DigestFrequencyWeekly})
+		return nil
+	})
+
+	var clear *cmd.ClearDigestItems
+	bus.AddHandler(func(ctx context.Context, c *cmd.ClearDigestItems) error {
+		clear = c
+		return nil
+	})
+
+	job := &jobs.DigestEmailJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(clear).IsNotNil()
+	Expect(clear.ItemIDs).HasLen(0)
+}
+
+func TestDigestEmailJob_ShouldSendDueDigestsAndClearTheirItems(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	// a timezone where it is currently the digest hour, and one where it is not
+	now := time.Now().UTC()
+	dueZone := time.FixedZone("due", (entity.DigestHour-now.Hour())*3600)
+	laterZone := time.FixedZone("later", (entity.DigestHour-now.Hour()-3)*3600)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetPendingDigests) error {
+		q.Result = []*entity.Digest{
+			{
+				Tenant: mock.DemoTenant, User: mock.JonSnow, BaseURL: "http://demo.test.fider.io",
+				Frequency: enum.DigestFrequencyDaily, Location: dueZone,
+				Items: []*entity.DigestItem{{ID: 1, PostID: 10, PostNumber: 1, PostTitle: "First"}},
+			},
+			{
+				Tenant: mock.DemoTenant, User: mock.AryaStark, BaseURL: "http://demo.test.fider.io",
+				Frequency: enum.DigestFrequencyDaily, Location: laterZone,
+				Items: []*entity.DigestItem{{ID: 2, PostID: 10, PostNumber: 1, PostTitle: "First"}},
+			},
+		}
+		return nil
+	})
+
+	cleared := make([][]int, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.ClearDigestItems) error {
+		cleared = append(cleared, c.ItemIDs)
+		return nil
+	})
+
+	job := &jobs.DigestEmailJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("digest")
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.JonSnow.Email)
+
+	Expect(cleared).HasLen(2)
+	Expect(cleared[0]).Equals([]int{1})
+	Expect(cleared[1]).HasLen(0)
+}
@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
+)
+
+// maxEmailAttempts is how many times a queued email is handed to the email provider before giving up on it
+const maxEmailAttempts = 5
+
+// maxEmailsPerRun keeps the transaction of a single run short, whatever the rate limit is
+const maxEmailsPerRun = 100
+
+// queuedEmailsRetention is how long sent and failed emails are kept for administrators to look at
+const queuedEmailsRetention = 30 * 24 * time.Hour
+
+type EmailQueueJobHandler struct {
+}
+
+func (e EmailQueueJobHandler) Schedule() string {
+	return "*/10 * * * * *" // every 10 seconds
+}
+
+func (e EmailQueueJobHandler) Run(ctx Context) error {
+	// the rate limit is shared by all instances, the job lock makes sure only one of them is sending
+	attempts := &query.CountRecentEmailAttempts{Since: time.Now().Add(-1 * time.Minute)}
+	if err := bus.Dispatch(ctx, attempts); err != nil {
+		return errors.Wrap(err, "failed to count recent email attempts")
+	}
+
+	limit := min(email.RateLimit()-attempts.Result, maxEmailsPerRun)
+	if limit <= 0 {
+		log.Debug(ctx, "Email rate limit reached, queued emails will be sent on the next run")
+		return nil
+	}
+
+	q := &query.GetDueQueuedEmails{Limit: limit}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get due queued emails")
+	}
+
+	sent := 0
+	for _, queued := range q.Result {
+		update := &cmd.UpdateQueuedEmail{ID: queued.ID, Status: enum.QueuedEmailSent}
+
+		if err := deliverQueuedEmail(ctx, queued); err != nil {
+			update.LastError = err.Error()
+			if email.IsPermanent(err) || queued.Attempts+1 >= maxEmailAttempts {
+				update.Status = enum.QueuedEmailFailed
+				log.Warnf(ctx, "Giving up on queued email '@{ID}' after @{Attempts} attempt(s): @{Error}", dto.Props{
+					"ID":       queued.ID,
+					"Attempts": queued.Attempts + 1,
+					"Error":    err.Error(),
+				})
+			} else {
+				update.Status = enum.QueuedEmailPending
+				update.NextAttemptAt = time.Now().Add(emailRetryDelay(queued.Attempts + 1))
+			}
+		} else {
+			sent++
+		}
+
+		if err := bus.Dispatch(ctx, update); err != nil {
+			return errors.Wrap(err, "failed to update queued email '%d'", queued.ID)
+		}
+	}
+
+	purge := &cmd.PurgeQueuedEmails{Before: time.Now().Add(-queuedEmailsRetention)}
+	if err := bus.Dispatch(ctx, purge); err != nil {
+		return errors.Wrap(err, "failed to purge queued emails")
+	}
+
+	log.Debugf(ctx, "@{Sent} of @{Count} queued email(s) sent", dto.Props{
+		"Sent":  sent,
+		"Count": len(q.Result),
+	})
+
+	return nil
+}
+
+// emailRetryDelay is 1 minute after the first attempt, then 4, 16 and 64 minutes
+func emailRetryDelay(attempts int) time.Duration {
+	return time.Minute << (2 * (attempts - 1))
+}
+
+// deliverQueuedEmail hands the email over to the email provider, templates that fail to render don't stop the job
+func deliverQueuedEmail(ctx context.Context, queued *entity.QueuedEmail) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = email.Permanent(errors.Panicked(r))
+		}
+	}()
+
+	mail, err := cmd.UnmarshalDeferredMail(queued.Payload)
+	if err != nil {
+		return email.Permanent(errors.Wrap(err, "failed to parse queued email '%d'", queued.ID))
+	}
+
+	if queued.BaseURL != "" {
+		u, err := url.Parse(queued.BaseURL)
+		if err != nil {
+			return email.Permanent(errors.Wrap(err, "failed to parse queued email base url '%s'", queued.BaseURL))
+		}
+		ctx = context.WithValue(ctx, app.RequestCtxKey, web.Request{URL: u})
+	}
+
+	if queued.Tenant != nil {
+		ctx = context.WithValue(ctx, app.TenantCtxKey, queued.Tenant)
+		ctx = context.WithValue(ctx, app.LocaleCtxKey, queued.Tenant.Locale)
+	}
+
+	return bus.Dispatch(ctx, &cmd.DeliverMail{Mail: mail})
+}
@@ -0,0 +1,138 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/jobs"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
+)
+
+func queuedEmailPayload(address string) string {
+	payload, err := (&cmd.SendMail{
+		To:           []dto.Recipient{dto.NewRecipient("", address, dto.Props{})},
+		TemplateName: "echo_test",
+		Props:        dto.Props{"name": "Jon"},
+	}).MarshalDeferred()
+	Expect(err).IsNil()
+	return payload
+}
+
+func mockEmailQueue(recentAttempts int, due []*entity.QueuedEmail) map[int]*cmd.UpdateQueuedEmail {
+	updates := make(map[int]*cmd.UpdateQueuedEmail)
+
+	bus.AddHandler(func(ctx context.Context, q *query.CountRecentEmailAttempts) error {
+		q.Result = recentAttempts
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetDueQueuedEmails) error {
+		q.Result = due
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.UpdateQueuedEmail) error {
+		updates[c.ID] = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.PurgeQueuedEmails) error {
+		return nil
+	})
+	return updates
+}
+
+func TestEmailQueueJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.EmailQueueJobHandler{}
+	Expect(job.Schedule()).Equals("*/10 * * * * *")
+}
+
+func TestEmailQueueJob_ShouldSendDueEmails(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	updates := mockEmailQueue(0, []*entity.QueuedEmail{
+		{ID: 1, Tenant: mock.DemoTenant, TemplateName: "echo_test", Recipient: mock.JonSnow.Email, Payload: queuedEmailPayload(mock.JonSnow.Email), BaseURL: "http://demo.test.fider.io"},
+		{ID: 2, Tenant: mock.DemoTenant, TemplateName: "echo_test", Recipient: mock.AryaStark.Email, Payload: "not json", BaseURL: "http://demo.test.fider.io"},
+	})
+
+	job := &jobs.EmailQueueJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].Tenant).Equals(mock.DemoTenant)
+	Expect(emailmock.MessageHistory[0].To[0].Address).Equals(mock.JonSnow.Email)
+
+	Expect(updates[1].Status).Equals(enum.QueuedEmailSent)
+	Expect(updates[2].Status).Equals(enum.QueuedEmailFailed)
+	Expect(updates[2].LastError).IsNotEmpty()
+}
+
+func TestEmailQueueJob_ShouldRetryTransientErrors(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	updates := mockEmailQueue(0, []*entity.QueuedEmail{
+		{ID: 1, Tenant: mock.DemoTenant, Recipient: mock.JonSnow.Email, Payload: queuedEmailPayload(mock.JonSnow.Email), Attempts: 1},
+		{ID: 2, Tenant: mock.DemoTenant, Recipient: mock.AryaStark.Email, Payload: queuedEmailPayload(mock.AryaStark.Email), Attempts: 4},
+		{ID: 3, Tenant: mock.DemoTenant, Recipient: "nobody@got.com", Payload: queuedEmailPayload("nobody@got.com")},
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeliverMail) error {
+		if c.Mail.To[0].Address == "nobody@got.com" {
+			return email.Permanent(errors.New("550 No such user"))
+		}
+		return errors.New("421 Try again later")
+	})
+
+	job := &jobs.EmailQueueJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+
+	Expect(updates[1].Status).Equals(enum.QueuedEmailPending)
+	Expect(updates[1].NextAttemptAt).TemporarilySimilar(time.Now().Add(4*time.Minute), 5*time.Second)
+	Expect(updates[2].Status).Equals(enum.QueuedEmailFailed)
+	Expect(updates[3].Status).Equals(enum.QueuedEmailFailed)
+}
+
+func TestEmailQueueJob_ShouldRespectRateLimit(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	job := &jobs.EmailQueueJobHandler{}
+
+	mockEmailQueue(email.RateLimit(), []*entity.QueuedEmail{})
+	called := false
+	bus.AddHandler(func(ctx context.Context, q *query.GetDueQueuedEmails) error {
+		called = true
+		return nil
+	})
+	Expect(job.Run(jobs.Context{Context: context.Background()})).IsNil()
+	Expect(called).IsFalse()
+
+	var limit int
+	bus.AddHandler(func(ctx context.Context, q *query.CountRecentEmailAttempts) error {
+		q.Result = email.RateLimit() - 3
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetDueQueuedEmails) error {
+		limit = q.Limit
+		return nil
+	})
+	Expect(job.Run(jobs.Context{Context: context.Background()})).IsNil()
+	Expect(limit).Equals(3)
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+)
+
+type PurgeExpiredOAuthGrantsJobHandler struct {
+}
+
+func (e PurgeExpiredOAuthGrantsJobHandler) Schedule() string {
+	return "0 40 * * * *" // every hour at minute 40
+}
+
+func (e PurgeExpiredOAuthGrantsJobHandler) Run(ctx Context) error {
+	log.Debug(ctx, "deleting expired OAuth authorization codes and refresh tokens")
+
+	c := &cmd.PurgeExpiredOAuthGrants{}
+	err := bus.Dispatch(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "@{RowsDeleted} OAuth authorization codes and refresh tokens were deleted", dto.Props{
+		"RowsDeleted": c.NumOfDeletedGrants,
+	})
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/blob"
+)
+
+type PurgeExpiredUserDataExportsJobHandler struct {
+}
+
+func (e PurgeExpiredUserDataExportsJobHandler) Schedule() string {
+	return "0 50 * * * *" // every hour at minute 50
+}
+
+func (e PurgeExpiredUserDataExportsJobHandler) Run(ctx Context) error {
+	log.Debug(ctx, "deleting expired user data exports")
+
+	q := &query.GetExpiredUserDataExports{}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return errors.Wrap(err, "failed to get expired user data exports")
+	}
+
+	// exports whose blob could not be deleted are kept so that it's tried again on the next run
+	ids := make([]int, 0, len(q.Result))
+	for _, export := range q.Result {
+		if export.BlobKey != "" {
+			tenantCtx := context.WithValue(ctx, app.TenantCtxKey, export.Tenant)
+			err := bus.Dispatch(tenantCtx, &cmd.DeleteBlob{Key: export.BlobKey})
+			if err != nil && errors.Cause(err) != blob.ErrNotFound {
+				log.Error(ctx, errors.Wrap(err, "failed to delete blob of user data export '%d'", export.ID))
+				continue
+			}
+		}
+		ids = append(ids, export.ID)
+	}
+
+	if err := bus.Dispatch(ctx, &cmd.DeleteUserDataExports{IDs: ids}); err != nil {
+		return errors.Wrap(err, "failed to delete user data exports")
+	}
+
+	log.Debugf(ctx, "@{RowsDeleted} user data exports were deleted", dto.Props{
+		"RowsDeleted": len(ids),
+	})
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/jobs"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestPurgeExpiredUserDataExportsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.PurgeExpiredUserDataExportsJobHandler{}
+	Expect(job.Schedule()).Equals("0 50 * * * *")
+}
+
+func TestPurgeExpiredUserDataExportsJob_DeletesBlobsOfTheirTenant(t *testing.T) {
+	RegisterT(t)
+
+	tenant1 := &entity.Tenant{ID: 1}
+	tenant2 := &entity.Tenant{ID: 2}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetExpiredUserDataExports) error {
+		q.Result = []*entity.UserDataExport{
+			{ID: 1, Tenant: tenant1, BlobKey: "exports/a.zip"},
+			{ID: 2, Tenant: tenant2},
+			{ID: 3, Tenant: tenant2, BlobKey: "exports/b.zip"},
+			{ID: 4, Tenant: tenant2, BlobKey: "exports/broken.zip"},
+		}
+		return nil
+	})
+
+	deletedBlobs := make(map[string]int)
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteBlob) error {
+		if c.Key == "exports/broken.zip" {
+			return errors.New("storage is unavailable")
+		}
+		deletedBlobs[c.Key] = ctx.Value(app.TenantCtxKey).(*entity.Tenant).ID
+		return nil
+	})
+
+	var deletedIDs []int
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteUserDataExports) error {
+		deletedIDs = c.IDs
+		return nil
+	})
+
+	job := &jobs.PurgeExpiredUserDataExportsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(deletedBlobs).Equals(map[string]int{"exports/a.zip": 1, "exports/b.zip": 2})
+	Expect(deletedIDs).Equals([]int{1, 2, 3})
+}
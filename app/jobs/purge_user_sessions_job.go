@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+)
+
+type PurgeExpiredUserSessionsJobHandler struct {
+}
+
+func (e PurgeExpiredUserSessionsJobHandler) Schedule() string {
+	return "0 30 * * * *" // every hour at minute 30
+}
+
+func (e PurgeExpiredUserSessionsJobHandler) Run(ctx Context) error {
+	log.Debug(ctx, "deleting expired user sessions")
+
+	c := &cmd.PurgeExpiredUserSessions{}
+	err := bus.Dispatch(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf(ctx, "@{RowsDeleted} user sessions were deleted", dto.Props{
+		"RowsDeleted": c.NumOfDeletedSessions,
+	})
+
+	return nil
+}
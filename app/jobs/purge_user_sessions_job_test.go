@@ -0,0 +1,35 @@
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/jobs"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestPurgeExpiredUserSessionsJob_Schedule_IsCorrect(t *testing.T) {
+	RegisterT(t)
+
+	job := &jobs.PurgeExpiredUserSessionsJobHandler{}
+	Expect(job.Schedule()).Equals("0 30 * * * *")
+}
+
+func TestPurgeExpiredUserSessionsJob_ShouldJustDispatchCommand(t *testing.T) {
+	RegisterT(t)
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.PurgeExpiredUserSessions) error {
+		dispatched = true
+		return nil
+	})
+
+	job := &jobs.PurgeExpiredUserSessionsJobHandler{}
+	err := job.Run(jobs.Context{
+		Context: context.Background(),
+	})
+	Expect(err).IsNil()
+	Expect(dispatched).IsTrue()
+}
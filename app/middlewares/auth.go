@@ -1,7 +1,11 @@
 package middlewares
 
 import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 )
 
@@ -17,6 +21,21 @@ func IsAuthenticated() web.MiddlewareFunc {
 	}
 }
 
+// BlockBlockedUsers keeps blocked users out of the routes registered after it, they are sent to the appeal page instead
+func BlockBlockedUsers() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			if c.IsAuthenticated() && c.User().Status == enum.UserBlocked {
+				if c.IsAjax() || c.Request.IsAPI() {
+					return c.Unauthorized()
+				}
+				return c.Redirect("/appeal")
+			}
+			return next(c)
+		}
+	}
+}
+
 // IsAuthorized blocks non-authorized requests
 func IsAuthorized(roles ...enum.Role) web.MiddlewareFunc {
 	return func(next web.HandlerFunc) web.HandlerFunc {
@@ -24,6 +43,9 @@ func IsAuthorized(roles ...enum.Role) web.MiddlewareFunc {
 			user := c.User()
 			for _, role := range roles {
 				if user.Role == role {
+					if token := c.APIToken(); token != nil && !token.HasScope(apiTokenScopeForRoles(roles)) {
+						return c.Forbidden()
+					}
 					return next(c)
 				}
 			}
@@ -31,3 +53,66 @@ func IsAuthorized(roles ...enum.Role) web.MiddlewareFunc {
 		}
 	}
 }
+
+// HasPermission blocks requests of users whose role and custom role don't grant given permission
+func HasPermission(permission enum.Permission) web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			user := c.User()
+			if user == nil || !user.HasPermission(permission) {
+				return c.Forbidden()
+			}
+			if token := c.APIToken(); token != nil && !token.HasScope(apiTokenScopeForPermission(permission)) {
+				return c.Forbidden()
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireTwoFactor blocks users until they enable two-factor authentication, if the tenant requires it for their role
+func RequireTwoFactor() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			user := c.User()
+			if user == nil || !c.Tenant().GeneralSettings.RequiresTwoFactor(user.Role) {
+				return next(c)
+			}
+
+			getTwoFactor := &query.GetUserTwoFactor{UserID: user.ID}
+			err := bus.Dispatch(c, getTwoFactor)
+			if err != nil && errors.Cause(err) != app.ErrNotFound {
+				return c.Failure(err)
+			}
+
+			if getTwoFactor.Result.IsEnabled() {
+				return next(c)
+			}
+
+			if c.IsAjax() || c.Request.IsAPI() {
+				return c.Forbidden()
+			}
+			return c.Redirect("/profile?twofactor=required#settings")
+		}
+	}
+}
+
+// apiTokenScopeForRoles returns the scope a personal API token needs for routes restricted to given roles
+// Routes moderators can use need the moderate scope, all others need the admin scope
+func apiTokenScopeForRoles(roles []enum.Role) enum.APITokenScope {
+	for _, role := range roles {
+		if role == enum.RoleModerator || role == enum.RoleHelper {
+			return enum.APITokenScopeModerate
+		}
+	}
+	return enum.APITokenScopeAdmin
+}
+
+// apiTokenScopeForPermission returns the scope a personal API token needs to use given permission
+// Permissions that moderators have only need the moderate scope, like the routes of IsAuthorized
+func apiTokenScopeForPermission(permission enum.Permission) enum.APITokenScope {
+	if enum.RoleModerator.HasPermission(permission) {
+		return enum.APITokenScopeModerate
+	}
+	return enum.APITokenScopeAdmin
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
@@ -42,6 +44,40 @@ func TestIsAuthorized_WithForbiddenRole(t *testing.T) {
 	Expect(status).Equals(http.StatusForbidden)
 }
 
+func TestIsAuthorized_WithAPITokenScope(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsAuthorized(enum.RoleAdministrator, enum.RoleCollaborator, enum.RoleModerator))
+	status, _ := server.
+		AsUser(mock.JonSnow).
+		WithAPIToken(&entity.APIToken{Scopes: []enum.APITokenScope{enum.APITokenScopeModerate}}).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestIsAuthorized_WithoutAPITokenScope(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.IsAuthorized(enum.RoleAdministrator, enum.RoleCollaborator))
+	status, _ := server.
+		AsUser(mock.JonSnow).
+		WithAPIToken(&entity.APIToken{Scopes: []enum.APITokenScope{enum.APITokenScopeModerate}}).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
 func TestIsAuthenticated_WithUser(t *testing.T) {
 	RegisterT(t)
 
@@ -66,3 +102,180 @@ func TestIsAuthenticated_WithoutUser(t *testing.T) {
 
 	Expect(status).Equals(http.StatusUnauthorized)
 }
+
+func TestBlockBlockedUsers_ActiveUser(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.BlockBlockedUsers())
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestBlockBlockedUsers_RedirectsToAppeal(t *testing.T) {
+	RegisterT(t)
+
+	blockedUser := *mock.AryaStark
+	blockedUser.Status = enum.UserBlocked
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.BlockBlockedUsers())
+	status, response := server.AsUser(&blockedUser).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/appeal")
+}
+
+func TestBlockBlockedUsers_API(t *testing.T) {
+	RegisterT(t)
+
+	blockedUser := *mock.AryaStark
+	blockedUser.Status = enum.UserBlocked
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.BlockBlockedUsers())
+	status, _ := server.
+		AsUser(&blockedUser).
+		AddHeader("Accept", "application/json").
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusUnauthorized)
+}
+
+func TestRequireTwoFactor_NotRequired(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.OnTenant(mock.DemoTenant).AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestRequireTwoFactor_RequiredButNotEnabled(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.GeneralSettings = &entity.GeneralSettings{TwoFactorRequiredFor: []string{"administrator"}}
+	server.Use(middlewares.RequireTwoFactor())
+	status, response := server.OnTenant(mock.DemoTenant).AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusTemporaryRedirect)
+	Expect(response.Header().Get("Location")).Equals("/profile?twofactor=required#settings")
+}
+
+func TestRequireTwoFactor_RequiredAndEnabled(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.GeneralSettings = &entity.GeneralSettings{TwoFactorRequiredFor: []string{"administrator"}}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		enabledAt := time.Now()
+		q.Result = &entity.UserTwoFactor{UserID: q.UserID, EnabledAt: &enabledAt}
+		return nil
+	})
+
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.OnTenant(mock.DemoTenant).AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestRequireTwoFactor_OtherRole(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	mock.DemoTenant.GeneralSettings = &entity.GeneralSettings{TwoFactorRequiredFor: []string{"administrator"}}
+	server.Use(middlewares.RequireTwoFactor())
+	status, _ := server.OnTenant(mock.DemoTenant).AsUser(mock.AryaStark).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestHasPermission_FromRole(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionPostLock))
+	status, _ := server.AsUser(mock.JonSnow).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestHasPermission_WithoutPermission(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionPostLock))
+	status, _ := server.AsUser(mock.AryaStark).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestHasPermission_FromCustomRole(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	user := &entity.User{ID: mock.AryaStark.ID, Name: mock.AryaStark.Name, Role: enum.RoleVisitor, Tenant: mock.DemoTenant}
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionPostLock}
+	})
+
+	server.Use(middlewares.HasPermission(enum.PermissionPostLock))
+	status, _ := server.AsUser(user).Execute(func(c *web.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	Expect(status).Equals(http.StatusOK)
+}
+
+func TestHasPermission_WithoutAPITokenScope(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserProfileStanding) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.HasPermission(enum.PermissionTagManage))
+	status, _ := server.
+		AsUser(mock.JonSnow).
+		WithAPIToken(&entity.APIToken{Scopes: []enum.APITokenScope{enum.APITokenScopeModerate}}).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusForbidden)
+}
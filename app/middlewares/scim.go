@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+// SCIMToken blocks requests that don't carry the SCIM token of the tenant as a bearer token
+func SCIMToken() web.MiddlewareFunc {
+	return func(next web.HandlerFunc) web.HandlerFunc {
+		return func(c *web.Context) error {
+			authHeader := c.Request.GetHeader("Authorization")
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return webutil.SCIMError(c, http.StatusUnauthorized, "", "A bearer token is required")
+			}
+
+			token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+			getToken := &query.GetSCIMTokenByHash{TokenHash: webutil.HashSCIMToken(token)}
+			if err := bus.Dispatch(c, getToken); err != nil {
+				if errors.Cause(err) == app.ErrNotFound {
+					return webutil.SCIMError(c, http.StatusUnauthorized, "", "SCIM token is invalid")
+				}
+				return c.Failure(err)
+			}
+
+			if err := webutil.TouchSCIMToken(c, getToken.Result); err != nil {
+				log.Error(c, err)
+			}
+
+			return next(c)
+		}
+	}
+}
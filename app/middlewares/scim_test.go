@@ -0,0 +1,87 @@
+package middlewares_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
+)
+
+func addSCIMTokenHandler(token, prefix string) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetSCIMTokenByHash) error {
+		if q.TokenHash == webutil.HashSCIMToken(token) {
+			q.Result = &entity.SCIMToken{Prefix: prefix, CreatedAt: time.Now()}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+}
+
+func TestSCIMToken_Valid(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, prefix := webutil.GenerateSCIMToken()
+	addSCIMTokenHandler(token, prefix)
+
+	touched := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.TouchSCIMToken) error {
+		touched = true
+		return nil
+	})
+
+	server.Use(middlewares.SCIMToken())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AddHeader("Authorization", "Bearer "+token).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(touched).IsTrue()
+}
+
+func TestSCIMToken_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, prefix := webutil.GenerateSCIMToken()
+	addSCIMTokenHandler(token, prefix)
+
+	server.Use(middlewares.SCIMToken())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AddHeader("Authorization", "Bearer "+token+"x").
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusUnauthorized)
+	Expect(response.Header().Get("Content-Type")).ContainsSubstring("application/scim+json")
+}
+
+func TestSCIMToken_Missing(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	server.Use(middlewares.SCIMToken())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		Execute(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusUnauthorized)
+}
@@ -1,7 +1,9 @@
 package middlewares
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +18,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
@@ -25,8 +28,10 @@ func User() web.MiddlewareFunc {
 	return func(next web.HandlerFunc) web.HandlerFunc {
 		return func(c *web.Context) error {
 			var (
-				token string
-				user  *entity.User
+				token    string
+				user     *entity.User
+				claims   *jwt.FiderClaims
+				apiToken *entity.APIToken
 			)
 
 			cookie, err := c.Request.Cookie(web.CookieAuthName)
@@ -40,7 +45,7 @@ func User() web.MiddlewareFunc {
 			}
 
 			if token != "" {
-				claims, err := jwt.DecodeFiderClaims(token)
+				claims, err = jwt.DecodeFiderClaims(token)
 				if err != nil {
 					c.RemoveCookie(web.CookieAuthName)
 					return next(c)
@@ -61,24 +66,63 @@ func User() web.MiddlewareFunc {
 				parts := strings.Split(authHeader, "Bearer")
 				if len(parts) == 2 {
 					apiKey := strings.TrimSpace(parts[1])
-					getUserByAPIKey := &query.GetUserByAPIKey{APIKey: apiKey}
-					err = bus.Dispatch(c, getUserByAPIKey)
-					if err != nil {
-						if errors.Cause(err) == app.ErrNotFound {
+					if webutil.IsAPIToken(apiKey) {
+						getAPIToken := &query.GetAPITokenByHash{TokenHash: webutil.HashAPIToken(apiKey)}
+						err = bus.Dispatch(c, getAPIToken)
+						if err != nil {
+							if errors.Cause(err) == app.ErrNotFound {
+								return c.HandleValidation(validate.Failed("API Token is invalid"))
+							}
+							return err
+						}
+						apiToken = getAPIToken.Result
+
+						if apiToken.IsExpired(time.Now()) {
+							return c.HandleValidation(validate.Failed("API Token has expired"))
+						}
+
+						if err := webutil.TouchAPIToken(c, apiToken); err != nil {
+							log.Error(c, err)
+						}
+					} else if webutil.IsOAuth2AccessToken(apiKey) {
+						apiToken, err = webutil.GetOAuth2AccessToken(c, apiKey)
+						if err != nil {
+							if errors.Cause(err) == app.ErrNotFound {
+								return c.HandleValidation(validate.Failed("Access Token is invalid"))
+							}
+							return err
+						}
+					} else {
+						getUserByAPIKey := &query.GetUserByAPIKey{APIKey: apiKey}
+						err = bus.Dispatch(c, getUserByAPIKey)
+						if err != nil {
+							if errors.Cause(err) == app.ErrNotFound {
+								return c.HandleValidation(validate.Failed("API Key is invalid"))
+							}
+							return err
+						}
+						user = getUserByAPIKey.Result
+
+						if !user.IsCollaborator() {
 							return c.HandleValidation(validate.Failed("API Key is invalid"))
 						}
-						return err
 					}
-					user = getUserByAPIKey.Result
 
-					if !user.IsCollaborator() {
-						return c.HandleValidation(validate.Failed("API Key is invalid"))
+					if apiToken != nil {
+						userByTokenID := &query.GetUserByID{UserID: apiToken.UserID}
+						if err := bus.Dispatch(c, userByTokenID); err != nil {
+							return err
+						}
+						user = userByTokenID.Result
 					}
 
 					if impersonateUserIDStr := c.Request.GetHeader("X-Fider-UserID"); impersonateUserIDStr != "" {
 						if !user.IsAdministrator() {
 							return c.HandleValidation(validate.Failed("Only Administrators are allowed to impersonate another user"))
 						}
+						if apiToken != nil && !apiToken.HasScope(enum.APITokenScopeAdmin) {
+							return c.HandleValidation(validate.Failed("Only API Tokens with the admin scope are allowed to impersonate another user"))
+						}
 						impersonateUserID, err := strconv.Atoi(impersonateUserIDStr)
 						if err != nil {
 							return c.HandleValidation(validate.Failed(fmt.Sprintf("User not found for given impersonate UserID '%s'", impersonateUserIDStr)))
@@ -97,71 +141,26 @@ func User() web.MiddlewareFunc {
 			}
 
 			if user != nil && c.Tenant() != nil && user.Tenant.ID == c.Tenant().ID {
-				// blocked users are unable to sign in
-				if user.Status == enum.UserBlocked {
-					c.RemoveCookie(web.CookieAuthName)
-					return c.Unauthorized()
-				}
-
-			var cachedStanding *query.GetUserProfileStanding
-			var standingFetched bool
-
-			fetchStanding := func(userID int) *query.GetUserProfileStanding {
-				if standingFetched {
-					return cachedStanding
-				}
-				standingFetched = true
-				cachedStanding = &query.GetUserProfileStanding{
-					UserID: userID,
-					Result: struct {
-						Warnings []struct {
-							ID        int        `json:"id"`
-							Reason    string     `json:"reason"`
-							CreatedAt time.Time  `json:"createdAt"`
-							ExpiresAt *time.Time `json:"expiresAt,omitempty"`
-						} `json:"warnings"`
-						Mutes []struct {
-							ID        int        `json:"id"`
-							Reason    string     `json:"reason"`
-							CreatedAt time.Time  `json:"createdAt"`
-							ExpiresAt *time.Time `json:"expiresAt,omitempty"`
-						} `json:"mutes"`
-					}{},
-				}
-				if err := bus.Dispatch(c, cachedStanding); err != nil {
-					return nil
-				}
-				return cachedStanding
-			}
-
-			user.SetWarningCheck(func(userID int) bool {
-				standing := fetchStanding(userID)
-				if standing == nil {
-					return false
-				}
-				now := time.Now()
-				for _, warning := range standing.Result.Warnings {
-					if warning.ExpiresAt == nil || warning.ExpiresAt.After(now) {
-						return true
+				if claims != nil {
+					ok, err := checkUserSession(c, claims, user)
+					if err != nil {
+						return err
+					}
+					if !ok {
+						c.RemoveCookie(web.CookieAuthName)
+						return next(c)
 					}
 				}
-				return false
-			})
 
-			user.SetMuteCheck(func(userID int) bool {
-				standing := fetchStanding(userID)
-				if standing == nil {
-					return false
-				}
-				now := time.Now()
-				for _, mute := range standing.Result.Mutes {
-					if mute.ExpiresAt == nil || mute.ExpiresAt.After(now) {
-						return true
+				if apiToken != nil {
+					if !apiToken.HasScope(apiTokenScopeFor(c.Request.Method, c.Request.URL.Path)) {
+						return c.Forbidden()
 					}
+					c.SetAPIToken(apiToken)
 				}
-				return false
-			})
 
+				SetStandingChecks(c, user)
+				SetPermissionLookup(c, user)
 				c.SetUser(user)
 			}
 
@@ -169,3 +168,141 @@ func User() web.MiddlewareFunc {
 		}
 	}
 }
+
+// checkUserSession returns false if the session of the auth cookie was revoked or has expired
+// Cookies issued before sessions were tracked are replaced by one with a new session, unless the user
+// has signed out everywhere since, which can only have happened after they were issued
+func checkUserSession(c *web.Context, claims *jwt.FiderClaims, user *entity.User) (bool, error) {
+	if claims.SessionID == "" {
+		revokedAt := &query.GetUserSessionsRevokedAt{UserID: user.ID}
+		if err := bus.Dispatch(c, revokedAt); err != nil {
+			return false, err
+		}
+		if revokedAt.Result != nil {
+			return false, nil
+		}
+
+		webutil.AddAuthUserCookie(c, user)
+		return true, nil
+	}
+
+	session, err := webutil.GetUserSession(c, claims.SessionID)
+	if err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if session.UserID != user.ID || !session.IsActive(time.Now()) {
+		return false, nil
+	}
+
+	if err := webutil.TouchUserSession(c, session); err != nil {
+		log.Error(c, err)
+	}
+
+	c.SetUserSession(session)
+	return true, nil
+}
+
+// apiTokenScopeFor returns the scope a personal API token needs for given request
+// Staff and administration routes also need the moderate or admin scope, see HasPermission,
+// and so do actions on content or profiles of other users, which are checked where they are authorized
+func apiTokenScopeFor(method, path string) enum.APITokenScope {
+	switch {
+	case strings.HasPrefix(path, "/api/v1/user/tokens") || strings.HasPrefix(path, "/api/v1/user/sessions"):
+		return enum.APITokenScopeAdmin
+	case method == http.MethodGet || method == http.MethodHead:
+		return enum.APITokenScopeReadPosts
+	case strings.HasPrefix(path, "/api/v1/user/"):
+		return enum.APITokenScopeAdmin
+	case strings.Contains(path, "/comments") || strings.Contains(path, "/reactions/"):
+		return enum.APITokenScopeWriteComments
+	default:
+		return enum.APITokenScopeWritePosts
+	}
+}
+
+// SetStandingChecks lets the user lazily look up their active warnings, mutes and restrictions
+func SetStandingChecks(ctx context.Context, user *entity.User) {
+	var cachedStanding *query.GetUserProfileStanding
+	var standingFetched bool
+
+	fetchStanding := func(userID int) *query.GetUserProfileStanding {
+		if standingFetched {
+			return cachedStanding
+		}
+		standingFetched = true
+		cachedStanding = &query.GetUserProfileStanding{
+			UserID: userID,
+		}
+		if err := bus.Dispatch(ctx, cachedStanding); err != nil {
+			return nil
+		}
+		return cachedStanding
+	}
+
+	user.SetWarningCheck(func(userID int) bool {
+		standing := fetchStanding(userID)
+		if standing == nil {
+			return false
+		}
+		now := time.Now()
+		for _, warning := range standing.Result.Warnings {
+			if warning.ExpiresAt == nil || warning.ExpiresAt.After(now) {
+				return true
+			}
+		}
+		return false
+	})
+
+	user.SetMuteCheck(func(userID int) bool {
+		standing := fetchStanding(userID)
+		if standing == nil {
+			return false
+		}
+		now := time.Now()
+		for _, mute := range standing.Result.Mutes {
+			if mute.ExpiresAt == nil || mute.ExpiresAt.After(now) {
+				return true
+			}
+		}
+		return false
+	})
+
+	user.SetRestrictionCheck(func(userID int, kind string) bool {
+		standing := fetchStanding(userID)
+		if standing == nil {
+			return false
+		}
+		now := time.Now()
+		for _, restriction := range standing.Result.Restrictions {
+			if restriction.Kind == kind && restriction.IsActive(now) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// SetPermissionLookup lets the user lazily look up the permissions of their custom role
+func SetPermissionLookup(ctx context.Context, user *entity.User) {
+	var permissions []enum.Permission
+	var fetched bool
+
+	user.SetPermissionLookup(func(userID int) []enum.Permission {
+		if fetched {
+			return permissions
+		}
+		fetched = true
+
+		getPermissions := &query.GetUserPermissions{UserID: userID}
+		if err := bus.Dispatch(ctx, getPermissions); err != nil {
+			log.Error(ctx, err)
+			return nil
+		}
+		permissions = getPermissions.Result
+		return permissions
+	})
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app/actions"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/middlewares"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
@@ -18,6 +20,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	webutil "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web/util"
 )
 
 func TestUser_NoCookie(t *testing.T) {
@@ -41,8 +44,9 @@ func TestUser_WithCookie(t *testing.T) {
 
 	server := mock.NewServer()
 	token, _ := jwt.Encode(jwt.FiderClaims{
-		UserID:   mock.JonSnow.ID,
-		UserName: mock.JonSnow.Name,
+		UserID:    mock.JonSnow.ID,
+		UserName:  mock.JonSnow.Name,
+		SessionID: "session-1",
 	})
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
@@ -53,6 +57,8 @@ func TestUser_WithCookie(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	addUserSessionHandler(activeSession(mock.JonSnow.ID, "session-1"))
+
 	server.Use(middlewares.User())
 	status, response := server.
 		OnTenant(mock.DemoTenant).
@@ -71,21 +77,26 @@ func TestUser_Blocked(t *testing.T) {
 	RegisterT(t)
 
 	server := mock.NewServer()
-	mock.JonSnow.Status = enum.UserBlocked
+	blockedUser := *mock.JonSnow
+	blockedUser.Status = enum.UserBlocked
 	token, _ := jwt.Encode(jwt.FiderClaims{
-		UserID:   mock.JonSnow.ID,
-		UserName: mock.JonSnow.Name,
+		UserID:    blockedUser.ID,
+		UserName:  blockedUser.Name,
+		SessionID: "session-1",
 	})
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
-		if q.UserID == mock.JonSnow.ID {
-			q.Result = mock.JonSnow
+		if q.UserID == blockedUser.ID {
+			q.Result = &blockedUser
 			return nil
 		}
 		return app.ErrNotFound
 	})
 
+	addUserSessionHandler(activeSession(blockedUser.ID, "session-1"))
+
 	server.Use(middlewares.User())
+	server.Use(middlewares.BlockBlockedUsers())
 	status, _ := server.
 		OnTenant(mock.DemoTenant).
 		AddHeader("Accept", "application/json").
@@ -103,8 +114,9 @@ func TestUser_LockedTenant_ShouldAllowSignIn(t *testing.T) {
 	server := mock.NewServer()
 	mock.DemoTenant.Status = enum.TenantLocked
 	token, _ := jwt.Encode(jwt.FiderClaims{
-		UserID:   mock.AryaStark.ID,
-		UserName: mock.AryaStark.Name,
+		UserID:    mock.AryaStark.ID,
+		UserName:  mock.AryaStark.Name,
+		SessionID: "session-1",
 	})
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
@@ -113,6 +125,8 @@ func TestUser_LockedTenant_ShouldAllowSignIn(t *testing.T) {
 		return nil
 	})
 
+	addUserSessionHandler(activeSession(mock.AryaStark.ID, "session-1"))
+
 	server.Use(middlewares.User())
 	status, response := server.
 		OnTenant(mock.DemoTenant).
@@ -190,8 +204,9 @@ func TestUser_WithSignUpCookie(t *testing.T) {
 
 	server := mock.NewServer()
 	token, _ := jwt.Encode(jwt.FiderClaims{
-		UserID:   mock.JonSnow.ID,
-		UserName: mock.JonSnow.Name,
+		UserID:    mock.JonSnow.ID,
+		UserName:  mock.JonSnow.Name,
+		SessionID: "session-1",
 	})
 
 	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
@@ -202,6 +217,8 @@ func TestUser_WithSignUpCookie(t *testing.T) {
 		return app.ErrNotFound
 	})
 
+	addUserSessionHandler(activeSession(mock.JonSnow.ID, "session-1"))
+
 	server.Use(middlewares.User())
 	status, response := server.
 		OnTenant(mock.DemoTenant).
@@ -426,3 +443,527 @@ func TestUser_Impersonation_ValidUser(t *testing.T) {
 	Expect(status).Equals(http.StatusOK)
 	Expect(response.Body.String()).Equals("Arya Stark")
 }
+
+func TestUser_Blocked_CanAppeal(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	blockedUser := *mock.JonSnow
+	blockedUser.Status = enum.UserBlocked
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:    blockedUser.ID,
+		UserName:  blockedUser.Name,
+		SessionID: "session-1",
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == blockedUser.ID {
+			q.Result = &blockedUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	addUserSessionHandler(activeSession(blockedUser.ID, "session-1"))
+
+	// the appeal routes are registered before BlockBlockedUsers
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://demo.test.fider.io/appeal").
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			return c.String(http.StatusOK, c.User().Name)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Jon Snow")
+}
+
+func TestUser_WithCookie_RevokedSession(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:    mock.JonSnow.ID,
+		UserName:  mock.JonSnow.Name,
+		SessionID: "session-1",
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	revoked := activeSession(mock.JonSnow.ID, "session-1")
+	revokedAt := time.Now().Add(-1 * time.Minute)
+	revoked.RevokedAt = &revokedAt
+	addUserSessionHandler(revoked)
+
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			if c.User() == nil {
+				return c.NoContent(http.StatusNoContent)
+			}
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusNoContent)
+	Expect(response.Header().Get("Set-Cookie")).ContainsSubstring(web.CookieAuthName + "=;")
+}
+
+func TestUser_WithCookie_SessionOfAnotherUser(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:    mock.JonSnow.ID,
+		UserName:  mock.JonSnow.Name,
+		SessionID: "session-1",
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	addUserSessionHandler(activeSession(mock.AryaStark.ID, "session-1"))
+
+	server.Use(middlewares.User())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			if c.User() == nil {
+				return c.NoContent(http.StatusNoContent)
+			}
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusNoContent)
+}
+
+func TestUser_WithCookie_SessionIsCached(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:    mock.JonSnow.ID,
+		UserName:  mock.JonSnow.Name,
+		SessionID: "session-1",
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+
+	queries := 0
+	session := activeSession(mock.JonSnow.ID, "session-1")
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserSession) error {
+		queries++
+		q.Result = session
+		return nil
+	})
+
+	server.Use(middlewares.User())
+	for range 3 {
+		status, _ := server.
+			OnTenant(mock.DemoTenant).
+			AddCookie(web.CookieAuthName, token).
+			Execute(func(c *web.Context) error {
+				Expect(c.UserSession()).Equals(session)
+				return c.NoContent(http.StatusOK)
+			})
+		Expect(status).Equals(http.StatusOK)
+	}
+
+	Expect(queries).Equals(1)
+}
+
+func TestUser_WithCookie_WithoutSession_IsUpgraded(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:   mock.JonSnow.ID,
+		UserName: mock.JonSnow.Name,
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserSessionsRevokedAt) error {
+		return nil
+	})
+
+	var created *cmd.CreateUserSession
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		created = c
+		return nil
+	})
+
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			return c.String(http.StatusOK, c.User().Name)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Jon Snow")
+	Expect(created).IsNotNil()
+	Expect(created.UserID).Equals(mock.JonSnow.ID)
+
+	cookies := response.Header()["Set-Cookie"]
+	Expect(cookies).HasLen(1)
+	claims, err := jwt.DecodeFiderClaims(web.ParseCookie(cookies[0]).Value)
+	Expect(err).IsNil()
+	Expect(claims.SessionID).Equals(created.SessionID)
+}
+
+func TestUser_WithCookie_WithoutSession_AfterSignOutEverywhere_IsRejected(t *testing.T) {
+	RegisterT(t)
+
+	server := mock.NewServer()
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:   mock.JonSnow.ID,
+		UserName: mock.JonSnow.Name,
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		q.Result = mock.JonSnow
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserSessionsRevokedAt) error {
+		revokedAt := time.Now().Add(-time.Hour)
+		q.Result = &revokedAt
+		return nil
+	})
+
+	created := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUserSession) error {
+		created = true
+		return nil
+	})
+
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		AddCookie(web.CookieAuthName, token).
+		Execute(func(c *web.Context) error {
+			if c.User() == nil {
+				return c.NoContent(http.StatusNoContent)
+			}
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusNoContent)
+	Expect(created).IsFalse()
+	Expect(response.Header().Get("Set-Cookie")).ContainsSubstring(web.CookieAuthName + "=;")
+}
+
+func TestUser_ValidAPIToken(t *testing.T) {
+	RegisterT(t)
+
+	token := &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID, Scopes: []enum.APITokenScope{enum.APITokenScopeReadPosts}}
+	addAPITokenHandler("fdr_arya", token)
+
+	var touched *cmd.TouchAPIToken
+	bus.AddHandler(func(ctx context.Context, c *cmd.TouchAPIToken) error {
+		touched = c
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer fdr_arya").
+		Execute(func(c *web.Context) error {
+			Expect(c.APIToken()).Equals(token)
+			return c.String(http.StatusOK, c.User().Name)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Arya Stark")
+	Expect(touched).IsNotNil()
+	Expect(touched.ID).Equals(1)
+}
+
+func TestUser_InvalidAPIToken(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_arya", &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer fdr_sansa").
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("API Token is invalid")
+}
+
+func TestUser_ExpiredAPIToken(t *testing.T) {
+	RegisterT(t)
+
+	expiresAt := time.Now().Add(-1 * time.Hour)
+	addAPITokenHandler("fdr_arya", &entity.APIToken{
+		ID:        1,
+		UserID:    mock.AryaStark.ID,
+		Scopes:    []enum.APITokenScope{enum.APITokenScopeReadPosts},
+		ExpiresAt: &expiresAt,
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer fdr_arya").
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("API Token has expired")
+}
+
+func TestUser_APIToken_MissingScope(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_arya", &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID, Scopes: []enum.APITokenScope{enum.APITokenScopeReadPosts}})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TouchAPIToken) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, _ := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts/1/comments").
+		AddHeader("Authorization", "Bearer fdr_arya").
+		AddHeader("Accept", "application/json").
+		ExecutePost(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		}, "{}")
+
+	Expect(status).Equals(http.StatusForbidden)
+}
+
+func TestUser_APIToken_Impersonation_WithoutAdminScope(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_jon", &entity.APIToken{ID: 1, UserID: mock.JonSnow.ID, Scopes: []enum.APITokenScope{enum.APITokenScopeModerate}})
+	bus.AddHandler(func(ctx context.Context, c *cmd.TouchAPIToken) error {
+		return nil
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer fdr_jon").
+		AddHeader("X-Fider-UserID", strconv.Itoa(mock.AryaStark.ID)).
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("Only API Tokens with the admin scope are allowed to impersonate another user")
+}
+
+func TestUser_APIToken_DeleteCommentOfOthers_WithoutModerateScope(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		scope  enum.APITokenScope
+		status int
+	}{
+		{enum.APITokenScopeWriteComments, http.StatusForbidden},
+		{enum.APITokenScopeModerate, http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		addAPITokenHandler("fdr_jon", &entity.APIToken{ID: 1, UserID: mock.JonSnow.ID, Scopes: []enum.APITokenScope{testCase.scope}})
+		bus.AddHandler(func(ctx context.Context, c *cmd.TouchAPIToken) error {
+			return nil
+		})
+		bus.AddHandler(func(ctx context.Context, q *query.GetCommentByID) error {
+			q.Result = &entity.Comment{ID: q.CommentID, Content: "Valar Morghulis", User: mock.AryaStark}
+			return nil
+		})
+
+		server := mock.NewServer()
+		server.Use(middlewares.User())
+		status, _ := server.
+			OnTenant(mock.DemoTenant).
+			WithURL("http://example.com/api/v1/posts/1/comments/2").
+			AddParam("number", 1).
+			AddParam("id", 2).
+			AddHeader("Authorization", "Bearer fdr_jon").
+			AddHeader("Accept", "application/json").
+			ExecutePost(func(c *web.Context) error {
+				if result := c.BindTo(new(actions.DeleteComment)); !result.Ok {
+					return c.HandleValidation(result)
+				}
+				return c.NoContent(http.StatusOK)
+			}, "{}")
+
+		Expect(status).Equals(testCase.status)
+	}
+}
+
+func activeSession(userID int, sessionID string) *entity.UserSession {
+	return &entity.UserSession{
+		ID:         1,
+		UserID:     userID,
+		SessionID:  sessionID,
+		CreatedAt:  time.Now(),
+		LastSeenAt: time.Now(),
+		ExpiresAt:  time.Now().Add(24 * time.Hour),
+	}
+}
+
+func addUserSessionHandler(sessions ...*entity.UserSession) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserSession) error {
+		for _, session := range sessions {
+			if session.SessionID == q.SessionID {
+				q.Result = session
+				return nil
+			}
+		}
+		return app.ErrNotFound
+	})
+}
+
+func TestUser_OAuth2AccessToken(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_arya", &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID})
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthClientByClientID) error {
+		if q.ClientID == "roadmap-bot" {
+			q.Result = &entity.OAuthClient{ID: 7, ClientID: "roadmap-bot", Name: "Roadmap Bot", Scopes: []enum.APITokenScope{enum.APITokenScopeReadPosts}}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	// the client no longer has the write:comments scope, so the token can't use it anymore
+	token, _ := jwt.Encode(jwt.OAuth2AccessClaims{
+		UserID:   mock.AryaStark.ID,
+		TenantID: mock.DemoTenant.ID,
+		ClientID: "roadmap-bot",
+		Scope:    "read:posts write:comments",
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(1 * time.Hour)),
+		},
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, response := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+token).
+		Execute(func(c *web.Context) error {
+			Expect(c.APIToken().Name).Equals("Roadmap Bot")
+			Expect(c.APIToken().Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts})
+			return c.String(http.StatusOK, c.User().Name)
+		})
+
+	Expect(status).Equals(http.StatusOK)
+	Expect(response.Body.String()).Equals("Arya Stark")
+}
+
+func TestUser_OAuth2AccessToken_DeletedClient(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_arya", &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID})
+	bus.AddHandler(func(ctx context.Context, q *query.GetOAuthClientByClientID) error {
+		return app.ErrNotFound
+	})
+
+	token, _ := jwt.Encode(jwt.OAuth2AccessClaims{
+		UserID:   mock.AryaStark.ID,
+		TenantID: mock.DemoTenant.ID,
+		ClientID: "roadmap-bot",
+		Scope:    "read:posts",
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(1 * time.Hour)),
+		},
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+token).
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("Access Token is invalid")
+}
+
+func TestUser_OAuth2AccessToken_AuthCookieIsNotAccepted(t *testing.T) {
+	RegisterT(t)
+
+	addAPITokenHandler("fdr_arya", &entity.APIToken{ID: 1, UserID: mock.AryaStark.ID})
+
+	token, _ := jwt.Encode(jwt.FiderClaims{
+		UserID:   mock.AryaStark.ID,
+		UserName: mock.AryaStark.Name,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(1 * time.Hour)),
+		},
+	})
+
+	server := mock.NewServer()
+	server.Use(middlewares.User())
+	status, query := server.
+		OnTenant(mock.DemoTenant).
+		WithURL("http://example.com/api/v1/posts").
+		AddHeader("Authorization", "Bearer "+token).
+		ExecuteAsJSON(func(c *web.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+
+	Expect(status).Equals(http.StatusBadRequest)
+	Expect(query.String("errors[0].message")).Equals("Access Token is invalid")
+}
+
+func addAPITokenHandler(secret string, token *entity.APIToken) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetAPITokenByHash) error {
+		if q.TokenHash == webutil.HashAPIToken(secret) {
+			q.Result = token
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		for _, user := range []*entity.User{mock.JonSnow, mock.AryaStark} {
+			if user.ID == q.UserID {
+				q.Result = user
+				return nil
+			}
+		}
+		return app.ErrNotFound
+	})
+}
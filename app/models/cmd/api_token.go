@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type CreateAPIToken struct {
+	UserID    int
+	Name      string
+	TokenHash string
+	Prefix    string
+	Scopes    []enum.APITokenScope
+	ExpiresAt *time.Time
+
+	Result *entity.APIToken
+}
+
+type DeleteAPIToken struct {
+	UserID int
+	ID     int
+}
+
+type TouchAPIToken struct {
+	ID int
+}
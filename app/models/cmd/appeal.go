@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// CreateAppeal files an appeal from the current user against one of their sanctions
+type CreateAppeal struct {
+	SanctionType   string
+	SanctionID     int
+	SanctionReason string
+	Text           string
+	Result         int
+}
+
+// DecideAppeal closes an appeal. ReplacementID is the warning or mute issued
+// in place of the original one when the sanction was reduced
+type DecideAppeal struct {
+	AppealID      int
+	Status        enum.AppealStatus
+	Note          string
+	ReducedUntil  *time.Time
+	ReplacementID int
+}
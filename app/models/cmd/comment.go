@@ -5,8 +5,10 @@ import (
 )
 
 type AddNewComment struct {
-	Post    *entity.Post
-	Content string
+	Post              *entity.Post
+	Content           string
+	ShadowHidden      bool
+	ModerationPending bool
 
 	Result *entity.Comment
 }
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type CreateCustomRole struct {
+	Name        string
+	Description string
+	Permissions []enum.Permission
+
+	Result *entity.CustomRole
+}
+
+type UpdateCustomRole struct {
+	ID          int
+	Name        string
+	Description string
+	Permissions []enum.Permission
+}
+
+type DeleteCustomRole struct {
+	ID int
+}
+
+// SetUserCustomRole assigns a custom role to a user, a CustomRoleID of 0 removes it
+type SetUserCustomRole struct {
+	UserID       int
+	CustomRoleID int
+}
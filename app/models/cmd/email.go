@@ -1,6 +1,11 @@
 package cmd
 
-import "github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+import (
+	"encoding/json"
+	"html/template"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+)
 
 type SendMail struct {
 	From         dto.Recipient
@@ -8,3 +13,54 @@ type SendMail struct {
 	TemplateName string
 	Props        dto.Props
 }
+
+// deferredMail is how a SendMail is stored until it is sent, the keys of trusted HTML props are kept
+// because JSON turns them into plain strings that the templates would escape
+type deferredMail struct {
+	Mail      *SendMail
+	HTMLProps map[int][]string
+}
+
+// MarshalDeferred serializes the email so that it can be sent later with UnmarshalDeferredMail
+func (c *SendMail) MarshalDeferred() (string, error) {
+	htmlProps := map[int][]string{-1: htmlKeys(c.Props)}
+	for i, to := range c.To {
+		htmlProps[i] = htmlKeys(to.Props)
+	}
+
+	payload, err := json.Marshal(&deferredMail{Mail: c, HTMLProps: htmlProps})
+	return string(payload), err
+}
+
+// UnmarshalDeferredMail restores an email serialized with MarshalDeferred
+func UnmarshalDeferredMail(payload string) (*SendMail, error) {
+	deferred := &deferredMail{}
+	if err := json.Unmarshal([]byte(payload), deferred); err != nil {
+		return nil, err
+	}
+
+	mail := deferred.Mail
+	restoreHTML(mail.Props, deferred.HTMLProps[-1])
+	for i := range mail.To {
+		restoreHTML(mail.To[i].Props, deferred.HTMLProps[i])
+	}
+	return mail, nil
+}
+
+func htmlKeys(props dto.Props) []string {
+	keys := make([]string, 0)
+	for k, v := range props {
+		if _, ok := v.(template.HTML); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func restoreHTML(props dto.Props, keys []string) {
+	for _, k := range keys {
+		if v, ok := props[k].(string); ok {
+			props[k] = template.HTML(v)
+		}
+	}
+}
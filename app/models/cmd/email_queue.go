@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// DeliverMail hands an email over to the email provider
+// SendMail only queues emails, this is what the queue uses to send them
+type DeliverMail struct {
+	Mail *SendMail
+}
+
+// QueueMail stores an email to a single recipient in the outbound queue
+type QueueMail struct {
+	TemplateName string
+	Recipient    string
+	Payload      string
+	BaseURL      string
+}
+
+// UpdateQueuedEmail records the outcome of an attempt to deliver a queued email
+// NextAttemptAt is only used when the email is still pending
+type UpdateQueuedEmail struct {
+	ID            int
+	Status        enum.QueuedEmailStatus
+	LastError     string
+	NextAttemptAt time.Time
+}
+
+// RetryQueuedEmail puts a failed email of the tenant back in the queue
+type RetryQueuedEmail struct {
+	ID int
+}
+
+// PurgeQueuedEmails deletes the emails that were created before given time and are no longer pending
+type PurgeQueuedEmails struct {
+	Before time.Time
+
+	//Output
+	NumOfDeletedEmails int
+}
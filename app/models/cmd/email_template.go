@@ -0,0 +1,19 @@
+package cmd
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+// SaveEmailTemplate creates or replaces the override of an email template in a locale
+type SaveEmailTemplate struct {
+	Name    string
+	Locale  string
+	Subject string
+	Body    string
+
+	Result *entity.EmailTemplate
+}
+
+// DeleteEmailTemplate removes the override of an email template in a locale, restoring the built-in one
+type DeleteEmailTemplate struct {
+	Name   string
+	Locale string
+}
@@ -9,6 +9,7 @@ type MuteUser struct {
 	UserID    int
 	Reason    string
 	ExpiresAt time.Time
+	Result    int
 }
 
 // WarnUser represents the command to warn a user
@@ -16,6 +17,7 @@ type WarnUser struct {
 	UserID    int
 	Reason    string
 	ExpiresAt time.Time
+	Result    int
 }
 
 // DeleteWarning represents the command to delete a warning
@@ -39,3 +41,33 @@ type ExpireMute struct {
 	UserID int
 	MuteID int
 }
+
+// ProposeUserBlock asks staff to review a user for a block. Result is false
+// when the user already had a pending proposal
+type ProposeUserBlock struct {
+	UserID int
+	Reason string
+	Result bool
+}
+
+// DismissUserBlockProposal closes the pending block proposal of a user without blocking them.
+// Result is false when the user had no pending proposal
+type DismissUserBlockProposal struct {
+	UserID int
+	Result bool
+}
+
+// RestrictUser shadow-bans a user or puts them on probation until ExpiresAt
+type RestrictUser struct {
+	UserID    int
+	Kind      string
+	Reason    string
+	ExpiresAt time.Time
+	Result    int
+}
+
+// LiftRestriction ends a shadow-ban or probation early
+type LiftRestriction struct {
+	UserID        int
+	RestrictionID int
+}
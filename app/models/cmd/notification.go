@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 )
 
 type MarkAllNotificationsAsRead struct{}
@@ -23,6 +26,8 @@ type AddNewNotification struct {
 	Title  string
 	Link   string
 	PostID int
+	// Event groups the notification with an unread one of the same event on the same post, if any
+	Event string
 
 	Result *entity.Notification
 }
@@ -37,9 +42,51 @@ type RemoveSubscriber struct {
 	User *entity.User
 }
 
+type MuteSubscriber struct {
+	Post *entity.Post
+	User *entity.User
+}
+
+// SetTagPreference makes the user follow or mute a tag, or clears it when Preference is zero
+type SetTagPreference struct {
+	Tag        *entity.Tag
+	User       *entity.User
+	Preference enum.TagPreference
+}
+
 type SupressEmail struct {
 	EmailAddresses []string
 
 	//Output
 	NumOfSupressedEmailAddresses int
 }
+
+type QueueDigestItems struct {
+	Users      []*entity.User
+	PostID     int
+	Event      string
+	AuthorName string
+	Excerpt    string
+	BaseURL    string
+	Link       string
+}
+
+type ClearDigestItems struct {
+	ItemIDs []int
+
+	//Output
+	NumOfClearedItems int
+}
+
+// DeferNotification holds a push or email notification back until the quiet hours of its recipient end
+type DeferNotification struct {
+	UserID    int
+	Channel   enum.NotificationChannel
+	Payload   string
+	BaseURL   string
+	ReleaseAt time.Time
+}
+
+type DeleteDeferredNotifications struct {
+	IDs []int
+}
@@ -8,6 +8,7 @@ type SaveCustomOAuthConfig struct {
 	ID                int
 	Logo              *dto.ImageUpload
 	Provider          string
+	Type              int
 	Status            int
 	DisplayName       string
 	ClientID          string
@@ -16,6 +17,7 @@ type SaveCustomOAuthConfig struct {
 	TokenURL          string
 	Scope             string
 	ProfileURL        string
+	IssuerURL         string
 	IsTrusted         bool
 	JSONUserIDPath    string
 	JSONUserNamePath  string
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type CreateOAuthClient struct {
+	ClientID     string
+	SecretHash   string
+	Name         string
+	RedirectURIs []string
+	Scopes       []enum.APITokenScope
+
+	Result *entity.OAuthClient
+}
+
+type DeleteOAuthClient struct {
+	ID int
+}
+
+type CreateOAuthAuthorizationCode struct {
+	ClientID      int
+	UserID        int
+	CodeHash      string
+	RedirectURI   string
+	Scopes        []enum.APITokenScope
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
+
+// UseOAuthAuthorizationCode marks the code as used, a code can only be exchanged once
+type UseOAuthAuthorizationCode struct {
+	CodeHash string
+
+	Result *entity.OAuthGrant
+}
+
+type CreateOAuthRefreshToken struct {
+	ClientID  int
+	UserID    int
+	TokenHash string
+	Scopes    []enum.APITokenScope
+	ExpiresAt time.Time
+}
+
+// UseOAuthRefreshToken revokes the refresh token, a new one is issued every time it's used
+type UseOAuthRefreshToken struct {
+	TokenHash string
+
+	Result *entity.OAuthGrant
+}
+
+type PurgeExpiredOAuthGrants struct {
+	NumOfDeletedGrants int
+}
@@ -119,9 +119,11 @@ type DeletePageTag struct {
 }
 
 type AddPageComment struct {
-	Page    *entity.Page
-	Content string
-	Result  *entity.Comment
+	Page              *entity.Page
+	Content           string
+	ShadowHidden      bool
+	ModerationPending bool
+	Result            *entity.Comment
 }
 
 type RefreshPageEmbeddedData struct {
@@ -8,8 +8,10 @@ import (
 )
 
 type AddNewPost struct {
-	Title       string
-	Description string
+	Title             string
+	Description       string
+	ShadowHidden      bool
+	ModerationPending bool
 
 	Result *entity.Post
 }
@@ -16,6 +16,12 @@ type DeletePushSubscriptionByEndpoint struct {
 	Endpoint string
 }
 
+// RecordPushDelivery tracks whether the push service accepted a message for the subscription
+type RecordPushDelivery struct {
+	SubscriptionID int
+	Success        bool
+}
+
 type SendPushNotification struct {
 	UserID int
 	Title  string
@@ -4,13 +4,17 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 )
 
+// CreateReport files a report by ReporterID or the current user.
+// System reports are filed by the site itself and have no reporter
 type CreateReport struct {
 	ReportedType enum.ReportType
 	ReportedID   int
 	Reason       string
 	Details      string
 	ReporterID   *int
+	System       bool
 	Result       int
+	ResultCaseID int
 }
 
 type AssignReport struct {
@@ -28,6 +32,19 @@ type ResolveReport struct {
 	ResolutionNote string
 }
 
+type ResolveReportCase struct {
+	CaseID         int
+	Status         enum.ReportStatus
+	ResolutionNote string
+}
+
+// MarkReportCaseAutoHidden flags a case as auto-hidden. Result is false
+// when the case had already been auto-hidden before
+type MarkReportCaseAutoHidden struct {
+	CaseID int
+	Result bool
+}
+
 type DeleteReport struct {
 	ReportID int
 }
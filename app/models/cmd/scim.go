@@ -0,0 +1,19 @@
+package cmd
+
+// SetSCIMToken replaces the SCIM token of the tenant, if any
+type SetSCIMToken struct {
+	TokenHash string
+	Prefix    string
+}
+
+type DeleteSCIMToken struct {
+}
+
+type TouchSCIMToken struct {
+}
+
+// SetSCIMExternalID stores the identifier the identity provider uses for a user, an empty ExternalID removes it
+type SetSCIMExternalID struct {
+	UserID     int
+	ExternalID string
+}
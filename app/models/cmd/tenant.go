@@ -28,6 +28,11 @@ type UpdateTenantEmailAuthAllowedSettings struct {
 	IsEmailAuthAllowed bool
 }
 
+// UpdateTenantTwoFactorPolicy only changes which roles must use two-factor authentication, leaving other general settings as they are
+type UpdateTenantTwoFactorPolicy struct {
+	Roles []string
+}
+
 type UpdateTenantSettings struct {
 	Logo           *dto.ImageUpload
 	Title          string
@@ -45,6 +45,19 @@ type RegisterUserProvider struct {
 	ProviderUID  string
 }
 
+type UnregisterUserProvider struct {
+	UserID       int
+	ProviderName string
+}
+
+// MoveUserProvider moves a provider identity from one user to another, the identity must still belong to FromUserID
+type MoveUserProvider struct {
+	FromUserID   int
+	ToUserID     int
+	ProviderName string
+	ProviderUID  string
+}
+
 type UpdateCurrentUser struct {
 	Name       string
 	AvatarType enum.AvatarType
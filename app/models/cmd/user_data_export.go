@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+)
+
+// CreateUserDataExport records a data export requested by the current user
+type CreateUserDataExport struct {
+	ExpiresAt time.Time
+
+	Result *entity.UserDataExport
+}
+
+// CompleteUserDataExport saves where the assembled export is stored and until when it can be downloaded
+type CompleteUserDataExport struct {
+	ID        int
+	BlobKey   string
+	ExpiresAt time.Time
+}
+
+// DeleteUserDataExports removes the records of exports whose blobs have been deleted, from any tenant
+type DeleteUserDataExports struct {
+	IDs []int
+}
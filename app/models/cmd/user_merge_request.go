@@ -0,0 +1,18 @@
+package cmd
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+
+// CreateUserMergeRequest asks staff to move a provider identity of another user to the current user
+// Result is the ID of the pending request, which is reused if the same identity was already requested
+type CreateUserMergeRequest struct {
+	OwnerID     int
+	Provider    string
+	ProviderUID string
+	Result      int
+}
+
+// DecideUserMergeRequest approves or rejects a merge request
+type DecideUserMergeRequest struct {
+	RequestID int
+	Status    enum.UserMergeRequestStatus
+}
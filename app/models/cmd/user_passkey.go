@@ -0,0 +1,23 @@
+package cmd
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+type AddUserPasskey struct {
+	UserID       int
+	Name         string
+	CredentialID string
+	Credential   []byte
+
+	Result *entity.UserPasskey
+}
+
+// UpdateUserPasskeyUsage stores the credential again after a sign-in, as its signature counter and flags may have changed
+type UpdateUserPasskeyUsage struct {
+	ID         int
+	Credential []byte
+}
+
+type DeleteUserPasskey struct {
+	ID     int
+	UserID int
+}
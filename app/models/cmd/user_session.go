@@ -0,0 +1,38 @@
+package cmd
+
+import "time"
+
+type CreateUserSession struct {
+	UserID    int
+	SessionID string
+	IPAddress string
+	UserAgent string
+	ExpiresAt time.Time
+}
+
+type TouchUserSession struct {
+	SessionID string
+	IPAddress string
+	UserAgent string
+}
+
+type RevokeUserSession struct {
+	UserID int
+	ID     int
+
+	// SessionID is the revoked session, so that it can be removed from the cache
+	Result string
+}
+
+type RevokeUserSessions struct {
+	UserID int
+	// ExceptSessionID keeps the session that asked to sign out everywhere else
+	ExceptSessionID string
+
+	// Result are the revoked sessions, so that they can be removed from the cache
+	Result []string
+}
+
+type PurgeExpiredUserSessions struct {
+	NumOfDeletedSessions int
+}
@@ -0,0 +1,39 @@
+package cmd
+
+// SaveUserTwoFactorSecret starts an enrollment, replacing any enrollment that was not confirmed
+type SaveUserTwoFactorSecret struct {
+	UserID int
+	Secret string
+}
+
+// EnableUserTwoFactor confirms the enrollment and replaces the recovery codes of the user
+type EnableUserTwoFactor struct {
+	UserID             int
+	Step               int64
+	RecoveryCodeHashes []string
+}
+
+type DisableUserTwoFactor struct {
+	UserID int
+}
+
+type ReplaceUserRecoveryCodes struct {
+	UserID             int
+	RecoveryCodeHashes []string
+}
+
+// UseTwoFactorStep records a code as used, it fails with app.ErrNotFound if the step is not newer than the last used one
+type UseTwoFactorStep struct {
+	UserID int
+	Step   int64
+}
+
+// UseRecoveryCode marks a recovery code as used, it fails with app.ErrNotFound if there's no such unused code
+type UseRecoveryCode struct {
+	UserID   int
+	CodeHash string
+}
+
+type RecordTwoFactorFailure struct {
+	UserID int
+}
@@ -7,6 +7,10 @@ type Recipient struct {
 	Name    string
 	Address string
 	Props   Props
+	// ReplyTo overrides the Reply-To header of the email sent to this recipient
+	ReplyTo string
+	// Unsubscribe is the one-click unsubscribe URL sent in the List-Unsubscribe header
+	Unsubscribe string
 }
 
 // NewRecipient creates a new Recipient
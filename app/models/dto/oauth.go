@@ -18,3 +18,14 @@ type OAuthProviderOption struct {
 	IsCustomProvider bool   `json:"isCustomProvider"`
 	IsEnabled        bool   `json:"isEnabled"`
 }
+
+//OIDCConfiguration is the discovery document of an OpenID Connect issuer
+type OIDCConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	UserinfoEndpoint                 string   `json:"userinfo_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported    []string `json:"code_challenge_methods_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
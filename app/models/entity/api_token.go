@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// APIToken is a personal access token, only a hash of the token is stored so it can't be shown again after creation
+type APIToken struct {
+	ID         int                  `json:"id"`
+	UserID     int                  `json:"-"`
+	Name       string               `json:"name"`
+	Prefix     string               `json:"prefix"`
+	Scopes     []enum.APITokenScope `json:"scopes"`
+	ExpiresAt  *time.Time           `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time           `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time            `json:"createdAt"`
+}
+
+// IsExpired returns true if the token can no longer be used
+func (t *APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !t.ExpiresAt.After(now)
+}
+
+// HasScope returns true if any of the scopes of the token allows what scope allows
+func (t *APIToken) HasScope(scope enum.APITokenScope) bool {
+	for _, s := range t.Scopes {
+		if s.Includes(scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+const (
+	// AppealSanctionWarning is an appeal against a warning
+	AppealSanctionWarning = "warning"
+	// AppealSanctionMute is an appeal against a mute
+	AppealSanctionMute = "mute"
+	// AppealSanctionBlock is an appeal against a block
+	AppealSanctionBlock = "block"
+)
+
+// Appeal is a request from a sanctioned user to review a warning, mute or block
+type Appeal struct {
+	ID                int               `json:"id"`
+	User              *User             `json:"user"`
+	SanctionType      string            `json:"sanctionType"`
+	SanctionID        int               `json:"sanctionId,omitempty"`
+	SanctionReason    string            `json:"sanctionReason"`
+	SanctionExpiresAt *time.Time        `json:"sanctionExpiresAt,omitempty"`
+	Text              string            `json:"text"`
+	Status            enum.AppealStatus `json:"status"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	DecidedAt         *time.Time        `json:"decidedAt,omitempty"`
+	DecidedBy         *User             `json:"decidedBy,omitempty"`
+	DecisionNote      string            `json:"decisionNote,omitempty"`
+	ReducedUntil      *time.Time        `json:"reducedUntil,omitempty"`
+}
+
+// IsPending returns true if the appeal still awaits a staff decision
+func (a *Appeal) IsPending() bool {
+	return a.Status == enum.AppealStatusPending
+}
+
+// IsValidAppealSanction returns true if the given sanction type can be appealed
+func IsValidAppealSanction(sanctionType string) bool {
+	return sanctionType == AppealSanctionWarning || sanctionType == AppealSanctionMute || sanctionType == AppealSanctionBlock
+}
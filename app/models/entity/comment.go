@@ -23,6 +23,7 @@ type Comment struct {
 	Mentions          []Mention        `json:"_"`
 	ModerationPending bool             `json:"moderationPending,omitempty"`
 	ModerationData    string           `json:"moderationData,omitempty"`
+	ShadowHidden      bool             `json:"shadowHidden,omitempty"`
 }
 
 func (c *Comment) ParseMentions() {
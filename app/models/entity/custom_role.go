@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// CustomRole is a role composed by the administrators of a tenant from individual permissions
+type CustomRole struct {
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Permissions []enum.Permission `json:"permissions"`
+	MemberCount int               `json:"memberCount"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
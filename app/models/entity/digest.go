@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// DigestHour is the time of day, in the timezone of each user, digest emails are sent at
+const DigestHour = 8
+
+// DigestItem is a notification waiting to be delivered in the next digest email of a user
+type DigestItem struct {
+	ID         int
+	Event      string
+	AuthorName string
+	Excerpt    string
+	Link       string
+	PostID     int
+	PostNumber int
+	PostSlug   string
+	PostTitle  string
+	CreatedAt  time.Time
+}
+
+// Digest is the set of pending digest items of a single user
+type Digest struct {
+	Tenant    *Tenant
+	User      *User
+	BaseURL   string
+	Frequency string
+	// Location is the timezone of the user, and QuietHours their quiet hours if they set them
+	Location   *time.Location
+	QuietHours *QuietHours
+	Items      []*DigestItem
+}
+
+// IsDue returns true if the digest is to be sent within the hour that starts at from.
+// Digests go out at DigestHour in the timezone of the user, or at the end of their quiet hours
+// when those cover it, and weekly digests only go out on mondays
+func (d *Digest) IsDue(from time.Time) bool {
+	location := d.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	// quiet hours can push the digest of a day into the next one
+	year, month, day := from.In(location).Date()
+	for _, offset := range []int{0, -1} {
+		scheduled := time.Date(year, month, day+offset, DigestHour, 0, 0, 0, location)
+		sendAt := scheduled
+		if d.QuietHours != nil {
+			sendAt = d.QuietHours.ReleaseAt(scheduled)
+		}
+		if sendAt.Before(from) || !sendAt.Before(from.Add(time.Hour)) {
+			continue
+		}
+		return d.Frequency != enum.DigestFrequencyWeekly || scheduled.Weekday() == time.Monday
+	}
+	return false
+}
+
+// DigestGroup holds the digest items of a single post
+type DigestGroup struct {
+	PostNumber int
+	PostSlug   string
+	PostTitle  string
+	Items      []*DigestItem
+}
+
+// Groups returns the digest items grouped by post, in the order each post first appeared
+func (d *Digest) Groups() []*DigestGroup {
+	groups := make([]*DigestGroup, 0)
+	byPost := make(map[int]*DigestGroup)
+	for _, item := range d.Items {
+		group, ok := byPost[item.PostID]
+		if !ok {
+			group = &DigestGroup{
+				PostNumber: item.PostNumber,
+				PostSlug:   item.PostSlug,
+				PostTitle:  item.PostTitle,
+			}
+			byPost[item.PostID] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+	return groups
+}
+
+// ItemIDs returns the IDs of all items in the digest
+func (d *Digest) ItemIDs() []int {
+	ids := make([]int, len(d.Items))
+	for i, item := range d.Items {
+		ids[i] = item.ID
+	}
+	return ids
+}
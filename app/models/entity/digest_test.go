@@ -0,0 +1,64 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestDigest_Groups(t *testing.T) {
+	RegisterT(t)
+
+	digest := &entity.Digest{
+		Items: []*entity.DigestItem{
+			{ID: 1, PostID: 10, PostNumber: 1, PostTitle: "First"},
+			{ID: 2, PostID: 20, PostNumber: 2, PostTitle: "Second"},
+			{ID: 3, PostID: 10, PostNumber: 1, PostTitle: "First"},
+		},
+	}
+
+	groups := digest.Groups()
+	Expect(groups).HasLen(2)
+	Expect(groups[0].PostTitle).Equals("First")
+	Expect(groups[0].Items).HasLen(2)
+	Expect(groups[0].Items[1].ID).Equals(3)
+	Expect(groups[1].PostTitle).Equals("Second")
+	Expect(groups[1].Items).HasLen(1)
+
+	Expect(digest.ItemIDs()).Equals([]int{1, 2, 3})
+}
+
+func TestDigest_IsDue(t *testing.T) {
+	RegisterT(t)
+
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+
+	// monday 08:00 in São Paulo is 11:00 UTC
+	monday := time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)
+	digest := &entity.Digest{Frequency: enum.DigestFrequencyDaily, Location: saoPaulo}
+	Expect(digest.IsDue(monday)).IsTrue()
+	Expect(digest.IsDue(monday.Add(-time.Hour))).IsFalse()
+	Expect(digest.IsDue(monday.Add(time.Hour))).IsFalse()
+
+	// without a timezone digests go out at 08:00 UTC
+	Expect((&entity.Digest{Frequency: enum.DigestFrequencyDaily}).IsDue(monday.Add(-3 * time.Hour))).IsTrue()
+
+	// weekly digests only go out on mondays
+	digest.Frequency = enum.DigestFrequencyWeekly
+	Expect(digest.IsDue(monday)).IsTrue()
+	Expect(digest.IsDue(monday.AddDate(0, 0, 1))).IsFalse()
+
+	// quiet hours hold the digest back until they end
+	digest.Frequency = enum.DigestFrequencyDaily
+	digest.QuietHours = entity.NewQuietHours("America/Sao_Paulo", "22:00", "09:30")
+	Expect(digest.IsDue(monday)).IsFalse()
+	Expect(digest.IsDue(monday.Add(time.Hour))).IsTrue()
+
+	// even into the next day
+	digest.QuietHours = entity.NewQuietHours("America/Sao_Paulo", "07:00", "06:00")
+	Expect(digest.IsDue(monday)).IsFalse()
+	Expect(digest.IsDue(monday.AddDate(0, 0, 1).Add(-2 * time.Hour))).IsTrue()
+}
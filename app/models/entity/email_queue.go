@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// QueuedEmail is an email to a single recipient in the outbound queue
+// Payload is the email serialized with SendMail.MarshalDeferred
+type QueuedEmail struct {
+	ID            int                    `json:"id"`
+	Tenant        *Tenant                `json:"-"`
+	TemplateName  string                 `json:"templateName"`
+	Recipient     string                 `json:"recipient"`
+	Payload       string                 `json:"-"`
+	BaseURL       string                 `json:"-"`
+	Status        enum.QueuedEmailStatus `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	LastError     string                 `json:"lastError,omitempty"`
+	NextAttemptAt time.Time              `json:"nextAttemptAt"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	SentAt        *time.Time             `json:"sentAt,omitempty"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// EmailTemplate is a tenant's override of a built-in email template in one locale
+// Subject and Body replace the "subject" and "body" blocks of the built-in template
+type EmailTemplate struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Locale    string    `json:"locale"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
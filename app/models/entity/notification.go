@@ -18,4 +18,13 @@ type Notification struct {
 	AvatarBlobKey string          `json:"-" db:"avatar_bkey"`
 	AvatarType    enum.AvatarType `json:"-" db:"avatar_type"`
 	AvatarURL     string          `json:"avatarURL,omitempty"`
+	Event         string          `json:"-" db:"event"`
+	PostTitle     string          `json:"-" db:"post_title"`
+	ActorCount    int             `json:"actorCount" db:"actor_count"`
+	LatestActors  []string        `json:"latestActors,omitempty" db:"latest_actors"`
+}
+
+// IsGrouped returns true if the notification collapses the same event from more than one user
+func (n *Notification) IsGrouped() bool {
+	return n.Event != "" && n.ActorCount > 1
 }
@@ -1,11 +1,16 @@
 package entity
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
 
 // OAuthConfig is the configuration of a custom OAuth provider
 type OAuthConfig struct {
 	ID                int
 	Provider          string
+	Type              int
 	DisplayName       string
 	LogoBlobKey       string
 	Status            int
@@ -14,6 +19,7 @@ type OAuthConfig struct {
 	AuthorizeURL      string
 	TokenURL          string
 	ProfileURL        string
+	IssuerURL         string
 	Scope             string
 	IsTrusted         bool
 	JSONUserIDPath    string
@@ -30,6 +36,7 @@ func (o OAuthConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"id":                o.ID,
 		"provider":          o.Provider,
+		"type":              o.Type,
 		"displayName":       o.DisplayName,
 		"logoBlobKey":       o.LogoBlobKey,
 		"status":            o.Status,
@@ -38,6 +45,7 @@ func (o OAuthConfig) MarshalJSON() ([]byte, error) {
 		"authorizeURL":      o.AuthorizeURL,
 		"tokenURL":          o.TokenURL,
 		"profileURL":        o.ProfileURL,
+		"issuerURL":         o.IssuerURL,
 		"scope":             o.Scope,
 		"isTrusted":         o.IsTrusted,
 		"jsonUserIDPath":    o.JSONUserIDPath,
@@ -45,3 +53,8 @@ func (o OAuthConfig) MarshalJSON() ([]byte, error) {
 		"jsonUserEmailPath": o.JSONUserEmailPath,
 	})
 }
+
+// IsOIDC returns true if the endpoints of the provider are discovered from its issuer
+func (o *OAuthConfig) IsOIDC() bool {
+	return o.Type == enum.OAuthConfigTypeOIDC
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// OAuthClient is a third-party app that users can authorize to use the API on their behalf
+// Not to be confused with OAuthConfig, which is a provider users sign in with
+type OAuthClient struct {
+	ID           int                  `json:"id"`
+	ClientID     string               `json:"clientId"`
+	Name         string               `json:"name"`
+	RedirectURIs []string             `json:"redirectUris"`
+	Scopes       []enum.APITokenScope `json:"scopes"`
+	// IsConfidential is true for clients that have a secret, public clients only rely on PKCE
+	IsConfidential bool      `json:"isConfidential"`
+	SecretHash     string    `json:"-"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// HasRedirectURI returns true if uri is exactly one of the registered redirect URIs
+func (c *OAuthClient) HasRedirectURI(uri string) bool {
+	for _, u := range c.RedirectURIs {
+		if u == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope returns true if the client is allowed to ask for given scope
+func (c *OAuthClient) HasScope(scope enum.APITokenScope) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthGrant is what a user allowed a client to do, either through a short-lived authorization code or a refresh token
+type OAuthGrant struct {
+	ID            int
+	ClientID      int
+	UserID        int
+	RedirectURI   string
+	Scopes        []enum.APITokenScope
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
@@ -30,6 +30,7 @@ type Post struct {
 	Downvotes         int                   `json:"downvotes"`
 	ModerationPending bool                  `json:"moderationPending,omitempty"`
 	ModerationData    string                `json:"moderationData,omitempty"`
+	ShadowHidden      bool                  `json:"shadowHidden,omitempty"`
 }
 
 type PostLockedSettings struct {
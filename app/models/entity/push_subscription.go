@@ -10,5 +10,9 @@ type PushSubscription struct {
 	KeyP256dh string    `db:"key_p256dh"`
 	KeyAuth   string    `db:"key_auth"`
 	CreatedAt time.Time `db:"created_at"`
+	// LastSuccessAt and LastFailureAt are when a message was last accepted or refused by the push service,
+	// FailureCount is the number of consecutive failures since the last success
+	LastSuccessAt *time.Time `db:"last_success_at"`
+	LastFailureAt *time.Time `db:"last_failure_at"`
+	FailureCount  int        `db:"failure_count"`
 }
-
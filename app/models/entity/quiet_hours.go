@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+
+	// timezones of user settings must resolve even on hosts without a zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// QuietHours is a daily window, in the user's timezone, during which push and email notifications are held back
+type QuietHours struct {
+	UserID   int
+	Email    string
+	Location *time.Location
+	// Start and End are minutes after midnight, the window wraps around midnight when Start is after End
+	Start int
+	End   int
+}
+
+// NewQuietHours returns the quiet hours stored in the user settings, or nil if they are not set or not valid
+func NewQuietHours(timezone, start, end string) *QuietHours {
+	startMinute, ok := ParseClock(start)
+	if !ok {
+		return nil
+	}
+	endMinute, ok := ParseClock(end)
+	if !ok || startMinute == endMinute {
+		return nil
+	}
+
+	location := time.UTC
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil
+		}
+		location = loc
+	}
+
+	return &QuietHours{
+		Location: location,
+		Start:    startMinute,
+		End:      endMinute,
+	}
+}
+
+// ParseClock parses a "HH:MM" time of day into minutes after midnight
+func ParseClock(v string) (int, bool) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// ReleaseAt returns when a notification sent at t can be delivered: t itself outside of the window, or the end of the window
+func (q *QuietHours) ReleaseAt(t time.Time) time.Time {
+	local := t.In(q.Location)
+	minute := local.Hour()*60 + local.Minute()
+
+	days := 0
+	if q.Start < q.End {
+		if minute < q.Start || minute >= q.End {
+			return t
+		}
+	} else {
+		if minute < q.Start && minute >= q.End {
+			return t
+		}
+		if minute >= q.Start {
+			days = 1
+		}
+	}
+
+	year, month, day := local.Date()
+	return time.Date(year, month, day+days, q.End/60, q.End%60, 0, 0, q.Location)
+}
+
+// DeferredNotification is a push or email notification held back during the quiet hours of its recipient
+type DeferredNotification struct {
+	ID      int
+	Tenant  *Tenant
+	User    *User
+	Channel enum.NotificationChannel
+	Payload string
+	BaseURL string
+}
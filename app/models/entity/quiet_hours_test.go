@@ -0,0 +1,52 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestNewQuietHours(t *testing.T) {
+	RegisterT(t)
+
+	q := entity.NewQuietHours("Europe/Berlin", "22:00", "07:30")
+	Expect(q).IsNotNil()
+	Expect(q.Location.String()).Equals("Europe/Berlin")
+	Expect(q.Start).Equals(22 * 60)
+	Expect(q.End).Equals(7*60 + 30)
+
+	Expect(entity.NewQuietHours("", "22:00", "07:00").Location).Equals(time.UTC)
+	Expect(entity.NewQuietHours("Mars/Olympus", "22:00", "07:00")).IsNil()
+	Expect(entity.NewQuietHours("", "", "07:00")).IsNil()
+	Expect(entity.NewQuietHours("", "25:00", "07:00")).IsNil()
+	Expect(entity.NewQuietHours("", "07:00", "07:00")).IsNil()
+}
+
+func TestQuietHours_ReleaseAt(t *testing.T) {
+	RegisterT(t)
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	overnight := entity.NewQuietHours("Europe/Berlin", "22:00", "07:00")
+	daytime := entity.NewQuietHours("Europe/Berlin", "09:00", "17:00")
+
+	testCases := []struct {
+		quiet    *entity.QuietHours
+		sentAt   time.Time
+		expected time.Time
+	}{
+		{overnight, time.Date(2024, 3, 1, 21, 59, 0, 0, berlin), time.Date(2024, 3, 1, 21, 59, 0, 0, berlin)},
+		{overnight, time.Date(2024, 3, 1, 23, 0, 0, 0, berlin), time.Date(2024, 3, 2, 7, 0, 0, 0, berlin)},
+		{overnight, time.Date(2024, 3, 2, 3, 0, 0, 0, berlin), time.Date(2024, 3, 2, 7, 0, 0, 0, berlin)},
+		{overnight, time.Date(2024, 3, 2, 7, 0, 0, 0, berlin), time.Date(2024, 3, 2, 7, 0, 0, 0, berlin)},
+		{overnight, time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 7, 0, 0, 0, berlin)},
+		{daytime, time.Date(2024, 3, 1, 8, 0, 0, 0, berlin), time.Date(2024, 3, 1, 8, 0, 0, 0, berlin)},
+		{daytime, time.Date(2024, 3, 1, 12, 0, 0, 0, berlin), time.Date(2024, 3, 1, 17, 0, 0, 0, berlin)},
+		{daytime, time.Date(2024, 3, 1, 17, 0, 0, 0, berlin), time.Date(2024, 3, 1, 17, 0, 0, 0, berlin)},
+	}
+
+	for _, testCase := range testCases {
+		Expect(testCase.quiet.ReleaseAt(testCase.sentAt).Equal(testCase.expected)).IsTrue()
+	}
+}
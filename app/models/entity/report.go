@@ -8,13 +8,15 @@ import (
 
 type Report struct {
 	ID             int               `json:"id"`
+	CaseID         int               `json:"caseId,omitempty"`
 	ReportedType   enum.ReportType   `json:"reportedType"`
 	ReportedID     int               `json:"reportedId"`
 	Reason         string            `json:"reason"`
 	Details        string            `json:"details,omitempty"`
 	Status         enum.ReportStatus `json:"status"`
 	CreatedAt      time.Time         `json:"createdAt"`
-	Reporter       *User             `json:"reporter"`
+	Reporter       *User             `json:"reporter,omitempty"`
+	ReporterScore  *ReportAccuracy   `json:"reporterScore,omitempty"`
 	AssignedTo     *User             `json:"assignedTo,omitempty"`
 	AssignedAt     *time.Time        `json:"assignedAt,omitempty"`
 	ResolvedAt     *time.Time        `json:"resolvedAt,omitempty"`
@@ -24,6 +26,93 @@ type Report struct {
 	PostSlug       string            `json:"postSlug,omitempty"`
 }
 
+// ReportCase groups every report filed against the same post or comment
+// so staff can triage the item once instead of report by report
+type ReportCase struct {
+	ID             int                  `json:"id"`
+	ReportedType   enum.ReportType      `json:"reportedType"`
+	ReportedID     int                  `json:"reportedId"`
+	Status         enum.ReportStatus    `json:"status"`
+	ReportCount    int                  `json:"reportCount"`
+	ReporterCount  int                  `json:"reporterCount"`
+	LeadReportID   int                  `json:"leadReportId"`
+	Reasons        []*ReportReasonCount `json:"reasons"`
+	Details        []string             `json:"details"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	LastReportedAt time.Time            `json:"lastReportedAt"`
+	AutoHiddenAt   *time.Time           `json:"autoHiddenAt,omitempty"`
+	ResolvedAt     *time.Time           `json:"resolvedAt,omitempty"`
+	ResolvedBy     *User                `json:"resolvedBy,omitempty"`
+	ResolutionNote string               `json:"resolutionNote,omitempty"`
+	PostNumber     int                  `json:"postNumber,omitempty"`
+	PostSlug       string               `json:"postSlug,omitempty"`
+	Reports        []*Report            `json:"reports,omitempty"`
+}
+
+// IsOpen returns true if the case still awaits a staff decision
+func (c *ReportCase) IsOpen() bool {
+	return c.Status == enum.ReportStatusPending || c.Status == enum.ReportStatusInReview
+}
+
+// ReporterIDs returns the distinct users that filed a report in this case
+func (c *ReportCase) ReporterIDs() []int {
+	seen := make(map[int]bool)
+	ids := make([]int, 0, len(c.Reports))
+	for _, r := range c.Reports {
+		if r.Reporter == nil || seen[r.Reporter.ID] {
+			continue
+		}
+		seen[r.Reporter.ID] = true
+		ids = append(ids, r.Reporter.ID)
+	}
+	return ids
+}
+
+// ReportAccuracy tracks how many of a user's reports were actioned or dismissed by staff
+type ReportAccuracy struct {
+	Resolved  int     `json:"resolved"`
+	Dismissed int     `json:"dismissed"`
+	Score     float64 `json:"score"`
+}
+
+// minReportsForAccuracy is the number of closed reports needed before the
+// accuracy score starts to affect a user's daily report limit
+const minReportsForAccuracy = 5
+
+// NewReportAccuracy builds the accuracy of a reporter from their closed reports.
+// The score is smoothed so that users with little history start around 0.5.
+// Keep it in sync with reportAccuracyScoreSQL used to order reports.
+func NewReportAccuracy(resolved, dismissed int) *ReportAccuracy {
+	return &ReportAccuracy{
+		Resolved:  resolved,
+		Dismissed: dismissed,
+		Score:     (float64(resolved) + 1) / (float64(resolved+dismissed) + 2),
+	}
+}
+
+// DailyReportLimit scales the tenant daily report limit with the reporter accuracy:
+// reliable reporters get twice the limit, serial false reporters get a fraction of it
+func (a *ReportAccuracy) DailyReportLimit(base int) int {
+	if a == nil || a.Resolved+a.Dismissed < minReportsForAccuracy {
+		return base
+	}
+
+	switch {
+	case a.Score >= 0.8:
+		return base * 2
+	case a.Score < 0.25:
+		return max(1, base/4)
+	case a.Score < 0.5:
+		return max(1, base/2)
+	}
+	return base
+}
+
+type ReportReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
 type ReportReason struct {
 	ID          int    `json:"id"`
 	Slug        string `json:"slug"`
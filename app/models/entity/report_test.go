@@ -0,0 +1,32 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestReportAccuracy_Score(t *testing.T) {
+	RegisterT(t)
+
+	Expect(entity.NewReportAccuracy(0, 0).Score).Equals(0.5)
+	Expect(entity.NewReportAccuracy(8, 0).Score).Equals(0.9)
+	Expect(entity.NewReportAccuracy(0, 8).Score).Equals(0.1)
+}
+
+func TestReportAccuracy_DailyReportLimit(t *testing.T) {
+	RegisterT(t)
+
+	var noHistory *entity.ReportAccuracy
+	Expect(noHistory.DailyReportLimit(10)).Equals(10)
+
+	// not enough closed reports to judge yet
+	Expect(entity.NewReportAccuracy(0, 4).DailyReportLimit(10)).Equals(10)
+
+	Expect(entity.NewReportAccuracy(8, 0).DailyReportLimit(10)).Equals(20)
+	Expect(entity.NewReportAccuracy(3, 3).DailyReportLimit(10)).Equals(10)
+	Expect(entity.NewReportAccuracy(2, 6).DailyReportLimit(10)).Equals(5)
+	Expect(entity.NewReportAccuracy(0, 8).DailyReportLimit(10)).Equals(2)
+	Expect(entity.NewReportAccuracy(0, 8).DailyReportLimit(2)).Equals(1)
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	// RestrictionShadowBan hides the new posts and comments of a user from everyone but themselves and staff
+	RestrictionShadowBan = "shadowban"
+	// RestrictionProbation holds the new posts and comments of a user for review and applies stricter limits
+	RestrictionProbation = "probation"
+)
+
+// IsValidRestriction returns true if kind is a known restriction
+func IsValidRestriction(kind string) bool {
+	return kind == RestrictionShadowBan || kind == RestrictionProbation
+}
+
+// UserRestriction is a shadow-ban or probation applied to a user until it expires
+type UserRestriction struct {
+	ID        int        `json:"id"`
+	Kind      string     `json:"kind"`
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IsActive returns true if the restriction has not expired yet
+func (r *UserRestriction) IsActive(now time.Time) bool {
+	return r.ExpiresAt == nil || r.ExpiresAt.After(now)
+}
+
+// Limits applied to users on probation when the tenant has not configured a "probation" entry
+var (
+	DefaultProbationPostLimit    = PostLimit{Count: 1, Hours: 24}
+	DefaultProbationCommentLimit = CommentLimit{Count: 10, Hours: 24}
+)
+
+// PostLimitFor returns the post rate limit that applies to the user, if any
+func (s *GeneralSettings) PostLimitFor(user *User) (PostLimit, bool) {
+	if user.IsOnProbation() {
+		if s != nil {
+			if limit, ok := s.PostLimits[RestrictionProbation]; ok && limit.Count > 0 {
+				return limit, true
+			}
+		}
+		return DefaultProbationPostLimit, true
+	}
+	if s == nil {
+		return PostLimit{}, false
+	}
+	limit, ok := s.PostLimits[user.Role.String()]
+	return limit, ok && limit.Count > 0
+}
+
+// CommentLimitFor returns the comment rate limit that applies to the user, if any
+func (s *GeneralSettings) CommentLimitFor(user *User) (CommentLimit, bool) {
+	if user.IsOnProbation() {
+		if s != nil {
+			if limit, ok := s.CommentLimits[RestrictionProbation]; ok && limit.Count > 0 {
+				return limit, true
+			}
+		}
+		return DefaultProbationCommentLimit, true
+	}
+	if s == nil {
+		return CommentLimit{}, false
+	}
+	limit, ok := s.CommentLimits[user.Role.String()]
+	return limit, ok && limit.Count > 0
+}
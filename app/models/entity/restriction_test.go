@@ -0,0 +1,80 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestGeneralSettings_PostLimitFor(t *testing.T) {
+	RegisterT(t)
+
+	settings := &entity.GeneralSettings{
+		PostLimits: map[string]entity.PostLimit{
+			"visitor": {Count: 5, Hours: 24},
+		},
+	}
+
+	user := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	limit, ok := settings.PostLimitFor(user)
+	Expect(ok).IsTrue()
+	Expect(limit.Count).Equals(5)
+
+	helper := &entity.User{ID: 2, Role: enum.RoleHelper}
+	_, ok = settings.PostLimitFor(helper)
+	Expect(ok).IsFalse()
+
+	user.SetRestrictionCheck(func(userID int, kind string) bool {
+		return kind == entity.RestrictionProbation
+	})
+	limit, ok = settings.PostLimitFor(user)
+	Expect(ok).IsTrue()
+	Expect(limit).Equals(entity.DefaultProbationPostLimit)
+
+	settings.PostLimits[entity.RestrictionProbation] = entity.PostLimit{Count: 2, Hours: 48}
+	limit, _ = settings.PostLimitFor(user)
+	Expect(limit.Count).Equals(2)
+	Expect(limit.Hours).Equals(48)
+}
+
+func TestGeneralSettings_CommentLimitFor(t *testing.T) {
+	RegisterT(t)
+
+	settings := &entity.GeneralSettings{}
+	user := &entity.User{ID: 1, Role: enum.RoleVisitor}
+
+	_, ok := settings.CommentLimitFor(user)
+	Expect(ok).IsFalse()
+
+	user.SetRestrictionCheck(func(userID int, kind string) bool {
+		return kind == entity.RestrictionProbation
+	})
+	limit, ok := settings.CommentLimitFor(user)
+	Expect(ok).IsTrue()
+	Expect(limit).Equals(entity.DefaultProbationCommentLimit)
+	Expect(user.IsShadowBanned()).IsFalse()
+}
+
+func TestGeneralSettings_LimitFor_NilSettings(t *testing.T) {
+	RegisterT(t)
+
+	var settings *entity.GeneralSettings
+	user := &entity.User{ID: 1, Role: enum.RoleVisitor}
+
+	_, ok := settings.PostLimitFor(user)
+	Expect(ok).IsFalse()
+	_, ok = settings.CommentLimitFor(user)
+	Expect(ok).IsFalse()
+
+	user.SetRestrictionCheck(func(userID int, kind string) bool {
+		return kind == entity.RestrictionProbation
+	})
+	postLimit, ok := settings.PostLimitFor(user)
+	Expect(ok).IsTrue()
+	Expect(postLimit).Equals(entity.DefaultProbationPostLimit)
+	commentLimit, ok := settings.CommentLimitFor(user)
+	Expect(ok).IsTrue()
+	Expect(commentLimit).Equals(entity.DefaultProbationCommentLimit)
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	// SanctionTriggerWarnings counts the warnings that are still active
+	SanctionTriggerWarnings = "warnings"
+	// SanctionTriggerMutes counts the mutes issued within the rule window
+	SanctionTriggerMutes = "mutes"
+
+	// SanctionActionMute mutes the user for the rule duration
+	SanctionActionMute = "mute"
+	// SanctionActionProposeBlock asks staff to review the user for a block
+	SanctionActionProposeBlock = "propose_block"
+)
+
+// SanctionRule is a single step of the sanctions ladder
+type SanctionRule struct {
+	Trigger    string `json:"trigger"`
+	Count      int    `json:"count"`
+	WindowDays int    `json:"windowDays,omitempty"`
+	Action     string `json:"action"`
+	Duration   string `json:"duration,omitempty"`
+}
+
+// SanctionPolicy is the escalation ladder evaluated whenever a user is warned or muted.
+// Rules are ordered from the mildest to the harshest step.
+type SanctionPolicy struct {
+	Enabled bool           `json:"enabled"`
+	Rules   []SanctionRule `json:"rules"`
+}
+
+// SanctionHistory is the moderation record of a user the policy is evaluated against
+type SanctionHistory struct {
+	ActiveWarnings int
+	MuteDates      []time.Time
+	MutedUntil     *time.Time
+	BlockProposed  bool
+}
+
+// SanctionStep is the next rule of the ladder a user would reach
+type SanctionStep struct {
+	Trigger    string `json:"trigger"`
+	Remaining  int    `json:"remaining"`
+	WindowDays int    `json:"windowDays,omitempty"`
+	Action     string `json:"action"`
+	Duration   string `json:"duration,omitempty"`
+}
+
+// DefaultSanctionPolicy returns the ladder suggested to new tenants, disabled by default
+func DefaultSanctionPolicy() SanctionPolicy {
+	return SanctionPolicy{
+		Enabled: false,
+		Rules: []SanctionRule{
+			{Trigger: SanctionTriggerWarnings, Count: 3, Action: SanctionActionMute, Duration: "24h"},
+			{Trigger: SanctionTriggerMutes, Count: 2, WindowDays: 30, Action: SanctionActionMute, Duration: "7d"},
+			{Trigger: SanctionTriggerMutes, Count: 3, WindowDays: 30, Action: SanctionActionProposeBlock},
+		},
+	}
+}
+
+// progress returns how many warnings or mutes of the history count towards the rule
+func (r SanctionRule) progress(h *SanctionHistory, now time.Time) int {
+	switch r.Trigger {
+	case SanctionTriggerWarnings:
+		return h.ActiveWarnings
+	case SanctionTriggerMutes:
+		if r.WindowDays <= 0 {
+			return len(h.MuteDates)
+		}
+		since := now.AddDate(0, 0, -r.WindowDays)
+		count := 0
+		for _, d := range h.MuteDates {
+			if !d.Before(since) {
+				count++
+			}
+		}
+		return count
+	}
+	return 0
+}
+
+// Due returns the harshest rule the user has reached, or nil if none applies.
+// A block proposal waiting for staff is skipped so the milder steps still apply meanwhile
+func (p *SanctionPolicy) Due(h *SanctionHistory, now time.Time) *SanctionRule {
+	if p == nil || !p.Enabled {
+		return nil
+	}
+
+	for i := len(p.Rules) - 1; i >= 0; i-- {
+		rule := p.Rules[i]
+		if rule.Action == SanctionActionProposeBlock && h.BlockProposed {
+			continue
+		}
+		if rule.Count > 0 && rule.progress(h, now) >= rule.Count {
+			return &rule
+		}
+	}
+	return nil
+}
+
+// Next returns the mildest rule the user has not reached yet, or nil once the ladder is exhausted
+func (p *SanctionPolicy) Next(h *SanctionHistory, now time.Time) *SanctionStep {
+	if p == nil || !p.Enabled {
+		return nil
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Count <= 0 {
+			continue
+		}
+		if rule.Action == SanctionActionProposeBlock && h.BlockProposed {
+			continue
+		}
+		current := rule.progress(h, now)
+		if current < rule.Count {
+			return &SanctionStep{
+				Trigger:    rule.Trigger,
+				Remaining:  rule.Count - current,
+				WindowDays: rule.WindowDays,
+				Action:     rule.Action,
+				Duration:   rule.Duration,
+			}
+		}
+	}
+	return nil
+}
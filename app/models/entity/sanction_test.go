@@ -0,0 +1,79 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+)
+
+func TestSanctionPolicy_Due(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	policy := entity.DefaultSanctionPolicy()
+
+	Expect(policy.Due(&entity.SanctionHistory{ActiveWarnings: 3}, now)).IsNil()
+
+	policy.Enabled = true
+	Expect(policy.Due(&entity.SanctionHistory{ActiveWarnings: 2}, now)).IsNil()
+
+	due := policy.Due(&entity.SanctionHistory{ActiveWarnings: 3}, now)
+	Expect(due.Action).Equals(entity.SanctionActionMute)
+	Expect(due.Duration).Equals("24h")
+
+	// old mutes fall out of the window
+	due = policy.Due(&entity.SanctionHistory{
+		ActiveWarnings: 3,
+		MuteDates:      []time.Time{now.AddDate(0, 0, -2), now.AddDate(0, 0, -45)},
+	}, now)
+	Expect(due.Duration).Equals("24h")
+
+	due = policy.Due(&entity.SanctionHistory{
+		MuteDates: []time.Time{now.AddDate(0, 0, -2), now.AddDate(0, 0, -10)},
+	}, now)
+	Expect(due.Duration).Equals("7d")
+
+	due = policy.Due(&entity.SanctionHistory{
+		MuteDates: []time.Time{now, now.AddDate(0, 0, -2), now.AddDate(0, 0, -10)},
+	}, now)
+	Expect(due.Action).Equals(entity.SanctionActionProposeBlock)
+
+	// while a block proposal is pending the mute steps keep applying
+	due = policy.Due(&entity.SanctionHistory{
+		MuteDates:     []time.Time{now, now.AddDate(0, 0, -2), now.AddDate(0, 0, -10)},
+		BlockProposed: true,
+	}, now)
+	Expect(due.Action).Equals(entity.SanctionActionMute)
+	Expect(due.Duration).Equals("7d")
+}
+
+func TestSanctionPolicy_Next(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	policy := entity.DefaultSanctionPolicy()
+	Expect(policy.Next(&entity.SanctionHistory{}, now)).IsNil()
+
+	policy.Enabled = true
+	next := policy.Next(&entity.SanctionHistory{ActiveWarnings: 1}, now)
+	Expect(next.Trigger).Equals(entity.SanctionTriggerWarnings)
+	Expect(next.Remaining).Equals(2)
+	Expect(next.Duration).Equals("24h")
+
+	next = policy.Next(&entity.SanctionHistory{ActiveWarnings: 3, MuteDates: []time.Time{now}}, now)
+	Expect(next.Trigger).Equals(entity.SanctionTriggerMutes)
+	Expect(next.Remaining).Equals(1)
+	Expect(next.Duration).Equals("7d")
+
+	next = policy.Next(&entity.SanctionHistory{ActiveWarnings: 3, MuteDates: []time.Time{now, now}}, now)
+	Expect(next.Action).Equals(entity.SanctionActionProposeBlock)
+
+	next = policy.Next(&entity.SanctionHistory{
+		ActiveWarnings: 3,
+		MuteDates:      []time.Time{now, now, now},
+		BlockProposed:  true,
+	}, now)
+	Expect(next).IsNil()
+}
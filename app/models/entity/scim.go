@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// SCIMToken is the bearer token identity providers use to provision users of a tenant, only its hash is stored
+type SCIMToken struct {
+	Prefix     string     `json:"prefix"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// SCIMUser is a user as seen by identity providers, ExternalID is their own identifier of the user
+type SCIMUser struct {
+	User       *User
+	ExternalID string
+	CreatedAt  time.Time
+}
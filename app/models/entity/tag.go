@@ -1,5 +1,7 @@
 package entity
 
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+
 //Tag represents a simple tag
 type Tag struct {
 	ID       int    `json:"id"`
@@ -8,3 +10,9 @@ type Tag struct {
 	Color    string `json:"color"`
 	IsPublic bool   `json:"isPublic"`
 }
+
+//UserTagPreference represents a tag that the user follows or muted
+type UserTagPreference struct {
+	TagID      int                `json:"tagId" db:"tag_id"`
+	Preference enum.TagPreference `json:"preference" db:"preference"`
+}
@@ -56,4 +56,20 @@ type GeneralSettings struct {
 	CommentingGloballyDisabled bool                    `json:"commentingGloballyDisabled"`
 	ReportingGloballyDisabled  bool                    `json:"reportingGloballyDisabled"`
 	ReportLimitsPerDay         int                     `json:"reportLimitsPerDay"`
+	ReportAutoHideThreshold    int                     `json:"reportAutoHideThreshold"`
+	SanctionPolicy             SanctionPolicy          `json:"sanctionPolicy"`
+	TwoFactorRequiredFor       []string                `json:"twoFactorRequiredFor"`
+}
+
+// RequiresTwoFactor returns true if users with given role must enable two-factor authentication to access staff pages
+func (s *GeneralSettings) RequiresTwoFactor(role enum.Role) bool {
+	if s == nil {
+		return false
+	}
+	for _, r := range s.TwoFactorRequiredFor {
+		if r == role.String() {
+			return true
+		}
+	}
+	return false
 }
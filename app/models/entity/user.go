@@ -8,19 +8,21 @@ import (
 
 // User represents an user inside our application
 type User struct {
-	ID            int             `json:"id"`
-	Name          string          `json:"name"`
-	Tenant        *Tenant         `json:"-"`
-	Email         string          `json:"-"`
-	Role          enum.Role       `json:"role"`
-	VisualRole    enum.VisualRole `json:"visualRole"`
-	Providers     []*UserProvider `json:"-"`
-	AvatarBlobKey string          `json:"-"`
-	AvatarType    enum.AvatarType `json:"-"`
-	AvatarURL     string          `json:"avatarURL,omitempty"`
-	Status        enum.UserStatus `json:"status"`
-	warningCheck  func(int) bool
-	muteCheck     func(int) bool
+	ID               int             `json:"id"`
+	Name             string          `json:"name"`
+	Tenant           *Tenant         `json:"-"`
+	Email            string          `json:"-"`
+	Role             enum.Role       `json:"role"`
+	VisualRole       enum.VisualRole `json:"visualRole"`
+	Providers        []*UserProvider `json:"-"`
+	AvatarBlobKey    string          `json:"-"`
+	AvatarType       enum.AvatarType `json:"-"`
+	AvatarURL        string          `json:"avatarURL,omitempty"`
+	Status           enum.UserStatus `json:"status"`
+	warningCheck     func(int) bool
+	muteCheck        func(int) bool
+	restrictionCheck func(int, string) bool
+	permissionLookup func(int) []enum.Permission
 }
 
 // Map permission role to equivalent visual role
@@ -74,6 +76,32 @@ func (u *User) IsHelper() bool {
 	return u.Role == enum.RoleHelper
 }
 
+// HasPermission returns true if the role or the custom role of the user grants given permission
+func (u *User) HasPermission(permission enum.Permission) bool {
+	if u.Role.HasPermission(permission) {
+		return true
+	}
+	if u.permissionLookup != nil {
+		for _, p := range u.permissionLookup(u.ID) {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Permissions returns every permission the user has, from their role and their custom role
+func (u *User) Permissions() []enum.Permission {
+	permissions := make([]enum.Permission, 0)
+	for _, permission := range enum.Permissions {
+		if u.HasPermission(permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
 // HasWarning returns true if user has an active warning
 func (u *User) HasWarning() bool {
 	if u.warningCheck != nil {
@@ -90,6 +118,22 @@ func (u *User) IsMuted() bool {
 	return false
 }
 
+// IsShadowBanned returns true if user is currently shadow-banned
+func (u *User) IsShadowBanned() bool {
+	if u.restrictionCheck != nil {
+		return u.restrictionCheck(u.ID, RestrictionShadowBan)
+	}
+	return false
+}
+
+// IsOnProbation returns true if user is currently on probation
+func (u *User) IsOnProbation() bool {
+	if u.restrictionCheck != nil {
+		return u.restrictionCheck(u.ID, RestrictionProbation)
+	}
+	return false
+}
+
 // SetWarningCheck sets the callback function to check if a user has a warning
 func (u *User) SetWarningCheck(check func(int) bool) {
 	u.warningCheck = check
@@ -100,6 +144,16 @@ func (u *User) SetMuteCheck(check func(int) bool) {
 	u.muteCheck = check
 }
 
+// SetRestrictionCheck sets the callback function to check if a user has an active restriction of a given kind
+func (u *User) SetRestrictionCheck(check func(int, string) bool) {
+	u.restrictionCheck = check
+}
+
+// SetPermissionLookup sets the callback function to get the permissions of the custom role of a user
+func (u *User) SetPermissionLookup(lookup func(int) []enum.Permission) {
+	u.permissionLookup = lookup
+}
+
 // UserProvider represents the relationship between an User and an Authentication provide
 type UserProvider struct {
 	UserID int    `json:"user_id" db:"user_id"`
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// UserDataExport is a ZIP of everything a user has stored on a site, requested by the user themselves
+// BlobKey is empty until the export has been assembled
+type UserDataExport struct {
+	ID        int       `json:"id"`
+	Tenant    *Tenant   `json:"-"`
+	UserID    int       `json:"userId"`
+	BlobKey   string    `json:"-"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// IsReady returns true if the export has been assembled and can still be downloaded
+func (e *UserDataExport) IsReady() bool {
+	return e.BlobKey != "" && time.Now().Before(e.ExpiresAt)
+}
+
+// UserDataExportLifetime is how long an export can be downloaded once it has been assembled
+const UserDataExportLifetime = 7 * 24 * time.Hour
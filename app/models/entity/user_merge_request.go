@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// UserMergeRequest is raised when a user tries to link a provider identity that already belongs to another user
+// Staff decide whether both accounts belong to the same person, in which case the identity is moved to the user who asked for it
+type UserMergeRequest struct {
+	ID          int                         `json:"id"`
+	User        *User                       `json:"user"`
+	Owner       *User                       `json:"owner"`
+	Provider    string                      `json:"provider"`
+	ProviderUID string                      `json:"providerUid"`
+	Status      enum.UserMergeRequestStatus `json:"status"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+	DecidedAt   *time.Time                  `json:"decidedAt,omitempty"`
+	DecidedBy   *User                       `json:"decidedBy,omitempty"`
+}
+
+// IsPending returns true if the merge request still awaits a staff decision
+func (r *UserMergeRequest) IsPending() bool {
+	return r.Status == enum.UserMergeRequestPending
+}
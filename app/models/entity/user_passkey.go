@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// UserPasskey is a WebAuthn credential a user registered to sign in without email or OAuth
+type UserPasskey struct {
+	ID           int        `json:"id"`
+	UserID       int        `json:"-"`
+	Name         string     `json:"name"`
+	CredentialID string     `json:"-"`
+	Credential   []byte     `json:"-"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// UserSession is a signed in browser of a user, the session ID is embedded in the auth cookie
+type UserSession struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"-"`
+	SessionID  string     `json:"-"`
+	IPAddress  string     `json:"ipAddress"`
+	UserAgent  string     `json:"userAgent"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt time.Time  `json:"lastSeenAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IsActive returns true if the session can still be used to sign in
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
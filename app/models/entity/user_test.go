@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
 )
 
@@ -53,3 +54,23 @@ func TestUser_MarshalJSON(t *testing.T) {
 	Expect(string(jsonData)).Equals(expectedJSON)
 
 }
+
+func TestUser_HasPermission(t *testing.T) {
+	RegisterT(t)
+
+	helper := &entity.User{ID: 1, Role: enum.RoleHelper}
+	Expect(helper.HasPermission(enum.PermissionTagAssign)).IsTrue()
+	Expect(helper.HasPermission(enum.PermissionPostLock)).IsFalse()
+
+	helper.SetPermissionLookup(func(userID int) []enum.Permission {
+		return []enum.Permission{enum.PermissionPostLock}
+	})
+	Expect(helper.HasPermission(enum.PermissionPostLock)).IsTrue()
+	Expect(helper.Permissions()).Equals([]enum.Permission{enum.PermissionQueueView, enum.PermissionTagAssign, enum.PermissionPostLock})
+
+	admin := &entity.User{ID: 2, Role: enum.RoleAdministrator}
+	Expect(admin.Permissions()).Equals(enum.Permissions)
+
+	visitor := &entity.User{ID: 3, Role: enum.RoleVisitor}
+	Expect(visitor.Permissions()).Equals([]enum.Permission{})
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// UserTwoFactor is the TOTP setup of a user, it only protects sign-ins once it has been enabled
+type UserTwoFactor struct {
+	UserID         int
+	Secret         string
+	LastUsedStep   int64
+	FailedAttempts int
+	EnabledAt      *time.Time
+	CreatedAt      time.Time
+}
+
+// IsEnabled returns true if the enrollment has been confirmed with a valid code
+func (t *UserTwoFactor) IsEnabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
@@ -0,0 +1,75 @@
+package enum
+
+// APITokenScope is what a personal API token is allowed to do on behalf of its owner
+type APITokenScope int
+
+const (
+	// APITokenScopeReadPosts allows reading posts, comments and everything else members can see
+	APITokenScopeReadPosts APITokenScope = 1
+	// APITokenScopeWritePosts allows creating, editing and voting on posts
+	APITokenScopeWritePosts APITokenScope = 2
+	// APITokenScopeWriteComments allows commenting and reacting
+	APITokenScopeWriteComments APITokenScope = 3
+	// APITokenScopeModerate allows everything staff members can do, it includes all the scopes above
+	APITokenScopeModerate APITokenScope = 4
+	// APITokenScopeAdmin allows everything, including account and site administration
+	APITokenScopeAdmin APITokenScope = 5
+)
+
+// APITokenScopes are all the scopes, in the order they are shown to users
+var APITokenScopes = []APITokenScope{
+	APITokenScopeReadPosts,
+	APITokenScopeWritePosts,
+	APITokenScopeWriteComments,
+	APITokenScopeModerate,
+	APITokenScopeAdmin,
+}
+
+var apiTokenScopeIDs = map[APITokenScope]string{
+	APITokenScopeReadPosts:     "read:posts",
+	APITokenScopeWritePosts:    "write:posts",
+	APITokenScopeWriteComments: "write:comments",
+	APITokenScopeModerate:      "moderate",
+	APITokenScopeAdmin:         "admin",
+}
+
+var apiTokenScopeNames = map[string]APITokenScope{
+	"read:posts":     APITokenScopeReadPosts,
+	"write:posts":    APITokenScopeWritePosts,
+	"write:comments": APITokenScopeWriteComments,
+	"moderate":       APITokenScopeModerate,
+	"admin":          APITokenScopeAdmin,
+}
+
+func (s APITokenScope) String() string {
+	return apiTokenScopeIDs[s]
+}
+
+// MarshalText returns the Text version of the API token scope
+func (s APITokenScope) MarshalText() ([]byte, error) {
+	return []byte(apiTokenScopeIDs[s]), nil
+}
+
+// UnmarshalText parse string into an API token scope, unknown scopes are left as zero
+func (s *APITokenScope) UnmarshalText(text []byte) error {
+	*s = apiTokenScopeNames[string(text)]
+	return nil
+}
+
+// ParseAPITokenScope returns the scope with given name and whether it exists
+func ParseAPITokenScope(name string) (APITokenScope, bool) {
+	scope, ok := apiTokenScopeNames[name]
+	return scope, ok
+}
+
+// Includes returns true if a token with this scope is allowed to do what other allows
+func (s APITokenScope) Includes(other APITokenScope) bool {
+	switch s {
+	case APITokenScopeAdmin:
+		return true
+	case APITokenScopeModerate:
+		return other != APITokenScopeAdmin
+	default:
+		return s == other
+	}
+}
@@ -0,0 +1,37 @@
+package enum
+
+type AppealStatus int
+
+const (
+	AppealStatusPending AppealStatus = 1
+	AppealStatusUpheld  AppealStatus = 2
+	AppealStatusReduced AppealStatus = 3
+	AppealStatusLifted  AppealStatus = 4
+)
+
+var appealStatusIDs = map[AppealStatus]string{
+	AppealStatusPending: "pending",
+	AppealStatusUpheld:  "upheld",
+	AppealStatusReduced: "reduced",
+	AppealStatusLifted:  "lifted",
+}
+
+var appealStatusNames = map[string]AppealStatus{
+	"pending": AppealStatusPending,
+	"upheld":  AppealStatusUpheld,
+	"reduced": AppealStatusReduced,
+	"lifted":  AppealStatusLifted,
+}
+
+func (s AppealStatus) String() string {
+	return appealStatusIDs[s]
+}
+
+func (s AppealStatus) MarshalText() ([]byte, error) {
+	return []byte(appealStatusIDs[s]), nil
+}
+
+func (s *AppealStatus) UnmarshalText(text []byte) error {
+	*s = appealStatusNames[string(text)]
+	return nil
+}
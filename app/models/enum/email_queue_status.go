@@ -0,0 +1,45 @@
+package enum
+
+// QueuedEmailStatus is the delivery status of an email in the outbound queue
+type QueuedEmailStatus int
+
+const (
+	// QueuedEmailPending means the email is waiting to be sent, either for the first time or to be retried
+	QueuedEmailPending QueuedEmailStatus = 1
+	// QueuedEmailSent means the email was accepted by the email provider
+	QueuedEmailSent QueuedEmailStatus = 2
+	// QueuedEmailFailed means the email was rejected by the email provider or ran out of attempts
+	QueuedEmailFailed QueuedEmailStatus = 3
+)
+
+var queuedEmailStatusIDs = map[QueuedEmailStatus]string{
+	QueuedEmailPending: "pending",
+	QueuedEmailSent:    "sent",
+	QueuedEmailFailed:  "failed",
+}
+
+var queuedEmailStatusName = map[string]QueuedEmailStatus{
+	"pending": QueuedEmailPending,
+	"sent":    QueuedEmailSent,
+	"failed":  QueuedEmailFailed,
+}
+
+// MarshalText returns the Text version of the queued email status
+func (status QueuedEmailStatus) MarshalText() ([]byte, error) {
+	return []byte(queuedEmailStatusIDs[status]), nil
+}
+
+// UnmarshalText parse string into a queued email status
+func (status *QueuedEmailStatus) UnmarshalText(text []byte) error {
+	*status = queuedEmailStatusName[string(text)]
+	return nil
+}
+
+// Name returns the name of a queued email status
+func (status QueuedEmailStatus) Name() string {
+	name, ok := queuedEmailStatusIDs[status]
+	if ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,40 @@
+package enum
+
+// EmailTemplate is a built-in email template that tenants can override
+type EmailTemplate struct {
+	Name string
+	// Placeholders are the props an override must use, so that the email keeps its links and content
+	Placeholders []string
+}
+
+var (
+	// notificationPlaceholders are kept so that recipients can always reach the content and their settings
+	notificationPlaceholders = []string{"view", "change"}
+
+	//AllEmailTemplates contains all email templates tenants can override
+	AllEmailTemplates = []EmailTemplate{
+		{Name: "new_post", Placeholders: []string{"content", "view", "change"}},
+		{Name: "new_comment", Placeholders: []string{"content", "view", "change"}},
+		{Name: "change_status", Placeholders: []string{"status", "view", "change"}},
+		{Name: "delete_post", Placeholders: []string{"change"}},
+		{Name: "report_outcome", Placeholders: []string{"change"}},
+		{Name: "post_locked", Placeholders: notificationPlaceholders},
+		{Name: "post_archived", Placeholders: notificationPlaceholders},
+		{Name: "post_tagged", Placeholders: notificationPlaceholders},
+		{Name: "content_hidden", Placeholders: notificationPlaceholders},
+		{Name: "content_approved", Placeholders: notificationPlaceholders},
+		{Name: "digest", Placeholders: []string{"groups", "change"}},
+		{Name: "signin_email", Placeholders: []string{"link"}},
+		{Name: "change_emailaddress_email", Placeholders: []string{"link"}},
+	}
+)
+
+// GetEmailTemplate returns the built-in email template with given name, if tenants can override it
+func GetEmailTemplate(name string) (EmailTemplate, bool) {
+	for _, t := range AllEmailTemplates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return EmailTemplate{}, false
+}
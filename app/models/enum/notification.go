@@ -14,6 +14,65 @@ var (
 	NotificationChannelEmail NotificationChannel = 2
 	//NotificationChannelPush is a push notification
 	NotificationChannelPush NotificationChannel = 4
+	//NotificationChannelDigest is an email notification grouped with others into a periodic digest
+	NotificationChannelDigest NotificationChannel = 8
+)
+
+// AllNotificationChannels contains all possible notification channels
+var AllNotificationChannels = []NotificationChannel{
+	NotificationChannelWeb,
+	NotificationChannelEmail,
+	NotificationChannelPush,
+	NotificationChannelDigest,
+}
+
+var notificationChannelIDs = map[NotificationChannel]string{
+	NotificationChannelWeb:    "web",
+	NotificationChannelEmail:  "email",
+	NotificationChannelPush:   "push",
+	NotificationChannelDigest: "digest",
+}
+
+var notificationChannelNames = map[string]NotificationChannel{
+	"web":    NotificationChannelWeb,
+	"email":  NotificationChannelEmail,
+	"push":   NotificationChannelPush,
+	"digest": NotificationChannelDigest,
+}
+
+func (c NotificationChannel) String() string {
+	return notificationChannelIDs[c]
+}
+
+func (c NotificationChannel) MarshalText() ([]byte, error) {
+	return []byte(notificationChannelIDs[c]), nil
+}
+
+func (c *NotificationChannel) UnmarshalText(text []byte) error {
+	*c = notificationChannelNames[string(text)]
+	return nil
+}
+
+// DigestFrequencySettingsKey is the user setting that controls how often digest emails are sent
+const DigestFrequencySettingsKey = "digest_frequency"
+
+var (
+	//DigestFrequencyDaily sends the digest email once a day
+	DigestFrequencyDaily = "daily"
+	//DigestFrequencyWeekly sends the digest email once a week
+	DigestFrequencyWeekly = "weekly"
+)
+
+// IsValidDigestFrequency returns true if v is a known digest frequency
+func IsValidDigestFrequency(v string) bool {
+	return v == DigestFrequencyDaily || v == DigestFrequencyWeekly
+}
+
+// Quiet hours hold back push and email notifications during a daily window in the user's timezone
+const (
+	TimezoneSettingsKey        = "timezone"
+	QuietHoursStartSettingsKey = "quiet_hours_start"
+	QuietHoursEndSettingsKey   = "quiet_hours_end"
 )
 
 // NotificationEvent represents all possible notification events
@@ -22,11 +81,51 @@ type NotificationEvent struct {
 	DefaultSettingValue           string
 	RequiresSubscriptionUserRoles []Role
 	DefaultEnabledUserRoles       []Role
-	Validate                      func(string) bool
+	// Channels are the channels users can receive the event on, AdministratorChannels only administrators can turn on or off
+	Channels              NotificationChannel
+	AdministratorChannels NotificationChannel
+	Validate              func(string) bool
+}
+
+// IsEnabledByDefault returns true if the default setting value of the event includes the channel
+func (e NotificationEvent) IsEnabledByDefault(channel NotificationChannel) bool {
+	value, err := strconv.Atoi(e.DefaultSettingValue)
+	return err == nil && value&int(channel) > 0
+}
+
+// IsAvailableFor returns true if users of the role can receive the event, events without any role are sent to everyone
+func (e NotificationEvent) IsAvailableFor(role Role) bool {
+	if len(e.RequiresSubscriptionUserRoles) == 0 && len(e.DefaultEnabledUserRoles) == 0 {
+		return true
+	}
+	for _, r := range e.RequiresSubscriptionUserRoles {
+		if r == role {
+			return true
+		}
+	}
+	for _, r := range e.DefaultEnabledUserRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelsFor returns the channels users of the role can turn on or off for the event
+func (e NotificationEvent) ChannelsFor(role Role) NotificationChannel {
+	if role == RoleAdministrator {
+		return e.Channels
+	}
+	return e.Channels &^ e.AdministratorChannels
 }
 
 func notificationEventValidation(v string) bool {
-	return v == "0" || v == "1" || v == "2" || v == "3" || v == "4" || v == "5" || v == "6" || v == "7"
+	channels, err := strconv.Atoi(v)
+	if err != nil {
+		return false
+	}
+	all := NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush | NotificationChannelDigest
+	return channels >= 0 && channels&^int(all) == 0
 }
 
 var (
@@ -40,7 +139,9 @@ var (
 			RoleCollaborator,
 			RoleModerator,
 		},
-		Validate: notificationEventValidation,
+		Channels:              NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush | NotificationChannelDigest,
+		AdministratorChannels: NotificationChannelEmail | NotificationChannelDigest,
+		Validate:              notificationEventValidation,
 	}
 	//NotificationEventNewComment is triggered when a new comment is posted
 	NotificationEventNewComment = NotificationEvent{
@@ -54,7 +155,9 @@ var (
 			RoleCollaborator,
 			RoleModerator,
 		},
-		Validate: notificationEventValidation,
+		Channels:              NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush | NotificationChannelDigest,
+		AdministratorChannels: NotificationChannelEmail | NotificationChannelDigest,
+		Validate:              notificationEventValidation,
 	}
 	//NotificationEventMention is triggered when a new comment is posted with the user @-mentioned
 	NotificationEventMention = NotificationEvent{
@@ -68,7 +171,9 @@ var (
 			RoleHelper,
 			RoleVisitor,
 		},
-		Validate: notificationEventValidation,
+		Channels:              NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		AdministratorChannels: NotificationChannelEmail,
+		Validate:              notificationEventValidation,
 	}
 	//NotificationEventChangeStatus is triggered when a new post has its status changed
 	NotificationEventChangeStatus = NotificationEvent{
@@ -83,7 +188,9 @@ var (
 			RoleCollaborator,
 			RoleVisitor,
 		},
-		Validate: notificationEventValidation,
+		Channels:              NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		AdministratorChannels: NotificationChannelEmail,
+		Validate:              notificationEventValidation,
 	}
 	//NotificationEventMute is triggered when a user is muted
 	NotificationEventMute = NotificationEvent{
@@ -91,6 +198,7 @@ var (
 		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
 		RequiresSubscriptionUserRoles: []Role{},
 		DefaultEnabledUserRoles:       []Role{},
+		Channels:                      NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
 		Validate:                      notificationEventValidation,
 	}
 	//NotificationEventWarning is triggered when a user receives a warning
@@ -99,8 +207,99 @@ var (
 		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
 		RequiresSubscriptionUserRoles: []Role{},
 		DefaultEnabledUserRoles:       []Role{},
+		Channels:                      NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
 		Validate:                      notificationEventValidation,
 	}
+	//NotificationEventReportOutcome is triggered when a report filed by the user is resolved or dismissed
+	NotificationEventReportOutcome = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_report_outcome",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
+	//NotificationEventPostLocked is triggered when staff lock a post the user created
+	NotificationEventPostLocked = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_post_locked",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
+	//NotificationEventPostArchived is triggered when staff archive a post the user created
+	NotificationEventPostArchived = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_post_archived",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
+	//NotificationEventPostTagged is triggered when staff tag a post the user created
+	NotificationEventPostTagged = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_post_tagged",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
+	//NotificationEventContentHidden is triggered when staff hide a post or comment the user created for review
+	NotificationEventContentHidden = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_content_hidden",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
+	//NotificationEventContentApproved is triggered when a post or comment the user created is approved from moderation
+	NotificationEventContentApproved = NotificationEvent{
+		UserSettingsKeyName:           "event_notification_content_approved",
+		DefaultSettingValue:           strconv.Itoa(int(NotificationChannelWeb | NotificationChannelEmail)),
+		RequiresSubscriptionUserRoles: []Role{},
+		DefaultEnabledUserRoles: []Role{
+			RoleAdministrator,
+			RoleCollaborator,
+			RoleModerator,
+			RoleHelper,
+			RoleVisitor,
+		},
+		Channels: NotificationChannelWeb | NotificationChannelEmail | NotificationChannelPush,
+		Validate: notificationEventValidation,
+	}
 	//AllNotificationEvents contains all possible notification events
 	AllNotificationEvents = []NotificationEvent{
 		NotificationEventNewPost,
@@ -109,5 +308,11 @@ var (
 		NotificationEventChangeStatus,
 		NotificationEventMute,
 		NotificationEventWarning,
+		NotificationEventReportOutcome,
+		NotificationEventPostLocked,
+		NotificationEventPostArchived,
+		NotificationEventPostTagged,
+		NotificationEventContentHidden,
+		NotificationEventContentApproved,
 	}
 )
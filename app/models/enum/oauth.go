@@ -6,3 +6,10 @@ var (
 	//OAuthConfigEnabled is used to enable an OAuthConfig for public use
 	OAuthConfigEnabled = 2
 )
+
+var (
+	//OAuthConfigTypeOAuth2 is a provider configured by hand with its endpoints and JSON paths
+	OAuthConfigTypeOAuth2 = 1
+	//OAuthConfigTypeOIDC is an OpenID Connect provider configured through the discovery document of its issuer
+	OAuthConfigTypeOIDC = 2
+)
@@ -0,0 +1,141 @@
+package enum
+
+// Permission is something a role allows its users to do
+type Permission string
+
+const (
+	// PermissionQueueView allows working through the post queue
+	PermissionQueueView Permission = "queue.view"
+	// PermissionTagAssign allows assigning and unassigning tags on posts
+	PermissionTagAssign Permission = "tag.assign"
+	// PermissionTagPrivate allows assigning private tags, and tagging posts that are more than a week old
+	PermissionTagPrivate Permission = "tag.private"
+	// PermissionTagManage allows creating, editing and deleting tags
+	PermissionTagManage Permission = "tag.manage"
+	// PermissionPostRespond allows marking posts as duplicates, and changing them to any status with PermissionPostStatus
+	PermissionPostRespond Permission = "post.respond"
+	// PermissionPostStatus lifts the duplicate-only limit of PermissionPostRespond
+	PermissionPostStatus Permission = "post.status"
+	// PermissionPostEdit allows editing posts of other users
+	PermissionPostEdit Permission = "post.edit"
+	// PermissionPostDelete allows deleting posts of other users
+	PermissionPostDelete Permission = "post.delete"
+	// PermissionPostLock allows locking and unlocking posts
+	PermissionPostLock Permission = "post.lock"
+	// PermissionPostArchive allows archiving and unarchiving posts
+	PermissionPostArchive Permission = "post.archive"
+	// PermissionCommentEdit allows editing comments of other users
+	PermissionCommentEdit Permission = "comment.edit"
+	// PermissionCommentDelete allows deleting comments of other users
+	PermissionCommentDelete Permission = "comment.delete"
+	// PermissionContentModerate allows approving and hiding posts and comments waiting for moderation
+	PermissionContentModerate Permission = "content.moderate"
+	// PermissionReportView allows viewing and claiming reports
+	PermissionReportView Permission = "report.view"
+	// PermissionReportResolve allows resolving reports and report cases
+	PermissionReportResolve Permission = "report.resolve"
+	// PermissionResponseManage allows managing canned responses and report reasons
+	PermissionResponseManage Permission = "response.manage"
+	// PermissionAppealDecide allows deciding on appeals of muted and restricted users
+	PermissionAppealDecide Permission = "appeal.decide"
+	// PermissionUserModerate allows warning, muting and restricting users
+	PermissionUserModerate Permission = "user.moderate"
+	// PermissionUserBlock allows blocking and unblocking users, and deciding on their appeals
+	PermissionUserBlock Permission = "user.block"
+)
+
+// Permissions are all the permissions, in the order they are shown to administrators
+var Permissions = []Permission{
+	PermissionQueueView,
+	PermissionTagAssign,
+	PermissionTagPrivate,
+	PermissionTagManage,
+	PermissionPostRespond,
+	PermissionPostStatus,
+	PermissionPostEdit,
+	PermissionPostDelete,
+	PermissionPostLock,
+	PermissionPostArchive,
+	PermissionCommentEdit,
+	PermissionCommentDelete,
+	PermissionContentModerate,
+	PermissionReportView,
+	PermissionReportResolve,
+	PermissionResponseManage,
+	PermissionAppealDecide,
+	PermissionUserModerate,
+	PermissionUserBlock,
+}
+
+var helperPermissions = []Permission{
+	PermissionQueueView,
+	PermissionTagAssign,
+}
+
+var moderatorPermissions = append(append([]Permission{}, helperPermissions...),
+	PermissionTagPrivate,
+	PermissionPostRespond,
+	PermissionPostEdit,
+	PermissionPostDelete,
+	PermissionCommentEdit,
+	PermissionCommentDelete,
+	PermissionContentModerate,
+	PermissionReportView,
+	PermissionReportResolve,
+	PermissionAppealDecide,
+	PermissionUserModerate,
+)
+
+var collaboratorPermissions = append(append([]Permission{}, moderatorPermissions...),
+	PermissionTagManage,
+	PermissionPostStatus,
+	PermissionResponseManage,
+	PermissionPostLock,
+	PermissionPostArchive,
+	PermissionUserBlock,
+)
+
+// rolePermissions are the built-in presets, every user gets the permissions of their role
+var rolePermissions = map[Role][]Permission{
+	RoleVisitor:       {},
+	RoleHelper:        helperPermissions,
+	RoleModerator:     moderatorPermissions,
+	RoleCollaborator:  collaboratorPermissions,
+	RoleAdministrator: Permissions,
+}
+
+// Permissions returns the permissions the role grants
+func (role Role) Permissions() []Permission {
+	return rolePermissions[role]
+}
+
+// HasPermission returns true if the role grants given permission
+func (role Role) HasPermission(permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesWithPermission returns the built-in roles that grant given permission
+func RolesWithPermission(permission Permission) []Role {
+	roles := make([]Role, 0)
+	for _, role := range []Role{RoleVisitor, RoleHelper, RoleModerator, RoleCollaborator, RoleAdministrator} {
+		if role.HasPermission(permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// IsValid returns true if the permission exists
+func (p Permission) IsValid() bool {
+	for _, permission := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -5,4 +5,6 @@ var (
 	SubscriberInactive = 0
 	//SubscriberActive means that the subscription is active
 	SubscriberActive = 1
+	//SubscriberMuted means that the user doesn't want any notification about the post, regardless of role defaults, followed tags or mentions
+	SubscriberMuted = 2
 )
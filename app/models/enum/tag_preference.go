@@ -0,0 +1,34 @@
+package enum
+
+// TagPreference is how a user wants to hear about posts with a given tag
+type TagPreference int
+
+const (
+	// TagPreferenceFollow notifies the user about posts with the tag as if they had subscribed to them
+	TagPreferenceFollow TagPreference = 1
+	// TagPreferenceMute silences posts with the tag, unless the user subscribed to the post itself
+	TagPreferenceMute TagPreference = 2
+)
+
+var tagPreferenceIDs = map[TagPreference]string{
+	TagPreferenceFollow: "follow",
+	TagPreferenceMute:   "mute",
+}
+
+var tagPreferenceNames = map[string]TagPreference{
+	"follow": TagPreferenceFollow,
+	"mute":   TagPreferenceMute,
+}
+
+func (p TagPreference) String() string {
+	return tagPreferenceIDs[p]
+}
+
+func (p TagPreference) MarshalText() ([]byte, error) {
+	return []byte(tagPreferenceIDs[p]), nil
+}
+
+func (p *TagPreference) UnmarshalText(text []byte) error {
+	*p = tagPreferenceNames[string(text)]
+	return nil
+}
@@ -0,0 +1,34 @@
+package enum
+
+type UserMergeRequestStatus int
+
+const (
+	UserMergeRequestPending  UserMergeRequestStatus = 1
+	UserMergeRequestApproved UserMergeRequestStatus = 2
+	UserMergeRequestRejected UserMergeRequestStatus = 3
+)
+
+var userMergeRequestStatusIDs = map[UserMergeRequestStatus]string{
+	UserMergeRequestPending:  "pending",
+	UserMergeRequestApproved: "approved",
+	UserMergeRequestRejected: "rejected",
+}
+
+var userMergeRequestStatusNames = map[string]UserMergeRequestStatus{
+	"pending":  UserMergeRequestPending,
+	"approved": UserMergeRequestApproved,
+	"rejected": UserMergeRequestRejected,
+}
+
+func (s UserMergeRequestStatus) String() string {
+	return userMergeRequestStatusIDs[s]
+}
+
+func (s UserMergeRequestStatus) MarshalText() ([]byte, error) {
+	return []byte(userMergeRequestStatusIDs[s]), nil
+}
+
+func (s *UserMergeRequestStatus) UnmarshalText(text []byte) error {
+	*s = userMergeRequestStatusNames[string(text)]
+	return nil
+}
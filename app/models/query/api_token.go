@@ -0,0 +1,15 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+type GetAPITokenByHash struct {
+	TokenHash string
+
+	Result *entity.APIToken
+}
+
+type ListAPITokens struct {
+	UserID int
+
+	Result []*entity.APIToken
+}
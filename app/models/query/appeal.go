@@ -0,0 +1,34 @@
+package query
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type GetAppealByID struct {
+	AppealID int
+	Result   *entity.Appeal
+}
+
+type ListAppeals struct {
+	Status  []enum.AppealStatus
+	Page    int
+	PerPage int
+	Result  []*entity.Appeal
+	Total   int
+}
+
+// GetUserAppeals returns every appeal filed by a user, newest first
+type GetUserAppeals struct {
+	UserID int
+	Result []*entity.Appeal
+}
+
+// IsSanctionAppealable returns false if the sanction was already appealed,
+// either directly or as the replacement issued by a reduced appeal
+type IsSanctionAppealable struct {
+	UserID       int
+	SanctionType string
+	SanctionID   int
+	Result       bool
+}
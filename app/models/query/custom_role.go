@@ -0,0 +1,37 @@
+package query
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type ListCustomRoles struct {
+	Result []*entity.CustomRole
+}
+
+type GetCustomRoleByID struct {
+	ID int
+
+	Result *entity.CustomRole
+}
+
+// GetUserCustomRole returns the custom role assigned to a user, or ErrNotFound when there is none
+type GetUserCustomRole struct {
+	UserID int
+
+	Result *entity.CustomRole
+}
+
+// GetUserPermissions returns the permissions of the custom role of a user, the permissions of their role are not included
+type GetUserPermissions struct {
+	UserID int
+
+	Result []enum.Permission
+}
+
+// ListCustomRoleMembers returns the users a custom role is assigned to
+type ListCustomRoleMembers struct {
+	CustomRoleID int
+
+	Result []*entity.User
+}
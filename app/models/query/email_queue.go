@@ -0,0 +1,34 @@
+package query
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+// GetDueQueuedEmails returns the oldest pending emails of all tenants that are due to be sent
+type GetDueQueuedEmails struct {
+	Limit int
+
+	Result []*entity.QueuedEmail
+}
+
+// CountRecentEmailAttempts returns how many queued emails were handed to the email provider since given time
+type CountRecentEmailAttempts struct {
+	Since time.Time
+
+	Result int
+}
+
+// ListQueuedEmails returns the latest queued emails of the tenant, filtered by status unless it's zero
+type ListQueuedEmails struct {
+	Status enum.QueuedEmailStatus
+
+	Result []*entity.QueuedEmail
+}
+
+// CountQueuedEmailsByStatus returns how many emails of the tenant are in the queue for each status
+type CountQueuedEmailsByStatus struct {
+	Result map[enum.QueuedEmailStatus]int
+}
@@ -0,0 +1,16 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+// GetEmailTemplate returns the override of an email template in a locale, Result is nil when there's none
+type GetEmailTemplate struct {
+	Name   string
+	Locale string
+
+	Result *entity.EmailTemplate
+}
+
+// ListEmailTemplates returns all email template overrides of the tenant
+type ListEmailTemplates struct {
+	Result []*entity.EmailTemplate
+}
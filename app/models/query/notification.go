@@ -35,8 +35,37 @@ type PurgeReadNotifications struct {
 }
 
 // GetUsersToNotify represents a query to get users who should receive notifications
+// UserIDs optionally restricts the result to the given users
+// PostID optionally excludes users who muted the post or one of its tags
 type GetUsersToNotify struct {
 	Event   enum.NotificationEvent
 	Channel enum.NotificationChannel
+	UserIDs []int
+	PostID  int
 	Result  []*entity.User
 }
+
+// GetTagPreferences returns the tags that the current user follows or muted
+type GetTagPreferences struct {
+	Result []*entity.UserTagPreference
+}
+
+// GetPendingDigests returns the queued digest items of every user whose digest frequency is one of Frequencies
+type GetPendingDigests struct {
+	Frequencies []string
+
+	Result []*entity.Digest
+}
+
+// GetQuietHours returns the quiet hours of the users, matched either by id or by email, that have set them
+type GetQuietHours struct {
+	UserIDs []int
+	Emails  []string
+
+	Result []*entity.QuietHours
+}
+
+// GetDueDeferredNotifications returns the deferred notifications of every tenant whose release time has passed
+type GetDueDeferredNotifications struct {
+	Result []*entity.DeferredNotification
+}
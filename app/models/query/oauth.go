@@ -15,6 +15,13 @@ type ListCustomOAuthConfig struct {
 	Result []*entity.OAuthConfig
 }
 
+// GetOIDCConfiguration fetches the discovery document of an OpenID Connect issuer
+type GetOIDCConfiguration struct {
+	IssuerURL string
+
+	Result *dto.OIDCConfiguration
+}
+
 type GetOAuthAuthorizationURL struct {
 	Provider   string
 	Redirect   string
@@ -26,6 +33,8 @@ type GetOAuthAuthorizationURL struct {
 type GetOAuthProfile struct {
 	Provider string
 	Code     string
+	// Nonce is the one from the state of the authorization request, only OpenID Connect providers use it
+	Nonce string
 
 	Result *dto.OAuthUserProfile
 }
@@ -33,6 +42,7 @@ type GetOAuthProfile struct {
 type GetOAuthRawProfile struct {
 	Provider string
 	Code     string
+	Nonce    string
 
 	Result string
 }
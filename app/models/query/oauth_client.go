@@ -0,0 +1,13 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+type ListOAuthClients struct {
+	Result []*entity.OAuthClient
+}
+
+type GetOAuthClientByClientID struct {
+	ClientID string
+
+	Result *entity.OAuthClient
+}
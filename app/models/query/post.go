@@ -23,6 +23,13 @@ type GetPostByID struct {
 	Result *entity.Post
 }
 
+// GetPostByCommentID returns the post a comment belongs to
+type GetPostByCommentID struct {
+	CommentID int
+
+	Result *entity.Post
+}
+
 type GetPostBySlug struct {
 	Slug string
 
@@ -20,6 +20,27 @@ type ListReports struct {
 	Total    int
 }
 
+type GetReportCaseByID struct {
+	CaseID int
+	Result *entity.ReportCase
+}
+
+type ListReportCases struct {
+	Status  []enum.ReportStatus
+	Type    enum.ReportType
+	Reason  string
+	Page    int
+	PerPage int
+	Result  []*entity.ReportCase
+	Total   int
+}
+
+// CountTrustedCaseReporters counts the distinct trusted users that reported the items of a case
+type CountTrustedCaseReporters struct {
+	CaseID int
+	Result int
+}
+
 type CountPendingReports struct {
 	Result int
 }
@@ -28,6 +49,11 @@ type GetReportReasons struct {
 	Result []*entity.ReportReason
 }
 
+type GetUserReportAccuracy struct {
+	UserID int
+	Result *entity.ReportAccuracy
+}
+
 type CountUserReportsToday struct {
 	UserID int
 	Result int
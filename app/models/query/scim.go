@@ -0,0 +1,31 @@
+package query
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type GetSCIMToken struct {
+	Result *entity.SCIMToken
+}
+
+type GetSCIMTokenByHash struct {
+	TokenHash string
+
+	Result *entity.SCIMToken
+}
+
+// SearchSCIMUsers returns a page of the users matching every filter that is set, deleted users are never returned
+type SearchSCIMUsers struct {
+	UserID     int
+	Email      string
+	ExternalID string
+	Name       string
+	Role       enum.Role
+	VisualRole enum.VisualRole
+	Offset     int
+	Limit      int
+
+	Result       []*entity.SCIMUser
+	TotalResults int
+}
@@ -15,6 +15,7 @@ type UserSubscribedTo struct {
 	PostID int
 
 	Result bool
+	Muted  bool
 }
 
 type GetUserByAPIKey struct {
@@ -91,9 +92,18 @@ type GetUserProfileStanding struct {
 			CreatedAt time.Time  `json:"createdAt"`
 			ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 		} `json:"mutes"`
+		Restrictions  []*entity.UserRestriction `json:"restrictions,omitempty"`
+		NextSanction  *entity.SanctionStep      `json:"nextSanction,omitempty"`
+		BlockProposed bool                      `json:"blockProposed,omitempty"`
 	}
 }
 
+// GetUserSanctionHistory returns the moderation record the sanctions policy is evaluated against
+type GetUserSanctionHistory struct {
+	UserID int
+	Result *entity.SanctionHistory
+}
+
 // UserPostResult represents a post in the search result
 type UserPostResult struct {
 	ID        int       `json:"id"`
@@ -0,0 +1,19 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+// GetLatestUserDataExport returns the most recent data export requested by the current user
+type GetLatestUserDataExport struct {
+	Result *entity.UserDataExport
+}
+
+type GetUserDataExportByID struct {
+	ID int
+
+	Result *entity.UserDataExport
+}
+
+// GetExpiredUserDataExports returns the data exports of every tenant that can no longer be downloaded
+type GetExpiredUserDataExports struct {
+	Result []*entity.UserDataExport
+}
@@ -0,0 +1,17 @@
+package query
+
+import (
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+)
+
+type GetUserMergeRequestByID struct {
+	RequestID int
+	Result    *entity.UserMergeRequest
+}
+
+// ListUserMergeRequests returns the merge requests with given status, or all of them when Status is zero
+type ListUserMergeRequests struct {
+	Status enum.UserMergeRequestStatus
+	Result []*entity.UserMergeRequest
+}
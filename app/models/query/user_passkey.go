@@ -0,0 +1,15 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+type ListUserPasskeys struct {
+	UserID int
+
+	Result []*entity.UserPasskey
+}
+
+type GetUserPasskeyByCredentialID struct {
+	CredentialID string
+
+	Result *entity.UserPasskey
+}
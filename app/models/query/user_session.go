@@ -0,0 +1,26 @@
+package query
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+)
+
+type GetUserSession struct {
+	SessionID string
+
+	Result *entity.UserSession
+}
+
+type ListUserSessions struct {
+	UserID int
+
+	Result []*entity.UserSession
+}
+
+// GetUserSessionsRevokedAt returns when given user last signed out everywhere, nil if they never did
+type GetUserSessionsRevokedAt struct {
+	UserID int
+
+	Result *time.Time
+}
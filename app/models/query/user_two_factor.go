@@ -0,0 +1,15 @@
+package query
+
+import "github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+
+type GetUserTwoFactor struct {
+	UserID int
+
+	Result *entity.UserTwoFactor
+}
+
+type CountUnusedRecoveryCodes struct {
+	UserID int
+
+	Result int
+}
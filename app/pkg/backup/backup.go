@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
@@ -45,6 +46,9 @@ func Create(ctx context.Context) (*bytes.Buffer, error) {
 	}
 
 	for _, bkey := range listBlobs.Result {
+		if strings.HasPrefix(bkey, UserExportsPrefix) {
+			continue
+		}
 		err := addBlobToZipFile(ctx, zipWriter, bkey)
 		if err != nil {
 			return nil, err
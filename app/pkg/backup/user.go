@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/blob"
+)
+
+// UserExportsPrefix is where the personal data exports are stored, they are left out of the tenant backup
+const UserExportsPrefix = "exports/"
+
+// userDataFiles are the JSON files of a personal data export, every query takes the tenant id and user id
+// Columns are listed explicitly so that secrets and moderation notes about the user are never exported
+var userDataFiles = []struct {
+	name  string
+	query string
+}{
+	{"profile", `
+		SELECT id, name, email, role, status, visual_role, avatar_type, created_at
+		FROM users WHERE tenant_id = $1 AND id = $2`},
+	{"settings", `
+		SELECT key, value
+		FROM user_settings WHERE tenant_id = $1 AND user_id = $2 ORDER BY key`},
+	{"posts", `
+		SELECT id, number, title, slug, description, status, created_at
+		FROM posts WHERE tenant_id = $1 AND user_id = $2 ORDER BY id`},
+	{"comments", `
+		SELECT id, post_id, page_id, content, created_at, edited_at, deleted_at
+		FROM comments WHERE tenant_id = $1 AND user_id = $2 ORDER BY id`},
+	{"votes", `
+		SELECT p.number AS post_number, p.title AS post_title, v.vote_type, v.created_at
+		FROM post_votes v
+		INNER JOIN posts p ON p.id = v.post_id AND p.tenant_id = v.tenant_id
+		WHERE v.tenant_id = $1 AND v.user_id = $2 ORDER BY v.created_at`},
+	{"reactions", `
+		SELECT r.comment_id, r.emoji, r.created_on AS created_at
+		FROM reactions r
+		INNER JOIN comments c ON c.id = r.comment_id
+		WHERE c.tenant_id = $1 AND r.user_id = $2 ORDER BY r.id`},
+	{"page_reactions", `
+		SELECT r.page_id, p.title AS page_title, r.emoji, r.created_at
+		FROM page_reactions r
+		INNER JOIN pages p ON p.id = r.page_id
+		WHERE p.tenant_id = $1 AND r.user_id = $2 ORDER BY r.id`},
+	{"reports", `
+		SELECT id, reported_type, reported_id, reason, details, status, created_at, resolved_at
+		FROM reports WHERE tenant_id = $1 AND reporter_id = $2 ORDER BY id`},
+	{"notifications", `
+		SELECT id, title, link, read, event, created_at
+		FROM notifications WHERE tenant_id = $1 AND user_id = $2 ORDER BY id`},
+}
+
+// CreateUserExport returns a Zip file with everything the given user has stored on the current tenant
+func CreateUserExport(ctx context.Context, user *entity.User) (*bytes.Buffer, error) {
+	trx := ctx.Value(app.TransactionCtxKey).(*dbx.Trx)
+	tenant, _ := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
+
+	buffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buffer)
+
+	for _, file := range userDataFiles {
+		rows, err := trx.Query(file.query, tenant.ID, user.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to export user %s", file.name)
+		}
+
+		data, err := json.MarshalIndent(jsonify(rows), "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal user %s", file.name)
+		}
+
+		fileWriter, err := zipWriter.Create(fmt.Sprintf("%s.json", file.name))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create %s.json in zip file", file.name)
+		}
+		if _, err = fileWriter.Write(data); err != nil {
+			return nil, errors.Wrap(err, "failed to write %s.json to zip file", file.name)
+		}
+	}
+
+	var bkeys []string
+	err := trx.Select(&bkeys, "SELECT attachment_bkey FROM attachments WHERE tenant_id = $1 AND user_id = $2 ORDER BY id", tenant.ID, user.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list attachments of user")
+	}
+	if user.AvatarType == enum.AvatarTypeCustom && user.AvatarBlobKey != "" {
+		bkeys = append(bkeys, user.AvatarBlobKey)
+	}
+
+	for _, bkey := range bkeys {
+		err := addBlobToZipFile(ctx, zipWriter, bkey)
+		// attachments whose blob has already been removed are left out
+		if err != nil && errors.Cause(err) != blob.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close zip file")
+	}
+
+	return buffer, nil
+}
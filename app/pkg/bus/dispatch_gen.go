@@ -19,6 +19,7 @@ var cAddNewPostHandler func(context.Context, *cmd.AddNewPost) error
 var cAddNewTagHandler func(context.Context, *cmd.AddNewTag) error
 var cAddPageCommentHandler func(context.Context, *cmd.AddPageComment) error
 var cAddSubscriberHandler func(context.Context, *cmd.AddSubscriber) error
+var cAddUserPasskeyHandler func(context.Context, *cmd.AddUserPasskey) error
 var cAddVoteHandler func(context.Context, *cmd.AddVote) error
 var cArchivePostHandler func(context.Context, *cmd.ArchivePost) error
 var cAssignReportHandler func(context.Context, *cmd.AssignReport) error
@@ -29,21 +30,40 @@ var cCancelBillingSubscriptionHandler func(context.Context, *cmd.CancelBillingSu
 var cChangeUserEmailHandler func(context.Context, *cmd.ChangeUserEmail) error
 var cChangeUserRoleHandler func(context.Context, *cmd.ChangeUserRole) error
 var cChangeUserVisualRoleHandler func(context.Context, *cmd.ChangeUserVisualRole) error
+var cClearDigestItemsHandler func(context.Context, *cmd.ClearDigestItems) error
+var cCompleteUserDataExportHandler func(context.Context, *cmd.CompleteUserDataExport) error
+var cCreateAPITokenHandler func(context.Context, *cmd.CreateAPIToken) error
+var cCreateAppealHandler func(context.Context, *cmd.CreateAppeal) error
 var cCreateCannedResponseHandler func(context.Context, *cmd.CreateCannedResponse) error
+var cCreateCustomRoleHandler func(context.Context, *cmd.CreateCustomRole) error
+var cCreateOAuthAuthorizationCodeHandler func(context.Context, *cmd.CreateOAuthAuthorizationCode) error
+var cCreateOAuthClientHandler func(context.Context, *cmd.CreateOAuthClient) error
+var cCreateOAuthRefreshTokenHandler func(context.Context, *cmd.CreateOAuthRefreshToken) error
 var cCreatePageHandler func(context.Context, *cmd.CreatePage) error
 var cCreatePageTagHandler func(context.Context, *cmd.CreatePageTag) error
 var cCreatePageTopicHandler func(context.Context, *cmd.CreatePageTopic) error
 var cCreateReportHandler func(context.Context, *cmd.CreateReport) error
 var cCreateReportReasonHandler func(context.Context, *cmd.CreateReportReason) error
 var cCreateTenantHandler func(context.Context, *cmd.CreateTenant) error
+var cCreateUserDataExportHandler func(context.Context, *cmd.CreateUserDataExport) error
+var cCreateUserMergeRequestHandler func(context.Context, *cmd.CreateUserMergeRequest) error
+var cCreateUserSessionHandler func(context.Context, *cmd.CreateUserSession) error
+var cDecideAppealHandler func(context.Context, *cmd.DecideAppeal) error
+var cDecideUserMergeRequestHandler func(context.Context, *cmd.DecideUserMergeRequest) error
+var cDeferNotificationHandler func(context.Context, *cmd.DeferNotification) error
+var cDeleteAPITokenHandler func(context.Context, *cmd.DeleteAPIToken) error
 var cDeleteAllPushSubscriptionsHandler func(context.Context, *cmd.DeleteAllPushSubscriptions) error
 var cDeleteBlobHandler func(context.Context, *cmd.DeleteBlob) error
 var cDeleteCannedResponseHandler func(context.Context, *cmd.DeleteCannedResponse) error
 var cDeleteCommentHandler func(context.Context, *cmd.DeleteComment) error
 var cDeleteCurrentUserHandler func(context.Context, *cmd.DeleteCurrentUser) error
+var cDeleteCustomRoleHandler func(context.Context, *cmd.DeleteCustomRole) error
+var cDeleteDeferredNotificationsHandler func(context.Context, *cmd.DeleteDeferredNotifications) error
+var cDeleteEmailTemplateHandler func(context.Context, *cmd.DeleteEmailTemplate) error
 var cDeleteImageFileHandler func(context.Context, *cmd.DeleteImageFile) error
 var cDeleteImageFileReferencesHandler func(context.Context, *cmd.DeleteImageFileReferences) error
 var cDeleteMuteHandler func(context.Context, *cmd.DeleteMute) error
+var cDeleteOAuthClientHandler func(context.Context, *cmd.DeleteOAuthClient) error
 var cDeletePageHandler func(context.Context, *cmd.DeletePage) error
 var cDeletePageTagHandler func(context.Context, *cmd.DeletePageTag) error
 var cDeletePageTopicHandler func(context.Context, *cmd.DeletePageTopic) error
@@ -51,24 +71,43 @@ var cDeletePushSubscriptionHandler func(context.Context, *cmd.DeletePushSubscrip
 var cDeletePushSubscriptionByEndpointHandler func(context.Context, *cmd.DeletePushSubscriptionByEndpoint) error
 var cDeleteReportHandler func(context.Context, *cmd.DeleteReport) error
 var cDeleteReportReasonHandler func(context.Context, *cmd.DeleteReportReason) error
+var cDeleteSCIMTokenHandler func(context.Context, *cmd.DeleteSCIMToken) error
 var cDeleteTagHandler func(context.Context, *cmd.DeleteTag) error
+var cDeleteUserDataExportsHandler func(context.Context, *cmd.DeleteUserDataExports) error
+var cDeleteUserPasskeyHandler func(context.Context, *cmd.DeleteUserPasskey) error
 var cDeleteWarningHandler func(context.Context, *cmd.DeleteWarning) error
+var cDeliverMailHandler func(context.Context, *cmd.DeliverMail) error
+var cDisableUserTwoFactorHandler func(context.Context, *cmd.DisableUserTwoFactor) error
+var cDismissUserBlockProposalHandler func(context.Context, *cmd.DismissUserBlockProposal) error
+var cEnableUserTwoFactorHandler func(context.Context, *cmd.EnableUserTwoFactor) error
 var cExpireMuteHandler func(context.Context, *cmd.ExpireMute) error
 var cExpireWarningHandler func(context.Context, *cmd.ExpireWarning) error
 var cGenerateCheckoutLinkHandler func(context.Context, *cmd.GenerateCheckoutLink) error
 var cGetWebhookPropsHandler func(context.Context, *cmd.GetWebhookProps) error
 var cHTTPRequestHandler func(context.Context, *cmd.HTTPRequest) error
+var cLiftRestrictionHandler func(context.Context, *cmd.LiftRestriction) error
 var cLockExpiredTenantsHandler func(context.Context, *cmd.LockExpiredTenants) error
 var cLockPostHandler func(context.Context, *cmd.LockPost) error
 var cMarkAllNotificationsAsReadHandler func(context.Context, *cmd.MarkAllNotificationsAsRead) error
 var cMarkNotificationAsReadHandler func(context.Context, *cmd.MarkNotificationAsRead) error
 var cMarkPostAsDuplicateHandler func(context.Context, *cmd.MarkPostAsDuplicate) error
+var cMarkReportCaseAutoHiddenHandler func(context.Context, *cmd.MarkReportCaseAutoHidden) error
+var cMoveUserProviderHandler func(context.Context, *cmd.MoveUserProvider) error
+var cMuteSubscriberHandler func(context.Context, *cmd.MuteSubscriber) error
 var cMuteUserHandler func(context.Context, *cmd.MuteUser) error
 var cParseOAuthRawProfileHandler func(context.Context, *cmd.ParseOAuthRawProfile) error
 var cPreviewWebhookHandler func(context.Context, *cmd.PreviewWebhook) error
+var cProposeUserBlockHandler func(context.Context, *cmd.ProposeUserBlock) error
 var cPublishScheduledPagesHandler func(context.Context, *cmd.PublishScheduledPages) error
 var cPurgeExpiredNotificationsHandler func(context.Context, *cmd.PurgeExpiredNotifications) error
+var cPurgeExpiredOAuthGrantsHandler func(context.Context, *cmd.PurgeExpiredOAuthGrants) error
+var cPurgeExpiredUserSessionsHandler func(context.Context, *cmd.PurgeExpiredUserSessions) error
+var cPurgeQueuedEmailsHandler func(context.Context, *cmd.PurgeQueuedEmails) error
 var cPurgeReadNotificationsHandler func(context.Context, *cmd.PurgeReadNotifications) error
+var cQueueDigestItemsHandler func(context.Context, *cmd.QueueDigestItems) error
+var cQueueMailHandler func(context.Context, *cmd.QueueMail) error
+var cRecordPushDeliveryHandler func(context.Context, *cmd.RecordPushDelivery) error
+var cRecordTwoFactorFailureHandler func(context.Context, *cmd.RecordTwoFactorFailure) error
 var cRefreshPageEmbeddedDataHandler func(context.Context, *cmd.RefreshPageEmbeddedData) error
 var cRefreshPostStatsHandler func(context.Context, *cmd.RefreshPostStats) error
 var cRegenerateAPIKeyHandler func(context.Context, *cmd.RegenerateAPIKey) error
@@ -78,17 +117,29 @@ var cRemoveSubscriberHandler func(context.Context, *cmd.RemoveSubscriber) error
 var cRemoveVoteHandler func(context.Context, *cmd.RemoveVote) error
 var cRenameImageFileHandler func(context.Context, *cmd.RenameImageFile) error
 var cReorderReportReasonsHandler func(context.Context, *cmd.ReorderReportReasons) error
+var cReplaceUserRecoveryCodesHandler func(context.Context, *cmd.ReplaceUserRecoveryCodes) error
 var cResolveReportHandler func(context.Context, *cmd.ResolveReport) error
+var cResolveReportCaseHandler func(context.Context, *cmd.ResolveReportCase) error
+var cRestrictUserHandler func(context.Context, *cmd.RestrictUser) error
+var cRetryQueuedEmailHandler func(context.Context, *cmd.RetryQueuedEmail) error
+var cRevokeUserSessionHandler func(context.Context, *cmd.RevokeUserSession) error
+var cRevokeUserSessionsHandler func(context.Context, *cmd.RevokeUserSessions) error
 var cSaveCustomOAuthConfigHandler func(context.Context, *cmd.SaveCustomOAuthConfig) error
+var cSaveEmailTemplateHandler func(context.Context, *cmd.SaveEmailTemplate) error
 var cSaveNavigationLinksHandler func(context.Context, *cmd.SaveNavigationLinks) error
 var cSavePageDraftHandler func(context.Context, *cmd.SavePageDraft) error
 var cSavePushSubscriptionHandler func(context.Context, *cmd.SavePushSubscription) error
+var cSaveUserTwoFactorSecretHandler func(context.Context, *cmd.SaveUserTwoFactorSecret) error
 var cSaveVerificationKeyHandler func(context.Context, *cmd.SaveVerificationKey) error
 var cSetAttachmentsHandler func(context.Context, *cmd.SetAttachments) error
 var cSetKeyAsVerifiedHandler func(context.Context, *cmd.SetKeyAsVerified) error
 var cSetModerationPendingHandler func(context.Context, *cmd.SetModerationPending) error
 var cSetPostResponseHandler func(context.Context, *cmd.SetPostResponse) error
+var cSetSCIMExternalIDHandler func(context.Context, *cmd.SetSCIMExternalID) error
+var cSetSCIMTokenHandler func(context.Context, *cmd.SetSCIMToken) error
 var cSetSystemSettingsHandler func(context.Context, *cmd.SetSystemSettings) error
+var cSetTagPreferenceHandler func(context.Context, *cmd.SetTagPreference) error
+var cSetUserCustomRoleHandler func(context.Context, *cmd.SetUserCustomRole) error
 var cStoreBlobHandler func(context.Context, *cmd.StoreBlob) error
 var cStoreEventHandler func(context.Context, *cmd.StoreEvent) error
 var cSupressEmailHandler func(context.Context, *cmd.SupressEmail) error
@@ -96,34 +147,46 @@ var cTestWebhookHandler func(context.Context, *cmd.TestWebhook) error
 var cToggleCommentReactionHandler func(context.Context, *cmd.ToggleCommentReaction) error
 var cTogglePageReactionHandler func(context.Context, *cmd.TogglePageReaction) error
 var cTogglePageSubscriptionHandler func(context.Context, *cmd.TogglePageSubscription) error
+var cTouchAPITokenHandler func(context.Context, *cmd.TouchAPIToken) error
+var cTouchSCIMTokenHandler func(context.Context, *cmd.TouchSCIMToken) error
+var cTouchUserSessionHandler func(context.Context, *cmd.TouchUserSession) error
 var cTriggerWebhooksHandler func(context.Context, *cmd.TriggerWebhooks) error
 var cUnarchivePostHandler func(context.Context, *cmd.UnarchivePost) error
 var cUnassignReportHandler func(context.Context, *cmd.UnassignReport) error
 var cUnassignTagHandler func(context.Context, *cmd.UnassignTag) error
 var cUnblockUserHandler func(context.Context, *cmd.UnblockUser) error
 var cUnlockPostHandler func(context.Context, *cmd.UnlockPost) error
+var cUnregisterUserProviderHandler func(context.Context, *cmd.UnregisterUserProvider) error
 var cUpdateCannedResponseHandler func(context.Context, *cmd.UpdateCannedResponse) error
 var cUpdateCommentHandler func(context.Context, *cmd.UpdateComment) error
 var cUpdateContentSettingsHandler func(context.Context, *cmd.UpdateContentSettings) error
 var cUpdateCurrentUserHandler func(context.Context, *cmd.UpdateCurrentUser) error
 var cUpdateCurrentUserSettingsHandler func(context.Context, *cmd.UpdateCurrentUserSettings) error
+var cUpdateCustomRoleHandler func(context.Context, *cmd.UpdateCustomRole) error
 var cUpdateImageFileReferencesHandler func(context.Context, *cmd.UpdateImageFileReferences) error
 var cUpdateMessageBannerHandler func(context.Context, *cmd.UpdateMessageBanner) error
 var cUpdatePageHandler func(context.Context, *cmd.UpdatePage) error
 var cUpdatePageTagHandler func(context.Context, *cmd.UpdatePageTag) error
 var cUpdatePageTopicHandler func(context.Context, *cmd.UpdatePageTopic) error
 var cUpdatePostHandler func(context.Context, *cmd.UpdatePost) error
+var cUpdateQueuedEmailHandler func(context.Context, *cmd.UpdateQueuedEmail) error
 var cUpdateReportReasonHandler func(context.Context, *cmd.UpdateReportReason) error
 var cUpdateTagHandler func(context.Context, *cmd.UpdateTag) error
 var cUpdateTenantAdvancedSettingsHandler func(context.Context, *cmd.UpdateTenantAdvancedSettings) error
 var cUpdateTenantEmailAuthAllowedSettingsHandler func(context.Context, *cmd.UpdateTenantEmailAuthAllowedSettings) error
 var cUpdateTenantPrivacySettingsHandler func(context.Context, *cmd.UpdateTenantPrivacySettings) error
 var cUpdateTenantSettingsHandler func(context.Context, *cmd.UpdateTenantSettings) error
+var cUpdateTenantTwoFactorPolicyHandler func(context.Context, *cmd.UpdateTenantTwoFactorPolicy) error
 var cUpdateUserHandler func(context.Context, *cmd.UpdateUser) error
 var cUpdateUserAvatarHandler func(context.Context, *cmd.UpdateUserAvatar) error
+var cUpdateUserPasskeyUsageHandler func(context.Context, *cmd.UpdateUserPasskeyUsage) error
 var cUploadImageHandler func(context.Context, *cmd.UploadImage) error
 var cUploadImageFileHandler func(context.Context, *cmd.UploadImageFile) error
 var cUploadImagesHandler func(context.Context, *cmd.UploadImages) error
+var cUseOAuthAuthorizationCodeHandler func(context.Context, *cmd.UseOAuthAuthorizationCode) error
+var cUseOAuthRefreshTokenHandler func(context.Context, *cmd.UseOAuthRefreshToken) error
+var cUseRecoveryCodeHandler func(context.Context, *cmd.UseRecoveryCode) error
+var cUseTwoFactorStepHandler func(context.Context, *cmd.UseTwoFactorStep) error
 var cUserListCreateCompanyHandler func(context.Context, *cmd.UserListCreateCompany) error
 var cUserListHandleRoleChangeHandler func(context.Context, *cmd.UserListHandleRoleChange) error
 var cUserListUpdateCompanyHandler func(context.Context, *cmd.UserListUpdateCompany) error
@@ -131,14 +194,19 @@ var cUserListUpdateUserHandler func(context.Context, *cmd.UserListUpdateUser) er
 var cWarnUserHandler func(context.Context, *cmd.WarnUser) error
 var qCountPendingReportsHandler func(context.Context, *query.CountPendingReports) error
 var qCountPostPerStatusHandler func(context.Context, *query.CountPostPerStatus) error
+var qCountQueuedEmailsByStatusHandler func(context.Context, *query.CountQueuedEmailsByStatus) error
+var qCountRecentEmailAttemptsHandler func(context.Context, *query.CountRecentEmailAttempts) error
+var qCountTrustedCaseReportersHandler func(context.Context, *query.CountTrustedCaseReporters) error
 var qCountUnreadNotificationsHandler func(context.Context, *query.CountUnreadNotifications) error
 var qCountUntaggedPostsHandler func(context.Context, *query.CountUntaggedPosts) error
+var qCountUnusedRecoveryCodesHandler func(context.Context, *query.CountUnusedRecoveryCodes) error
 var qCountUserReportsTodayHandler func(context.Context, *query.CountUserReportsToday) error
 var qCountUsersHandler func(context.Context, *query.CountUsers) error
 var qCountVotesSinceArchiveHandler func(context.Context, *query.CountVotesSinceArchive) error
 var qCreateEditWebhookHandler func(context.Context, *query.CreateEditWebhook) error
 var qDeleteWebhookHandler func(context.Context, *query.DeleteWebhook) error
 var qFetchRecentSupressionsHandler func(context.Context, *query.FetchRecentSupressions) error
+var qGetAPITokenByHashHandler func(context.Context, *query.GetAPITokenByHash) error
 var qGetActiveNotificationsHandler func(context.Context, *query.GetActiveNotifications) error
 var qGetActiveSubscribersHandler func(context.Context, *query.GetActiveSubscribers) error
 var qGetAllPostsHandler func(context.Context, *query.GetAllPosts) error
@@ -148,6 +216,7 @@ var qGetAllTagsHandler func(context.Context, *query.GetAllTags) error
 var qGetAllUserProvidersHandler func(context.Context, *query.GetAllUserProviders) error
 var qGetAllUsersHandler func(context.Context, *query.GetAllUsers) error
 var qGetAllUsersNamesHandler func(context.Context, *query.GetAllUsersNames) error
+var qGetAppealByIDHandler func(context.Context, *query.GetAppealByID) error
 var qGetArchivablePostsHandler func(context.Context, *query.GetArchivablePosts) error
 var qGetAssignedTagsHandler func(context.Context, *query.GetAssignedTags) error
 var qGetAttachmentsHandler func(context.Context, *query.GetAttachments) error
@@ -160,14 +229,22 @@ var qGetCommentsByPageHandler func(context.Context, *query.GetCommentsByPage) er
 var qGetCommentsByPostHandler func(context.Context, *query.GetCommentsByPost) error
 var qGetCurrentUserSettingsHandler func(context.Context, *query.GetCurrentUserSettings) error
 var qGetCustomOAuthConfigByProviderHandler func(context.Context, *query.GetCustomOAuthConfigByProvider) error
+var qGetCustomRoleByIDHandler func(context.Context, *query.GetCustomRoleByID) error
+var qGetDueDeferredNotificationsHandler func(context.Context, *query.GetDueDeferredNotifications) error
+var qGetDueQueuedEmailsHandler func(context.Context, *query.GetDueQueuedEmails) error
+var qGetEmailTemplateHandler func(context.Context, *query.GetEmailTemplate) error
+var qGetExpiredUserDataExportsHandler func(context.Context, *query.GetExpiredUserDataExports) error
 var qGetFirstTenantHandler func(context.Context, *query.GetFirstTenant) error
 var qGetImageFileHandler func(context.Context, *query.GetImageFile) error
+var qGetLatestUserDataExportHandler func(context.Context, *query.GetLatestUserDataExport) error
 var qGetNameFromBlobKeyHandler func(context.Context, *query.GetNameFromBlobKey) error
 var qGetNavigationLinksHandler func(context.Context, *query.GetNavigationLinks) error
 var qGetNotificationByIDHandler func(context.Context, *query.GetNotificationByID) error
 var qGetOAuthAuthorizationURLHandler func(context.Context, *query.GetOAuthAuthorizationURL) error
+var qGetOAuthClientByClientIDHandler func(context.Context, *query.GetOAuthClientByClientID) error
 var qGetOAuthProfileHandler func(context.Context, *query.GetOAuthProfile) error
 var qGetOAuthRawProfileHandler func(context.Context, *query.GetOAuthRawProfile) error
+var qGetOIDCConfigurationHandler func(context.Context, *query.GetOIDCConfiguration) error
 var qGetPageByIDHandler func(context.Context, *query.GetPageByID) error
 var qGetPageBySlugHandler func(context.Context, *query.GetPageBySlug) error
 var qGetPageDraftHandler func(context.Context, *query.GetPageDraft) error
@@ -176,6 +253,8 @@ var qGetPageTagByIDHandler func(context.Context, *query.GetPageTagByID) error
 var qGetPageTagsHandler func(context.Context, *query.GetPageTags) error
 var qGetPageTopicByIDHandler func(context.Context, *query.GetPageTopicByID) error
 var qGetPageTopicsHandler func(context.Context, *query.GetPageTopics) error
+var qGetPendingDigestsHandler func(context.Context, *query.GetPendingDigests) error
+var qGetPostByCommentIDHandler func(context.Context, *query.GetPostByCommentID) error
 var qGetPostByIDHandler func(context.Context, *query.GetPostByID) error
 var qGetPostByNumberHandler func(context.Context, *query.GetPostByNumber) error
 var qGetPostBySlugHandler func(context.Context, *query.GetPostBySlug) error
@@ -183,22 +262,38 @@ var qGetPostsByIDsHandler func(context.Context, *query.GetPostsByIDs) error
 var qGetPrunableFilesHandler func(context.Context, *query.GetPrunableFiles) error
 var qGetPushSubscriptionsByUserHandler func(context.Context, *query.GetPushSubscriptionsByUser) error
 var qGetPushSubscriptionsByUsersHandler func(context.Context, *query.GetPushSubscriptionsByUsers) error
+var qGetQuietHoursHandler func(context.Context, *query.GetQuietHours) error
 var qGetReportByIDHandler func(context.Context, *query.GetReportByID) error
+var qGetReportCaseByIDHandler func(context.Context, *query.GetReportCaseByID) error
 var qGetReportReasonsHandler func(context.Context, *query.GetReportReasons) error
+var qGetSCIMTokenHandler func(context.Context, *query.GetSCIMToken) error
+var qGetSCIMTokenByHashHandler func(context.Context, *query.GetSCIMTokenByHash) error
 var qGetSystemSettingsHandler func(context.Context, *query.GetSystemSettings) error
 var qGetTagBySlugHandler func(context.Context, *query.GetTagBySlug) error
+var qGetTagPreferencesHandler func(context.Context, *query.GetTagPreferences) error
 var qGetTenantByDomainHandler func(context.Context, *query.GetTenantByDomain) error
 var qGetTenantProfanityWordsHandler func(context.Context, *query.GetTenantProfanityWords) error
 var qGetTrialingTenantContactsHandler func(context.Context, *query.GetTrialingTenantContacts) error
+var qGetUserAppealsHandler func(context.Context, *query.GetUserAppeals) error
 var qGetUserByAPIKeyHandler func(context.Context, *query.GetUserByAPIKey) error
 var qGetUserByEmailHandler func(context.Context, *query.GetUserByEmail) error
 var qGetUserByIDHandler func(context.Context, *query.GetUserByID) error
 var qGetUserByProviderHandler func(context.Context, *query.GetUserByProvider) error
 var qGetUserCommentCountHandler func(context.Context, *query.GetUserCommentCount) error
+var qGetUserCustomRoleHandler func(context.Context, *query.GetUserCustomRole) error
+var qGetUserDataExportByIDHandler func(context.Context, *query.GetUserDataExportByID) error
+var qGetUserMergeRequestByIDHandler func(context.Context, *query.GetUserMergeRequestByID) error
+var qGetUserPasskeyByCredentialIDHandler func(context.Context, *query.GetUserPasskeyByCredentialID) error
+var qGetUserPermissionsHandler func(context.Context, *query.GetUserPermissions) error
 var qGetUserPostCountHandler func(context.Context, *query.GetUserPostCount) error
 var qGetUserProfileStandingHandler func(context.Context, *query.GetUserProfileStanding) error
 var qGetUserProfileStatsHandler func(context.Context, *query.GetUserProfileStats) error
+var qGetUserReportAccuracyHandler func(context.Context, *query.GetUserReportAccuracy) error
 var qGetUserReportedItemsOnPostHandler func(context.Context, *query.GetUserReportedItemsOnPost) error
+var qGetUserSanctionHistoryHandler func(context.Context, *query.GetUserSanctionHistory) error
+var qGetUserSessionHandler func(context.Context, *query.GetUserSession) error
+var qGetUserSessionsRevokedAtHandler func(context.Context, *query.GetUserSessionsRevokedAt) error
+var qGetUserTwoFactorHandler func(context.Context, *query.GetUserTwoFactor) error
 var qGetUsersByIDsHandler func(context.Context, *query.GetUsersByIDs) error
 var qGetUsersToNotifyHandler func(context.Context, *query.GetUsersToNotify) error
 var qGetVerificationByKeyHandler func(context.Context, *query.GetVerificationByKey) error
@@ -207,23 +302,36 @@ var qHasPushSubscriptionHandler func(context.Context, *query.HasPushSubscription
 var qHasUserReportedTargetHandler func(context.Context, *query.HasUserReportedTarget) error
 var qIsCNAMEAvailableHandler func(context.Context, *query.IsCNAMEAvailable) error
 var qIsImageFileInUseHandler func(context.Context, *query.IsImageFileInUse) error
+var qIsSanctionAppealableHandler func(context.Context, *query.IsSanctionAppealable) error
 var qIsSubdomainAvailableHandler func(context.Context, *query.IsSubdomainAvailable) error
+var qListAPITokensHandler func(context.Context, *query.ListAPITokens) error
 var qListActiveOAuthProvidersHandler func(context.Context, *query.ListActiveOAuthProviders) error
 var qListActiveWebhooksByTypeHandler func(context.Context, *query.ListActiveWebhooksByType) error
 var qListAllOAuthProvidersHandler func(context.Context, *query.ListAllOAuthProviders) error
 var qListAllReportReasonsHandler func(context.Context, *query.ListAllReportReasons) error
 var qListAllWebhooksHandler func(context.Context, *query.ListAllWebhooks) error
 var qListAllWebhooksByTypeHandler func(context.Context, *query.ListAllWebhooksByType) error
+var qListAppealsHandler func(context.Context, *query.ListAppeals) error
 var qListBlobsHandler func(context.Context, *query.ListBlobs) error
 var qListCannedResponsesHandler func(context.Context, *query.ListCannedResponses) error
 var qListCustomOAuthConfigHandler func(context.Context, *query.ListCustomOAuthConfig) error
+var qListCustomRoleMembersHandler func(context.Context, *query.ListCustomRoleMembers) error
+var qListCustomRolesHandler func(context.Context, *query.ListCustomRoles) error
+var qListEmailTemplatesHandler func(context.Context, *query.ListEmailTemplates) error
 var qListImageFilesHandler func(context.Context, *query.ListImageFiles) error
+var qListOAuthClientsHandler func(context.Context, *query.ListOAuthClients) error
 var qListPagesHandler func(context.Context, *query.ListPages) error
 var qListPostVotesHandler func(context.Context, *query.ListPostVotes) error
+var qListQueuedEmailsHandler func(context.Context, *query.ListQueuedEmails) error
+var qListReportCasesHandler func(context.Context, *query.ListReportCases) error
 var qListReportsHandler func(context.Context, *query.ListReports) error
+var qListUserMergeRequestsHandler func(context.Context, *query.ListUserMergeRequests) error
+var qListUserPasskeysHandler func(context.Context, *query.ListUserPasskeys) error
+var qListUserSessionsHandler func(context.Context, *query.ListUserSessions) error
 var qMarkWebhookAsFailedHandler func(context.Context, *query.MarkWebhookAsFailed) error
 var qPostIsReferencedHandler func(context.Context, *query.PostIsReferenced) error
 var qSearchPostsHandler func(context.Context, *query.SearchPosts) error
+var qSearchSCIMUsersHandler func(context.Context, *query.SearchSCIMUsers) error
 var qSearchUserContentHandler func(context.Context, *query.SearchUserContent) error
 var qUserSubscribedToHandler func(context.Context, *query.UserSubscribedTo) error
 var qUserSubscribedToPageHandler func(context.Context, *query.UserSubscribedToPage) error
@@ -251,6 +359,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		cAddPageCommentHandler = fn
 	case func(context.Context, *cmd.AddSubscriber) error:
 		cAddSubscriberHandler = fn
+	case func(context.Context, *cmd.AddUserPasskey) error:
+		cAddUserPasskeyHandler = fn
 	case func(context.Context, *cmd.AddVote) error:
 		cAddVoteHandler = fn
 	case func(context.Context, *cmd.ArchivePost) error:
@@ -271,8 +381,24 @@ func registerTypedHandler(handler HandlerFunc) {
 		cChangeUserRoleHandler = fn
 	case func(context.Context, *cmd.ChangeUserVisualRole) error:
 		cChangeUserVisualRoleHandler = fn
+	case func(context.Context, *cmd.ClearDigestItems) error:
+		cClearDigestItemsHandler = fn
+	case func(context.Context, *cmd.CompleteUserDataExport) error:
+		cCompleteUserDataExportHandler = fn
+	case func(context.Context, *cmd.CreateAPIToken) error:
+		cCreateAPITokenHandler = fn
+	case func(context.Context, *cmd.CreateAppeal) error:
+		cCreateAppealHandler = fn
 	case func(context.Context, *cmd.CreateCannedResponse) error:
 		cCreateCannedResponseHandler = fn
+	case func(context.Context, *cmd.CreateCustomRole) error:
+		cCreateCustomRoleHandler = fn
+	case func(context.Context, *cmd.CreateOAuthAuthorizationCode) error:
+		cCreateOAuthAuthorizationCodeHandler = fn
+	case func(context.Context, *cmd.CreateOAuthClient) error:
+		cCreateOAuthClientHandler = fn
+	case func(context.Context, *cmd.CreateOAuthRefreshToken) error:
+		cCreateOAuthRefreshTokenHandler = fn
 	case func(context.Context, *cmd.CreatePage) error:
 		cCreatePageHandler = fn
 	case func(context.Context, *cmd.CreatePageTag) error:
@@ -285,6 +411,20 @@ func registerTypedHandler(handler HandlerFunc) {
 		cCreateReportReasonHandler = fn
 	case func(context.Context, *cmd.CreateTenant) error:
 		cCreateTenantHandler = fn
+	case func(context.Context, *cmd.CreateUserDataExport) error:
+		cCreateUserDataExportHandler = fn
+	case func(context.Context, *cmd.CreateUserMergeRequest) error:
+		cCreateUserMergeRequestHandler = fn
+	case func(context.Context, *cmd.CreateUserSession) error:
+		cCreateUserSessionHandler = fn
+	case func(context.Context, *cmd.DecideAppeal) error:
+		cDecideAppealHandler = fn
+	case func(context.Context, *cmd.DecideUserMergeRequest) error:
+		cDecideUserMergeRequestHandler = fn
+	case func(context.Context, *cmd.DeferNotification) error:
+		cDeferNotificationHandler = fn
+	case func(context.Context, *cmd.DeleteAPIToken) error:
+		cDeleteAPITokenHandler = fn
 	case func(context.Context, *cmd.DeleteAllPushSubscriptions) error:
 		cDeleteAllPushSubscriptionsHandler = fn
 	case func(context.Context, *cmd.DeleteBlob) error:
@@ -295,12 +435,20 @@ func registerTypedHandler(handler HandlerFunc) {
 		cDeleteCommentHandler = fn
 	case func(context.Context, *cmd.DeleteCurrentUser) error:
 		cDeleteCurrentUserHandler = fn
+	case func(context.Context, *cmd.DeleteCustomRole) error:
+		cDeleteCustomRoleHandler = fn
+	case func(context.Context, *cmd.DeleteDeferredNotifications) error:
+		cDeleteDeferredNotificationsHandler = fn
+	case func(context.Context, *cmd.DeleteEmailTemplate) error:
+		cDeleteEmailTemplateHandler = fn
 	case func(context.Context, *cmd.DeleteImageFile) error:
 		cDeleteImageFileHandler = fn
 	case func(context.Context, *cmd.DeleteImageFileReferences) error:
 		cDeleteImageFileReferencesHandler = fn
 	case func(context.Context, *cmd.DeleteMute) error:
 		cDeleteMuteHandler = fn
+	case func(context.Context, *cmd.DeleteOAuthClient) error:
+		cDeleteOAuthClientHandler = fn
 	case func(context.Context, *cmd.DeletePage) error:
 		cDeletePageHandler = fn
 	case func(context.Context, *cmd.DeletePageTag) error:
@@ -315,10 +463,24 @@ func registerTypedHandler(handler HandlerFunc) {
 		cDeleteReportHandler = fn
 	case func(context.Context, *cmd.DeleteReportReason) error:
 		cDeleteReportReasonHandler = fn
+	case func(context.Context, *cmd.DeleteSCIMToken) error:
+		cDeleteSCIMTokenHandler = fn
 	case func(context.Context, *cmd.DeleteTag) error:
 		cDeleteTagHandler = fn
+	case func(context.Context, *cmd.DeleteUserDataExports) error:
+		cDeleteUserDataExportsHandler = fn
+	case func(context.Context, *cmd.DeleteUserPasskey) error:
+		cDeleteUserPasskeyHandler = fn
 	case func(context.Context, *cmd.DeleteWarning) error:
 		cDeleteWarningHandler = fn
+	case func(context.Context, *cmd.DeliverMail) error:
+		cDeliverMailHandler = fn
+	case func(context.Context, *cmd.DisableUserTwoFactor) error:
+		cDisableUserTwoFactorHandler = fn
+	case func(context.Context, *cmd.DismissUserBlockProposal) error:
+		cDismissUserBlockProposalHandler = fn
+	case func(context.Context, *cmd.EnableUserTwoFactor) error:
+		cEnableUserTwoFactorHandler = fn
 	case func(context.Context, *cmd.ExpireMute) error:
 		cExpireMuteHandler = fn
 	case func(context.Context, *cmd.ExpireWarning) error:
@@ -329,6 +491,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		cGetWebhookPropsHandler = fn
 	case func(context.Context, *cmd.HTTPRequest) error:
 		cHTTPRequestHandler = fn
+	case func(context.Context, *cmd.LiftRestriction) error:
+		cLiftRestrictionHandler = fn
 	case func(context.Context, *cmd.LockExpiredTenants) error:
 		cLockExpiredTenantsHandler = fn
 	case func(context.Context, *cmd.LockPost) error:
@@ -339,18 +503,40 @@ func registerTypedHandler(handler HandlerFunc) {
 		cMarkNotificationAsReadHandler = fn
 	case func(context.Context, *cmd.MarkPostAsDuplicate) error:
 		cMarkPostAsDuplicateHandler = fn
+	case func(context.Context, *cmd.MarkReportCaseAutoHidden) error:
+		cMarkReportCaseAutoHiddenHandler = fn
+	case func(context.Context, *cmd.MoveUserProvider) error:
+		cMoveUserProviderHandler = fn
+	case func(context.Context, *cmd.MuteSubscriber) error:
+		cMuteSubscriberHandler = fn
 	case func(context.Context, *cmd.MuteUser) error:
 		cMuteUserHandler = fn
 	case func(context.Context, *cmd.ParseOAuthRawProfile) error:
 		cParseOAuthRawProfileHandler = fn
 	case func(context.Context, *cmd.PreviewWebhook) error:
 		cPreviewWebhookHandler = fn
+	case func(context.Context, *cmd.ProposeUserBlock) error:
+		cProposeUserBlockHandler = fn
 	case func(context.Context, *cmd.PublishScheduledPages) error:
 		cPublishScheduledPagesHandler = fn
 	case func(context.Context, *cmd.PurgeExpiredNotifications) error:
 		cPurgeExpiredNotificationsHandler = fn
+	case func(context.Context, *cmd.PurgeExpiredOAuthGrants) error:
+		cPurgeExpiredOAuthGrantsHandler = fn
+	case func(context.Context, *cmd.PurgeExpiredUserSessions) error:
+		cPurgeExpiredUserSessionsHandler = fn
+	case func(context.Context, *cmd.PurgeQueuedEmails) error:
+		cPurgeQueuedEmailsHandler = fn
 	case func(context.Context, *cmd.PurgeReadNotifications) error:
 		cPurgeReadNotificationsHandler = fn
+	case func(context.Context, *cmd.QueueDigestItems) error:
+		cQueueDigestItemsHandler = fn
+	case func(context.Context, *cmd.QueueMail) error:
+		cQueueMailHandler = fn
+	case func(context.Context, *cmd.RecordPushDelivery) error:
+		cRecordPushDeliveryHandler = fn
+	case func(context.Context, *cmd.RecordTwoFactorFailure) error:
+		cRecordTwoFactorFailureHandler = fn
 	case func(context.Context, *cmd.RefreshPageEmbeddedData) error:
 		cRefreshPageEmbeddedDataHandler = fn
 	case func(context.Context, *cmd.RefreshPostStats) error:
@@ -369,16 +555,32 @@ func registerTypedHandler(handler HandlerFunc) {
 		cRenameImageFileHandler = fn
 	case func(context.Context, *cmd.ReorderReportReasons) error:
 		cReorderReportReasonsHandler = fn
+	case func(context.Context, *cmd.ReplaceUserRecoveryCodes) error:
+		cReplaceUserRecoveryCodesHandler = fn
 	case func(context.Context, *cmd.ResolveReport) error:
 		cResolveReportHandler = fn
+	case func(context.Context, *cmd.ResolveReportCase) error:
+		cResolveReportCaseHandler = fn
+	case func(context.Context, *cmd.RestrictUser) error:
+		cRestrictUserHandler = fn
+	case func(context.Context, *cmd.RetryQueuedEmail) error:
+		cRetryQueuedEmailHandler = fn
+	case func(context.Context, *cmd.RevokeUserSession) error:
+		cRevokeUserSessionHandler = fn
+	case func(context.Context, *cmd.RevokeUserSessions) error:
+		cRevokeUserSessionsHandler = fn
 	case func(context.Context, *cmd.SaveCustomOAuthConfig) error:
 		cSaveCustomOAuthConfigHandler = fn
+	case func(context.Context, *cmd.SaveEmailTemplate) error:
+		cSaveEmailTemplateHandler = fn
 	case func(context.Context, *cmd.SaveNavigationLinks) error:
 		cSaveNavigationLinksHandler = fn
 	case func(context.Context, *cmd.SavePageDraft) error:
 		cSavePageDraftHandler = fn
 	case func(context.Context, *cmd.SavePushSubscription) error:
 		cSavePushSubscriptionHandler = fn
+	case func(context.Context, *cmd.SaveUserTwoFactorSecret) error:
+		cSaveUserTwoFactorSecretHandler = fn
 	case func(context.Context, *cmd.SaveVerificationKey) error:
 		cSaveVerificationKeyHandler = fn
 	case func(context.Context, *cmd.SetAttachments) error:
@@ -389,8 +591,16 @@ func registerTypedHandler(handler HandlerFunc) {
 		cSetModerationPendingHandler = fn
 	case func(context.Context, *cmd.SetPostResponse) error:
 		cSetPostResponseHandler = fn
+	case func(context.Context, *cmd.SetSCIMExternalID) error:
+		cSetSCIMExternalIDHandler = fn
+	case func(context.Context, *cmd.SetSCIMToken) error:
+		cSetSCIMTokenHandler = fn
 	case func(context.Context, *cmd.SetSystemSettings) error:
 		cSetSystemSettingsHandler = fn
+	case func(context.Context, *cmd.SetTagPreference) error:
+		cSetTagPreferenceHandler = fn
+	case func(context.Context, *cmd.SetUserCustomRole) error:
+		cSetUserCustomRoleHandler = fn
 	case func(context.Context, *cmd.StoreBlob) error:
 		cStoreBlobHandler = fn
 	case func(context.Context, *cmd.StoreEvent) error:
@@ -405,6 +615,12 @@ func registerTypedHandler(handler HandlerFunc) {
 		cTogglePageReactionHandler = fn
 	case func(context.Context, *cmd.TogglePageSubscription) error:
 		cTogglePageSubscriptionHandler = fn
+	case func(context.Context, *cmd.TouchAPIToken) error:
+		cTouchAPITokenHandler = fn
+	case func(context.Context, *cmd.TouchSCIMToken) error:
+		cTouchSCIMTokenHandler = fn
+	case func(context.Context, *cmd.TouchUserSession) error:
+		cTouchUserSessionHandler = fn
 	case func(context.Context, *cmd.TriggerWebhooks) error:
 		cTriggerWebhooksHandler = fn
 	case func(context.Context, *cmd.UnarchivePost) error:
@@ -417,6 +633,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		cUnblockUserHandler = fn
 	case func(context.Context, *cmd.UnlockPost) error:
 		cUnlockPostHandler = fn
+	case func(context.Context, *cmd.UnregisterUserProvider) error:
+		cUnregisterUserProviderHandler = fn
 	case func(context.Context, *cmd.UpdateCannedResponse) error:
 		cUpdateCannedResponseHandler = fn
 	case func(context.Context, *cmd.UpdateComment) error:
@@ -427,6 +645,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		cUpdateCurrentUserHandler = fn
 	case func(context.Context, *cmd.UpdateCurrentUserSettings) error:
 		cUpdateCurrentUserSettingsHandler = fn
+	case func(context.Context, *cmd.UpdateCustomRole) error:
+		cUpdateCustomRoleHandler = fn
 	case func(context.Context, *cmd.UpdateImageFileReferences) error:
 		cUpdateImageFileReferencesHandler = fn
 	case func(context.Context, *cmd.UpdateMessageBanner) error:
@@ -439,6 +659,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		cUpdatePageTopicHandler = fn
 	case func(context.Context, *cmd.UpdatePost) error:
 		cUpdatePostHandler = fn
+	case func(context.Context, *cmd.UpdateQueuedEmail) error:
+		cUpdateQueuedEmailHandler = fn
 	case func(context.Context, *cmd.UpdateReportReason) error:
 		cUpdateReportReasonHandler = fn
 	case func(context.Context, *cmd.UpdateTag) error:
@@ -451,16 +673,28 @@ func registerTypedHandler(handler HandlerFunc) {
 		cUpdateTenantPrivacySettingsHandler = fn
 	case func(context.Context, *cmd.UpdateTenantSettings) error:
 		cUpdateTenantSettingsHandler = fn
+	case func(context.Context, *cmd.UpdateTenantTwoFactorPolicy) error:
+		cUpdateTenantTwoFactorPolicyHandler = fn
 	case func(context.Context, *cmd.UpdateUser) error:
 		cUpdateUserHandler = fn
 	case func(context.Context, *cmd.UpdateUserAvatar) error:
 		cUpdateUserAvatarHandler = fn
+	case func(context.Context, *cmd.UpdateUserPasskeyUsage) error:
+		cUpdateUserPasskeyUsageHandler = fn
 	case func(context.Context, *cmd.UploadImage) error:
 		cUploadImageHandler = fn
 	case func(context.Context, *cmd.UploadImageFile) error:
 		cUploadImageFileHandler = fn
 	case func(context.Context, *cmd.UploadImages) error:
 		cUploadImagesHandler = fn
+	case func(context.Context, *cmd.UseOAuthAuthorizationCode) error:
+		cUseOAuthAuthorizationCodeHandler = fn
+	case func(context.Context, *cmd.UseOAuthRefreshToken) error:
+		cUseOAuthRefreshTokenHandler = fn
+	case func(context.Context, *cmd.UseRecoveryCode) error:
+		cUseRecoveryCodeHandler = fn
+	case func(context.Context, *cmd.UseTwoFactorStep) error:
+		cUseTwoFactorStepHandler = fn
 	case func(context.Context, *cmd.UserListCreateCompany) error:
 		cUserListCreateCompanyHandler = fn
 	case func(context.Context, *cmd.UserListHandleRoleChange) error:
@@ -475,10 +709,18 @@ func registerTypedHandler(handler HandlerFunc) {
 		qCountPendingReportsHandler = fn
 	case func(context.Context, *query.CountPostPerStatus) error:
 		qCountPostPerStatusHandler = fn
+	case func(context.Context, *query.CountQueuedEmailsByStatus) error:
+		qCountQueuedEmailsByStatusHandler = fn
+	case func(context.Context, *query.CountRecentEmailAttempts) error:
+		qCountRecentEmailAttemptsHandler = fn
+	case func(context.Context, *query.CountTrustedCaseReporters) error:
+		qCountTrustedCaseReportersHandler = fn
 	case func(context.Context, *query.CountUnreadNotifications) error:
 		qCountUnreadNotificationsHandler = fn
 	case func(context.Context, *query.CountUntaggedPosts) error:
 		qCountUntaggedPostsHandler = fn
+	case func(context.Context, *query.CountUnusedRecoveryCodes) error:
+		qCountUnusedRecoveryCodesHandler = fn
 	case func(context.Context, *query.CountUserReportsToday) error:
 		qCountUserReportsTodayHandler = fn
 	case func(context.Context, *query.CountUsers) error:
@@ -491,6 +733,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		qDeleteWebhookHandler = fn
 	case func(context.Context, *query.FetchRecentSupressions) error:
 		qFetchRecentSupressionsHandler = fn
+	case func(context.Context, *query.GetAPITokenByHash) error:
+		qGetAPITokenByHashHandler = fn
 	case func(context.Context, *query.GetActiveNotifications) error:
 		qGetActiveNotificationsHandler = fn
 	case func(context.Context, *query.GetActiveSubscribers) error:
@@ -509,6 +753,8 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetAllUsersHandler = fn
 	case func(context.Context, *query.GetAllUsersNames) error:
 		qGetAllUsersNamesHandler = fn
+	case func(context.Context, *query.GetAppealByID) error:
+		qGetAppealByIDHandler = fn
 	case func(context.Context, *query.GetArchivablePosts) error:
 		qGetArchivablePostsHandler = fn
 	case func(context.Context, *query.GetAssignedTags) error:
@@ -533,10 +779,22 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetCurrentUserSettingsHandler = fn
 	case func(context.Context, *query.GetCustomOAuthConfigByProvider) error:
 		qGetCustomOAuthConfigByProviderHandler = fn
+	case func(context.Context, *query.GetCustomRoleByID) error:
+		qGetCustomRoleByIDHandler = fn
+	case func(context.Context, *query.GetDueDeferredNotifications) error:
+		qGetDueDeferredNotificationsHandler = fn
+	case func(context.Context, *query.GetDueQueuedEmails) error:
+		qGetDueQueuedEmailsHandler = fn
+	case func(context.Context, *query.GetEmailTemplate) error:
+		qGetEmailTemplateHandler = fn
+	case func(context.Context, *query.GetExpiredUserDataExports) error:
+		qGetExpiredUserDataExportsHandler = fn
 	case func(context.Context, *query.GetFirstTenant) error:
 		qGetFirstTenantHandler = fn
 	case func(context.Context, *query.GetImageFile) error:
 		qGetImageFileHandler = fn
+	case func(context.Context, *query.GetLatestUserDataExport) error:
+		qGetLatestUserDataExportHandler = fn
 	case func(context.Context, *query.GetNameFromBlobKey) error:
 		qGetNameFromBlobKeyHandler = fn
 	case func(context.Context, *query.GetNavigationLinks) error:
@@ -545,10 +803,14 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetNotificationByIDHandler = fn
 	case func(context.Context, *query.GetOAuthAuthorizationURL) error:
 		qGetOAuthAuthorizationURLHandler = fn
+	case func(context.Context, *query.GetOAuthClientByClientID) error:
+		qGetOAuthClientByClientIDHandler = fn
 	case func(context.Context, *query.GetOAuthProfile) error:
 		qGetOAuthProfileHandler = fn
 	case func(context.Context, *query.GetOAuthRawProfile) error:
 		qGetOAuthRawProfileHandler = fn
+	case func(context.Context, *query.GetOIDCConfiguration) error:
+		qGetOIDCConfigurationHandler = fn
 	case func(context.Context, *query.GetPageByID) error:
 		qGetPageByIDHandler = fn
 	case func(context.Context, *query.GetPageBySlug) error:
@@ -565,6 +827,10 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetPageTopicByIDHandler = fn
 	case func(context.Context, *query.GetPageTopics) error:
 		qGetPageTopicsHandler = fn
+	case func(context.Context, *query.GetPendingDigests) error:
+		qGetPendingDigestsHandler = fn
+	case func(context.Context, *query.GetPostByCommentID) error:
+		qGetPostByCommentIDHandler = fn
 	case func(context.Context, *query.GetPostByID) error:
 		qGetPostByIDHandler = fn
 	case func(context.Context, *query.GetPostByNumber) error:
@@ -579,20 +845,32 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetPushSubscriptionsByUserHandler = fn
 	case func(context.Context, *query.GetPushSubscriptionsByUsers) error:
 		qGetPushSubscriptionsByUsersHandler = fn
+	case func(context.Context, *query.GetQuietHours) error:
+		qGetQuietHoursHandler = fn
 	case func(context.Context, *query.GetReportByID) error:
 		qGetReportByIDHandler = fn
+	case func(context.Context, *query.GetReportCaseByID) error:
+		qGetReportCaseByIDHandler = fn
 	case func(context.Context, *query.GetReportReasons) error:
 		qGetReportReasonsHandler = fn
+	case func(context.Context, *query.GetSCIMToken) error:
+		qGetSCIMTokenHandler = fn
+	case func(context.Context, *query.GetSCIMTokenByHash) error:
+		qGetSCIMTokenByHashHandler = fn
 	case func(context.Context, *query.GetSystemSettings) error:
 		qGetSystemSettingsHandler = fn
 	case func(context.Context, *query.GetTagBySlug) error:
 		qGetTagBySlugHandler = fn
+	case func(context.Context, *query.GetTagPreferences) error:
+		qGetTagPreferencesHandler = fn
 	case func(context.Context, *query.GetTenantByDomain) error:
 		qGetTenantByDomainHandler = fn
 	case func(context.Context, *query.GetTenantProfanityWords) error:
 		qGetTenantProfanityWordsHandler = fn
 	case func(context.Context, *query.GetTrialingTenantContacts) error:
 		qGetTrialingTenantContactsHandler = fn
+	case func(context.Context, *query.GetUserAppeals) error:
+		qGetUserAppealsHandler = fn
 	case func(context.Context, *query.GetUserByAPIKey) error:
 		qGetUserByAPIKeyHandler = fn
 	case func(context.Context, *query.GetUserByEmail) error:
@@ -603,14 +881,34 @@ func registerTypedHandler(handler HandlerFunc) {
 		qGetUserByProviderHandler = fn
 	case func(context.Context, *query.GetUserCommentCount) error:
 		qGetUserCommentCountHandler = fn
+	case func(context.Context, *query.GetUserCustomRole) error:
+		qGetUserCustomRoleHandler = fn
+	case func(context.Context, *query.GetUserDataExportByID) error:
+		qGetUserDataExportByIDHandler = fn
+	case func(context.Context, *query.GetUserMergeRequestByID) error:
+		qGetUserMergeRequestByIDHandler = fn
+	case func(context.Context, *query.GetUserPasskeyByCredentialID) error:
+		qGetUserPasskeyByCredentialIDHandler = fn
+	case func(context.Context, *query.GetUserPermissions) error:
+		qGetUserPermissionsHandler = fn
 	case func(context.Context, *query.GetUserPostCount) error:
 		qGetUserPostCountHandler = fn
 	case func(context.Context, *query.GetUserProfileStanding) error:
 		qGetUserProfileStandingHandler = fn
 	case func(context.Context, *query.GetUserProfileStats) error:
 		qGetUserProfileStatsHandler = fn
+	case func(context.Context, *query.GetUserReportAccuracy) error:
+		qGetUserReportAccuracyHandler = fn
 	case func(context.Context, *query.GetUserReportedItemsOnPost) error:
 		qGetUserReportedItemsOnPostHandler = fn
+	case func(context.Context, *query.GetUserSanctionHistory) error:
+		qGetUserSanctionHistoryHandler = fn
+	case func(context.Context, *query.GetUserSession) error:
+		qGetUserSessionHandler = fn
+	case func(context.Context, *query.GetUserSessionsRevokedAt) error:
+		qGetUserSessionsRevokedAtHandler = fn
+	case func(context.Context, *query.GetUserTwoFactor) error:
+		qGetUserTwoFactorHandler = fn
 	case func(context.Context, *query.GetUsersByIDs) error:
 		qGetUsersByIDsHandler = fn
 	case func(context.Context, *query.GetUsersToNotify) error:
@@ -627,8 +925,12 @@ func registerTypedHandler(handler HandlerFunc) {
 		qIsCNAMEAvailableHandler = fn
 	case func(context.Context, *query.IsImageFileInUse) error:
 		qIsImageFileInUseHandler = fn
+	case func(context.Context, *query.IsSanctionAppealable) error:
+		qIsSanctionAppealableHandler = fn
 	case func(context.Context, *query.IsSubdomainAvailable) error:
 		qIsSubdomainAvailableHandler = fn
+	case func(context.Context, *query.ListAPITokens) error:
+		qListAPITokensHandler = fn
 	case func(context.Context, *query.ListActiveOAuthProviders) error:
 		qListActiveOAuthProvidersHandler = fn
 	case func(context.Context, *query.ListActiveWebhooksByType) error:
@@ -641,26 +943,48 @@ func registerTypedHandler(handler HandlerFunc) {
 		qListAllWebhooksHandler = fn
 	case func(context.Context, *query.ListAllWebhooksByType) error:
 		qListAllWebhooksByTypeHandler = fn
+	case func(context.Context, *query.ListAppeals) error:
+		qListAppealsHandler = fn
 	case func(context.Context, *query.ListBlobs) error:
 		qListBlobsHandler = fn
 	case func(context.Context, *query.ListCannedResponses) error:
 		qListCannedResponsesHandler = fn
 	case func(context.Context, *query.ListCustomOAuthConfig) error:
 		qListCustomOAuthConfigHandler = fn
+	case func(context.Context, *query.ListCustomRoleMembers) error:
+		qListCustomRoleMembersHandler = fn
+	case func(context.Context, *query.ListCustomRoles) error:
+		qListCustomRolesHandler = fn
+	case func(context.Context, *query.ListEmailTemplates) error:
+		qListEmailTemplatesHandler = fn
 	case func(context.Context, *query.ListImageFiles) error:
 		qListImageFilesHandler = fn
+	case func(context.Context, *query.ListOAuthClients) error:
+		qListOAuthClientsHandler = fn
 	case func(context.Context, *query.ListPages) error:
 		qListPagesHandler = fn
 	case func(context.Context, *query.ListPostVotes) error:
 		qListPostVotesHandler = fn
+	case func(context.Context, *query.ListQueuedEmails) error:
+		qListQueuedEmailsHandler = fn
+	case func(context.Context, *query.ListReportCases) error:
+		qListReportCasesHandler = fn
 	case func(context.Context, *query.ListReports) error:
 		qListReportsHandler = fn
+	case func(context.Context, *query.ListUserMergeRequests) error:
+		qListUserMergeRequestsHandler = fn
+	case func(context.Context, *query.ListUserPasskeys) error:
+		qListUserPasskeysHandler = fn
+	case func(context.Context, *query.ListUserSessions) error:
+		qListUserSessionsHandler = fn
 	case func(context.Context, *query.MarkWebhookAsFailed) error:
 		qMarkWebhookAsFailedHandler = fn
 	case func(context.Context, *query.PostIsReferenced) error:
 		qPostIsReferencedHandler = fn
 	case func(context.Context, *query.SearchPosts) error:
 		qSearchPostsHandler = fn
+	case func(context.Context, *query.SearchSCIMUsers) error:
+		qSearchSCIMUsersHandler = fn
 	case func(context.Context, *query.SearchUserContent) error:
 		qSearchUserContentHandler = fn
 	case func(context.Context, *query.UserSubscribedTo) error:
@@ -712,6 +1036,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.AddSubscriber")
 		}
 		return cAddSubscriberHandler(ctx, m)
+	case *cmd.AddUserPasskey:
+		if cAddUserPasskeyHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.AddUserPasskey")
+		}
+		return cAddUserPasskeyHandler(ctx, m)
 	case *cmd.AddVote:
 		if cAddVoteHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.AddVote")
@@ -762,11 +1091,51 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.ChangeUserVisualRole")
 		}
 		return cChangeUserVisualRoleHandler(ctx, m)
+	case *cmd.ClearDigestItems:
+		if cClearDigestItemsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.ClearDigestItems")
+		}
+		return cClearDigestItemsHandler(ctx, m)
+	case *cmd.CompleteUserDataExport:
+		if cCompleteUserDataExportHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CompleteUserDataExport")
+		}
+		return cCompleteUserDataExportHandler(ctx, m)
+	case *cmd.CreateAPIToken:
+		if cCreateAPITokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateAPIToken")
+		}
+		return cCreateAPITokenHandler(ctx, m)
+	case *cmd.CreateAppeal:
+		if cCreateAppealHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateAppeal")
+		}
+		return cCreateAppealHandler(ctx, m)
 	case *cmd.CreateCannedResponse:
 		if cCreateCannedResponseHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.CreateCannedResponse")
 		}
 		return cCreateCannedResponseHandler(ctx, m)
+	case *cmd.CreateCustomRole:
+		if cCreateCustomRoleHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateCustomRole")
+		}
+		return cCreateCustomRoleHandler(ctx, m)
+	case *cmd.CreateOAuthAuthorizationCode:
+		if cCreateOAuthAuthorizationCodeHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateOAuthAuthorizationCode")
+		}
+		return cCreateOAuthAuthorizationCodeHandler(ctx, m)
+	case *cmd.CreateOAuthClient:
+		if cCreateOAuthClientHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateOAuthClient")
+		}
+		return cCreateOAuthClientHandler(ctx, m)
+	case *cmd.CreateOAuthRefreshToken:
+		if cCreateOAuthRefreshTokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateOAuthRefreshToken")
+		}
+		return cCreateOAuthRefreshTokenHandler(ctx, m)
 	case *cmd.CreatePage:
 		if cCreatePageHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.CreatePage")
@@ -797,6 +1166,41 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.CreateTenant")
 		}
 		return cCreateTenantHandler(ctx, m)
+	case *cmd.CreateUserDataExport:
+		if cCreateUserDataExportHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateUserDataExport")
+		}
+		return cCreateUserDataExportHandler(ctx, m)
+	case *cmd.CreateUserMergeRequest:
+		if cCreateUserMergeRequestHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateUserMergeRequest")
+		}
+		return cCreateUserMergeRequestHandler(ctx, m)
+	case *cmd.CreateUserSession:
+		if cCreateUserSessionHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.CreateUserSession")
+		}
+		return cCreateUserSessionHandler(ctx, m)
+	case *cmd.DecideAppeal:
+		if cDecideAppealHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DecideAppeal")
+		}
+		return cDecideAppealHandler(ctx, m)
+	case *cmd.DecideUserMergeRequest:
+		if cDecideUserMergeRequestHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DecideUserMergeRequest")
+		}
+		return cDecideUserMergeRequestHandler(ctx, m)
+	case *cmd.DeferNotification:
+		if cDeferNotificationHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeferNotification")
+		}
+		return cDeferNotificationHandler(ctx, m)
+	case *cmd.DeleteAPIToken:
+		if cDeleteAPITokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteAPIToken")
+		}
+		return cDeleteAPITokenHandler(ctx, m)
 	case *cmd.DeleteAllPushSubscriptions:
 		if cDeleteAllPushSubscriptionsHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.DeleteAllPushSubscriptions")
@@ -822,6 +1226,21 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.DeleteCurrentUser")
 		}
 		return cDeleteCurrentUserHandler(ctx, m)
+	case *cmd.DeleteCustomRole:
+		if cDeleteCustomRoleHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteCustomRole")
+		}
+		return cDeleteCustomRoleHandler(ctx, m)
+	case *cmd.DeleteDeferredNotifications:
+		if cDeleteDeferredNotificationsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteDeferredNotifications")
+		}
+		return cDeleteDeferredNotificationsHandler(ctx, m)
+	case *cmd.DeleteEmailTemplate:
+		if cDeleteEmailTemplateHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteEmailTemplate")
+		}
+		return cDeleteEmailTemplateHandler(ctx, m)
 	case *cmd.DeleteImageFile:
 		if cDeleteImageFileHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.DeleteImageFile")
@@ -837,6 +1256,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.DeleteMute")
 		}
 		return cDeleteMuteHandler(ctx, m)
+	case *cmd.DeleteOAuthClient:
+		if cDeleteOAuthClientHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteOAuthClient")
+		}
+		return cDeleteOAuthClientHandler(ctx, m)
 	case *cmd.DeletePage:
 		if cDeletePageHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.DeletePage")
@@ -872,16 +1296,51 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.DeleteReportReason")
 		}
 		return cDeleteReportReasonHandler(ctx, m)
+	case *cmd.DeleteSCIMToken:
+		if cDeleteSCIMTokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteSCIMToken")
+		}
+		return cDeleteSCIMTokenHandler(ctx, m)
 	case *cmd.DeleteTag:
 		if cDeleteTagHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.DeleteTag")
 		}
 		return cDeleteTagHandler(ctx, m)
+	case *cmd.DeleteUserDataExports:
+		if cDeleteUserDataExportsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteUserDataExports")
+		}
+		return cDeleteUserDataExportsHandler(ctx, m)
+	case *cmd.DeleteUserPasskey:
+		if cDeleteUserPasskeyHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeleteUserPasskey")
+		}
+		return cDeleteUserPasskeyHandler(ctx, m)
 	case *cmd.DeleteWarning:
 		if cDeleteWarningHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.DeleteWarning")
 		}
 		return cDeleteWarningHandler(ctx, m)
+	case *cmd.DeliverMail:
+		if cDeliverMailHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DeliverMail")
+		}
+		return cDeliverMailHandler(ctx, m)
+	case *cmd.DisableUserTwoFactor:
+		if cDisableUserTwoFactorHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DisableUserTwoFactor")
+		}
+		return cDisableUserTwoFactorHandler(ctx, m)
+	case *cmd.DismissUserBlockProposal:
+		if cDismissUserBlockProposalHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.DismissUserBlockProposal")
+		}
+		return cDismissUserBlockProposalHandler(ctx, m)
+	case *cmd.EnableUserTwoFactor:
+		if cEnableUserTwoFactorHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.EnableUserTwoFactor")
+		}
+		return cEnableUserTwoFactorHandler(ctx, m)
 	case *cmd.ExpireMute:
 		if cExpireMuteHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.ExpireMute")
@@ -907,6 +1366,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.HTTPRequest")
 		}
 		return cHTTPRequestHandler(ctx, m)
+	case *cmd.LiftRestriction:
+		if cLiftRestrictionHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.LiftRestriction")
+		}
+		return cLiftRestrictionHandler(ctx, m)
 	case *cmd.LockExpiredTenants:
 		if cLockExpiredTenantsHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.LockExpiredTenants")
@@ -932,6 +1396,21 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.MarkPostAsDuplicate")
 		}
 		return cMarkPostAsDuplicateHandler(ctx, m)
+	case *cmd.MarkReportCaseAutoHidden:
+		if cMarkReportCaseAutoHiddenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.MarkReportCaseAutoHidden")
+		}
+		return cMarkReportCaseAutoHiddenHandler(ctx, m)
+	case *cmd.MoveUserProvider:
+		if cMoveUserProviderHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.MoveUserProvider")
+		}
+		return cMoveUserProviderHandler(ctx, m)
+	case *cmd.MuteSubscriber:
+		if cMuteSubscriberHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.MuteSubscriber")
+		}
+		return cMuteSubscriberHandler(ctx, m)
 	case *cmd.MuteUser:
 		if cMuteUserHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.MuteUser")
@@ -947,6 +1426,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.PreviewWebhook")
 		}
 		return cPreviewWebhookHandler(ctx, m)
+	case *cmd.ProposeUserBlock:
+		if cProposeUserBlockHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.ProposeUserBlock")
+		}
+		return cProposeUserBlockHandler(ctx, m)
 	case *cmd.PublishScheduledPages:
 		if cPublishScheduledPagesHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.PublishScheduledPages")
@@ -957,11 +1441,46 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.PurgeExpiredNotifications")
 		}
 		return cPurgeExpiredNotificationsHandler(ctx, m)
+	case *cmd.PurgeExpiredOAuthGrants:
+		if cPurgeExpiredOAuthGrantsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.PurgeExpiredOAuthGrants")
+		}
+		return cPurgeExpiredOAuthGrantsHandler(ctx, m)
+	case *cmd.PurgeExpiredUserSessions:
+		if cPurgeExpiredUserSessionsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.PurgeExpiredUserSessions")
+		}
+		return cPurgeExpiredUserSessionsHandler(ctx, m)
+	case *cmd.PurgeQueuedEmails:
+		if cPurgeQueuedEmailsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.PurgeQueuedEmails")
+		}
+		return cPurgeQueuedEmailsHandler(ctx, m)
 	case *cmd.PurgeReadNotifications:
 		if cPurgeReadNotificationsHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.PurgeReadNotifications")
 		}
 		return cPurgeReadNotificationsHandler(ctx, m)
+	case *cmd.QueueDigestItems:
+		if cQueueDigestItemsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.QueueDigestItems")
+		}
+		return cQueueDigestItemsHandler(ctx, m)
+	case *cmd.QueueMail:
+		if cQueueMailHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.QueueMail")
+		}
+		return cQueueMailHandler(ctx, m)
+	case *cmd.RecordPushDelivery:
+		if cRecordPushDeliveryHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RecordPushDelivery")
+		}
+		return cRecordPushDeliveryHandler(ctx, m)
+	case *cmd.RecordTwoFactorFailure:
+		if cRecordTwoFactorFailureHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RecordTwoFactorFailure")
+		}
+		return cRecordTwoFactorFailureHandler(ctx, m)
 	case *cmd.RefreshPageEmbeddedData:
 		if cRefreshPageEmbeddedDataHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.RefreshPageEmbeddedData")
@@ -1007,16 +1526,51 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.ReorderReportReasons")
 		}
 		return cReorderReportReasonsHandler(ctx, m)
+	case *cmd.ReplaceUserRecoveryCodes:
+		if cReplaceUserRecoveryCodesHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.ReplaceUserRecoveryCodes")
+		}
+		return cReplaceUserRecoveryCodesHandler(ctx, m)
 	case *cmd.ResolveReport:
 		if cResolveReportHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.ResolveReport")
 		}
 		return cResolveReportHandler(ctx, m)
+	case *cmd.ResolveReportCase:
+		if cResolveReportCaseHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.ResolveReportCase")
+		}
+		return cResolveReportCaseHandler(ctx, m)
+	case *cmd.RestrictUser:
+		if cRestrictUserHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RestrictUser")
+		}
+		return cRestrictUserHandler(ctx, m)
+	case *cmd.RetryQueuedEmail:
+		if cRetryQueuedEmailHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RetryQueuedEmail")
+		}
+		return cRetryQueuedEmailHandler(ctx, m)
+	case *cmd.RevokeUserSession:
+		if cRevokeUserSessionHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RevokeUserSession")
+		}
+		return cRevokeUserSessionHandler(ctx, m)
+	case *cmd.RevokeUserSessions:
+		if cRevokeUserSessionsHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.RevokeUserSessions")
+		}
+		return cRevokeUserSessionsHandler(ctx, m)
 	case *cmd.SaveCustomOAuthConfig:
 		if cSaveCustomOAuthConfigHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.SaveCustomOAuthConfig")
 		}
 		return cSaveCustomOAuthConfigHandler(ctx, m)
+	case *cmd.SaveEmailTemplate:
+		if cSaveEmailTemplateHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SaveEmailTemplate")
+		}
+		return cSaveEmailTemplateHandler(ctx, m)
 	case *cmd.SaveNavigationLinks:
 		if cSaveNavigationLinksHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.SaveNavigationLinks")
@@ -1032,6 +1586,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.SavePushSubscription")
 		}
 		return cSavePushSubscriptionHandler(ctx, m)
+	case *cmd.SaveUserTwoFactorSecret:
+		if cSaveUserTwoFactorSecretHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SaveUserTwoFactorSecret")
+		}
+		return cSaveUserTwoFactorSecretHandler(ctx, m)
 	case *cmd.SaveVerificationKey:
 		if cSaveVerificationKeyHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.SaveVerificationKey")
@@ -1057,11 +1616,31 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.SetPostResponse")
 		}
 		return cSetPostResponseHandler(ctx, m)
+	case *cmd.SetSCIMExternalID:
+		if cSetSCIMExternalIDHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SetSCIMExternalID")
+		}
+		return cSetSCIMExternalIDHandler(ctx, m)
+	case *cmd.SetSCIMToken:
+		if cSetSCIMTokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SetSCIMToken")
+		}
+		return cSetSCIMTokenHandler(ctx, m)
 	case *cmd.SetSystemSettings:
 		if cSetSystemSettingsHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.SetSystemSettings")
 		}
 		return cSetSystemSettingsHandler(ctx, m)
+	case *cmd.SetTagPreference:
+		if cSetTagPreferenceHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SetTagPreference")
+		}
+		return cSetTagPreferenceHandler(ctx, m)
+	case *cmd.SetUserCustomRole:
+		if cSetUserCustomRoleHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.SetUserCustomRole")
+		}
+		return cSetUserCustomRoleHandler(ctx, m)
 	case *cmd.StoreBlob:
 		if cStoreBlobHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.StoreBlob")
@@ -1097,6 +1676,21 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.TogglePageSubscription")
 		}
 		return cTogglePageSubscriptionHandler(ctx, m)
+	case *cmd.TouchAPIToken:
+		if cTouchAPITokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.TouchAPIToken")
+		}
+		return cTouchAPITokenHandler(ctx, m)
+	case *cmd.TouchSCIMToken:
+		if cTouchSCIMTokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.TouchSCIMToken")
+		}
+		return cTouchSCIMTokenHandler(ctx, m)
+	case *cmd.TouchUserSession:
+		if cTouchUserSessionHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.TouchUserSession")
+		}
+		return cTouchUserSessionHandler(ctx, m)
 	case *cmd.TriggerWebhooks:
 		if cTriggerWebhooksHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.TriggerWebhooks")
@@ -1127,6 +1721,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UnlockPost")
 		}
 		return cUnlockPostHandler(ctx, m)
+	case *cmd.UnregisterUserProvider:
+		if cUnregisterUserProviderHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UnregisterUserProvider")
+		}
+		return cUnregisterUserProviderHandler(ctx, m)
 	case *cmd.UpdateCannedResponse:
 		if cUpdateCannedResponseHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UpdateCannedResponse")
@@ -1152,6 +1751,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UpdateCurrentUserSettings")
 		}
 		return cUpdateCurrentUserSettingsHandler(ctx, m)
+	case *cmd.UpdateCustomRole:
+		if cUpdateCustomRoleHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UpdateCustomRole")
+		}
+		return cUpdateCustomRoleHandler(ctx, m)
 	case *cmd.UpdateImageFileReferences:
 		if cUpdateImageFileReferencesHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UpdateImageFileReferences")
@@ -1182,6 +1786,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UpdatePost")
 		}
 		return cUpdatePostHandler(ctx, m)
+	case *cmd.UpdateQueuedEmail:
+		if cUpdateQueuedEmailHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UpdateQueuedEmail")
+		}
+		return cUpdateQueuedEmailHandler(ctx, m)
 	case *cmd.UpdateReportReason:
 		if cUpdateReportReasonHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UpdateReportReason")
@@ -1212,6 +1821,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UpdateTenantSettings")
 		}
 		return cUpdateTenantSettingsHandler(ctx, m)
+	case *cmd.UpdateTenantTwoFactorPolicy:
+		if cUpdateTenantTwoFactorPolicyHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UpdateTenantTwoFactorPolicy")
+		}
+		return cUpdateTenantTwoFactorPolicyHandler(ctx, m)
 	case *cmd.UpdateUser:
 		if cUpdateUserHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UpdateUser")
@@ -1222,6 +1836,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UpdateUserAvatar")
 		}
 		return cUpdateUserAvatarHandler(ctx, m)
+	case *cmd.UpdateUserPasskeyUsage:
+		if cUpdateUserPasskeyUsageHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UpdateUserPasskeyUsage")
+		}
+		return cUpdateUserPasskeyUsageHandler(ctx, m)
 	case *cmd.UploadImage:
 		if cUploadImageHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UploadImage")
@@ -1237,6 +1856,26 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: cmd.UploadImages")
 		}
 		return cUploadImagesHandler(ctx, m)
+	case *cmd.UseOAuthAuthorizationCode:
+		if cUseOAuthAuthorizationCodeHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UseOAuthAuthorizationCode")
+		}
+		return cUseOAuthAuthorizationCodeHandler(ctx, m)
+	case *cmd.UseOAuthRefreshToken:
+		if cUseOAuthRefreshTokenHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UseOAuthRefreshToken")
+		}
+		return cUseOAuthRefreshTokenHandler(ctx, m)
+	case *cmd.UseRecoveryCode:
+		if cUseRecoveryCodeHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UseRecoveryCode")
+		}
+		return cUseRecoveryCodeHandler(ctx, m)
+	case *cmd.UseTwoFactorStep:
+		if cUseTwoFactorStepHandler == nil {
+			return fmt.Errorf("handler not registered: cmd.UseTwoFactorStep")
+		}
+		return cUseTwoFactorStepHandler(ctx, m)
 	case *cmd.UserListCreateCompany:
 		if cUserListCreateCompanyHandler == nil {
 			return fmt.Errorf("handler not registered: cmd.UserListCreateCompany")
@@ -1272,6 +1911,21 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.CountPostPerStatus")
 		}
 		return qCountPostPerStatusHandler(ctx, m)
+	case *query.CountQueuedEmailsByStatus:
+		if qCountQueuedEmailsByStatusHandler == nil {
+			return fmt.Errorf("handler not registered: query.CountQueuedEmailsByStatus")
+		}
+		return qCountQueuedEmailsByStatusHandler(ctx, m)
+	case *query.CountRecentEmailAttempts:
+		if qCountRecentEmailAttemptsHandler == nil {
+			return fmt.Errorf("handler not registered: query.CountRecentEmailAttempts")
+		}
+		return qCountRecentEmailAttemptsHandler(ctx, m)
+	case *query.CountTrustedCaseReporters:
+		if qCountTrustedCaseReportersHandler == nil {
+			return fmt.Errorf("handler not registered: query.CountTrustedCaseReporters")
+		}
+		return qCountTrustedCaseReportersHandler(ctx, m)
 	case *query.CountUnreadNotifications:
 		if qCountUnreadNotificationsHandler == nil {
 			return fmt.Errorf("handler not registered: query.CountUnreadNotifications")
@@ -1282,6 +1936,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.CountUntaggedPosts")
 		}
 		return qCountUntaggedPostsHandler(ctx, m)
+	case *query.CountUnusedRecoveryCodes:
+		if qCountUnusedRecoveryCodesHandler == nil {
+			return fmt.Errorf("handler not registered: query.CountUnusedRecoveryCodes")
+		}
+		return qCountUnusedRecoveryCodesHandler(ctx, m)
 	case *query.CountUserReportsToday:
 		if qCountUserReportsTodayHandler == nil {
 			return fmt.Errorf("handler not registered: query.CountUserReportsToday")
@@ -1312,6 +1971,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.FetchRecentSupressions")
 		}
 		return qFetchRecentSupressionsHandler(ctx, m)
+	case *query.GetAPITokenByHash:
+		if qGetAPITokenByHashHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetAPITokenByHash")
+		}
+		return qGetAPITokenByHashHandler(ctx, m)
 	case *query.GetActiveNotifications:
 		if qGetActiveNotificationsHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetActiveNotifications")
@@ -1357,6 +2021,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetAllUsersNames")
 		}
 		return qGetAllUsersNamesHandler(ctx, m)
+	case *query.GetAppealByID:
+		if qGetAppealByIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetAppealByID")
+		}
+		return qGetAppealByIDHandler(ctx, m)
 	case *query.GetArchivablePosts:
 		if qGetArchivablePostsHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetArchivablePosts")
@@ -1417,6 +2086,31 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetCustomOAuthConfigByProvider")
 		}
 		return qGetCustomOAuthConfigByProviderHandler(ctx, m)
+	case *query.GetCustomRoleByID:
+		if qGetCustomRoleByIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetCustomRoleByID")
+		}
+		return qGetCustomRoleByIDHandler(ctx, m)
+	case *query.GetDueDeferredNotifications:
+		if qGetDueDeferredNotificationsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetDueDeferredNotifications")
+		}
+		return qGetDueDeferredNotificationsHandler(ctx, m)
+	case *query.GetDueQueuedEmails:
+		if qGetDueQueuedEmailsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetDueQueuedEmails")
+		}
+		return qGetDueQueuedEmailsHandler(ctx, m)
+	case *query.GetEmailTemplate:
+		if qGetEmailTemplateHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetEmailTemplate")
+		}
+		return qGetEmailTemplateHandler(ctx, m)
+	case *query.GetExpiredUserDataExports:
+		if qGetExpiredUserDataExportsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetExpiredUserDataExports")
+		}
+		return qGetExpiredUserDataExportsHandler(ctx, m)
 	case *query.GetFirstTenant:
 		if qGetFirstTenantHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetFirstTenant")
@@ -1427,6 +2121,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetImageFile")
 		}
 		return qGetImageFileHandler(ctx, m)
+	case *query.GetLatestUserDataExport:
+		if qGetLatestUserDataExportHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetLatestUserDataExport")
+		}
+		return qGetLatestUserDataExportHandler(ctx, m)
 	case *query.GetNameFromBlobKey:
 		if qGetNameFromBlobKeyHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetNameFromBlobKey")
@@ -1447,6 +2146,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetOAuthAuthorizationURL")
 		}
 		return qGetOAuthAuthorizationURLHandler(ctx, m)
+	case *query.GetOAuthClientByClientID:
+		if qGetOAuthClientByClientIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetOAuthClientByClientID")
+		}
+		return qGetOAuthClientByClientIDHandler(ctx, m)
 	case *query.GetOAuthProfile:
 		if qGetOAuthProfileHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetOAuthProfile")
@@ -1457,6 +2161,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetOAuthRawProfile")
 		}
 		return qGetOAuthRawProfileHandler(ctx, m)
+	case *query.GetOIDCConfiguration:
+		if qGetOIDCConfigurationHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetOIDCConfiguration")
+		}
+		return qGetOIDCConfigurationHandler(ctx, m)
 	case *query.GetPageByID:
 		if qGetPageByIDHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetPageByID")
@@ -1497,6 +2206,16 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetPageTopics")
 		}
 		return qGetPageTopicsHandler(ctx, m)
+	case *query.GetPendingDigests:
+		if qGetPendingDigestsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetPendingDigests")
+		}
+		return qGetPendingDigestsHandler(ctx, m)
+	case *query.GetPostByCommentID:
+		if qGetPostByCommentIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetPostByCommentID")
+		}
+		return qGetPostByCommentIDHandler(ctx, m)
 	case *query.GetPostByID:
 		if qGetPostByIDHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetPostByID")
@@ -1532,16 +2251,36 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetPushSubscriptionsByUsers")
 		}
 		return qGetPushSubscriptionsByUsersHandler(ctx, m)
+	case *query.GetQuietHours:
+		if qGetQuietHoursHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetQuietHours")
+		}
+		return qGetQuietHoursHandler(ctx, m)
 	case *query.GetReportByID:
 		if qGetReportByIDHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetReportByID")
 		}
 		return qGetReportByIDHandler(ctx, m)
+	case *query.GetReportCaseByID:
+		if qGetReportCaseByIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetReportCaseByID")
+		}
+		return qGetReportCaseByIDHandler(ctx, m)
 	case *query.GetReportReasons:
 		if qGetReportReasonsHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetReportReasons")
 		}
 		return qGetReportReasonsHandler(ctx, m)
+	case *query.GetSCIMToken:
+		if qGetSCIMTokenHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetSCIMToken")
+		}
+		return qGetSCIMTokenHandler(ctx, m)
+	case *query.GetSCIMTokenByHash:
+		if qGetSCIMTokenByHashHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetSCIMTokenByHash")
+		}
+		return qGetSCIMTokenByHashHandler(ctx, m)
 	case *query.GetSystemSettings:
 		if qGetSystemSettingsHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetSystemSettings")
@@ -1552,6 +2291,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetTagBySlug")
 		}
 		return qGetTagBySlugHandler(ctx, m)
+	case *query.GetTagPreferences:
+		if qGetTagPreferencesHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetTagPreferences")
+		}
+		return qGetTagPreferencesHandler(ctx, m)
 	case *query.GetTenantByDomain:
 		if qGetTenantByDomainHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetTenantByDomain")
@@ -1567,6 +2311,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetTrialingTenantContacts")
 		}
 		return qGetTrialingTenantContactsHandler(ctx, m)
+	case *query.GetUserAppeals:
+		if qGetUserAppealsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserAppeals")
+		}
+		return qGetUserAppealsHandler(ctx, m)
 	case *query.GetUserByAPIKey:
 		if qGetUserByAPIKeyHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetUserByAPIKey")
@@ -1592,6 +2341,31 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetUserCommentCount")
 		}
 		return qGetUserCommentCountHandler(ctx, m)
+	case *query.GetUserCustomRole:
+		if qGetUserCustomRoleHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserCustomRole")
+		}
+		return qGetUserCustomRoleHandler(ctx, m)
+	case *query.GetUserDataExportByID:
+		if qGetUserDataExportByIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserDataExportByID")
+		}
+		return qGetUserDataExportByIDHandler(ctx, m)
+	case *query.GetUserMergeRequestByID:
+		if qGetUserMergeRequestByIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserMergeRequestByID")
+		}
+		return qGetUserMergeRequestByIDHandler(ctx, m)
+	case *query.GetUserPasskeyByCredentialID:
+		if qGetUserPasskeyByCredentialIDHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserPasskeyByCredentialID")
+		}
+		return qGetUserPasskeyByCredentialIDHandler(ctx, m)
+	case *query.GetUserPermissions:
+		if qGetUserPermissionsHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserPermissions")
+		}
+		return qGetUserPermissionsHandler(ctx, m)
 	case *query.GetUserPostCount:
 		if qGetUserPostCountHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetUserPostCount")
@@ -1607,11 +2381,36 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.GetUserProfileStats")
 		}
 		return qGetUserProfileStatsHandler(ctx, m)
+	case *query.GetUserReportAccuracy:
+		if qGetUserReportAccuracyHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserReportAccuracy")
+		}
+		return qGetUserReportAccuracyHandler(ctx, m)
 	case *query.GetUserReportedItemsOnPost:
 		if qGetUserReportedItemsOnPostHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetUserReportedItemsOnPost")
 		}
 		return qGetUserReportedItemsOnPostHandler(ctx, m)
+	case *query.GetUserSanctionHistory:
+		if qGetUserSanctionHistoryHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserSanctionHistory")
+		}
+		return qGetUserSanctionHistoryHandler(ctx, m)
+	case *query.GetUserSession:
+		if qGetUserSessionHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserSession")
+		}
+		return qGetUserSessionHandler(ctx, m)
+	case *query.GetUserSessionsRevokedAt:
+		if qGetUserSessionsRevokedAtHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserSessionsRevokedAt")
+		}
+		return qGetUserSessionsRevokedAtHandler(ctx, m)
+	case *query.GetUserTwoFactor:
+		if qGetUserTwoFactorHandler == nil {
+			return fmt.Errorf("handler not registered: query.GetUserTwoFactor")
+		}
+		return qGetUserTwoFactorHandler(ctx, m)
 	case *query.GetUsersByIDs:
 		if qGetUsersByIDsHandler == nil {
 			return fmt.Errorf("handler not registered: query.GetUsersByIDs")
@@ -1652,11 +2451,21 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.IsImageFileInUse")
 		}
 		return qIsImageFileInUseHandler(ctx, m)
+	case *query.IsSanctionAppealable:
+		if qIsSanctionAppealableHandler == nil {
+			return fmt.Errorf("handler not registered: query.IsSanctionAppealable")
+		}
+		return qIsSanctionAppealableHandler(ctx, m)
 	case *query.IsSubdomainAvailable:
 		if qIsSubdomainAvailableHandler == nil {
 			return fmt.Errorf("handler not registered: query.IsSubdomainAvailable")
 		}
 		return qIsSubdomainAvailableHandler(ctx, m)
+	case *query.ListAPITokens:
+		if qListAPITokensHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListAPITokens")
+		}
+		return qListAPITokensHandler(ctx, m)
 	case *query.ListActiveOAuthProviders:
 		if qListActiveOAuthProvidersHandler == nil {
 			return fmt.Errorf("handler not registered: query.ListActiveOAuthProviders")
@@ -1687,6 +2496,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.ListAllWebhooksByType")
 		}
 		return qListAllWebhooksByTypeHandler(ctx, m)
+	case *query.ListAppeals:
+		if qListAppealsHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListAppeals")
+		}
+		return qListAppealsHandler(ctx, m)
 	case *query.ListBlobs:
 		if qListBlobsHandler == nil {
 			return fmt.Errorf("handler not registered: query.ListBlobs")
@@ -1702,11 +2516,31 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.ListCustomOAuthConfig")
 		}
 		return qListCustomOAuthConfigHandler(ctx, m)
+	case *query.ListCustomRoleMembers:
+		if qListCustomRoleMembersHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListCustomRoleMembers")
+		}
+		return qListCustomRoleMembersHandler(ctx, m)
+	case *query.ListCustomRoles:
+		if qListCustomRolesHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListCustomRoles")
+		}
+		return qListCustomRolesHandler(ctx, m)
+	case *query.ListEmailTemplates:
+		if qListEmailTemplatesHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListEmailTemplates")
+		}
+		return qListEmailTemplatesHandler(ctx, m)
 	case *query.ListImageFiles:
 		if qListImageFilesHandler == nil {
 			return fmt.Errorf("handler not registered: query.ListImageFiles")
 		}
 		return qListImageFilesHandler(ctx, m)
+	case *query.ListOAuthClients:
+		if qListOAuthClientsHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListOAuthClients")
+		}
+		return qListOAuthClientsHandler(ctx, m)
 	case *query.ListPages:
 		if qListPagesHandler == nil {
 			return fmt.Errorf("handler not registered: query.ListPages")
@@ -1717,11 +2551,36 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.ListPostVotes")
 		}
 		return qListPostVotesHandler(ctx, m)
+	case *query.ListQueuedEmails:
+		if qListQueuedEmailsHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListQueuedEmails")
+		}
+		return qListQueuedEmailsHandler(ctx, m)
+	case *query.ListReportCases:
+		if qListReportCasesHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListReportCases")
+		}
+		return qListReportCasesHandler(ctx, m)
 	case *query.ListReports:
 		if qListReportsHandler == nil {
 			return fmt.Errorf("handler not registered: query.ListReports")
 		}
 		return qListReportsHandler(ctx, m)
+	case *query.ListUserMergeRequests:
+		if qListUserMergeRequestsHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListUserMergeRequests")
+		}
+		return qListUserMergeRequestsHandler(ctx, m)
+	case *query.ListUserPasskeys:
+		if qListUserPasskeysHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListUserPasskeys")
+		}
+		return qListUserPasskeysHandler(ctx, m)
+	case *query.ListUserSessions:
+		if qListUserSessionsHandler == nil {
+			return fmt.Errorf("handler not registered: query.ListUserSessions")
+		}
+		return qListUserSessionsHandler(ctx, m)
 	case *query.MarkWebhookAsFailed:
 		if qMarkWebhookAsFailedHandler == nil {
 			return fmt.Errorf("handler not registered: query.MarkWebhookAsFailed")
@@ -1737,6 +2596,11 @@ func dispatchTyped(ctx context.Context, msg Msg) error {
 			return fmt.Errorf("handler not registered: query.SearchPosts")
 		}
 		return qSearchPostsHandler(ctx, m)
+	case *query.SearchSCIMUsers:
+		if qSearchSCIMUsersHandler == nil {
+			return fmt.Errorf("handler not registered: query.SearchSCIMUsers")
+		}
+		return qSearchSCIMUsersHandler(ctx, m)
 	case *query.SearchUserContent:
 		if qSearchUserContentHandler == nil {
 			return fmt.Errorf("handler not registered: query.SearchUserContent")
@@ -102,15 +102,25 @@ type config struct {
 		Allowlist                 string `env:"EMAIL_ALLOWLIST"`
 		Blocklist                 string `env:"EMAIL_BLOCKLIST"`
 		DisableEmailNotifications bool   `env:"DISABLE_EMAIL_NOTIFICATIONS,default=false"`
-		AWSSES                    struct {
+		Inbound                   struct {
+			ReplyDomain string `env:"EMAIL_INBOUND_REPLY_DOMAIN"`
+			Secret      string `env:"EMAIL_INBOUND_SECRET"`
+		}
+		// Events are the bounces and complaints the email provider reports through webhooks
+		Events struct {
+			Secret string `env:"EMAIL_EVENTS_SECRET"`
+		}
+		AWSSES struct {
 			Region          string `env:"EMAIL_AWSSES_REGION"`
 			AccessKeyID     string `env:"EMAIL_AWSSES_ACCESS_KEY_ID"`
 			SecretAccessKey string `env:"EMAIL_AWSSES_SECRET_ACCESS_KEY"`
+			RateLimit       int    `env:"EMAIL_AWSSES_RATE_LIMIT,default=600"`
 		}
 		Mailgun struct {
-			APIKey string `env:"EMAIL_MAILGUN_API"`
-			Domain string `env:"EMAIL_MAILGUN_DOMAIN"`
-			Region string `env:"EMAIL_MAILGUN_REGION,default=US"`
+			APIKey    string `env:"EMAIL_MAILGUN_API"`
+			Domain    string `env:"EMAIL_MAILGUN_DOMAIN"`
+			Region    string `env:"EMAIL_MAILGUN_REGION,default=US"`
+			RateLimit int    `env:"EMAIL_MAILGUN_RATE_LIMIT,default=300"`
 		}
 		SMTP struct {
 			Host           string `env:"EMAIL_SMTP_HOST"`
@@ -118,6 +128,7 @@ type config struct {
 			Username       string `env:"EMAIL_SMTP_USERNAME"`
 			Password       string `env:"EMAIL_SMTP_PASSWORD"`
 			EnableStartTLS bool   `env:"EMAIL_SMTP_ENABLE_STARTTLS,default=true"`
+			RateLimit      int    `env:"EMAIL_SMTP_RATE_LIMIT,default=60"`
 		}
 	}
 	WebPush struct {
@@ -247,6 +258,14 @@ func IsWebPushEnabled() bool {
 	return Config.WebPush.VAPIDPublicKey != "" && Config.WebPush.VAPIDPrivateKey != ""
 }
 
+func IsReplyByEmailEnabled() bool {
+	return Config.Email.Inbound.ReplyDomain != "" && Config.Email.Inbound.Secret != ""
+}
+
+func IsEmailEventsEnabled() bool {
+	return Config.Email.Events.Secret != ""
+}
+
 func IsOpenAIModerationEnabled() bool {
 	return Config.OpenAI.APIKey != "" && Config.OpenAI.ModerationEnabled
 }
@@ -31,6 +31,7 @@ type FiderClaims struct {
 	UserName  string `json:"user/name"`
 	UserEmail string `json:"user/email"`
 	Origin    string `json:"origin"`
+	SessionID string `json:"session/id,omitempty"`
 	Metadata
 }
 
@@ -47,6 +48,55 @@ type OAuthClaims struct {
 type OAuthStateClaims struct {
 	Redirect   string `json:"oauthstate/redirect"`
 	Identifier string `json:"oauthstate/identifier"`
+	// Nonce is only set for OpenID Connect providers, it is sent as the nonce and used to derive the PKCE verifier
+	Nonce string `json:"oauthstate/nonce,omitempty"`
+	Metadata
+}
+
+// ReplyClaims represents what goes into the reply-to address of notification emails
+type ReplyClaims struct {
+	PostNumber int `json:"reply/post"`
+	UserID     int `json:"reply/user"`
+	Metadata
+}
+
+// UnsubscribeClaims represents what goes into the one-click unsubscribe link of notification emails
+type UnsubscribeClaims struct {
+	UserID int `json:"unsubscribe/user"`
+	// Event is the settings key of the notification event, or the digest frequency key for digest emails
+	Event string `json:"unsubscribe/event"`
+	Metadata
+}
+
+// OAuth2AccessClaims represents what goes into the access tokens issued to third-party apps
+type OAuth2AccessClaims struct {
+	UserID   int    `json:"oauth2/user"`
+	TenantID int    `json:"oauth2/tenant"`
+	ClientID string `json:"oauth2/client"`
+	Scope    string `json:"oauth2/scope"`
+	Metadata
+}
+
+// TwoFactorClaims represents who is signing in while their two-factor code is awaited
+type TwoFactorClaims struct {
+	UserID   int    `json:"twofactor/user"`
+	TenantID int    `json:"twofactor/tenant"`
+	Redirect string `json:"twofactor/redirect"`
+	Metadata
+}
+
+// PasskeyClaims holds the WebAuthn session of an ongoing passkey registration or sign in
+type PasskeyClaims struct {
+	TenantID int    `json:"passkey/tenant"`
+	Session  string `json:"passkey/session"`
+	Metadata
+}
+
+// UserDataExportClaims represents what goes into the download link of a personal data export
+type UserDataExportClaims struct {
+	ExportID int `json:"export/id"`
+	UserID   int `json:"export/user"`
+	TenantID int `json:"export/tenant"`
 	Metadata
 }
 
@@ -90,6 +140,78 @@ func DecodeOAuthStateClaims(token string) (*OAuthStateClaims, error) {
 	return claims, nil
 }
 
+// DecodeReplyClaims extract ReplyClaims from given JWT token
+func DecodeReplyClaims(token string) (*ReplyClaims, error) {
+	claims := &ReplyClaims{}
+	err := decode(token, claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode Reply claims")
+	}
+	return claims, nil
+}
+
+// DecodeUnsubscribeClaims extract UnsubscribeClaims from given JWT token
+func DecodeUnsubscribeClaims(token string) (*UnsubscribeClaims, error) {
+	claims := &UnsubscribeClaims{}
+	err := decode(token, claims)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode Unsubscribe claims")
+	}
+	return claims, nil
+}
+
+// DecodeOAuth2AccessClaims extract OAuth2AccessClaims from given JWT token
+func DecodeOAuth2AccessClaims(token string) (*OAuth2AccessClaims, error) {
+	claims := &OAuth2AccessClaims{}
+	err := decode(token, claims)
+	if err == nil && claims.ClientID == "" {
+		err = errors.New("token is not an OAuth2 access token")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode OAuth2 access claims")
+	}
+	return claims, nil
+}
+
+// DecodeTwoFactorClaims extract TwoFactorClaims from given JWT token
+func DecodeTwoFactorClaims(token string) (*TwoFactorClaims, error) {
+	claims := &TwoFactorClaims{}
+	err := decode(token, claims)
+	if err == nil && claims.UserID == 0 {
+		err = errors.New("token is not a two-factor challenge")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode two-factor claims")
+	}
+	return claims, nil
+}
+
+// DecodePasskeyClaims extract PasskeyClaims from given JWT token
+func DecodePasskeyClaims(token string) (*PasskeyClaims, error) {
+	claims := &PasskeyClaims{}
+	err := decode(token, claims)
+	if err == nil && claims.Session == "" {
+		err = errors.New("token is not a passkey challenge")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode passkey claims")
+	}
+	return claims, nil
+}
+
+// DecodeUserDataExportClaims extract UserDataExportClaims from given JWT token
+func DecodeUserDataExportClaims(token string) (*UserDataExportClaims, error) {
+	claims := &UserDataExportClaims{}
+	err := decode(token, claims)
+	if err == nil && claims.ExportID == 0 {
+		err = errors.New("token is not a data export link")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode data export claims")
+	}
+	return claims, nil
+}
+
 func decode(token string, claims jwtgo.Claims) error {
 	jwtToken, err := jwtgo.ParseWithClaims(token, claims, func(t *jwtgo.Token) (any, error) {
 		if _, ok := t.Method.(*jwtgo.SigningMethodHMAC); !ok {
@@ -135,3 +135,102 @@ func TestJWT_DecodeOAuthClaimsExpired(t *testing.T) {
 	Expect(err).IsNotNil()
 	Expect(decoded).IsNil()
 }
+
+func TestJWT_DecodeReplyClaims(t *testing.T) {
+	RegisterT(t)
+
+	claims := &jwt.ReplyClaims{
+		PostNumber: 12,
+		UserID:     424,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(time.Hour)),
+		},
+	}
+
+	token, err := jwt.Encode(claims)
+	Expect(err).IsNil()
+
+	decoded, err := jwt.DecodeReplyClaims(token)
+	Expect(err).IsNil()
+	Expect(decoded.PostNumber).Equals(12)
+	Expect(decoded.UserID).Equals(424)
+}
+
+func TestJWT_DecodeUnsubscribeClaims(t *testing.T) {
+	RegisterT(t)
+
+	claims := &jwt.UnsubscribeClaims{
+		UserID: 424,
+		Event:  "event_notification_new_comment",
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(time.Hour)),
+		},
+	}
+
+	token, err := jwt.Encode(claims)
+	Expect(err).IsNil()
+
+	decoded, err := jwt.DecodeUnsubscribeClaims(token)
+	Expect(err).IsNil()
+	Expect(decoded.UserID).Equals(424)
+	Expect(decoded.Event).Equals("event_notification_new_comment")
+
+	_, err = jwt.DecodeUnsubscribeClaims(token + "x")
+	Expect(err).IsNotNil()
+}
+
+func TestJWT_DecodeOAuth2AccessClaims(t *testing.T) {
+	RegisterT(t)
+
+	claims := &jwt.OAuth2AccessClaims{
+		UserID:   424,
+		TenantID: 1,
+		ClientID: "client-1",
+		Scope:    "read:posts write:comments",
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(time.Hour)),
+		},
+	}
+
+	token, err := jwt.Encode(claims)
+	Expect(err).IsNil()
+
+	decoded, err := jwt.DecodeOAuth2AccessClaims(token)
+	Expect(err).IsNil()
+	Expect(decoded.UserID).Equals(424)
+	Expect(decoded.ClientID).Equals("client-1")
+	Expect(decoded.Scope).Equals("read:posts write:comments")
+
+	// auth cookies are signed with the same secret, they must not be accepted as access tokens
+	cookie, err := jwt.Encode(&jwt.FiderClaims{UserID: 424, UserName: "Jon Snow"})
+	Expect(err).IsNil()
+	_, err = jwt.DecodeOAuth2AccessClaims(cookie)
+	Expect(err).IsNotNil()
+}
+
+func TestJWT_DecodeUserDataExportClaims(t *testing.T) {
+	RegisterT(t)
+
+	claims := &jwt.UserDataExportClaims{
+		ExportID: 7,
+		UserID:   424,
+		TenantID: 1,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(time.Hour)),
+		},
+	}
+
+	token, err := jwt.Encode(claims)
+	Expect(err).IsNil()
+
+	decoded, err := jwt.DecodeUserDataExportClaims(token)
+	Expect(err).IsNil()
+	Expect(decoded.ExportID).Equals(7)
+	Expect(decoded.UserID).Equals(424)
+	Expect(decoded.TenantID).Equals(1)
+
+	unsubscribe, err := jwt.Encode(&jwt.UnsubscribeClaims{UserID: 424, Event: "event_notification_new_comment"})
+	Expect(err).IsNil()
+	_, err = jwt.DecodeUserDataExportClaims(unsubscribe)
+	Expect(err).IsNotNil()
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
@@ -32,6 +33,10 @@ func createServer() *Server {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserTwoFactor) error {
+		return app.ErrNotFound
+	})
+
 	engine := web.New()
 
 	// Create a new request and set matched routed into context
@@ -77,6 +82,18 @@ func (s *Server) AsUser(user *entity.User) *Server {
 	return s
 }
 
+// WithUserSession set current context user session
+func (s *Server) WithUserSession(session *entity.UserSession) *Server {
+	s.context.SetUserSession(session)
+	return s
+}
+
+// WithAPIToken set current context as authenticated with given personal API token
+func (s *Server) WithAPIToken(token *entity.APIToken) *Server {
+	s.context.SetAPIToken(token)
+	return s
+}
+
 // AddParam to current context route parameters
 func (s *Server) AddParam(name string, value any) *Server {
 	s.context.AddParam(name, fmt.Sprintf("%v", value))
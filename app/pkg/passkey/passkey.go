@@ -0,0 +1,113 @@
+package passkey
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// New returns the relying party of the site at baseURL
+// Passkeys are bound to the host they were registered on, so each tenant host is its own relying party
+func New(siteName, baseURL string) (*webauthn.WebAuthn, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse base url '%s'", baseURL)
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:                  u.Hostname(),
+		RPDisplayName:         siteName,
+		RPOrigins:             []string{u.Scheme + "://" + u.Host},
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		},
+	})
+}
+
+// User is a user with their passkeys, as the WebAuthn ceremonies expect it
+type User struct {
+	User        *entity.User
+	Credentials []webauthn.Credential
+}
+
+// NewUser decodes the stored passkeys of given user
+func NewUser(user *entity.User, passkeys []*entity.UserPasskey) (*User, error) {
+	credentials := make([]webauthn.Credential, len(passkeys))
+	for i, passkey := range passkeys {
+		credential, err := Unmarshal(passkey.Credential)
+		if err != nil {
+			return nil, err
+		}
+		credentials[i] = *credential
+	}
+	return &User{User: user, Credentials: credentials}, nil
+}
+
+// WebAuthnID returns the user handle stored by the authenticator
+func (u *User) WebAuthnID() []byte {
+	return UserHandle(u.User.ID)
+}
+
+// WebAuthnName returns the account name shown by the authenticator
+func (u *User) WebAuthnName() string {
+	if u.User.Email != "" {
+		return u.User.Email
+	}
+	return u.User.Name
+}
+
+// WebAuthnDisplayName returns the name shown by the authenticator
+func (u *User) WebAuthnDisplayName() string {
+	return u.User.Name
+}
+
+// WebAuthnCredentials returns the passkeys of the user
+func (u *User) WebAuthnCredentials() []webauthn.Credential {
+	return u.Credentials
+}
+
+// UserHandle returns the user handle of given user, it's what the authenticator gives back during a sign-in
+func UserHandle(userID int) []byte {
+	return []byte(strconv.Itoa(userID))
+}
+
+// UserIDFromHandle returns the user a user handle was created for
+func UserIDFromHandle(handle []byte) (int, error) {
+	userID, err := strconv.Atoi(string(handle))
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid user handle")
+	}
+	return userID, nil
+}
+
+// CredentialID returns how the id of a credential is stored and looked up
+func CredentialID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+// Marshal returns how a credential is stored
+func Marshal(credential *webauthn.Credential) ([]byte, error) {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal passkey credential")
+	}
+	return data, nil
+}
+
+// Unmarshal decodes a stored credential
+func Unmarshal(data []byte) (*webauthn.Credential, error) {
+	credential := &webauthn.Credential{}
+	if err := json.Unmarshal(data, credential); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal passkey credential")
+	}
+	return credential, nil
+}
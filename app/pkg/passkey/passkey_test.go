@@ -0,0 +1,55 @@
+package passkey_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/passkey"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+func TestNew_RelyingPartyIsTenantHost(t *testing.T) {
+	RegisterT(t)
+
+	relyingParty, err := passkey.New("Demonstration", "https://demo.test.fider.io:3000")
+	Expect(err).IsNil()
+	Expect(relyingParty.Config.RPID).Equals("demo.test.fider.io")
+	Expect(relyingParty.Config.RPOrigins).Equals([]string{"https://demo.test.fider.io:3000"})
+	Expect(string(relyingParty.Config.AttestationPreference)).Equals("none")
+}
+
+func TestUserHandle(t *testing.T) {
+	RegisterT(t)
+
+	userID, err := passkey.UserIDFromHandle(passkey.UserHandle(42))
+	Expect(err).IsNil()
+	Expect(userID).Equals(42)
+
+	_, err = passkey.UserIDFromHandle([]byte("not-a-user"))
+	Expect(err).IsNotNil()
+}
+
+func TestNewUser_DecodesCredentials(t *testing.T) {
+	RegisterT(t)
+
+	data, err := passkey.Marshal(&webauthn.Credential{
+		ID:              []byte{1, 2, 3},
+		PublicKey:       []byte{4, 5, 6},
+		AttestationType: "none",
+		Authenticator:   webauthn.Authenticator{SignCount: 7},
+	})
+	Expect(err).IsNil()
+
+	user, err := passkey.NewUser(&entity.User{ID: 1, Name: "Jon Snow", Email: "jon.snow@got.com"}, []*entity.UserPasskey{
+		{ID: 1, CredentialID: passkey.CredentialID([]byte{1, 2, 3}), Credential: data},
+	})
+	Expect(err).IsNil()
+	Expect(user.WebAuthnID()).Equals([]byte("1"))
+	Expect(user.WebAuthnName()).Equals("jon.snow@got.com")
+	Expect(user.WebAuthnDisplayName()).Equals("Jon Snow")
+	Expect(user.WebAuthnCredentials()).HasLen(1)
+	Expect(user.WebAuthnCredentials()[0].ID).Equals([]byte{1, 2, 3})
+	Expect(user.WebAuthnCredentials()[0].Authenticator.SignCount).Equals(uint32(7))
+	Expect(passkey.CredentialID([]byte{1, 2, 3})).Equals("AQID")
+}
@@ -0,0 +1,295 @@
+package replymail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/sns"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+const localPartPrefix = "reply+"
+
+// Message is the part of an inbound email needed to post it as a comment
+type Message struct {
+	From       string
+	Recipients []string
+	Text       string
+}
+
+// Address returns the reply-to address that carries the given token
+func Address(token, domain string) string {
+	return localPartPrefix + token + "@" + domain
+}
+
+// Token returns the token of the first recipient that is a reply address on the given domain
+func Token(recipients []string, domain string) string {
+	for _, recipient := range recipients {
+		at := strings.LastIndex(recipient, "@")
+		if at < 0 {
+			continue
+		}
+		local, host := recipient[:at], recipient[at+1:]
+		if strings.EqualFold(host, domain) && strings.HasPrefix(strings.ToLower(local), localPartPrefix) {
+			return local[len(localPartPrefix):]
+		}
+	}
+	return ""
+}
+
+// Parse reads a raw MIME email and extracts its sender, recipients and text body
+func Parse(raw []byte) (*Message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read email")
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) == 0 {
+		return nil, errors.New("email has no valid sender")
+	}
+
+	recipients := make([]string, 0)
+	for _, key := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		if list, err := msg.Header.AddressList(key); err == nil {
+			for _, address := range list {
+				recipients = append(recipients, address.Address)
+			}
+		}
+	}
+
+	plain, htmlBody, err := readBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	text := plain
+	if strings.TrimSpace(text) == "" {
+		text = htmlToText(htmlBody)
+	}
+
+	return &Message{
+		From:       from[0].Address,
+		Recipients: recipients,
+		Text:       text,
+	}, nil
+}
+
+// readBody returns the first text/plain and text/html parts found in body
+func readBody(contentType, transferEncoding string, body io.Reader) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	switch strings.ToLower(transferEncoding) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var plain, htmlBody string
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", errors.Wrap(err, "failed to read email part")
+			}
+			if strings.HasPrefix(strings.ToLower(part.Header.Get("Content-Disposition")), "attachment") {
+				continue
+			}
+
+			partPlain, partHTML, err := readBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", "", err
+			}
+			if plain == "" {
+				plain = partPlain
+			}
+			if htmlBody == "" {
+				htmlBody = partHTML
+			}
+		}
+		return plain, htmlBody, nil
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to read email body")
+	}
+
+	switch mediaType {
+	case "text/plain":
+		return string(content), "", nil
+	case "text/html":
+		return "", string(content), nil
+	}
+	return "", "", nil
+}
+
+// newlineStripper drops line breaks so base64 bodies wrapped at 76 columns can be decoded
+type newlineStripper struct {
+	r io.Reader
+}
+
+func (s *newlineStripper) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	j := 0
+	for i := 0; i < n; i++ {
+		if p[i] != '\r' && p[i] != '\n' {
+			p[j] = p[i]
+			j++
+		}
+	}
+	return j, err
+}
+
+var (
+	strictPolicy   = bluemonday.StrictPolicy()
+	regexHTMLQuote = regexp.MustCompile(`(?is)<blockquote.*|<div[^>]*class="[^"]*(gmail_quote|moz-cite-prefix)[^"]*".*`)
+	regexHTMLBreak = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>`)
+)
+
+func htmlToText(input string) string {
+	input = regexHTMLQuote.ReplaceAllString(input, "")
+	input = regexHTMLBreak.ReplaceAllString(input, "\n")
+	return html.UnescapeString(strictPolicy.Sanitize(input))
+}
+
+var (
+	regexReplyHeader = regexp.MustCompile(`(?s)^On\s.+wrote:$`)
+	regexOutlookRule = regexp.MustCompile(`^(-{2,}\s*Original Message\s*-{2,}|_{10,})$`)
+	regexMobileSig   = regexp.MustCompile(`^Sent from my \w+`)
+)
+
+// StripQuoted removes the quoted conversation and signature that mail clients add below a reply
+func StripQuoted(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	kept := make([]string, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if line == "-- " || trimmed == "--" || regexOutlookRule.MatchString(trimmed) || regexMobileSig.MatchString(trimmed) {
+			break
+		}
+
+		// the "On <date>, <name> wrote:" header is often wrapped over two lines
+		if strings.HasPrefix(trimmed, "On ") {
+			if regexReplyHeader.MatchString(trimmed) {
+				break
+			}
+			if i+1 < len(lines) && regexReplyHeader.MatchString(trimmed+" "+strings.TrimSpace(lines[i+1])) {
+				break
+			}
+		}
+
+		// Outlook quotes the original message below a "From:" header block
+		if strings.HasPrefix(trimmed, "From:") && i+1 < len(lines) {
+			next := strings.TrimSpace(lines[i+1])
+			if strings.HasPrefix(next, "Sent:") || strings.HasPrefix(next, "Date:") {
+				break
+			}
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// FromMailgun returns the raw email posted by a Mailgun route that forwards to a URL ending in "mime"
+func FromMailgun(contentType, body string) ([]byte, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse content type")
+	}
+
+	var raw string
+	switch mediaType {
+	case "multipart/form-data":
+		form, err := multipart.NewReader(strings.NewReader(body), params["boundary"]).ReadForm(32 << 20)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read form")
+		}
+		defer func() { _ = form.RemoveAll() }()
+		if values := form.Value["body-mime"]; len(values) > 0 {
+			raw = values[0]
+		}
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read form")
+		}
+		raw = values.Get("body-mime")
+	}
+
+	if raw == "" {
+		return nil, errors.New("body-mime is missing")
+	}
+	return []byte(raw), nil
+}
+
+// SESNotification is what Amazon SES delivers through SNS
+type SESNotification struct {
+	// SubscribeURL is set when SNS asks to confirm the subscription of the endpoint
+	SubscribeURL string
+	Content      []byte
+}
+
+// FromSES returns the raw email of an SNS notification published by an SES receipt rule
+func FromSES(body string) (*SESNotification, error) {
+	envelope, err := sns.Parse([]byte(body))
+	if err != nil {
+		return nil, err
+	}
+	if envelope.IsSubscriptionConfirmation() {
+		return &SESNotification{SubscribeURL: envelope.SubscribeURL}, nil
+	}
+
+	var message struct {
+		Content string `json:"content"`
+		Receipt struct {
+			Action struct {
+				Encoding string `json:"encoding"`
+			} `json:"action"`
+		} `json:"receipt"`
+	}
+	if err := json.Unmarshal([]byte(envelope.Message), &message); err != nil {
+		return nil, errors.Wrap(err, "failed to parse SES notification")
+	}
+
+	if message.Content == "" {
+		return nil, errors.New("SES notification has no content")
+	}
+
+	if strings.EqualFold(message.Receipt.Action.Encoding, "BASE64") {
+		content, err := base64.StdEncoding.DecodeString(message.Content)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode SES content")
+		}
+		return &SESNotification{Content: content}, nil
+	}
+	return &SESNotification{Content: []byte(message.Content)}, nil
+}
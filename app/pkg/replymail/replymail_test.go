@@ -0,0 +1,160 @@
+package replymail_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/replymail"
+)
+
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+func TestAddressAndToken(t *testing.T) {
+	RegisterT(t)
+
+	address := replymail.Address("abc.def-ghi", "reply.example.com")
+	Expect(address).Equals("reply+abc.def-ghi@reply.example.com")
+
+	Expect(replymail.Token([]string{"jon@example.com", address}, "reply.example.com")).Equals("abc.def-ghi")
+	Expect(replymail.Token([]string{"reply+abc@other.com"}, "reply.example.com")).Equals("")
+	Expect(replymail.Token([]string{"jon@reply.example.com"}, "reply.example.com")).Equals("")
+}
+
+func TestParse_PlainText(t *testing.T) {
+	RegisterT(t)
+
+	raw := crlf(`From: Jon Snow <jon.snow@got.com>
+To: reply+token123@reply.example.com
+Subject: Re: [Demo] Add dark mode
+Content-Type: text/plain; charset="UTF-8"
+
+I agree with this!
+
+On Mon, 1 Jan 2024 at 10:00, Demo <noreply@example.com> wrote:
+> Arya left a comment
+`)
+
+	msg, err := replymail.Parse([]byte(raw))
+	Expect(err).IsNil()
+	Expect(msg.From).Equals("jon.snow@got.com")
+	Expect(msg.Recipients).Equals([]string{"reply+token123@reply.example.com"})
+	Expect(replymail.StripQuoted(msg.Text)).Equals("I agree with this!")
+}
+
+func TestParse_MultipartAlternative(t *testing.T) {
+	RegisterT(t)
+
+	raw := crlf(`From: jon.snow@got.com
+To: "Demo" <reply+token123@reply.example.com>
+Content-Type: multipart/alternative; boundary="xyz"
+
+--xyz
+Content-Type: text/plain; charset="UTF-8"
+Content-Transfer-Encoding: quoted-printable
+
+Caf=C3=A9 sounds good
+--xyz
+Content-Type: text/html; charset="UTF-8"
+
+<p>Caf&eacute; sounds good</p>
+--xyz--
+`)
+
+	msg, err := replymail.Parse([]byte(raw))
+	Expect(err).IsNil()
+	Expect(strings.TrimSpace(msg.Text)).Equals("Café sounds good")
+}
+
+func TestParse_HTMLOnly(t *testing.T) {
+	RegisterT(t)
+
+	body := base64.StdEncoding.EncodeToString([]byte(`<div>Hello <b>there</b><br>second line</div><div class="gmail_quote">On Mon wrote: old stuff</div>`))
+	raw := crlf(`From: jon.snow@got.com
+To: reply+token123@reply.example.com
+Content-Type: text/html; charset="UTF-8"
+Content-Transfer-Encoding: base64
+
+` + body[:20] + "\n" + body[20:] + "\n")
+
+	msg, err := replymail.Parse([]byte(raw))
+	Expect(err).IsNil()
+	Expect(replymail.StripQuoted(msg.Text)).Equals("Hello there\nsecond line")
+}
+
+func TestParse_NoSender(t *testing.T) {
+	RegisterT(t)
+
+	_, err := replymail.Parse([]byte(crlf("To: reply+a@b.com\n\nhello\n")))
+	Expect(err).IsNotNil()
+}
+
+func TestStripQuoted(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"Sounds good\n\n-- \nJon Snow\nLord Commander", "Sounds good"},
+		{"Sounds good\n\nSent from my iPhone", "Sounds good"},
+		{"Sounds good\n\nOn Mon, Jan 1, 2024 at 10:00 AM Demo <\nnoreply@example.com> wrote:\n> old", "Sounds good"},
+		{"Sounds good\n\n-----Original Message-----\nFrom: Demo", "Sounds good"},
+		{"Sounds good\n\nFrom: Demo <noreply@example.com>\nSent: Monday\nTo: Jon", "Sounds good"},
+		{"> quoted\nfirst\n> quoted\nsecond", "first\nsecond"},
+		{"From: the start, this was\na good idea", "From: the start, this was\na good idea"},
+	}
+
+	for _, testCase := range testCases {
+		Expect(replymail.StripQuoted(testCase.input)).Equals(testCase.expected)
+	}
+}
+
+func TestFromMailgun(t *testing.T) {
+	RegisterT(t)
+
+	raw, err := replymail.FromMailgun("application/x-www-form-urlencoded", "recipient=a%40b.com&body-mime=From%3A+jon%40got.com")
+	Expect(err).IsNil()
+	Expect(string(raw)).Equals("From: jon@got.com")
+
+	body := crlf(`--b
+Content-Disposition: form-data; name="body-mime"
+
+From: jon@got.com
+--b--
+`)
+	raw, err = replymail.FromMailgun("multipart/form-data; boundary=b", body)
+	Expect(err).IsNil()
+	Expect(string(raw)).Equals("From: jon@got.com")
+
+	_, err = replymail.FromMailgun("application/x-www-form-urlencoded", "recipient=a%40b.com")
+	Expect(err).IsNotNil()
+}
+
+func TestFromSES(t *testing.T) {
+	RegisterT(t)
+
+	message, _ := json.Marshal(map[string]any{
+		"content": base64.StdEncoding.EncodeToString([]byte("From: jon@got.com")),
+		"receipt": map[string]any{"action": map[string]any{"type": "SNS", "encoding": "BASE64"}},
+	})
+	envelope, _ := json.Marshal(map[string]any{"Type": "Notification", "Message": string(message)})
+
+	notification, err := replymail.FromSES(string(envelope))
+	Expect(err).IsNil()
+	Expect(notification.SubscribeURL).Equals("")
+	Expect(string(notification.Content)).Equals("From: jon@got.com")
+
+	confirmation, _ := json.Marshal(map[string]any{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"})
+	notification, err = replymail.FromSES(string(confirmation))
+	Expect(err).IsNil()
+	Expect(notification.SubscribeURL).Equals("https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription")
+
+	evil, _ := json.Marshal(map[string]any{"Type": "SubscriptionConfirmation", "SubscribeURL": "http://169.254.169.254/latest"})
+	_, err = replymail.FromSES(string(evil))
+	Expect(err).IsNotNil()
+}
@@ -0,0 +1,94 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidFilter is returned for filters that can't be parsed or use unsupported operators
+var ErrInvalidFilter = errors.New("filter is invalid or not supported")
+
+// Comparison is an equality test of an attribute, like userName eq "jon@got.com"
+type Comparison struct {
+	// Attribute is lowercase, attribute names are case insensitive
+	Attribute string
+	Value     string
+}
+
+// ParseFilter parses the equality comparisons joined by "and" that identity providers use to look up resources
+// Other operators, "or", "not" and grouping are not supported
+func ParseFilter(filter string) ([]Comparison, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	comparisons := make([]Comparison, 0)
+	for i := 0; i < len(tokens); i += 4 {
+		if len(tokens) < i+3 {
+			return nil, ErrInvalidFilter
+		}
+
+		attribute, operator, value := tokens[i], tokens[i+1], tokens[i+2]
+		if attribute.quoted || operator.quoted || strings.ToLower(operator.text) != "eq" {
+			return nil, ErrInvalidFilter
+		}
+		comparisons = append(comparisons, Comparison{
+			Attribute: strings.ToLower(attribute.text),
+			Value:     value.text,
+		})
+
+		if len(tokens) > i+3 && (tokens[i+3].quoted || strings.ToLower(tokens[i+3].text) != "and" || len(tokens) == i+4) {
+			return nil, ErrInvalidFilter
+		}
+	}
+
+	if len(comparisons) == 0 {
+		return nil, ErrInvalidFilter
+	}
+	return comparisons, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(filter string) ([]token, error) {
+	tokens := make([]token, 0)
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ':
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, ErrInvalidFilter
+			}
+
+			var text string
+			if err := json.Unmarshal([]byte(filter[i:end+1]), &text); err != nil {
+				return nil, ErrInvalidFilter
+			}
+			tokens = append(tokens, token{text: text, quoted: true})
+			i = end + 1
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			return nil, ErrInvalidFilter
+		default:
+			end := i
+			for end < len(filter) && !strings.ContainsRune(` "()[]`, rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, token{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
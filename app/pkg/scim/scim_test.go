@@ -0,0 +1,107 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/scim"
+)
+
+func TestParseFilter_Equality(t *testing.T) {
+	RegisterT(t)
+
+	comparisons, err := scim.ParseFilter(`userName eq "jon.snow@got.com"`)
+	Expect(err).IsNil()
+	Expect(comparisons).HasLen(1)
+	Expect(comparisons[0].Attribute).Equals("username")
+	Expect(comparisons[0].Value).Equals("jon.snow@got.com")
+}
+
+func TestParseFilter_And(t *testing.T) {
+	RegisterT(t)
+
+	comparisons, err := scim.ParseFilter(`externalId EQ "00u1" and emails.value eq "say \"hi\"@got.com"`)
+	Expect(err).IsNil()
+	Expect(comparisons).HasLen(2)
+	Expect(comparisons[0].Attribute).Equals("externalid")
+	Expect(comparisons[0].Value).Equals("00u1")
+	Expect(comparisons[1].Attribute).Equals("emails.value")
+	Expect(comparisons[1].Value).Equals(`say "hi"@got.com`)
+}
+
+func TestParseFilter_Unsupported(t *testing.T) {
+	RegisterT(t)
+
+	for _, filter := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName co "jon"`,
+		`userName eq "jon" or userName eq "arya"`,
+		`userName eq "jon" and`,
+		`emails[type eq "work"]`,
+		`(userName eq "jon")`,
+		`userName eq "jon`,
+		`"userName" eq "jon"`,
+	} {
+		_, err := scim.ParseFilter(filter)
+		Expect(err).Equals(scim.ErrInvalidFilter)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		startIndex string
+		count      string
+		start      int
+		size       int
+	}{
+		{"", "", 1, scim.DefaultCount},
+		{"0", "10", 1, 10},
+		{"-5", "-1", 1, 0},
+		{"21", "20", 21, 20},
+		{"1", "5000", 1, scim.MaxCount},
+		{"abc", "abc", 1, scim.DefaultCount},
+	}
+
+	for _, testCase := range testCases {
+		start, size := scim.ParsePagination(testCase.startIndex, testCase.count)
+		Expect(start).Equals(testCase.start)
+		Expect(size).Equals(testCase.size)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	RegisterT(t)
+
+	for raw, expected := range map[string]bool{`true`: true, `false`: false, `"True"`: true, `"False"`: false} {
+		value, ok := scim.ParseBool(json.RawMessage(raw))
+		Expect(ok).IsTrue()
+		Expect(value).Equals(expected)
+	}
+
+	_, ok := scim.ParseBool(json.RawMessage(`"maybe"`))
+	Expect(ok).IsFalse()
+}
+
+func TestUser_FullNameAndEmail(t *testing.T) {
+	RegisterT(t)
+
+	user := &scim.User{UserName: "jon.snow@got.com"}
+	Expect(user.FullName()).Equals("jon.snow")
+	Expect(user.PrimaryEmail()).Equals("jon.snow@got.com")
+
+	user = &scim.User{
+		UserName: "jsnow",
+		Name:     &scim.Name{GivenName: "Jon", FamilyName: "Snow"},
+		Emails:   []scim.Email{{Value: "other@got.com"}, {Value: "jon.snow@got.com", Primary: true}},
+	}
+	Expect(user.FullName()).Equals("Jon Snow")
+	Expect(user.PrimaryEmail()).Equals("jon.snow@got.com")
+
+	user.DisplayName = "Lord Snow"
+	Expect(user.FullName()).Equals("Lord Snow")
+}
@@ -0,0 +1,39 @@
+package sns
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+// Envelope is the message Amazon SNS posts to a subscribed HTTP endpoint
+type Envelope struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+// IsSubscriptionConfirmation returns true if SNS asks to confirm the subscription of the endpoint
+// by visiting SubscribeURL, in which case Message holds no notification
+func (e *Envelope) IsSubscriptionConfirmation() bool {
+	return e.Type == "SubscriptionConfirmation"
+}
+
+// Parse returns the envelope of an SNS message. The subscribe url of a confirmation is visited by
+// the server, so only https urls of Amazon are accepted
+func Parse(body []byte) (*Envelope, error) {
+	envelope := &Envelope{}
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to parse SNS envelope")
+	}
+
+	if envelope.IsSubscriptionConfirmation() {
+		u, err := url.Parse(envelope.SubscribeURL)
+		if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+			return nil, errors.New("invalid SNS subscribe url '%s'", envelope.SubscribeURL)
+		}
+	}
+	return envelope, nil
+}
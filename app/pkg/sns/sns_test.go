@@ -0,0 +1,38 @@
+package sns_test
+
+import (
+	"testing"
+
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/sns"
+)
+
+func TestParse_Notification(t *testing.T) {
+	RegisterT(t)
+
+	envelope, err := sns.Parse([]byte(`{"Type": "Notification", "Message": "{\"notificationType\": \"Bounce\"}"}`))
+	Expect(err).IsNil()
+	Expect(envelope.IsSubscriptionConfirmation()).IsFalse()
+	Expect(envelope.Message).Equals(`{"notificationType": "Bounce"}`)
+}
+
+func TestParse_SubscriptionConfirmation(t *testing.T) {
+	RegisterT(t)
+
+	envelope, err := sns.Parse([]byte(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"}`))
+	Expect(err).IsNil()
+	Expect(envelope.IsSubscriptionConfirmation()).IsTrue()
+	Expect(envelope.SubscribeURL).Equals("https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription")
+
+	for _, subscribeURL := range []string{"http://sns.us-east-1.amazonaws.com/", "https://evil.com/confirm", "http://169.254.169.254/latest", ""} {
+		_, err = sns.Parse([]byte(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "` + subscribeURL + `"}`))
+		Expect(err).IsNotNil()
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	RegisterT(t)
+
+	_, err := sns.Parse([]byte(`not json`))
+	Expect(err).IsNotNil()
+}
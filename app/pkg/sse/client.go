@@ -5,6 +5,7 @@ type Channel string
 const (
 	ChannelReports Channel = "reports"
 	ChannelQueue   Channel = "queue"
+	ChannelAppeals Channel = "appeals"
 )
 
 type Client struct {
@@ -18,6 +18,9 @@ const (
 	MsgQueuePostTagged   = "queue.post_tagged"
 	MsgQueueViewerJoined = "queue.viewer_joined"
 	MsgQueueViewerLeft   = "queue.viewer_left"
+
+	MsgAppealNew     = "appeal.new"
+	MsgAppealDecided = "appeal.decided"
 )
 
 type Message struct {
@@ -36,6 +39,7 @@ type ClientInfo struct {
 
 type ReportEventPayload struct {
 	ReportID     int         `json:"reportId"`
+	CaseID       int         `json:"caseId,omitempty"`
 	ReportedType string      `json:"reportedType,omitempty"`
 	ReportedID   int         `json:"reportedId,omitempty"`
 	Reason       string      `json:"reason,omitempty"`
@@ -43,6 +47,13 @@ type ReportEventPayload struct {
 	AssignedTo   *ClientInfo `json:"assignedTo,omitempty"`
 }
 
+type AppealEventPayload struct {
+	AppealID     int    `json:"appealId"`
+	UserID       int    `json:"userId,omitempty"`
+	SanctionType string `json:"sanctionType,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
 type ViewerEventPayload struct {
 	ReportID int    `json:"reportId"`
 	UserID   int    `json:"userId"`
@@ -198,6 +209,8 @@ func getChannelForMessage(messageType string) Channel {
 		return ChannelReports
 	case MsgQueuePostNew, MsgQueuePostTagged, MsgQueueViewerJoined, MsgQueueViewerLeft:
 		return ChannelQueue
+	case MsgAppealNew, MsgAppealDecided:
+		return ChannelAppeals
 	default:
 		return ""
 	}
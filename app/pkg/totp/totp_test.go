@@ -0,0 +1,81 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+)
+
+// rfcSecret is the SHA1 secret used by the test vectors of RFC 6238
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, testCase := range testCases {
+		code, err := totp.Code(rfcSecret, totp.Step(time.Unix(testCase.unix, 0)))
+		Expect(err).IsNil()
+		Expect(code).Equals(testCase.code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	RegisterT(t)
+
+	now := time.Unix(1111111111, 0)
+
+	step, ok := totp.Validate(rfcSecret, "050471", now)
+	Expect(ok).IsTrue()
+	Expect(step).Equals(totp.Step(now))
+
+	// previous and next codes are accepted for clock drift
+	_, ok = totp.Validate(rfcSecret, "050471", now.Add(30*time.Second))
+	Expect(ok).IsTrue()
+	_, ok = totp.Validate(rfcSecret, "050471", now.Add(-30*time.Second))
+	Expect(ok).IsTrue()
+
+	_, ok = totp.Validate(rfcSecret, "050471", now.Add(90*time.Second))
+	Expect(ok).IsFalse()
+	_, ok = totp.Validate(rfcSecret, "050 471", now)
+	Expect(ok).IsTrue()
+	_, ok = totp.Validate(rfcSecret, "000000", now)
+	Expect(ok).IsFalse()
+	_, ok = totp.Validate(rfcSecret, "50471", now)
+	Expect(ok).IsFalse()
+	_, ok = totp.Validate("not base32!", "050471", now)
+	Expect(ok).IsFalse()
+}
+
+func TestGenerateSecret(t *testing.T) {
+	RegisterT(t)
+
+	secret := totp.GenerateSecret()
+	Expect(secret).HasLen(32)
+	Expect(secret).NotEquals(totp.GenerateSecret())
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	Expect(err).IsNil()
+	Expect(code).HasLen(6)
+}
+
+func TestURL(t *testing.T) {
+	RegisterT(t)
+
+	url := totp.URL("Demo Tenant", "jon.snow@got.com", "JBSWY3DPEHPK3PXP")
+	Expect(url).Equals("otpauth://totp/Demo%20Tenant:jon.snow@got.com?algorithm=SHA1&digits=6&issuer=Demo+Tenant&period=30&secret=JBSWY3DPEHPK3PXP")
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"text/template/parse"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/assets"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
@@ -34,6 +35,82 @@ func GetTemplate(baseFileName, templateFileName string) *template.Template {
 	return tpl
 }
 
+// GetOverrideTemplate parses a base file together with "subject" and "body" blocks that override the ones of its built-in template
+// It isn't cached as overrides can change at any time
+func GetOverrideTemplate(baseFileName, subject, body string) (*template.Template, error) {
+	baseFile := strings.TrimPrefix(baseFileName, "/")
+
+	tpl, err := template.New(path.Base(baseFile)).Funcs(templateFunctions).ParseFS(assets.FS, baseFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse template %s", baseFileName)
+	}
+
+	if _, err := tpl.New("subject").Parse(subject); err != nil {
+		return nil, err
+	}
+	if _, err := tpl.New("body").Parse(body); err != nil {
+		return nil, err
+	}
+
+	return tpl, nil
+}
+
+// Fields returns the names of the props referenced by the named templates, such as "title" for {{ .title }}
+func Fields(tmpl *template.Template, names ...string) []string {
+	fields := make([]string, 0)
+	seen := make(map[string]bool)
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			if !seen[n.Ident[0]] {
+				seen[n.Ident[0]] = true
+				fields = append(fields, n.Ident[0])
+			}
+		}
+	}
+
+	for _, name := range names {
+		if t := tmpl.Lookup(name); t != nil && t.Tree != nil {
+			walk(t.Tree.Root)
+		}
+	}
+	return fields
+}
+
 func Render(ctx context.Context, tmpl *template.Template, w io.Writer, data any) error {
 	if err := template.Must(tmpl.Clone()).Funcs(template.FuncMap{
 		"translate": func(key string, params ...i18n.Params) string {
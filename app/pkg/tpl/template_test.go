@@ -23,3 +23,39 @@ func TestGetTemplate_Render(t *testing.T) {
 	Expect(bf.String()).ContainsSubstring(`Hello, John!`)
 	Expect(bf.String()).ContainsSubstring(`This goes on the head.`)
 }
+
+func TestGetOverrideTemplate_Render(t *testing.T) {
+	RegisterT(t)
+
+	bf := new(bytes.Buffer)
+	tmpl, err := tpl.GetOverrideTemplate("app/pkg/tpl/testdata/base.html", "", "Welcome, {{ .name }}!")
+	Expect(err).IsNil()
+
+	err = tpl.Render(context.Background(), tmpl, bf, dto.Props{
+		"name": "John",
+	})
+
+	Expect(err).IsNil()
+	Expect(bf.String()).ContainsSubstring(`<body>Welcome, John!</body>`)
+}
+
+func TestGetOverrideTemplate_InvalidSyntax(t *testing.T) {
+	RegisterT(t)
+
+	tmpl, err := tpl.GetOverrideTemplate("app/pkg/tpl/testdata/base.html", "", "Welcome, {{ .name }!")
+	Expect(err).IsNotNil()
+	Expect(tmpl).IsNil()
+}
+
+func TestFields(t *testing.T) {
+	RegisterT(t)
+
+	tmpl, err := tpl.GetOverrideTemplate("app/pkg/tpl/testdata/base.html", "[{{ .siteName }}]", `
+		{{ if .title }}<h1>{{ .title | stripHtml }}</h1>{{ end }}
+		{{ range .groups }}{{ .url }}{{ end }}
+		{{ translate "some.key" (dict "change" .change) }}
+	`)
+	Expect(err).IsNil()
+	Expect(tpl.Fields(tmpl, "subject", "body")).Equals([]string{"siteName", "title", "groups", "url", "change"})
+	Expect(tpl.Fields(tmpl, "head")).Equals([]string{})
+}
@@ -63,6 +63,12 @@ const CookieAuthName = "auth"
 // CookieSignUpAuthName is the name of the cookie that holds the temporary Authentication Token
 const CookieSignUpAuthName = "__signup_auth"
 
+// CookieTwoFactorName is the name of the cookie that holds who is signing in until they type their two-factor code
+const CookieTwoFactorName = "__twofactor"
+
+// CookiePasskeyName is the name of the cookie that holds the challenge of an ongoing passkey registration or sign in
+const CookiePasskeyName = "__passkey"
+
 // Context shared between http pipeline
 type Context struct {
 	context.Context
@@ -369,6 +375,34 @@ func (c *Context) SetUser(user *entity.User) {
 	c.Set(app.UserCtxKey, user)
 }
 
+// UserSession returns the session the authenticated user signed in with, API key requests don't have one
+func (c *Context) UserSession() *entity.UserSession {
+	session, ok := c.Value(app.UserSessionCtxKey).(*entity.UserSession)
+	if ok {
+		return session
+	}
+	return nil
+}
+
+// SetUserSession update HTTP context with the session of current user
+func (c *Context) SetUserSession(session *entity.UserSession) {
+	c.Set(app.UserSessionCtxKey, session)
+}
+
+// APIToken returns the personal API token the request was authenticated with, if any
+func (c *Context) APIToken() *entity.APIToken {
+	token, ok := c.Value(app.APITokenCtxKey).(*entity.APIToken)
+	if ok {
+		return token
+	}
+	return nil
+}
+
+// SetAPIToken update HTTP context with the personal API token of current request
+func (c *Context) SetAPIToken(token *entity.APIToken) {
+	c.Set(app.APITokenCtxKey, token)
+}
+
 // AddCookie adds a cookie
 func (c *Context) AddCookie(name, value string, expires time.Time) *http.Cookie {
 	cookie := &http.Cookie{
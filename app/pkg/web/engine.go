@@ -202,7 +202,7 @@ func (e *Engine) Worker() worker.Worker {
 func (e *Engine) Group() *Group {
 	g := &Group{
 		engine:      e,
-		middlewares: e.middlewares,
+		middlewares: append([]MiddlewareFunc{}, e.middlewares...),
 	}
 	return g
 }
@@ -231,6 +231,10 @@ func (e *Engine) Delete(path string, handler HandlerFunc) {
 	e.mux.Handle("DELETE", path, e.handle(e.middlewares, handler))
 }
 
+func (e *Engine) Patch(path string, handler HandlerFunc) {
+	e.mux.Handle("PATCH", path, e.handle(e.middlewares, handler))
+}
+
 func (e *Engine) NotFound(handler HandlerFunc) {
 	e.mux.NotFound = &notFoundHandler{
 		engine:  e,
@@ -262,7 +266,7 @@ type Group struct {
 func (g *Group) Group() *Group {
 	g2 := &Group{
 		engine:      g.engine,
-		middlewares: g.middlewares,
+		middlewares: append([]MiddlewareFunc{}, g.middlewares...),
 	}
 	return g2
 }
@@ -287,6 +291,10 @@ func (g *Group) Delete(path string, handler HandlerFunc) {
 	g.engine.mux.Handle("DELETE", path, g.engine.handle(g.middlewares, handler))
 }
 
+func (g *Group) Patch(path string, handler HandlerFunc) {
+	g.engine.mux.Handle("PATCH", path, g.engine.handle(g.middlewares, handler))
+}
+
 func (g *Group) Static(prefix, root string) {
 	subFS, err := fs.Sub(assets.FS, root)
 	if err != nil {
@@ -239,20 +239,6 @@ func getUserStandingInfo(ctx *Context, userID int) userStandingInfo {
 
 	standing := &query.GetUserProfileStanding{
 		UserID: userID,
-		Result: struct {
-			Warnings []struct {
-				ID        int        `json:"id"`
-				Reason    string     `json:"reason"`
-				CreatedAt time.Time  `json:"createdAt"`
-				ExpiresAt *time.Time `json:"expiresAt,omitempty"`
-			} `json:"warnings"`
-			Mutes []struct {
-				ID        int        `json:"id"`
-				Reason    string     `json:"reason"`
-				CreatedAt time.Time  `json:"createdAt"`
-				ExpiresAt *time.Time `json:"expiresAt,omitempty"`
-			} `json:"mutes"`
-		}{},
 	}
 	if err := bus.Dispatch(ctx, standing); err != nil {
 		return result
@@ -377,6 +363,7 @@ func (r *Renderer) Render(w io.Writer, statusCode int, props Props, ctx *Context
 			"isCollaborator":  u.IsCollaborator(),
 			"isModerator":     u.IsModerator(),
 			"isHelper":        u.IsHelper(),
+			"permissions":     u.Permissions(),
 			"hasWarning":      standing.hasWarning,
 			"isMuted":         standing.isMuted,
 			"latestWarningId": standing.latestWarningID,
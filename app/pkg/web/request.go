@@ -108,6 +108,17 @@ func (r *Request) IsVerifiedCrawler() bool {
 	return crawler.DefaultVerifier.IsVerified(ip)
 }
 
+// ClientIP returns the IP address of the client that made the request
+func (r *Request) ClientIP() string {
+	if r.instance == nil {
+		return ""
+	}
+	if ip := crawler.GetRealIP(r.instance); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
 // IsCustomDomain returns true if the request was made using a custom domain (CNAME)
 func (r *Request) IsCustomDomain() bool {
 	return !strings.HasSuffix(r.URL.Hostname(), env.Config.HostDomain)
@@ -0,0 +1,50 @@
+package webutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// apiTokenPrefix tells personal API tokens apart from the legacy API keys
+const apiTokenPrefix = "fdr_"
+
+// apiTokenTouchInterval is how often the last used time of a token is saved
+const apiTokenTouchInterval = time.Minute
+
+// GenerateAPIToken returns a new personal API token and the beginning of it, which is shown to help users recognize it later
+func GenerateAPIToken() (token, prefix string) {
+	token = apiTokenPrefix + rand.String(40)
+	return token, token[:len(apiTokenPrefix)+8]
+}
+
+// IsAPIToken returns true if given bearer token is a personal API token rather than a legacy API key
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, apiTokenPrefix)
+}
+
+// HashAPIToken returns what is stored instead of the token itself
+func HashAPIToken(token string) string {
+	return hashSecret(token)
+}
+
+// hashSecret is enough for random secrets like tokens, unlike passwords they can't be guessed
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// TouchAPIToken saves when the token was last used, at most once a minute
+func TouchAPIToken(ctx *web.Context, token *entity.APIToken) error {
+	if token.LastUsedAt != nil && time.Since(*token.LastUsedAt) < apiTokenTouchInterval {
+		return nil
+	}
+	return bus.Dispatch(ctx, &cmd.TouchAPIToken{ID: token.ID})
+}
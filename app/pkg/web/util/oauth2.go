@@ -0,0 +1,109 @@
+package webutil
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// OAuth2AccessTokenExpiration is how long an access token issued to a third-party app can be used
+const OAuth2AccessTokenExpiration = time.Hour
+
+// GenerateOAuth2Secret returns a new client secret, authorization code or refresh token
+func GenerateOAuth2Secret() string {
+	return rand.String(48)
+}
+
+// HashOAuth2Secret returns what is stored instead of the secret itself
+func HashOAuth2Secret(secret string) string {
+	return hashSecret(secret)
+}
+
+// VerifyPKCE returns true if verifier is the one the S256 challenge was created from
+func VerifyPKCE(verifier, challenge string) bool {
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// ParseOAuth2Scope returns the known scopes of a space separated scope parameter
+func ParseOAuth2Scope(scope string) []enum.APITokenScope {
+	scopes := make([]enum.APITokenScope, 0)
+	for _, name := range strings.Fields(scope) {
+		if s, ok := enum.ParseAPITokenScope(name); ok {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// FormatOAuth2Scope returns the space separated scope parameter of given scopes
+func FormatOAuth2Scope(scopes []enum.APITokenScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = scope.String()
+	}
+	return strings.Join(names, " ")
+}
+
+// EncodeOAuth2AccessToken returns an access token that lets client act as given user within scopes
+func EncodeOAuth2AccessToken(ctx *web.Context, client *entity.OAuthClient, userID int, scopes []enum.APITokenScope) (string, error) {
+	return jwt.Encode(jwt.OAuth2AccessClaims{
+		UserID:   userID,
+		TenantID: ctx.Tenant().ID,
+		ClientID: client.ClientID,
+		Scope:    FormatOAuth2Scope(scopes),
+		Metadata: jwt.Metadata{
+			IssuedAt:  jwt.Time(time.Now()),
+			ExpiresAt: jwt.Time(time.Now().Add(OAuth2AccessTokenExpiration)),
+		},
+	})
+}
+
+// IsOAuth2AccessToken returns true if given bearer token looks like an access token issued to a third-party app
+func IsOAuth2AccessToken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// GetOAuth2AccessToken returns what the access token allows, as if it were a personal API token of its user
+// app.ErrNotFound is returned if the token is invalid, has expired or its client was deleted
+func GetOAuth2AccessToken(ctx *web.Context, token string) (*entity.APIToken, error) {
+	claims, err := jwt.DecodeOAuth2AccessClaims(token)
+	if err != nil || ctx.Tenant() == nil || claims.TenantID != ctx.Tenant().ID {
+		return nil, app.ErrNotFound
+	}
+
+	getClient := &query.GetOAuthClientByClientID{ClientID: claims.ClientID}
+	if err := bus.Dispatch(ctx, getClient); err != nil {
+		if errors.Cause(err) == app.ErrNotFound {
+			return nil, app.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// only the scopes the client is registered with are ever allowed
+	scopes := make([]enum.APITokenScope, 0)
+	for _, scope := range ParseOAuth2Scope(claims.Scope) {
+		if getClient.Result.HasScope(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &entity.APIToken{
+		UserID: claims.UserID,
+		Name:   getClient.Result.Name,
+		Scopes: scopes,
+	}, nil
+}
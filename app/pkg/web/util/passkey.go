@@ -0,0 +1,61 @@
+package webutil
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// passkeyChallengeExpiration is how long users have to answer the prompt of their authenticator
+const passkeyChallengeExpiration = 5 * time.Minute
+
+// StartPasskeyChallenge remembers the WebAuthn session until the authenticator answers it
+func StartPasskeyChallenge(ctx *web.Context, session *webauthn.SessionData) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal passkey session")
+	}
+
+	expiresAt := time.Now().Add(passkeyChallengeExpiration)
+	token, err := jwt.Encode(jwt.PasskeyClaims{
+		TenantID: ctx.Tenant().ID,
+		Session:  string(data),
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(expiresAt),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to start passkey challenge")
+	}
+
+	ctx.AddCookie(web.CookiePasskeyName, token, expiresAt)
+	return nil
+}
+
+// GetPasskeyChallenge returns the ongoing WebAuthn session, or nil if there's none for the current tenant
+func GetPasskeyChallenge(ctx *web.Context) *webauthn.SessionData {
+	cookie, err := ctx.Request.Cookie(web.CookiePasskeyName)
+	if err != nil {
+		return nil
+	}
+
+	claims, err := jwt.DecodePasskeyClaims(cookie.Value)
+	if err != nil || claims.TenantID != ctx.Tenant().ID {
+		return nil
+	}
+
+	session := &webauthn.SessionData{}
+	if err := json.Unmarshal([]byte(claims.Session), session); err != nil {
+		return nil
+	}
+	return session
+}
+
+// EndPasskeyChallenge removes the passkey challenge cookie, each challenge can only be answered once
+func EndPasskeyChallenge(ctx *web.Context) {
+	ctx.RemoveCookie(web.CookiePasskeyName)
+}
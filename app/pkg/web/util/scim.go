@@ -0,0 +1,50 @@
+package webutil
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/scim"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// scimTokenPrefix makes SCIM tokens easy to recognize in identity provider settings and secret scanners
+const scimTokenPrefix = "fdrscim_"
+
+// GenerateSCIMToken returns a new SCIM token and the beginning of it, which is shown to help administrators recognize it later
+func GenerateSCIMToken() (token, prefix string) {
+	token = scimTokenPrefix + rand.String(40)
+	return token, token[:len(scimTokenPrefix)+8]
+}
+
+// HashSCIMToken returns what is stored instead of the token itself
+func HashSCIMToken(token string) string {
+	return hashSecret(token)
+}
+
+// TouchSCIMToken saves when the token was last used, at most once a minute
+func TouchSCIMToken(ctx *web.Context, token *entity.SCIMToken) error {
+	if token.LastUsedAt != nil && time.Since(*token.LastUsedAt) < apiTokenTouchInterval {
+		return nil
+	}
+	return bus.Dispatch(ctx, &cmd.TouchSCIMToken{})
+}
+
+// SCIMResponse writes a SCIM resource, list or error with the SCIM media type
+func SCIMResponse(ctx *web.Context, status int, body any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal SCIM response")
+	}
+	return ctx.Blob(status, scim.ContentType, b)
+}
+
+// SCIMError writes a SCIM error, scimType is optional
+func SCIMError(ctx *web.Context, status int, scimType, detail string) error {
+	return SCIMResponse(ctx, status, scim.NewError(status, scimType, detail))
+}
@@ -0,0 +1,86 @@
+package webutil
+
+import (
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// sessionCacheDuration bounds how long a session revoked on another instance can still be used on this one
+const sessionCacheDuration = time.Minute
+
+// sessionTouchInterval is how often the last seen time of a session is saved
+const sessionTouchInterval = 5 * time.Minute
+
+func sessionCacheKey(sessionID string) string {
+	return "user_session:" + sessionID
+}
+
+// GetUserSession returns the session with given ID
+// Sessions are cached for a short while so that checking them doesn't hit the database on every request
+func GetUserSession(ctx *web.Context, sessionID string) (*entity.UserSession, error) {
+	key := sessionCacheKey(sessionID)
+	if cached, found := ctx.Engine().Cache().Get(key); found {
+		return cached.(*entity.UserSession), nil
+	}
+
+	q := &query.GetUserSession{SessionID: sessionID}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		return nil, err
+	}
+
+	ctx.Engine().Cache().Set(key, q.Result, sessionCacheDuration)
+	return q.Result, nil
+}
+
+// TouchUserSession saves when and from where the session was last seen, at most once every few minutes unless the client changed
+func TouchUserSession(ctx *web.Context, session *entity.UserSession) error {
+	ipAddress := ctx.Request.ClientIP()
+	userAgent := ctx.Request.GetHeader("User-Agent")
+	if time.Since(session.LastSeenAt) < sessionTouchInterval && session.IPAddress == ipAddress && session.UserAgent == userAgent {
+		return nil
+	}
+
+	if err := bus.Dispatch(ctx, &cmd.TouchUserSession{
+		SessionID: session.SessionID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}); err != nil {
+		return err
+	}
+
+	// cached sessions are shared between requests, the next one reloads it instead
+	ForgetUserSessions(ctx, session.SessionID)
+	return nil
+}
+
+// ForgetUserSessions removes given sessions from the cache, so that their revocation takes effect right away on this instance
+func ForgetUserSessions(ctx *web.Context, sessionIDs ...string) {
+	for _, sessionID := range sessionIDs {
+		ctx.Engine().Cache().Delete(sessionCacheKey(sessionID))
+	}
+}
+
+// RevokeUserSession signs given user out of the browser of given session
+func RevokeUserSession(ctx *web.Context, userID, id int) error {
+	revoke := &cmd.RevokeUserSession{UserID: userID, ID: id}
+	if err := bus.Dispatch(ctx, revoke); err != nil {
+		return err
+	}
+	ForgetUserSessions(ctx, revoke.Result)
+	return nil
+}
+
+// RevokeUserSessions signs given user out of every browser, except the one of exceptSessionID when it's set
+func RevokeUserSessions(ctx *web.Context, userID int, exceptSessionID string) error {
+	revoke := &cmd.RevokeUserSessions{UserID: userID, ExceptSessionID: exceptSessionID}
+	if err := bus.Dispatch(ctx, revoke); err != nil {
+		return err
+	}
+	ForgetUserSessions(ctx, revoke.Result...)
+	return nil
+}
@@ -0,0 +1,137 @@
+package webutil
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/totp"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// twoFactorChallengeExpiration is how long users have to type their code after the first sign-in step
+const twoFactorChallengeExpiration = 5 * time.Minute
+
+// MaxTwoFactorAttempts is how many wrong codes in a row are allowed before only recovery codes are accepted
+const MaxTwoFactorAttempts = 5
+
+// RecoveryCodesCount is how many recovery codes are generated at once
+const RecoveryCodesCount = 10
+
+// GenerateRecoveryCodes returns new single-use recovery codes, formatted as xxxxx-xxxxx to be easier to copy
+func GenerateRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodesCount)
+	for i := range codes {
+		code := strings.ToLower(rand.String(10))
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes
+}
+
+// HashRecoveryCode returns what is stored instead of the recovery code itself
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return hashSecret(code)
+}
+
+// HashRecoveryCodes returns the hashes of given recovery codes
+func HashRecoveryCodes(codes []string) []string {
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = HashRecoveryCode(code)
+	}
+	return hashes
+}
+
+// CheckTwoFactorCode returns true if code is either a valid TOTP code or an unused recovery code of the user
+// Each TOTP code and recovery code is only accepted once, and TOTP codes stop being accepted after too many wrong ones
+func CheckTwoFactorCode(ctx *web.Context, twoFactor *entity.UserTwoFactor, code string) (bool, error) {
+	if !twoFactor.IsEnabled() {
+		return false, nil
+	}
+
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		if twoFactor.FailedAttempts < MaxTwoFactorAttempts {
+			if step, ok := totp.Validate(twoFactor.Secret, code, time.Now()); ok {
+				err := bus.Dispatch(ctx, &cmd.UseTwoFactorStep{UserID: twoFactor.UserID, Step: step})
+				if err == nil {
+					return true, nil
+				}
+				if errors.Cause(err) != app.ErrNotFound {
+					return false, err
+				}
+			}
+		}
+	} else {
+		err := bus.Dispatch(ctx, &cmd.UseRecoveryCode{UserID: twoFactor.UserID, CodeHash: HashRecoveryCode(code)})
+		if err == nil {
+			return true, nil
+		}
+		if errors.Cause(err) != app.ErrNotFound {
+			return false, err
+		}
+	}
+
+	if err := bus.Dispatch(ctx, &cmd.RecordTwoFactorFailure{UserID: twoFactor.UserID}); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+func isTOTPCode(code string) bool {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// StartTwoFactorChallenge remembers who is signing in until they type their two-factor code
+func StartTwoFactorChallenge(ctx *web.Context, user *entity.User, redirect string) {
+	expiresAt := time.Now().Add(twoFactorChallengeExpiration)
+	token, err := jwt.Encode(jwt.TwoFactorClaims{
+		UserID:   user.ID,
+		TenantID: ctx.Tenant().ID,
+		Redirect: redirect,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(expiresAt),
+		},
+	})
+	if err != nil {
+		panic(errors.Wrap(err, "failed to start two-factor challenge"))
+	}
+
+	ctx.AddCookie(web.CookieTwoFactorName, token, expiresAt)
+}
+
+// GetTwoFactorChallenge returns who is signing in, or nil if there's no ongoing two-factor challenge
+func GetTwoFactorChallenge(ctx *web.Context) *jwt.TwoFactorClaims {
+	cookie, err := ctx.Request.Cookie(web.CookieTwoFactorName)
+	if err != nil {
+		return nil
+	}
+
+	claims, err := jwt.DecodeTwoFactorClaims(cookie.Value)
+	if err != nil || claims.TenantID != ctx.Tenant().ID {
+		return nil
+	}
+	return claims
+}
+
+// EndTwoFactorChallenge removes the two-factor challenge cookie
+func EndTwoFactorChallenge(ctx *web.Context) {
+	ctx.RemoveCookie(web.CookieTwoFactorName)
+}
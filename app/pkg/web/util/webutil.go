@@ -4,21 +4,41 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 )
 
-func encode(user *entity.User) string {
+// authExpiration is how long users stay signed in on a browser
+const authExpiration = 365 * 24 * time.Hour
+
+func encode(ctx *web.Context, user *entity.User) string {
+	sessionID := rand.String(48)
+	expiresAt := time.Now().Add(authExpiration)
+
+	if err := bus.Dispatch(ctx, &cmd.CreateUserSession{
+		UserID:    user.ID,
+		SessionID: sessionID,
+		IPAddress: ctx.Request.ClientIP(),
+		UserAgent: ctx.Request.GetHeader("User-Agent"),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		panic(errors.Wrap(err, "failed to create user session"))
+	}
+
 	token, err := jwt.Encode(jwt.FiderClaims{
 		UserID:    user.ID,
 		UserName:  user.Name,
 		UserEmail: user.Email,
 		Origin:    jwt.FiderClaimsOriginUI,
+		SessionID: sessionID,
 		Metadata: jwt.Metadata{
-			ExpiresAt: jwt.Time(time.Now().Add(365 * 24 * time.Hour)),
+			ExpiresAt: jwt.Time(expiresAt),
 		},
 	})
 
@@ -29,14 +49,14 @@ func encode(user *entity.User) string {
 	return token
 }
 
-// AddAuthUserCookie generates Auth Token and adds a cookie
+// AddAuthUserCookie starts a new session for the user and adds its Auth Token to a cookie
 func AddAuthUserCookie(ctx *web.Context, user *entity.User) {
-	AddAuthTokenCookie(ctx, encode(user))
+	AddAuthTokenCookie(ctx, encode(ctx, user))
 }
 
 // AddAuthTokenCookie adds given token to a cookie
 func AddAuthTokenCookie(ctx *web.Context, token string) {
-	expiresAt := time.Now().Add(365 * 24 * time.Hour)
+	expiresAt := time.Now().Add(authExpiration)
 	ctx.AddCookie(web.CookieAuthName, token, expiresAt)
 }
 
@@ -45,7 +65,7 @@ func SetSignUpAuthCookie(ctx *web.Context, user *entity.User) {
 	http.SetCookie(&ctx.Response, &http.Cookie{
 		Name:     web.CookieSignUpAuthName,
 		Domain:   env.MultiTenantDomain(),
-		Value:    encode(user),
+		Value:    encode(ctx, user),
 		HttpOnly: true,
 		Path:     "/",
 		Expires:  time.Now().Add(5 * time.Minute),
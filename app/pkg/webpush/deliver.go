@@ -0,0 +1,60 @@
+package webpush
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+)
+
+// Deliver sends the message to every push subscription of the given users and returns the subscriptions worth retrying
+func Deliver(ctx context.Context, userIDs []int, msg *Message) []*entity.PushSubscription {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	q := &query.GetPushSubscriptionsByUsers{UserIDs: userIDs}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		log.Error(ctx, err)
+		return nil
+	}
+
+	return DeliverTo(ctx, q.Result, msg)
+}
+
+// DeliverTo sends the message to the given subscriptions, recording the outcome of each one and removing the ones
+// that expired. It returns the subscriptions that failed with an error worth retrying.
+func DeliverTo(ctx context.Context, subs []*entity.PushSubscription, msg *Message) []*entity.PushSubscription {
+	failed := make([]*entity.PushSubscription, 0)
+	for _, sub := range subs {
+		pushSub := &Subscription{
+			Endpoint: sub.Endpoint,
+		}
+		pushSub.Keys.P256dh = sub.KeyP256dh
+		pushSub.Keys.Auth = sub.KeyAuth
+
+		err := SendNotification(ctx, pushSub, msg.Notification, msg.Options)
+		if err != nil && IsSubscriptionExpired(err) {
+			bus.Dispatch(ctx, &cmd.DeletePushSubscriptionByEndpoint{
+				Endpoint: sub.Endpoint,
+			})
+			continue
+		}
+
+		if err := bus.Dispatch(ctx, &cmd.RecordPushDelivery{SubscriptionID: sub.ID, Success: err == nil}); err != nil {
+			log.Error(ctx, err)
+		}
+
+		if err != nil {
+			log.Warn(ctx, fmt.Sprintf("failed to send push notification: %s", err.Error()))
+			if IsRetryable(err) {
+				failed = append(failed, sub)
+			}
+		}
+	}
+	return failed
+}
@@ -16,8 +16,10 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sync"
 	"time"
 
@@ -147,7 +149,43 @@ func GetPublicKeyBase64() string {
 	return keys.publicB64
 }
 
-func SendNotification(ctx context.Context, sub *Subscription, notification *Notification, ttl int) error {
+// Urgency tells the push service how soon the message has to reach the device, lower urgencies save battery
+type Urgency string
+
+const (
+	UrgencyVeryLow Urgency = "very-low"
+	UrgencyLow     Urgency = "low"
+	UrgencyNormal  Urgency = "normal"
+	UrgencyHigh    Urgency = "high"
+)
+
+// Options are the delivery headers of a push message
+type Options struct {
+	// TTL is how many seconds the push service keeps the message while the device is offline
+	TTL     int     `json:"ttl"`
+	Urgency Urgency `json:"urgency,omitempty"`
+	// Topic replaces a message with the same topic that was not delivered yet
+	Topic string `json:"topic,omitempty"`
+}
+
+// Message is a notification together with how it is delivered
+type Message struct {
+	Notification *Notification `json:"notification"`
+	Options      Options       `json:"options"`
+}
+
+var regexTopic = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Topic returns a valid Topic header for the given notification tag, which has to be at most 32 URL-safe base64 characters
+func Topic(tag string) string {
+	if tag == "" || regexTopic.MatchString(tag) {
+		return tag
+	}
+	hash := sha256.Sum256([]byte(tag))
+	return base64.RawURLEncoding.EncodeToString(hash[:])[:32]
+}
+
+func SendNotification(ctx context.Context, sub *Subscription, notification *Notification, opts Options) error {
 	keys, err := GetVAPIDKeys()
 	if err != nil {
 		return err
@@ -176,8 +214,15 @@ func SendNotification(ctx context.Context, sub *Subscription, notification *Noti
 	req.Header.Set("Content-Type", "application/octet-stream")
 	req.Header.Set("Content-Encoding", "aes128gcm")
 	req.Header.Set("Authorization", vapidHeader)
-	req.Header.Set("TTL", fmt.Sprintf("%d", ttl))
-	req.Header.Set("Urgency", "normal")
+	urgency := opts.Urgency
+	if urgency == "" {
+		urgency = UrgencyNormal
+	}
+	req.Header.Set("TTL", fmt.Sprintf("%d", opts.TTL))
+	req.Header.Set("Urgency", string(urgency))
+	if topic := Topic(opts.Topic); topic != "" {
+		req.Header.Set("Topic", topic)
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -195,7 +240,35 @@ func SendNotification(ctx context.Context, sub *Subscription, notification *Noti
 		return &SubscriptionExpiredError{Endpoint: sub.Endpoint}
 	}
 
-	return errors.New("push notification failed with status %d: %s", resp.StatusCode, string(body))
+	return &DeliveryError{StatusCode: resp.StatusCode, Body: string(body)}
+}
+
+// DeliveryError is returned when the push service did not accept the message
+type DeliveryError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("push notification failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsRetryable returns true if sending the message again later may succeed: the push service could not be reached,
+// was rate limiting or failed on its side
+func IsRetryable(err error) bool {
+	if err == nil || IsSubscriptionExpired(err) {
+		return false
+	}
+	if deliveryErr, ok := err.(*DeliveryError); ok {
+		return deliveryErr.StatusCode == http.StatusTooManyRequests || deliveryErr.StatusCode >= 500
+	}
+	return isNetworkError(err)
+}
+
+// isNetworkError returns true if the push service could not be reached
+func isNetworkError(err error) bool {
+	_, ok := errors.Cause(err).(net.Error)
+	return ok
 }
 
 type SubscriptionExpiredError struct {
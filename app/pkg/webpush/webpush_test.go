@@ -0,0 +1,36 @@
+package webpush_test
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/webpush"
+)
+
+func TestTopic(t *testing.T) {
+	RegisterT(t)
+
+	Expect(webpush.Topic("")).Equals("")
+	Expect(webpush.Topic("comments-12")).Equals("comments-12")
+	Expect(webpush.Topic("report_outcome-post-4")).Equals("report_outcome-post-4")
+
+	long := webpush.Topic("report-outcome-comment-1234567890123")
+	Expect(long).HasLen(32)
+	Expect(long).Equals(webpush.Topic("report-outcome-comment-1234567890123"))
+	Expect(webpush.Topic("status:12")).HasLen(32)
+}
+
+func TestIsRetryable(t *testing.T) {
+	RegisterT(t)
+
+	Expect(webpush.IsRetryable(nil)).IsFalse()
+	Expect(webpush.IsRetryable(&webpush.SubscriptionExpiredError{Endpoint: "https://push.example.com/1"})).IsFalse()
+	Expect(webpush.IsRetryable(&webpush.DeliveryError{StatusCode: 400})).IsFalse()
+	Expect(webpush.IsRetryable(&webpush.DeliveryError{StatusCode: 413})).IsFalse()
+	Expect(webpush.IsRetryable(&webpush.DeliveryError{StatusCode: 429})).IsTrue()
+	Expect(webpush.IsRetryable(&webpush.DeliveryError{StatusCode: 503})).IsTrue()
+	Expect(webpush.IsRetryable(errors.Wrap(&net.OpError{Op: "dial"}, "failed to send push notification"))).IsTrue()
+	Expect(webpush.IsRetryable(errors.New("failed to encrypt payload"))).IsFalse()
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
@@ -16,6 +17,7 @@ type Context struct {
 	context.Context
 	workerID string
 	taskName string
+	worker   Worker
 }
 
 // NewContext creates a new context
@@ -52,6 +54,17 @@ func (c *Context) TaskName() string {
 	return c.taskName
 }
 
+// EnqueueAfter schedules a follow-up task on the worker running the current one, with the same origin.
+// It returns false when the current task is not run by a worker.
+func (c *Context) EnqueueAfter(delay time.Duration, task Task) bool {
+	if c.worker == nil {
+		return false
+	}
+	task.OriginContext = c
+	c.worker.EnqueueAfter(delay, task)
+	return true
+}
+
 // Set saves data in the context.
 func (c *Context) Set(key any, val any) {
 	c.Context = context.WithValue(c.Context, key, val)
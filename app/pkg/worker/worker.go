@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,6 +25,7 @@ type Task struct {
 type Worker interface {
 	Run(id string)
 	Enqueue(task Task)
+	EnqueueAfter(delay time.Duration, task Task)
 	Use(middleware MiddlewareFunc)
 	Length() int64
 	Shutdown(ctx context.Context) error
@@ -34,6 +36,10 @@ type BackgroundWorker struct {
 	queue      chan Task
 	len        atomic.Int64
 	middleware MiddlewareFunc
+
+	mu       sync.Mutex
+	delayed  map[*time.Timer]struct{}
+	stopping bool
 }
 
 var maxQueueSize = 100
@@ -49,6 +55,7 @@ func New() *BackgroundWorker {
 	return &BackgroundWorker{
 		Context: ctx,
 		queue:   make(chan Task, maxQueueSize),
+		delayed: make(map[*time.Timer]struct{}),
 		middleware: func(next Job) Job {
 			return next
 		},
@@ -61,12 +68,31 @@ func (w *BackgroundWorker) Run(workerID string) {
 	})
 	for task := range w.queue {
 		c := NewContext(w, workerID, task)
+		c.worker = w
 		_ = w.middleware(task.Job)(c)
 		w.len.Add(-1)
 	}
 }
 
 func (w *BackgroundWorker) Shutdown(ctx context.Context) error {
+	// delayed tasks are not waited for, they could be minutes away
+	w.mu.Lock()
+	w.stopping = true
+	cancelled := 0
+	for timer := range w.delayed {
+		if timer.Stop() {
+			cancelled++
+		}
+	}
+	w.delayed = make(map[*time.Timer]struct{})
+	w.mu.Unlock()
+
+	if cancelled > 0 {
+		log.Warnf(w, "Cancelled @{Count} delayed task(s)", dto.Props{
+			"Count": cancelled,
+		})
+	}
+
 	if w.Length() > 0 {
 		ticker := time.NewTicker(500 * time.Millisecond)
 		defer ticker.Stop()
@@ -95,6 +121,31 @@ func (w *BackgroundWorker) Enqueue(task Task) {
 	w.queue <- task
 }
 
+// EnqueueAfter adds the task to the queue once delay has passed. It is only counted as pending from then on,
+// so shutdown doesn't wait for it and tasks that are still waiting are cancelled instead
+func (w *BackgroundWorker) EnqueueAfter(delay time.Duration, task Task) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopping {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		w.mu.Lock()
+		delete(w.delayed, timer)
+		if w.stopping {
+			w.mu.Unlock()
+			return
+		}
+		w.len.Add(1)
+		w.mu.Unlock()
+
+		w.queue <- task
+	})
+	w.delayed[timer] = struct{}{}
+}
+
 func (w *BackgroundWorker) Length() int64 {
 	return w.len.Load()
 }
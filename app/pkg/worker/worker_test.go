@@ -3,6 +3,7 @@ package worker_test
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -76,3 +77,73 @@ func TestBackgroundWorker_ShutdownWithRunningTasks(t *testing.T) {
 	go w.Run("worker-1")
 	Expect(w.Shutdown(ctx)).IsNil()
 }
+
+func TestBackgroundWorker_EnqueueAfter(t *testing.T) {
+	RegisterT(t)
+
+	var attempts int
+	mu := &sync.RWMutex{}
+
+	w := worker.New()
+	var retry worker.Task
+	retry = worker.Task{
+		Name: "Retry Something",
+		Job: func(c *worker.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts++
+			if attempts < 3 {
+				Expect(c.EnqueueAfter(10*time.Millisecond, retry)).IsTrue()
+			}
+			return nil
+		},
+	}
+	w.Enqueue(retry)
+
+	go w.Run("worker-1")
+	Expect(func() int {
+		mu.RLock()
+		defer mu.RUnlock()
+		return attempts
+	}).EventuallyEquals(3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	Expect(w.Shutdown(ctx)).IsNil()
+}
+
+func TestBackgroundWorker_Shutdown_CancelsDelayedTasks(t *testing.T) {
+	RegisterT(t)
+
+	var ran atomic.Bool
+	w := worker.New()
+	w.EnqueueAfter(50*time.Millisecond, worker.Task{
+		Name: "Retry Something",
+		Job: func(c *worker.Context) error {
+			ran.Store(true)
+			return nil
+		},
+	})
+	go w.Run("worker-1")
+
+	// shutdown doesn't wait for the delayed task
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	Expect(w.Shutdown(ctx)).IsNil()
+
+	time.Sleep(100 * time.Millisecond)
+	Expect(ran.Load()).IsFalse()
+	Expect(w.Length()).Equals(int64(0))
+
+	// and nothing is scheduled once it has started
+	w.EnqueueAfter(time.Millisecond, dummyTask)
+	time.Sleep(20 * time.Millisecond)
+	Expect(w.Length()).Equals(int64(0))
+}
+
+func TestContext_EnqueueAfter_WithoutWorker(t *testing.T) {
+	RegisterT(t)
+
+	c := worker.NewContext(context.Background(), "0", dummyTask)
+	Expect(c.EnqueueAfter(time.Second, dummyTask)).IsFalse()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	ses "github.com/aws/aws-sdk-go/service/sesv2"
@@ -51,11 +52,13 @@ func (s Service) Init() {
 	}
 
 	sesClient = ses.New(awsSession)
-	bus.AddListener(sendMail)
+	bus.AddListener(email.QueueMail)
+	bus.AddHandler(sendMail)
 	bus.AddHandler(fetchRecentSupressions)
 }
 
-func sendMail(ctx context.Context, c *cmd.SendMail) {
+func sendMail(ctx context.Context, d *cmd.DeliverMail) error {
+	c := d.Mail
 	if c.Props == nil {
 		c.Props = dto.Props{}
 	}
@@ -66,7 +69,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 	for _, to := range c.To {
 		if to.Address == "" {
-			return
+			return nil
 		}
 
 		if !email.CanSendTo(to.Address) {
@@ -74,7 +77,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 				"Name":    to.Name,
 				"Address": to.Address,
 			})
-			return
+			return nil
 		}
 
 		log.Debugf(ctx, "Sending email to @{Address} with template @{TemplateName} and params @{Props}.", dto.Props{
@@ -83,7 +86,12 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			"Props":        to.Props,
 		})
 
-		message := email.RenderMessage(ctx, c.TemplateName, c.From.Address, c.Props.Merge(to.Props))
+		replyTo := c.From.Address
+		if to.ReplyTo != "" {
+			replyTo = to.ReplyTo
+		}
+
+		message := email.RenderMessage(ctx, c.TemplateName, replyTo, c.Props.Merge(to.Props))
 		tags := []*ses.MessageTag{
 			{Name: aws.String("template"), Value: aws.String(c.TemplateName)},
 		}
@@ -116,16 +124,30 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			},
 			EmailTags: tags,
 		}
+		if replyTo != c.From.Address {
+			input.ReplyToAddresses = []*string{aws.String(replyTo)}
+		}
+		if to.Unsubscribe != "" {
+			input.Content.Simple.Headers = []*ses.MessageHeader{
+				{Name: aws.String("List-Unsubscribe"), Value: aws.String("<" + to.Unsubscribe + ">")},
+				{Name: aws.String("List-Unsubscribe-Post"), Value: aws.String("List-Unsubscribe=One-Click")},
+			}
+		}
 
 		result, err := sesClient.SendEmailWithContext(ctx, input)
 		if err != nil {
-			panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
+			// throttling and service errors are worth retrying, rejected messages are not
+			if awsErr, ok := err.(awserr.Error); ok && (awsErr.Code() == ses.ErrCodeMessageRejected || awsErr.Code() == ses.ErrCodeBadRequestException) {
+				err = email.Permanent(err)
+			}
+			return errors.Wrap(err, "failed to send email with template %s", c.TemplateName)
 		}
 
 		log.Debugf(ctx, "Email sent with ID @{MessageId}.", dto.Props{
 			"MessageId": *result.MessageId,
 		})
 	}
+	return nil
 }
 
 func fetchRecentSupressions(ctx context.Context, q *query.FetchRecentSupressions) error {
@@ -2,9 +2,17 @@ package email_test
 
 import (
 	"context"
+	"encoding/json"
+	"html/template"
 	"testing"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
 
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
@@ -54,6 +62,68 @@ Hello World Fider!
 </html>`)
 }
 
+func TestRenderMessage_WithOverride(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	var getTemplate *query.GetEmailTemplate
+	bus.AddHandler(func(ctx context.Context, q *query.GetEmailTemplate) error {
+		getTemplate = q
+		q.Result = &entity.EmailTemplate{
+			Name:    "signin_email",
+			Locale:  "en",
+			Subject: "Sign in to {{ .siteName }}",
+			Body:    "<tr><td>Click {{ .link | html }} to sign in</td></tr>",
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{Subdomain: "got"})
+	message := email.RenderMessage(ctx, "signin_email", email.NoReply, dto.Props{
+		"siteName": "Fider",
+		"link":     "<a href='http://got.test.fider.io/signin/verify?k=123'>here</a>",
+	})
+
+	Expect(getTemplate.Name).Equals("signin_email")
+	Expect(getTemplate.Locale).Equals("en")
+	Expect(message.Subject).Equals("Sign in to Fider")
+	Expect(message.Body).ContainsSubstring("<tr><td>Click <a href='http://got.test.fider.io/signin/verify?k=123'>here</a> to sign in</td></tr>")
+}
+
+func TestRenderMessage_BrokenOverride_FallsBackToBuiltin(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetEmailTemplate) error {
+		q.Result = &entity.EmailTemplate{
+			Name:    "signin_email",
+			Locale:  "en",
+			Subject: "Custom subject for {{ .siteName }}",
+			Body:    "{{ template \"missing\" }}",
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(context.Background(), app.TenantCtxKey, &entity.Tenant{Subdomain: "got"})
+	message := email.RenderMessage(ctx, "signin_email", email.NoReply, dto.Props{
+		"siteName": "Fider",
+		"link":     "<a href='http://got.test.fider.io/signin/verify?k=123'>here</a>",
+	})
+
+	Expect(message.Subject).Equals("Sign in to Fider")
+	Expect(message.Body).ContainsSubstring("Fider")
+	Expect(message.Body).ContainsSubstring("<a href='http://got.test.fider.io/signin/verify?k=123'>here</a>")
+}
+
+func TestBuiltinTemplate(t *testing.T) {
+	RegisterT(t)
+
+	subject, body, err := email.BuiltinTemplate("echo_test")
+	Expect(err).IsNil()
+	Expect(subject).Equals("Message to: {{ .name }}")
+	Expect(body).Equals("Hello World {{ .name }}!")
+}
+
 func TestCanSendTo(t *testing.T) {
 	RegisterT(t)
 
@@ -161,3 +231,166 @@ func TestRecipient_String(t *testing.T) {
 		Expect(r.String()).Equals(testCase.expected)
 	}
 }
+
+func TestQueueMail_QueuesEachRecipient(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	queued := make([]*cmd.QueueMail, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueMail) error {
+		queued = append(queued, c)
+		return nil
+	})
+
+	email.QueueMail(context.Background(), &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			dto.NewRecipient("Jon Snow", "jon.snow@got.com", dto.Props{"name": "Jon"}),
+			dto.NewRecipient("No Email", "", dto.Props{}),
+			dto.NewRecipient("Arya Stark", "arya.stark@got.com", dto.Props{"name": "Arya"}),
+		},
+		TemplateName: "echo_test",
+		Props:        dto.Props{"content": template.HTML("<p>Hello</p>")},
+	})
+
+	Expect(queued).HasLen(2)
+	Expect(queued[0].TemplateName).Equals("echo_test")
+	Expect(queued[0].Recipient).Equals("jon.snow@got.com")
+	Expect(queued[1].Recipient).Equals("arya.stark@got.com")
+
+	mail, err := cmd.UnmarshalDeferredMail(queued[1].Payload)
+	Expect(err).IsNil()
+	Expect(mail.To).HasLen(1)
+	Expect(mail.To[0].Props["name"]).Equals("Arya")
+	Expect(mail.Props["content"]).Equals(template.HTML("<p>Hello</p>"))
+}
+
+func TestQueueMail_DeliversWhenQueueFails(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueMail) error {
+		return errors.New("database is down")
+	})
+	delivered := make([]*cmd.DeliverMail, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeliverMail) error {
+		delivered = append(delivered, c)
+		return nil
+	})
+
+	email.QueueMail(context.Background(), &cmd.SendMail{
+		To:           []dto.Recipient{dto.NewRecipient("Jon Snow", "jon.snow@got.com", dto.Props{})},
+		TemplateName: "echo_test",
+	})
+
+	Expect(delivered).HasLen(1)
+	Expect(delivered[0].Mail.To[0].Address).Equals("jon.snow@got.com")
+}
+
+func TestQueueMail_DeliversSignInRightAway(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	queued := make([]*cmd.QueueMail, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueMail) error {
+		queued = append(queued, c)
+		return nil
+	})
+	delivered := make([]*cmd.DeliverMail, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeliverMail) error {
+		delivered = append(delivered, c)
+		return nil
+	})
+
+	email.QueueMail(context.Background(), &cmd.SendMail{
+		To:           []dto.Recipient{dto.NewRecipient("Jon Snow", "jon.snow@got.com", dto.Props{})},
+		TemplateName: "signin_email",
+	})
+
+	Expect(delivered).HasLen(1)
+	Expect(delivered[0].Mail.To[0].Address).Equals("jon.snow@got.com")
+	Expect(queued).HasLen(0)
+}
+
+func TestQueueMail_QueuesSignInWhenDeliveryFails(t *testing.T) {
+	RegisterT(t)
+	bus.Init()
+
+	queued := make([]*cmd.QueueMail, 0)
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueMail) error {
+		queued = append(queued, c)
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeliverMail) error {
+		return errors.New("connection refused")
+	})
+
+	email.QueueMail(context.Background(), &cmd.SendMail{
+		To:           []dto.Recipient{dto.NewRecipient("Jon Snow", "jon.snow@got.com", dto.Props{})},
+		TemplateName: "signin_email",
+	})
+
+	Expect(queued).HasLen(1)
+	Expect(queued[0].Recipient).Equals("jon.snow@got.com")
+}
+
+func TestIsPermanent(t *testing.T) {
+	RegisterT(t)
+
+	err := errors.New("550 No such user")
+	Expect(email.IsPermanent(err)).IsFalse()
+	Expect(email.IsPermanent(email.Permanent(err))).IsTrue()
+	Expect(email.IsPermanent(errors.Wrap(email.Permanent(err), "failed to send email"))).IsTrue()
+	Expect(email.Permanent(nil)).IsNil()
+}
+
+func TestParseMailgunEvent(t *testing.T) {
+	RegisterT(t)
+
+	testCases := []struct {
+		body    string
+		address string
+	}{
+		{`{"event-data": {"event": "failed", "severity": "permanent", "recipient": "jon.snow@got.com"}}`, "jon.snow@got.com"},
+		{`{"event-data": {"event": "complained", "recipient": "arya.stark@got.com"}}`, "arya.stark@got.com"},
+		{`{"event-data": {"event": "failed", "severity": "temporary", "recipient": "jon.snow@got.com"}}`, ""},
+		{`{"event-data": {"event": "delivered", "recipient": "jon.snow@got.com"}}`, ""},
+	}
+
+	for _, testCase := range testCases {
+		address, err := email.ParseMailgunEvent([]byte(testCase.body))
+		Expect(err).IsNil()
+		Expect(address).Equals(testCase.address)
+	}
+
+	_, err := email.ParseMailgunEvent([]byte("not json"))
+	Expect(err).IsNotNil()
+}
+
+func TestParseSESEvents(t *testing.T) {
+	RegisterT(t)
+
+	snsMessage := func(message string) []byte {
+		body, _ := json.Marshal(map[string]string{"Type": "Notification", "Message": message})
+		return body
+	}
+
+	events, err := email.ParseSESEvents(snsMessage(`{"notificationType": "Bounce", "bounce": {"bounceType": "Permanent", "bouncedRecipients": [{"emailAddress": "jon.snow@got.com"}, {"emailAddress": "arya.stark@got.com"}]}}`))
+	Expect(err).IsNil()
+	Expect(events.Addresses).Equals([]string{"jon.snow@got.com", "arya.stark@got.com"})
+
+	events, err = email.ParseSESEvents(snsMessage(`{"eventType": "Complaint", "complaint": {"complainedRecipients": [{"emailAddress": "jon.snow@got.com"}]}}`))
+	Expect(err).IsNil()
+	Expect(events.Addresses).Equals([]string{"jon.snow@got.com"})
+
+	events, err = email.ParseSESEvents(snsMessage(`{"notificationType": "Bounce", "bounce": {"bounceType": "Transient", "bouncedRecipients": [{"emailAddress": "jon.snow@got.com"}]}}`))
+	Expect(err).IsNil()
+	Expect(events.Addresses).HasLen(0)
+
+	events, err = email.ParseSESEvents([]byte(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"}`))
+	Expect(err).IsNil()
+	Expect(events.SubscribeURL).Equals("https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription")
+
+	_, err = email.ParseSESEvents([]byte(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://evil.com/confirm"}`))
+	Expect(err).IsNotNil()
+}
@@ -43,6 +43,7 @@ func (s Service) Enabled() bool {
 func (s Service) Init() {
 	MessageHistory = make([]*HistoryItem, 0)
 	bus.AddListener(sendMail)
+	bus.AddHandler(deliverMail)
 	bus.AddHandler(fetchRecentSupressions)
 }
 
@@ -51,6 +52,12 @@ func fetchRecentSupressions(ctx context.Context, c *query.FetchRecentSupressions
 	return nil
 }
 
+// deliverMail records the emails sent by the outbound queue, which sendMail records directly since there's no queue in tests
+func deliverMail(ctx context.Context, c *cmd.DeliverMail) error {
+	sendMail(ctx, c.Mail)
+	return nil
+}
+
 func sendMail(ctx context.Context, c *cmd.SendMail) {
 	if c.Props == nil {
 		c.Props = dto.Props{}
@@ -0,0 +1,87 @@
+package email
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/sns"
+)
+
+// ParseMailgunEvent returns the address that should no longer receive emails according to a Mailgun webhook event
+// Only permanent failures and complaints suppress an address, an empty string is returned for all other events
+func ParseMailgunEvent(body []byte) (string, error) {
+	var payload struct {
+		EventData struct {
+			Event     string `json:"event"`
+			Severity  string `json:"severity"`
+			Recipient string `json:"recipient"`
+		} `json:"event-data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", errors.Wrap(err, "failed to parse Mailgun event")
+	}
+
+	event := payload.EventData
+	if (event.Event == "failed" && event.Severity == "permanent") || event.Event == "complained" {
+		return strings.TrimSpace(event.Recipient), nil
+	}
+	return "", nil
+}
+
+// SESEvents is what Amazon SES publishes through SNS about the emails it sent
+type SESEvents struct {
+	// SubscribeURL is set when SNS asks to confirm the subscription of the endpoint
+	SubscribeURL string
+	// Addresses are the recipients of permanent bounces and complaints
+	Addresses []string
+}
+
+type sesRecipient struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+// ParseSESEvents returns the addresses that should no longer receive emails according to an SES notification or event
+func ParseSESEvents(body []byte) (*SESEvents, error) {
+	envelope, err := sns.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.IsSubscriptionConfirmation() {
+		return &SESEvents{SubscribeURL: envelope.SubscribeURL}, nil
+	}
+
+	// notifications have a notificationType while configuration set events have an eventType
+	var message struct {
+		NotificationType string `json:"notificationType"`
+		EventType        string `json:"eventType"`
+		Bounce           struct {
+			BounceType        string         `json:"bounceType"`
+			BouncedRecipients []sesRecipient `json:"bouncedRecipients"`
+		} `json:"bounce"`
+		Complaint struct {
+			ComplainedRecipients []sesRecipient `json:"complainedRecipients"`
+		} `json:"complaint"`
+	}
+	if err := json.Unmarshal([]byte(envelope.Message), &message); err != nil {
+		return nil, errors.Wrap(err, "failed to parse SES notification")
+	}
+
+	var recipients []sesRecipient
+	switch message.NotificationType + message.EventType {
+	case "Bounce":
+		if message.Bounce.BounceType == "Permanent" {
+			recipients = message.Bounce.BouncedRecipients
+		}
+	case "Complaint":
+		recipients = message.Complaint.ComplainedRecipients
+	}
+
+	events := &SESEvents{Addresses: make([]string, 0, len(recipients))}
+	for _, r := range recipients {
+		if address := strings.TrimSpace(r.EmailAddress); address != "" {
+			events.Addresses = append(events.Addresses, address)
+		}
+	}
+	return events, nil
+}
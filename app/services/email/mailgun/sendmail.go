@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -19,9 +20,10 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
 )
 
-func sendMail(ctx context.Context, c *cmd.SendMail) {
+func sendMail(ctx context.Context, d *cmd.DeliverMail) error {
+	c := d.Mail
 	if len(c.To) == 0 {
-		return
+		return nil
 	}
 
 	if c.Props == nil {
@@ -34,6 +36,31 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 	isBatch := len(c.To) > 1
 
+	// Reply-To and List-Unsubscribe can't be set per recipient on a batch, so those are sent one by one
+	if isBatch {
+		for _, to := range c.To {
+			if to.ReplyTo != "" || to.Unsubscribe != "" {
+				for _, r := range c.To {
+					err := sendMail(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
+						From:         c.From,
+						To:           []dto.Recipient{r},
+						TemplateName: c.TemplateName,
+						Props:        c.Props,
+					}})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+	}
+
+	replyTo := c.From.Address
+	if !isBatch && c.To[0].ReplyTo != "" {
+		replyTo = c.To[0].ReplyTo
+	}
+
 	var message *email.Message
 	if isBatch {
 		// Replace recipient specific Go templates variables with Mailgun template variables
@@ -44,15 +71,19 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		}
 		message = email.RenderMessage(ctx, c.TemplateName, c.From.Address, c.Props)
 	} else {
-		message = email.RenderMessage(ctx, c.TemplateName, c.From.Address, c.Props.Merge(c.To[0].Props))
+		message = email.RenderMessage(ctx, c.TemplateName, replyTo, c.Props.Merge(c.To[0].Props))
 	}
 
 	form := url.Values{}
 	form.Add("from", c.From.String())
-	form.Add("h:Reply-To", c.From.Address)
+	form.Add("h:Reply-To", replyTo)
 	form.Add("subject", message.Subject)
 	form.Add("html", message.Body)
 	form.Add("o:tag", fmt.Sprintf("template:%s", c.TemplateName))
+	if !isBatch && c.To[0].Unsubscribe != "" {
+		form.Add("h:List-Unsubscribe", "<"+c.To[0].Unsubscribe+">")
+		form.Add("h:List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+	}
 
 	tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant)
 	if ok && !env.IsSingleHostMode() {
@@ -77,13 +108,13 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 	// If we skipped all recipients, just return
 	if len(recipientVariables) == 0 {
-		return
+		return nil
 	}
 
 	if isBatch {
 		json, err := json.Marshal(recipientVariables)
 		if err != nil {
-			panic(errors.Wrap(err, "failed to marshal recipient variables"))
+			return errors.Wrap(err, "failed to marshal recipient variables")
 		}
 
 		form.Add("recipient-variables", string(json))
@@ -115,9 +146,20 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 	}
 	err := bus.Dispatch(ctx, req)
 	if err != nil {
-		panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
+		return errors.Wrap(err, "failed to send email with template %s", c.TemplateName)
+	}
+
+	// Mailgun answers 429 when it's throttling and 5xx when it's having issues, other errors mean the message was rejected
+	if req.ResponseStatusCode >= 300 {
+		err := errors.New("unexpected status code while sending email with template %s: %d", c.TemplateName, req.ResponseStatusCode)
+		if req.ResponseStatusCode != http.StatusTooManyRequests && req.ResponseStatusCode < 500 {
+			err = email.Permanent(err)
+		}
+		return err
 	}
+
 	log.Debugf(ctx, "Email sent with response code @{StatusCode}.", dto.Props{
 		"StatusCode": req.ResponseStatusCode,
 	})
+	return nil
 }
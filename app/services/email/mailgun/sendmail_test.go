@@ -4,12 +4,14 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/mailgun"
@@ -27,6 +29,9 @@ func reset() {
 		Subdomain: "got",
 	})
 	bus.Init(mailgun.Service{}, httpclientmock.Service{})
+	bus.AddHandler(func(ctx context.Context, q *query.GetEmailTemplate) error {
+		return nil
+	})
 }
 
 func TestSend_Success(t *testing.T) {
@@ -34,7 +39,7 @@ func TestSend_Success(t *testing.T) {
 	env.Config.HostMode = "multi"
 	reset()
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -46,7 +51,7 @@ func TestSend_Success(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(httpclientmock.RequestsHistory).HasLen(1)
 	Expect(httpclientmock.RequestsHistory[0].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
@@ -105,7 +110,7 @@ func TestSend_SkipEmptyAddress(t *testing.T) {
 	RegisterT(t)
 	reset()
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -117,7 +122,7 @@ func TestSend_SkipEmptyAddress(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(httpclientmock.RequestsHistory).HasLen(0)
 }
@@ -127,7 +132,7 @@ func TestSend_SkipUnlistedAddress(t *testing.T) {
 	reset()
 	email.SetAllowlist("^.*@gmail.com$")
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -139,7 +144,7 @@ func TestSend_SkipUnlistedAddress(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(httpclientmock.RequestsHistory).HasLen(0)
 }
@@ -149,7 +154,7 @@ func TestBatch_Success(t *testing.T) {
 	reset()
 	email.SetAllowlist("")
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -168,7 +173,7 @@ func TestBatch_Success(t *testing.T) {
 			},
 		},
 		TemplateName: "echo_test",
-	})
+	}})
 
 	Expect(httpclientmock.RequestsHistory).HasLen(1)
 	Expect(httpclientmock.RequestsHistory[0].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
@@ -227,6 +232,87 @@ Hello World %recipient.name%!
 </html>`)
 }
 
+func TestBatch_WithReplyTo_SendsOneByOne(t *testing.T) {
+	RegisterT(t)
+	reset()
+	email.SetAllowlist("")
+
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			{
+				Name:    "Jon Sow",
+				Address: "jon.snow@got.com",
+				Props:   dto.Props{"name": "Jon"},
+				ReplyTo: "reply+jon@reply.got.com",
+			},
+			{
+				Name:    "Arya Stark",
+				Address: "arya.start@got.com",
+				Props:   dto.Props{"name": "Arya"},
+				ReplyTo: "reply+arya@reply.got.com",
+			},
+		},
+		TemplateName: "echo_test",
+	}})
+
+	Expect(httpclientmock.RequestsHistory).HasLen(2)
+
+	for i, expected := range []struct{ to, replyTo, subject string }{
+		{`"Jon Sow" <jon.snow@got.com>`, "reply+jon@reply.got.com", "Message to: Jon"},
+		{`"Arya Stark" <arya.start@got.com>`, "reply+arya@reply.got.com", "Message to: Arya"},
+	} {
+		bytes, err := io.ReadAll(httpclientmock.RequestsHistory[i].Body)
+		Expect(err).IsNil()
+		values, err := url.ParseQuery(string(bytes))
+		Expect(err).IsNil()
+		Expect(values.Get("to")).Equals(expected.to)
+		Expect(values.Get("h:Reply-To")).Equals(expected.replyTo)
+		Expect(values.Get("subject")).Equals(expected.subject)
+		Expect(values.Get("recipient-variables")).Equals("")
+		Expect(strings.Contains(values.Get("html"), "notification-only address")).IsFalse()
+	}
+}
+
+func TestSend_WithUnsubscribe_SetsListUnsubscribeHeaders(t *testing.T) {
+	RegisterT(t)
+	reset()
+	email.SetAllowlist("")
+
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
+		From: dto.Recipient{Name: "Fider Test"},
+		To: []dto.Recipient{
+			{
+				Name:        "Jon Sow",
+				Address:     "jon.snow@got.com",
+				Props:       dto.Props{"name": "Jon"},
+				Unsubscribe: "http://got.test.fider.io/unsubscribe?token=jon",
+			},
+			{
+				Name:    "Arya Stark",
+				Address: "arya.start@got.com",
+				Props:   dto.Props{"name": "Arya"},
+			},
+		},
+		TemplateName: "echo_test",
+	}})
+
+	Expect(httpclientmock.RequestsHistory).HasLen(2)
+
+	for i, expected := range []struct{ to, unsubscribe, unsubscribePost string }{
+		{`"Jon Sow" <jon.snow@got.com>`, "<http://got.test.fider.io/unsubscribe?token=jon>", "List-Unsubscribe=One-Click"},
+		{`"Arya Stark" <arya.start@got.com>`, "", ""},
+	} {
+		bytes, err := io.ReadAll(httpclientmock.RequestsHistory[i].Body)
+		Expect(err).IsNil()
+		values, err := url.ParseQuery(string(bytes))
+		Expect(err).IsNil()
+		Expect(values.Get("to")).Equals(expected.to)
+		Expect(values.Get("h:List-Unsubscribe")).Equals(expected.unsubscribe)
+		Expect(values.Get("h:List-Unsubscribe-Post")).Equals(expected.unsubscribePost)
+	}
+}
+
 func TestGetBaseURL(t *testing.T) {
 	RegisterT(t)
 	reset()
@@ -247,29 +333,29 @@ func TestGetBaseURL(t *testing.T) {
 
 	// Fall back to US if there is nothing set
 	env.Config.Email.Mailgun.Region = ""
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[0].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 
 	// Return the EU domain for EU, ignore the case
 	env.Config.Email.Mailgun.Region = "EU"
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[1].URL.String()).Equals("https://api.eu.mailgun.net/v3/mydomain.com/messages")
 
 	env.Config.Email.Mailgun.Region = "eu"
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[2].URL.String()).Equals("https://api.eu.mailgun.net/v3/mydomain.com/messages")
 
 	// Return the US domain for US, ignore the case
 	env.Config.Email.Mailgun.Region = "US"
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[3].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 	env.Config.Email.Mailgun.Region = "us"
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[4].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 
 	// Return the US domain if the region is invalid
 	env.Config.Email.Mailgun.Region = "Mars"
-	bus.Publish(ctx, sendMail)
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
 	Expect(httpclientmock.RequestsHistory[5].URL.String()).Equals("https://api.mailgun.net/v3/mydomain.com/messages")
 
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
 )
 
 // Known base URLs
@@ -38,7 +39,8 @@ func (s Service) Enabled() bool {
 }
 
 func (s Service) Init() {
-	bus.AddListener(sendMail)
+	bus.AddListener(email.QueueMail)
+	bus.AddHandler(sendMail)
 	bus.AddHandler(fetchRecentSupressions)
 }
 
@@ -3,12 +3,22 @@ package email
 import (
 	"bytes"
 	"context"
+	"html/template"
 	"strings"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/tpl"
 )
 
+const baseTemplate = "/views/email/base_email.html"
+
 // Message represents what is sent by email
 type Message struct {
 	Subject string
@@ -16,20 +26,47 @@ type Message struct {
 }
 
 // RenderMessage returns the HTML of an email based on template and params
-func RenderMessage(ctx context.Context, templateName string, fromAddress string, params dto.Props) *Message {
-	noreply := false
-	if fromAddress == NoReply {
-		noreply = true
+// replyAddress is where replies to the email go, the footer warns about replying when that's the no-reply address
+// The tenant's override of the template is used when there's one, falling back to the built-in template if it fails to render
+func RenderMessage(ctx context.Context, templateName string, replyAddress string, params dto.Props) *Message {
+	params = params.Merge(dto.Props{
+		"logo":    params["logo"],
+		"noreply": replyAddress == NoReply,
+	})
+
+	if override := getOverride(ctx, templateName); override != nil {
+		message, err := RenderOverride(ctx, override.Subject, override.Body, params)
+		if err == nil {
+			return message
+		}
+		log.Warnf(ctx, "Failed to render override of email template '@{Name}', using the built-in one: @{Error}", dto.Props{
+			"Name":  templateName,
+			"Error": err.Error(),
+		})
 	}
 
-	tmpl := tpl.GetTemplate("/views/email/base_email.html", "/views/email/"+templateName+".html")
-	var bf bytes.Buffer
-	if err := tpl.Render(ctx, tmpl, &bf, params.Merge(dto.Props{
-		"logo":    params["logo"],
-		"noreply": noreply,
-	})); err != nil {
+	tmpl := tpl.GetTemplate(baseTemplate, "/views/email/"+templateName+".html")
+	message, err := render(ctx, tmpl, params)
+	if err != nil {
 		panic(err)
 	}
+	return message
+}
+
+// RenderOverride returns the HTML of an email whose subject and body blocks are given instead of read from a built-in template
+func RenderOverride(ctx context.Context, subject, body string, params dto.Props) (*Message, error) {
+	tmpl, err := tpl.GetOverrideTemplate(baseTemplate, subject, body)
+	if err != nil {
+		return nil, err
+	}
+	return render(ctx, tmpl, params)
+}
+
+func render(ctx context.Context, tmpl *template.Template, params dto.Props) (*Message, error) {
+	var bf bytes.Buffer
+	if err := tpl.Render(ctx, tmpl, &bf, params); err != nil {
+		return nil, err
+	}
 
 	content := strings.ReplaceAll(strings.ReplaceAll(bf.String(), "\r\n", "\n"), "\r", "\n")
 	lines := strings.Split(content, "\n")
@@ -39,5 +76,22 @@ func RenderMessage(ctx context.Context, templateName string, fromAddress string,
 	return &Message{
 		Subject: subject,
 		Body:    body,
+	}, nil
+}
+
+// getOverride returns the tenant's override of the template in the current locale, if any
+func getOverride(ctx context.Context, templateName string) *entity.EmailTemplate {
+	if _, ok := enum.GetEmailTemplate(templateName); !ok {
+		return nil
+	}
+	if tenant, ok := ctx.Value(app.TenantCtxKey).(*entity.Tenant); !ok || tenant == nil {
+		return nil
+	}
+
+	q := &query.GetEmailTemplate{Name: templateName, Locale: i18n.GetLocale(ctx)}
+	if err := bus.Dispatch(ctx, q); err != nil {
+		log.Error(ctx, err)
+		return nil
 	}
+	return q.Result
 }
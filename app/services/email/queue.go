@@ -0,0 +1,101 @@
+package email
+
+import (
+	"context"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+// immediateTemplates are the emails a user is waiting for to sign in, they are delivered right away
+// and only go through the queue when the delivery fails
+var immediateTemplates = map[string]bool{
+	"signin_email":              true,
+	"signup_email":              true,
+	"change_emailaddress_email": true,
+}
+
+// QueueMail is how email providers listen to SendMail, a copy of the email is stored in the outbound queue for each recipient
+// so that deliveries are rate limited and retried one by one. Emails that can't be queued are delivered right away instead
+func QueueMail(ctx context.Context, c *cmd.SendMail) {
+	for _, to := range c.To {
+		if to.Address == "" {
+			continue
+		}
+
+		mail := &cmd.SendMail{
+			From:         c.From,
+			To:           []dto.Recipient{to},
+			TemplateName: c.TemplateName,
+			Props:        c.Props,
+		}
+
+		if immediateTemplates[c.TemplateName] {
+			err := bus.Dispatch(ctx, &cmd.DeliverMail{Mail: mail})
+			if err == nil {
+				continue
+			}
+			log.Error(ctx, errors.Wrap(err, "failed to deliver email with template %s, queueing it for a retry", c.TemplateName))
+			if IsPermanent(err) {
+				continue
+			}
+		}
+
+		payload, err := mail.MarshalDeferred()
+		if err == nil {
+			err = bus.Dispatch(ctx, &cmd.QueueMail{
+				TemplateName: c.TemplateName,
+				Recipient:    to.Address,
+				Payload:      payload,
+				BaseURL:      web.BaseURL(ctx),
+			})
+		}
+
+		if err != nil {
+			log.Error(ctx, errors.Wrap(err, "failed to queue email with template %s", c.TemplateName))
+			if err := bus.Dispatch(ctx, &cmd.DeliverMail{Mail: mail}); err != nil {
+				log.Error(ctx, err)
+			}
+		}
+	}
+}
+
+// RateLimit is how many emails the configured email provider is sent per minute at most
+func RateLimit() int {
+	switch env.Config.Email.Type {
+	case "mailgun":
+		return env.Config.Email.Mailgun.RateLimit
+	case "awsses":
+		return env.Config.Email.AWSSES.RateLimit
+	default:
+		return env.Config.Email.SMTP.RateLimit
+	}
+}
+
+// permanentError is a delivery error that retrying won't fix, like an address the provider rejects
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Permanent marks err as a delivery error that retrying won't fix, the queue gives up on the email right away
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true if err was marked as permanent by the email provider, all other errors are retried
+func IsPermanent(err error) bool {
+	_, ok := errors.Cause(err).(*permanentError)
+	return ok
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	gosmtp "net/smtp"
+	"net/textproto"
 	"net/url"
 	"strconv"
 	"time"
@@ -42,7 +43,8 @@ func (s Service) Enabled() bool {
 }
 
 func (s Service) Init() {
-	bus.AddListener(sendMail)
+	bus.AddListener(email.QueueMail)
+	bus.AddHandler(sendMail)
 	bus.AddHandler(fetchRecentSupressions)
 }
 
@@ -51,7 +53,8 @@ func fetchRecentSupressions(ctx context.Context, c *query.FetchRecentSupressions
 	return nil
 }
 
-func sendMail(ctx context.Context, c *cmd.SendMail) {
+func sendMail(ctx context.Context, d *cmd.DeliverMail) error {
+	c := d.Mail
 	if c.Props == nil {
 		c.Props = dto.Props{}
 	}
@@ -62,7 +65,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 
 	for _, to := range c.To {
 		if to.Address == "" {
-			return
+			return nil
 		}
 
 		u, err := url.Parse(web.BaseURL(ctx))
@@ -76,7 +79,7 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 				"Name":    to.Name,
 				"Address": to.Address,
 			})
-			return
+			return nil
 		}
 
 		log.Debugf(ctx, "Sending email to @{Address} with template @{TemplateName} and params @{Props}.", dto.Props{
@@ -85,16 +88,25 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 			"Props":        to.Props,
 		})
 
-		message := email.RenderMessage(ctx, c.TemplateName, c.From.Address, c.Props.Merge(to.Props))
+		replyTo := c.From.Address
+		if to.ReplyTo != "" {
+			replyTo = to.ReplyTo
+		}
+
+		message := email.RenderMessage(ctx, c.TemplateName, replyTo, c.Props.Merge(to.Props))
 		b := builder{}
 		b.Set("From", c.From.String())
-		b.Set("Reply-To", c.From.Address)
+		b.Set("Reply-To", replyTo)
 		b.Set("To", to.String())
 		b.Set("Subject", message.Subject)
 		b.Set("MIME-version", "1.0")
 		b.Set("Content-Type", "text/html; charset=\"UTF-8\"")
 		b.Set("Date", time.Now().Format(time.RFC1123Z))
 		b.Set("Message-ID", generateMessageID(localname))
+		if to.Unsubscribe != "" {
+			b.Set("List-Unsubscribe", "<"+to.Unsubscribe+">")
+			b.Set("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+		}
 		b.Body(message.Body)
 
 		smtpConfig := env.Config.Email.SMTP
@@ -102,10 +114,15 @@ func sendMail(ctx context.Context, c *cmd.SendMail) {
 		auth := authenticate(smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
 		err = Send(localname, servername, smtpConfig.EnableStartTLS, auth, email.NoReply, []string{to.Address}, b.Bytes())
 		if err != nil {
-			panic(errors.Wrap(err, "failed to send email with template %s", c.TemplateName))
+			// 5xx replies are permanent failures, like a recipient the server doesn't know about
+			if reply, ok := err.(*textproto.Error); ok && reply.Code >= 500 {
+				err = email.Permanent(err)
+			}
+			return errors.Wrap(err, "failed to send email with template %s", c.TemplateName)
 		}
 		log.Debug(ctx, "Email sent.")
 	}
+	return nil
 }
 
 var Send = func(localName, serverAddress string, enableStartTLS bool, a gosmtp.Auth, from string, to []string, msg []byte) error {
@@ -3,6 +3,7 @@ package smtp_test
 import (
 	"context"
 	gosmtp "net/smtp"
+	"net/textproto"
 	"regexp"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email"
@@ -41,13 +43,16 @@ func reset() {
 	smtp.Send = mockSend
 	requests = make([]request, 0)
 	bus.Init(smtp.Service{})
+	bus.AddHandler(func(ctx context.Context, q *query.GetEmailTemplate) error {
+		return nil
+	})
 }
 
 func TestSend_Success(t *testing.T) {
 	RegisterT(t)
 	reset()
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -59,7 +64,7 @@ func TestSend_Success(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(requests).HasLen(1)
 	Expect(requests[0].servername).Equals("localhost:1234")
@@ -77,7 +82,7 @@ func TestSend_SkipEmptyAddress(t *testing.T) {
 	RegisterT(t)
 	reset()
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -89,7 +94,7 @@ func TestSend_SkipEmptyAddress(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(requests).HasLen(0)
 }
@@ -99,7 +104,7 @@ func TestSend_SkipUnlistedAddress(t *testing.T) {
 	reset()
 	email.SetAllowlist("^.*@gmail.com$")
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -111,7 +116,7 @@ func TestSend_SkipUnlistedAddress(t *testing.T) {
 		Props: dto.Props{
 			"name": "Hello",
 		},
-	})
+	}})
 
 	Expect(requests).HasLen(0)
 }
@@ -121,7 +126,7 @@ func TestBatch_Success(t *testing.T) {
 	reset()
 	email.SetAllowlist("")
 
-	bus.Publish(ctx, &cmd.SendMail{
+	bus.MustDispatch(ctx, &cmd.DeliverMail{Mail: &cmd.SendMail{
 		From: dto.Recipient{Name: "Fider Test"},
 		To: []dto.Recipient{
 			{
@@ -140,7 +145,7 @@ func TestBatch_Success(t *testing.T) {
 			},
 		},
 		TemplateName: "echo_test",
-	})
+	}})
 
 	Expect(requests).HasLen(2)
 
@@ -160,3 +165,30 @@ func TestBatch_Success(t *testing.T) {
 	Expect(string(requests[1].body)).ContainsSubstring("Message-ID: ")
 	Expect(string(requests[1].body)).ContainsSubstring("Hello World Arya!")
 }
+
+func TestSend_RejectedRecipient_IsPermanent(t *testing.T) {
+	RegisterT(t)
+	reset()
+	email.SetAllowlist("")
+
+	sendMail := &cmd.SendMail{
+		From:         dto.Recipient{Name: "Fider Test"},
+		To:           []dto.Recipient{{Name: "Jon Sow", Address: "jon.snow@got.com"}},
+		TemplateName: "echo_test",
+		Props:        dto.Props{"name": "Hello"},
+	}
+
+	smtp.Send = func(localname, servername string, enableStartTLS bool, auth gosmtp.Auth, from string, to []string, body []byte) error {
+		return &textproto.Error{Code: 550, Msg: "No such user"}
+	}
+	err := bus.Dispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
+	Expect(err).IsNotNil()
+	Expect(email.IsPermanent(err)).IsTrue()
+
+	smtp.Send = func(localname, servername string, enableStartTLS bool, auth gosmtp.Auth, from string, to []string, body []byte) error {
+		return &textproto.Error{Code: 421, Msg: "Try again later"}
+	}
+	err = bus.Dispatch(ctx, &cmd.DeliverMail{Mail: sendMail})
+	Expect(err).IsNotNil()
+	Expect(email.IsPermanent(err)).IsFalse()
+}
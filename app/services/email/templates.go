@@ -0,0 +1,107 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/assets"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+)
+
+const (
+	subjectBlock = `{{define "subject"}}`
+	bodyBlock    = `{{define "body"}}`
+	endBlock     = "{{end}}"
+)
+
+// BuiltinTemplate returns the "subject" and "body" blocks of a built-in email template, which overrides start from
+func BuiltinTemplate(name string) (subject, body string, err error) {
+	content, err := fs.ReadFile(assets.FS, fmt.Sprintf("views/email/%s.html", name))
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to read email template '%s'", name)
+	}
+	text := string(content)
+
+	// the "subject" block is a single line without actions of its own, the "body" block is whatever is left up to the last {{end}}
+	subjectStart := strings.Index(text, subjectBlock)
+	bodyStart := strings.Index(text, bodyBlock)
+	bodyEnd := strings.LastIndex(text, endBlock)
+	if subjectStart < 0 || bodyStart < 0 || bodyEnd < bodyStart {
+		return "", "", errors.New("email template '%s' has no subject or body block", name)
+	}
+
+	subject = strings.TrimSuffix(strings.TrimSpace(text[subjectStart+len(subjectBlock):bodyStart]), endBlock)
+	body = strings.TrimSpace(text[bodyStart+len(bodyBlock) : bodyEnd])
+	return subject, body, nil
+}
+
+// SampleProps returns example props of an email template, used to preview overrides
+func SampleProps(ctx context.Context, name string) dto.Props {
+	baseURL, logoURL := web.BaseURL(ctx), web.LogoURL(ctx)
+	postURL := baseURL + "/posts/36/example-post-title"
+	anchor := func(text, url string) string {
+		return fmt.Sprintf("<a href='%s'>%s</a>", url, text)
+	}
+
+	props := dto.Props{
+		"siteName": "Example Site",
+		"title":    "Example post title",
+		"userName": "Jane Doe",
+		"postLink": anchor("#36", postURL),
+		"view":     anchor(i18n.T(ctx, "email.subscription.view"), postURL),
+		"change":   anchor(i18n.T(ctx, "email.subscription.change"), baseURL+"/profile#settings"),
+		"logo":     logoURL,
+	}
+
+	switch name {
+	case "new_post":
+		props["content"] = template.HTML("<p>This is an example post, nothing was created on your site.</p>")
+	case "new_comment":
+		props["content"] = template.HTML("<p>This is an example comment, nothing was posted on your site.</p>")
+		props["messageLocaleString"] = "email.new_comment.text"
+		props["unsubscribe"] = anchor(i18n.T(ctx, "email.subscription.unsubscribe"), postURL)
+	case "change_status":
+		props["status"] = "Planned"
+		props["duplicate"] = ""
+		props["content"] = template.HTML("<p>We plan to work on this next month.</p>")
+		props["unsubscribe"] = anchor(i18n.T(ctx, "email.subscription.unsubscribe"), postURL)
+	case "delete_post":
+		props["content"] = template.HTML("<p>This post was a duplicate.</p>")
+	case "report_outcome":
+		props["outcome"] = "actioned"
+		props["type"] = "post"
+	case "post_locked":
+		props["message"] = "This discussion has run its course."
+	case "post_tagged":
+		props["tag"] = "Example tag"
+	case "content_hidden", "content_approved":
+		props["type"] = "comment"
+	case "digest":
+		props["groups"] = []dto.Props{
+			{
+				"number": 36,
+				"title":  "Example post title",
+				"url":    postURL,
+				"items": []dto.Props{
+					{"event": "new_post", "userName": "Jane Doe", "excerpt": "This is an example post.", "url": postURL},
+					{"event": "new_comment", "userName": "John Doe", "excerpt": "This is an example comment.", "url": postURL},
+				},
+			},
+		}
+	case "signin_email":
+		props["link"] = anchor(baseURL+"/signin/verify?k=example", baseURL+"/signin/verify?k=example")
+	case "change_emailaddress_email":
+		props["name"] = "Jane Doe"
+		props["oldEmail"] = "jane.doe@example.com"
+		props["newEmail"] = "jane@example.com"
+		props["link"] = anchor(baseURL+"/change-email/verify?k=example", baseURL+"/change-email/verify?k=example")
+	}
+
+	return props
+}
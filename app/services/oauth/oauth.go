@@ -18,6 +18,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jsonq"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/validate"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 	"golang.org/x/oauth2"
@@ -50,6 +51,7 @@ func (s Service) Init() {
 	bus.AddHandler(getOAuthRawProfile)
 	bus.AddHandler(listActiveOAuthProviders)
 	bus.AddHandler(listAllOAuthProviders)
+	bus.AddHandler(getOIDCConfiguration)
 }
 
 func getProviderStatus(key string) int {
@@ -115,11 +117,16 @@ func parseOAuthRawProfile(ctx context.Context, c *cmd.ParseOAuthRawProfile) erro
 		return err
 	}
 
+	idPath, namePath, emailPath := config.JSONUserIDPath, config.JSONUserNamePath, config.JSONUserEmailPath
+	if config.IsOIDC() {
+		idPath, namePath, emailPath = oidcStandardClaims.ID, oidcStandardClaims.Name, oidcStandardClaims.Email
+	}
+
 	query := jsonq.New(c.Body)
 	profile := &dto.OAuthUserProfile{
-		ID:    strings.TrimSpace(query.String(config.JSONUserIDPath)),
-		Name:  strings.TrimSpace(query.String(config.JSONUserNamePath)),
-		Email: strings.ToLower(strings.TrimSpace(query.String(config.JSONUserEmailPath))),
+		ID:    strings.TrimSpace(query.String(idPath)),
+		Name:  strings.TrimSpace(query.String(namePath)),
+		Email: strings.ToLower(strings.TrimSpace(query.String(emailPath))),
 	}
 
 	if profile.ID == "" {
@@ -149,17 +156,39 @@ func getOAuthAuthorizationURL(ctx context.Context, q *query.GetOAuthAuthorizatio
 		return err
 	}
 
+	authorizeURL := config.AuthorizeURL
+	var oidc *dto.OIDCConfiguration
+	if config.IsOIDC() {
+		discovery := &query.GetOIDCConfiguration{IssuerURL: config.IssuerURL}
+		if err := bus.Dispatch(ctx, discovery); err != nil {
+			return err
+		}
+		oidc = discovery.Result
+		authorizeURL = oidc.AuthorizationEndpoint
+	}
+
 	oauthBaseURL := web.OAuthBaseURL(ctx)
-	authURL, _ := url.Parse(config.AuthorizeURL)
+	authURL, _ := url.Parse(authorizeURL)
 	parameters := getProviderInitialParams(authURL)
 	parameters.Add("client_id", config.ClientID)
 	parameters.Add("scope", config.Scope)
 	parameters.Add("redirect_uri", fmt.Sprintf("%s/oauth/%s/callback", oauthBaseURL, q.Provider))
 	parameters.Add("response_type", "code")
 
+	var nonce string
+	if oidc != nil {
+		nonce = rand.String(32)
+		parameters.Add("nonce", nonce)
+		if oidcSupportsPKCE(oidc) {
+			parameters.Add("code_challenge", oauth2.S256ChallengeFromVerifier(oidcCodeVerifier(nonce)))
+			parameters.Add("code_challenge_method", "S256")
+		}
+	}
+
 	state, err := jwt.Encode(jwt.OAuthStateClaims{
 		Redirect:   q.Redirect,
 		Identifier: q.Identifier,
+		Nonce:      nonce,
 	})
 
 	if err != nil {
@@ -183,7 +212,7 @@ func getOAuthProfile(ctx context.Context, q *query.GetOAuthProfile) error {
 		return errors.New("Provider %s is disabled", q.Provider)
 	}
 
-	rawProfile := &query.GetOAuthRawProfile{Provider: q.Provider, Code: q.Code}
+	rawProfile := &query.GetOAuthRawProfile{Provider: q.Provider, Code: q.Code, Nonce: q.Nonce}
 	err = bus.Dispatch(ctx, rawProfile)
 	if err != nil {
 		return err
@@ -205,22 +234,46 @@ func getOAuthRawProfile(ctx context.Context, q *query.GetOAuthRawProfile) error
 		return err
 	}
 
+	endpoint := oauth2.Endpoint{
+		AuthURL:  config.AuthorizeURL,
+		TokenURL: config.TokenURL,
+	}
+	var oidc *dto.OIDCConfiguration
+	if config.IsOIDC() {
+		discovery := &query.GetOIDCConfiguration{IssuerURL: config.IssuerURL}
+		if err := bus.Dispatch(ctx, discovery); err != nil {
+			return err
+		}
+		oidc = discovery.Result
+		endpoint = oauth2.Endpoint{
+			AuthURL:  oidc.AuthorizationEndpoint,
+			TokenURL: oidc.TokenEndpoint,
+		}
+	}
+
 	oauthBaseURL := web.OAuthBaseURL(ctx)
 	exchange := (&oauth2.Config{
 		ClientID:     config.ClientID,
 		ClientSecret: config.ClientSecret,
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  config.AuthorizeURL,
-			TokenURL: config.TokenURL,
-		},
-		RedirectURL: fmt.Sprintf("%s/oauth/%s/callback", oauthBaseURL, q.Provider),
+		Endpoint:     endpoint,
+		RedirectURL:  fmt.Sprintf("%s/oauth/%s/callback", oauthBaseURL, q.Provider),
 	}).Exchange
 
-	oauthToken, err := exchange(ctx, q.Code)
+	var options []oauth2.AuthCodeOption
+	if oidc != nil && oidcSupportsPKCE(oidc) {
+		options = append(options, oauth2.VerifierOption(oidcCodeVerifier(q.Nonce)))
+	}
+
+	oauthToken, err := exchange(ctx, q.Code, options...)
 	if err != nil {
 		return err
 	}
 
+	if oidc != nil {
+		q.Result, err = getOIDCProfile(ctx, config, oidc, oauthToken, q.Nonce)
+		return err
+	}
+
 	if config.ProfileURL == "" {
 		parts := strings.Split(oauthToken.AccessToken, ".")
 		if len(parts) != 3 {
@@ -0,0 +1,319 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"github.com/patrickmn/go-cache"
+	"golang.org/x/oauth2"
+)
+
+// oidcLeeway is the clock skew tolerated when validating the time claims of ID tokens
+const oidcLeeway = time.Minute
+
+var (
+	// oidcConfigurations caches the discovery documents, keyed by issuer URL
+	oidcConfigurations = cache.New(1*time.Hour, 10*time.Minute)
+
+	// oidcKeySets caches the signing keys, keyed by JWKS URI
+	// Issuers rotate their keys by publishing a new one before using it, so the key set is fetched again when a token is signed by an unknown key
+	oidcKeySets = cache.New(24*time.Hour, 1*time.Hour)
+
+	// oidcMissedKeys remembers the unknown keys for a while, so that they don't cause a fetch of the key set on every sign in
+	oidcMissedKeys = cache.New(1*time.Minute, 5*time.Minute)
+
+	oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// oidcStandardClaims are the JSON paths used to map the standard claims of OpenID Connect providers to a user profile
+var oidcStandardClaims = struct {
+	ID    string
+	Name  string
+	Email string
+}{
+	ID:    "sub",
+	Name:  "name, preferred_username, nickname",
+	Email: "email",
+}
+
+func getOIDCConfiguration(ctx context.Context, q *query.GetOIDCConfiguration) error {
+	issuer := strings.TrimSuffix(q.IssuerURL, "/")
+	if cached, ok := oidcConfigurations.Get(issuer); ok {
+		q.Result = cached.(*dto.OIDCConfiguration)
+		return nil
+	}
+
+	req := &cmd.HTTPRequest{
+		URL:    issuer + "/.well-known/openid-configuration",
+		Method: "GET",
+		Headers: map[string]string{
+			"Accept": "application/json",
+		},
+	}
+	if err := bus.Dispatch(ctx, req); err != nil {
+		return errors.Wrap(err, "failed to fetch OpenID configuration of '%s'", issuer)
+	}
+	if req.ResponseStatusCode != http.StatusOK {
+		return errors.New("Failed to fetch OpenID configuration of '%s'. Status Code: %d", issuer, req.ResponseStatusCode)
+	}
+
+	config := &dto.OIDCConfiguration{}
+	if err := json.Unmarshal(req.ResponseBody, config); err != nil {
+		return errors.Wrap(err, "failed to parse OpenID configuration of '%s'", issuer)
+	}
+
+	// a document that claims to be from another issuer could be used to accept ID tokens of that issuer
+	if strings.TrimSuffix(config.Issuer, "/") != issuer {
+		return errors.New("OpenID configuration of '%s' is for another issuer: '%s'", issuer, config.Issuer)
+	}
+	if config.AuthorizationEndpoint == "" || config.TokenEndpoint == "" || config.JWKSURI == "" {
+		return errors.New("OpenID configuration of '%s' is missing the authorization, token or JWKS endpoint", issuer)
+	}
+
+	oidcConfigurations.SetDefault(issuer, config)
+	q.Result = config
+	return nil
+}
+
+// oidcSupportsPKCE returns false only when the issuer lists the challenge methods it supports and S256 is not one of them
+func oidcSupportsPKCE(config *dto.OIDCConfiguration) bool {
+	return len(config.CodeChallengeMethodsSupported) == 0 || slices.Contains(config.CodeChallengeMethodsSupported, "S256")
+}
+
+// oidcCodeVerifier derives the PKCE verifier from the nonce, so that nothing has to be stored between the authorization request and the callback
+// The nonce is visible to anyone who sees the callback URL, but the verifier can't be computed from it without the JWT secret
+func oidcCodeVerifier(nonce string) string {
+	mac := hmac.New(sha256.New, []byte(env.Config.JWTSecret))
+	mac.Write([]byte("oidc/pkce/" + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// getOIDCProfile returns the claims of the ID token as JSON, completed by the userinfo endpoint when the ID token doesn't have the name or email
+func getOIDCProfile(ctx context.Context, config *entity.OAuthConfig, oidc *dto.OIDCConfiguration, token *oauth2.Token, nonce string) (string, error) {
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return "", errors.New("Token response of '%s' doesn't have an ID token", oidc.Issuer)
+	}
+
+	claims, err := verifyIDToken(ctx, config, oidc, rawIDToken, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	if oidc.UserinfoEndpoint != "" && (claims["name"] == nil || claims["email"] == nil) {
+		req := &cmd.HTTPRequest{
+			URL:    oidc.UserinfoEndpoint,
+			Method: "GET",
+			Headers: map[string]string{
+				"Accept":        "application/json",
+				"Authorization": "Bearer " + token.AccessToken,
+			},
+		}
+		if err := bus.Dispatch(ctx, req); err != nil {
+			return "", errors.Wrap(err, "failed to fetch userinfo of '%s'", oidc.Issuer)
+		}
+
+		userinfo := jwtgo.MapClaims{}
+		if req.ResponseStatusCode == http.StatusOK && json.Unmarshal(req.ResponseBody, &userinfo) == nil && userinfo["sub"] == claims["sub"] {
+			for key, value := range userinfo {
+				if _, ok := claims[key]; !ok {
+					claims[key] = value
+				}
+			}
+		}
+	}
+
+	// emails are used to find existing users, so an unverified one could be used to take over their account
+	verified := true
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		verified = v
+	case string:
+		verified = v == "true"
+	}
+	if !verified {
+		delete(claims, "email")
+	}
+
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal ID token claims")
+	}
+	return string(body), nil
+}
+
+// verifyIDToken validates the signature of the ID token against the keys of the issuer, and its claims as per OpenID Connect Core 3.1.3.7
+func verifyIDToken(ctx context.Context, config *entity.OAuthConfig, oidc *dto.OIDCConfiguration, rawIDToken, nonce string) (jwtgo.MapClaims, error) {
+	claims := jwtgo.MapClaims{}
+	parser := jwtgo.NewParser(jwtgo.WithValidMethods(oidcSigningMethods), jwtgo.WithoutClaimsValidation())
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(t *jwtgo.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return getOIDCSigningKey(ctx, oidc.JWKSURI, kid)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to verify ID token of '%s'", oidc.Issuer)
+	}
+
+	now := time.Now()
+	if iss, _ := claims["iss"].(string); iss != oidc.Issuer {
+		return nil, errors.New("ID token was issued by '%s' instead of '%s'", iss, oidc.Issuer)
+	}
+	if !claims.VerifyAudience(config.ClientID, true) {
+		return nil, errors.New("ID token of '%s' was issued to another client", oidc.Issuer)
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != config.ClientID {
+		return nil, errors.New("ID token of '%s' was authorized for another client", oidc.Issuer)
+	}
+	if !claims.VerifyExpiresAt(now.Add(-oidcLeeway).Unix(), true) {
+		return nil, errors.New("ID token of '%s' has expired", oidc.Issuer)
+	}
+	if !claims.VerifyIssuedAt(now.Add(oidcLeeway).Unix(), false) {
+		return nil, errors.New("ID token of '%s' was issued in the future", oidc.Issuer)
+	}
+	if value, _ := claims["nonce"].(string); nonce == "" || subtle.ConstantTimeCompare([]byte(value), []byte(nonce)) != 1 {
+		return nil, errors.New("ID token of '%s' doesn't have the nonce of the authorization request", oidc.Issuer)
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		return nil, errors.New("ID token of '%s' doesn't have a subject", oidc.Issuer)
+	}
+
+	return claims, nil
+}
+
+type oidcKeySet map[string]any
+
+// find returns the key with given ID, tokens without a key ID can only be used with issuers that have a single key
+func (s oidcKeySet) find(kid string) any {
+	if kid == "" && len(s) == 1 {
+		for _, key := range s {
+			return key
+		}
+	}
+	return s[kid]
+}
+
+func getOIDCSigningKey(ctx context.Context, jwksURI, kid string) (any, error) {
+	if cached, ok := oidcKeySets.Get(jwksURI); ok {
+		if key := cached.(oidcKeySet).find(kid); key != nil {
+			return key, nil
+		}
+	}
+
+	missedKey := jwksURI + "#" + kid
+	if _, ok := oidcMissedKeys.Get(missedKey); ok {
+		return nil, errors.New("Key '%s' is not in '%s'", kid, jwksURI)
+	}
+
+	keySet, err := fetchOIDCKeySet(ctx, jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	oidcKeySets.SetDefault(jwksURI, keySet)
+
+	if key := keySet.find(kid); key != nil {
+		return key, nil
+	}
+
+	oidcMissedKeys.SetDefault(missedKey, true)
+	return nil, errors.New("Key '%s' is not in '%s'", kid, jwksURI)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func fetchOIDCKeySet(ctx context.Context, jwksURI string) (oidcKeySet, error) {
+	req := &cmd.HTTPRequest{
+		URL:    jwksURI,
+		Method: "GET",
+		Headers: map[string]string{
+			"Accept": "application/json",
+		},
+	}
+	if err := bus.Dispatch(ctx, req); err != nil {
+		return nil, errors.Wrap(err, "failed to fetch keys from '%s'", jwksURI)
+	}
+	if req.ResponseStatusCode != http.StatusOK {
+		return nil, errors.New("Failed to fetch keys from '%s'. Status Code: %d", jwksURI, req.ResponseStatusCode)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(req.ResponseBody, &jwks); err != nil {
+		return nil, errors.Wrap(err, "failed to parse keys from '%s'", jwksURI)
+	}
+
+	// keys that are not for signatures or of unsupported types are skipped, they can't be used to sign ID tokens anyway
+	keySet := make(oidcKeySet, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key := jwk.publicKey(); key != nil {
+			keySet[jwk.Kid] = key
+		}
+	}
+	return keySet, nil
+}
+
+func (k jsonWebKey) publicKey() any {
+	switch k.Kty {
+	case "RSA":
+		n, errN := decodeJWKInt(k.N)
+		e, errE := decodeJWKInt(k.E)
+		if errN != nil || errE != nil || !e.IsInt64() {
+			return nil
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		curves := map[string]elliptic.Curve{
+			"P-256": elliptic.P256(),
+			"P-384": elliptic.P384(),
+			"P-521": elliptic.P521(),
+		}
+		curve, ok := curves[k.Crv]
+		x, errX := decodeJWKInt(k.X)
+		y, errY := decodeJWKInt(k.Y)
+		if !ok || errX != nil || errY != nil {
+			return nil
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	}
+	return nil
+}
+
+func decodeJWKInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(bytes) == 0 {
+		return nil, errors.New("invalid JWK value")
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
@@ -0,0 +1,307 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/oauth"
+	jwtgo "github.com/golang-jwt/jwt/v4"
+	"golang.org/x/oauth2"
+)
+
+// oidcIssuer is a stub OpenID Connect provider, each test uses its own issuer URL because discovery documents and keys are cached
+type oidcIssuer struct {
+	URL          string
+	Keys         map[string]*rsa.PrivateKey
+	Userinfo     map[string]any
+	IDToken      jwtgo.MapClaims
+	KeyID        string
+	ForgedKey    *rsa.PrivateKey
+	KeyFetches   int
+	CodeVerifier string
+	tokenServer  *httptest.Server
+}
+
+func newOIDCIssuer(t *testing.T, issuerURL string) *oidcIssuer {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	issuer := &oidcIssuer{
+		URL:   issuerURL,
+		Keys:  map[string]*rsa.PrivateKey{"key-1": key},
+		KeyID: "key-1",
+	}
+
+	issuer.tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		issuer.CodeVerifier = r.PostForm.Get("code_verifier")
+		key := issuer.Keys[issuer.KeyID]
+		if issuer.ForgedKey != nil {
+			key = issuer.ForgedKey
+		}
+		idToken := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, issuer.IDToken)
+		idToken.Header["kid"] = issuer.KeyID
+		signed, _ := idToken.SignedString(key)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "the-access-token",
+			"token_type":   "Bearer",
+			"id_token":     signed,
+		})
+	}))
+	t.Cleanup(issuer.tokenServer.Close)
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.HTTPRequest) error {
+		var body any
+		switch c.URL {
+		case issuer.URL + "/.well-known/openid-configuration":
+			body = map[string]any{
+				"issuer":                           issuer.URL,
+				"authorization_endpoint":           issuer.URL + "/authorize",
+				"token_endpoint":                   issuer.tokenServer.URL + "/token",
+				"userinfo_endpoint":                issuer.URL + "/userinfo",
+				"jwks_uri":                         issuer.URL + "/jwks",
+				"code_challenge_methods_supported": []string{"S256"},
+			}
+		case issuer.URL + "/jwks":
+			issuer.KeyFetches++
+			keys := make([]map[string]string, 0, len(issuer.Keys))
+			for kid, key := range issuer.Keys {
+				keys = append(keys, map[string]string{
+					"kty": "RSA",
+					"kid": kid,
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				})
+			}
+			body = map[string]any{"keys": keys}
+		case issuer.URL + "/userinfo":
+			if c.Headers["Authorization"] != "Bearer the-access-token" {
+				c.ResponseStatusCode = http.StatusUnauthorized
+				return nil
+			}
+			body = issuer.Userinfo
+		default:
+			c.ResponseStatusCode = http.StatusNotFound
+			return nil
+		}
+		c.ResponseStatusCode = http.StatusOK
+		c.ResponseBody, _ = json.Marshal(body)
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomOAuthConfigByProvider) error {
+		if q.Provider == "_OIDC" {
+			q.Result = &entity.OAuthConfig{
+				Provider:     q.Provider,
+				Type:         enum.OAuthConfigTypeOIDC,
+				Status:       enum.OAuthConfigEnabled,
+				IssuerURL:    issuer.URL,
+				ClientID:     "fider-client",
+				ClientSecret: "fider-secret",
+				Scope:        "openid profile email",
+			}
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	return issuer
+}
+
+func (i *oidcIssuer) claims(nonce string) jwtgo.MapClaims {
+	return jwtgo.MapClaims{
+		"iss":            i.URL,
+		"aud":            "fider-client",
+		"sub":            "248289761001",
+		"name":           "Jane Doe",
+		"email":          "jane.doe@example.com",
+		"email_verified": true,
+		"nonce":          nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	}
+}
+
+func TestGetAuthURL_OIDC(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	newOIDCIssuer(t, "https://auth-url.oidc.test")
+
+	ctx := newGetContext("http://login.test.fider.io:3000")
+	authURL := &query.GetOAuthAuthorizationURL{
+		Provider:   "_OIDC",
+		Redirect:   "http://example.org",
+		Identifier: "789",
+	}
+
+	err := bus.Dispatch(ctx, authURL)
+	Expect(err).IsNil()
+
+	result, _ := url.Parse(authURL.Result)
+	Expect(result.Host).Equals("auth-url.oidc.test")
+	Expect(result.Path).Equals("/authorize")
+	Expect(result.Query().Get("client_id")).Equals("fider-client")
+	Expect(result.Query().Get("scope")).Equals("openid profile email")
+	Expect(result.Query().Get("code_challenge_method")).Equals("S256")
+
+	state, err := jwt.DecodeOAuthStateClaims(result.Query().Get("state"))
+	Expect(err).IsNil()
+	Expect(state.Redirect).Equals("http://example.org")
+	Expect(state.Nonce).HasLen(32)
+	Expect(result.Query().Get("nonce")).Equals(state.Nonce)
+	Expect(result.Query().Get("code_challenge")).NotEquals("")
+}
+
+func TestGetOAuthProfile_OIDC(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	issuer := newOIDCIssuer(t, "https://profile.oidc.test")
+	issuer.IDToken = issuer.claims("the-nonce")
+
+	ctx := newGetContext("http://login.test.fider.io:3000")
+	profile := &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	err := bus.Dispatch(ctx, profile)
+	Expect(err).IsNil()
+	Expect(profile.Result.ID).Equals("248289761001")
+	Expect(profile.Result.Name).Equals("Jane Doe")
+	Expect(profile.Result.Email).Equals("jane.doe@example.com")
+
+	// the verifier sent to the token endpoint must match the challenge of the authorization request
+	authURL := &query.GetOAuthAuthorizationURL{Provider: "_OIDC", Redirect: "http://example.org"}
+	Expect(bus.Dispatch(ctx, authURL)).IsNil()
+	result, _ := url.Parse(authURL.Result)
+	state, _ := jwt.DecodeOAuthStateClaims(result.Query().Get("state"))
+
+	profile = &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: state.Nonce}
+	issuer.IDToken = issuer.claims(state.Nonce)
+	Expect(bus.Dispatch(ctx, profile)).IsNil()
+	Expect(oauth2.S256ChallengeFromVerifier(issuer.CodeVerifier)).Equals(result.Query().Get("code_challenge"))
+}
+
+func TestGetOAuthProfile_OIDC_CompletedByUserinfo(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	issuer := newOIDCIssuer(t, "https://userinfo.oidc.test")
+	issuer.IDToken = issuer.claims("the-nonce")
+	delete(issuer.IDToken, "name")
+	delete(issuer.IDToken, "email")
+	delete(issuer.IDToken, "email_verified")
+	issuer.Userinfo = map[string]any{
+		"sub":                "248289761001",
+		"preferred_username": "jdoe",
+		"email":              "jane.doe@example.com",
+		"email_verified":     true,
+	}
+
+	ctx := newGetContext("http://login.test.fider.io:3000")
+	profile := &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	err := bus.Dispatch(ctx, profile)
+	Expect(err).IsNil()
+	Expect(profile.Result.Name).Equals("jdoe")
+	Expect(profile.Result.Email).Equals("jane.doe@example.com")
+
+	// userinfo of another subject must be ignored
+	issuer.Userinfo["sub"] = "somebody-else"
+	profile = &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	err = bus.Dispatch(ctx, profile)
+	Expect(err).IsNil()
+	Expect(profile.Result.Email).Equals("")
+}
+
+func TestGetOAuthProfile_OIDC_UnverifiedEmail(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	issuer := newOIDCIssuer(t, "https://unverified.oidc.test")
+	issuer.IDToken = issuer.claims("the-nonce")
+	issuer.IDToken["email_verified"] = false
+
+	ctx := newGetContext("http://login.test.fider.io:3000")
+	profile := &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	err := bus.Dispatch(ctx, profile)
+	Expect(err).IsNil()
+	Expect(profile.Result.ID).Equals("248289761001")
+	Expect(profile.Result.Email).Equals("")
+}
+
+func TestGetOAuthProfile_OIDC_InvalidIDToken(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	issuer := newOIDCIssuer(t, "https://invalid.oidc.test")
+	ctx := newGetContext("http://login.test.fider.io:3000")
+
+	testCases := []func(claims jwtgo.MapClaims){
+		func(claims jwtgo.MapClaims) { claims["nonce"] = "another-nonce" },
+		func(claims jwtgo.MapClaims) { delete(claims, "nonce") },
+		func(claims jwtgo.MapClaims) { claims["aud"] = "another-client" },
+		func(claims jwtgo.MapClaims) { claims["azp"] = "another-client" },
+		func(claims jwtgo.MapClaims) { claims["iss"] = "https://evil.oidc.test" },
+		func(claims jwtgo.MapClaims) { claims["exp"] = time.Now().Add(-5 * time.Minute).Unix() },
+		func(claims jwtgo.MapClaims) { delete(claims, "exp") },
+		func(claims jwtgo.MapClaims) { claims["iat"] = time.Now().Add(5 * time.Minute).Unix() },
+		func(claims jwtgo.MapClaims) { delete(claims, "sub") },
+	}
+
+	for _, change := range testCases {
+		issuer.IDToken = issuer.claims("the-nonce")
+		change(issuer.IDToken)
+
+		profile := &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+		err := bus.Dispatch(ctx, profile)
+		Expect(err).IsNotNil()
+		Expect(profile.Result).IsNil()
+	}
+}
+
+func TestGetOAuthProfile_OIDC_KeyRotation(t *testing.T) {
+	RegisterT(t)
+	bus.Init(&oauth.Service{})
+	issuer := newOIDCIssuer(t, "https://rotation.oidc.test")
+	issuer.IDToken = issuer.claims("the-nonce")
+	ctx := newGetContext("http://login.test.fider.io:3000")
+
+	profile := &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	Expect(bus.Dispatch(ctx, profile)).IsNil()
+	Expect(issuer.KeyFetches).Equals(1)
+
+	// the issuer publishes a new key and starts using it
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	issuer.Keys["key-2"] = newKey
+	issuer.KeyID = "key-2"
+
+	profile = &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	Expect(bus.Dispatch(ctx, profile)).IsNil()
+	Expect(issuer.KeyFetches).Equals(2)
+
+	// a token signed by a key that isn't published is rejected, and the key set isn't fetched again right away
+	issuer.ForgedKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	issuer.KeyID = "key-3"
+
+	for i := 0; i < 2; i++ {
+		profile = &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+		Expect(bus.Dispatch(ctx, profile)).IsNotNil()
+	}
+	Expect(issuer.KeyFetches).Equals(3)
+
+	// a token that claims to be signed by a known key but isn't is rejected too
+	issuer.KeyID = "key-2"
+	profile = &query.GetOAuthProfile{Provider: "_OIDC", Code: "the-code", Nonce: "the-nonce"}
+	Expect(bus.Dispatch(ctx, profile)).IsNotNil()
+	Expect(issuer.KeyFetches).Equals(3)
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbAPIToken struct {
+	ID         int          `db:"id"`
+	UserID     int          `db:"user_id"`
+	Name       string       `db:"name"`
+	Prefix     string       `db:"token_prefix"`
+	Scopes     []string     `db:"scopes"`
+	ExpiresAt  dbx.NullTime `db:"expires_at"`
+	LastUsedAt dbx.NullTime `db:"last_used_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+func (t *dbAPIToken) toModel() *entity.APIToken {
+	token := &entity.APIToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Name:      t.Name,
+		Prefix:    t.Prefix,
+		Scopes:    parseAPITokenScopes(t.Scopes),
+		CreatedAt: t.CreatedAt,
+	}
+	if t.ExpiresAt.Valid {
+		token.ExpiresAt = &t.ExpiresAt.Time
+	}
+	if t.LastUsedAt.Valid {
+		token.LastUsedAt = &t.LastUsedAt.Time
+	}
+	return token
+}
+
+func createAPIToken(ctx context.Context, c *cmd.CreateAPIToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var id int
+		err := trx.Scalar(&id, `
+			INSERT INTO user_api_tokens (tenant_id, user_id, name, token_hash, token_prefix, scopes, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, tenant.ID, c.UserID, c.Name, c.TokenHash, c.Prefix, pq.Array(apiTokenScopeNames(c.Scopes)), c.ExpiresAt, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to create API token for user '%d'", c.UserID)
+		}
+
+		c.Result = &entity.APIToken{
+			ID:        id,
+			UserID:    c.UserID,
+			Name:      c.Name,
+			Prefix:    c.Prefix,
+			Scopes:    c.Scopes,
+			ExpiresAt: c.ExpiresAt,
+			CreatedAt: time.Now(),
+		}
+		return nil
+	})
+}
+
+func getAPITokenByHash(ctx context.Context, q *query.GetAPITokenByHash) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		token := dbAPIToken{}
+		err := trx.Get(&token, `
+			SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, created_at
+			FROM user_api_tokens
+			WHERE tenant_id = $1 AND token_hash = $2
+		`, tenant.ID, q.TokenHash)
+		if err != nil {
+			return errors.Wrap(err, "failed to get API token")
+		}
+		q.Result = token.toModel()
+		return nil
+	})
+}
+
+func listAPITokens(ctx context.Context, q *query.ListAPITokens) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var tokens []*dbAPIToken
+		err := trx.Select(&tokens, `
+			SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, created_at
+			FROM user_api_tokens
+			WHERE tenant_id = $1 AND user_id = $2
+			ORDER BY created_at DESC
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list API tokens of user '%d'", q.UserID)
+		}
+
+		q.Result = make([]*entity.APIToken, len(tokens))
+		for i, token := range tokens {
+			q.Result[i] = token.toModel()
+		}
+		return nil
+	})
+}
+
+func deleteAPIToken(ctx context.Context, c *cmd.DeleteAPIToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(
+			"DELETE FROM user_api_tokens WHERE tenant_id = $1 AND user_id = $2 AND id = $3",
+			tenant.ID, c.UserID, c.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete API token '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func touchAPIToken(ctx context.Context, c *cmd.TouchAPIToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE user_api_tokens SET last_used_at = $3 WHERE tenant_id = $1 AND id = $2",
+			tenant.ID, c.ID, time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to touch API token '%d'", c.ID)
+		}
+		return nil
+	})
+}
+
+// apiTokenScopeNames returns what is stored in the scopes columns
+func apiTokenScopeNames(scopes []enum.APITokenScope) []string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = scope.String()
+	}
+	return names
+}
+
+// parseAPITokenScopes ignores scopes that no longer exist
+func parseAPITokenScopes(names []string) []enum.APITokenScope {
+	scopes := make([]enum.APITokenScope, 0, len(names))
+	for _, name := range names {
+		if scope, ok := enum.ParseAPITokenScope(name); ok {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,100 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestAPITokenStorage_CreateAndGetByHash(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	expiresAt := time.Now().Add(-time.Hour)
+	createToken := &cmd.CreateAPIToken{
+		UserID:    aryaStark.ID,
+		Name:      "My Script",
+		TokenHash: "hash-1",
+		Prefix:    "fdr_abcd",
+		Scopes:    []enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeWriteComments},
+		ExpiresAt: &expiresAt,
+	}
+	err := bus.Dispatch(aryaStarkCtx, createToken)
+	Expect(err).IsNil()
+	Expect(createToken.Result.ID).NotEquals(0)
+
+	getToken := &query.GetAPITokenByHash{TokenHash: "hash-1"}
+	err = bus.Dispatch(aryaStarkCtx, getToken)
+	Expect(err).IsNil()
+	Expect(getToken.Result.ID).Equals(createToken.Result.ID)
+	Expect(getToken.Result.UserID).Equals(aryaStark.ID)
+	Expect(getToken.Result.Name).Equals("My Script")
+	Expect(getToken.Result.Prefix).Equals("fdr_abcd")
+	Expect(getToken.Result.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts, enum.APITokenScopeWriteComments})
+	Expect(getToken.Result.ExpiresAt).IsNotNil()
+	Expect(getToken.Result.IsExpired(time.Now())).IsTrue()
+	Expect(getToken.Result.LastUsedAt).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetAPITokenByHash{TokenHash: "hash-2"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// tokens are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &query.GetAPITokenByHash{TokenHash: "hash-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestAPITokenStorage_Touch(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createToken := &cmd.CreateAPIToken{UserID: aryaStark.ID, Name: "My Script", TokenHash: "hash-1", Prefix: "fdr_abcd"}
+	err := bus.Dispatch(aryaStarkCtx, createToken)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.TouchAPIToken{ID: createToken.Result.ID})
+	Expect(err).IsNil()
+
+	getToken := &query.GetAPITokenByHash{TokenHash: "hash-1"}
+	err = bus.Dispatch(aryaStarkCtx, getToken)
+	Expect(err).IsNil()
+	Expect(getToken.Result.LastUsedAt).IsNotNil()
+	Expect(getToken.Result.ExpiresAt).IsNil()
+	Expect(getToken.Result.IsExpired(time.Now())).IsFalse()
+}
+
+func TestAPITokenStorage_ListAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createToken := &cmd.CreateAPIToken{UserID: aryaStark.ID, Name: "Token 1", TokenHash: "hash-1", Prefix: "fdr_1111"}
+	err := bus.Dispatch(aryaStarkCtx, createToken)
+	Expect(err).IsNil()
+	err = bus.Dispatch(sansaStarkCtx, &cmd.CreateAPIToken{UserID: sansaStark.ID, Name: "Token 2", TokenHash: "hash-2", Prefix: "fdr_2222"})
+	Expect(err).IsNil()
+
+	listTokens := &query.ListAPITokens{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, listTokens)
+	Expect(err).IsNil()
+	Expect(listTokens.Result).HasLen(1)
+	Expect(listTokens.Result[0].Name).Equals("Token 1")
+
+	// the token can only be deleted by the user who owns it
+	err = bus.Dispatch(sansaStarkCtx, &cmd.DeleteAPIToken{UserID: sansaStark.ID, ID: createToken.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DeleteAPIToken{UserID: aryaStark.ID, ID: createToken.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetAPITokenByHash{TokenHash: "hash-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(sansaStarkCtx, &query.GetAPITokenByHash{TokenHash: "hash-2"})
+	Expect(err).IsNil()
+}
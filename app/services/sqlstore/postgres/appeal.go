@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbAppeal struct {
+	ID                  int            `db:"id"`
+	UserID              int            `db:"user_id"`
+	UserName            string         `db:"user_name"`
+	UserAvatarType      int64          `db:"user_avatar_type"`
+	UserAvatarBkey      string         `db:"user_avatar_bkey"`
+	SanctionType        string         `db:"sanction_type"`
+	SanctionID          sql.NullInt64  `db:"sanction_id"`
+	SanctionReason      string         `db:"sanction_reason"`
+	SanctionExpiresAt   sql.NullTime   `db:"sanction_expires_at"`
+	Text                string         `db:"text"`
+	Status              string         `db:"status"`
+	CreatedAt           time.Time      `db:"created_at"`
+	DecidedAt           sql.NullTime   `db:"decided_at"`
+	DecidedByID         sql.NullInt64  `db:"decided_by_id"`
+	DecidedByName       sql.NullString `db:"decided_by_name"`
+	DecidedByAvatarType sql.NullInt64  `db:"decided_by_avatar_type"`
+	DecidedByAvatarBkey sql.NullString `db:"decided_by_avatar_bkey"`
+	DecisionNote        sql.NullString `db:"decision_note"`
+	ReducedUntil        sql.NullTime   `db:"reduced_until"`
+}
+
+const selectAppealSQL = `
+			SELECT
+				a.id, a.user_id, u.name as user_name, u.avatar_type as user_avatar_type, u.avatar_bkey as user_avatar_bkey,
+				a.sanction_type, a.sanction_id, a.sanction_reason,
+				COALESCE(w.expires_at, m.expires_at) as sanction_expires_at,
+				a.text, a.status, a.created_at, a.decided_at,
+				a.decided_by as decided_by_id, du.name as decided_by_name, du.avatar_type as decided_by_avatar_type, du.avatar_bkey as decided_by_avatar_bkey,
+				a.decision_note, a.reduced_until
+			FROM user_appeals a
+			INNER JOIN users u ON u.id = a.user_id AND u.tenant_id = a.tenant_id
+			LEFT JOIN users du ON du.id = a.decided_by AND du.tenant_id = a.tenant_id
+			LEFT JOIN user_warnings w ON a.sanction_type = 'warning' AND w.id = a.sanction_id AND w.tenant_id = a.tenant_id
+			LEFT JOIN user_mutes m ON a.sanction_type = 'mute' AND m.id = a.sanction_id AND m.tenant_id = a.tenant_id`
+
+func (a *dbAppeal) toModel(ctx context.Context) *entity.Appeal {
+	appeal := &entity.Appeal{
+		ID: a.ID,
+		User: &entity.User{
+			ID:        a.UserID,
+			Name:      a.UserName,
+			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(a.UserAvatarType), a.UserID, a.UserName, a.UserAvatarBkey),
+		},
+		SanctionType:   a.SanctionType,
+		SanctionReason: a.SanctionReason,
+		Text:           a.Text,
+		CreatedAt:      a.CreatedAt,
+	}
+	_ = appeal.Status.UnmarshalText([]byte(a.Status))
+
+	if a.SanctionID.Valid {
+		appeal.SanctionID = int(a.SanctionID.Int64)
+	}
+	if a.SanctionExpiresAt.Valid {
+		appeal.SanctionExpiresAt = &a.SanctionExpiresAt.Time
+	}
+	if a.DecidedAt.Valid {
+		appeal.DecidedAt = &a.DecidedAt.Time
+	}
+	if a.DecidedByID.Valid {
+		appeal.DecidedBy = &entity.User{
+			ID:   int(a.DecidedByID.Int64),
+			Name: a.DecidedByName.String,
+		}
+		if a.DecidedByAvatarType.Valid {
+			appeal.DecidedBy.AvatarURL = buildAvatarURL(ctx, enum.AvatarType(a.DecidedByAvatarType.Int64), int(a.DecidedByID.Int64), a.DecidedByName.String, a.DecidedByAvatarBkey.String)
+		}
+	}
+	if a.DecisionNote.Valid {
+		appeal.DecisionNote = a.DecisionNote.String
+	}
+	if a.ReducedUntil.Valid {
+		appeal.ReducedUntil = &a.ReducedUntil.Time
+	}
+	return appeal
+}
+
+func createAppeal(ctx context.Context, c *cmd.CreateAppeal) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var sanctionID any
+		if c.SanctionID > 0 {
+			sanctionID = c.SanctionID
+		}
+
+		err := trx.Scalar(&c.Result, `
+			INSERT INTO user_appeals (tenant_id, user_id, sanction_type, sanction_id, sanction_reason, text, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 'pending', NOW())
+			RETURNING id
+		`, tenant.ID, user.ID, c.SanctionType, sanctionID, c.SanctionReason, c.Text)
+		if err != nil {
+			return errors.Wrap(err, "failed to create appeal")
+		}
+		return nil
+	})
+}
+
+func decideAppeal(ctx context.Context, c *cmd.DecideAppeal) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var replacementID any
+		if c.ReplacementID > 0 {
+			replacementID = c.ReplacementID
+		}
+
+		_, err := trx.Execute(`
+			UPDATE user_appeals
+			SET status = $1, decided_at = NOW(), decided_by = $2, decision_note = $3, reduced_until = $4, replacement_id = $5
+			WHERE id = $6 AND tenant_id = $7 AND status = 'pending'
+		`, c.Status.String(), user.ID, nullIfEmpty(c.Note), c.ReducedUntil, replacementID, c.AppealID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to decide appeal")
+		}
+		return nil
+	})
+}
+
+func getAppealByID(ctx context.Context, q *query.GetAppealByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		appeal := dbAppeal{}
+		err := trx.Get(&appeal, selectAppealSQL+`
+			WHERE a.id = $1 AND a.tenant_id = $2
+		`, q.AppealID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get appeal with id '%d'", q.AppealID)
+		}
+		q.Result = appeal.toModel(ctx)
+		return nil
+	})
+}
+
+func listAppeals(ctx context.Context, q *query.ListAppeals) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if q.Page < 1 {
+			q.Page = 1
+		}
+		if q.PerPage < 1 {
+			q.PerPage = 20
+		}
+		offset := (q.Page - 1) * q.PerPage
+
+		conditions := "a.tenant_id = $1"
+		args := []interface{}{tenant.ID}
+		argIdx := 2
+
+		if len(q.Status) > 0 {
+			statusStrings := make([]string, len(q.Status))
+			for i, s := range q.Status {
+				statusStrings[i] = s.String()
+			}
+			conditions += " AND a.status = ANY($" + strconv.Itoa(argIdx) + ")"
+			args = append(args, pq.Array(statusStrings))
+			argIdx++
+		}
+
+		err := trx.Scalar(&q.Total, "SELECT COUNT(*) FROM user_appeals a WHERE "+conditions, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to count appeals")
+		}
+
+		var appeals []*dbAppeal
+		err = trx.Select(&appeals, selectAppealSQL+`
+			WHERE `+conditions+`
+			ORDER BY a.created_at ASC
+			LIMIT $`+strconv.Itoa(argIdx)+` OFFSET $`+strconv.Itoa(argIdx+1),
+			append(args, q.PerPage, offset)...)
+		if err != nil {
+			return errors.Wrap(err, "failed to list appeals")
+		}
+
+		q.Result = make([]*entity.Appeal, len(appeals))
+		for i, a := range appeals {
+			q.Result[i] = a.toModel(ctx)
+		}
+		return nil
+	})
+}
+
+func getUserAppeals(ctx context.Context, q *query.GetUserAppeals) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var appeals []*dbAppeal
+		err := trx.Select(&appeals, selectAppealSQL+`
+			WHERE a.user_id = $1 AND a.tenant_id = $2
+			ORDER BY a.created_at DESC
+		`, q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user appeals")
+		}
+
+		q.Result = make([]*entity.Appeal, len(appeals))
+		for i, a := range appeals {
+			q.Result[i] = a.toModel(ctx)
+		}
+		return nil
+	})
+}
+
+func isSanctionAppealable(ctx context.Context, q *query.IsSanctionAppealable) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var exists bool
+		var err error
+		if q.SanctionType == entity.AppealSanctionBlock {
+			// an upheld appeal only counts for the current block, users blocked before it was tracked keep theirs
+			exists, err = trx.Exists(`
+				SELECT 1 FROM user_appeals a
+				INNER JOIN users u ON u.id = a.user_id AND u.tenant_id = a.tenant_id
+				WHERE a.tenant_id = $1 AND a.user_id = $2 AND a.sanction_type = 'block'
+				AND (a.status = 'pending' OR (a.status = 'upheld' AND a.created_at >= COALESCE(u.blocked_at, '-infinity')))
+			`, tenant.ID, q.UserID)
+		} else {
+			exists, err = trx.Exists(`
+				SELECT 1 FROM user_appeals
+				WHERE tenant_id = $1 AND user_id = $2 AND sanction_type = $3 AND (sanction_id = $4 OR replacement_id = $4)
+			`, tenant.ID, q.UserID, q.SanctionType, q.SanctionID)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to check existing appeals")
+		}
+		q.Result = !exists
+		return nil
+	})
+}
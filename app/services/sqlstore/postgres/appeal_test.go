@@ -0,0 +1,55 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestAppealStorage_BlockCanBeAppealedAgainAfterNewBlock(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(jonSnowCtx, &cmd.BlockUser{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+
+	appealable := &query.IsSanctionAppealable{UserID: aryaStark.ID, SanctionType: entity.AppealSanctionBlock}
+	err = bus.Dispatch(aryaStarkCtx, appealable)
+	Expect(err).IsNil()
+	Expect(appealable.Result).IsTrue()
+
+	createAppeal := &cmd.CreateAppeal{SanctionType: entity.AppealSanctionBlock, Text: "I did nothing wrong"}
+	err = bus.Dispatch(aryaStarkCtx, createAppeal)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, appealable)
+	Expect(err).IsNil()
+	Expect(appealable.Result).IsFalse()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DecideAppeal{AppealID: createAppeal.Result, Status: enum.AppealStatusUpheld})
+	Expect(err).IsNil()
+
+	// the upheld appeal closes this block
+	err = bus.Dispatch(aryaStarkCtx, appealable)
+	Expect(err).IsNil()
+	Expect(appealable.Result).IsFalse()
+
+	Expect(bus.Dispatch(jonSnowCtx, &cmd.UnblockUser{UserID: aryaStark.ID})).IsNil()
+	Expect(bus.Dispatch(jonSnowCtx, &cmd.BlockUser{UserID: aryaStark.ID})).IsNil()
+
+	// the whole test runs in one transaction, so move the new block past the first appeal
+	_, err = trx.Execute("UPDATE users SET blocked_at = NOW() + INTERVAL '1 minute' WHERE id = $1", aryaStark.ID)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, appealable)
+	Expect(err).IsNil()
+	Expect(appealable.Result).IsTrue()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateAppeal{SanctionType: entity.AppealSanctionBlock, Text: "Please reconsider"})
+	Expect(err).IsNil()
+}
@@ -24,6 +24,7 @@ type dbComment struct {
 	ReactionCounts    dbx.NullString `db:"reaction_counts"`
 	ModerationPending bool           `db:"moderation_pending"`
 	ModerationData    dbx.NullString `db:"moderation_data"`
+	ShadowHidden      bool           `db:"shadow_hidden"`
 }
 
 func (c *dbComment) toModel(ctx context.Context) *entity.Comment {
@@ -50,6 +51,7 @@ func (c *dbComment) toModel(ctx context.Context) *entity.Comment {
 		if c.ModerationData.Valid {
 			comment.ModerationData = c.ModerationData.String
 		}
+		comment.ShadowHidden = c.ShadowHidden
 	}
 
 	return comment
@@ -59,10 +61,10 @@ func addNewComment(ctx context.Context, c *cmd.AddNewComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		var id int
 		if err := trx.Get(&id, `
-			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at) 
-			VALUES ($1, $2, $3, $4, $5) 
+			INSERT INTO comments (tenant_id, post_id, content, user_id, created_at, shadow_hidden, moderation_pending) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7) 
 			RETURNING id
-		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now()); err != nil {
+		`, tenant.ID, c.Post.ID, c.Content, user.ID, time.Now(), c.ShadowHidden, c.ModerationPending); err != nil {
 			return errors.Wrap(err, "failed add new comment")
 		}
 
@@ -245,7 +247,8 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 					at.attachment_bkeys,
 					ar.reaction_counts,
 					c.moderation_pending,
-					c.moderation_data
+					c.moderation_data,
+					c.shadow_hidden
 			FROM comments c
 			INNER JOIN posts p
 			ON p.id = c.post_id
@@ -263,7 +266,7 @@ func getCommentsByPost(ctx context.Context, q *query.GetCommentsByPost) error {
 			WHERE p.id = $1
 			AND p.tenant_id = $2
 			AND c.deleted_at IS NULL
-			AND (c.moderation_pending = FALSE OR c.user_id = $4 OR $5 = TRUE)
+			AND ((c.moderation_pending = FALSE AND c.shadow_hidden = FALSE) OR c.user_id = $4 OR $5 = TRUE)
 			ORDER BY c.created_at ASC`, q.Post.ID, tenant.ID, userId, userId, user != nil && (user.IsCollaborator() || user.IsModerator() || user.IsAdministrator()))
 		if err != nil {
 			return errors.Wrap(err, "failed get comments of post with id '%d'", q.Post.ID)
@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbCustomRole struct {
+	ID          int       `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	Permissions []string  `db:"permissions"`
+	MemberCount int       `db:"member_count"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (r *dbCustomRole) toModel() *entity.CustomRole {
+	return &entity.CustomRole{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: parsePermissions(r.Permissions),
+		MemberCount: r.MemberCount,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+// parsePermissions drops permissions that no longer exist
+func parsePermissions(names []string) []enum.Permission {
+	permissions := make([]enum.Permission, 0, len(names))
+	for _, name := range names {
+		if permission := enum.Permission(name); permission.IsValid() {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+func permissionNames(permissions []enum.Permission) []string {
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	return names
+}
+
+const selectCustomRoles = `
+	SELECT r.id, r.name, r.description, r.permissions, r.created_at,
+		(SELECT COUNT(*) FROM user_custom_roles ucr WHERE ucr.tenant_id = r.tenant_id AND ucr.custom_role_id = r.id) AS member_count
+	FROM custom_roles r
+`
+
+func listCustomRoles(ctx context.Context, q *query.ListCustomRoles) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var roles []*dbCustomRole
+		err := trx.Select(&roles, selectCustomRoles+"WHERE r.tenant_id = $1 ORDER BY r.name", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list custom roles")
+		}
+
+		q.Result = make([]*entity.CustomRole, len(roles))
+		for i, role := range roles {
+			q.Result[i] = role.toModel()
+		}
+		return nil
+	})
+}
+
+func getCustomRoleByID(ctx context.Context, q *query.GetCustomRoleByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		role := dbCustomRole{}
+		err := trx.Get(&role, selectCustomRoles+"WHERE r.tenant_id = $1 AND r.id = $2", tenant.ID, q.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get custom role '%d'", q.ID)
+		}
+		q.Result = role.toModel()
+		return nil
+	})
+}
+
+func getUserCustomRole(ctx context.Context, q *query.GetUserCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		role := dbCustomRole{}
+		err := trx.Get(&role, selectCustomRoles+`
+			INNER JOIN user_custom_roles u ON u.tenant_id = r.tenant_id AND u.custom_role_id = r.id
+			WHERE r.tenant_id = $1 AND u.user_id = $2
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get custom role of user '%d'", q.UserID)
+		}
+		q.Result = role.toModel()
+		return nil
+	})
+}
+
+func getUserPermissions(ctx context.Context, q *query.GetUserPermissions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var permissions []string
+		err := trx.Scalar(pq.Array(&permissions), `
+			SELECT r.permissions
+			FROM custom_roles r
+			INNER JOIN user_custom_roles u ON u.tenant_id = r.tenant_id AND u.custom_role_id = r.id
+			WHERE r.tenant_id = $1 AND u.user_id = $2
+		`, tenant.ID, q.UserID)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return errors.Wrap(err, "failed to get permissions of user '%d'", q.UserID)
+		}
+		q.Result = parsePermissions(permissions)
+		return nil
+	})
+}
+
+func listCustomRoleMembers(ctx context.Context, q *query.ListCustomRoleMembers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var users []*dbUser
+		err := trx.Select(&users, `
+			SELECT u.id, u.name, u.email, u.tenant_id, u.role, u.status, u.avatar_type, u.avatar_bkey, u.visual_role
+			FROM users u
+			INNER JOIN user_custom_roles ucr ON ucr.tenant_id = u.tenant_id AND ucr.user_id = u.id
+			WHERE u.tenant_id = $1 AND ucr.custom_role_id = $2 AND u.status != $3
+			ORDER BY u.name
+		`, tenant.ID, q.CustomRoleID, enum.UserDeleted)
+		if err != nil {
+			return errors.Wrap(err, "failed to list members of custom role '%d'", q.CustomRoleID)
+		}
+
+		q.Result = make([]*entity.User, len(users))
+		for i, u := range users {
+			q.Result[i] = u.toModel(ctx)
+		}
+		return nil
+	})
+}
+
+func createCustomRole(ctx context.Context, c *cmd.CreateCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		var id int
+		err := trx.Scalar(&id, `
+			INSERT INTO custom_roles (tenant_id, name, description, permissions, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`, tenant.ID, c.Name, c.Description, pq.Array(permissionNames(c.Permissions)), now)
+		if err != nil {
+			return errors.Wrap(err, "failed to create custom role")
+		}
+
+		c.Result = &entity.CustomRole{
+			ID:          id,
+			Name:        c.Name,
+			Description: c.Description,
+			Permissions: c.Permissions,
+			CreatedAt:   now,
+		}
+		return nil
+	})
+}
+
+func updateCustomRole(ctx context.Context, c *cmd.UpdateCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(
+			"UPDATE custom_roles SET name = $3, description = $4, permissions = $5 WHERE tenant_id = $1 AND id = $2",
+			tenant.ID, c.ID, c.Name, c.Description, pq.Array(permissionNames(c.Permissions)),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update custom role '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func deleteCustomRole(ctx context.Context, c *cmd.DeleteCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute("DELETE FROM custom_roles WHERE tenant_id = $1 AND id = $2", tenant.ID, c.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete custom role '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func setUserCustomRole(ctx context.Context, c *cmd.SetUserCustomRole) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if c.CustomRoleID == 0 {
+			_, err := trx.Execute("DELETE FROM user_custom_roles WHERE tenant_id = $1 AND user_id = $2", tenant.ID, c.UserID)
+			if err != nil {
+				return errors.Wrap(err, "failed to remove custom role of user '%d'", c.UserID)
+			}
+			return nil
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO user_custom_roles (tenant_id, user_id, custom_role_id, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, user_id) DO UPDATE SET custom_role_id = $3, created_at = $4
+		`, tenant.ID, c.UserID, c.CustomRoleID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to assign custom role '%d' to user '%d'", c.CustomRoleID, c.UserID)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,153 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestCustomRoleStorage_CreateAndUpdate(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createRole := &cmd.CreateCustomRole{Name: "Tag Gardener", Description: "Keeps tags tidy", Permissions: []enum.Permission{enum.PermissionTagAssign}}
+	err := bus.Dispatch(jonSnowCtx, createRole)
+	Expect(err).IsNil()
+
+	getRole := &query.GetCustomRoleByID{ID: createRole.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, getRole)
+	Expect(err).IsNil()
+	Expect(getRole.Result.Name).Equals("Tag Gardener")
+	Expect(getRole.Result.Description).Equals("Keeps tags tidy")
+	Expect(getRole.Result.Permissions).Equals([]enum.Permission{enum.PermissionTagAssign})
+	Expect(getRole.Result.MemberCount).Equals(0)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UpdateCustomRole{
+		ID:          createRole.Result.ID,
+		Name:        "Tag Keeper",
+		Permissions: []enum.Permission{enum.PermissionTagAssign, enum.PermissionTagPrivate},
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, getRole)
+	Expect(err).IsNil()
+	Expect(getRole.Result.Name).Equals("Tag Keeper")
+	Expect(getRole.Result.Description).Equals("")
+	Expect(getRole.Result.Permissions).Equals([]enum.Permission{enum.PermissionTagAssign, enum.PermissionTagPrivate})
+
+	// permissions that no longer exist are ignored when they are read
+	_, err = trx.Execute("UPDATE custom_roles SET permissions = ARRAY['tag.assign', 'tag.removed'] WHERE id = $1", createRole.Result.ID)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, getRole)
+	Expect(err).IsNil()
+	Expect(getRole.Result.Permissions).Equals([]enum.Permission{enum.PermissionTagAssign})
+}
+
+func TestCustomRoleStorage_TenantIsolation(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createRole := &cmd.CreateCustomRole{Name: "Tag Gardener", Permissions: []enum.Permission{enum.PermissionTagAssign}}
+	err := bus.Dispatch(jonSnowCtx, createRole)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(tonyStarkCtx, &query.GetCustomRoleByID{ID: createRole.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	listRoles := &query.ListCustomRoles{}
+	err = bus.Dispatch(tonyStarkCtx, listRoles)
+	Expect(err).IsNil()
+	Expect(listRoles.Result).HasLen(0)
+
+	err = bus.Dispatch(tonyStarkCtx, &cmd.UpdateCustomRole{ID: createRole.Result.ID, Name: "Hijacked"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(tonyStarkCtx, &cmd.DeleteCustomRole{ID: createRole.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	listRoles = &query.ListCustomRoles{}
+	err = bus.Dispatch(jonSnowCtx, listRoles)
+	Expect(err).IsNil()
+	Expect(listRoles.Result).HasLen(1)
+	Expect(listRoles.Result[0].Name).Equals("Tag Gardener")
+}
+
+func TestCustomRoleStorage_AssignAndRemove(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	gardener := &cmd.CreateCustomRole{Name: "Tag Gardener", Permissions: []enum.Permission{enum.PermissionTagAssign}}
+	err := bus.Dispatch(jonSnowCtx, gardener)
+	Expect(err).IsNil()
+	reviewer := &cmd.CreateCustomRole{Name: "Report Reviewer", Permissions: []enum.Permission{enum.PermissionReportView}}
+	err = bus.Dispatch(jonSnowCtx, reviewer)
+	Expect(err).IsNil()
+
+	getPermissions := &query.GetUserPermissions{UserID: aryaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, getPermissions)
+	Expect(err).IsNil()
+	Expect(getPermissions.Result).HasLen(0)
+
+	err = bus.Dispatch(jonSnowCtx, &query.GetUserCustomRole{UserID: aryaStark.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetUserCustomRole{UserID: aryaStark.ID, CustomRoleID: gardener.Result.ID})
+	Expect(err).IsNil()
+
+	// a user has one custom role at most, assigning another one replaces it
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetUserCustomRole{UserID: aryaStark.ID, CustomRoleID: reviewer.Result.ID})
+	Expect(err).IsNil()
+
+	getRole := &query.GetUserCustomRole{UserID: aryaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, getRole)
+	Expect(err).IsNil()
+	Expect(getRole.Result.ID).Equals(reviewer.Result.ID)
+	Expect(getRole.Result.MemberCount).Equals(1)
+
+	err = bus.Dispatch(jonSnowCtx, getPermissions)
+	Expect(err).IsNil()
+	Expect(getPermissions.Result).Equals([]enum.Permission{enum.PermissionReportView})
+
+	listMembers := &query.ListCustomRoleMembers{CustomRoleID: reviewer.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, listMembers)
+	Expect(err).IsNil()
+	Expect(listMembers.Result).HasLen(1)
+	Expect(listMembers.Result[0].ID).Equals(aryaStark.ID)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetUserCustomRole{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, getPermissions)
+	Expect(err).IsNil()
+	Expect(getPermissions.Result).HasLen(0)
+}
+
+func TestCustomRoleStorage_DeleteRemovesAssignments(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createRole := &cmd.CreateCustomRole{Name: "Tag Gardener", Permissions: []enum.Permission{enum.PermissionTagAssign}}
+	err := bus.Dispatch(jonSnowCtx, createRole)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetUserCustomRole{UserID: aryaStark.ID, CustomRoleID: createRole.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteCustomRole{ID: createRole.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &query.GetCustomRoleByID{ID: createRole.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	getPermissions := &query.GetUserPermissions{UserID: aryaStark.ID}
+	err = bus.Dispatch(jonSnowCtx, getPermissions)
+	Expect(err).IsNil()
+	Expect(getPermissions.Result).HasLen(0)
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbDigestItem struct {
+	ID         int       `db:"id"`
+	TenantID   int       `db:"tenant_id"`
+	UserID     int       `db:"user_id"`
+	Event      string    `db:"event"`
+	AuthorName string    `db:"author_name"`
+	Excerpt    string    `db:"excerpt"`
+	BaseURL    string    `db:"base_url"`
+	Link       string    `db:"link"`
+	PostID     int       `db:"post_id"`
+	PostNumber int       `db:"post_number"`
+	PostSlug   string    `db:"post_slug"`
+	PostTitle  string    `db:"post_title"`
+	CreatedAt  time.Time `db:"created_at"`
+	Frequency  string    `db:"frequency"`
+	Timezone   string    `db:"timezone"`
+	QuietStart string    `db:"quiet_start"`
+	QuietEnd   string    `db:"quiet_end"`
+}
+
+func (i *dbDigestItem) toModel() *entity.DigestItem {
+	return &entity.DigestItem{
+		ID:         i.ID,
+		Event:      i.Event,
+		AuthorName: i.AuthorName,
+		Excerpt:    i.Excerpt,
+		Link:       i.Link,
+		PostID:     i.PostID,
+		PostNumber: i.PostNumber,
+		PostSlug:   i.PostSlug,
+		PostTitle:  i.PostTitle,
+		CreatedAt:  i.CreatedAt,
+	}
+}
+
+func queueDigestItems(ctx context.Context, c *cmd.QueueDigestItems) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		for _, u := range c.Users {
+			_, err := trx.Execute(`
+				INSERT INTO notification_digest_items (tenant_id, user_id, post_id, event, author_name, excerpt, base_url, link, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+			`, tenant.ID, u.ID, c.PostID, c.Event, c.AuthorName, c.Excerpt, c.BaseURL, c.Link)
+			if err != nil {
+				return errors.Wrap(err, "failed to queue digest item")
+			}
+		}
+		return nil
+	})
+}
+
+func getPendingDigests(ctx context.Context, q *query.GetPendingDigests) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		q.Result = make([]*entity.Digest, 0)
+
+		var items []*dbDigestItem
+		err := trx.Select(&items, `
+			SELECT d.id, d.tenant_id, d.user_id, d.event, d.author_name, d.excerpt, d.base_url, d.link, d.created_at,
+				p.id AS post_id, p.number AS post_number, p.slug AS post_slug, p.title AS post_title,
+				COALESCE(s.value, $2) AS frequency, COALESCE(tz.value, '') AS timezone,
+				COALESCE(qs.value, '') AS quiet_start, COALESCE(qe.value, '') AS quiet_end
+			FROM notification_digest_items d
+			INNER JOIN posts p ON p.id = d.post_id AND p.tenant_id = d.tenant_id
+			INNER JOIN users u ON u.id = d.user_id AND u.tenant_id = d.tenant_id
+			INNER JOIN tenants t ON t.id = d.tenant_id
+			LEFT JOIN user_settings s ON s.user_id = d.user_id AND s.tenant_id = d.tenant_id AND s.key = $1
+			LEFT JOIN user_settings tz ON tz.user_id = d.user_id AND tz.tenant_id = d.tenant_id AND tz.key = $7
+			LEFT JOIN user_settings qs ON qs.user_id = d.user_id AND qs.tenant_id = d.tenant_id AND qs.key = $8
+			LEFT JOIN user_settings qe ON qe.user_id = d.user_id AND qe.tenant_id = d.tenant_id AND qe.key = $9
+			WHERE COALESCE(s.value, $2) = ANY($3)
+			AND p.status <> $4
+			AND u.status = $5
+			AND u.email_supressed_at IS NULL
+			AND t.status = $6
+			ORDER BY d.tenant_id, d.user_id, d.created_at
+		`, enum.DigestFrequencySettingsKey, enum.DigestFrequencyDaily, pq.Array(q.Frequencies), enum.PostDeleted, enum.UserActive, enum.TenantActive,
+			enum.TimezoneSettingsKey, enum.QuietHoursStartSettingsKey, enum.QuietHoursEndSettingsKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to get pending digest items")
+		}
+
+		tenantIDs := make([]int, len(items))
+		for i, item := range items {
+			tenantIDs[i] = item.TenantID
+		}
+		tenants, err := getTenantsByID(trx, tenantIDs)
+		if err != nil {
+			return err
+		}
+
+		var current *entity.Digest
+		for _, item := range items {
+			if current == nil || current.Tenant.ID != item.TenantID || current.User.ID != item.UserID {
+				dbUser := dbUser{}
+				err = trx.Get(&dbUser, `
+					SELECT id, name, email, tenant_id, role, status
+					FROM users
+					WHERE id = $1 AND tenant_id = $2
+				`, item.UserID, item.TenantID)
+				if err != nil {
+					return errors.Wrap(err, "failed to get user '%d' of digest", item.UserID)
+				}
+
+				location, err := time.LoadLocation(item.Timezone)
+				if err != nil {
+					location = time.UTC
+				}
+
+				current = &entity.Digest{
+					Tenant:     tenants[item.TenantID],
+					User:       dbUser.toModel(ctx),
+					BaseURL:    item.BaseURL,
+					Frequency:  item.Frequency,
+					Location:   location,
+					QuietHours: entity.NewQuietHours(item.Timezone, item.QuietStart, item.QuietEnd),
+				}
+				q.Result = append(q.Result, current)
+			}
+			current.Items = append(current.Items, item.toModel())
+		}
+		return nil
+	})
+}
+
+func clearDigestItems(ctx context.Context, c *cmd.ClearDigestItems) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		// items that could not be delivered for a month (deleted posts, blocked users, supressed emails) are dropped as well
+		count, err := trx.Execute(`
+			DELETE FROM notification_digest_items
+			WHERE id = ANY($1) OR created_at <= NOW() - INTERVAL '30 days'
+		`, pq.Array(c.ItemIDs))
+		if err != nil {
+			return errors.Wrap(err, "failed to clear digest items")
+		}
+		c.NumOfClearedItems = int(count)
+		return nil
+	})
+}
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbQueuedEmail struct {
+	ID            int            `db:"id"`
+	TenantID      sql.NullInt64  `db:"tenant_id"`
+	TemplateName  string         `db:"template_name"`
+	Recipient     string         `db:"recipient"`
+	Payload       string         `db:"payload"`
+	BaseURL       string         `db:"base_url"`
+	Status        int            `db:"status"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+	SentAt        dbx.NullTime   `db:"sent_at"`
+}
+
+func (e *dbQueuedEmail) toModel() *entity.QueuedEmail {
+	email := &entity.QueuedEmail{
+		ID:            e.ID,
+		TemplateName:  e.TemplateName,
+		Recipient:     e.Recipient,
+		Payload:       e.Payload,
+		BaseURL:       e.BaseURL,
+		Status:        enum.QueuedEmailStatus(e.Status),
+		Attempts:      e.Attempts,
+		LastError:     e.LastError.String,
+		NextAttemptAt: e.NextAttemptAt,
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.SentAt.Valid {
+		email.SentAt = &e.SentAt.Time
+	}
+	return email
+}
+
+func queueMail(ctx context.Context, c *cmd.QueueMail) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		// emails sent before a tenant exists, like the sign up verification, are queued without one
+		var tenantID any
+		if tenant != nil {
+			tenantID = tenant.ID
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO email_queue (tenant_id, template_name, recipient, payload, base_url, status, attempts, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 0, NOW(), NOW())
+		`, tenantID, c.TemplateName, c.Recipient, c.Payload, c.BaseURL, enum.QueuedEmailPending)
+		if err != nil {
+			return errors.Wrap(err, "failed to queue email with template '%s'", c.TemplateName)
+		}
+		return nil
+	})
+}
+
+func getDueQueuedEmails(ctx context.Context, q *query.GetDueQueuedEmails) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		q.Result = make([]*entity.QueuedEmail, 0)
+		if q.Limit <= 0 {
+			return nil
+		}
+
+		var rows []*dbQueuedEmail
+		err := trx.Select(&rows, `
+			SELECT e.id, e.tenant_id, e.template_name, e.recipient, e.payload, e.base_url, e.status, e.attempts, e.last_error, e.next_attempt_at, e.created_at, e.sent_at
+			FROM email_queue e
+			LEFT JOIN tenants t ON t.id = e.tenant_id
+			WHERE e.status = $1
+			AND e.next_attempt_at <= NOW()
+			AND (e.tenant_id IS NULL OR t.status <> $2)
+			ORDER BY e.next_attempt_at
+			LIMIT $3
+		`, enum.QueuedEmailPending, enum.TenantDisabled, q.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to get due queued emails")
+		}
+
+		tenantIDs := make([]int, 0, len(rows))
+		for _, row := range rows {
+			if row.TenantID.Valid {
+				tenantIDs = append(tenantIDs, int(row.TenantID.Int64))
+			}
+		}
+		tenants, err := getTenantsByID(trx, tenantIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			email := row.toModel()
+			if row.TenantID.Valid {
+				email.Tenant = tenants[int(row.TenantID.Int64)]
+			}
+			q.Result = append(q.Result, email)
+		}
+		return nil
+	})
+}
+
+func countRecentEmailAttempts(ctx context.Context, q *query.CountRecentEmailAttempts) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		err := trx.Scalar(&q.Result, "SELECT COUNT(*) FROM email_queue WHERE last_attempt_at >= $1", q.Since)
+		if err != nil {
+			return errors.Wrap(err, "failed to count recent email attempts")
+		}
+		return nil
+	})
+}
+
+func updateQueuedEmail(ctx context.Context, c *cmd.UpdateQueuedEmail) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		var lastError any
+		if c.LastError != "" {
+			lastError = c.LastError
+		}
+
+		_, err := trx.Execute(`
+			UPDATE email_queue
+			SET status = $2,
+				last_error = $3,
+				next_attempt_at = CASE WHEN $2 = $4 THEN $5 ELSE next_attempt_at END,
+				sent_at = CASE WHEN $2 = $6 THEN NOW() ELSE NULL END,
+				attempts = attempts + 1,
+				last_attempt_at = NOW()
+			WHERE id = $1
+		`, c.ID, c.Status, lastError, enum.QueuedEmailPending, c.NextAttemptAt, enum.QueuedEmailSent)
+		if err != nil {
+			return errors.Wrap(err, "failed to update queued email '%d'", c.ID)
+		}
+		return nil
+	})
+}
+
+func retryQueuedEmail(ctx context.Context, c *cmd.RetryQueuedEmail) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE email_queue
+			SET status = $3, next_attempt_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND status = $4
+		`, c.ID, tenant.ID, enum.QueuedEmailPending, enum.QueuedEmailFailed)
+		if err != nil {
+			return errors.Wrap(err, "failed to retry queued email '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func purgeQueuedEmails(ctx context.Context, c *cmd.PurgeQueuedEmails) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		rows, err := trx.Execute(`
+			DELETE FROM email_queue
+			WHERE created_at < $1 AND status <> $2
+		`, c.Before, enum.QueuedEmailPending)
+		if err != nil {
+			return errors.Wrap(err, "failed to purge queued emails")
+		}
+		c.NumOfDeletedEmails = int(rows)
+		return nil
+	})
+}
+
+func listQueuedEmails(ctx context.Context, q *query.ListQueuedEmails) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var rows []*dbQueuedEmail
+		err := trx.Select(&rows, `
+			SELECT id, tenant_id, template_name, recipient, payload, base_url, status, attempts, last_error, next_attempt_at, created_at, sent_at
+			FROM email_queue
+			WHERE tenant_id = $1 AND ($2 = 0 OR status = $2)
+			ORDER BY created_at DESC, id DESC
+			LIMIT 100
+		`, tenant.ID, q.Status)
+		if err != nil {
+			return errors.Wrap(err, "failed to list queued emails")
+		}
+
+		q.Result = make([]*entity.QueuedEmail, len(rows))
+		for i, row := range rows {
+			q.Result[i] = row.toModel()
+		}
+		return nil
+	})
+}
+
+func countQueuedEmailsByStatus(ctx context.Context, q *query.CountQueuedEmailsByStatus) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		type dbStatusCount struct {
+			Status int `db:"status"`
+			Count  int `db:"count"`
+		}
+
+		var rows []*dbStatusCount
+		err := trx.Select(&rows, `
+			SELECT status, COUNT(*) AS count
+			FROM email_queue
+			WHERE tenant_id = $1
+			GROUP BY status
+		`, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count queued emails")
+		}
+
+		q.Result = map[enum.QueuedEmailStatus]int{
+			enum.QueuedEmailPending: 0,
+			enum.QueuedEmailSent:    0,
+			enum.QueuedEmailFailed:  0,
+		}
+		for _, row := range rows {
+			q.Result[enum.QueuedEmailStatus(row.Status)] = row.Count
+		}
+		return nil
+	})
+}
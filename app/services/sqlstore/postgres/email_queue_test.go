@@ -0,0 +1,144 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestEmailQueueStorage_TenantIsolation(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.QueueMail{TemplateName: "new_comment", Recipient: "arya.stark@got.com", Payload: "{}", BaseURL: "http://demo.test.fider.io"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(avengersTenantCtx, &cmd.QueueMail{TemplateName: "new_post", Recipient: "tony.stark@avengers.com", Payload: "{}", BaseURL: "http://avengers.test.fider.io"})
+	Expect(err).IsNil()
+
+	listEmails := &query.ListQueuedEmails{}
+	err = bus.Dispatch(demoTenantCtx, listEmails)
+	Expect(err).IsNil()
+	Expect(listEmails.Result).HasLen(1)
+	Expect(listEmails.Result[0].TemplateName).Equals("new_comment")
+	Expect(listEmails.Result[0].Recipient).Equals("arya.stark@got.com")
+	Expect(listEmails.Result[0].Status).Equals(enum.QueuedEmailPending)
+
+	countEmails := &query.CountQueuedEmailsByStatus{}
+	err = bus.Dispatch(avengersTenantCtx, countEmails)
+	Expect(err).IsNil()
+	Expect(countEmails.Result[enum.QueuedEmailPending]).Equals(1)
+	Expect(countEmails.Result[enum.QueuedEmailFailed]).Equals(0)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.UpdateQueuedEmail{ID: listEmails.Result[0].ID, Status: enum.QueuedEmailFailed, LastError: "mailbox is full"})
+	Expect(err).IsNil()
+
+	// a failed email can only be retried by its tenant
+	err = bus.Dispatch(avengersTenantCtx, &cmd.RetryQueuedEmail{ID: listEmails.Result[0].ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.RetryQueuedEmail{ID: listEmails.Result[0].ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.RetryQueuedEmail{ID: listEmails.Result[0].ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestEmailQueueStorage_DueEmails(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.QueueMail{TemplateName: "new_comment", Recipient: "arya.stark@got.com", Payload: "{}", BaseURL: "http://demo.test.fider.io"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(avengersTenantCtx, &cmd.QueueMail{TemplateName: "new_post", Recipient: "tony.stark@avengers.com", Payload: "{}", BaseURL: "http://avengers.test.fider.io"})
+	Expect(err).IsNil()
+
+	getDue := &query.GetDueQueuedEmails{Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, getDue)
+	Expect(err).IsNil()
+	Expect(getDue.Result).HasLen(2)
+
+	var demoEmailID int
+	for _, email := range getDue.Result {
+		if email.Recipient == "arya.stark@got.com" {
+			demoEmailID = email.ID
+			Expect(email.Tenant.ID).Equals(demoTenant.ID)
+		} else {
+			Expect(email.Tenant.ID).Equals(avengersTenant.ID)
+		}
+	}
+
+	// an email that failed temporarily waits for its next attempt
+	err = bus.Dispatch(demoTenantCtx, &cmd.UpdateQueuedEmail{
+		ID:            demoEmailID,
+		Status:        enum.QueuedEmailPending,
+		LastError:     "too many requests",
+		NextAttemptAt: time.Now().Add(time.Hour),
+	})
+	Expect(err).IsNil()
+
+	countAttempts := &query.CountRecentEmailAttempts{Since: time.Now().Add(-time.Hour)}
+	err = bus.Dispatch(demoTenantCtx, countAttempts)
+	Expect(err).IsNil()
+	Expect(countAttempts.Result).Equals(1)
+
+	// emails of disabled tenants are never sent
+	_, err = trx.Execute("UPDATE tenants SET status = $1 WHERE id = $2", enum.TenantDisabled, avengersTenant.ID)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getDue)
+	Expect(err).IsNil()
+	Expect(getDue.Result).HasLen(0)
+
+	listEmails := &query.ListQueuedEmails{Status: enum.QueuedEmailPending}
+	err = bus.Dispatch(demoTenantCtx, listEmails)
+	Expect(err).IsNil()
+	Expect(listEmails.Result).HasLen(1)
+	Expect(listEmails.Result[0].Attempts).Equals(1)
+	Expect(listEmails.Result[0].LastError).Equals("too many requests")
+}
+
+func TestEmailQueueStorage_SentAndPurge(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.QueueMail{TemplateName: "new_comment", Recipient: "arya.stark@got.com", Payload: "{}", BaseURL: "http://demo.test.fider.io"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(demoTenantCtx, &cmd.QueueMail{TemplateName: "new_comment", Recipient: "sansa.stark@got.com", Payload: "{}", BaseURL: "http://demo.test.fider.io"})
+	Expect(err).IsNil()
+
+	listEmails := &query.ListQueuedEmails{}
+	err = bus.Dispatch(demoTenantCtx, listEmails)
+	Expect(err).IsNil()
+	Expect(listEmails.Result).HasLen(2)
+
+	sentID := listEmails.Result[0].ID
+	err = bus.Dispatch(demoTenantCtx, &cmd.UpdateQueuedEmail{ID: sentID, Status: enum.QueuedEmailSent})
+	Expect(err).IsNil()
+
+	listEmails = &query.ListQueuedEmails{Status: enum.QueuedEmailSent}
+	err = bus.Dispatch(demoTenantCtx, listEmails)
+	Expect(err).IsNil()
+	Expect(listEmails.Result).HasLen(1)
+	Expect(listEmails.Result[0].ID).Equals(sentID)
+	Expect(listEmails.Result[0].SentAt).IsNotNil()
+	Expect(listEmails.Result[0].LastError).Equals("")
+
+	// pending emails are kept, however old they are
+	purge := &cmd.PurgeQueuedEmails{Before: time.Now().Add(time.Minute)}
+	err = bus.Dispatch(demoTenantCtx, purge)
+	Expect(err).IsNil()
+	Expect(purge.NumOfDeletedEmails).Equals(1)
+
+	countEmails := &query.CountQueuedEmailsByStatus{}
+	err = bus.Dispatch(demoTenantCtx, countEmails)
+	Expect(err).IsNil()
+	Expect(countEmails.Result[enum.QueuedEmailPending]).Equals(1)
+	Expect(countEmails.Result[enum.QueuedEmailSent]).Equals(0)
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbEmailTemplate struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Locale    string    `db:"locale"`
+	Subject   string    `db:"subject"`
+	Body      string    `db:"body"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (t *dbEmailTemplate) toModel() *entity.EmailTemplate {
+	return &entity.EmailTemplate{
+		ID:        t.ID,
+		Name:      t.Name,
+		Locale:    t.Locale,
+		Subject:   t.Subject,
+		Body:      t.Body,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func getEmailTemplate(ctx context.Context, q *query.GetEmailTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = nil
+
+		template := dbEmailTemplate{}
+		err := trx.Get(&template, `
+			SELECT id, name, locale, subject, body, updated_at
+			FROM email_templates
+			WHERE tenant_id = $1 AND name = $2 AND locale = $3
+		`, tenant.ID, q.Name, q.Locale)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return nil
+			}
+			return errors.Wrap(err, "failed to get email template '%s'", q.Name)
+		}
+
+		q.Result = template.toModel()
+		return nil
+	})
+}
+
+func listEmailTemplates(ctx context.Context, q *query.ListEmailTemplates) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var templates []*dbEmailTemplate
+		err := trx.Select(&templates, `
+			SELECT id, name, locale, subject, body, updated_at
+			FROM email_templates
+			WHERE tenant_id = $1
+			ORDER BY name, locale
+		`, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list email templates")
+		}
+
+		q.Result = make([]*entity.EmailTemplate, len(templates))
+		for i, template := range templates {
+			q.Result[i] = template.toModel()
+		}
+		return nil
+	})
+}
+
+func saveEmailTemplate(ctx context.Context, c *cmd.SaveEmailTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		template := dbEmailTemplate{}
+		err := trx.Get(&template, `
+			INSERT INTO email_templates (tenant_id, name, locale, subject, body, updated_by_id, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+			ON CONFLICT (tenant_id, name, locale)
+			DO UPDATE SET subject = $4, body = $5, updated_by_id = $6, updated_at = NOW()
+			RETURNING id, name, locale, subject, body, updated_at
+		`, tenant.ID, c.Name, c.Locale, c.Subject, c.Body, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to save email template '%s'", c.Name)
+		}
+
+		c.Result = template.toModel()
+		return nil
+	})
+}
+
+func deleteEmailTemplate(ctx context.Context, c *cmd.DeleteEmailTemplate) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"DELETE FROM email_templates WHERE tenant_id = $1 AND name = $2 AND locale = $3",
+			tenant.ID, c.Name, c.Locale,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete email template '%s'", c.Name)
+		}
+		return nil
+	})
+}
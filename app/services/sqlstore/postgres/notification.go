@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
@@ -128,9 +129,16 @@ func getActiveNotifications(ctx context.Context, q *query.GetActiveNotifications
 		}
 
 		query := fmt.Sprintf(`
-			SELECT n.id, n.title, n.link, n.read, n.created_at, n.author_id, u.avatar_type, u.avatar_bkey, u.name
+			SELECT n.id, n.title, n.link, n.read, n.created_at, n.author_id, u.avatar_type, u.avatar_bkey, u.name,
+				COALESCE(n.event, '') AS event, COALESCE(p.title, '') AS post_title, cardinality(n.actor_ids) AS actor_count,
+				ARRAY(
+					SELECT a.name FROM unnest(n.actor_ids[1:3]) WITH ORDINALITY AS ids(id, position)
+					INNER JOIN users a ON a.id = ids.id
+					ORDER BY ids.position
+				) AS latest_actors
 			FROM notifications n
 			LEFT JOIN users u ON u.id = n.author_id
+			LEFT JOIN posts p ON p.id = n.post_id
 			WHERE %s
 			ORDER BY n.updated_at DESC
 			LIMIT $%d OFFSET $%d
@@ -195,14 +203,45 @@ func addNewNotification(ctx context.Context, c *cmd.AddNewNotification) error {
 			postID = nil
 		}
 
+		var event interface{} = c.Event
+		if c.Event == "" || c.PostID == 0 {
+			event = nil
+		}
+
+		// the same event on a post is collapsed into the unread notification the user already has, with the latest actor first
+		if event != nil {
+			err := trx.Get(notification, `
+				UPDATE notifications
+				SET title = $5, link = $6, author_id = $7, updated_at = $8,
+				actor_ids = array_prepend($7::INT, array_remove(actor_ids, $7::INT))
+				WHERE id = (
+					SELECT id FROM notifications
+					WHERE tenant_id = $1 AND user_id = $2 AND post_id = $3 AND event = $4 AND read = false
+					ORDER BY updated_at DESC
+					LIMIT 1
+				)
+				RETURNING id, created_at, cardinality(actor_ids) AS actor_count
+			`, tenant.ID, c.User.ID, postID, event, c.Title, c.Link, user.ID, now)
+			if err == nil {
+				notification.Event = c.Event
+				c.Result = notification
+				return nil
+			}
+			if errors.Cause(err) != app.ErrNotFound {
+				return errors.Wrap(err, "failed to group notification")
+			}
+		}
+
 		err := trx.Get(&notification.ID, `
-			INSERT INTO notifications (tenant_id, user_id, title, link, read, post_id, author_id, created_at, updated_at) 
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+			INSERT INTO notifications (tenant_id, user_id, title, link, read, post_id, author_id, created_at, updated_at, event, actor_ids) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, ARRAY[$7::INT])
 			RETURNING id
-		`, tenant.ID, c.User.ID, c.Title, c.Link, false, postID, user.ID, now)
+		`, tenant.ID, c.User.ID, c.Title, c.Link, false, postID, user.ID, now, event)
 		if err != nil {
 			return errors.Wrap(err, "failed to insert notification")
 		}
+		notification.Event = c.Event
+		notification.ActorCount = 1
 
 		c.Result = notification
 		return nil
@@ -230,26 +269,108 @@ func removeSubscriber(ctx context.Context, c *cmd.RemoveSubscriber) error {
 	})
 }
 
+func muteSubscriber(ctx context.Context, c *cmd.MuteSubscriber) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO post_subscribers (tenant_id, user_id, post_id, created_at, updated_at, status)
+			VALUES ($1, $2, $3, $4, $4, $5) ON CONFLICT (user_id, post_id)
+			DO UPDATE SET status = $5, updated_at = $4`,
+			tenant.ID, c.User.ID, c.Post.ID, time.Now(), enum.SubscriberMuted,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed mute post subscriber")
+		}
+		return nil
+	})
+}
+
+func setTagPreference(ctx context.Context, c *cmd.SetTagPreference) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if c.Preference == 0 {
+			_, err := trx.Execute(`
+				DELETE FROM user_tag_preferences WHERE tenant_id = $1 AND user_id = $2 AND tag_id = $3
+			`, tenant.ID, c.User.ID, c.Tag.ID)
+			if err != nil {
+				return errors.Wrap(err, "failed to clear tag preference")
+			}
+			return nil
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO user_tag_preferences (tenant_id, user_id, tag_id, preference, created_at)
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT (user_id, tag_id)
+			DO UPDATE SET preference = $4, created_at = $5
+		`, tenant.ID, c.User.ID, c.Tag.ID, c.Preference, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to set tag preference")
+		}
+		return nil
+	})
+}
+
+func getTagPreferences(ctx context.Context, q *query.GetTagPreferences) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.UserTagPreference, 0)
+		if user == nil {
+			return nil
+		}
+
+		err := trx.Select(&q.Result, `
+			SELECT tag_id, preference FROM user_tag_preferences
+			WHERE tenant_id = $1 AND user_id = $2
+			ORDER BY tag_id
+		`, tenant.ID, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get tag preferences")
+		}
+		return nil
+	})
+}
+
+// tagPreferenceCondition matches users that have the given preference for at least one of the tags of the post
+func tagPreferenceCondition(postIDParam string, preference enum.TagPreference) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM user_tag_preferences utp
+		INNER JOIN post_tags pt ON pt.tag_id = utp.tag_id AND pt.tenant_id = utp.tenant_id
+		WHERE utp.user_id = u.id AND utp.tenant_id = u.tenant_id AND pt.post_id = %s AND utp.preference = %d
+	)`, postIDParam, preference)
+}
+
 func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.User, 0)
 
 		var (
-			users []*dbUser
-			err   error
+			users  []*dbUser
+			postID int
+			err    error
 		)
 
-		// When searching for email subscrivers, skip users with email supressed
+		err = trx.Scalar(&postID, "SELECT id FROM posts WHERE tenant_id = $1 AND number = $2", tenant.ID, q.Number)
+		if err != nil && errors.Cause(err) != app.ErrNotFound {
+			return errors.Wrap(err, "failed to get post number '%d'", q.Number)
+		}
+
+		// When searching for email or digest subscribers, skip users with email supressed
 		supressionCondition := ""
-		if q.Channel == enum.NotificationChannelEmail {
+		if q.Channel == enum.NotificationChannelEmail || q.Channel == enum.NotificationChannelDigest {
 			supressionCondition = "AND u.email_supressed_at IS NULL"
 		}
 
+		// Following a tag turns on the event with its default channels, unless the user changed them explicitly
+		enabledByDefault := q.Event.IsEnabledByDefault(q.Channel)
+
 		// If the event doesn't require a subscription, notify everyone
+		// A muted post or tag silences it unless the user subscribed to the post,
+		// and users who follow tags only hear about posts in those tags
 		if len(q.Event.RequiresSubscriptionUserRoles) == 0 {
 			err = trx.Select(&users, fmt.Sprintf(`
 				SELECT DISTINCT u.id, u.name, u.email, u.tenant_id, u.role, u.status
 				FROM users u
+				LEFT JOIN post_subscribers sub
+				ON sub.user_id = u.id
+				AND sub.post_id = $6
+				AND sub.tenant_id = u.tenant_id
 				LEFT JOIN user_settings set
 				ON set.user_id = u.id
 				AND set.tenant_id = u.tenant_id
@@ -257,40 +378,65 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 				WHERE u.tenant_id = $2
 				AND u.status = $5
 				%s
+				AND (sub.status IS NULL OR sub.status <> $9)
+				AND (NOT %s OR sub.status = $7)
 				AND (
-					(set.value IS NULL AND u.role = ANY($3))
-					OR CAST(set.value AS integer) & $4 > 0
+					(
+						((set.value IS NULL AND u.role = ANY($3)) OR CAST(set.value AS integer) & $4 > 0)
+						AND NOT EXISTS (SELECT 1 FROM user_tag_preferences f WHERE f.user_id = u.id AND f.tenant_id = u.tenant_id AND f.preference = %d)
+					)
+					OR (
+						%s
+						AND ((set.value IS NULL AND $8) OR CAST(set.value AS integer) & $4 > 0)
+					)
 				)
-				ORDER by u.id`, supressionCondition),
+				ORDER by u.id`,
+				supressionCondition,
+				tagPreferenceCondition("$6", enum.TagPreferenceMute),
+				enum.TagPreferenceFollow,
+				tagPreferenceCondition("$6", enum.TagPreferenceFollow),
+			),
 				q.Event.UserSettingsKeyName,
 				tenant.ID,
 				pq.Array(q.Event.DefaultEnabledUserRoles),
 				q.Channel,
 				enum.UserActive,
+				postID,
+				enum.SubscriberActive,
+				enabledByDefault,
+				enum.SubscriberMuted,
 			)
 		} else {
-			// If the event requires a subscription, notify only those who subscribed
+			// If the event requires a subscription, notify only those who subscribed or follow one of the post tags
 			err = trx.Select(&users, fmt.Sprintf(`
 				SELECT DISTINCT u.id, u.name, u.email, u.tenant_id, u.role, u.status
 				FROM users u
 				LEFT JOIN post_subscribers sub
 				ON sub.user_id = u.id
-				AND sub.post_id = (SELECT id FROM posts p WHERE p.tenant_id = $4 and p.number = $1 LIMIT 1)
+				AND sub.post_id = $1
 				AND sub.tenant_id = u.tenant_id
 				LEFT JOIN user_settings set
 				ON set.user_id = u.id
 				AND set.key = $3
 				AND set.tenant_id = u.tenant_id
-		WHERE u.tenant_id = $4
-		AND u.status = $8
-		%s
-		AND ( sub.status = $2 OR (sub.status IS NULL AND NOT u.role = ANY($7)) )
+				WHERE u.tenant_id = $4
+				AND u.status = $8
+				%s
 				AND (
-					(set.value IS NULL AND u.role = ANY($5))
+					sub.status = $2
+					OR (sub.status IS NULL AND NOT %s AND (NOT u.role = ANY($7) OR %s))
+				)
+				AND (
+					(set.value IS NULL AND (u.role = ANY($5) OR (%s AND $9)))
 					OR CAST(set.value AS integer) & $6 > 0
 				)
-				ORDER by u.id`, supressionCondition),
-				q.Number,
+				ORDER by u.id`,
+				supressionCondition,
+				tagPreferenceCondition("$1", enum.TagPreferenceMute),
+				tagPreferenceCondition("$1", enum.TagPreferenceFollow),
+				tagPreferenceCondition("$1", enum.TagPreferenceFollow),
+			),
+				postID,
 				enum.SubscriberActive,
 				q.Event.UserSettingsKeyName,
 				tenant.ID,
@@ -298,6 +444,7 @@ func getActiveSubscribers(ctx context.Context, q *query.GetActiveSubscribers) er
 				q.Channel,
 				pq.Array(q.Event.RequiresSubscriptionUserRoles),
 				enum.UserActive,
+				enabledByDefault,
 			)
 		}
 
@@ -359,12 +506,24 @@ func getUsersToNotify(ctx context.Context, q *query.GetUsersToNotify) error {
 				(set.value IS NULL AND u.role = ANY($3))
 				OR CAST(set.value AS integer) & $4 > 0
 			)
+			AND ($6::int[] IS NULL OR u.id = ANY($6))
+			AND ($7 = 0 OR (
+				NOT EXISTS (SELECT 1 FROM post_subscribers ps WHERE ps.user_id = u.id AND ps.post_id = $7 AND ps.status = $8)
+				AND (
+					NOT `+tagPreferenceCondition("$7", enum.TagPreferenceMute)+`
+					OR EXISTS (SELECT 1 FROM post_subscribers ps WHERE ps.user_id = u.id AND ps.post_id = $7 AND ps.status = $9)
+				)
+			))
 			ORDER by u.id`,
 			q.Event.UserSettingsKeyName,
 			tenant.ID,
 			pq.Array(q.Event.DefaultEnabledUserRoles),
 			q.Channel,
 			enum.UserActive,
+			pq.Array(q.UserIDs),
+			q.PostID,
+			enum.SubscriberMuted,
+			enum.SubscriberActive,
 		)
 		if err != nil {
 			return errors.Wrap(err, "failed to get users to notify")
@@ -89,6 +89,42 @@ func TestNotificationStorage_GetActiveNotifications(t *testing.T) {
 	Expect(activeNotifications.Result[0].Read).IsTrue()
 }
 
+func TestNotificationStorage_GroupsUnreadNotificationsOfSameEvent(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "Title", Description: "Description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	fromJon := &cmd.AddNewNotification{User: aryaStark, Title: "Jon commented", Link: "/posts/1#comment-1", PostID: newPost.Result.ID, Event: "new_comment"}
+	fromSansa := &cmd.AddNewNotification{User: aryaStark, Title: "Sansa commented", Link: "/posts/1#comment-2", PostID: newPost.Result.ID, Event: "new_comment"}
+	bus.MustDispatch(jonSnowCtx, fromJon)
+	bus.MustDispatch(sansaStarkCtx, fromSansa)
+	Expect(fromSansa.Result.ID).Equals(fromJon.Result.ID)
+	Expect(fromSansa.Result.ActorCount).Equals(2)
+
+	// the same actor again only moves to the front of the group
+	bus.MustDispatch(jonSnowCtx, &cmd.AddNewNotification{User: aryaStark, Title: "Jon commented", Link: "/posts/1#comment-3", PostID: newPost.Result.ID, Event: "new_comment"})
+
+	activeNotifications := &query.GetActiveNotifications{}
+	err = bus.Dispatch(aryaStarkCtx, activeNotifications)
+	Expect(err).IsNil()
+	Expect(activeNotifications.Result).HasLen(1)
+	Expect(activeNotifications.Result[0].Title).Equals("Jon commented")
+	Expect(activeNotifications.Result[0].Link).Equals("/posts/1#comment-3")
+	Expect(activeNotifications.Result[0].ActorCount).Equals(2)
+	Expect(activeNotifications.Result[0].LatestActors).Equals([]string{"Jon Snow", "Sansa Stark"})
+	Expect(activeNotifications.Result[0].IsGrouped()).IsTrue()
+
+	// once read, a new event starts a new group
+	bus.MustDispatch(aryaStarkCtx, &cmd.MarkAllNotificationsAsRead{})
+	afterRead := &cmd.AddNewNotification{User: aryaStark, Title: "Sansa commented", Link: "/posts/1#comment-4", PostID: newPost.Result.ID, Event: "new_comment"}
+	bus.MustDispatch(sansaStarkCtx, afterRead)
+	Expect(afterRead.Result.ID).NotEquals(fromJon.Result.ID)
+	Expect(afterRead.Result.ActorCount).Equals(1)
+}
+
 func TestNotificationStorage_ReadAll(t *testing.T) {
 	SetupDatabaseTest(t)
 	defer TeardownDatabaseTest()
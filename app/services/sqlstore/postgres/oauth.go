@@ -15,6 +15,7 @@ import (
 type dbOAuthConfig struct {
 	ID                int    `db:"id"`
 	Provider          string `db:"provider"`
+	Type              int    `db:"provider_type"`
 	DisplayName       string `db:"display_name"`
 	LogoBlobKey       string `db:"logo_bkey"`
 	Status            int    `db:"status"`
@@ -25,6 +26,7 @@ type dbOAuthConfig struct {
 	TokenURL          string `db:"token_url"`
 	Scope             string `db:"scope"`
 	ProfileURL        string `db:"profile_url"`
+	IssuerURL         string `db:"issuer_url"`
 	JSONUserIDPath    string `db:"json_user_id_path"`
 	JSONUserNamePath  string `db:"json_user_name_path"`
 	JSONUserEmailPath string `db:"json_user_email_path"`
@@ -34,6 +36,7 @@ func (m *dbOAuthConfig) toModel() *entity.OAuthConfig {
 	return &entity.OAuthConfig{
 		ID:                m.ID,
 		Provider:          m.Provider,
+		Type:              m.Type,
 		DisplayName:       m.DisplayName,
 		Status:            m.Status,
 		IsTrusted:         m.IsTrusted,
@@ -43,6 +46,7 @@ func (m *dbOAuthConfig) toModel() *entity.OAuthConfig {
 		AuthorizeURL:      m.AuthorizeURL,
 		TokenURL:          m.TokenURL,
 		ProfileURL:        m.ProfileURL,
+		IssuerURL:         m.IssuerURL,
 		Scope:             m.Scope,
 		JSONUserIDPath:    m.JSONUserIDPath,
 		JSONUserNamePath:  m.JSONUserNamePath,
@@ -58,9 +62,9 @@ func getCustomOAuthConfigByProvider(ctx context.Context, q *query.GetCustomOAuth
 
 		config := &dbOAuthConfig{}
 		err := trx.Get(config, `
-		SELECT id, provider, display_name, status, is_trusted, logo_bkey,
+		SELECT id, provider, provider_type, display_name, status, is_trusted, logo_bkey,
 					 client_id, client_secret, authorize_url,
-					 profile_url, token_url, scope, json_user_id_path,
+					 profile_url, token_url, issuer_url, scope, json_user_id_path,
 					 json_user_name_path, json_user_email_path
 		FROM oauth_providers
 		WHERE tenant_id = $1 AND provider = $2
@@ -82,9 +86,9 @@ func listCustomOAuthConfig(ctx context.Context, q *query.ListCustomOAuthConfig)
 
 		configs := []*dbOAuthConfig{}
 		err := trx.Select(&configs, `
-		SELECT id, provider, display_name, status, is_trusted, logo_bkey,
+		SELECT id, provider, provider_type, display_name, status, is_trusted, logo_bkey,
 					 client_id, client_secret, authorize_url,
-					 profile_url, token_url, scope, json_user_id_path,
+					 profile_url, token_url, issuer_url, scope, json_user_id_path,
 					 json_user_name_path, json_user_email_path
 		FROM oauth_providers
 		WHERE tenant_id = $1
@@ -114,29 +118,32 @@ func saveCustomOAuthConfig(ctx context.Context, c *cmd.SaveCustomOAuthConfig) er
 				tenant_id, provider, display_name, status, is_trusted,
 				client_id, client_secret, authorize_url,
 				profile_url, token_url, scope, json_user_id_path,
-				json_user_name_path, json_user_email_path, logo_bkey
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+				json_user_name_path, json_user_email_path, logo_bkey,
+				provider_type, issuer_url
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 			RETURNING id`
 
 			err = trx.Get(&c.ID, query, tenant.ID, c.Provider,
 				c.DisplayName, c.Status, c.IsTrusted, c.ClientID, c.ClientSecret,
 				c.AuthorizeURL, c.ProfileURL, c.TokenURL,
 				c.Scope, c.JSONUserIDPath, c.JSONUserNamePath,
-				c.JSONUserEmailPath, c.Logo.BlobKey)
+				c.JSONUserEmailPath, c.Logo.BlobKey,
+				c.Type, c.IssuerURL)
 		} else {
 			query := `
 				UPDATE oauth_providers 
 				SET display_name = $3, status = $4, client_id = $5, client_secret = $6, 
 						authorize_url = $7, profile_url = $8, token_url = $9, scope = $10, 
 						json_user_id_path = $11, json_user_name_path = $12, json_user_email_path = $13,
-						logo_bkey = $14, is_trusted = $15
+						logo_bkey = $14, is_trusted = $15, provider_type = $16, issuer_url = $17
 			WHERE tenant_id = $1 AND id = $2`
 
 			_, err = trx.Execute(query, tenant.ID, c.ID,
 				c.DisplayName, c.Status, c.ClientID, c.ClientSecret,
 				c.AuthorizeURL, c.ProfileURL, c.TokenURL,
 				c.Scope, c.JSONUserIDPath, c.JSONUserNamePath,
-				c.JSONUserEmailPath, c.Logo.BlobKey, c.IsTrusted)
+				c.JSONUserEmailPath, c.Logo.BlobKey, c.IsTrusted,
+				c.Type, c.IssuerURL)
 		}
 
 		if err != nil {
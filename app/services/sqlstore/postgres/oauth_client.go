@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbOAuthClient struct {
+	ID           int            `db:"id"`
+	ClientID     string         `db:"client_id"`
+	SecretHash   sql.NullString `db:"client_secret_hash"`
+	Name         string         `db:"name"`
+	RedirectURIs []string       `db:"redirect_uris"`
+	Scopes       []string       `db:"scopes"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func (c *dbOAuthClient) toModel() *entity.OAuthClient {
+	return &entity.OAuthClient{
+		ID:             c.ID,
+		ClientID:       c.ClientID,
+		Name:           c.Name,
+		RedirectURIs:   c.RedirectURIs,
+		Scopes:         parseAPITokenScopes(c.Scopes),
+		IsConfidential: c.SecretHash.Valid,
+		SecretHash:     c.SecretHash.String,
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+type dbOAuthGrant struct {
+	ID            int            `db:"id"`
+	ClientID      int            `db:"client_id"`
+	UserID        int            `db:"user_id"`
+	RedirectURI   sql.NullString `db:"redirect_uri"`
+	Scopes        []string       `db:"scopes"`
+	CodeChallenge sql.NullString `db:"code_challenge"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+}
+
+func (g *dbOAuthGrant) toModel() *entity.OAuthGrant {
+	return &entity.OAuthGrant{
+		ID:            g.ID,
+		ClientID:      g.ClientID,
+		UserID:        g.UserID,
+		RedirectURI:   g.RedirectURI.String,
+		Scopes:        parseAPITokenScopes(g.Scopes),
+		CodeChallenge: g.CodeChallenge.String,
+		ExpiresAt:     g.ExpiresAt,
+	}
+}
+
+func createOAuthClient(ctx context.Context, c *cmd.CreateOAuthClient) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		secretHash := sql.NullString{String: c.SecretHash, Valid: c.SecretHash != ""}
+
+		var id int
+		err := trx.Scalar(&id, `
+			INSERT INTO oauth_clients (tenant_id, client_id, client_secret_hash, name, redirect_uris, scopes, created_by_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id
+		`, tenant.ID, c.ClientID, secretHash, c.Name, pq.Array(c.RedirectURIs), pq.Array(apiTokenScopeNames(c.Scopes)), user.ID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to create OAuth client")
+		}
+
+		c.Result = &entity.OAuthClient{
+			ID:             id,
+			ClientID:       c.ClientID,
+			Name:           c.Name,
+			RedirectURIs:   c.RedirectURIs,
+			Scopes:         c.Scopes,
+			IsConfidential: secretHash.Valid,
+			SecretHash:     c.SecretHash,
+			CreatedAt:      time.Now(),
+		}
+		return nil
+	})
+}
+
+func deleteOAuthClient(ctx context.Context, c *cmd.DeleteOAuthClient) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute("DELETE FROM oauth_clients WHERE tenant_id = $1 AND id = $2", tenant.ID, c.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete OAuth client '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func listOAuthClients(ctx context.Context, q *query.ListOAuthClients) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var clients []*dbOAuthClient
+		err := trx.Select(&clients, `
+			SELECT id, client_id, client_secret_hash, name, redirect_uris, scopes, created_at
+			FROM oauth_clients
+			WHERE tenant_id = $1
+			ORDER BY name
+		`, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list OAuth clients")
+		}
+
+		q.Result = make([]*entity.OAuthClient, len(clients))
+		for i, client := range clients {
+			q.Result[i] = client.toModel()
+		}
+		return nil
+	})
+}
+
+func getOAuthClientByClientID(ctx context.Context, q *query.GetOAuthClientByClientID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		client := dbOAuthClient{}
+		err := trx.Get(&client, `
+			SELECT id, client_id, client_secret_hash, name, redirect_uris, scopes, created_at
+			FROM oauth_clients
+			WHERE tenant_id = $1 AND client_id = $2
+		`, tenant.ID, q.ClientID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get OAuth client '%s'", q.ClientID)
+		}
+		q.Result = client.toModel()
+		return nil
+	})
+}
+
+func createOAuthAuthorizationCode(ctx context.Context, c *cmd.CreateOAuthAuthorizationCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO oauth_authorization_codes (tenant_id, client_id, user_id, code_hash, redirect_uri, scopes, code_challenge, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, tenant.ID, c.ClientID, c.UserID, c.CodeHash, c.RedirectURI, pq.Array(apiTokenScopeNames(c.Scopes)), c.CodeChallenge, time.Now(), c.ExpiresAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to create OAuth authorization code")
+		}
+		return nil
+	})
+}
+
+func useOAuthAuthorizationCode(ctx context.Context, c *cmd.UseOAuthAuthorizationCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		grant := dbOAuthGrant{}
+		err := trx.Get(&grant, `
+			UPDATE oauth_authorization_codes SET used_at = $3
+			WHERE tenant_id = $1 AND code_hash = $2 AND used_at IS NULL AND expires_at > $3
+			RETURNING id, client_id, user_id, redirect_uri, scopes, code_challenge, expires_at
+		`, tenant.ID, c.CodeHash, time.Now())
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return app.ErrNotFound
+			}
+			return errors.Wrap(err, "failed to use OAuth authorization code")
+		}
+		c.Result = grant.toModel()
+		return nil
+	})
+}
+
+func createOAuthRefreshToken(ctx context.Context, c *cmd.CreateOAuthRefreshToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO oauth_refresh_tokens (tenant_id, client_id, user_id, token_hash, scopes, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, tenant.ID, c.ClientID, c.UserID, c.TokenHash, pq.Array(apiTokenScopeNames(c.Scopes)), time.Now(), c.ExpiresAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to create OAuth refresh token")
+		}
+		return nil
+	})
+}
+
+func useOAuthRefreshToken(ctx context.Context, c *cmd.UseOAuthRefreshToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		grant := dbOAuthGrant{}
+		err := trx.Get(&grant, `
+			UPDATE oauth_refresh_tokens SET revoked_at = $3
+			WHERE tenant_id = $1 AND token_hash = $2 AND revoked_at IS NULL AND expires_at > $3
+			RETURNING id, client_id, user_id, scopes, expires_at
+		`, tenant.ID, c.TokenHash, time.Now())
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return app.ErrNotFound
+			}
+			return errors.Wrap(err, "failed to use OAuth refresh token")
+		}
+		c.Result = grant.toModel()
+		return nil
+	})
+}
+
+func purgeExpiredOAuthGrants(ctx context.Context, c *cmd.PurgeExpiredOAuthGrants) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		codes, err := trx.Execute("DELETE FROM oauth_authorization_codes WHERE expires_at < NOW()")
+		if err != nil {
+			return errors.Wrap(err, "failed to purge expired OAuth authorization codes")
+		}
+
+		tokens, err := trx.Execute("DELETE FROM oauth_refresh_tokens WHERE expires_at < NOW() OR revoked_at < NOW() - INTERVAL '1 day'")
+		if err != nil {
+			return errors.Wrap(err, "failed to purge expired OAuth refresh tokens")
+		}
+
+		c.NumOfDeletedGrants = int(codes + tokens)
+		return nil
+	})
+}
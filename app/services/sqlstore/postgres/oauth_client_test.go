@@ -0,0 +1,174 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestOAuthClientStorage_CreateGetAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createClient := &cmd.CreateOAuthClient{
+		ClientID:     "client-1",
+		SecretHash:   "secret-hash",
+		Name:         "Tarkov Bot",
+		RedirectURIs: []string{"https://bot.example.com/callback"},
+		Scopes:       []enum.APITokenScope{enum.APITokenScopeReadPosts},
+	}
+	err := bus.Dispatch(jonSnowCtx, createClient)
+	Expect(err).IsNil()
+
+	getClient := &query.GetOAuthClientByClientID{ClientID: "client-1"}
+	err = bus.Dispatch(jonSnowCtx, getClient)
+	Expect(err).IsNil()
+	Expect(getClient.Result.ID).Equals(createClient.Result.ID)
+	Expect(getClient.Result.Name).Equals("Tarkov Bot")
+	Expect(getClient.Result.RedirectURIs).Equals([]string{"https://bot.example.com/callback"})
+	Expect(getClient.Result.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts})
+	Expect(getClient.Result.IsConfidential).IsTrue()
+	Expect(getClient.Result.SecretHash).Equals("secret-hash")
+
+	// clients are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &query.GetOAuthClientByClientID{ClientID: "client-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	listClients := &query.ListOAuthClients{}
+	err = bus.Dispatch(tonyStarkCtx, listClients)
+	Expect(err).IsNil()
+	Expect(listClients.Result).HasLen(0)
+
+	err = bus.Dispatch(tonyStarkCtx, &cmd.DeleteOAuthClient{ID: createClient.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.DeleteOAuthClient{ID: createClient.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, getClient)
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestOAuthClientStorage_PublicClient(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(jonSnowCtx, &cmd.CreateOAuthClient{ClientID: "client-1", Name: "Tarkov App", RedirectURIs: []string{"http://localhost/callback"}})
+	Expect(err).IsNil()
+
+	getClient := &query.GetOAuthClientByClientID{ClientID: "client-1"}
+	err = bus.Dispatch(jonSnowCtx, getClient)
+	Expect(err).IsNil()
+	Expect(getClient.Result.IsConfidential).IsFalse()
+	Expect(getClient.Result.SecretHash).Equals("")
+}
+
+func TestOAuthClientStorage_AuthorizationCodeCanOnlyBeUsedOnce(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createClient := &cmd.CreateOAuthClient{ClientID: "client-1", Name: "Tarkov Bot", RedirectURIs: []string{"https://bot.example.com/callback"}}
+	err := bus.Dispatch(jonSnowCtx, createClient)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateOAuthAuthorizationCode{
+		ClientID:      createClient.Result.ID,
+		UserID:        aryaStark.ID,
+		CodeHash:      "code-hash",
+		RedirectURI:   "https://bot.example.com/callback",
+		Scopes:        []enum.APITokenScope{enum.APITokenScopeWriteComments},
+		CodeChallenge: "challenge",
+		ExpiresAt:     time.Now().Add(10 * time.Minute),
+	})
+	Expect(err).IsNil()
+
+	// codes are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &cmd.UseOAuthAuthorizationCode{CodeHash: "code-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	useCode := &cmd.UseOAuthAuthorizationCode{CodeHash: "code-hash"}
+	err = bus.Dispatch(aryaStarkCtx, useCode)
+	Expect(err).IsNil()
+	Expect(useCode.Result.ClientID).Equals(createClient.Result.ID)
+	Expect(useCode.Result.UserID).Equals(aryaStark.ID)
+	Expect(useCode.Result.RedirectURI).Equals("https://bot.example.com/callback")
+	Expect(useCode.Result.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeWriteComments})
+	Expect(useCode.Result.CodeChallenge).Equals("challenge")
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseOAuthAuthorizationCode{CodeHash: "code-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestOAuthClientStorage_ExpiredAuthorizationCode(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createClient := &cmd.CreateOAuthClient{ClientID: "client-1", Name: "Tarkov Bot", RedirectURIs: []string{"https://bot.example.com/callback"}}
+	err := bus.Dispatch(jonSnowCtx, createClient)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateOAuthAuthorizationCode{
+		ClientID:  createClient.Result.ID,
+		UserID:    aryaStark.ID,
+		CodeHash:  "code-hash",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseOAuthAuthorizationCode{CodeHash: "code-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	purge := &cmd.PurgeExpiredOAuthGrants{}
+	err = bus.Dispatch(demoTenantCtx, purge)
+	Expect(err).IsNil()
+	Expect(purge.NumOfDeletedGrants).Equals(1)
+}
+
+func TestOAuthClientStorage_RefreshTokenIsRevokedWhenUsed(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createClient := &cmd.CreateOAuthClient{ClientID: "client-1", Name: "Tarkov Bot", RedirectURIs: []string{"https://bot.example.com/callback"}}
+	err := bus.Dispatch(jonSnowCtx, createClient)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateOAuthRefreshToken{
+		ClientID:  createClient.Result.ID,
+		UserID:    aryaStark.ID,
+		TokenHash: "refresh-hash",
+		Scopes:    []enum.APITokenScope{enum.APITokenScopeReadPosts},
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateOAuthRefreshToken{
+		ClientID:  createClient.Result.ID,
+		UserID:    aryaStark.ID,
+		TokenHash: "expired-hash",
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+	Expect(err).IsNil()
+
+	// refresh tokens are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &cmd.UseOAuthRefreshToken{TokenHash: "refresh-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	useToken := &cmd.UseOAuthRefreshToken{TokenHash: "refresh-hash"}
+	err = bus.Dispatch(aryaStarkCtx, useToken)
+	Expect(err).IsNil()
+	Expect(useToken.Result.ClientID).Equals(createClient.Result.ID)
+	Expect(useToken.Result.UserID).Equals(aryaStark.ID)
+	Expect(useToken.Result.Scopes).Equals([]enum.APITokenScope{enum.APITokenScopeReadPosts})
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseOAuthRefreshToken{TokenHash: "refresh-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseOAuthRefreshToken{TokenHash: "expired-hash"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
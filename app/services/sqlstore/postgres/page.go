@@ -118,7 +118,7 @@ func getPageBySlug(ctx context.Context, q *query.GetPageBySlug) error {
 				ub.id AS updated_by_id, ub.name AS updated_by_name, ub.email AS updated_by_email,
 				ub.role AS updated_by_role, ub.status AS updated_by_status,
 				ub.avatar_type AS updated_by_avatar_type, ub.avatar_bkey AS updated_by_avatar_bkey,
-				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL) as comments_count
+				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL AND shadow_hidden = FALSE) as comments_count
 			FROM pages p
 			INNER JOIN users cb ON cb.id = p.created_by_id
 			INNER JOIN users ub ON ub.id = p.updated_by_id
@@ -150,7 +150,7 @@ func getPageByID(ctx context.Context, q *query.GetPageByID) error {
 				ub.id AS updated_by_id, ub.name AS updated_by_name, ub.email AS updated_by_email,
 				ub.role AS updated_by_role, ub.status AS updated_by_status,
 				ub.avatar_type AS updated_by_avatar_type, ub.avatar_bkey AS updated_by_avatar_bkey,
-				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL) as comments_count
+				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL AND shadow_hidden = FALSE) as comments_count
 			FROM pages p
 			INNER JOIN users cb ON cb.id = p.created_by_id
 			INNER JOIN users ub ON ub.id = p.updated_by_id
@@ -565,7 +565,7 @@ func listPages(ctx context.Context, q *query.ListPages) error {
 			SELECT p.id, p.title, p.slug, p.excerpt, p.banner_image_bkey,
 				p.status, p.visibility, p.published_at, p.created_at, p.updated_at,
 				cb.id AS created_by_id, cb.name AS created_by_name,
-				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL) as comments_count
+				(SELECT COUNT(*) FROM comments WHERE page_id = p.id AND deleted_at IS NULL AND shadow_hidden = FALSE) as comments_count
 			FROM pages p
 			INNER JOIN users cb ON cb.id = p.created_by_id
 			WHERE %s
@@ -867,15 +867,16 @@ func getCommentsByPage(ctx context.Context, q *query.GetCommentsByPage) error {
 				e.role AS edited_by_role, e.status AS edited_by_status,
 				e.avatar_type AS edited_by_avatar_type, e.avatar_bkey AS edited_by_avatar_bkey,
 				ar.reaction_counts,
-				c.moderation_pending, c.moderation_data
+				c.moderation_pending, c.moderation_data, c.shadow_hidden
 			FROM comments c
 			INNER JOIN users u ON u.id = c.user_id
 			LEFT JOIN users e ON e.id = c.edited_by_id
 			LEFT JOIN agg_reactions ar ON ar.comment_id = c.id
 			WHERE c.page_id = $1 AND c.tenant_id = $2
 			AND c.deleted_at IS NULL
+			AND ((c.moderation_pending = FALSE AND c.shadow_hidden = FALSE) OR c.user_id = $3 OR $4 = TRUE)
 			ORDER BY c.created_at ASC
-		`, q.Page.ID, tenant.ID, userID)
+		`, q.Page.ID, tenant.ID, userID, user != nil && (user.IsCollaborator() || user.IsModerator() || user.IsAdministrator()))
 
 		if err != nil {
 			return errors.Wrap(err, "failed to get comments by page")
@@ -893,10 +894,10 @@ func addPageComment(ctx context.Context, c *cmd.AddPageComment) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		var id int
 		if err := trx.Get(&id, `
-			INSERT INTO comments (tenant_id, page_id, content, user_id, created_at)
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO comments (tenant_id, page_id, content, user_id, created_at, shadow_hidden, moderation_pending)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 			RETURNING id
-		`, tenant.ID, c.Page.ID, c.Content, user.ID, time.Now()); err != nil {
+		`, tenant.ID, c.Page.ID, c.Content, user.ID, time.Now(), c.ShadowHidden, c.ModerationPending); err != nil {
 			return errors.Wrap(err, "failed to add page comment")
 		}
 
@@ -54,6 +54,7 @@ type dbPost struct {
 	ArchivedFromStatus sql.NullInt64  `db:"archived_from_status"`
 	ModerationPending  bool           `db:"moderation_pending"`
 	ModerationData     sql.NullString `db:"moderation_data"`
+	ShadowHidden       bool           `db:"shadow_hidden"`
 }
 
 func (i *dbPost) toModel(ctx context.Context) *entity.Post {
@@ -129,6 +130,7 @@ func (i *dbPost) toModel(ctx context.Context) *entity.Post {
 		if i.ModerationData.Valid {
 			post.ModerationData = i.ModerationData.String
 		}
+		post.ShadowHidden = i.ShadowHidden
 	}
 
 	return post
@@ -399,11 +401,12 @@ func buildHydration(tenantID int, user *entity.User, cteName string, limit strin
 		voteTypeField = fmt.Sprintf("(SELECT vote_type FROM post_votes WHERE post_id = p.id AND user_id = %d LIMIT 1)", user.ID)
 	}
 
+	// pending and shadow-hidden posts are only visible to their author and staff
 	moderationFilter := ""
 	if user == nil {
-		moderationFilter = "AND p.moderation_pending = FALSE"
+		moderationFilter = "AND p.moderation_pending = FALSE AND p.shadow_hidden = FALSE"
 	} else if !user.IsCollaborator() && !user.IsModerator() && !user.IsAdministrator() {
-		moderationFilter = fmt.Sprintf("AND (p.moderation_pending = FALSE OR p.user_id = %d)", user.ID)
+		moderationFilter = fmt.Sprintf("AND ((p.moderation_pending = FALSE AND p.shadow_hidden = FALSE) OR p.user_id = %d)", user.ID)
 	}
 
 	orderClause := ""
@@ -461,7 +464,8 @@ func buildHydration(tenantID int, user *entity.User, cteName string, limit strin
 			%s AS tag_dates,
 			%s AS vote_type,
 			p.moderation_pending,
-			p.moderation_data
+			p.moderation_data,
+			p.shadow_hidden
 		FROM %s tp
 		JOIN posts p ON p.id = tp.id %s
 		INNER JOIN users u ON u.id = p.user_id AND u.tenant_id = %d
@@ -674,7 +678,7 @@ func countPostPerStatus(ctx context.Context, q *query.CountPostPerStatus) error
 
 		q.Result = make(map[enum.PostStatus]int)
 		stats := []*dbStatusCount{}
-		err := trx.Select(&stats, "SELECT status, COUNT(*) AS count FROM posts WHERE tenant_id = $1 GROUP BY status", tenant.ID)
+		err := trx.Select(&stats, "SELECT status, COUNT(*) AS count FROM posts WHERE tenant_id = $1 AND shadow_hidden = FALSE GROUP BY status", tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to count posts per status")
 		}
@@ -690,9 +694,9 @@ func addNewPost(ctx context.Context, c *cmd.AddNewPost) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		var id int
 		err := trx.Get(&id,
-			`INSERT INTO posts (title, slug, number, description, tenant_id, user_id, created_at, status) 
-			 VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM posts p WHERE p.tenant_id = $4), $3, $4, $5, $6, 0) 
-			 RETURNING id`, c.Title, slug.Make(c.Title), c.Description, tenant.ID, user.ID, time.Now())
+			`INSERT INTO posts (title, slug, number, description, tenant_id, user_id, created_at, status, shadow_hidden, moderation_pending) 
+			 VALUES ($1, $2, (SELECT COALESCE(MAX(number), 0) + 1 FROM posts p WHERE p.tenant_id = $4), $3, $4, $5, $6, 0, $7, $8) 
+			 RETURNING id`, c.Title, slug.Make(c.Title), c.Description, tenant.ID, user.ID, time.Now(), c.ShadowHidden, c.ModerationPending)
 		if err != nil {
 			return errors.Wrap(err, "failed add new post")
 		}
@@ -740,6 +744,18 @@ func getPostByID(ctx context.Context, q *query.GetPostByID) error {
 	})
 }
 
+func getPostByCommentID(ctx context.Context, q *query.GetPostByCommentID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		sqlQuery := buildSinglePostQuery(tenant, user, "p.id = (SELECT post_id FROM comments WHERE tenant_id = $1 AND id = $2)")
+		post, err := querySinglePost(ctx, trx, sqlQuery, tenant.ID, q.CommentID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get post of comment with id '%d'", q.CommentID)
+		}
+		q.Result = post
+		return nil
+	})
+}
+
 func getPostBySlug(ctx context.Context, q *query.GetPostBySlug) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		sqlQuery := buildSinglePostQuery(tenant, user, "p.slug = $2")
@@ -1020,7 +1036,7 @@ func refreshPostStats(ctx context.Context, c *cmd.RefreshPostStats) error {
 					SELECT COUNT(*) 
 					FROM comments c 
 					WHERE c.post_id = p.id AND c.tenant_id = p.tenant_id 
-					AND c.deleted_at IS NULL 
+					AND c.deleted_at IS NULL AND c.shadow_hidden = FALSE
 					AND c.created_at > CURRENT_DATE - INTERVAL '30 days'
 				), 0)
 			WHERE p.status NOT IN ($1, $2)`
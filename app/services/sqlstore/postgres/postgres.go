@@ -48,8 +48,56 @@ func (s Service) Init() {
 	bus.AddHandler(addNewNotification)
 	bus.AddHandler(addSubscriber)
 	bus.AddHandler(removeSubscriber)
+	bus.AddHandler(muteSubscriber)
+	bus.AddHandler(setTagPreference)
+	bus.AddHandler(getTagPreferences)
 	bus.AddHandler(supressEmail)
 	bus.AddHandler(getActiveSubscribers)
+	bus.AddHandler(queueDigestItems)
+	bus.AddHandler(getPendingDigests)
+	bus.AddHandler(clearDigestItems)
+	bus.AddHandler(getQuietHours)
+	bus.AddHandler(deferNotification)
+	bus.AddHandler(getDueDeferredNotifications)
+	bus.AddHandler(deleteDeferredNotifications)
+
+	bus.AddHandler(getEmailTemplate)
+	bus.AddHandler(listEmailTemplates)
+	bus.AddHandler(saveEmailTemplate)
+	bus.AddHandler(deleteEmailTemplate)
+	bus.AddHandler(queueMail)
+	bus.AddHandler(getDueQueuedEmails)
+	bus.AddHandler(countRecentEmailAttempts)
+	bus.AddHandler(updateQueuedEmail)
+	bus.AddHandler(retryQueuedEmail)
+	bus.AddHandler(purgeQueuedEmails)
+	bus.AddHandler(listQueuedEmails)
+	bus.AddHandler(countQueuedEmailsByStatus)
+
+	bus.AddHandler(createUserSession)
+	bus.AddHandler(getUserSession)
+	bus.AddHandler(listUserSessions)
+	bus.AddHandler(touchUserSession)
+	bus.AddHandler(revokeUserSession)
+	bus.AddHandler(revokeUserSessions)
+	bus.AddHandler(getUserSessionsRevokedAt)
+	bus.AddHandler(purgeExpiredUserSessions)
+
+	bus.AddHandler(createAPIToken)
+	bus.AddHandler(getAPITokenByHash)
+	bus.AddHandler(listAPITokens)
+	bus.AddHandler(deleteAPIToken)
+	bus.AddHandler(touchAPIToken)
+
+	bus.AddHandler(createOAuthClient)
+	bus.AddHandler(deleteOAuthClient)
+	bus.AddHandler(listOAuthClients)
+	bus.AddHandler(getOAuthClientByClientID)
+	bus.AddHandler(createOAuthAuthorizationCode)
+	bus.AddHandler(useOAuthAuthorizationCode)
+	bus.AddHandler(createOAuthRefreshToken)
+	bus.AddHandler(useOAuthRefreshToken)
+	bus.AddHandler(purgeExpiredOAuthGrants)
 
 	bus.AddHandler(getTagBySlug)
 	bus.AddHandler(getAssignedTags)
@@ -67,6 +115,7 @@ func (s Service) Init() {
 	bus.AddHandler(addNewPost)
 	bus.AddHandler(updatePost)
 	bus.AddHandler(getPostByID)
+	bus.AddHandler(getPostByCommentID)
 	bus.AddHandler(getPostBySlug)
 	bus.AddHandler(getPostByNumber)
 	bus.AddHandler(getUserPostCount)
@@ -124,6 +173,8 @@ func (s Service) Init() {
 	bus.AddHandler(getCurrentUserSettings)
 	bus.AddHandler(registerUser)
 	bus.AddHandler(registerUserProvider)
+	bus.AddHandler(unregisterUserProvider)
+	bus.AddHandler(moveUserProvider)
 	bus.AddHandler(updateCurrentUser)
 	bus.AddHandler(updateUserAvatar)
 	bus.AddHandler(getUserByAPIKey)
@@ -148,6 +199,7 @@ func (s Service) Init() {
 	bus.AddHandler(updateTenantSettings)
 	bus.AddHandler(updateTenantPrivacySettings)
 	bus.AddHandler(updateTenantEmailAuthAllowedSettings)
+	bus.AddHandler(updateTenantTwoFactorPolicy)
 	bus.AddHandler(updateTenantAdvancedSettings)
 	bus.AddHandler(updateGeneralSettings)
 	bus.AddHandler(UpdateMessageBanner)
@@ -184,6 +236,61 @@ func (s Service) Init() {
 	bus.AddHandler(expireWarning)
 	bus.AddHandler(expireMute)
 	bus.AddHandler(getUsersToNotify)
+	bus.AddHandler(getUserSanctionHistory)
+	bus.AddHandler(proposeUserBlock)
+	bus.AddHandler(dismissUserBlockProposal)
+	bus.AddHandler(restrictUser)
+	bus.AddHandler(liftRestriction)
+
+	bus.AddHandler(createAppeal)
+	bus.AddHandler(decideAppeal)
+	bus.AddHandler(getAppealByID)
+	bus.AddHandler(listAppeals)
+	bus.AddHandler(getUserAppeals)
+	bus.AddHandler(isSanctionAppealable)
+
+	bus.AddHandler(createUserMergeRequest)
+	bus.AddHandler(decideUserMergeRequest)
+	bus.AddHandler(getUserMergeRequestByID)
+	bus.AddHandler(listUserMergeRequests)
+
+	bus.AddHandler(getUserTwoFactor)
+	bus.AddHandler(countUnusedRecoveryCodes)
+	bus.AddHandler(saveUserTwoFactorSecret)
+	bus.AddHandler(enableUserTwoFactor)
+	bus.AddHandler(disableUserTwoFactor)
+	bus.AddHandler(replaceUserRecoveryCodes)
+	bus.AddHandler(useTwoFactorStep)
+	bus.AddHandler(useRecoveryCode)
+	bus.AddHandler(recordTwoFactorFailure)
+	bus.AddHandler(listUserPasskeys)
+	bus.AddHandler(getUserPasskeyByCredentialID)
+	bus.AddHandler(addUserPasskey)
+	bus.AddHandler(updateUserPasskeyUsage)
+	bus.AddHandler(deleteUserPasskey)
+	bus.AddHandler(listCustomRoles)
+	bus.AddHandler(getCustomRoleByID)
+	bus.AddHandler(getUserCustomRole)
+	bus.AddHandler(getUserPermissions)
+	bus.AddHandler(listCustomRoleMembers)
+	bus.AddHandler(createCustomRole)
+	bus.AddHandler(updateCustomRole)
+	bus.AddHandler(deleteCustomRole)
+	bus.AddHandler(setUserCustomRole)
+	bus.AddHandler(getSCIMToken)
+	bus.AddHandler(getSCIMTokenByHash)
+	bus.AddHandler(setSCIMToken)
+	bus.AddHandler(deleteSCIMToken)
+	bus.AddHandler(touchSCIMToken)
+	bus.AddHandler(setSCIMExternalID)
+	bus.AddHandler(searchSCIMUsers)
+
+	bus.AddHandler(createUserDataExport)
+	bus.AddHandler(completeUserDataExport)
+	bus.AddHandler(deleteUserDataExports)
+	bus.AddHandler(getLatestUserDataExport)
+	bus.AddHandler(getUserDataExportByID)
+	bus.AddHandler(getExpiredUserDataExports)
 
 	bus.AddHandler(updateUser)
 
@@ -198,17 +305,24 @@ func (s Service) Init() {
 	bus.AddHandler(getReportReasons)
 	bus.AddHandler(listAllReportReasons)
 	bus.AddHandler(countUserReportsToday)
+	bus.AddHandler(getUserReportAccuracy)
 	bus.AddHandler(hasUserReportedTarget)
 	bus.AddHandler(getUserReportedItemsOnPost)
 	bus.AddHandler(createReportReason)
 	bus.AddHandler(updateReportReason)
 	bus.AddHandler(deleteReportReason)
 	bus.AddHandler(reorderReportReasons)
+	bus.AddHandler(getReportCaseByID)
+	bus.AddHandler(listReportCases)
+	bus.AddHandler(resolveReportCase)
+	bus.AddHandler(markReportCaseAutoHidden)
+	bus.AddHandler(countTrustedCaseReporters)
 
 	bus.AddHandler(savePushSubscription)
 	bus.AddHandler(deletePushSubscription)
 	bus.AddHandler(deleteAllPushSubscriptions)
 	bus.AddHandler(deletePushSubscriptionByEndpoint)
+	bus.AddHandler(recordPushDelivery)
 	bus.AddHandler(getPushSubscriptionsByUser)
 	bus.AddHandler(getPushSubscriptionsByUsers)
 	bus.AddHandler(getAllPushSubscriptions)
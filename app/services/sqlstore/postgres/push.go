@@ -85,6 +85,30 @@ func deletePushSubscriptionByEndpoint(ctx context.Context, c *cmd.DeletePushSubs
 	})
 }
 
+func recordPushDelivery(ctx context.Context, c *cmd.RecordPushDelivery) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if tenant == nil {
+			return nil
+		}
+
+		query := `
+			UPDATE push_subscriptions SET last_failure_at = NOW(), failure_count = failure_count + 1
+			WHERE tenant_id = $1 AND id = $2
+		`
+		if c.Success {
+			query = `
+				UPDATE push_subscriptions SET last_success_at = NOW(), failure_count = 0
+				WHERE tenant_id = $1 AND id = $2
+			`
+		}
+
+		if _, err := trx.Execute(query, tenant.ID, c.SubscriptionID); err != nil {
+			return errors.Wrap(err, "failed to record push delivery of subscription %d", c.SubscriptionID)
+		}
+		return nil
+	})
+}
+
 func getPushSubscriptionsByUser(ctx context.Context, q *query.GetPushSubscriptionsByUser) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		q.Result = make([]*entity.PushSubscription, 0)
@@ -94,7 +118,7 @@ func getPushSubscriptionsByUser(ctx context.Context, q *query.GetPushSubscriptio
 		}
 
 		err := trx.Select(&q.Result, `
-			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at
+			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at, last_success_at, last_failure_at, failure_count
 			FROM push_subscriptions
 			WHERE tenant_id = $1 AND user_id = $2
 		`, tenant.ID, q.UserID)
@@ -115,7 +139,7 @@ func getPushSubscriptionsByUsers(ctx context.Context, q *query.GetPushSubscripti
 		}
 
 		err := trx.Select(&q.Result, `
-			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at
+			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at, last_success_at, last_failure_at, failure_count
 			FROM push_subscriptions
 			WHERE tenant_id = $1 AND user_id = ANY($2)
 		`, tenant.ID, pq.Array(q.UserIDs))
@@ -136,7 +160,7 @@ func getAllPushSubscriptions(ctx context.Context, q *query.GetAllPushSubscriptio
 		}
 
 		err := trx.Select(&q.Result, `
-			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at
+			SELECT id, tenant_id, user_id, endpoint, key_p256dh, key_auth, created_at, last_success_at, last_failure_at, failure_count
 			FROM push_subscriptions
 			WHERE tenant_id = $1
 		`, tenant.ID)
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbQuietHours struct {
+	UserID   int    `db:"user_id"`
+	Email    string `db:"email"`
+	Timezone string `db:"timezone"`
+	Start    string `db:"quiet_start"`
+	End      string `db:"quiet_end"`
+}
+
+type dbDeferredNotification struct {
+	ID       int    `db:"id"`
+	TenantID int    `db:"tenant_id"`
+	UserID   int    `db:"user_id"`
+	Channel  int    `db:"channel"`
+	Payload  string `db:"payload"`
+	BaseURL  string `db:"base_url"`
+}
+
+func getQuietHours(ctx context.Context, q *query.GetQuietHours) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		q.Result = make([]*entity.QuietHours, 0)
+		if len(q.UserIDs) == 0 && len(q.Emails) == 0 {
+			return nil
+		}
+
+		emails := make([]string, len(q.Emails))
+		for i, email := range q.Emails {
+			emails[i] = strings.ToLower(email)
+		}
+
+		var rows []*dbQuietHours
+		err := trx.Select(&rows, `
+			SELECT u.id AS user_id, u.email, COALESCE(tz.value, '') AS timezone, qs.value AS quiet_start, qe.value AS quiet_end
+			FROM users u
+			INNER JOIN user_settings qs ON qs.user_id = u.id AND qs.tenant_id = u.tenant_id AND qs.key = $2
+			INNER JOIN user_settings qe ON qe.user_id = u.id AND qe.tenant_id = u.tenant_id AND qe.key = $3
+			LEFT JOIN user_settings tz ON tz.user_id = u.id AND tz.tenant_id = u.tenant_id AND tz.key = $4
+			WHERE u.tenant_id = $1 AND (u.id = ANY($5) OR LOWER(u.email) = ANY($6))
+		`, tenant.ID, enum.QuietHoursStartSettingsKey, enum.QuietHoursEndSettingsKey, enum.TimezoneSettingsKey, pq.Array(q.UserIDs), pq.Array(emails))
+		if err != nil {
+			return errors.Wrap(err, "failed to get quiet hours")
+		}
+
+		for _, row := range rows {
+			quiet := entity.NewQuietHours(row.Timezone, row.Start, row.End)
+			if quiet != nil {
+				quiet.UserID = row.UserID
+				quiet.Email = row.Email
+				q.Result = append(q.Result, quiet)
+			}
+		}
+		return nil
+	})
+}
+
+func deferNotification(ctx context.Context, c *cmd.DeferNotification) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO deferred_notifications (tenant_id, user_id, channel, payload, base_url, release_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		`, tenant.ID, c.UserID, c.Channel, c.Payload, c.BaseURL, c.ReleaseAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to defer notification")
+		}
+		return nil
+	})
+}
+
+func getDueDeferredNotifications(ctx context.Context, q *query.GetDueDeferredNotifications) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		q.Result = make([]*entity.DeferredNotification, 0)
+
+		var rows []*dbDeferredNotification
+		err := trx.Select(&rows, `
+			SELECT d.id, d.tenant_id, d.user_id, d.channel, d.payload, d.base_url
+			FROM deferred_notifications d
+			INNER JOIN users u ON u.id = d.user_id AND u.tenant_id = d.tenant_id
+			INNER JOIN tenants t ON t.id = d.tenant_id
+			WHERE d.release_at <= NOW()
+			AND u.status = $1
+			AND t.status = $2
+			ORDER BY d.tenant_id, d.release_at
+		`, enum.UserActive, enum.TenantActive)
+		if err != nil {
+			return errors.Wrap(err, "failed to get due deferred notifications")
+		}
+
+		tenantIDs := make([]int, len(rows))
+		for i, row := range rows {
+			tenantIDs[i] = row.TenantID
+		}
+		tenants, err := getTenantsByID(trx, tenantIDs)
+		if err != nil {
+			return err
+		}
+
+		users := make(map[int]*entity.User)
+		for _, row := range rows {
+			user, ok := users[row.UserID]
+			if !ok {
+				dbUser := dbUser{}
+				err = trx.Get(&dbUser, `
+					SELECT id, name, email, tenant_id, role, status
+					FROM users
+					WHERE id = $1 AND tenant_id = $2
+				`, row.UserID, row.TenantID)
+				if err != nil {
+					return errors.Wrap(err, "failed to get user '%d' of deferred notification", row.UserID)
+				}
+				user = dbUser.toModel(ctx)
+				users[row.UserID] = user
+			}
+
+			q.Result = append(q.Result, &entity.DeferredNotification{
+				ID:      row.ID,
+				Tenant:  tenants[row.TenantID],
+				User:    user,
+				Channel: enum.NotificationChannel(row.Channel),
+				Payload: row.Payload,
+				BaseURL: row.BaseURL,
+			})
+		}
+		return nil
+	})
+}
+
+func deleteDeferredNotifications(ctx context.Context, c *cmd.DeleteDeferredNotifications) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		// notifications of users or tenants that were blocked since they were deferred are dropped after a week
+		_, err := trx.Execute(`
+			DELETE FROM deferred_notifications
+			WHERE id = ANY($1) OR release_at <= NOW() - INTERVAL '7 days'
+		`, pq.Array(c.IDs))
+		if err != nil {
+			return errors.Wrap(err, "failed to delete deferred notifications")
+		}
+		return nil
+	})
+}
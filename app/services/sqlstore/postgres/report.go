@@ -17,16 +17,19 @@ import (
 
 type dbReport struct {
 	ID                   int            `db:"id"`
+	CaseID               sql.NullInt64  `db:"case_id"`
 	ReportedType         string         `db:"reported_type"`
 	ReportedID           int            `db:"reported_id"`
 	Reason               string         `db:"reason"`
 	Details              sql.NullString `db:"details"`
 	Status               string         `db:"status"`
 	CreatedAt            time.Time      `db:"created_at"`
-	ReporterID           int            `db:"reporter_id"`
-	ReporterName         string         `db:"reporter_name"`
+	ReporterID           sql.NullInt64  `db:"reporter_id"`
+	ReporterName         sql.NullString `db:"reporter_name"`
 	ReporterAvatarType   sql.NullInt64  `db:"reporter_avatar_type"`
 	ReporterAvatarBkey   sql.NullString `db:"reporter_avatar_bkey"`
+	ReporterResolved     int            `db:"reporter_resolved"`
+	ReporterDismissed    int            `db:"reporter_dismissed"`
 	AssignedToID         sql.NullInt64  `db:"assigned_to_id"`
 	AssignedToName       sql.NullString `db:"assigned_to_name"`
 	AssignedToAvatarType sql.NullInt64  `db:"assigned_to_avatar_type"`
@@ -42,28 +45,58 @@ type dbReport struct {
 	PostSlug             sql.NullString `db:"post_slug"`
 }
 
+// reportAccuracyScoreSQL mirrors entity.NewReportAccuracy so reports from reliable reporters come first
+const reportAccuracyScoreSQL = "(COALESCE(ra.resolved, 0) + 1.0) / (COALESCE(ra.resolved, 0) + COALESCE(ra.dismissed, 0) + 2.0)"
+
+const selectReportSQL = `
+			SELECT 
+				r.id, r.case_id, r.reported_type, r.reported_id, r.reason, r.details, r.status, r.created_at,
+				r.reporter_id, ru.name as reporter_name, ru.avatar_type as reporter_avatar_type, ru.avatar_bkey as reporter_avatar_bkey,
+				COALESCE(ra.resolved, 0) as reporter_resolved, COALESCE(ra.dismissed, 0) as reporter_dismissed,
+				r.assigned_to as assigned_to_id, au.name as assigned_to_name, au.avatar_type as assigned_to_avatar_type, au.avatar_bkey as assigned_to_avatar_bkey, r.assigned_at,
+				r.resolved_at, r.resolved_by as resolved_by_id, rbu.name as resolved_by_name, rbu.avatar_type as resolved_by_avatar_type, rbu.avatar_bkey as resolved_by_avatar_bkey,
+				r.resolution_note,
+				COALESCE(p.number, cp.number) as post_number,
+				COALESCE(p.slug, cp.slug) as post_slug
+			FROM reports r
+			LEFT JOIN users ru ON ru.id = r.reporter_id
+			LEFT JOIN reporter_accuracy ra ON ra.tenant_id = r.tenant_id AND ra.user_id = r.reporter_id
+			LEFT JOIN users au ON au.id = r.assigned_to
+			LEFT JOIN users rbu ON rbu.id = r.resolved_by
+			LEFT JOIN posts p ON r.reported_type = 'post' AND p.id = r.reported_id
+			LEFT JOIN comments c ON r.reported_type = 'comment' AND c.id = r.reported_id
+			LEFT JOIN posts cp ON c.post_id = cp.id`
+
 func (r *dbReport) toModel(ctx context.Context) *entity.Report {
 	report := &entity.Report{
 		ID:        r.ID,
 		Reason:    r.Reason,
 		Status:    enum.ReportStatusPending,
 		CreatedAt: r.CreatedAt,
-		Reporter: &entity.User{
-			ID:   r.ReporterID,
-			Name: r.ReporterName,
-		},
 	}
 
 	_ = report.ReportedType.UnmarshalText([]byte(r.ReportedType))
 	report.ReportedID = r.ReportedID
 	_ = report.Status.UnmarshalText([]byte(r.Status))
 
+	if r.CaseID.Valid {
+		report.CaseID = int(r.CaseID.Int64)
+	}
+
 	if r.Details.Valid {
 		report.Details = r.Details.String
 	}
 
-	if r.ReporterAvatarType.Valid {
-		report.Reporter.AvatarURL = buildAvatarURL(ctx, enum.AvatarType(r.ReporterAvatarType.Int64), r.ReporterID, r.ReporterName, r.ReporterAvatarBkey.String)
+	// reports created by the automated moderation have no reporter
+	if r.ReporterID.Valid {
+		report.Reporter = &entity.User{
+			ID:   int(r.ReporterID.Int64),
+			Name: r.ReporterName.String,
+		}
+		if r.ReporterAvatarType.Valid {
+			report.Reporter.AvatarURL = buildAvatarURL(ctx, enum.AvatarType(r.ReporterAvatarType.Int64), int(r.ReporterID.Int64), r.ReporterName.String, r.ReporterAvatarBkey.String)
+		}
+		report.ReporterScore = entity.NewReportAccuracy(r.ReporterResolved, r.ReporterDismissed)
 	}
 
 	if r.AssignedToID.Valid {
@@ -133,7 +166,9 @@ func (r *dbReportReason) toModel() *entity.ReportReason {
 func createReport(ctx context.Context, c *cmd.CreateReport) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		var reporterID interface{}
-		if c.ReporterID != nil {
+		if c.System {
+			reporterID = nil
+		} else if c.ReporterID != nil {
 			reporterID = *c.ReporterID
 		} else if user != nil {
 			reporterID = user.ID
@@ -141,20 +176,88 @@ func createReport(ctx context.Context, c *cmd.CreateReport) error {
 			reporterID = nil
 		}
 
+		caseID, err := getOrCreateOpenReportCase(trx, tenant, c.ReportedType, c.ReportedID)
+		if err != nil {
+			return err
+		}
+
 		var id int
-		err := trx.Scalar(&id, `
-			INSERT INTO reports (tenant_id, reporter_id, reported_type, reported_id, reason, details, status, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, 'pending', NOW())
+		err = trx.Scalar(&id, `
+			INSERT INTO reports (tenant_id, reporter_id, reported_type, reported_id, reason, details, status, created_at, case_id)
+			VALUES ($1, $2, $3, $4, $5, $6, 'pending', NOW(), $7)
 			RETURNING id
-		`, tenant.ID, reporterID, c.ReportedType.String(), c.ReportedID, c.Reason, nullIfEmpty(c.Details))
+		`, tenant.ID, reporterID, c.ReportedType.String(), c.ReportedID, c.Reason, nullIfEmpty(c.Details), caseID)
 		if err != nil {
 			return errors.Wrap(err, "failed to create report")
 		}
 		c.Result = id
+		c.ResultCaseID = caseID
 		return nil
 	})
 }
 
+// getOrCreateOpenReportCase returns the open case of given item, creating one if there's none yet
+func getOrCreateOpenReportCase(trx *dbx.Trx, tenant *entity.Tenant, reportedType enum.ReportType, reportedID int) (int, error) {
+	_, err := trx.Execute(`
+		INSERT INTO report_cases (tenant_id, reported_type, reported_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, 'pending', NOW(), NOW())
+		ON CONFLICT (tenant_id, reported_type, reported_id) WHERE status IN ('pending', 'in_review') DO NOTHING
+	`, tenant.ID, reportedType.String(), reportedID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create report case")
+	}
+
+	var caseID int
+	err = trx.Scalar(&caseID, `
+		UPDATE report_cases SET updated_at = NOW()
+		WHERE tenant_id = $1 AND reported_type = $2 AND reported_id = $3 AND status IN ('pending', 'in_review')
+		RETURNING id
+	`, tenant.ID, reportedType.String(), reportedID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get open report case")
+	}
+	return caseID, nil
+}
+
+// syncReportCaseStatus brings the case status in line with its member reports after one of them changed
+func syncReportCaseStatus(trx *dbx.Trx, tenant *entity.Tenant, reportID int) error {
+	_, err := trx.Execute(`
+		UPDATE report_cases rc
+		SET status = CASE
+				WHEN EXISTS (SELECT 1 FROM reports r WHERE r.case_id = rc.id AND r.status = 'in_review') THEN 'in_review'
+				ELSE 'pending'
+			END,
+			updated_at = NOW()
+		WHERE rc.id = (SELECT case_id FROM reports WHERE id = $1 AND tenant_id = $2)
+		AND rc.status IN ('pending', 'in_review')
+		AND EXISTS (SELECT 1 FROM reports r WHERE r.case_id = rc.id AND r.status IN ('pending', 'in_review'))
+	`, reportID, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to sync report case status")
+	}
+
+	// the case is closed with the outcome of its last report once nothing is left open
+	_, err = trx.Execute(`
+		UPDATE report_cases rc
+		SET status = last.status, resolved_at = last.resolved_at, resolved_by = last.resolved_by,
+			resolution_note = last.resolution_note, updated_at = NOW()
+		FROM (
+			SELECT case_id, status, resolved_at, resolved_by, resolution_note
+			FROM reports
+			WHERE case_id = (SELECT case_id FROM reports WHERE id = $1 AND tenant_id = $2)
+			ORDER BY resolved_at DESC NULLS LAST
+			LIMIT 1
+		) last
+		WHERE rc.id = last.case_id
+		AND rc.status IN ('pending', 'in_review')
+		AND NOT EXISTS (SELECT 1 FROM reports r WHERE r.case_id = rc.id AND r.status IN ('pending', 'in_review'))
+	`, reportID, tenant.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to close report case")
+	}
+	return nil
+}
+
 func assignReport(ctx context.Context, c *cmd.AssignReport) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		_, err := trx.Execute(`
@@ -165,7 +268,7 @@ func assignReport(ctx context.Context, c *cmd.AssignReport) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to assign report")
 		}
-		return nil
+		return syncReportCaseStatus(trx, tenant, c.ReportID)
 	})
 }
 
@@ -179,55 +282,79 @@ func unassignReport(ctx context.Context, c *cmd.UnassignReport) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to unassign report")
 		}
-		return nil
+		return syncReportCaseStatus(trx, tenant, c.ReportID)
 	})
 }
 
 func resolveReport(ctx context.Context, c *cmd.ResolveReport) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		_, err := trx.Execute(`
+		var reporters []*dbReporterID
+		err := trx.Select(&reporters, `
 			UPDATE reports 
 			SET status = $1, resolved_at = NOW(), resolved_by = $2, resolution_note = $3
 			WHERE id = $4 AND tenant_id = $5
+			RETURNING reporter_id
 		`, c.Status.String(), user.ID, nullIfEmpty(c.ResolutionNote), c.ReportID, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to resolve report")
 		}
-		return nil
+		if err := refreshReporterAccuracy(trx, tenant, reporters); err != nil {
+			return err
+		}
+		return syncReportCaseStatus(trx, tenant, c.ReportID)
 	})
 }
 
 func deleteReport(ctx context.Context, c *cmd.DeleteReport) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
-		_, err := trx.Execute(`
+		var reporters []*dbReporterID
+		err := trx.Select(&reporters, `
 			DELETE FROM reports WHERE id = $1 AND tenant_id = $2
+			RETURNING reporter_id
 		`, c.ReportID, tenant.ID)
 		if err != nil {
 			return errors.Wrap(err, "failed to delete report")
 		}
-		return nil
+		return refreshReporterAccuracy(trx, tenant, reporters)
 	})
 }
 
+type dbReporterID struct {
+	ReporterID sql.NullInt64 `db:"reporter_id"`
+}
+
+// refreshReporterAccuracy recounts the report outcomes of given reporters
+func refreshReporterAccuracy(trx *dbx.Trx, tenant *entity.Tenant, reporters []*dbReporterID) error {
+	ids := make([]int64, 0, len(reporters))
+	for _, r := range reporters {
+		if r.ReporterID.Valid {
+			ids = append(ids, r.ReporterID.Int64)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := trx.Execute(`
+		INSERT INTO reporter_accuracy (tenant_id, user_id, resolved, dismissed)
+		SELECT $1, u.id,
+			COUNT(r.id) FILTER (WHERE r.status = 'resolved'),
+			COUNT(r.id) FILTER (WHERE r.status = 'dismissed')
+		FROM (SELECT DISTINCT id FROM unnest($2::int[]) AS id) u
+		LEFT JOIN reports r ON r.tenant_id = $1 AND r.reporter_id = u.id
+		GROUP BY u.id
+		ON CONFLICT (tenant_id, user_id) DO UPDATE SET resolved = EXCLUDED.resolved, dismissed = EXCLUDED.dismissed
+	`, tenant.ID, pq.Array(ids))
+	if err != nil {
+		return errors.Wrap(err, "failed to refresh reporter accuracy")
+	}
+	return nil
+}
+
 func getReportByID(ctx context.Context, q *query.GetReportByID) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		report := dbReport{}
-		err := trx.Get(&report, `
-			SELECT 
-				r.id, r.reported_type, r.reported_id, r.reason, r.details, r.status, r.created_at,
-				r.reporter_id, ru.name as reporter_name, ru.avatar_type as reporter_avatar_type, ru.avatar_bkey as reporter_avatar_bkey,
-				r.assigned_to as assigned_to_id, au.name as assigned_to_name, au.avatar_type as assigned_to_avatar_type, au.avatar_bkey as assigned_to_avatar_bkey, r.assigned_at,
-				r.resolved_at, r.resolved_by as resolved_by_id, rbu.name as resolved_by_name, rbu.avatar_type as resolved_by_avatar_type, rbu.avatar_bkey as resolved_by_avatar_bkey,
-				r.resolution_note,
-				COALESCE(p.number, cp.number) as post_number,
-				COALESCE(p.slug, cp.slug) as post_slug
-			FROM reports r
-			LEFT JOIN users ru ON ru.id = r.reporter_id
-			LEFT JOIN users au ON au.id = r.assigned_to
-			LEFT JOIN users rbu ON rbu.id = r.resolved_by
-			LEFT JOIN posts p ON r.reported_type = 'post' AND p.id = r.reported_id
-			LEFT JOIN comments c ON r.reported_type = 'comment' AND c.id = r.reported_id
-			LEFT JOIN posts cp ON c.post_id = cp.id
+		err := trx.Get(&report, selectReportSQL+`
 			WHERE r.id = $1 AND r.tenant_id = $2
 		`, q.ReportID, tenant.ID)
 		if err != nil {
@@ -280,24 +407,9 @@ func listReports(ctx context.Context, q *query.ListReports) error {
 		}
 
 		var reports []*dbReport
-		err = trx.Select(&reports, `
-			SELECT 
-				r.id, r.reported_type, r.reported_id, r.reason, r.details, r.status, r.created_at,
-				r.reporter_id, ru.name as reporter_name, ru.avatar_type as reporter_avatar_type, ru.avatar_bkey as reporter_avatar_bkey,
-				r.assigned_to as assigned_to_id, au.name as assigned_to_name, au.avatar_type as assigned_to_avatar_type, au.avatar_bkey as assigned_to_avatar_bkey, r.assigned_at,
-				r.resolved_at, r.resolved_by as resolved_by_id, rbu.name as resolved_by_name, rbu.avatar_type as resolved_by_avatar_type, rbu.avatar_bkey as resolved_by_avatar_bkey,
-				r.resolution_note,
-				COALESCE(p.number, cp.number) as post_number,
-				COALESCE(p.slug, cp.slug) as post_slug
-			FROM reports r
-			LEFT JOIN users ru ON ru.id = r.reporter_id
-			LEFT JOIN users au ON au.id = r.assigned_to
-			LEFT JOIN users rbu ON rbu.id = r.resolved_by
-			LEFT JOIN posts p ON r.reported_type = 'post' AND p.id = r.reported_id
-			LEFT JOIN comments c ON r.reported_type = 'comment' AND c.id = r.reported_id
-			LEFT JOIN posts cp ON c.post_id = cp.id
+		err = trx.Select(&reports, selectReportSQL+`
 			WHERE `+conditions+`
-			ORDER BY r.created_at DESC
+			ORDER BY `+reportAccuracyScoreSQL+` DESC, r.created_at DESC
 			LIMIT $`+strconv.Itoa(argIdx)+` OFFSET $`+strconv.Itoa(argIdx+1),
 			append(args, q.PerPage, offset)...)
 		if err != nil {
@@ -312,6 +424,318 @@ func listReports(ctx context.Context, q *query.ListReports) error {
 	})
 }
 
+type dbReportCase struct {
+	ID             int            `db:"id"`
+	ReportedType   string         `db:"reported_type"`
+	ReportedID     int            `db:"reported_id"`
+	Status         string         `db:"status"`
+	CreatedAt      time.Time      `db:"created_at"`
+	AutoHiddenAt   sql.NullTime   `db:"auto_hidden_at"`
+	ResolvedAt     sql.NullTime   `db:"resolved_at"`
+	ResolvedByID   sql.NullInt64  `db:"resolved_by_id"`
+	ResolvedByName sql.NullString `db:"resolved_by_name"`
+	ResolutionNote sql.NullString `db:"resolution_note"`
+	ReportCount    int            `db:"report_count"`
+	ReporterCount  int            `db:"reporter_count"`
+	LeadReportID   int            `db:"lead_report_id"`
+	LastReportedAt time.Time      `db:"last_reported_at"`
+	PostNumber     sql.NullInt64  `db:"post_number"`
+	PostSlug       sql.NullString `db:"post_slug"`
+}
+
+func (r *dbReportCase) toModel() *entity.ReportCase {
+	reportCase := &entity.ReportCase{
+		ID:             r.ID,
+		ReportedID:     r.ReportedID,
+		ReportCount:    r.ReportCount,
+		ReporterCount:  r.ReporterCount,
+		LeadReportID:   r.LeadReportID,
+		Reasons:        []*entity.ReportReasonCount{},
+		Details:        []string{},
+		CreatedAt:      r.CreatedAt,
+		LastReportedAt: r.LastReportedAt,
+	}
+
+	_ = reportCase.ReportedType.UnmarshalText([]byte(r.ReportedType))
+	_ = reportCase.Status.UnmarshalText([]byte(r.Status))
+
+	if r.AutoHiddenAt.Valid {
+		reportCase.AutoHiddenAt = &r.AutoHiddenAt.Time
+	}
+	if r.ResolvedAt.Valid {
+		reportCase.ResolvedAt = &r.ResolvedAt.Time
+	}
+	if r.ResolvedByID.Valid {
+		reportCase.ResolvedBy = &entity.User{
+			ID:   int(r.ResolvedByID.Int64),
+			Name: r.ResolvedByName.String,
+		}
+	}
+	if r.ResolutionNote.Valid {
+		reportCase.ResolutionNote = r.ResolutionNote.String
+	}
+	if r.PostNumber.Valid {
+		reportCase.PostNumber = int(r.PostNumber.Int64)
+	}
+	if r.PostSlug.Valid {
+		reportCase.PostSlug = r.PostSlug.String
+	}
+
+	return reportCase
+}
+
+const selectReportCaseSQL = `
+			SELECT
+				rc.id, rc.reported_type, rc.reported_id, rc.status, rc.created_at, rc.auto_hidden_at,
+				rc.resolved_at, rc.resolved_by as resolved_by_id, rbu.name as resolved_by_name, rc.resolution_note,
+				COUNT(r.id) as report_count,
+				COUNT(DISTINCT r.reporter_id) as reporter_count,
+				COALESCE(MIN(r.id) FILTER (WHERE r.status IN ('pending', 'in_review')), MIN(r.id)) as lead_report_id,
+				MAX(r.created_at) as last_reported_at,
+				COALESCE(MAX(p.number), MAX(cp.number)) as post_number,
+				COALESCE(MAX(p.slug), MAX(cp.slug)) as post_slug
+			FROM report_cases rc
+			INNER JOIN reports r ON r.case_id = rc.id
+			LEFT JOIN users rbu ON rbu.id = rc.resolved_by
+			LEFT JOIN posts p ON rc.reported_type = 'post' AND p.id = rc.reported_id
+			LEFT JOIN comments c ON rc.reported_type = 'comment' AND c.id = rc.reported_id
+			LEFT JOIN posts cp ON c.post_id = cp.id`
+
+type dbReportCaseReason struct {
+	CaseID int    `db:"case_id"`
+	Reason string `db:"reason"`
+	Count  int    `db:"count"`
+}
+
+type dbReportCaseDetail struct {
+	CaseID  int    `db:"case_id"`
+	Details string `db:"details"`
+}
+
+// fillReportCaseBreakdown loads the reason breakdown and combined details of given cases
+func fillReportCaseBreakdown(trx *dbx.Trx, tenant *entity.Tenant, cases []*entity.ReportCase) error {
+	if len(cases) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*entity.ReportCase, len(cases))
+	ids := make([]int, len(cases))
+	for i, rc := range cases {
+		byID[rc.ID] = rc
+		ids[i] = rc.ID
+	}
+
+	var reasons []*dbReportCaseReason
+	err := trx.Select(&reasons, `
+		SELECT case_id, reason, COUNT(*) as count
+		FROM reports
+		WHERE tenant_id = $1 AND case_id = ANY($2)
+		GROUP BY case_id, reason
+		ORDER BY count DESC, reason ASC
+	`, tenant.ID, pq.Array(ids))
+	if err != nil {
+		return errors.Wrap(err, "failed to get report case reasons")
+	}
+	for _, r := range reasons {
+		rc := byID[r.CaseID]
+		rc.Reasons = append(rc.Reasons, &entity.ReportReasonCount{Reason: r.Reason, Count: r.Count})
+	}
+
+	var details []*dbReportCaseDetail
+	err = trx.Select(&details, `
+		SELECT case_id, details
+		FROM reports
+		WHERE tenant_id = $1 AND case_id = ANY($2) AND details IS NOT NULL AND details <> ''
+		ORDER BY created_at ASC
+	`, tenant.ID, pq.Array(ids))
+	if err != nil {
+		return errors.Wrap(err, "failed to get report case details")
+	}
+	for _, d := range details {
+		rc := byID[d.CaseID]
+		rc.Details = append(rc.Details, d.Details)
+	}
+
+	return nil
+}
+
+func getReportCaseByID(ctx context.Context, q *query.GetReportCaseByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		reportCase := dbReportCase{}
+		err := trx.Get(&reportCase, selectReportCaseSQL+`
+			WHERE rc.id = $1 AND rc.tenant_id = $2
+			GROUP BY rc.id, rbu.name
+		`, q.CaseID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get report case by ID")
+		}
+
+		result := reportCase.toModel()
+		if err := fillReportCaseBreakdown(trx, tenant, []*entity.ReportCase{result}); err != nil {
+			return err
+		}
+
+		var reports []*dbReport
+		err = trx.Select(&reports, selectReportSQL+`
+			WHERE r.case_id = $1 AND r.tenant_id = $2
+			ORDER BY r.created_at ASC
+		`, q.CaseID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get report case members")
+		}
+
+		result.Reports = make([]*entity.Report, len(reports))
+		for i, r := range reports {
+			result.Reports[i] = r.toModel(ctx)
+		}
+
+		q.Result = result
+		return nil
+	})
+}
+
+func listReportCases(ctx context.Context, q *query.ListReportCases) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if q.Page < 1 {
+			q.Page = 1
+		}
+		if q.PerPage < 1 {
+			q.PerPage = 20
+		}
+		offset := (q.Page - 1) * q.PerPage
+
+		conditions := "rc.tenant_id = $1"
+		args := []interface{}{tenant.ID}
+		argIdx := 2
+
+		if len(q.Status) > 0 {
+			statusStrings := make([]string, len(q.Status))
+			for i, s := range q.Status {
+				statusStrings[i] = s.String()
+			}
+			conditions += " AND rc.status = ANY($" + strconv.Itoa(argIdx) + ")"
+			args = append(args, pq.Array(statusStrings))
+			argIdx++
+		}
+
+		if q.Type != 0 {
+			conditions += " AND rc.reported_type = $" + strconv.Itoa(argIdx)
+			args = append(args, q.Type.String())
+			argIdx++
+		}
+
+		if q.Reason != "" {
+			conditions += " AND EXISTS (SELECT 1 FROM reports fr WHERE fr.case_id = rc.id AND fr.reason = $" + strconv.Itoa(argIdx) + ")"
+			args = append(args, q.Reason)
+			argIdx++
+		}
+
+		err := trx.Scalar(&q.Total, `
+			SELECT COUNT(*) FROM report_cases rc
+			WHERE `+conditions+` AND EXISTS (SELECT 1 FROM reports r WHERE r.case_id = rc.id)
+		`, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to count report cases")
+		}
+
+		var cases []*dbReportCase
+		err = trx.Select(&cases, selectReportCaseSQL+`
+			WHERE `+conditions+`
+			GROUP BY rc.id, rbu.name
+			ORDER BY reporter_count DESC, last_reported_at DESC
+			LIMIT $`+strconv.Itoa(argIdx)+` OFFSET $`+strconv.Itoa(argIdx+1),
+			append(args, q.PerPage, offset)...)
+		if err != nil {
+			return errors.Wrap(err, "failed to list report cases")
+		}
+
+		q.Result = make([]*entity.ReportCase, len(cases))
+		for i, rc := range cases {
+			q.Result[i] = rc.toModel()
+		}
+		return fillReportCaseBreakdown(trx, tenant, q.Result)
+	})
+}
+
+func resolveReportCase(ctx context.Context, c *cmd.ResolveReportCase) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var reporters []*dbReporterID
+		err := trx.Select(&reporters, `
+			UPDATE reports
+			SET status = $1, resolved_at = NOW(), resolved_by = $2, resolution_note = $3
+			WHERE case_id = $4 AND tenant_id = $5 AND status IN ('pending', 'in_review')
+			RETURNING reporter_id
+		`, c.Status.String(), user.ID, nullIfEmpty(c.ResolutionNote), c.CaseID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve report case members")
+		}
+		if err := refreshReporterAccuracy(trx, tenant, reporters); err != nil {
+			return err
+		}
+
+		_, err = trx.Execute(`
+			UPDATE report_cases
+			SET status = $1, resolved_at = NOW(), resolved_by = $2, resolution_note = $3, updated_at = NOW()
+			WHERE id = $4 AND tenant_id = $5 AND status IN ('pending', 'in_review')
+		`, c.Status.String(), user.ID, nullIfEmpty(c.ResolutionNote), c.CaseID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve report case")
+		}
+		return nil
+	})
+}
+
+func markReportCaseAutoHidden(ctx context.Context, c *cmd.MarkReportCaseAutoHidden) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE report_cases SET auto_hidden_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND auto_hidden_at IS NULL
+		`, c.CaseID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to mark report case as auto-hidden")
+		}
+		c.Result = rows > 0
+		return nil
+	})
+}
+
+// countTrustedCaseReporters only counts active users allowed to view reports, through their role
+// or their custom role, or members with an account older than a week that are neither muted nor
+// carrying an active warning
+func countTrustedCaseReporters(ctx context.Context, q *query.CountTrustedCaseReporters) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		err := trx.Scalar(&q.Result, `
+			SELECT COUNT(DISTINCT r.reporter_id)
+			FROM reports r
+			INNER JOIN users u ON u.id = r.reporter_id AND u.tenant_id = r.tenant_id
+			WHERE r.case_id = $1 AND r.tenant_id = $2 AND u.status = $3
+			AND (
+				u.role = ANY($4)
+				OR EXISTS (
+					SELECT 1 FROM user_custom_roles ucr
+					INNER JOIN custom_roles cr ON cr.id = ucr.custom_role_id AND cr.tenant_id = ucr.tenant_id
+					WHERE ucr.user_id = u.id AND ucr.tenant_id = u.tenant_id AND $5 = ANY(cr.permissions)
+				)
+				OR (
+					u.created_at <= NOW() - INTERVAL '7 days'
+					AND NOT EXISTS (
+						SELECT 1 FROM user_mutes m
+						WHERE m.user_id = u.id AND m.tenant_id = u.tenant_id AND (m.expires_at IS NULL OR m.expires_at > NOW())
+					)
+					AND NOT EXISTS (
+						SELECT 1 FROM user_warnings w
+						WHERE w.user_id = u.id AND w.tenant_id = u.tenant_id AND (w.expires_at IS NULL OR w.expires_at > NOW())
+					)
+				)
+			)
+		`, q.CaseID, tenant.ID, enum.UserActive, pq.Array(enum.RolesWithPermission(enum.PermissionReportView)), string(enum.PermissionReportView))
+		if err != nil {
+			return errors.Wrap(err, "failed to count trusted case reporters")
+		}
+		return nil
+	})
+}
+
 func countPendingReports(ctx context.Context, q *query.CountPendingReports) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		err := trx.Scalar(&q.Result, `
@@ -346,6 +770,27 @@ func getReportReasons(ctx context.Context, q *query.GetReportReasons) error {
 	})
 }
 
+func getUserReportAccuracy(ctx context.Context, q *query.GetUserReportAccuracy) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var counts struct {
+			Resolved  int `db:"resolved"`
+			Dismissed int `db:"dismissed"`
+		}
+		err := trx.Get(&counts, `
+			SELECT
+				COUNT(*) FILTER (WHERE status = 'resolved') as resolved,
+				COUNT(*) FILTER (WHERE status = 'dismissed') as dismissed
+			FROM reports
+			WHERE tenant_id = $1 AND reporter_id = $2
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user report accuracy")
+		}
+		q.Result = entity.NewReportAccuracy(counts.Resolved, counts.Dismissed)
+		return nil
+	})
+}
+
 func countUserReportsToday(ctx context.Context, q *query.CountUserReportsToday) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		err := trx.Scalar(&q.Result, `
@@ -0,0 +1,218 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+)
+
+func TestReportStorage_GroupsReportsOfSameItemIntoCase(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	aryaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	err = bus.Dispatch(aryaStarkCtx, aryaReport)
+	Expect(err).IsNil()
+	Expect(aryaReport.ResultCaseID).NotEquals(0)
+
+	sansaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Off-topic", Details: "Not about the game"}
+	err = bus.Dispatch(sansaStarkCtx, sansaReport)
+	Expect(err).IsNil()
+	Expect(sansaReport.ResultCaseID).Equals(aryaReport.ResultCaseID)
+
+	getCase := &query.GetReportCaseByID{CaseID: aryaReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusPending)
+	Expect(getCase.Result.ReportCount).Equals(2)
+	Expect(getCase.Result.ReporterCount).Equals(2)
+	Expect(getCase.Result.Reasons).HasLen(2)
+	Expect(getCase.Result.Details).Equals([]string{"Not about the game"})
+	Expect(getCase.Result.Reports).HasLen(2)
+	Expect(getCase.Result.PostNumber).Equals(newPost.Result.Number)
+
+	listCases := &query.ListReportCases{Status: []enum.ReportStatus{enum.ReportStatusPending}}
+	err = bus.Dispatch(demoTenantCtx, listCases)
+	Expect(err).IsNil()
+	Expect(listCases.Total).Equals(1)
+	Expect(listCases.Result).HasLen(1)
+	Expect(listCases.Result[0].ID).Equals(aryaReport.ResultCaseID)
+}
+
+func TestReportStorage_ClosedCaseStartsNewCase(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	firstReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	err = bus.Dispatch(aryaStarkCtx, firstReport)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ResolveReport{ReportID: firstReport.Result, Status: enum.ReportStatusDismissed})
+	Expect(err).IsNil()
+
+	getCase := &query.GetReportCaseByID{CaseID: firstReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusDismissed)
+	Expect(getCase.Result.ResolvedBy.ID).Equals(jonSnow.ID)
+
+	secondReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	err = bus.Dispatch(sansaStarkCtx, secondReport)
+	Expect(err).IsNil()
+	Expect(secondReport.ResultCaseID).NotEquals(firstReport.ResultCaseID)
+}
+
+func TestReportStorage_AssignReportMovesCaseInReview(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	createReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	err = bus.Dispatch(aryaStarkCtx, createReport)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignReport{ReportID: createReport.Result, AssignToID: jonSnow.ID})
+	Expect(err).IsNil()
+
+	getCase := &query.GetReportCaseByID{CaseID: createReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusInReview)
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.UnassignReport{ReportID: createReport.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusPending)
+}
+
+func TestReportStorage_ResolveReportCase(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	aryaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	sansaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	Expect(bus.Dispatch(aryaStarkCtx, aryaReport)).IsNil()
+	Expect(bus.Dispatch(sansaStarkCtx, sansaReport)).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.ResolveReportCase{CaseID: aryaReport.ResultCaseID, Status: enum.ReportStatusResolved, ResolutionNote: "Removed"})
+	Expect(err).IsNil()
+
+	getCase := &query.GetReportCaseByID{CaseID: aryaReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusResolved)
+	Expect(getCase.Result.ResolutionNote).Equals("Removed")
+	for _, report := range getCase.Result.Reports {
+		Expect(report.Status).Equals(enum.ReportStatusResolved)
+	}
+
+	// a closed case keeps its outcome
+	err = bus.Dispatch(jonSnowCtx, &cmd.ResolveReportCase{CaseID: aryaReport.ResultCaseID, Status: enum.ReportStatusDismissed})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Status).Equals(enum.ReportStatusResolved)
+	Expect(getCase.Result.ResolutionNote).Equals("Removed")
+}
+
+func TestReportStorage_MarkReportCaseAutoHiddenOnce(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	createReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	err = bus.Dispatch(aryaStarkCtx, createReport)
+	Expect(err).IsNil()
+
+	markHidden := &cmd.MarkReportCaseAutoHidden{CaseID: createReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, markHidden)
+	Expect(err).IsNil()
+	Expect(markHidden.Result).IsTrue()
+
+	markHidden = &cmd.MarkReportCaseAutoHidden{CaseID: createReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, markHidden)
+	Expect(err).IsNil()
+	Expect(markHidden.Result).IsFalse()
+}
+
+func TestReportStorage_CountTrustedCaseReporters(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(jonSnowCtx, newPost)
+	Expect(err).IsNil()
+
+	aryaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	sansaReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Spam"}
+	Expect(bus.Dispatch(aryaStarkCtx, aryaReport)).IsNil()
+	Expect(bus.Dispatch(sansaStarkCtx, sansaReport)).IsNil()
+
+	// both accounts were just created
+	countTrusted := &query.CountTrustedCaseReporters{CaseID: aryaReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, countTrusted)
+	Expect(err).IsNil()
+	Expect(countTrusted.Result).Equals(0)
+
+	// a custom role allowed to view reports makes a reporter trusted
+	createRole := &cmd.CreateCustomRole{Name: "Report Reviewer", Permissions: []enum.Permission{enum.PermissionReportView}}
+	Expect(bus.Dispatch(jonSnowCtx, createRole)).IsNil()
+	Expect(bus.Dispatch(jonSnowCtx, &cmd.SetUserCustomRole{UserID: aryaStark.ID, CustomRoleID: createRole.Result.ID})).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, countTrusted)
+	Expect(err).IsNil()
+	Expect(countTrusted.Result).Equals(1)
+
+	// so does an established account
+	_, err = trx.Execute("UPDATE users SET created_at = NOW() - INTERVAL '30 days' WHERE id = $1", sansaStark.ID)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, countTrusted)
+	Expect(err).IsNil()
+	Expect(countTrusted.Result).Equals(2)
+}
+
+func TestReportStorage_SystemReportHasNoReporter(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "My new post", Description: "with this description"}
+	err := bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	// held content is reported while its author is the current user
+	createReport := &cmd.CreateReport{ReportedType: enum.ReportTypePost, ReportedID: newPost.Result.ID, Reason: "Held for review", System: true}
+	err = bus.Dispatch(aryaStarkCtx, createReport)
+	Expect(err).IsNil()
+
+	getCase := &query.GetReportCaseByID{CaseID: createReport.ResultCaseID}
+	err = bus.Dispatch(demoTenantCtx, getCase)
+	Expect(err).IsNil()
+	Expect(getCase.Result.Reports).HasLen(1)
+	Expect(getCase.Result.Reports[0].Reporter).IsNil()
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbUserRestriction struct {
+	ID        int          `db:"id"`
+	Kind      string       `db:"kind"`
+	Reason    string       `db:"reason"`
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+}
+
+func (r *dbUserRestriction) toModel() *entity.UserRestriction {
+	restriction := &entity.UserRestriction{
+		ID:        r.ID,
+		Kind:      r.Kind,
+		Reason:    r.Reason,
+		CreatedAt: r.CreatedAt,
+	}
+	if r.ExpiresAt.Valid {
+		restriction.ExpiresAt = &r.ExpiresAt.Time
+	}
+	return restriction
+}
+
+func getUserRestrictions(trx *dbx.Trx, tenantID, userID int) ([]*entity.UserRestriction, error) {
+	var restrictions []*dbUserRestriction
+	err := trx.Select(&restrictions, `
+		SELECT id, kind, reason, created_at, expires_at
+		FROM user_restrictions
+		WHERE user_id = $1 AND tenant_id = $2
+		ORDER BY created_at DESC
+	`, userID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user restrictions")
+	}
+
+	result := make([]*entity.UserRestriction, len(restrictions))
+	for i, r := range restrictions {
+		result[i] = r.toModel()
+	}
+	return result, nil
+}
+
+func restrictUser(ctx context.Context, c *cmd.RestrictUser) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var expiresAt sql.NullTime
+		if !c.ExpiresAt.IsZero() {
+			expiresAt = sql.NullTime{Time: c.ExpiresAt, Valid: true}
+		}
+
+		// a new restriction replaces the active one of the same kind
+		_, err := trx.Execute(`
+			UPDATE user_restrictions
+			SET expires_at = NOW()
+			WHERE user_id = $1 AND tenant_id = $2 AND kind = $3 AND (expires_at IS NULL OR expires_at > NOW())
+		`, c.UserID, tenant.ID, c.Kind)
+		if err != nil {
+			return errors.Wrap(err, "failed to expire previous restriction")
+		}
+
+		err = trx.Scalar(&c.Result, `
+			INSERT INTO user_restrictions (tenant_id, user_id, kind, reason, created_at, expires_at, created_by)
+			VALUES ($1, $2, $3, $4, NOW(), $5, $6)
+			RETURNING id
+		`, tenant.ID, c.UserID, c.Kind, c.Reason, expiresAt, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to restrict user")
+		}
+		return nil
+	})
+}
+
+func liftRestriction(ctx context.Context, c *cmd.LiftRestriction) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var kind string
+		err := trx.Scalar(&kind, `
+			UPDATE user_restrictions
+			SET expires_at = NOW()
+			WHERE id = $1 AND user_id = $2 AND tenant_id = $3 AND (expires_at IS NULL OR expires_at > NOW())
+			RETURNING kind
+		`, c.RestrictionID, c.UserID, tenant.ID)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return nil
+			}
+			return errors.Wrap(err, "failed to lift restriction")
+		}
+
+		// lifting a shadow-ban early means it was not deserved, so whatever it hid becomes public.
+		// Content from a shadow-ban that ran its course stays hidden.
+		if kind == entity.RestrictionShadowBan {
+			for _, table := range []string{"posts", "comments"} {
+				if _, err := trx.Execute(
+					"UPDATE "+table+" SET shadow_hidden = FALSE WHERE user_id = $1 AND tenant_id = $2 AND shadow_hidden = TRUE",
+					c.UserID, tenant.ID,
+				); err != nil {
+					return errors.Wrap(err, "failed to reveal shadow-hidden %s", table)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func getUserSanctionHistory(ctx context.Context, q *query.GetUserSanctionHistory) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		history := &entity.SanctionHistory{}
+
+		err := trx.Scalar(&history.ActiveWarnings, `
+			SELECT COUNT(*) FROM user_warnings
+			WHERE user_id = $1 AND tenant_id = $2 AND (expires_at IS NULL OR expires_at > NOW())
+		`, q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count active warnings")
+		}
+
+		var mutes []*dbUserMute
+		err = trx.Select(&mutes, `
+			SELECT id, reason, created_at, expires_at FROM user_mutes m
+			WHERE user_id = $1 AND tenant_id = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM user_appeals a
+				WHERE a.tenant_id = m.tenant_id AND a.sanction_type = 'mute' AND a.sanction_id = m.id AND a.status IN ('lifted', 'reduced')
+			)
+			ORDER BY created_at DESC
+		`, q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user mutes")
+		}
+		history.MuteDates = make([]time.Time, len(mutes))
+		for i, m := range mutes {
+			history.MuteDates[i] = m.CreatedAt
+			if m.ExpiresAt.Valid && m.ExpiresAt.Time.After(time.Now()) {
+				if history.MutedUntil == nil || m.ExpiresAt.Time.After(*history.MutedUntil) {
+					history.MutedUntil = &m.ExpiresAt.Time
+				}
+			}
+		}
+
+		err = trx.Scalar(&history.BlockProposed, `
+			SELECT EXISTS(SELECT 1 FROM user_block_proposals WHERE user_id = $1 AND tenant_id = $2 AND resolved_at IS NULL)
+		`, q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to check block proposal")
+		}
+
+		q.Result = history
+		return nil
+	})
+}
+
+func proposeUserBlock(ctx context.Context, c *cmd.ProposeUserBlock) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var createdBy any
+		if user != nil {
+			createdBy = user.ID
+		}
+
+		rows, err := trx.Execute(`
+			INSERT INTO user_block_proposals (tenant_id, user_id, reason, created_at, created_by)
+			VALUES ($1, $2, $3, NOW(), $4)
+			ON CONFLICT (tenant_id, user_id) WHERE resolved_at IS NULL DO NOTHING
+		`, tenant.ID, c.UserID, c.Reason, createdBy)
+		if err != nil {
+			return errors.Wrap(err, "failed to propose user block")
+		}
+		c.Result = rows > 0
+		return nil
+	})
+}
+
+func dismissUserBlockProposal(ctx context.Context, c *cmd.DismissUserBlockProposal) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE user_block_proposals SET resolved_at = NOW()
+			WHERE tenant_id = $1 AND user_id = $2 AND resolved_at IS NULL
+		`, tenant.ID, c.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to dismiss user block proposal")
+		}
+		c.Result = rows > 0
+		return nil
+	})
+}
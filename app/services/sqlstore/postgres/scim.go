@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbSCIMToken struct {
+	Prefix     string       `db:"token_prefix"`
+	LastUsedAt dbx.NullTime `db:"last_used_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+func (t *dbSCIMToken) toModel() *entity.SCIMToken {
+	token := &entity.SCIMToken{
+		Prefix:    t.Prefix,
+		CreatedAt: t.CreatedAt,
+	}
+	if t.LastUsedAt.Valid {
+		token.LastUsedAt = &t.LastUsedAt.Time
+	}
+	return token
+}
+
+type dbSCIMUser struct {
+	User       *dbUser   `db:"user"`
+	ExternalID string    `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func getSCIMToken(ctx context.Context, q *query.GetSCIMToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		token := dbSCIMToken{}
+		err := trx.Get(&token, "SELECT token_prefix, last_used_at, created_at FROM tenant_scim_tokens WHERE tenant_id = $1", tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get SCIM token")
+		}
+		q.Result = token.toModel()
+		return nil
+	})
+}
+
+func getSCIMTokenByHash(ctx context.Context, q *query.GetSCIMTokenByHash) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		token := dbSCIMToken{}
+		err := trx.Get(&token, `
+			SELECT token_prefix, last_used_at, created_at
+			FROM tenant_scim_tokens
+			WHERE tenant_id = $1 AND token_hash = $2
+		`, tenant.ID, q.TokenHash)
+		if err != nil {
+			return errors.Wrap(err, "failed to get SCIM token")
+		}
+		q.Result = token.toModel()
+		return nil
+	})
+}
+
+func setSCIMToken(ctx context.Context, c *cmd.SetSCIMToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO tenant_scim_tokens (tenant_id, token_hash, token_prefix, last_used_at, created_at)
+			VALUES ($1, $2, $3, NULL, $4)
+			ON CONFLICT (tenant_id) DO UPDATE SET token_hash = $2, token_prefix = $3, last_used_at = NULL, created_at = $4
+		`, tenant.ID, c.TokenHash, c.Prefix, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to set SCIM token")
+		}
+		return nil
+	})
+}
+
+func deleteSCIMToken(ctx context.Context, c *cmd.DeleteSCIMToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if _, err := trx.Execute("DELETE FROM tenant_scim_tokens WHERE tenant_id = $1", tenant.ID); err != nil {
+			return errors.Wrap(err, "failed to delete SCIM token")
+		}
+		return nil
+	})
+}
+
+func touchSCIMToken(ctx context.Context, c *cmd.TouchSCIMToken) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if _, err := trx.Execute("UPDATE tenant_scim_tokens SET last_used_at = $2 WHERE tenant_id = $1", tenant.ID, time.Now()); err != nil {
+			return errors.Wrap(err, "failed to touch SCIM token")
+		}
+		return nil
+	})
+}
+
+func setSCIMExternalID(ctx context.Context, c *cmd.SetSCIMExternalID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if c.ExternalID == "" {
+			_, err := trx.Execute("DELETE FROM scim_users WHERE tenant_id = $1 AND user_id = $2", tenant.ID, c.UserID)
+			if err != nil {
+				return errors.Wrap(err, "failed to remove external id of user '%d'", c.UserID)
+			}
+			return nil
+		}
+
+		_, err := trx.Execute(`
+			INSERT INTO scim_users (tenant_id, user_id, external_id, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, user_id) DO UPDATE SET external_id = $3
+		`, tenant.ID, c.UserID, c.ExternalID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to set external id of user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
+
+func searchSCIMUsers(ctx context.Context, q *query.SearchSCIMUsers) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		conditions := []string{"u.tenant_id = $1", "u.status != $2"}
+		args := []any{tenant.ID, enum.UserDeleted}
+		where := func(condition string, arg any) {
+			args = append(args, arg)
+			conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+		}
+
+		if q.UserID != 0 {
+			where("u.id = $%d", q.UserID)
+		}
+		if q.Email != "" {
+			where("u.email = LOWER($%d)", strings.TrimSpace(q.Email))
+		}
+		if q.ExternalID != "" {
+			where("s.external_id = $%d", q.ExternalID)
+		}
+		if q.Name != "" {
+			where("u.name = $%d", q.Name)
+		}
+		if q.Role != 0 {
+			where("u.role = $%d", q.Role)
+		}
+		if q.VisualRole != enum.VisualRoleNone {
+			where("u.visual_role = $%d", q.VisualRole)
+		}
+
+		from := `
+			FROM users u
+			LEFT JOIN scim_users s ON s.tenant_id = u.tenant_id AND s.user_id = u.id
+			WHERE ` + strings.Join(conditions, " AND ")
+
+		if err := trx.Scalar(&q.TotalResults, "SELECT COUNT(*)"+from, args...); err != nil {
+			return errors.Wrap(err, "failed to count SCIM users")
+		}
+
+		var users []*dbSCIMUser
+		err := trx.Select(&users, `
+			SELECT u.id AS user_id, u.name AS user_name, u.email AS user_email, u.tenant_id AS user_tenant_id,
+				u.role AS user_role, u.status AS user_status, u.avatar_type AS user_avatar_type,
+				u.avatar_bkey AS user_avatar_bkey, u.visual_role AS user_visual_role,
+				COALESCE(s.external_id, '') AS external_id, u.created_at
+			`+from+fmt.Sprintf(" ORDER BY u.id LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2),
+			append(args, q.Limit, q.Offset)...,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to search SCIM users")
+		}
+
+		q.Result = make([]*entity.SCIMUser, len(users))
+		for i, u := range users {
+			q.Result[i] = &entity.SCIMUser{
+				User:       u.User.toModel(ctx),
+				ExternalID: u.ExternalID,
+				CreatedAt:  u.CreatedAt,
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,125 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestSCIMStorage_Token(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &query.GetSCIMToken{})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.SetSCIMToken{TokenHash: "hash-1", Prefix: "scim_1111"})
+	Expect(err).IsNil()
+
+	getToken := &query.GetSCIMTokenByHash{TokenHash: "hash-1"}
+	err = bus.Dispatch(demoTenantCtx, getToken)
+	Expect(err).IsNil()
+	Expect(getToken.Result.Prefix).Equals("scim_1111")
+	Expect(getToken.Result.LastUsedAt).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.TouchSCIMToken{})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getToken)
+	Expect(err).IsNil()
+	Expect(getToken.Result.LastUsedAt).IsNotNil()
+
+	// tokens are looked up in the tenant of the request only
+	err = bus.Dispatch(avengersTenantCtx, &query.GetSCIMTokenByHash{TokenHash: "hash-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// a new token replaces the previous one
+	err = bus.Dispatch(demoTenantCtx, &cmd.SetSCIMToken{TokenHash: "hash-2", Prefix: "scim_2222"})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &query.GetSCIMTokenByHash{TokenHash: "hash-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	getCurrent := &query.GetSCIMToken{}
+	err = bus.Dispatch(demoTenantCtx, getCurrent)
+	Expect(err).IsNil()
+	Expect(getCurrent.Result.Prefix).Equals("scim_2222")
+	Expect(getCurrent.Result.LastUsedAt).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeleteSCIMToken{})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, &query.GetSCIMTokenByHash{TokenHash: "hash-2"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestSCIMStorage_SearchUsers(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(demoTenantCtx, &cmd.SetSCIMExternalID{UserID: aryaStark.ID, ExternalID: "okta-arya"})
+	Expect(err).IsNil()
+
+	search := &query.SearchSCIMUsers{ExternalID: "okta-arya", Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.TotalResults).Equals(1)
+	Expect(search.Result).HasLen(1)
+	Expect(search.Result[0].User.ID).Equals(aryaStark.ID)
+	Expect(search.Result[0].ExternalID).Equals("okta-arya")
+
+	search = &query.SearchSCIMUsers{Email: "ARYA.STARK@got.com", Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(1)
+	Expect(search.Result[0].ExternalID).Equals("okta-arya")
+
+	search = &query.SearchSCIMUsers{UserID: sansaStark.ID, Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(1)
+	Expect(search.Result[0].ExternalID).Equals("")
+
+	// users are searched in the tenant of the request only
+	search = &query.SearchSCIMUsers{ExternalID: "okta-arya", Limit: 10}
+	err = bus.Dispatch(avengersTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.TotalResults).Equals(0)
+	Expect(search.Result).HasLen(0)
+
+	search = &query.SearchSCIMUsers{UserID: aryaStark.ID, Limit: 10}
+	err = bus.Dispatch(avengersTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(0)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.SetSCIMExternalID{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+
+	search = &query.SearchSCIMUsers{ExternalID: "okta-arya", Limit: 10}
+	err = bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(0)
+}
+
+func TestSCIMStorage_SearchUsers_Paging(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	search := &query.SearchSCIMUsers{Limit: 1}
+	err := bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(1)
+	Expect(search.TotalResults > 1).IsTrue()
+
+	firstID := search.Result[0].User.ID
+	search = &query.SearchSCIMUsers{Limit: 1, Offset: 1}
+	err = bus.Dispatch(demoTenantCtx, search)
+	Expect(err).IsNil()
+	Expect(search.Result).HasLen(1)
+	Expect(search.Result[0].User.ID > firstID).IsTrue()
+}
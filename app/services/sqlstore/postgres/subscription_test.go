@@ -352,3 +352,117 @@ func TestSubscription_EmailSupressed(t *testing.T) {
 	Expect(q.Result).HasLen(1)
 	Expect(q.Result[0].ID).Equals(jonSnow.ID)
 }
+
+func TestSubscription_MutedPost(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	newPost := &cmd.AddNewPost{Title: "Post #1", Description: "Description #1"}
+	err := bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.MuteSubscriber{Post: newPost.Result, User: jonSnow})
+	Expect(err).IsNil()
+
+	newPostSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	newCommentSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewComment}
+	mentioned := &query.GetUsersToNotify{Event: enum.NotificationEventMention, Channel: enum.NotificationChannelWeb, UserIDs: []int{jonSnow.ID}, PostID: newPost.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, newPostSubscribers, newCommentSubscribers, mentioned)
+	Expect(err).IsNil()
+
+	Expect(newPostSubscribers.Result).HasLen(0)
+	Expect(newCommentSubscribers.Result).HasLen(0)
+	Expect(mentioned.Result).HasLen(0)
+
+	subscribed := &query.UserSubscribedTo{PostID: newPost.Result.ID}
+	err = bus.Dispatch(jonSnowCtx, subscribed)
+	Expect(err).IsNil()
+	Expect(subscribed.Result).IsFalse()
+	Expect(subscribed.Muted).IsTrue()
+}
+
+func TestSubscription_FollowedTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	tag := &cmd.AddNewTag{Name: "Bug", Color: "FF0000", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, tag)
+	Expect(err).IsNil()
+
+	tagged := &cmd.AddNewPost{Title: "Post #1", Description: "Description #1"}
+	untagged := &cmd.AddNewPost{Title: "Post #2", Description: "Description #2"}
+	err = bus.Dispatch(sansaStarkCtx, tagged, untagged)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: tag.Result, Post: tagged.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SetTagPreference{Tag: tag.Result, User: aryaStark, Preference: enum.TagPreferenceFollow})
+	Expect(err).IsNil()
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetTagPreference{Tag: tag.Result, User: jonSnow, Preference: enum.TagPreferenceFollow})
+	Expect(err).IsNil()
+
+	// followers are treated as subscribers of the tagged post
+	newCommentSubscribers := &query.GetActiveSubscribers{Number: tagged.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewComment}
+	err = bus.Dispatch(aryaStarkCtx, newCommentSubscribers)
+	Expect(err).IsNil()
+	Expect(newCommentSubscribers.Result).HasLen(2)
+	Expect(newCommentSubscribers.Result[0].ID).Equals(jonSnow.ID)
+	Expect(newCommentSubscribers.Result[1].ID).Equals(aryaStark.ID)
+
+	// and only hear about new posts in the tags they follow
+	taggedSubscribers := &query.GetActiveSubscribers{Number: tagged.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	untaggedSubscribers := &query.GetActiveSubscribers{Number: untagged.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	err = bus.Dispatch(aryaStarkCtx, taggedSubscribers, untaggedSubscribers)
+	Expect(err).IsNil()
+	Expect(taggedSubscribers.Result).HasLen(2)
+	Expect(untaggedSubscribers.Result).HasLen(0)
+
+	preferences := &query.GetTagPreferences{}
+	err = bus.Dispatch(aryaStarkCtx, preferences)
+	Expect(err).IsNil()
+	Expect(preferences.Result).HasLen(1)
+	Expect(preferences.Result[0].TagID).Equals(tag.Result.ID)
+	Expect(preferences.Result[0].Preference).Equals(enum.TagPreferenceFollow)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SetTagPreference{Tag: tag.Result, User: aryaStark})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, preferences)
+	Expect(err).IsNil()
+	Expect(preferences.Result).HasLen(0)
+}
+
+func TestSubscription_MutedTag(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	tag := &cmd.AddNewTag{Name: "Off Topic", Color: "000000", IsPublic: true}
+	err := bus.Dispatch(jonSnowCtx, tag)
+	Expect(err).IsNil()
+
+	newPost := &cmd.AddNewPost{Title: "Post #1", Description: "Description #1"}
+	err = bus.Dispatch(aryaStarkCtx, newPost)
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.AssignTag{Tag: tag.Result, Post: newPost.Result})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(jonSnowCtx, &cmd.SetTagPreference{Tag: tag.Result, User: jonSnow, Preference: enum.TagPreferenceMute})
+	Expect(err).IsNil()
+
+	newPostSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewPost}
+	newCommentSubscribers := &query.GetActiveSubscribers{Number: newPost.Result.Number, Channel: enum.NotificationChannelWeb, Event: enum.NotificationEventNewComment}
+	err = bus.Dispatch(aryaStarkCtx, newPostSubscribers, newCommentSubscribers)
+	Expect(err).IsNil()
+	Expect(newPostSubscribers.Result).HasLen(0)
+	Expect(newCommentSubscribers.Result).HasLen(0)
+
+	// subscribing to the post itself wins over the muted tag
+	err = bus.Dispatch(jonSnowCtx, &cmd.AddSubscriber{Post: newPost.Result, User: jonSnow})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, newCommentSubscribers)
+	Expect(err).IsNil()
+	Expect(newCommentSubscribers.Result).HasLen(1)
+	Expect(newCommentSubscribers.Result[0].ID).Equals(jonSnow.ID)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
 )
 
 type dbTenant struct {
@@ -121,6 +122,9 @@ func updateGeneralSettings(ctx context.Context, c *cmd.UpdateContentSettings) er
 				CommentingGloballyDisabled: false,
 				ReportingGloballyDisabled:  false,
 				ReportLimitsPerDay:         10,
+				ReportAutoHideThreshold:    0,
+				SanctionPolicy:             entity.DefaultSanctionPolicy(),
+				TwoFactorRequiredFor:       []string{},
 			}
 		}
 
@@ -189,6 +193,33 @@ func updateTenantEmailAuthAllowedSettings(ctx context.Context, c *cmd.UpdateTena
 	})
 }
 
+func updateTenantTwoFactorPolicy(ctx context.Context, c *cmd.UpdateTenantTwoFactorPolicy) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		roles := c.Roles
+		if roles == nil {
+			roles = []string{}
+		}
+
+		rolesJSON, err := json.Marshal(roles)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal two-factor roles")
+		}
+
+		_, err = trx.Execute(
+			"UPDATE tenants SET general_settings = jsonb_set(general_settings, '{twoFactorRequiredFor}', $1::jsonb) WHERE id = $2",
+			string(rolesJSON), tenant.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update tenant two-factor policy")
+		}
+
+		if tenant.GeneralSettings != nil {
+			tenant.GeneralSettings.TwoFactorRequiredFor = roles
+		}
+		return nil
+	})
+}
+
 func updateTenantSettings(ctx context.Context, c *cmd.UpdateTenantSettings) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		if c.Logo.Remove {
@@ -362,6 +393,30 @@ func getTenantByDomain(ctx context.Context, q *query.GetTenantByDomain) error {
 	})
 }
 
+// getTenantsByID loads the tenants of rows that were queried across every tenant, like the
+// background jobs do, in a single query. The result is keyed by tenant id
+func getTenantsByID(trx *dbx.Trx, tenantIDs []int) (map[int]*entity.Tenant, error) {
+	tenants := make(map[int]*entity.Tenant)
+	if len(tenantIDs) == 0 {
+		return tenants, nil
+	}
+
+	var rows []*dbTenant
+	err := trx.Select(&rows, `
+		SELECT id, name, subdomain, cname, invitation, locale, welcome_message, status, is_private, logo_bkey, custom_css, is_email_auth_allowed, profanity_words, general_settings, message_banner
+		FROM tenants
+		WHERE id = ANY($1)
+	`, pq.Array(tenantIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tenants by id")
+	}
+
+	for _, row := range rows {
+		tenants[row.ID] = row.toModel()
+	}
+	return tenants, nil
+}
+
 func getTenantProfanityWords(ctx context.Context, q *query.GetTenantProfanityWords) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		var profanityWords string
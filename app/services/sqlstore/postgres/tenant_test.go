@@ -375,6 +375,7 @@ func TestTenantStorage_Save_Get_ListOAuthConfig(t *testing.T) {
 			BlobKey: "",
 		},
 		Provider:          "_TEST2222", //this has to be ignored
+		Type:              enum.OAuthConfigTypeOIDC,
 		DisplayName:       "New My Provider",
 		ClientID:          "New 823187ahjjfdha8fds7yfdashfjkdsa",
 		ClientSecret:      "New jijads78d76cn347768x3t4668q275@ˆ&Tnycasdgsacuyhij",
@@ -382,6 +383,7 @@ func TestTenantStorage_Save_Get_ListOAuthConfig(t *testing.T) {
 		TokenURL:          "New http://provider/oauth/token",
 		Scope:             "New profile email",
 		ProfileURL:        "New http://provider/profile/me",
+		IssuerURL:         "https://provider",
 		JSONUserIDPath:    "New user.id",
 		JSONUserNamePath:  "New user.name",
 		JSONUserEmailPath: "New user.email",
@@ -404,6 +406,8 @@ func TestTenantStorage_Save_Get_ListOAuthConfig(t *testing.T) {
 	Expect(customConfigs.Result[0].Scope).Equals("New profile email")
 	Expect(customConfigs.Result[0].Status).Equals(0)
 	Expect(customConfigs.Result[0].ProfileURL).Equals("New http://provider/profile/me")
+	Expect(customConfigs.Result[0].Type).Equals(enum.OAuthConfigTypeOIDC)
+	Expect(customConfigs.Result[0].IssuerURL).Equals("https://provider")
 	Expect(customConfigs.Result[0].JSONUserIDPath).Equals("New user.id")
 	Expect(customConfigs.Result[0].JSONUserNamePath).Equals("New user.name")
 	Expect(customConfigs.Result[0].JSONUserEmailPath).Equals("New user.email")
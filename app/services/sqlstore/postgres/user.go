@@ -123,11 +123,18 @@ func countUsers(ctx context.Context, q *query.CountUsers) error {
 func blockUser(ctx context.Context, c *cmd.BlockUser) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		if _, err := trx.Execute(
-			"UPDATE users SET status = $3 WHERE id = $1 AND tenant_id = $2",
+			"UPDATE users SET status = $3, blocked_at = NOW() WHERE id = $1 AND tenant_id = $2",
 			c.UserID, tenant.ID, enum.UserBlocked,
 		); err != nil {
 			return errors.Wrap(err, "failed to block user")
 		}
+
+		if _, err := trx.Execute(
+			"UPDATE user_block_proposals SET resolved_at = NOW() WHERE user_id = $1 AND tenant_id = $2 AND resolved_at IS NULL",
+			c.UserID, tenant.ID,
+		); err != nil {
+			return errors.Wrap(err, "failed to resolve block proposals")
+		}
 		return nil
 	})
 }
@@ -164,6 +171,16 @@ func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 			{"post_votes", "user_id"},
 			{"post_subscribers", "user_id"},
 			{"email_verifications", "user_id"},
+			{"user_api_tokens", "user_id"},
+			{"oauth_authorization_codes", "user_id"},
+			{"oauth_refresh_tokens", "user_id"},
+			{"user_merge_requests", "user_id"},
+			{"user_merge_requests", "owner_id"},
+			{"user_recovery_codes", "user_id"},
+			{"user_two_factor", "user_id"},
+			{"user_passkeys", "user_id"},
+			{"user_custom_roles", "user_id"},
+			{"scim_users", "user_id"},
 		}
 
 		for _, table := range tables {
@@ -175,6 +192,14 @@ func deleteCurrentUser(ctx context.Context, c *cmd.DeleteCurrentUser) error {
 			}
 		}
 
+		// data exports are expired instead, their blobs are deleted along with them by the purge job
+		if _, err := trx.Execute(
+			"UPDATE user_data_exports SET expires_at = $3 WHERE user_id = $1 AND tenant_id = $2",
+			user.ID, tenant.ID, time.Now(),
+		); err != nil {
+			return errors.Wrap(err, "failed to expire current user's data exports")
+		}
+
 		return nil
 	})
 }
@@ -237,6 +262,8 @@ func userSubscribedTo(ctx context.Context, q *query.UserSubscribedTo) error {
 			return nil
 		}
 
+		q.Muted = status == enum.SubscriberMuted
+
 		q.Result = false
 		return nil
 	})
@@ -357,6 +384,31 @@ func registerUserProvider(ctx context.Context, c *cmd.RegisterUserProvider) erro
 	})
 }
 
+func unregisterUserProvider(ctx context.Context, c *cmd.UnregisterUserProvider) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		cmd := "DELETE FROM user_providers WHERE tenant_id = $1 AND user_id = $2 AND provider = $3"
+		_, err := trx.Execute(cmd, tenant.ID, c.UserID, c.ProviderName)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove provider '%s' from user with id '%d'", c.ProviderName, c.UserID)
+		}
+		return nil
+	})
+}
+
+func moveUserProvider(ctx context.Context, c *cmd.MoveUserProvider) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		cmd := "UPDATE user_providers SET user_id = $5, created_at = $6 WHERE tenant_id = $1 AND user_id = $2 AND provider = $3 AND provider_uid = $4"
+		rows, err := trx.Execute(cmd, tenant.ID, c.FromUserID, c.ProviderName, c.ProviderUID, c.ToUserID, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to move provider '%s:%s' from user with id '%d' to '%d'", c.ProviderName, c.ProviderUID, c.FromUserID, c.ToUserID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
 func updateCurrentUser(ctx context.Context, c *cmd.UpdateCurrentUser) error {
 	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
 		blobKey := ""
@@ -686,6 +738,18 @@ func getUserProfileStanding(ctx context.Context, q *query.GetUserProfileStanding
 			}
 		}
 
+		q.Result.Restrictions, err = getUserRestrictions(trx, tenant.ID, q.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = trx.Scalar(&q.Result.BlockProposed, `
+			SELECT EXISTS(SELECT 1 FROM user_block_proposals WHERE user_id = $1 AND tenant_id = $2 AND resolved_at IS NULL)
+		`, q.UserID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to check block proposal")
+		}
+
 		return nil
 	})
 }
@@ -865,11 +929,11 @@ func muteUser(ctx context.Context, c *cmd.MuteUser) error {
 		}
 
 		// Then insert the new mute
-		_, err = trx.Execute(`
+		return trx.Scalar(&c.Result, `
 			INSERT INTO user_mutes (user_id, tenant_id, reason, created_at, expires_at, created_by)
 			VALUES ($1, $2, $3, NOW(), $4, $5)
+			RETURNING id
 		`, c.UserID, tenant.ID, c.Reason, c.ExpiresAt, user.ID)
-		return err
 	})
 }
 
@@ -886,11 +950,11 @@ func warnUser(ctx context.Context, c *cmd.WarnUser) error {
 			expiresAt.Time = c.ExpiresAt
 		}
 
-		_, err := trx.Execute(`
+		return trx.Scalar(&c.Result, `
 			INSERT INTO user_warnings (user_id, tenant_id, reason, created_at, expires_at, created_by)
 			VALUES ($1, $2, $3, NOW(), $4, $5)
+			RETURNING id
 		`, c.UserID, tenant.ID, c.Reason, expiresAt, user.ID)
-		return err
 	})
 }
 
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/lib/pq"
+)
+
+type dbUserDataExport struct {
+	ID        int            `db:"id"`
+	TenantID  int            `db:"tenant_id"`
+	UserID    int            `db:"user_id"`
+	BlobKey   dbx.NullString `db:"blob_key"`
+	ExpiresAt time.Time      `db:"expires_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (e *dbUserDataExport) toModel() *entity.UserDataExport {
+	return &entity.UserDataExport{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		BlobKey:   e.BlobKey.String,
+		ExpiresAt: e.ExpiresAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func createUserDataExport(ctx context.Context, c *cmd.CreateUserDataExport) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		export := dbUserDataExport{}
+		err := trx.Get(&export, `
+			INSERT INTO user_data_exports (tenant_id, user_id, blob_key, expires_at, created_at)
+			VALUES ($1, $2, NULL, $3, $4)
+			RETURNING id, tenant_id, user_id, blob_key, expires_at, created_at
+		`, tenant.ID, user.ID, c.ExpiresAt, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to create data export of user '%d'", user.ID)
+		}
+		c.Result = export.toModel()
+		return nil
+	})
+}
+
+func completeUserDataExport(ctx context.Context, c *cmd.CompleteUserDataExport) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE user_data_exports SET blob_key = $3, expires_at = $4 WHERE id = $1 AND tenant_id = $2",
+			c.ID, tenant.ID, c.BlobKey, c.ExpiresAt,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to complete data export '%d'", c.ID)
+		}
+		return nil
+	})
+}
+
+func deleteUserDataExports(ctx context.Context, c *cmd.DeleteUserDataExports) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		if len(c.IDs) == 0 {
+			return nil
+		}
+		if _, err := trx.Execute("DELETE FROM user_data_exports WHERE id = ANY($1)", pq.Array(c.IDs)); err != nil {
+			return errors.Wrap(err, "failed to delete data exports")
+		}
+		return nil
+	})
+}
+
+func getLatestUserDataExport(ctx context.Context, q *query.GetLatestUserDataExport) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		export := dbUserDataExport{}
+		err := trx.Get(&export, `
+			SELECT id, tenant_id, user_id, blob_key, expires_at, created_at
+			FROM user_data_exports
+			WHERE tenant_id = $1 AND user_id = $2
+			ORDER BY created_at DESC
+			LIMIT 1
+		`, tenant.ID, user.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get latest data export of user '%d'", user.ID)
+		}
+		q.Result = export.toModel()
+		return nil
+	})
+}
+
+func getUserDataExportByID(ctx context.Context, q *query.GetUserDataExportByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		export := dbUserDataExport{}
+		err := trx.Get(&export, `
+			SELECT id, tenant_id, user_id, blob_key, expires_at, created_at
+			FROM user_data_exports
+			WHERE id = $1 AND tenant_id = $2
+		`, q.ID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get data export '%d'", q.ID)
+		}
+		q.Result = export.toModel()
+		return nil
+	})
+}
+
+func getExpiredUserDataExports(ctx context.Context, q *query.GetExpiredUserDataExports) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		var exports []*dbUserDataExport
+		err := trx.Select(&exports, `
+			SELECT id, tenant_id, user_id, blob_key, expires_at, created_at
+			FROM user_data_exports
+			WHERE expires_at <= NOW()
+			ORDER BY tenant_id, id
+		`)
+		if err != nil {
+			return errors.Wrap(err, "failed to get expired data exports")
+		}
+
+		tenantIDs := make([]int, len(exports))
+		for i, e := range exports {
+			tenantIDs[i] = e.TenantID
+		}
+		tenants, err := getTenantsByID(trx, tenantIDs)
+		if err != nil {
+			return err
+		}
+
+		q.Result = make([]*entity.UserDataExport, len(exports))
+		for i, e := range exports {
+			q.Result[i] = e.toModel()
+			q.Result[i].Tenant = tenants[e.TenantID]
+		}
+		return nil
+	})
+}
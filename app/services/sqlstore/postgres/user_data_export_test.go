@@ -0,0 +1,109 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestUserDataExportStorage_CreateAndComplete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &query.GetLatestUserDataExport{})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	createExport := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(time.Hour)}
+	err = bus.Dispatch(aryaStarkCtx, createExport)
+	Expect(err).IsNil()
+	Expect(createExport.Result.UserID).Equals(aryaStark.ID)
+	Expect(createExport.Result.BlobKey).Equals("")
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CompleteUserDataExport{
+		ID:        createExport.Result.ID,
+		BlobKey:   "exports/arya.zip",
+		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	})
+	Expect(err).IsNil()
+
+	getLatest := &query.GetLatestUserDataExport{}
+	err = bus.Dispatch(aryaStarkCtx, getLatest)
+	Expect(err).IsNil()
+	Expect(getLatest.Result.ID).Equals(createExport.Result.ID)
+	Expect(getLatest.Result.BlobKey).Equals("exports/arya.zip")
+	Expect(getLatest.Result.ExpiresAt.After(time.Now().Add(6 * 24 * time.Hour))).IsTrue()
+
+	// exports are only returned to the user who requested them
+	err = bus.Dispatch(sansaStarkCtx, &query.GetLatestUserDataExport{})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestUserDataExportStorage_TenantIsolation(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	createExport := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(time.Hour)}
+	err := bus.Dispatch(aryaStarkCtx, createExport)
+	Expect(err).IsNil()
+
+	getExport := &query.GetUserDataExportByID{ID: createExport.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, getExport)
+	Expect(err).IsNil()
+	Expect(getExport.Result.UserID).Equals(aryaStark.ID)
+
+	err = bus.Dispatch(tonyStarkCtx, &query.GetUserDataExportByID{ID: createExport.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// an export of another tenant can't be completed
+	err = bus.Dispatch(tonyStarkCtx, &cmd.CompleteUserDataExport{ID: createExport.Result.ID, BlobKey: "exports/tony.zip", ExpiresAt: time.Now().Add(time.Hour)})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, getExport)
+	Expect(err).IsNil()
+	Expect(getExport.Result.BlobKey).Equals("")
+}
+
+func TestUserDataExportStorage_Expired(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	expired := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(-time.Hour)}
+	err := bus.Dispatch(aryaStarkCtx, expired)
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CompleteUserDataExport{ID: expired.Result.ID, BlobKey: "exports/arya.zip", ExpiresAt: time.Now().Add(-time.Hour)})
+	Expect(err).IsNil()
+
+	expiredOfOtherTenant := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(-time.Hour)}
+	err = bus.Dispatch(tonyStarkCtx, expiredOfOtherTenant)
+	Expect(err).IsNil()
+
+	active := &cmd.CreateUserDataExport{ExpiresAt: time.Now().Add(time.Hour)}
+	err = bus.Dispatch(sansaStarkCtx, active)
+	Expect(err).IsNil()
+
+	getExpired := &query.GetExpiredUserDataExports{}
+	err = bus.Dispatch(demoTenantCtx, getExpired)
+	Expect(err).IsNil()
+	Expect(getExpired.Result).HasLen(2)
+	Expect(getExpired.Result[0].ID).Equals(expired.Result.ID)
+	Expect(getExpired.Result[0].BlobKey).Equals("exports/arya.zip")
+	Expect(getExpired.Result[0].Tenant.ID).Equals(demoTenant.ID)
+	Expect(getExpired.Result[1].ID).Equals(expiredOfOtherTenant.Result.ID)
+	Expect(getExpired.Result[1].Tenant.ID).Equals(avengersTenant.ID)
+
+	err = bus.Dispatch(demoTenantCtx, &cmd.DeleteUserDataExports{IDs: []int{expired.Result.ID, expiredOfOtherTenant.Result.ID}})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(demoTenantCtx, getExpired)
+	Expect(err).IsNil()
+	Expect(getExpired.Result).HasLen(0)
+
+	err = bus.Dispatch(sansaStarkCtx, &query.GetUserDataExportByID{ID: active.Result.ID})
+	Expect(err).IsNil()
+}
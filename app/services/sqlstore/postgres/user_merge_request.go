@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbUserMergeRequest struct {
+	ID              int            `db:"id"`
+	UserID          int            `db:"user_id"`
+	UserName        string         `db:"user_name"`
+	UserEmail       string         `db:"user_email"`
+	UserAvatarType  int64          `db:"user_avatar_type"`
+	UserAvatarBkey  string         `db:"user_avatar_bkey"`
+	OwnerID         int            `db:"owner_id"`
+	OwnerName       string         `db:"owner_name"`
+	OwnerEmail      string         `db:"owner_email"`
+	OwnerAvatarType int64          `db:"owner_avatar_type"`
+	OwnerAvatarBkey string         `db:"owner_avatar_bkey"`
+	Provider        string         `db:"provider"`
+	ProviderUID     string         `db:"provider_uid"`
+	Status          string         `db:"status"`
+	CreatedAt       time.Time      `db:"created_at"`
+	DecidedAt       sql.NullTime   `db:"decided_at"`
+	DecidedByID     sql.NullInt64  `db:"decided_by_id"`
+	DecidedByName   sql.NullString `db:"decided_by_name"`
+}
+
+const selectUserMergeRequestSQL = `
+			SELECT
+				r.id, r.user_id, u.name as user_name, u.email as user_email, u.avatar_type as user_avatar_type, u.avatar_bkey as user_avatar_bkey,
+				r.owner_id, o.name as owner_name, o.email as owner_email, o.avatar_type as owner_avatar_type, o.avatar_bkey as owner_avatar_bkey,
+				r.provider, r.provider_uid, r.status, r.created_at, r.decided_at,
+				r.decided_by as decided_by_id, du.name as decided_by_name
+			FROM user_merge_requests r
+			INNER JOIN users u ON u.id = r.user_id AND u.tenant_id = r.tenant_id
+			INNER JOIN users o ON o.id = r.owner_id AND o.tenant_id = r.tenant_id
+			LEFT JOIN users du ON du.id = r.decided_by AND du.tenant_id = r.tenant_id`
+
+func (r *dbUserMergeRequest) toModel(ctx context.Context) *entity.UserMergeRequest {
+	request := &entity.UserMergeRequest{
+		ID: r.ID,
+		User: &entity.User{
+			ID:        r.UserID,
+			Name:      r.UserName,
+			Email:     r.UserEmail,
+			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(r.UserAvatarType), r.UserID, r.UserName, r.UserAvatarBkey),
+		},
+		Owner: &entity.User{
+			ID:        r.OwnerID,
+			Name:      r.OwnerName,
+			Email:     r.OwnerEmail,
+			AvatarURL: buildAvatarURL(ctx, enum.AvatarType(r.OwnerAvatarType), r.OwnerID, r.OwnerName, r.OwnerAvatarBkey),
+		},
+		Provider:    r.Provider,
+		ProviderUID: r.ProviderUID,
+		CreatedAt:   r.CreatedAt,
+	}
+	_ = request.Status.UnmarshalText([]byte(r.Status))
+
+	if r.DecidedAt.Valid {
+		request.DecidedAt = &r.DecidedAt.Time
+	}
+	if r.DecidedByID.Valid {
+		request.DecidedBy = &entity.User{
+			ID:   int(r.DecidedByID.Int64),
+			Name: r.DecidedByName.String,
+		}
+	}
+	return request
+}
+
+func createUserMergeRequest(ctx context.Context, c *cmd.CreateUserMergeRequest) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		err := trx.Scalar(&c.Result, `
+			SELECT id FROM user_merge_requests
+			WHERE tenant_id = $1 AND user_id = $2 AND provider = $3 AND provider_uid = $4 AND status = 'pending'
+		`, tenant.ID, user.ID, c.Provider, c.ProviderUID)
+		if err == nil {
+			return nil
+		}
+		if errors.Cause(err) != app.ErrNotFound {
+			return errors.Wrap(err, "failed to check existing merge requests")
+		}
+
+		err = trx.Scalar(&c.Result, `
+			INSERT INTO user_merge_requests (tenant_id, user_id, owner_id, provider, provider_uid, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, 'pending', NOW())
+			RETURNING id
+		`, tenant.ID, user.ID, c.OwnerID, c.Provider, c.ProviderUID)
+		if err != nil {
+			return errors.Wrap(err, "failed to create merge request")
+		}
+		return nil
+	})
+}
+
+func decideUserMergeRequest(ctx context.Context, c *cmd.DecideUserMergeRequest) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			UPDATE user_merge_requests
+			SET status = $1, decided_at = NOW(), decided_by = $2
+			WHERE id = $3 AND tenant_id = $4 AND status = 'pending'
+		`, c.Status.String(), user.ID, c.RequestID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to decide merge request")
+		}
+		return nil
+	})
+}
+
+func getUserMergeRequestByID(ctx context.Context, q *query.GetUserMergeRequestByID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		request := dbUserMergeRequest{}
+		err := trx.Get(&request, selectUserMergeRequestSQL+`
+			WHERE r.id = $1 AND r.tenant_id = $2
+		`, q.RequestID, tenant.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get merge request with id '%d'", q.RequestID)
+		}
+		q.Result = request.toModel(ctx)
+		return nil
+	})
+}
+
+func listUserMergeRequests(ctx context.Context, q *query.ListUserMergeRequests) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var requests []*dbUserMergeRequest
+		err := trx.Select(&requests, selectUserMergeRequestSQL+`
+			WHERE r.tenant_id = $1 AND ($2 = '' OR r.status = $2)
+			ORDER BY r.created_at ASC
+			LIMIT 100
+		`, tenant.ID, q.Status.String())
+		if err != nil {
+			return errors.Wrap(err, "failed to list merge requests")
+		}
+
+		q.Result = make([]*entity.UserMergeRequest, len(requests))
+		for i, r := range requests {
+			q.Result[i] = r.toModel(ctx)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbUserPasskey struct {
+	ID           int          `db:"id"`
+	UserID       int          `db:"user_id"`
+	Name         string       `db:"name"`
+	CredentialID string       `db:"credential_id"`
+	Credential   string       `db:"credential"`
+	CreatedAt    time.Time    `db:"created_at"`
+	LastUsedAt   dbx.NullTime `db:"last_used_at"`
+}
+
+func (p *dbUserPasskey) toModel() *entity.UserPasskey {
+	passkey := &entity.UserPasskey{
+		ID:           p.ID,
+		UserID:       p.UserID,
+		Name:         p.Name,
+		CredentialID: p.CredentialID,
+		Credential:   []byte(p.Credential),
+		CreatedAt:    p.CreatedAt,
+	}
+	if p.LastUsedAt.Valid {
+		passkey.LastUsedAt = &p.LastUsedAt.Time
+	}
+	return passkey
+}
+
+func listUserPasskeys(ctx context.Context, q *query.ListUserPasskeys) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var passkeys []*dbUserPasskey
+		err := trx.Select(&passkeys, `
+			SELECT id, user_id, name, credential_id, credential, created_at, last_used_at
+			FROM user_passkeys
+			WHERE tenant_id = $1 AND user_id = $2
+			ORDER BY created_at
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list passkeys of user '%d'", q.UserID)
+		}
+
+		q.Result = make([]*entity.UserPasskey, len(passkeys))
+		for i, passkey := range passkeys {
+			q.Result[i] = passkey.toModel()
+		}
+		return nil
+	})
+}
+
+func getUserPasskeyByCredentialID(ctx context.Context, q *query.GetUserPasskeyByCredentialID) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		passkey := dbUserPasskey{}
+		err := trx.Get(&passkey, `
+			SELECT id, user_id, name, credential_id, credential, created_at, last_used_at
+			FROM user_passkeys
+			WHERE tenant_id = $1 AND credential_id = $2
+		`, tenant.ID, q.CredentialID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get passkey by credential id")
+		}
+		q.Result = passkey.toModel()
+		return nil
+	})
+}
+
+func addUserPasskey(ctx context.Context, c *cmd.AddUserPasskey) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		now := time.Now()
+		var id int
+		err := trx.Scalar(&id, `
+			INSERT INTO user_passkeys (tenant_id, user_id, name, credential_id, credential, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, tenant.ID, c.UserID, c.Name, c.CredentialID, string(c.Credential), now)
+		if err != nil {
+			return errors.Wrap(err, "failed to add passkey for user '%d'", c.UserID)
+		}
+
+		c.Result = &entity.UserPasskey{
+			ID:           id,
+			UserID:       c.UserID,
+			Name:         c.Name,
+			CredentialID: c.CredentialID,
+			Credential:   c.Credential,
+			CreatedAt:    now,
+		}
+		return nil
+	})
+}
+
+func updateUserPasskeyUsage(ctx context.Context, c *cmd.UpdateUserPasskeyUsage) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE user_passkeys SET credential = $3, last_used_at = $4 WHERE tenant_id = $1 AND id = $2",
+			tenant.ID, c.ID, string(c.Credential), time.Now(),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to update usage of passkey '%d'", c.ID)
+		}
+		return nil
+	})
+}
+
+func deleteUserPasskey(ctx context.Context, c *cmd.DeleteUserPasskey) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(
+			"DELETE FROM user_passkeys WHERE tenant_id = $1 AND user_id = $2 AND id = $3",
+			tenant.ID, c.UserID, c.ID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete passkey '%d'", c.ID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
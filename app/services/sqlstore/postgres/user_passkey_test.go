@@ -0,0 +1,77 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestUserPasskeyStorage_AddAndGetByCredentialID(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addPasskey := &cmd.AddUserPasskey{UserID: aryaStark.ID, Name: "My Laptop", CredentialID: "credential-1", Credential: []byte(`{"id":"credential-1"}`)}
+	err := bus.Dispatch(aryaStarkCtx, addPasskey)
+	Expect(err).IsNil()
+
+	getPasskey := &query.GetUserPasskeyByCredentialID{CredentialID: "credential-1"}
+	err = bus.Dispatch(aryaStarkCtx, getPasskey)
+	Expect(err).IsNil()
+	Expect(getPasskey.Result.ID).Equals(addPasskey.Result.ID)
+	Expect(getPasskey.Result.UserID).Equals(aryaStark.ID)
+	Expect(getPasskey.Result.Name).Equals("My Laptop")
+	Expect(getPasskey.Result.Credential).Equals([]byte(`{"id":"credential-1"}`))
+	Expect(getPasskey.Result.LastUsedAt).IsNil()
+
+	// passkeys are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &query.GetUserPasskeyByCredentialID{CredentialID: "credential-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UpdateUserPasskeyUsage{ID: addPasskey.Result.ID, Credential: []byte(`{"id":"credential-1","signCount":1}`)})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, getPasskey)
+	Expect(err).IsNil()
+	Expect(getPasskey.Result.Credential).Equals([]byte(`{"id":"credential-1","signCount":1}`))
+	Expect(getPasskey.Result.LastUsedAt).IsNotNil()
+}
+
+func TestUserPasskeyStorage_ListAndDelete(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	addPasskey := &cmd.AddUserPasskey{UserID: aryaStark.ID, Name: "My Laptop", CredentialID: "credential-1", Credential: []byte("{}")}
+	err := bus.Dispatch(aryaStarkCtx, addPasskey)
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.AddUserPasskey{UserID: aryaStark.ID, Name: "My Phone", CredentialID: "credential-2", Credential: []byte("{}")})
+	Expect(err).IsNil()
+	err = bus.Dispatch(sansaStarkCtx, &cmd.AddUserPasskey{UserID: sansaStark.ID, Name: "My Key", CredentialID: "credential-3", Credential: []byte("{}")})
+	Expect(err).IsNil()
+
+	listPasskeys := &query.ListUserPasskeys{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, listPasskeys)
+	Expect(err).IsNil()
+	Expect(listPasskeys.Result).HasLen(2)
+	Expect(listPasskeys.Result[0].Name).Equals("My Laptop")
+	Expect(listPasskeys.Result[1].Name).Equals("My Phone")
+
+	// the passkey can only be deleted by the user who owns it
+	err = bus.Dispatch(sansaStarkCtx, &cmd.DeleteUserPasskey{UserID: sansaStark.ID, ID: addPasskey.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DeleteUserPasskey{UserID: aryaStark.ID, ID: addPasskey.Result.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, listPasskeys)
+	Expect(err).IsNil()
+	Expect(listPasskeys.Result).HasLen(1)
+	Expect(listPasskeys.Result[0].Name).Equals("My Phone")
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetUserPasskeyByCredentialID{CredentialID: "credential-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbUserSession struct {
+	ID         int            `db:"id"`
+	UserID     int            `db:"user_id"`
+	SessionID  string         `db:"session_id"`
+	IPAddress  sql.NullString `db:"ip_address"`
+	UserAgent  sql.NullString `db:"user_agent"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastSeenAt time.Time      `db:"last_seen_at"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	RevokedAt  dbx.NullTime   `db:"revoked_at"`
+}
+
+func (s *dbUserSession) toModel() *entity.UserSession {
+	session := &entity.UserSession{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		SessionID:  s.SessionID,
+		IPAddress:  s.IPAddress.String,
+		UserAgent:  s.UserAgent.String,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+	}
+	if s.RevokedAt.Valid {
+		session.RevokedAt = &s.RevokedAt.Time
+	}
+	return session
+}
+
+type dbRevokedSession struct {
+	SessionID string `db:"session_id"`
+}
+
+func createUserSession(ctx context.Context, c *cmd.CreateUserSession) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		ipAddress := sql.NullString{String: c.IPAddress, Valid: c.IPAddress != ""}
+		userAgent := sql.NullString{String: c.UserAgent, Valid: c.UserAgent != ""}
+
+		_, err := trx.Execute(`
+			INSERT INTO user_sessions (tenant_id, user_id, session_id, ip_address, user_agent, created_at, last_seen_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, NOW(), NOW(), $6)
+		`, tenant.ID, c.UserID, c.SessionID, ipAddress, userAgent, c.ExpiresAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to create session for user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
+
+func getUserSession(ctx context.Context, q *query.GetUserSession) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		session := dbUserSession{}
+		err := trx.Get(&session, `
+			SELECT id, user_id, session_id, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at
+			FROM user_sessions
+			WHERE tenant_id = $1 AND session_id = $2
+		`, tenant.ID, q.SessionID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get user session")
+		}
+		q.Result = session.toModel()
+		return nil
+	})
+}
+
+func listUserSessions(ctx context.Context, q *query.ListUserSessions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var sessions []*dbUserSession
+		err := trx.Select(&sessions, `
+			SELECT id, user_id, session_id, ip_address, user_agent, created_at, last_seen_at, expires_at, revoked_at
+			FROM user_sessions
+			WHERE tenant_id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+			ORDER BY last_seen_at DESC
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list sessions of user '%d'", q.UserID)
+		}
+
+		q.Result = make([]*entity.UserSession, len(sessions))
+		for i, session := range sessions {
+			q.Result[i] = session.toModel()
+		}
+		return nil
+	})
+}
+
+func touchUserSession(ctx context.Context, c *cmd.TouchUserSession) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		ipAddress := sql.NullString{String: c.IPAddress, Valid: c.IPAddress != ""}
+		userAgent := sql.NullString{String: c.UserAgent, Valid: c.UserAgent != ""}
+
+		_, err := trx.Execute(`
+			UPDATE user_sessions SET last_seen_at = NOW(), ip_address = $3, user_agent = $4
+			WHERE tenant_id = $1 AND session_id = $2
+		`, tenant.ID, c.SessionID, ipAddress, userAgent)
+		if err != nil {
+			return errors.Wrap(err, "failed to touch user session")
+		}
+		return nil
+	})
+}
+
+func revokeUserSession(ctx context.Context, c *cmd.RevokeUserSession) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var sessionID string
+		err := trx.Scalar(&sessionID, `
+			UPDATE user_sessions SET revoked_at = NOW()
+			WHERE tenant_id = $1 AND user_id = $2 AND id = $3 AND revoked_at IS NULL
+			RETURNING session_id
+		`, tenant.ID, c.UserID, c.ID)
+		if err != nil {
+			if errors.Cause(err) == app.ErrNotFound {
+				return app.ErrNotFound
+			}
+			return errors.Wrap(err, "failed to revoke session '%d'", c.ID)
+		}
+		c.Result = sessionID
+		return nil
+	})
+}
+
+func revokeUserSessions(ctx context.Context, c *cmd.RevokeUserSessions) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var revoked []*dbRevokedSession
+		err := trx.Select(&revoked, `
+			UPDATE user_sessions SET revoked_at = NOW()
+			WHERE tenant_id = $1 AND user_id = $2 AND session_id <> $3 AND revoked_at IS NULL
+			RETURNING session_id
+		`, tenant.ID, c.UserID, c.ExceptSessionID)
+		if err != nil {
+			return errors.Wrap(err, "failed to revoke sessions of user '%d'", c.UserID)
+		}
+
+		_, err = trx.Execute("UPDATE users SET sessions_revoked_at = NOW() WHERE tenant_id = $1 AND id = $2", tenant.ID, c.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to save when sessions of user '%d' were revoked", c.UserID)
+		}
+
+		c.Result = make([]string, len(revoked))
+		for i, session := range revoked {
+			c.Result[i] = session.SessionID
+		}
+		return nil
+	})
+}
+
+func getUserSessionsRevokedAt(ctx context.Context, q *query.GetUserSessionsRevokedAt) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		var revokedAt dbx.NullTime
+		err := trx.Scalar(&revokedAt, "SELECT sessions_revoked_at FROM users WHERE tenant_id = $1 AND id = $2", tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get when sessions of user '%d' were revoked", q.UserID)
+		}
+		if revokedAt.Valid {
+			q.Result = &revokedAt.Time
+		}
+		return nil
+	})
+}
+
+func purgeExpiredUserSessions(ctx context.Context, c *cmd.PurgeExpiredUserSessions) error {
+	return using(ctx, func(trx *dbx.Trx, _ *entity.Tenant, _ *entity.User) error {
+		rows, err := trx.Execute("DELETE FROM user_sessions WHERE expires_at < NOW()")
+		if err != nil {
+			return errors.Wrap(err, "failed to purge expired user sessions")
+		}
+		c.NumOfDeletedSessions = int(rows)
+		return nil
+	})
+}
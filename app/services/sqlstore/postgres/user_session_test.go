@@ -0,0 +1,137 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestUserSessionStorage_CreateAndGet(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.CreateUserSession{
+		UserID:    aryaStark.ID,
+		SessionID: "session-1",
+		IPAddress: "127.0.0.1",
+		UserAgent: "Firefox",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	})
+	Expect(err).IsNil()
+
+	getSession := &query.GetUserSession{SessionID: "session-1"}
+	err = bus.Dispatch(aryaStarkCtx, getSession)
+	Expect(err).IsNil()
+	Expect(getSession.Result.UserID).Equals(aryaStark.ID)
+	Expect(getSession.Result.IPAddress).Equals("127.0.0.1")
+	Expect(getSession.Result.UserAgent).Equals("Firefox")
+	Expect(getSession.Result.RevokedAt).IsNil()
+
+	// sessions are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &query.GetUserSession{SessionID: "session-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestUserSessionStorage_Revoke(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.CreateUserSession{UserID: aryaStark.ID, SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour)})
+	Expect(err).IsNil()
+
+	getSession := &query.GetUserSession{SessionID: "session-1"}
+	err = bus.Dispatch(aryaStarkCtx, getSession)
+	Expect(err).IsNil()
+
+	// the session can only be revoked for the user who owns it
+	err = bus.Dispatch(sansaStarkCtx, &cmd.RevokeUserSession{UserID: sansaStark.ID, ID: getSession.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	revoke := &cmd.RevokeUserSession{UserID: aryaStark.ID, ID: getSession.Result.ID}
+	err = bus.Dispatch(aryaStarkCtx, revoke)
+	Expect(err).IsNil()
+	Expect(revoke.Result).Equals("session-1")
+
+	err = bus.Dispatch(aryaStarkCtx, getSession)
+	Expect(err).IsNil()
+	Expect(getSession.Result.RevokedAt).IsNotNil()
+
+	listSessions := &query.ListUserSessions{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, listSessions)
+	Expect(err).IsNil()
+	Expect(listSessions.Result).HasLen(0)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.RevokeUserSession{UserID: aryaStark.ID, ID: getSession.Result.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestUserSessionStorage_RevokeAllButCurrent(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	for _, sessionID := range []string{"session-1", "session-2", "session-3"} {
+		err := bus.Dispatch(aryaStarkCtx, &cmd.CreateUserSession{UserID: aryaStark.ID, SessionID: sessionID, ExpiresAt: time.Now().Add(time.Hour)})
+		Expect(err).IsNil()
+	}
+	err := bus.Dispatch(sansaStarkCtx, &cmd.CreateUserSession{UserID: sansaStark.ID, SessionID: "session-4", ExpiresAt: time.Now().Add(time.Hour)})
+	Expect(err).IsNil()
+
+	revokedAt := &query.GetUserSessionsRevokedAt{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, revokedAt)
+	Expect(err).IsNil()
+	Expect(revokedAt.Result).IsNil()
+
+	revoke := &cmd.RevokeUserSessions{UserID: aryaStark.ID, ExceptSessionID: "session-2"}
+	err = bus.Dispatch(aryaStarkCtx, revoke)
+	Expect(err).IsNil()
+	Expect(revoke.Result).HasLen(2)
+
+	listSessions := &query.ListUserSessions{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, listSessions)
+	Expect(err).IsNil()
+	Expect(listSessions.Result).HasLen(1)
+	Expect(listSessions.Result[0].SessionID).Equals("session-2")
+
+	err = bus.Dispatch(aryaStarkCtx, revokedAt)
+	Expect(err).IsNil()
+	Expect(revokedAt.Result).IsNotNil()
+
+	// sessions of other users are kept
+	listSessions = &query.ListUserSessions{UserID: sansaStark.ID}
+	err = bus.Dispatch(sansaStarkCtx, listSessions)
+	Expect(err).IsNil()
+	Expect(listSessions.Result).HasLen(1)
+}
+
+func TestUserSessionStorage_PurgeExpired(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.CreateUserSession{UserID: aryaStark.ID, SessionID: "expired", ExpiresAt: time.Now().Add(-time.Hour)})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.CreateUserSession{UserID: aryaStark.ID, SessionID: "active", ExpiresAt: time.Now().Add(time.Hour)})
+	Expect(err).IsNil()
+
+	listSessions := &query.ListUserSessions{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, listSessions)
+	Expect(err).IsNil()
+	Expect(listSessions.Result).HasLen(1)
+	Expect(listSessions.Result[0].SessionID).Equals("active")
+
+	purge := &cmd.PurgeExpiredUserSessions{}
+	err = bus.Dispatch(demoTenantCtx, purge)
+	Expect(err).IsNil()
+	Expect(purge.NumOfDeletedSessions).Equals(1)
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetUserSession{SessionID: "expired"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetUserSession{SessionID: "active"})
+	Expect(err).IsNil()
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/dbx"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+type dbUserTwoFactor struct {
+	UserID         int          `db:"user_id"`
+	Secret         string       `db:"secret"`
+	LastUsedStep   int64        `db:"last_used_step"`
+	FailedAttempts int          `db:"failed_attempts"`
+	EnabledAt      dbx.NullTime `db:"enabled_at"`
+	CreatedAt      time.Time    `db:"created_at"`
+}
+
+func (t *dbUserTwoFactor) toModel() *entity.UserTwoFactor {
+	model := &entity.UserTwoFactor{
+		UserID:         t.UserID,
+		Secret:         t.Secret,
+		LastUsedStep:   t.LastUsedStep,
+		FailedAttempts: t.FailedAttempts,
+		CreatedAt:      t.CreatedAt,
+	}
+	if t.EnabledAt.Valid {
+		model.EnabledAt = &t.EnabledAt.Time
+	}
+	return model
+}
+
+func getUserTwoFactor(ctx context.Context, q *query.GetUserTwoFactor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		twoFactor := dbUserTwoFactor{}
+		err := trx.Get(&twoFactor, `
+			SELECT user_id, secret, last_used_step, failed_attempts, enabled_at, created_at
+			FROM user_two_factor
+			WHERE tenant_id = $1 AND user_id = $2
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to get two-factor settings of user '%d'", q.UserID)
+		}
+		q.Result = twoFactor.toModel()
+		return nil
+	})
+}
+
+func countUnusedRecoveryCodes(ctx context.Context, q *query.CountUnusedRecoveryCodes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		err := trx.Scalar(&q.Result, `
+			SELECT COUNT(*) FROM user_recovery_codes
+			WHERE tenant_id = $1 AND user_id = $2 AND used_at IS NULL
+		`, tenant.ID, q.UserID)
+		if err != nil {
+			return errors.Wrap(err, "failed to count recovery codes of user '%d'", q.UserID)
+		}
+		return nil
+	})
+}
+
+func saveUserTwoFactorSecret(ctx context.Context, c *cmd.SaveUserTwoFactorSecret) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(`
+			INSERT INTO user_two_factor (tenant_id, user_id, secret, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, user_id) DO UPDATE
+			SET secret = $3, created_at = $4, last_used_step = 0, failed_attempts = 0
+			WHERE user_two_factor.enabled_at IS NULL
+		`, tenant.ID, c.UserID, c.Secret, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to save two-factor secret of user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
+
+func enableUserTwoFactor(ctx context.Context, c *cmd.EnableUserTwoFactor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE user_two_factor SET enabled_at = $3, last_used_step = $4, failed_attempts = 0
+			WHERE tenant_id = $1 AND user_id = $2 AND enabled_at IS NULL
+		`, tenant.ID, c.UserID, time.Now(), c.Step)
+		if err != nil {
+			return errors.Wrap(err, "failed to enable two-factor of user '%d'", c.UserID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+
+		return replaceRecoveryCodes(trx, tenant, c.UserID, c.RecoveryCodeHashes)
+	})
+}
+
+func disableUserTwoFactor(ctx context.Context, c *cmd.DisableUserTwoFactor) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		if _, err := trx.Execute(
+			"DELETE FROM user_recovery_codes WHERE tenant_id = $1 AND user_id = $2",
+			tenant.ID, c.UserID,
+		); err != nil {
+			return errors.Wrap(err, "failed to delete recovery codes of user '%d'", c.UserID)
+		}
+
+		if _, err := trx.Execute(
+			"DELETE FROM user_two_factor WHERE tenant_id = $1 AND user_id = $2",
+			tenant.ID, c.UserID,
+		); err != nil {
+			return errors.Wrap(err, "failed to disable two-factor of user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
+
+func replaceUserRecoveryCodes(ctx context.Context, c *cmd.ReplaceUserRecoveryCodes) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		return replaceRecoveryCodes(trx, tenant, c.UserID, c.RecoveryCodeHashes)
+	})
+}
+
+func replaceRecoveryCodes(trx *dbx.Trx, tenant *entity.Tenant, userID int, hashes []string) error {
+	if _, err := trx.Execute(
+		"DELETE FROM user_recovery_codes WHERE tenant_id = $1 AND user_id = $2",
+		tenant.ID, userID,
+	); err != nil {
+		return errors.Wrap(err, "failed to delete recovery codes of user '%d'", userID)
+	}
+
+	now := time.Now()
+	for _, hash := range hashes {
+		if _, err := trx.Execute(
+			"INSERT INTO user_recovery_codes (tenant_id, user_id, code_hash, created_at) VALUES ($1, $2, $3, $4)",
+			tenant.ID, userID, hash, now,
+		); err != nil {
+			return errors.Wrap(err, "failed to add recovery code of user '%d'", userID)
+		}
+	}
+	return nil
+}
+
+func useTwoFactorStep(ctx context.Context, c *cmd.UseTwoFactorStep) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE user_two_factor SET last_used_step = $3, failed_attempts = 0
+			WHERE tenant_id = $1 AND user_id = $2 AND last_used_step < $3
+		`, tenant.ID, c.UserID, c.Step)
+		if err != nil {
+			return errors.Wrap(err, "failed to use two-factor code of user '%d'", c.UserID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func useRecoveryCode(ctx context.Context, c *cmd.UseRecoveryCode) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		rows, err := trx.Execute(`
+			UPDATE user_recovery_codes SET used_at = $4
+			WHERE tenant_id = $1 AND user_id = $2 AND code_hash = $3 AND used_at IS NULL
+		`, tenant.ID, c.UserID, c.CodeHash, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to use recovery code of user '%d'", c.UserID)
+		}
+		if rows == 0 {
+			return app.ErrNotFound
+		}
+
+		if _, err := trx.Execute(
+			"UPDATE user_two_factor SET failed_attempts = 0 WHERE tenant_id = $1 AND user_id = $2",
+			tenant.ID, c.UserID,
+		); err != nil {
+			return errors.Wrap(err, "failed to reset two-factor attempts of user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
+
+func recordTwoFactorFailure(ctx context.Context, c *cmd.RecordTwoFactorFailure) error {
+	return using(ctx, func(trx *dbx.Trx, tenant *entity.Tenant, user *entity.User) error {
+		_, err := trx.Execute(
+			"UPDATE user_two_factor SET failed_attempts = failed_attempts + 1 WHERE tenant_id = $1 AND user_id = $2",
+			tenant.ID, c.UserID,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to record two-factor failure of user '%d'", c.UserID)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,136 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+)
+
+func TestUserTwoFactorStorage_EnrollAndEnable(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.SaveUserTwoFactorSecret{UserID: aryaStark.ID, Secret: "SECRET1"})
+	Expect(err).IsNil()
+
+	// an enrollment that wasn't confirmed is replaced
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SaveUserTwoFactorSecret{UserID: aryaStark.ID, Secret: "SECRET2"})
+	Expect(err).IsNil()
+
+	getTwoFactor := &query.GetUserTwoFactor{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, getTwoFactor)
+	Expect(err).IsNil()
+	Expect(getTwoFactor.Result.Secret).Equals("SECRET2")
+	Expect(getTwoFactor.Result.EnabledAt).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.EnableUserTwoFactor{UserID: aryaStark.ID, Step: 100, RecoveryCodeHashes: []string{"code-1", "code-2"}})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, getTwoFactor)
+	Expect(err).IsNil()
+	Expect(getTwoFactor.Result.EnabledAt).IsNotNil()
+	Expect(getTwoFactor.Result.LastUsedStep).Equals(int64(100))
+
+	countCodes := &query.CountUnusedRecoveryCodes{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(2)
+
+	// an enabled secret can't be replaced by a new enrollment
+	err = bus.Dispatch(aryaStarkCtx, &cmd.SaveUserTwoFactorSecret{UserID: aryaStark.ID, Secret: "SECRET3"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, getTwoFactor)
+	Expect(err).IsNil()
+	Expect(getTwoFactor.Result.Secret).Equals("SECRET2")
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.EnableUserTwoFactor{UserID: aryaStark.ID, Step: 200})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// two-factor settings are looked up in the tenant of the request only
+	err = bus.Dispatch(tonyStarkCtx, &query.GetUserTwoFactor{UserID: aryaStark.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
+
+func TestUserTwoFactorStorage_StepCanOnlyBeUsedOnce(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.SaveUserTwoFactorSecret{UserID: aryaStark.ID, Secret: "SECRET"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.EnableUserTwoFactor{UserID: aryaStark.ID, Step: 100})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.RecordTwoFactorFailure{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.RecordTwoFactorFailure{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+
+	getTwoFactor := &query.GetUserTwoFactor{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, getTwoFactor)
+	Expect(err).IsNil()
+	Expect(getTwoFactor.Result.FailedAttempts).Equals(2)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseTwoFactorStep{UserID: aryaStark.ID, Step: 100})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseTwoFactorStep{UserID: aryaStark.ID, Step: 101})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseTwoFactorStep{UserID: aryaStark.ID, Step: 101})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, getTwoFactor)
+	Expect(err).IsNil()
+	Expect(getTwoFactor.Result.LastUsedStep).Equals(int64(101))
+	Expect(getTwoFactor.Result.FailedAttempts).Equals(0)
+}
+
+func TestUserTwoFactorStorage_RecoveryCodes(t *testing.T) {
+	SetupDatabaseTest(t)
+	defer TeardownDatabaseTest()
+
+	err := bus.Dispatch(aryaStarkCtx, &cmd.SaveUserTwoFactorSecret{UserID: aryaStark.ID, Secret: "SECRET"})
+	Expect(err).IsNil()
+	err = bus.Dispatch(aryaStarkCtx, &cmd.EnableUserTwoFactor{UserID: aryaStark.ID, Step: 100, RecoveryCodeHashes: []string{"code-1", "code-2"}})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseRecoveryCode{UserID: aryaStark.ID, CodeHash: "code-1"})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseRecoveryCode{UserID: aryaStark.ID, CodeHash: "code-1"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	// recovery codes belong to the user they were created for
+	err = bus.Dispatch(sansaStarkCtx, &cmd.UseRecoveryCode{UserID: sansaStark.ID, CodeHash: "code-2"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	countCodes := &query.CountUnusedRecoveryCodes{UserID: aryaStark.ID}
+	err = bus.Dispatch(aryaStarkCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(1)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.ReplaceUserRecoveryCodes{UserID: aryaStark.ID, RecoveryCodeHashes: []string{"code-3", "code-4", "code-5"}})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(3)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.UseRecoveryCode{UserID: aryaStark.ID, CodeHash: "code-2"})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+
+	err = bus.Dispatch(aryaStarkCtx, &cmd.DisableUserTwoFactor{UserID: aryaStark.ID})
+	Expect(err).IsNil()
+
+	err = bus.Dispatch(aryaStarkCtx, countCodes)
+	Expect(err).IsNil()
+	Expect(countCodes.Result).Equals(0)
+
+	err = bus.Dispatch(aryaStarkCtx, &query.GetUserTwoFactor{UserID: aryaStark.ID})
+	Expect(errors.Cause(err)).Equals(app.ErrNotFound)
+}
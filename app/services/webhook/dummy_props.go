@@ -70,6 +70,9 @@ func dummyTriggerProps(c context.Context, webhookType enum.WebhookType) webhook.
 		props["reason"] = "Spam"
 	case enum.WebhookReportResolved:
 		props["reportId"] = 42
+		props["caseId"] = 17
+		props["reportCount"] = 3
+		props["reporterCount"] = 3
 		props["status"] = "resolved"
 		props["resolutionNote"] = "This report was resolved after review."
 		props["reportedType"] = "post"
@@ -0,0 +1,38 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
+)
+
+// NotifyAboutAppealDecision lets the appellant know how staff decided on their appeal
+func NotifyAboutAppealDecision(appeal *entity.Appeal, status enum.AppealStatus, note string) worker.Task {
+	return describe("Notify about appeal decision", func(c *worker.Context) error {
+		sanction, link := appeal.SanctionReason, "/profile#standing"
+		if appeal.SanctionType == entity.AppealSanctionBlock {
+			sanction, link = i18n.T(c, "web.appeal_decided.block"), "/appeal"
+		}
+
+		title := i18n.T(c, fmt.Sprintf("web.appeal_decided.%s.text", status.String()), i18n.Params{
+			"sanction": sanction,
+			"note":     note,
+		})
+
+		err := bus.Dispatch(c, &cmd.AddNewNotification{
+			User:  appeal.User,
+			Title: title,
+			Link:  link,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return nil
+	})
+}
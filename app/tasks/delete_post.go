@@ -77,7 +77,7 @@ func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.T
 			to := make([]dto.Recipient, 0)
 			for _, user := range users {
 				if user.ID != author.ID {
-					to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+					to = append(to, notificationRecipient(c, user, enum.NotificationEventChangeStatus))
 				}
 			}
 
@@ -89,7 +89,7 @@ func NotifyAboutDeletedPost(post *entity.Post, deleteCommentAdded bool) worker.T
 				"logo":     logoURL,
 			}
 
-			bus.Publish(c, &cmd.SendMail{
+			publishNotificationMail(c, &cmd.SendMail{
 				From:         dto.Recipient{Name: c.User().Name},
 				To:           to,
 				TemplateName: "delete_post",
@@ -38,6 +38,10 @@ func TestNotifyAboutDeletePostTask(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -84,9 +88,10 @@ func TestNotifyAboutDeletePostTask(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Arya Stark",
-		Address: "arya.stark@got.com",
-		Props:   dto.Props{},
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventChangeStatus),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -145,6 +150,10 @@ func TestNotifyAboutDeletePostTask_NoComment(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
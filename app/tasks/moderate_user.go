@@ -1,6 +1,7 @@
 package tasks
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
@@ -115,3 +116,56 @@ func NotifyAboutWarning(warnedUser *entity.User, reason string, expiresAt *time.
 		return nil
 	})
 }
+
+// NotifyAboutProbation tells a user their new content will be reviewed before it appears.
+// Shadow-bans are deliberately never notified.
+func NotifyAboutProbation(user *entity.User, reason string) worker.Task {
+	return describe("Notify about user probation", func(c *worker.Context) error {
+		title := i18n.T(c, "web.user_probation.text", i18n.Params{
+			"reason": reason,
+		})
+
+		err := bus.Dispatch(c, &cmd.AddNewNotification{
+			User:   user,
+			Title:  title,
+			Link:   "/profile#standing",
+			PostID: 0,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
+
+// NotifyAboutBlockProposal lets staff know the sanctions policy proposes to block a user
+func NotifyAboutBlockProposal(proposedUser *entity.User, reason string) worker.Task {
+	return describe("Notify about block proposal", func(c *worker.Context) error {
+		getAllUsers := &query.GetAllUsers{}
+		if err := bus.Dispatch(c, getAllUsers); err != nil {
+			return c.Failure(err)
+		}
+
+		title := i18n.T(c, "web.block_proposed.text", i18n.Params{
+			"userName": proposedUser.Name,
+			"reason":   reason,
+		})
+		link := fmt.Sprintf("/profile/%d#standing", proposedUser.ID)
+
+		for _, user := range getAllUsers.Result {
+			if user.Status != enum.UserActive || !(user.IsAdministrator() || user.IsCollaborator()) {
+				continue
+			}
+			err := bus.Dispatch(c, &cmd.AddNewNotification{
+				User:  user,
+				Title: title,
+				Link:  link,
+			})
+			if err != nil {
+				return c.Failure(err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -87,7 +87,7 @@ func ModerateNewContent(contentType string, contentID int, text string, imageBlo
 			ReportedID:   contentID,
 			Reason:       "Auto-flagged by AI moderation",
 			Details:      fmt.Sprintf("Flagged categories: %s", strings.Join(categoryNames, ", ")),
-			ReporterID:   nil,
+			System:       true,
 		}
 		if err := bus.Dispatch(c, createReport); err != nil {
 			log.Warn(c, fmt.Sprintf("Failed to create auto-report: %s", err.Error()))
@@ -97,3 +97,24 @@ func ModerateNewContent(contentType string, contentID int, text string, imageBlo
 	})
 }
 
+// HoldForProbationReview files a report so staff review content that was held because its author is on probation
+func HoldForProbationReview(contentType string, contentID int) worker.Task {
+	return describe("Queue probation content for review", func(c *worker.Context) error {
+		reportedType := enum.ReportTypeComment
+		if contentType == "post" {
+			reportedType = enum.ReportTypePost
+		}
+
+		createReport := &cmd.CreateReport{
+			ReportedType: reportedType,
+			ReportedID:   contentID,
+			Reason:       "Held for review: author is on probation",
+			Details:      fmt.Sprintf("%s by %s is hidden until approved", contentType, c.User().Name),
+			System:       true,
+		}
+		if err := bus.Dispatch(c, createReport); err != nil {
+			return c.Failure(err)
+		}
+		return nil
+	})
+}
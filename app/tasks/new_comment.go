@@ -19,7 +19,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
 )
 
-// NotifyAboutNewComment sends a notification (web and email) to subscribers
+// NotifyAboutNewComment sends a notification (web, email and digest) to subscribers
 func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Task {
 	return describe("Notify about new comment", func(c *worker.Context) error {
 		comment.ParseMentions()
@@ -44,6 +44,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 					Title:  title,
 					Link:   link,
 					PostID: post.ID,
+					Event:  notificationGroupNewComment,
 				})
 				if err != nil {
 					return c.Failure(err)
@@ -61,8 +62,9 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 		pushBody := truncateText(post.Title, 100)
 		pushIcon := baseURL + "/static/favicon?size=200"
 		pushURL := baseURL + link
-		pushTag := fmt.Sprintf("comment-%d", comment.ID)
-		sendPushNotifications(c, pushUsers, author.ID, pushTitle, pushBody, pushURL, pushIcon, pushTag)
+		// comments on the same post replace each other on the device instead of piling up
+		pushTag := fmt.Sprintf("comments-%d", post.ID)
+		sendPushNotifications(c, pushUsers, author.ID, enum.NotificationEventNewComment, pushTitle, pushBody, pushURL, pushIcon, pushTag)
 
 		// Web notification - mentions
 		if comment.Mentions != nil {
@@ -75,6 +77,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 			q := &query.GetUsersToNotify{
 				Event:   enum.NotificationEventMention,
 				Channel: enum.NotificationChannelWeb,
+				PostID:  post.ID,
 			}
 			err = bus.Dispatch(c, q)
 			if err != nil {
@@ -91,6 +94,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 							Title:  title,
 							Link:   link,
 							PostID: post.ID,
+							Event:  notificationGroupNewMention,
 						})
 						if err != nil {
 							return c.Failure(err)
@@ -103,6 +107,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 			pushMentionQ := &query.GetUsersToNotify{
 				Event:   enum.NotificationEventMention,
 				Channel: enum.NotificationChannelPush,
+				PostID:  post.ID,
 			}
 			err = bus.Dispatch(c, pushMentionQ)
 			if err != nil {
@@ -125,8 +130,8 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 			if len(pushMentionedUsers) > 0 {
 				mentionPushTitle := fmt.Sprintf("%s mentioned you", author.Name)
 				mentionPushBody := truncateText(post.Title, 100)
-				mentionPushTag := fmt.Sprintf("mention-%d", comment.ID)
-				sendPushNotifications(c, pushMentionedUsers, author.ID, mentionPushTitle, mentionPushBody, pushURL, pushIcon, mentionPushTag)
+				mentionPushTag := fmt.Sprintf("mentions-%d", post.ID)
+				sendPushNotifications(c, pushMentionedUsers, author.ID, enum.NotificationEventMention, mentionPushTitle, mentionPushBody, pushURL, pushIcon, mentionPushTag)
 			}
 		}
 
@@ -141,18 +146,24 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 		to := make([]dto.Recipient, 0)
 		for _, user := range users {
 			if user.ID != author.ID {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+				to = append(to, commentRecipient(c, user, post, enum.NotificationEventNewComment))
 			}
 		}
 
 		sendEmailNotifications(c, post, to, strippedContent, enum.NotificationEventNewComment, comment.ID)
 
+		// Digest email notification
+		if err := queueDigestItems(c, post, enum.NotificationEventNewComment, strippedContent, link); err != nil {
+			return c.Failure(err)
+		}
+
 		// Mentions
 		to = make([]dto.Recipient, 0)
 		if comment.Mentions != nil {
 			q := &query.GetUsersToNotify{
 				Event:   enum.NotificationEventMention,
 				Channel: enum.NotificationChannelEmail,
+				PostID:  post.ID,
 			}
 			err = bus.Dispatch(c, q)
 			if err != nil {
@@ -164,7 +175,7 @@ func NotifyAboutNewComment(comment *entity.Comment, post *entity.Post) worker.Ta
 				// Check if the user is in the list of users with mention notifications enabled
 				for _, u := range q.Result {
 					if u.ID == mention.ID && mention.IsNew {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+						to = append(to, commentRecipient(c, u, post, enum.NotificationEventMention))
 						break
 					}
 				}
@@ -214,6 +225,7 @@ func NotifyAboutUpdatedComment(content string, post *entity.Post, commentID int)
 			q := &query.GetUsersToNotify{
 				Event:   enum.NotificationEventMention,
 				Channel: enum.NotificationChannelWeb,
+				PostID:  post.ID,
 			}
 			err := bus.Dispatch(c, q)
 			if err != nil {
@@ -230,6 +242,7 @@ func NotifyAboutUpdatedComment(content string, post *entity.Post, commentID int)
 							Title:  title,
 							Link:   link,
 							PostID: post.ID,
+							Event:  notificationGroupNewMention,
 						})
 						if err != nil {
 							return c.Failure(err)
@@ -246,6 +259,7 @@ func NotifyAboutUpdatedComment(content string, post *entity.Post, commentID int)
 			q := &query.GetUsersToNotify{
 				Event:   enum.NotificationEventMention,
 				Channel: enum.NotificationChannelEmail,
+				PostID:  post.ID,
 			}
 			err := bus.Dispatch(c, q)
 			if err != nil {
@@ -256,7 +270,7 @@ func NotifyAboutUpdatedComment(content string, post *entity.Post, commentID int)
 				// Check if the user is in the list of mention subscribers (users)
 				for _, u := range q.Result {
 					if u.ID == mention.ID && mention.IsNew {
-						to = append(to, dto.NewRecipient(u.Name, u.Email, dto.Props{}))
+						to = append(to, commentRecipient(c, u, post, enum.NotificationEventMention))
 						break
 					}
 				}
@@ -307,7 +321,7 @@ func sendEmailNotifications(c *worker.Context, post *entity.Post, to []dto.Recip
 	mailProps["change"] = linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/profile#settings")
 	mailProps["logo"] = logoURL
 
-	bus.Publish(c, &cmd.SendMail{
+	publishNotificationMail(c, &cmd.SendMail{
 		From:         dto.Recipient{Name: author.Name},
 		To:           to,
 		TemplateName: "new_comment",
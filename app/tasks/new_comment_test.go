@@ -4,6 +4,7 @@ import (
 	"context"
 	"html/template"
 	"testing"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/webhook"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
 	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
@@ -41,6 +43,16 @@ func TestNotifyAboutNewCommentTask(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	var queueDigestItems *cmd.QueueDigestItems
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueDigestItems) error {
+		queueDigestItems = c
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -90,9 +102,10 @@ func TestNotifyAboutNewCommentTask(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Jon Snow",
-		Address: "jon.snow@got.com",
-		Props:   dto.Props{},
+		Name:        "Jon Snow",
+		Address:     "jon.snow@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.JonSnow, enum.NotificationEventNewComment),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -100,6 +113,11 @@ func TestNotifyAboutNewCommentTask(t *testing.T) {
 	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript#comment-0")
 	Expect(addNewNotification.Title).Equals("**Arya Stark** left a comment on **Add support for TypeScript**.")
 	Expect(addNewNotification.User).Equals(mock.JonSnow)
+	Expect(addNewNotification.Event).Equals("new_comment")
+
+	Expect(queueDigestItems).IsNotNil()
+	Expect(queueDigestItems.Event).Equals(enum.NotificationEventNewComment.UserSettingsKeyName)
+	Expect(queueDigestItems.Excerpt).Equals("I agree")
 
 	Expect(triggerWebhooks).IsNotNil()
 	Expect(triggerWebhooks.Type).Equals(enum.WebhookNewComment)
@@ -148,6 +166,14 @@ func TestNotifyAboutNewCommentTask_WithMention(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueDigestItems) error {
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -197,9 +223,10 @@ func TestNotifyAboutNewCommentTask_WithMention(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Jon Snow",
-		Address: "jon.snow@got.com",
-		Props:   dto.Props{},
+		Name:        "Jon Snow",
+		Address:     "jon.snow@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.JonSnow, enum.NotificationEventMention),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -207,6 +234,7 @@ func TestNotifyAboutNewCommentTask_WithMention(t *testing.T) {
 	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript#comment-0")
 	Expect(addNewNotification.Title).Equals("**Arya Stark** mentioned you in **Add support for TypeScript**.")
 	Expect(addNewNotification.User).Equals(mock.JonSnow)
+	Expect(addNewNotification.Event).Equals("new_mention")
 
 	Expect(triggerWebhooks).IsNotNil()
 	Expect(triggerWebhooks.Type).Equals(enum.WebhookNewComment)
@@ -251,6 +279,10 @@ func TestNotifyAboutUpdatedComment(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
 	worker := mock.NewWorker()
 	post := &entity.Post{
 		ID:          1,
@@ -296,9 +328,10 @@ func TestNotifyAboutUpdatedComment(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Jon Snow",
-		Address: "jon.snow@got.com",
-		Props:   dto.Props{},
+		Name:        "Jon Snow",
+		Address:     "jon.snow@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.JonSnow, enum.NotificationEventMention),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -306,4 +339,91 @@ func TestNotifyAboutUpdatedComment(t *testing.T) {
 	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript#comment-1")
 	Expect(addNewNotification.Title).Equals("**Arya Stark** mentioned you in **Add support for TypeScript**.")
 	Expect(addNewNotification.User).Equals(mock.JonSnow)
+	Expect(addNewNotification.Event).Equals("new_mention")
+}
+
+func TestNotifyAboutNewCommentTask_QuietHours(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetActiveSubscribers) error {
+		if q.Event.UserSettingsKeyName == "event_notification_new_comment" {
+			q.Result = []*entity.User{mock.JonSnow}
+		} else {
+			q.Result = []*entity.User{}
+		}
+		return nil
+	})
+
+	// a window that started an hour ago and ends in an hour
+	now := time.Now().UTC()
+	minute := now.Hour()*60 + now.Minute()
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		Expect(q.Emails).Equals([]string{mock.JonSnow.Email})
+		q.Result = []*entity.QuietHours{
+			{UserID: mock.JonSnow.ID, Email: mock.JonSnow.Email, Location: time.UTC, Start: (minute + 23*60) % (24 * 60), End: (minute + 60) % (24 * 60)},
+		}
+		return nil
+	})
+
+	var deferNotification *cmd.DeferNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeferNotification) error {
+		deferNotification = c
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueDigestItems) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		q.Result = []*entity.User{}
+		return nil
+	})
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+	task := tasks.NotifyAboutNewComment(&entity.Comment{Content: "I agree"}, post)
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.AryaStark).
+		WithBaseURL("http://domain.com").
+		Execute(task)
+
+	Expect(err).IsNil()
+	Expect(emailmock.MessageHistory).HasLen(0)
+
+	Expect(deferNotification).IsNotNil()
+	Expect(deferNotification.UserID).Equals(mock.JonSnow.ID)
+	Expect(deferNotification.Channel).Equals(enum.NotificationChannelEmail)
+	Expect(deferNotification.BaseURL).Equals("http://domain.com")
+	Expect(deferNotification.ReleaseAt.After(now)).IsTrue()
+
+	mail, err := cmd.UnmarshalDeferredMail(deferNotification.Payload)
+	Expect(err).IsNil()
+	Expect(mail.TemplateName).Equals("new_comment")
+	Expect(mail.To).HasLen(1)
+	Expect(mail.To[0].Address).Equals(mock.JonSnow.Email)
+	Expect(mail.Props["content"]).Equals(template.HTML("<p>I agree</p>"))
+}
+
+// unsubscribeURL is the one-click unsubscribe link of notification emails sent in these tests
+func unsubscribeURL(user *entity.User, event enum.NotificationEvent) string {
+	token, _ := jwt.Encode(&jwt.UnsubscribeClaims{UserID: user.ID, Event: event.UserSettingsKeyName})
+	return "http://domain.com/unsubscribe?token=" + token
 }
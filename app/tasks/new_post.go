@@ -16,7 +16,7 @@ import (
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
 )
 
-// NotifyAboutNewPost sends a notification (web and email) to subscribers
+// NotifyAboutNewPost sends a notification (web, email and digest) to subscribers
 func NotifyAboutNewPost(post *entity.Post) worker.Task {
 	return describe("Notify about new post", func(c *worker.Context) error {
 		// Web notification
@@ -59,7 +59,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 		pushIcon := baseURL + "/static/favicon?size=200"
 		pushURL := baseURL + link
 		pushTag := fmt.Sprintf("post-%d", post.Number)
-		sendPushNotifications(c, pushUsers, author.ID, pushTitle, pushBody, pushURL, pushIcon, pushTag)
+		sendPushNotifications(c, pushUsers, author.ID, enum.NotificationEventNewPost, pushTitle, pushBody, pushURL, pushIcon, pushTag)
 
 		// Email notification
 		if !env.Config.Email.DisableEmailNotifications {
@@ -71,7 +71,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 			to := make([]dto.Recipient, 0)
 			for _, user := range users {
 				if user.ID != author.ID {
-					to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+					to = append(to, notificationRecipient(c, user, enum.NotificationEventNewPost))
 				}
 			}
 
@@ -86,7 +86,7 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 				"logo":     logoURL,
 			}
 
-			bus.Publish(c, &cmd.SendMail{
+			publishNotificationMail(c, &cmd.SendMail{
 				From:         dto.Recipient{Name: author.Name},
 				To:           to,
 				TemplateName: "new_post",
@@ -94,6 +94,11 @@ func NotifyAboutNewPost(post *entity.Post) worker.Task {
 			})
 		}
 
+		// Digest email notification
+		if err := queueDigestItems(c, post, enum.NotificationEventNewPost, post.Description, link); err != nil {
+			return c.Failure(err)
+		}
+
 		webhookProps := webhook.Props{}
 		webhookProps.SetPost(post, "post", baseURL, false, false)
 		webhookProps.SetUser(author, "author")
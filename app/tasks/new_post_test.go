@@ -37,6 +37,16 @@ func TestNotifyAboutNewPostTask(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	var queueDigestItems *cmd.QueueDigestItems
+	bus.AddHandler(func(ctx context.Context, c *cmd.QueueDigestItems) error {
+		queueDigestItems = c
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -78,9 +88,10 @@ func TestNotifyAboutNewPostTask(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Arya Stark",
-		Address: "arya.stark@got.com",
-		Props:   dto.Props{},
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventNewPost),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -89,6 +100,12 @@ func TestNotifyAboutNewPostTask(t *testing.T) {
 	Expect(addNewNotification.Title).Equals("**Jon Snow** created a new post **Add support for TypeScript**.")
 	Expect(addNewNotification.User).Equals(mock.AryaStark)
 
+	Expect(queueDigestItems).IsNotNil()
+	Expect(queueDigestItems.Users).Equals([]*entity.User{mock.AryaStark})
+	Expect(queueDigestItems.Event).Equals(enum.NotificationEventNewPost.UserSettingsKeyName)
+	Expect(queueDigestItems.BaseURL).Equals("http://domain.com")
+	Expect(queueDigestItems.Link).Equals("/posts/1/add-support-for-typescript")
+
 	Expect(triggerWebhooks).IsNotNil()
 	Expect(triggerWebhooks.Type).Equals(enum.WebhookNewPost)
 	Expect(triggerWebhooks.Props).ContainsProps(webhook.Props{
@@ -0,0 +1,226 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
+)
+
+// authorNotification is a notification sent to the author of a post or comment when staff act on it
+type authorNotification struct {
+	event    enum.NotificationEvent
+	author   *entity.User
+	post     *entity.Post
+	link     string
+	template string
+	params   i18n.Params
+	pushTag  string
+}
+
+// NotifyAboutLockedPost lets the author know their post no longer accepts comments
+func NotifyAboutLockedPost(post *entity.Post, lockMessage string) worker.Task {
+	return describe("Notify author about locked post", func(c *worker.Context) error {
+		return notifyAuthor(c, &authorNotification{
+			event:    enum.NotificationEventPostLocked,
+			author:   post.User,
+			post:     post,
+			link:     fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug),
+			template: "post_locked",
+			params:   i18n.Params{"title": post.Title, "message": lockMessage},
+			pushTag:  fmt.Sprintf("post-locked-%d", post.ID),
+		})
+	})
+}
+
+// NotifyAboutArchivedPosts lets the authors of the posts know they were archived
+func NotifyAboutArchivedPosts(postIDs []int) worker.Task {
+	return describe("Notify authors about archived posts", func(c *worker.Context) error {
+		for _, postID := range postIDs {
+			getPost := &query.GetPostByID{PostID: postID}
+			if err := bus.Dispatch(c, getPost); err != nil {
+				return c.Failure(err)
+			}
+
+			// bulk archiving skips posts that were deleted or already archived
+			post := getPost.Result
+			if post.Status != enum.PostArchived {
+				continue
+			}
+
+			err := notifyAuthor(c, &authorNotification{
+				event:    enum.NotificationEventPostArchived,
+				author:   post.User,
+				post:     post,
+				link:     fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug),
+				template: "post_archived",
+				params:   i18n.Params{"title": post.Title},
+				pushTag:  fmt.Sprintf("post-archived-%d", post.ID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// NotifyAboutTaggedPost lets the author know staff tagged their post
+func NotifyAboutTaggedPost(post *entity.Post, tag *entity.Tag) worker.Task {
+	return describe("Notify author about tagged post", func(c *worker.Context) error {
+		return notifyAuthor(c, &authorNotification{
+			event:    enum.NotificationEventPostTagged,
+			author:   post.User,
+			post:     post,
+			link:     fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug),
+			template: "post_tagged",
+			params:   i18n.Params{"title": post.Title, "tag": tag.Name},
+			pushTag:  fmt.Sprintf("post-tagged-%d", post.ID),
+		})
+	})
+}
+
+// NotifyAboutModeratedContent lets the author of a post or comment know staff hid it for review or approved it
+func NotifyAboutModeratedContent(contentType string, contentID int, approved bool) worker.Task {
+	return describe("Notify author about moderated content", func(c *worker.Context) error {
+		var (
+			post   *entity.Post
+			author *entity.User
+			link   string
+		)
+
+		if contentType == "post" {
+			getPost := &query.GetPostByID{PostID: contentID}
+			if err := bus.Dispatch(c, getPost); err != nil {
+				return c.Failure(err)
+			}
+			post, author = getPost.Result, getPost.Result.User
+			link = fmt.Sprintf("/posts/%d/%s", post.Number, post.Slug)
+		} else {
+			getComment := &query.GetCommentByID{CommentID: contentID}
+			getPost := &query.GetPostByCommentID{CommentID: contentID}
+			if err := bus.Dispatch(c, getComment, getPost); err != nil {
+				return c.Failure(err)
+			}
+			post, author = getPost.Result, getComment.Result.User
+			link = fmt.Sprintf("/posts/%d/%s#comment-%d", post.Number, post.Slug, contentID)
+		}
+
+		event, template := enum.NotificationEventContentHidden, "content_hidden"
+		if approved {
+			event, template = enum.NotificationEventContentApproved, "content_approved"
+		}
+
+		return notifyAuthor(c, &authorNotification{
+			event:    event,
+			author:   author,
+			post:     post,
+			link:     link,
+			template: template,
+			params:   i18n.Params{"title": post.Title, "type": contentType},
+			pushTag:  fmt.Sprintf("%s-%s-%d", template, contentType, contentID),
+		})
+	})
+}
+
+// notifyAuthor sends the notification on every channel the author enabled for its event
+// Authors aren't notified about their own actions, and muting the post silences it
+func notifyAuthor(c *worker.Context, n *authorNotification) error {
+	if n.author == nil || (c.User() != nil && c.User().ID == n.author.ID) {
+		return nil
+	}
+
+	authorIDs := []int{n.author.ID}
+	title := i18n.T(c, "web."+n.template+".text", n.params)
+
+	// Web notification
+	webUsers := &query.GetUsersToNotify{
+		Event:   n.event,
+		Channel: enum.NotificationChannelWeb,
+		UserIDs: authorIDs,
+		PostID:  n.post.ID,
+	}
+	if err := bus.Dispatch(c, webUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	for _, user := range webUsers.Result {
+		err := bus.Dispatch(c, &cmd.AddNewNotification{
+			User:   user,
+			Title:  title,
+			Link:   n.link,
+			PostID: n.post.ID,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+	}
+
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	// Push notification
+	pushUsers := &query.GetUsersToNotify{
+		Event:   n.event,
+		Channel: enum.NotificationChannelPush,
+		UserIDs: authorIDs,
+		PostID:  n.post.ID,
+	}
+	if err := bus.Dispatch(c, pushUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	pushTitle := i18n.T(c, "email."+n.template+".subject", n.params)
+	pushIcon := baseURL + "/static/favicon?size=200"
+	sendPushNotifications(c, pushUsers.Result, 0, n.event, pushTitle, title, baseURL+n.link, pushIcon, n.pushTag)
+
+	// Email notification
+	if env.Config.Email.DisableEmailNotifications {
+		return nil
+	}
+
+	emailUsers := &query.GetUsersToNotify{
+		Event:   n.event,
+		Channel: enum.NotificationChannelEmail,
+		UserIDs: authorIDs,
+		PostID:  n.post.ID,
+	}
+	if err := bus.Dispatch(c, emailUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	if len(emailUsers.Result) == 0 {
+		return nil
+	}
+
+	to := make([]dto.Recipient, 0, len(emailUsers.Result))
+	for _, user := range emailUsers.Result {
+		to = append(to, notificationRecipient(c, user, n.event))
+	}
+
+	props := dto.Props{
+		"siteName": c.Tenant().Name,
+		"view":     linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "%s", n.link),
+		"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/profile#settings"),
+		"logo":     logoURL,
+	}
+	for k, v := range n.params {
+		props[k] = v
+	}
+
+	publishNotificationMail(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: c.Tenant().Name},
+		To:           to,
+		TemplateName: n.template,
+		Props:        props,
+	})
+
+	return nil
+}
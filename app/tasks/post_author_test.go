@@ -0,0 +1,118 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+)
+
+func TestNotifyAboutLockedPostTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	var usersToNotify []*query.GetUsersToNotify
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		usersToNotify = append(usersToNotify, q)
+		if q.Channel != enum.NotificationChannelPush {
+			q.Result = []*entity.User{mock.AryaStark}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.AryaStark,
+	}
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutLockedPost(post, "Off topic"))
+
+	Expect(err).IsNil()
+	Expect(usersToNotify).HasLen(3)
+	for _, q := range usersToNotify {
+		Expect(q.Event.UserSettingsKeyName).Equals(enum.NotificationEventPostLocked.UserSettingsKeyName)
+		Expect(q.UserIDs).Equals([]int{mock.AryaStark.ID})
+		Expect(q.PostID).Equals(post.ID)
+	}
+
+	Expect(addNewNotification).IsNotNil()
+	Expect(addNewNotification.User).Equals(mock.AryaStark)
+	Expect(addNewNotification.PostID).Equals(post.ID)
+	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript")
+	Expect(addNewNotification.Title).Equals("Your post **Add support for TypeScript** has been locked.")
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("post_locked")
+	Expect(emailmock.MessageHistory[0].Props).Equals(dto.Props{
+		"siteName": "Demonstration",
+		"title":    "Add support for TypeScript",
+		"message":  "Off topic",
+		"view":     "<a href='http://domain.com/posts/1/add-support-for-typescript'>view it on your browser</a>",
+		"change":   "<a href='http://domain.com/profile#settings'>change your notification preferences</a>",
+		"logo":     "https://fider.io/images/logo-100x100.png",
+	})
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventPostLocked),
+	})
+}
+
+func TestNotifyAboutTaggedPostTask_AuthorIsNotNotifiedOfOwnAction(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		dispatched = true
+		return nil
+	})
+
+	worker := mock.NewWorker()
+	post := &entity.Post{
+		ID:     1,
+		Number: 1,
+		Title:  "Add support for TypeScript",
+		Slug:   "add-support-for-typescript",
+		User:   mock.JonSnow,
+	}
+
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutTaggedPost(post, &entity.Tag{Name: "Bug"}))
+
+	Expect(err).IsNil()
+	Expect(dispatched).IsFalse()
+	Expect(emailmock.MessageHistory).HasLen(0)
+}
@@ -1,9 +1,16 @@
 package tasks
 
 import (
+	"fmt"
+
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/webhook"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
@@ -34,20 +41,66 @@ func NotifyAboutNewReport(reportID int, reportedType enum.ReportType, reportedID
 	})
 }
 
-func NotifyAboutReportResolved(reportID int, status enum.ReportStatus, resolutionNote string, reportedType enum.ReportType, reportedID int, reason string) worker.Task {
+func NotifyAboutReportResolved(report *entity.Report, status enum.ReportStatus, resolutionNote string) worker.Task {
 	return describe("Notify about report resolved", func(c *worker.Context) error {
 		tenant := c.Tenant()
 		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
 		resolver := c.User()
 
 		webhookProps := webhook.Props{
-			"reportId":       reportID,
+			"reportId":       report.ID,
+			"status":         status.String(),
+			"resolutionNote": resolutionNote,
+			"reportedType":   report.ReportedType.String(),
+			"reportedId":     report.ReportedID,
+			"reason":         report.Reason,
+		}
+		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
+		webhookProps.SetUser(resolver, "resolver")
+
+		err := bus.Dispatch(c, &cmd.TriggerWebhooks{
+			Type:  enum.WebhookReportResolved,
+			Props: webhookProps,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		if report.Reporter == nil {
+			return nil
+		}
+
+		return notifyReportersAboutOutcome(c, []int{report.Reporter.ID}, status, report.ReportedType, report.ReportedID, report.PostNumber, report.PostSlug)
+	})
+}
+
+// NotifyAboutReportCaseResolved triggers the resolution webhook for the whole case
+// and lets every reporter know the outcome of their report
+func NotifyAboutReportCaseResolved(reportCase *entity.ReportCase, status enum.ReportStatus, resolutionNote string) worker.Task {
+	return describe("Notify about report case resolved", func(c *worker.Context) error {
+		tenant := c.Tenant()
+		baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+		resolver := c.User()
+
+		reason := ""
+		if len(reportCase.Reasons) > 0 {
+			reason = reportCase.Reasons[0].Reason
+		}
+
+		webhookProps := webhook.Props{
+			"reportId":       0,
+			"caseId":         reportCase.ID,
+			"reportCount":    reportCase.ReportCount,
+			"reporterCount":  reportCase.ReporterCount,
 			"status":         status.String(),
 			"resolutionNote": resolutionNote,
-			"reportedType":   reportedType.String(),
-			"reportedId":     reportedID,
+			"reportedType":   reportCase.ReportedType.String(),
+			"reportedId":     reportCase.ReportedID,
 			"reason":         reason,
 		}
+		if len(reportCase.Reports) > 0 {
+			webhookProps["reportId"] = reportCase.Reports[0].ID
+		}
 		webhookProps.SetTenant(tenant, "tenant", baseURL, logoURL)
 		webhookProps.SetUser(resolver, "resolver")
 
@@ -59,6 +112,114 @@ func NotifyAboutReportResolved(reportID int, status enum.ReportStatus, resolutio
 			return c.Failure(err)
 		}
 
+		return notifyReportersAboutOutcome(c, reportCase.ReporterIDs(), status, reportCase.ReportedType, reportCase.ReportedID, reportCase.PostNumber, reportCase.PostSlug)
+	})
+}
+
+// notifyReportersAboutOutcome tells reporters whether staff actioned or dismissed
+// their report, on every channel they enabled for the report outcome event
+func notifyReportersAboutOutcome(c *worker.Context, reporterIDs []int, status enum.ReportStatus, reportedType enum.ReportType, reportedID, postNumber int, postSlug string) error {
+	if len(reporterIDs) == 0 {
+		return nil
+	}
+
+	outcome := "actioned"
+	if status == enum.ReportStatusDismissed {
+		outcome = "dismissed"
+	}
+
+	link := ""
+	if postNumber > 0 {
+		link = fmt.Sprintf("/posts/%d/%s", postNumber, postSlug)
+		if reportedType == enum.ReportTypeComment {
+			link += fmt.Sprintf("#comment-%d", reportedID)
+		}
+	}
+
+	params := i18n.Params{"type": reportedType.String()}
+
+	// Web notification
+	webUsers := &query.GetUsersToNotify{
+		Event:   enum.NotificationEventReportOutcome,
+		Channel: enum.NotificationChannelWeb,
+		UserIDs: reporterIDs,
+	}
+	if err := bus.Dispatch(c, webUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	title := i18n.T(c, "web.report_outcome."+outcome+".text", params)
+	for _, user := range webUsers.Result {
+		err := bus.Dispatch(c, &cmd.AddNewNotification{
+			User:  user,
+			Title: title,
+			Link:  link,
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+	}
+
+	baseURL, logoURL := web.BaseURL(c), web.LogoURL(c)
+
+	// Push notification
+	pushUsers := &query.GetUsersToNotify{
+		Event:   enum.NotificationEventReportOutcome,
+		Channel: enum.NotificationChannelPush,
+		UserIDs: reporterIDs,
+	}
+	if err := bus.Dispatch(c, pushUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	pushTitle := "Your report has been reviewed"
+	pushBody := title
+	pushIcon := baseURL + "/static/favicon?size=200"
+	pushURL := baseURL + link
+	pushTag := fmt.Sprintf("report-outcome-%s-%d", reportedType.String(), reportedID)
+	sendPushNotifications(c, pushUsers.Result, 0, enum.NotificationEventReportOutcome, pushTitle, pushBody, pushURL, pushIcon, pushTag)
+
+	// Email notification
+	if env.Config.Email.DisableEmailNotifications {
 		return nil
+	}
+
+	emailUsers := &query.GetUsersToNotify{
+		Event:   enum.NotificationEventReportOutcome,
+		Channel: enum.NotificationChannelEmail,
+		UserIDs: reporterIDs,
+	}
+	if err := bus.Dispatch(c, emailUsers); err != nil {
+		return c.Failure(err)
+	}
+
+	if len(emailUsers.Result) == 0 {
+		return nil
+	}
+
+	to := make([]dto.Recipient, 0, len(emailUsers.Result))
+	for _, user := range emailUsers.Result {
+		to = append(to, notificationRecipient(c, user, enum.NotificationEventReportOutcome))
+	}
+
+	props := dto.Props{
+		"siteName": c.Tenant().Name,
+		"outcome":  outcome,
+		"type":     reportedType.String(),
+		"view":     "",
+		"change":   linkWithText(i18n.T(c, "email.subscription.change"), baseURL, "/profile#settings"),
+		"logo":     logoURL,
+	}
+	if link != "" {
+		props["view"] = linkWithText(i18n.T(c, "email.subscription.view"), baseURL, "%s", link)
+	}
+
+	publishNotificationMail(c, &cmd.SendMail{
+		From:         dto.Recipient{Name: c.Tenant().Name},
+		To:           to,
+		TemplateName: "report_outcome",
+		Props:        props,
 	})
+
+	return nil
 }
@@ -0,0 +1,180 @@
+package tasks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	. "github.com/Spicy-Bush/fider-tarkov-community/app/pkg/assert"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/mock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/services/email/emailmock"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/tasks"
+)
+
+func TestNotifyAboutReportResolvedTask(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	var triggerWebhooks *cmd.TriggerWebhooks
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		triggerWebhooks = c
+		return nil
+	})
+
+	var addNewNotification *cmd.AddNewNotification
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		addNewNotification = c
+		return nil
+	})
+
+	var usersToNotify []*query.GetUsersToNotify
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		usersToNotify = append(usersToNotify, q)
+		if q.Channel != enum.NotificationChannelPush {
+			q.Result = []*entity.User{mock.AryaStark}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	report := &entity.Report{
+		ID:           1,
+		ReportedType: enum.ReportTypeComment,
+		ReportedID:   20,
+		Reason:       "Spam",
+		Reporter:     mock.AryaStark,
+		PostNumber:   1,
+		PostSlug:     "add-support-for-typescript",
+	}
+
+	worker := mock.NewWorker()
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutReportResolved(report, enum.ReportStatusResolved, "Removed"))
+
+	Expect(err).IsNil()
+	Expect(triggerWebhooks.Type).Equals(enum.WebhookReportResolved)
+	Expect(triggerWebhooks.Props["resolutionNote"]).Equals("Removed")
+
+	Expect(usersToNotify).HasLen(3)
+	for _, q := range usersToNotify {
+		Expect(q.Event.UserSettingsKeyName).Equals(enum.NotificationEventReportOutcome.UserSettingsKeyName)
+		Expect(q.UserIDs).Equals([]int{mock.AryaStark.ID})
+	}
+
+	Expect(addNewNotification).IsNotNil()
+	Expect(addNewNotification.User).Equals(mock.AryaStark)
+	Expect(addNewNotification.Link).Equals("/posts/1/add-support-for-typescript#comment-20")
+	Expect(addNewNotification.Title).Equals("Thanks for your report. Our moderators took action on the comment you reported.")
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("report_outcome")
+	Expect(emailmock.MessageHistory[0].Props).Equals(dto.Props{
+		"siteName": "Demonstration",
+		"outcome":  "actioned",
+		"type":     "comment",
+		"view":     "<a href='http://domain.com/posts/1/add-support-for-typescript#comment-20'>view it on your browser</a>",
+		"change":   "<a href='http://domain.com/profile#settings'>change your notification preferences</a>",
+		"logo":     "https://fider.io/images/logo-100x100.png",
+	})
+	Expect(emailmock.MessageHistory[0].To).HasLen(1)
+	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventReportOutcome),
+	})
+}
+
+func TestNotifyAboutReportResolvedTask_AutomatedReport(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	dispatched := false
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		dispatched = true
+		return nil
+	})
+
+	report := &entity.Report{ID: 1, ReportedType: enum.ReportTypePost, ReportedID: 10, Reason: "Probation review"}
+
+	worker := mock.NewWorker()
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutReportResolved(report, enum.ReportStatusDismissed, ""))
+
+	Expect(err).IsNil()
+	Expect(dispatched).IsFalse()
+	Expect(emailmock.MessageHistory).HasLen(0)
+}
+
+func TestNotifyAboutReportCaseResolvedTask_Dismissed(t *testing.T) {
+	RegisterT(t)
+	bus.Init(emailmock.Service{})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.AddNewNotification) error {
+		return nil
+	})
+
+	var emailUsers *query.GetUsersToNotify
+	bus.AddHandler(func(ctx context.Context, q *query.GetUsersToNotify) error {
+		if q.Channel == enum.NotificationChannelEmail {
+			emailUsers = q
+			q.Result = []*entity.User{mock.AryaStark, mock.JonSnow}
+		}
+		return nil
+	})
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
+	reportCase := &entity.ReportCase{
+		ID:           5,
+		ReportedType: enum.ReportTypePost,
+		ReportedID:   10,
+		PostNumber:   1,
+		PostSlug:     "add-support-for-typescript",
+		Reports: []*entity.Report{
+			{ID: 1, Reporter: mock.AryaStark},
+			{ID: 2, Reporter: mock.JonSnow},
+			{ID: 3, Reporter: mock.AryaStark},
+		},
+	}
+
+	worker := mock.NewWorker()
+	err := worker.
+		OnTenant(mock.DemoTenant).
+		AsUser(mock.JonSnow).
+		WithBaseURL("http://domain.com").
+		Execute(tasks.NotifyAboutReportCaseResolved(reportCase, enum.ReportStatusDismissed, ""))
+
+	Expect(err).IsNil()
+	Expect(emailUsers.UserIDs).Equals([]int{mock.AryaStark.ID, mock.JonSnow.ID})
+
+	Expect(emailmock.MessageHistory).HasLen(1)
+	Expect(emailmock.MessageHistory[0].TemplateName).Equals("report_outcome")
+	Expect(emailmock.MessageHistory[0].Props["outcome"]).Equals("dismissed")
+	Expect(emailmock.MessageHistory[0].Props["view"]).Equals("<a href='http://domain.com/posts/1/add-support-for-typescript'>view it on your browser</a>")
+	Expect(emailmock.MessageHistory[0].To).HasLen(2)
+}
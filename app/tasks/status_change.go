@@ -63,7 +63,7 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 		pushIcon := baseURL + "/static/favicon?size=200"
 		pushURL := baseURL + link
 		pushTag := fmt.Sprintf("status-%d", post.Number)
-		sendPushNotifications(c, pushUsers, 0, pushTitle, pushBody, pushURL, pushIcon, pushTag)
+		sendPushNotifications(c, pushUsers, 0, enum.NotificationEventChangeStatus, pushTitle, pushBody, pushURL, pushIcon, pushTag)
 
 		// Email notification
 		if !env.Config.Email.DisableEmailNotifications {
@@ -79,7 +79,7 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 
 			to := make([]dto.Recipient, 0)
 			for _, user := range users {
-				to = append(to, dto.NewRecipient(user.Name, user.Email, dto.Props{}))
+				to = append(to, notificationRecipient(c, user, enum.NotificationEventChangeStatus))
 			}
 
 			props := dto.Props{
@@ -95,7 +95,7 @@ func NotifyAboutStatusChange(post *entity.Post, prevStatus enum.PostStatus) work
 				"logo":        logoURL,
 			}
 
-			bus.Publish(c, &cmd.SendMail{
+			publishNotificationMail(c, &cmd.SendMail{
 				From:         dto.Recipient{Name: author.Name},
 				To:           to,
 				TemplateName: "change_status",
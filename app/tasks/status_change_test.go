@@ -38,6 +38,10 @@ func TestNotifyAboutStatusChangeTask(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -89,9 +93,10 @@ func TestNotifyAboutStatusChangeTask(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Arya Stark",
-		Address: "arya.stark@got.com",
-		Props:   dto.Props{},
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventChangeStatus),
 	})
 
 	Expect(addNewNotification).IsNotNil()
@@ -151,6 +156,10 @@ func TestNotifyAboutStatusChangeTask_Duplicate(t *testing.T) {
 		return nil
 	})
 
+	bus.AddHandler(func(ctx context.Context, q *query.GetQuietHours) error {
+		return nil
+	})
+
 	var triggerWebhooks *cmd.TriggerWebhooks
 	bus.AddHandler(func(ctx context.Context, c *cmd.TriggerWebhooks) error {
 		triggerWebhooks = c
@@ -206,9 +215,10 @@ func TestNotifyAboutStatusChangeTask_Duplicate(t *testing.T) {
 	})
 	Expect(emailmock.MessageHistory[0].To).HasLen(1)
 	Expect(emailmock.MessageHistory[0].To[0]).Equals(dto.Recipient{
-		Name:    "Arya Stark",
-		Address: "arya.stark@got.com",
-		Props:   dto.Props{},
+		Name:        "Arya Stark",
+		Address:     "arya.stark@got.com",
+		Props:       dto.Props{},
+		Unsubscribe: unsubscribeURL(mock.AryaStark, enum.NotificationEventChangeStatus),
 	})
 
 	Expect(addNewNotification).IsNotNil()
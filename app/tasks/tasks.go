@@ -2,19 +2,33 @@ package tasks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/env"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/log"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/markdown"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/replymail"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/webpush"
 	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
 )
 
+// events whose web notifications on the same post are collapsed into one while unread
+const (
+	notificationGroupNewComment = "new_comment"
+	notificationGroupNewMention = "new_mention"
+)
+
 func describe(name string, job worker.Job) worker.Task {
 	return worker.Task{Name: name, Job: job}
 }
@@ -45,7 +59,100 @@ func getActiveSubscribers(ctx context.Context, post *entity.Post, channel enum.N
 	return q.Result, err
 }
 
-func sendPushNotifications(ctx context.Context, users []*entity.User, excludeUserID int, title, body, url, icon, tag string) {
+// notificationRecipient returns the recipient of a notification email, which can unsubscribe from the event in one click
+func notificationRecipient(ctx context.Context, user *entity.User, event enum.NotificationEvent) dto.Recipient {
+	recipient := dto.NewRecipient(user.Name, user.Email, dto.Props{})
+
+	// the token does not expire, unsubscribe links of old emails are expected to keep working
+	token, err := jwt.Encode(&jwt.UnsubscribeClaims{
+		UserID: user.ID,
+		Event:  event.UserSettingsKeyName,
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return recipient
+	}
+
+	recipient.Unsubscribe = web.BaseURL(ctx) + "/unsubscribe?token=" + token
+	return recipient
+}
+
+// commentRecipient returns the recipient of a comment email, which can be answered to post a reply when reply-by-email is enabled
+func commentRecipient(ctx context.Context, user *entity.User, post *entity.Post, event enum.NotificationEvent) dto.Recipient {
+	recipient := notificationRecipient(ctx, user, event)
+	if !env.IsReplyByEmailEnabled() {
+		return recipient
+	}
+
+	token, err := jwt.Encode(&jwt.ReplyClaims{
+		PostNumber: post.Number,
+		UserID:     user.ID,
+		Metadata: jwt.Metadata{
+			ExpiresAt: jwt.Time(time.Now().Add(30 * 24 * time.Hour)),
+		},
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return recipient
+	}
+
+	recipient.ReplyTo = replymail.Address(token, env.Config.Email.Inbound.ReplyDomain)
+	recipient.Props["canReply"] = true
+	return recipient
+}
+
+// queueDigestItems stores the event for the subscribers that receive it in their periodic digest email
+func queueDigestItems(c *worker.Context, post *entity.Post, event enum.NotificationEvent, content, link string) error {
+	if env.Config.Email.DisableEmailNotifications {
+		return nil
+	}
+
+	users, err := getActiveSubscribers(c, post, enum.NotificationChannelDigest, event)
+	if err != nil {
+		return err
+	}
+
+	author := c.User()
+	to := make([]*entity.User, 0, len(users))
+	for _, user := range users {
+		if user.ID != author.ID {
+			to = append(to, user)
+		}
+	}
+
+	if len(to) == 0 {
+		return nil
+	}
+
+	return bus.Dispatch(c, &cmd.QueueDigestItems{
+		Users:      to,
+		PostID:     post.ID,
+		Event:      event.UserSettingsKeyName,
+		AuthorName: author.Name,
+		Excerpt:    truncateText(markdown.PlainText(content), 300),
+		BaseURL:    web.BaseURL(c),
+		Link:       link,
+	})
+}
+
+// pushDelivery is how long push notifications of an event are worth delivering to devices that are offline, and how urgently
+var pushDelivery = map[string]webpush.Options{
+	enum.NotificationEventNewPost.UserSettingsKeyName:         {TTL: 24 * 60 * 60, Urgency: webpush.UrgencyLow},
+	enum.NotificationEventNewComment.UserSettingsKeyName:      {TTL: 24 * 60 * 60, Urgency: webpush.UrgencyNormal},
+	enum.NotificationEventMention.UserSettingsKeyName:         {TTL: 3 * 24 * 60 * 60, Urgency: webpush.UrgencyHigh},
+	enum.NotificationEventChangeStatus.UserSettingsKeyName:    {TTL: 3 * 24 * 60 * 60, Urgency: webpush.UrgencyNormal},
+	enum.NotificationEventReportOutcome.UserSettingsKeyName:   {TTL: 7 * 24 * 60 * 60, Urgency: webpush.UrgencyLow},
+	enum.NotificationEventPostLocked.UserSettingsKeyName:      {TTL: 3 * 24 * 60 * 60, Urgency: webpush.UrgencyNormal},
+	enum.NotificationEventPostArchived.UserSettingsKeyName:    {TTL: 7 * 24 * 60 * 60, Urgency: webpush.UrgencyLow},
+	enum.NotificationEventPostTagged.UserSettingsKeyName:      {TTL: 24 * 60 * 60, Urgency: webpush.UrgencyLow},
+	enum.NotificationEventContentHidden.UserSettingsKeyName:   {TTL: 3 * 24 * 60 * 60, Urgency: webpush.UrgencyNormal},
+	enum.NotificationEventContentApproved.UserSettingsKeyName: {TTL: 3 * 24 * 60 * 60, Urgency: webpush.UrgencyNormal},
+}
+
+// maxPushAttempts is how many times a push notification is sent to a subscription that keeps failing with a retryable error
+const maxPushAttempts = 4
+
+func sendPushNotifications(c *worker.Context, users []*entity.User, excludeUserID int, event enum.NotificationEvent, title, body, url, icon, tag string) {
 	if !env.IsWebPushEnabled() || len(users) == 0 {
 		return
 	}
@@ -61,40 +168,147 @@ func sendPushNotifications(ctx context.Context, users []*entity.User, excludeUse
 		return
 	}
 
-	q := &query.GetPushSubscriptionsByUsers{UserIDs: userIDs}
-	if err := bus.Dispatch(ctx, q); err != nil {
-		log.Error(ctx, err)
+	options, ok := pushDelivery[event.UserSettingsKeyName]
+	if !ok {
+		options = webpush.Options{TTL: 24 * 60 * 60, Urgency: webpush.UrgencyNormal}
+	}
+	// the tag already identifies what the notification is about, so a newer one replaces the stale one on the push service
+	options.Topic = tag
+
+	msg := &webpush.Message{
+		Notification: &webpush.Notification{
+			Title: title,
+			Body:  body,
+			Icon:  icon,
+			URL:   url,
+			Tag:   tag,
+		},
+		Options: options,
+	}
+
+	// users in their quiet hours get the notification once the window ends
+	quiet := getQuietHours(c, &query.GetQuietHours{UserIDs: userIDs})
+	if len(quiet) > 0 {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			log.Error(c, err)
+		} else {
+			now := time.Now()
+			deliverNow := make([]int, 0, len(userIDs))
+			for _, userID := range userIDs {
+				q, ok := quiet[userID]
+				if !ok || !deferNotification(c, userID, enum.NotificationChannelPush, string(payload), q.ReleaseAt(now)) {
+					deliverNow = append(deliverNow, userID)
+				}
+			}
+			userIDs = deliverNow
+		}
+	}
+
+	failed := webpush.Deliver(c, userIDs, msg)
+	retryPushNotification(c, failed, msg, 1)
+}
+
+// retryPushNotification sends the message again to the subscriptions that failed, waiting longer after every attempt
+func retryPushNotification(c *worker.Context, subs []*entity.PushSubscription, msg *webpush.Message, attempt int) {
+	if len(subs) == 0 {
 		return
 	}
 
-	if len(q.Result) == 0 {
+	if attempt >= maxPushAttempts {
+		log.Warnf(c, "Giving up on push notification to @{Count} subscription(s) after @{Attempts} attempts", dto.Props{
+			"Count":    len(subs),
+			"Attempts": attempt,
+		})
 		return
 	}
 
-	notification := &webpush.Notification{
-		Title: title,
-		Body:  body,
-		Icon:  icon,
-		URL:   url,
-		Tag:   tag,
+	// 30 seconds, 2 minutes and 8 minutes
+	delay := 30 * time.Second << (2 * (attempt - 1))
+	c.EnqueueAfter(delay, describe("Retry push notification", func(c *worker.Context) error {
+		failed := webpush.DeliverTo(c, subs, msg)
+		retryPushNotification(c, failed, msg, attempt+1)
+		return nil
+	}))
+}
+
+// publishNotificationMail sends a notification email, holding back the copies of recipients that are in their quiet hours
+func publishNotificationMail(ctx context.Context, msg *cmd.SendMail) {
+	emails := make([]string, 0, len(msg.To))
+	for _, to := range msg.To {
+		if to.Address != "" {
+			emails = append(emails, to.Address)
+		}
 	}
 
-	for _, sub := range q.Result {
-		pushSub := &webpush.Subscription{
-			Endpoint: sub.Endpoint,
+	quietByEmail := make(map[string]*entity.QuietHours)
+	if len(emails) > 0 {
+		for _, q := range getQuietHours(ctx, &query.GetQuietHours{Emails: emails}) {
+			quietByEmail[strings.ToLower(q.Email)] = q
 		}
-		pushSub.Keys.P256dh = sub.KeyP256dh
-		pushSub.Keys.Auth = sub.KeyAuth
+	}
 
-		err := webpush.SendNotification(ctx, pushSub, notification, 86400)
-		if err != nil {
-			if webpush.IsSubscriptionExpired(err) {
-				bus.Dispatch(ctx, &cmd.DeletePushSubscriptionByEndpoint{
-					Endpoint: sub.Endpoint,
-				})
-			} else {
-				log.Warn(ctx, fmt.Sprintf("failed to send push notification: %s", err.Error()))
+	if len(quietByEmail) > 0 {
+		now := time.Now()
+		sendNow := make([]dto.Recipient, 0, len(msg.To))
+		for _, to := range msg.To {
+			q, ok := quietByEmail[strings.ToLower(to.Address)]
+			if !ok {
+				sendNow = append(sendNow, to)
+				continue
+			}
+
+			single := *msg
+			single.To = []dto.Recipient{to}
+			payload, err := single.MarshalDeferred()
+			if err != nil {
+				log.Error(ctx, err)
+				sendNow = append(sendNow, to)
+				continue
 			}
+			if !deferNotification(ctx, q.UserID, enum.NotificationChannelEmail, payload, q.ReleaseAt(now)) {
+				sendNow = append(sendNow, to)
+			}
+		}
+
+		if len(sendNow) == 0 {
+			return
 		}
+		msg.To = sendNow
+	}
+
+	bus.Publish(ctx, msg)
+}
+
+// getQuietHours returns the quiet hours found by q keyed by user id, errors are logged so that notifications are sent right away instead
+func getQuietHours(ctx context.Context, q *query.GetQuietHours) map[int]*entity.QuietHours {
+	result := make(map[int]*entity.QuietHours)
+	if err := bus.Dispatch(ctx, q); err != nil {
+		log.Error(ctx, err)
+		return result
+	}
+	for _, quiet := range q.Result {
+		result[quiet.UserID] = quiet
+	}
+	return result
+}
+
+// deferNotification holds the notification back until releaseAt and returns false if it has to be sent right away
+func deferNotification(ctx context.Context, userID int, channel enum.NotificationChannel, payload string, releaseAt time.Time) bool {
+	if !releaseAt.After(time.Now()) {
+		return false
+	}
+
+	err := bus.Dispatch(ctx, &cmd.DeferNotification{
+		UserID:    userID,
+		Channel:   channel,
+		Payload:   payload,
+		BaseURL:   web.BaseURL(ctx),
+		ReleaseAt: releaseAt,
+	})
+	if err != nil {
+		log.Error(ctx, err)
+		return false
 	}
+	return true
 }
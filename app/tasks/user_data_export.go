@@ -0,0 +1,70 @@
+package tasks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/dto"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/backup"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/errors"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/jwt"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/rand"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/web"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
+)
+
+// ExportUserData assembles the personal data export of the requestor and emails them a link to download it
+func ExportUserData(export *entity.UserDataExport) worker.Task {
+	return describe("Export user data", func(c *worker.Context) error {
+		user := c.User()
+
+		file, err := backup.CreateUserExport(c, user)
+		if err != nil {
+			return c.Failure(errors.Wrap(err, "failed to create data export of user '%d'", user.ID))
+		}
+
+		// blobs can be viewed by their key, so it must not be guessable
+		bkey := fmt.Sprintf("%s%s.zip", backup.UserExportsPrefix, rand.String(40))
+		if err := bus.Dispatch(c, &cmd.StoreBlob{Key: bkey, Content: file.Bytes(), ContentType: "application/zip"}); err != nil {
+			return c.Failure(err)
+		}
+
+		expiresAt := time.Now().Add(entity.UserDataExportLifetime)
+		if err := bus.Dispatch(c, &cmd.CompleteUserDataExport{ID: export.ID, BlobKey: bkey, ExpiresAt: expiresAt}); err != nil {
+			return c.Failure(err)
+		}
+
+		token, err := jwt.Encode(&jwt.UserDataExportClaims{
+			ExportID: export.ID,
+			UserID:   user.ID,
+			TenantID: c.Tenant().ID,
+			Metadata: jwt.Metadata{
+				ExpiresAt: jwt.Time(expiresAt),
+			},
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		to := dto.NewRecipient(user.Name, user.Email, dto.Props{
+			"name": user.Name,
+			"link": link(web.BaseURL(c), "/user/export/download?token=%s", token),
+			"days": int(entity.UserDataExportLifetime.Hours() / 24),
+		})
+
+		bus.Publish(c, &cmd.SendMail{
+			From:         dto.Recipient{Name: c.Tenant().Name},
+			To:           []dto.Recipient{to},
+			TemplateName: "user_data_export",
+			Props: dto.Props{
+				"siteName": c.Tenant().Name,
+				"logo":     web.LogoURL(c),
+			},
+		})
+
+		return nil
+	})
+}
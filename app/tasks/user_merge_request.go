@@ -0,0 +1,43 @@
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/cmd"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/entity"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/enum"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/models/query"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/bus"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/i18n"
+	"github.com/Spicy-Bush/fider-tarkov-community/app/pkg/worker"
+)
+
+// NotifyAboutUserMergeRequestDecision lets the user who raised a merge request know how staff decided on it
+func NotifyAboutUserMergeRequestDecision(request *entity.UserMergeRequest, status enum.UserMergeRequestStatus) worker.Task {
+	return describe("Notify about merge request decision", func(c *worker.Context) error {
+		provider := request.Provider
+		providers := &query.ListAllOAuthProviders{}
+		if err := bus.Dispatch(c, providers); err == nil {
+			for _, p := range providers.Result {
+				if p.Provider == request.Provider {
+					provider = p.DisplayName
+				}
+			}
+		}
+
+		title := i18n.T(c, fmt.Sprintf("web.merge_request_decided.%s.text", status.String()), i18n.Params{
+			"provider": provider,
+		})
+
+		err := bus.Dispatch(c, &cmd.AddNewNotification{
+			User:  request.User,
+			Title: title,
+			Link:  "/profile#settings",
+		})
+		if err != nil {
+			return c.Failure(err)
+		}
+
+		return nil
+	})
+}
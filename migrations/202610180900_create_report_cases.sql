@@ -0,0 +1,58 @@
+CREATE TABLE report_cases (
+    id              SERIAL PRIMARY KEY,
+    tenant_id       INT NOT NULL REFERENCES tenants(id),
+    reported_type   VARCHAR(20) NOT NULL,
+    reported_id     INT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    auto_hidden_at  TIMESTAMPTZ,
+    resolved_at     TIMESTAMPTZ,
+    resolved_by     INT REFERENCES users(id),
+    resolution_note TEXT
+);
+
+ALTER TABLE report_cases ADD CONSTRAINT report_cases_type_check CHECK (reported_type IN ('post', 'comment'));
+ALTER TABLE report_cases ADD CONSTRAINT report_cases_status_check CHECK (status IN ('pending', 'in_review', 'resolved', 'dismissed'));
+
+-- only one open case may exist per reported item
+CREATE UNIQUE INDEX idx_report_cases_open_target ON report_cases(tenant_id, reported_type, reported_id) WHERE status IN ('pending', 'in_review');
+CREATE INDEX idx_report_cases_tenant_status ON report_cases(tenant_id, status);
+
+ALTER TABLE reports ADD COLUMN case_id INT REFERENCES report_cases(id);
+CREATE INDEX idx_reports_case ON reports(case_id);
+
+-- open reports on the same item are merged into a single case
+INSERT INTO report_cases (tenant_id, reported_type, reported_id, status, created_at, updated_at)
+SELECT tenant_id, reported_type, reported_id,
+       CASE WHEN bool_or(status = 'in_review') THEN 'in_review' ELSE 'pending' END,
+       MIN(created_at), MAX(created_at)
+FROM reports
+WHERE status IN ('pending', 'in_review')
+GROUP BY tenant_id, reported_type, reported_id;
+
+UPDATE reports r
+SET case_id = rc.id
+FROM report_cases rc
+WHERE r.status IN ('pending', 'in_review')
+AND rc.tenant_id = r.tenant_id
+AND rc.reported_type = r.reported_type
+AND rc.reported_id = r.reported_id
+AND rc.status IN ('pending', 'in_review');
+
+-- closed reports keep their own history as single-report cases
+WITH closed AS (
+    SELECT id AS report_id, nextval('report_cases_id_seq') AS case_id,
+           tenant_id, reported_type, reported_id, status, created_at, resolved_at, resolved_by, resolution_note
+    FROM reports
+    WHERE status IN ('resolved', 'dismissed')
+), inserted AS (
+    INSERT INTO report_cases (id, tenant_id, reported_type, reported_id, status, created_at, updated_at, resolved_at, resolved_by, resolution_note)
+    SELECT case_id, tenant_id, reported_type, reported_id, status, created_at, COALESCE(resolved_at, created_at), resolved_at, resolved_by, resolution_note
+    FROM closed
+    RETURNING id
+)
+UPDATE reports r
+SET case_id = closed.case_id
+FROM closed
+WHERE r.id = closed.report_id;
@@ -0,0 +1,12 @@
+CREATE TABLE user_block_proposals (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    user_id     INT NOT NULL REFERENCES users(id),
+    reason      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_by  INT REFERENCES users(id),
+    resolved_at TIMESTAMPTZ
+);
+
+-- a user has at most one block proposal waiting for staff
+CREATE UNIQUE INDEX idx_user_block_proposals_open ON user_block_proposals(tenant_id, user_id) WHERE resolved_at IS NULL;
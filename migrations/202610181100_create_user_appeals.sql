@@ -0,0 +1,26 @@
+CREATE TABLE user_appeals (
+    id              SERIAL PRIMARY KEY,
+    tenant_id       INT NOT NULL REFERENCES tenants(id),
+    user_id         INT NOT NULL REFERENCES users(id),
+    sanction_type   VARCHAR(20) NOT NULL,
+    sanction_id     INT,
+    sanction_reason TEXT NOT NULL DEFAULT '',
+    text            TEXT NOT NULL,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at      TIMESTAMPTZ,
+    decided_by      INT REFERENCES users(id),
+    decision_note   TEXT,
+    reduced_until   TIMESTAMPTZ,
+    replacement_id  INT
+);
+
+ALTER TABLE user_appeals ADD CONSTRAINT user_appeals_type_check CHECK (sanction_type IN ('warning', 'mute', 'block'));
+ALTER TABLE user_appeals ADD CONSTRAINT user_appeals_status_check CHECK (status IN ('pending', 'upheld', 'reduced', 'lifted'));
+
+-- a warning or mute can only be appealed once
+CREATE UNIQUE INDEX idx_user_appeals_sanction ON user_appeals(tenant_id, sanction_type, sanction_id) WHERE sanction_id IS NOT NULL;
+-- a block can only be appealed again once a previous appeal has lifted it
+CREATE UNIQUE INDEX idx_user_appeals_block ON user_appeals(tenant_id, user_id) WHERE sanction_type = 'block' AND status IN ('pending', 'upheld');
+CREATE INDEX idx_user_appeals_tenant_status ON user_appeals(tenant_id, status);
+CREATE INDEX idx_user_appeals_user ON user_appeals(tenant_id, user_id);
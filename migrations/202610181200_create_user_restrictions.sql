@@ -0,0 +1,55 @@
+CREATE TABLE user_restrictions (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    user_id     INT NOT NULL REFERENCES users(id),
+    kind        VARCHAR(20) NOT NULL CHECK (kind IN ('shadowban', 'probation')),
+    reason      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ,
+    created_by  INT NOT NULL REFERENCES users(id)
+);
+
+CREATE INDEX idx_user_restrictions_user_tenant_expires ON user_restrictions (user_id, tenant_id, expires_at);
+
+-- content created while its author is shadow-banned stays visible only to the author and staff
+ALTER TABLE posts ADD COLUMN shadow_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comments ADD COLUMN shadow_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_posts_shadow_hidden ON posts(id) WHERE shadow_hidden = TRUE;
+CREATE INDEX idx_comments_shadow_hidden ON comments(id) WHERE shadow_hidden = TRUE;
+
+-- shadow-hidden comments are left out of the public comment counters
+CREATE OR REPLACE FUNCTION update_post_comment_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NOT NEW.shadow_hidden THEN
+            UPDATE posts SET comments_count = comments_count + 1
+            WHERE id = NEW.post_id AND tenant_id = NEW.tenant_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF (OLD.deleted_at IS NULL AND NOT OLD.shadow_hidden) AND NOT (NEW.deleted_at IS NULL AND NOT NEW.shadow_hidden) THEN
+            UPDATE posts SET comments_count = comments_count - 1
+            WHERE id = NEW.post_id AND tenant_id = NEW.tenant_id;
+        ELSIF NOT (OLD.deleted_at IS NULL AND NOT OLD.shadow_hidden) AND (NEW.deleted_at IS NULL AND NOT NEW.shadow_hidden) THEN
+            UPDATE posts SET comments_count = comments_count + 1
+            WHERE id = NEW.post_id AND tenant_id = NEW.tenant_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        IF OLD.deleted_at IS NULL AND NOT OLD.shadow_hidden THEN
+            UPDATE posts SET comments_count = comments_count - 1
+            WHERE id = OLD.post_id AND tenant_id = OLD.tenant_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_post_comments_count ON comments;
+CREATE TRIGGER trg_post_comments_count
+    AFTER INSERT OR UPDATE OF deleted_at, shadow_hidden OR DELETE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION update_post_comment_count();
@@ -0,0 +1,15 @@
+CREATE TABLE notification_digest_items (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    user_id     INT NOT NULL REFERENCES users(id),
+    post_id     INT NOT NULL REFERENCES posts(id),
+    event       VARCHAR(100) NOT NULL,
+    author_name VARCHAR(100) NOT NULL,
+    excerpt     TEXT NOT NULL DEFAULT '',
+    base_url    TEXT NOT NULL,
+    link        TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notification_digest_items_user ON notification_digest_items(tenant_id, user_id, created_at);
+CREATE INDEX idx_notification_digest_items_created_at ON notification_digest_items(created_at);
@@ -0,0 +1,6 @@
+ALTER TABLE notifications ADD COLUMN event VARCHAR(100) NULL;
+ALTER TABLE notifications ADD COLUMN actor_ids INT[] NOT NULL DEFAULT '{}';
+
+UPDATE notifications SET actor_ids = ARRAY[author_id] WHERE author_id IS NOT NULL;
+
+CREATE INDEX idx_notifications_unread_group ON notifications(tenant_id, user_id, post_id, event) WHERE read = false AND event IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE user_tag_preferences (
+    tenant_id  INT NOT NULL REFERENCES tenants(id),
+    user_id    INT NOT NULL REFERENCES users(id),
+    tag_id     INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    preference SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX idx_user_tag_preferences_tag ON user_tag_preferences(tenant_id, tag_id, preference);
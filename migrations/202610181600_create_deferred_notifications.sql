@@ -0,0 +1,12 @@
+CREATE TABLE deferred_notifications (
+    id         SERIAL PRIMARY KEY,
+    tenant_id  INT NOT NULL REFERENCES tenants(id),
+    user_id    INT NOT NULL REFERENCES users(id),
+    channel    SMALLINT NOT NULL,
+    payload    TEXT NOT NULL,
+    base_url   TEXT NOT NULL,
+    release_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_deferred_notifications_release_at ON deferred_notifications(release_at);
@@ -0,0 +1,3 @@
+ALTER TABLE push_subscriptions ADD COLUMN last_success_at TIMESTAMPTZ NULL;
+ALTER TABLE push_subscriptions ADD COLUMN last_failure_at TIMESTAMPTZ NULL;
+ALTER TABLE push_subscriptions ADD COLUMN failure_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+CREATE TABLE email_templates (
+    id            SERIAL PRIMARY KEY,
+    tenant_id     INT NOT NULL REFERENCES tenants(id),
+    name          VARCHAR(100) NOT NULL,
+    locale        VARCHAR(10) NOT NULL,
+    subject       TEXT NOT NULL,
+    body          TEXT NOT NULL,
+    updated_by_id INT NOT NULL REFERENCES users(id),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_email_templates_tenant_name_locale ON email_templates(tenant_id, name, locale);
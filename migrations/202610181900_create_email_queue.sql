@@ -0,0 +1,19 @@
+CREATE TABLE email_queue (
+    id              SERIAL PRIMARY KEY,
+    tenant_id       INT NULL REFERENCES tenants(id),
+    template_name   VARCHAR(100) NOT NULL,
+    recipient       VARCHAR(200) NOT NULL,
+    payload         TEXT NOT NULL,
+    base_url        TEXT NOT NULL,
+    status          SMALLINT NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    last_error      TEXT NULL,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_attempt_at TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at         TIMESTAMPTZ NULL
+);
+
+CREATE INDEX idx_email_queue_status_next_attempt_at ON email_queue(status, next_attempt_at);
+CREATE INDEX idx_email_queue_last_attempt_at ON email_queue(last_attempt_at);
+CREATE INDEX idx_email_queue_tenant_id_created_at ON email_queue(tenant_id, created_at);
@@ -0,0 +1,16 @@
+CREATE TABLE user_sessions (
+    id           SERIAL PRIMARY KEY,
+    tenant_id    INT NOT NULL REFERENCES tenants(id),
+    user_id      INT NOT NULL REFERENCES users(id),
+    session_id   VARCHAR(64) NOT NULL,
+    ip_address   VARCHAR(45) NULL,
+    user_agent   TEXT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    revoked_at   TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_user_sessions_session_id ON user_sessions(session_id);
+CREATE INDEX idx_user_sessions_tenant_id_user_id ON user_sessions(tenant_id, user_id);
+CREATE INDEX idx_user_sessions_expires_at ON user_sessions(expires_at);
@@ -0,0 +1,15 @@
+CREATE TABLE user_api_tokens (
+    id           SERIAL PRIMARY KEY,
+    tenant_id    INT NOT NULL REFERENCES tenants(id),
+    user_id      INT NOT NULL REFERENCES users(id),
+    name         VARCHAR(100) NOT NULL,
+    token_hash   VARCHAR(64) NOT NULL,
+    token_prefix VARCHAR(12) NOT NULL,
+    scopes       TEXT[] NOT NULL,
+    expires_at   TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_user_api_tokens_token_hash ON user_api_tokens(token_hash);
+CREATE INDEX idx_user_api_tokens_tenant_id_user_id ON user_api_tokens(tenant_id, user_id);
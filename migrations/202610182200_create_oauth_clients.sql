@@ -0,0 +1,47 @@
+CREATE TABLE oauth_clients (
+    id                 SERIAL PRIMARY KEY,
+    tenant_id          INT NOT NULL REFERENCES tenants(id),
+    client_id          VARCHAR(40) NOT NULL,
+    client_secret_hash VARCHAR(64) NULL,
+    name               VARCHAR(100) NOT NULL,
+    redirect_uris      TEXT[] NOT NULL,
+    scopes             TEXT[] NOT NULL,
+    created_by_id      INT NOT NULL REFERENCES users(id),
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_oauth_clients_client_id ON oauth_clients(client_id);
+CREATE INDEX idx_oauth_clients_tenant_id ON oauth_clients(tenant_id);
+
+CREATE TABLE oauth_authorization_codes (
+    id              SERIAL PRIMARY KEY,
+    tenant_id       INT NOT NULL REFERENCES tenants(id),
+    client_id       INT NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    user_id         INT NOT NULL REFERENCES users(id),
+    code_hash       VARCHAR(64) NOT NULL,
+    redirect_uri    TEXT NOT NULL,
+    scopes          TEXT[] NOT NULL,
+    code_challenge  VARCHAR(128) NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMPTZ NOT NULL,
+    used_at         TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_oauth_authorization_codes_code_hash ON oauth_authorization_codes(code_hash);
+CREATE INDEX idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes(expires_at);
+
+CREATE TABLE oauth_refresh_tokens (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    client_id   INT NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id),
+    token_hash  VARCHAR(64) NOT NULL,
+    scopes      TEXT[] NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL,
+    revoked_at  TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_oauth_refresh_tokens_token_hash ON oauth_refresh_tokens(token_hash);
+CREATE INDEX idx_oauth_refresh_tokens_tenant_id_user_id ON oauth_refresh_tokens(tenant_id, user_id);
+CREATE INDEX idx_oauth_refresh_tokens_expires_at ON oauth_refresh_tokens(expires_at);
@@ -0,0 +1,18 @@
+CREATE TABLE user_merge_requests (
+    id           SERIAL PRIMARY KEY,
+    tenant_id    INT NOT NULL REFERENCES tenants(id),
+    user_id      INT NOT NULL REFERENCES users(id),
+    owner_id     INT NOT NULL REFERENCES users(id),
+    provider     VARCHAR(40) NOT NULL,
+    provider_uid VARCHAR(100) NOT NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at   TIMESTAMPTZ,
+    decided_by   INT REFERENCES users(id)
+);
+
+ALTER TABLE user_merge_requests ADD CONSTRAINT user_merge_requests_status_check CHECK (status IN ('pending', 'approved', 'rejected'));
+
+-- linking the same identity again while staff haven't decided doesn't raise another request
+CREATE UNIQUE INDEX idx_user_merge_requests_pending ON user_merge_requests(tenant_id, user_id, provider, provider_uid) WHERE status = 'pending';
+CREATE INDEX idx_user_merge_requests_tenant_status ON user_merge_requests(tenant_id, status);
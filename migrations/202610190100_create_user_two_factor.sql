@@ -0,0 +1,21 @@
+CREATE TABLE user_two_factor (
+    tenant_id       INT NOT NULL REFERENCES tenants(id),
+    user_id         INT NOT NULL REFERENCES users(id),
+    secret          VARCHAR(64) NOT NULL,
+    last_used_step  BIGINT NOT NULL DEFAULT 0,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    enabled_at      TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+CREATE TABLE user_recovery_codes (
+    id         SERIAL PRIMARY KEY,
+    tenant_id  INT NOT NULL REFERENCES tenants(id),
+    user_id    INT NOT NULL REFERENCES users(id),
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_recovery_codes_tenant_id_user_id ON user_recovery_codes(tenant_id, user_id);
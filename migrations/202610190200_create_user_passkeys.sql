@@ -0,0 +1,13 @@
+CREATE TABLE user_passkeys (
+    id            SERIAL PRIMARY KEY,
+    tenant_id     INT NOT NULL REFERENCES tenants(id),
+    user_id       INT NOT NULL REFERENCES users(id),
+    name          VARCHAR(50) NOT NULL,
+    credential_id VARCHAR(512) NOT NULL,
+    credential    JSONB NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at  TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX idx_user_passkeys_tenant_id_credential_id ON user_passkeys(tenant_id, credential_id);
+CREATE INDEX idx_user_passkeys_tenant_id_user_id ON user_passkeys(tenant_id, user_id);
@@ -0,0 +1,20 @@
+CREATE TABLE custom_roles (
+    id          SERIAL PRIMARY KEY,
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    name        VARCHAR(50) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_custom_roles_tenant_id_name ON custom_roles(tenant_id, LOWER(name));
+
+CREATE TABLE user_custom_roles (
+    tenant_id      INT NOT NULL REFERENCES tenants(id),
+    user_id        INT NOT NULL REFERENCES users(id),
+    custom_role_id INT NOT NULL REFERENCES custom_roles(id) ON DELETE CASCADE,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+CREATE INDEX idx_user_custom_roles_custom_role_id ON user_custom_roles(custom_role_id);
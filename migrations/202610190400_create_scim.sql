@@ -0,0 +1,20 @@
+CREATE TABLE tenant_scim_tokens (
+    tenant_id    INT NOT NULL REFERENCES tenants(id),
+    token_hash   VARCHAR(64) NOT NULL,
+    token_prefix VARCHAR(20) NOT NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id)
+);
+
+CREATE UNIQUE INDEX idx_tenant_scim_tokens_token_hash ON tenant_scim_tokens(token_hash);
+
+CREATE TABLE scim_users (
+    tenant_id   INT NOT NULL REFERENCES tenants(id),
+    user_id     INT NOT NULL REFERENCES users(id),
+    external_id VARCHAR(200) NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+CREATE UNIQUE INDEX idx_scim_users_tenant_id_external_id ON scim_users(tenant_id, external_id);
@@ -0,0 +1,11 @@
+CREATE TABLE user_data_exports (
+    id         SERIAL PRIMARY KEY,
+    tenant_id  INT NOT NULL REFERENCES tenants(id),
+    user_id    INT NOT NULL REFERENCES users(id),
+    blob_key   VARCHAR(512) NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_data_exports_tenant_id_user_id ON user_data_exports(tenant_id, user_id);
+CREATE INDEX idx_user_data_exports_expires_at ON user_data_exports(expires_at);
@@ -0,0 +1,17 @@
+-- outcome counts of each reporter, kept up to date as their reports are resolved,
+-- so listing reports doesn't count every report of every reporter on each row
+CREATE TABLE reporter_accuracy (
+    tenant_id INT NOT NULL REFERENCES tenants(id),
+    user_id   INT NOT NULL REFERENCES users(id),
+    resolved  INT NOT NULL DEFAULT 0,
+    dismissed INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, user_id)
+);
+
+INSERT INTO reporter_accuracy (tenant_id, user_id, resolved, dismissed)
+SELECT tenant_id, reporter_id,
+    COUNT(*) FILTER (WHERE status = 'resolved'),
+    COUNT(*) FILTER (WHERE status = 'dismissed')
+FROM reports
+WHERE reporter_id IS NOT NULL
+GROUP BY tenant_id, reporter_id;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN blocked_at TIMESTAMPTZ;
+
+-- an upheld appeal only closes the block it was made against, users blocked again later can appeal again
+DROP INDEX idx_user_appeals_block;
+CREATE UNIQUE INDEX idx_user_appeals_block ON user_appeals(tenant_id, user_id) WHERE sanction_type = 'block' AND status = 'pending';
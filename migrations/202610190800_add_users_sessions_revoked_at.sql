@@ -0,0 +1,2 @@
+-- auth cookies issued before sessions were tracked have no session to revoke, signing out everywhere ends them through this instead
+ALTER TABLE users ADD COLUMN sessions_revoked_at TIMESTAMPTZ;
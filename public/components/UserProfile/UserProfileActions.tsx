@@ -3,16 +3,17 @@
 import React, { useState } from "react"
 import { useUserProfile } from "./context"
 import { Trans } from "@lingui/react/macro"
-import { actions, Failure } from "@fider/services"
+import { i18n } from "@lingui/core"
+import { actions, Failure, notify } from "@fider/services"
 import { UserStatus } from "@fider/models"
-import { ModerationModal, Button } from "@fider/components"
+import { ModerationModal, ModerationActionType, Button } from "@fider/components"
 
 export const UserProfileActions: React.FC = () => {
   const { user, canModerate, canBlock, refreshStanding, refreshUser } = useUserProfile()
 
   const [moderationModal, setModerationModal] = useState({
     isOpen: false,
-    actionType: "mute" as "mute" | "warning",
+    actionType: "mute" as ModerationActionType,
     error: undefined as Failure | undefined,
   })
 
@@ -20,24 +21,30 @@ export const UserProfileActions: React.FC = () => {
 
   const isBlocked = user.status === UserStatus.Blocked || user.status === 2
 
-  const openMuteModal = () => {
+  const openModal = (actionType: ModerationActionType) => {
     setModerationModal({
       isOpen: true,
-      actionType: "mute",
-      error: undefined,
-    })
-  }
-
-  const openWarnModal = () => {
-    setModerationModal({
-      isOpen: true,
-      actionType: "warning",
+      actionType,
       error: undefined,
     })
   }
 
   const handleModeration = async (data: { reason: string; duration: string }) => {
-    if (moderationModal.actionType === "mute") {
+    const actionType = moderationModal.actionType
+    if (actionType === "shadowban" || actionType === "probation") {
+      const result = await actions.restrictUser(user.id, {
+        kind: actionType,
+        reason: data.reason,
+        duration: data.duration,
+      })
+
+      if (result.ok) {
+        setModerationModal(prev => ({ ...prev, isOpen: false }))
+        await refreshStanding()
+      } else if (result.error) {
+        setModerationModal(prev => ({ ...prev, error: result.error }))
+      }
+    } else if (actionType === "mute") {
       const result = await actions.muteUser(user.id, {
         reason: data.reason,
         duration: data.duration,
@@ -65,12 +72,21 @@ export const UserProfileActions: React.FC = () => {
   }
 
   const handleBlockUser = async () => {
-    const result = await actions.blockUser(user.id)
+    const revokeSessions = window.confirm(i18n._("moderation.block.revokesessions", { message: "Also sign this user out of every browser?" }))
+    const result = await actions.blockUser(user.id, revokeSessions)
     if (result.ok) {
       refreshUser()
     }
   }
 
+  const handleRevokeSessions = async () => {
+    if (!window.confirm(i18n._("moderation.revokesessions.confirm", { message: "Sign this user out of every browser?" }))) return
+    const result = await actions.revokeAllSessionsOfUser(user.id)
+    if (result.ok) {
+      notify.success(i18n._("moderation.revokesessions.success", { message: "The user was signed out of every browser." }))
+    }
+  }
+
   const handleUnblockUser = async () => {
     const result = await actions.unblockUser(user.id)
     if (result.ok) {
@@ -94,16 +110,25 @@ export const UserProfileActions: React.FC = () => {
                 <Trans id="action.unblock">Unblock User</Trans>
               </Button>
             )}
+            <Button variant="secondary" onClick={handleRevokeSessions}>
+              <Trans id="action.revokesessions">Sign Out Everywhere</Trans>
+            </Button>
           </div>
         )}
         {canModerate && !isBlocked && (
           <div className="flex gap-2 max-md:flex-wrap max-md:justify-center">
-            <Button variant="danger" onClick={openMuteModal}>
+            <Button variant="danger" onClick={() => openModal("mute")}>
               <Trans id="action.mute">Mute User</Trans>
             </Button>
-            <Button variant="secondary" onClick={openWarnModal}>
+            <Button variant="secondary" onClick={() => openModal("warning")}>
               <Trans id="action.warn">Warn User</Trans>
             </Button>
+            <Button variant="secondary" onClick={() => openModal("probation")}>
+              <Trans id="action.probation">Put on Probation</Trans>
+            </Button>
+            <Button variant="secondary" onClick={() => openModal("shadowban")}>
+              <Trans id="action.shadowban">Shadow-ban User</Trans>
+            </Button>
           </div>
         )}
       </div>
@@ -1,12 +1,49 @@
 // UserProfileDetails converted to Tailwind
 
-import React, { useState } from "react"
+import React, { useEffect, useState } from "react"
 import { Icon, Select, SelectOption } from "@fider/components"
 import { useUserProfile } from "./context"
-import { UserRole, VisualRole } from "@fider/models"
+import { CustomRole, UserRole, VisualRole } from "@fider/models"
 import { actions, Fider } from "@fider/services"
 import { heroiconsChevronDown as IconChevronDown, heroiconsChevronUp as IconChevronUp, heroiconsMail as IconMail, heroiconsIdentification as IconIdentification } from "@fider/icons.generated"
 
+const CustomRoleSelect: React.FC<{ userID: number }> = ({ userID }) => {
+  const [roles, setRoles] = useState<CustomRole[]>()
+  const [current, setCurrent] = useState("")
+  const [isChanging, setIsChanging] = useState(false)
+
+  useEffect(() => {
+    Promise.all([actions.listCustomRoles(), actions.getUserCustomRole(userID)]).then(([list, assigned]) => {
+      if (list.ok && assigned.ok) {
+        setRoles(list.data)
+        setCurrent(assigned.data ? assigned.data.id.toString() : "")
+      }
+    })
+  }, [userID])
+
+  if (!roles || roles.length === 0) return null
+
+  const options: SelectOption[] = [{ label: "None", value: "" }, ...roles.map((r) => ({ label: r.name, value: r.id.toString() }))]
+
+  const handleChange = async (option?: SelectOption) => {
+    if (!option || isChanging) return
+
+    setIsChanging(true)
+    const result = await actions.setUserCustomRole(userID, option.value ? parseInt(option.value, 10) : 0)
+    if (result.ok) {
+      setCurrent(option.value)
+    }
+    setIsChanging(false)
+  }
+
+  return (
+    <div className="flex items-center gap-2 text-sm flex-wrap">
+      <span className="text-border-strong font-medium min-w-[80px]">Custom Role:</span>
+      <Select field="customRole" value={current} options={options} onChange={handleChange} disabled={isChanging} />
+    </div>
+  )
+}
+
 interface UserProfileDetailsProps {
   providers?: { name: string; uid: string }[]
   email?: string
@@ -150,6 +187,8 @@ export const UserProfileDetails: React.FC<UserProfileDetailsProps> = ({
             </div>
           )}
 
+          {canChangeRole && <CustomRoleSelect userID={user.id} />}
+
           {!canChangeRole && (
             <div className="flex items-center gap-2 text-sm">
               <span className="text-border-strong font-medium min-w-[80px]">Role:</span>
@@ -8,6 +8,13 @@ import { actions, Failure, Fider, cache } from "@fider/services"
 import { NotificationSettings } from "@fider/pages/MySettings/components/NotificationSettings"
 import { APIKeyForm } from "@fider/pages/MySettings/components/APIKeyForm"
 import { DangerZone } from "@fider/pages/MySettings/components/DangerZone"
+import { TagPreferences } from "@fider/pages/MySettings/components/TagPreferences"
+import { LinkedAccounts } from "@fider/pages/MySettings/components/LinkedAccounts"
+import { TwoFactor } from "@fider/pages/MySettings/components/TwoFactor"
+import { Passkeys } from "@fider/pages/MySettings/components/Passkeys"
+import { UserSessions } from "@fider/pages/MySettings/components/UserSessions"
+import { APITokens } from "@fider/pages/MySettings/components/APITokens"
+import { DataExport } from "@fider/pages/MySettings/components/DataExport"
 import { heroiconsMail as IconMail, heroiconsBell as IconBell, heroiconsKey as IconKey, heroiconsExclamation as IconWarning, heroiconsAdjustments as IconAdjustments } from "@fider/icons.generated"
 
 const VOTE_POSITION_KEY = "fider_vote_position"
@@ -185,6 +192,8 @@ const UserProfileSettingsComponent: React.FC<UserProfileSettingsProps> = ({ user
         </div>
       </div>
 
+      <TagPreferences />
+
       <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
         <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
           <Icon sprite={IconAdjustments} className="h-5 w-5 text-primary" />
@@ -211,6 +220,16 @@ const UserProfileSettingsComponent: React.FC<UserProfileSettingsProps> = ({ user
         </div>
       </div>
 
+      <LinkedAccounts />
+
+      <TwoFactor />
+
+      <Passkeys />
+
+      <UserSessions />
+
+      <APITokens />
+
       {Fider.session.user.isCollaborator && (
         <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
           <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
@@ -225,6 +244,8 @@ const UserProfileSettingsComponent: React.FC<UserProfileSettingsProps> = ({ user
         </div>
       )}
 
+      <DataExport />
+
       <div className="bg-elevated rounded-card shadow-sm overflow-hidden border border-danger-light">
         <div className="flex items-center gap-2 p-4 border-b border-danger-light bg-danger-light">
           <Icon sprite={IconWarning} className="h-5 w-5 text-danger" />
@@ -9,7 +9,7 @@ import { i18n } from "@lingui/core"
 import { heroiconsCalendar as IconCalendar, heroiconsExclamation as IconWarning, heroiconsMuted as IconVolumeOff, heroiconsThumbsup as IconThumbsUp } from "@fider/icons.generated"
 
 const UserProfileStandingComponent: React.FC = () => {
-  const { user, standing, canDeleteModeration, canModerate, refreshStanding, isViewingOwnProfile } = useUserProfile()
+  const { user, standing, canDeleteModeration, canModerate, canBlock, refreshStanding, isViewingOwnProfile } = useUserProfile()
 
   if (!user) return null
 
@@ -37,6 +37,14 @@ const UserProfileStandingComponent: React.FC = () => {
     }
   }
 
+  const handleLiftRestriction = async (restrictionId: number) => {
+    const result = await actions.liftRestriction(user.id, restrictionId)
+    if (result.ok) {
+      await refreshStanding()
+      notify.success(i18n._("profile.restriction.lift.success", { message: "Restriction has been lifted" }))
+    }
+  }
+
   const handleExpireMute = async (muteId: number) => {
     const result = await actions.expireMute(user.id, muteId)
     if (result.ok) {
@@ -45,7 +53,17 @@ const UserProfileStandingComponent: React.FC = () => {
     }
   }
 
-  if (standing.warnings.length === 0 && standing.mutes.length === 0) {
+  const handleDismissBlockProposal = async () => {
+    const result = await actions.dismissBlockProposal(user.id)
+    if (result.ok) {
+      await refreshStanding()
+      notify.success(i18n._("profile.blockproposal.dismiss.success", { message: "Block proposal has been dismissed" }))
+    }
+  }
+
+  const restrictions = standing.restrictions || []
+
+  if (standing.warnings.length === 0 && standing.mutes.length === 0 && restrictions.length === 0) {
     return (
       <div className="flex flex-col gap-6">
         <div className="flex flex-col items-center justify-center py-12 px-4 text-center text-success rounded-card min-h-[300px]">
@@ -67,8 +85,37 @@ const UserProfileStandingComponent: React.FC = () => {
     )
   }
 
+  const next = standing.nextSanction
+
   return (
     <div className="flex flex-col gap-6">
+      {(next || standing.blockProposed) && (
+        <div className="bg-elevated rounded-card shadow-sm p-4 text-sm">
+          {standing.blockProposed && (
+            <div className="flex items-center justify-between gap-4 mb-1">
+              <p className="m-0 text-danger font-medium">
+                <Trans id="profile.standing.blockproposed">A block has been proposed to staff.</Trans>
+              </p>
+              {canBlock && (
+                <Button variant="secondary" size="small" onClick={handleDismissBlockProposal}>
+                  <Trans id="profile.standing.blockproposed.dismiss">Dismiss</Trans>
+                </Button>
+              )}
+            </div>
+          )}
+          {next && (
+            <p className="m-0 text-muted">
+              {next.action === "mute" ? (
+                <Trans id="profile.standing.nextsanction.mute">
+                  {next.remaining} more {next.trigger} will result in a mute for {next.duration}.
+                </Trans>
+              ) : (
+                <Trans id="profile.standing.nextsanction.block">{next.remaining} more {next.trigger} will result in a block proposal.</Trans>
+              )}
+            </p>
+          )}
+        </div>
+      )}
       <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
         <h3 className="m-0 p-4 font-semibold flex items-center gap-2">
           <Icon sprite={IconWarning} className="h-5 w-5 text-warning" />
@@ -106,6 +153,11 @@ const UserProfileStandingComponent: React.FC = () => {
                         {isExpired ? "Expired" : "Active"}
                       </span>
                     )}
+                    {isActive && isViewingOwnProfile && (
+                      <Button variant="secondary" size="small" href="/appeal">
+                        <Trans id="appeal.action">Appeal</Trans>
+                      </Button>
+                    )}
                     {isActive && canModerate && (
                       <Button variant="secondary" size="small" onClick={() => handleExpireWarning(warning.id)}>
                         <Trans id="action.removeWarning">Remove</Trans>
@@ -161,6 +213,11 @@ const UserProfileStandingComponent: React.FC = () => {
                         {isExpired ? "Expired" : "Active"}
                       </span>
                     )}
+                    {isActive && isViewingOwnProfile && (
+                      <Button variant="secondary" size="small" href="/appeal">
+                        <Trans id="appeal.action">Appeal</Trans>
+                      </Button>
+                    )}
                     {isActive && canModerate && (
                       <Button variant="secondary" size="small" onClick={() => handleExpireMute(mute.id)}>
                         <Trans id="action.unmute">Unmute</Trans>
@@ -178,6 +235,58 @@ const UserProfileStandingComponent: React.FC = () => {
           </div>
         )}
       </div>
+
+      {restrictions.length > 0 && (
+        <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+          <h3 className="m-0 p-4 font-semibold flex items-center gap-2">
+            <Icon sprite={IconWarning} className="h-5 w-5 text-warning" />
+            <Trans id="profile.standing.restrictions">Restrictions</Trans>
+          </h3>
+          <div className="divide-y divide-surface-alt">
+            {restrictions.map(restriction => {
+              const isActive = !restriction.expiresAt || new Date(restriction.expiresAt) > new Date()
+              return (
+                <div key={restriction.id} className="flex items-start justify-between gap-4 p-4 transition-all hover:bg-tertiary max-md:flex-col">
+                  <div className="flex-1 min-w-0">
+                    <span className="text-xs uppercase text-muted">
+                      {restriction.kind === "shadowban" ? (
+                        <Trans id="profile.standing.restriction.shadowban">Shadow-ban</Trans>
+                      ) : (
+                        <Trans id="profile.standing.restriction.probation">Probation</Trans>
+                      )}
+                    </span>
+                    <p className="m-0 mb-2 text-foreground text-[0.95em] leading-relaxed">{restriction.reason}</p>
+                    <div className="flex flex-wrap gap-3 mt-2">
+                      <span className="flex items-center gap-1 text-muted text-[0.85em]">
+                        <Icon sprite={IconCalendar} className="h-4 w-4 text-subtle" />
+                        {new Date(restriction.createdAt).toLocaleDateString()}
+                      </span>
+                      {restriction.expiresAt && (
+                        <span className="flex items-center gap-1 text-muted text-[0.85em]">
+                          <Icon sprite={IconWarning} className="h-4 w-4 text-subtle" />
+                          <Trans id="profile.standing.expires">
+                            Expires: {new Date(restriction.expiresAt).toLocaleDateString()}
+                          </Trans>
+                        </span>
+                      )}
+                    </div>
+                  </div>
+                  <div className="flex flex-col items-end gap-2 shrink-0 max-md:flex-row max-md:items-center max-md:w-full max-md:mt-2">
+                    <span className={`px-2 py-1 rounded text-xs font-medium ${isActive ? "bg-warning-light text-warning" : "bg-surface-alt text-muted"}`}>
+                      {isActive ? "Active" : "Expired"}
+                    </span>
+                    {isActive && canModerate && (
+                      <Button variant="secondary" size="small" onClick={() => handleLiftRestriction(restriction.id)}>
+                        <Trans id="action.liftRestriction">Lift</Trans>
+                      </Button>
+                    )}
+                  </div>
+                </div>
+              )
+            })}
+          </div>
+        </div>
+      )}
     </div>
   )
 }
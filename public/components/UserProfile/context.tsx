@@ -35,9 +35,28 @@ export interface Mute {
   expiresAt?: string
 }
 
+export interface SanctionStep {
+  trigger: "warnings" | "mutes"
+  remaining: number
+  windowDays?: number
+  action: "mute" | "propose_block"
+  duration?: string
+}
+
+export interface Restriction {
+  id: number
+  kind: "shadowban" | "probation"
+  reason: string
+  createdAt: string
+  expiresAt?: string
+}
+
 export interface UserProfileStanding {
   warnings: Warning[]
   mutes: Mute[]
+  restrictions?: Restriction[]
+  nextSanction?: SanctionStep
+  blockProposed?: boolean
 }
 
 interface UserProfileState {
@@ -3,7 +3,7 @@
 import React, { useState } from "react"
 import { SocialSignInButton, Form, Button, Input, Message } from "@fider/components"
 import { Divider } from "@fider/components/layout"
-import { device, actions, Failure, isCookieEnabled } from "@fider/services"
+import { device, actions, passkey, Failure, isCookieEnabled } from "@fider/services"
 import { useFider } from "@fider/hooks"
 import { Trans } from "@lingui/react/macro"
 
@@ -37,6 +37,23 @@ export const SignInControl: React.FunctionComponent<SignInControlProps> = (props
     }
   }
 
+  const signInWithPasskey = async () => {
+    try {
+      const result = await passkey.signIn()
+      if (result && result.ok) {
+        if (props.redirectTo) {
+          location.href = props.redirectTo
+        } else {
+          location.reload()
+        }
+      } else if (result && result.error) {
+        setError(result.error)
+      }
+    } catch {
+      // the browser prompt was dismissed
+    }
+  }
+
   const providersLen = fider.settings.oauth.length
 
   if (!isCookieEnabled()) {
@@ -63,6 +80,14 @@ export const SignInControl: React.FunctionComponent<SignInControlProps> = (props
         </>
       )}
 
+      {passkey.isPasskeySupported() && (
+        <div className="mb-2">
+          <Button variant="secondary" className="w-full justify-center" onClick={signInWithPasskey}>
+            <Trans id="signin.passkey">Sign in with a passkey</Trans>
+          </Button>
+        </div>
+      )}
+
       {props.useEmail &&
         (showEmailForm ? (
           <div>
@@ -6,7 +6,7 @@ import { VStack } from "@fider/components/layout"
 import { useFider } from "@fider/hooks"
 import { useLayout } from "@fider/contexts/LayoutContext"
 import { useAdminLayout } from "./context"
-import { classSet } from "@fider/services"
+import { classSet, hasPermission } from "@fider/services"
 
 import {
   heroiconsChevronUp as IconChevron,
@@ -21,11 +21,14 @@ import {
   heroiconsAdjustments as IconAdjustments,
   heroiconsLock as IconLock,
   heroiconsEnvelope as IconEnvelope,
+  heroiconsMail as IconMail,
   heroiconsKey as IconKey,
   heroiconsCreditCard as IconCreditCard,
   heroiconsPhotograph as IconPhoto,
   heroiconsDownload as IconDownload,
   heroiconsArchive as IconArchive,
+  heroiconsShieldcheck as IconShieldCheck,
+  heroiconsIdentification as IconIdentification,
 } from "@fider/icons.generated"
 
 interface SidebarItemProps {
@@ -106,24 +109,44 @@ export const AdminSidebar: React.FC = () => {
       <nav className="flex-1 overflow-y-auto overflow-x-hidden p-4 px-2">
         <VStack spacing={0}>
           <SidebarSection label="Moderation" collapsed={!sidebarOpen}>
-            <SidebarItem title="Post Queue" href="/admin/queue" isActive={activeItem === "queue"} icon={IconInbox} collapsed={!sidebarOpen} />
+            {hasPermission("queue.view") && (
+              <SidebarItem title="Post Queue" href="/admin/queue" isActive={activeItem === "queue"} icon={IconInbox} collapsed={!sidebarOpen} />
+            )}
             {(isModerator || isCollaborator || isAdministrator) && (
-              <>
-                <SidebarItem title="Members" href="/admin/members" isActive={activeItem === "members"} icon={IconUsers} collapsed={!sidebarOpen} />
-                <SidebarItem title="Reports" href="/admin/reports" isActive={activeItem === "reports"} icon={IconFlag} collapsed={!sidebarOpen} />
-                <SidebarItem title="Archive" href="/admin/archive" isActive={activeItem === "archive"} icon={IconArchive} collapsed={!sidebarOpen} />
-              </>
+              <SidebarItem title="Members" href="/admin/members" isActive={activeItem === "members"} icon={IconUsers} collapsed={!sidebarOpen} />
+            )}
+            {hasPermission("report.view") && (
+              <SidebarItem title="Reports" href="/admin/reports" isActive={activeItem === "reports"} icon={IconFlag} collapsed={!sidebarOpen} />
+            )}
+            {hasPermission("appeal.decide") && (
+              <SidebarItem title="Appeals" href="/admin/appeals" isActive={activeItem === "appeals"} icon={IconShieldCheck} collapsed={!sidebarOpen} />
+            )}
+            {hasPermission("post.archive") && (
+              <SidebarItem title="Archive" href="/admin/archive" isActive={activeItem === "archive"} icon={IconArchive} collapsed={!sidebarOpen} />
+            )}
+            {(isCollaborator || isAdministrator) && (
+              <SidebarItem title="Merge Requests" href="/admin/merge-requests" isActive={activeItem === "mergerequests"} icon={IconUsers} collapsed={!sidebarOpen} />
             )}
           </SidebarSection>
 
-          {(isCollaborator || isAdministrator) && (
+          {(isCollaborator || isAdministrator || hasPermission("tag.manage") || hasPermission("response.manage")) && (
             <SidebarSection label="Site" collapsed={!sidebarOpen}>
-              <SidebarItem title="General" href="/admin" isActive={activeItem === "general"} icon={IconCog} collapsed={!sidebarOpen} />
-              <SidebarItem title="Content" href="/admin/content-settings" isActive={activeItem === "content"} icon={IconDocumentText} collapsed={!sidebarOpen} />
-              <SidebarItem title="Pages" href="/admin/pages" isActive={activeItem === "pages"} icon={IconDocumentText} collapsed={!sidebarOpen} />
-              <SidebarItem title="Responses" href="/admin/responses" isActive={activeItem === "responses"} icon={IconChat} collapsed={!sidebarOpen} />
-              <SidebarItem title="Tags" href="/admin/tags" isActive={activeItem === "tags"} icon={IconTag} collapsed={!sidebarOpen} />
-              <SidebarItem title="Webhooks" href="/admin/webhooks" isActive={activeItem === "webhooks"} icon={IconLink} collapsed={!sidebarOpen} />
+              {(isCollaborator || isAdministrator) && (
+                <>
+                  <SidebarItem title="General" href="/admin" isActive={activeItem === "general"} icon={IconCog} collapsed={!sidebarOpen} />
+                  <SidebarItem title="Content" href="/admin/content-settings" isActive={activeItem === "content"} icon={IconDocumentText} collapsed={!sidebarOpen} />
+                  <SidebarItem title="Pages" href="/admin/pages" isActive={activeItem === "pages"} icon={IconDocumentText} collapsed={!sidebarOpen} />
+                </>
+              )}
+              {hasPermission("response.manage") && (
+                <SidebarItem title="Responses" href="/admin/responses" isActive={activeItem === "responses"} icon={IconChat} collapsed={!sidebarOpen} />
+              )}
+              {hasPermission("tag.manage") && (
+                <SidebarItem title="Tags" href="/admin/tags" isActive={activeItem === "tags"} icon={IconTag} collapsed={!sidebarOpen} />
+              )}
+              {(isCollaborator || isAdministrator) && (
+                <SidebarItem title="Webhooks" href="/admin/webhooks" isActive={activeItem === "webhooks"} icon={IconLink} collapsed={!sidebarOpen} />
+              )}
             </SidebarSection>
           )}
 
@@ -132,7 +155,11 @@ export const AdminSidebar: React.FC = () => {
               <SidebarItem title="Advanced" href="/admin/advanced" isActive={activeItem === "advanced"} icon={IconAdjustments} collapsed={!sidebarOpen} />
               <SidebarItem title="Privacy" href="/admin/privacy" isActive={activeItem === "privacy"} icon={IconLock} collapsed={!sidebarOpen} />
               <SidebarItem title="Invitations" href="/admin/invitations" isActive={activeItem === "invitations"} icon={IconEnvelope} collapsed={!sidebarOpen} />
+              <SidebarItem title="Email Templates" href="/admin/email-templates" isActive={activeItem === "emailtemplates"} icon={IconEnvelope} collapsed={!sidebarOpen} />
+              <SidebarItem title="Email Queue" href="/admin/email-queue" isActive={activeItem === "emailqueue"} icon={IconMail} collapsed={!sidebarOpen} />
+              <SidebarItem title="Roles" href="/admin/roles" isActive={activeItem === "roles"} icon={IconUsers} collapsed={!sidebarOpen} />
               <SidebarItem title="Authentication" href="/admin/authentication" isActive={activeItem === "authentication"} icon={IconKey} collapsed={!sidebarOpen} />
+              <SidebarItem title="OAuth Apps" href="/admin/oauth-clients" isActive={activeItem === "oauthclients"} icon={IconIdentification} collapsed={!sidebarOpen} />
               {fider.settings.isBillingEnabled && (
                 <SidebarItem title="Billing" href="/admin/billing" isActive={activeItem === "billing"} icon={IconCreditCard} collapsed={!sidebarOpen} />
               )}
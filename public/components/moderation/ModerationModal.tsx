@@ -6,10 +6,12 @@ import { i18n } from "@lingui/core"
 import { Failure, actions } from "@fider/services"
 import { CannedResponse } from "@fider/services/actions/response"
 
+export type ModerationActionType = 'mute' | 'warning' | 'shadowban' | 'probation'
+
 interface ModerationModalProps {
   isOpen: boolean
   onClose: () => void
-  actionType: 'mute' | 'warning'
+  actionType: ModerationActionType
   onSubmit: (data: { reason: string; duration: string }) => Promise<void>
   error?: Failure
 }
@@ -41,7 +43,7 @@ export const ModerationModal: React.FC<ModerationModalProps> = ({ isOpen, onClos
       setIsLoading(true)
       setLoadError(undefined)
       
-      actions.listCannedResponses(actionType)
+      actions.listCannedResponses(actionType === 'warning' ? 'warning' : 'mute')
         .then(result => {
           if (result.ok) {
             setCannedResponses(result.data || [])
@@ -82,12 +84,30 @@ export const ModerationModal: React.FC<ModerationModalProps> = ({ isOpen, onClos
       <Modal.Header>
         {actionType === 'warning' ? (
           <Trans id="moderation.warning.header">Warn User</Trans>
+        ) : actionType === 'shadowban' ? (
+          <Trans id="moderation.shadowban.header">Shadow-ban User</Trans>
+        ) : actionType === 'probation' ? (
+          <Trans id="moderation.probation.header">Put User on Probation</Trans>
         ) : (
           <Trans id="moderation.mute.header">Mute User</Trans>
         )}
       </Modal.Header>
       
       <Modal.Content>
+        {actionType === 'shadowban' && (
+          <p className="text-muted text-sm mt-0 mb-4">
+            <Trans id="moderation.shadowban.description">
+              New posts and comments from this user will only be visible to them and to staff. The user is not notified.
+            </Trans>
+          </p>
+        )}
+        {actionType === 'probation' && (
+          <p className="text-muted text-sm mt-0 mb-4">
+            <Trans id="moderation.probation.description">
+              New posts and comments from this user are held for review and stricter rate limits apply.
+            </Trans>
+          </p>
+        )}
         <Form error={error}>
           <div className="mb-4">
             <label className="text-medium">
@@ -132,8 +152,10 @@ export const ModerationModal: React.FC<ModerationModalProps> = ({ isOpen, onClos
             field="reason"
             label={actionType === 'warning' ? (
               i18n._("moderation.warning.reason.label", { message: "Warning Reason" })
-            ) : (
+            ) : actionType === 'mute' ? (
               i18n._("moderation.mute.reason.label", { message: "Mute Reason" })
+            ) : (
+              i18n._("moderation.restriction.reason.label", { message: "Reason" })
             )}
             value={reason}
             onChange={setReason}
@@ -148,8 +170,10 @@ export const ModerationModal: React.FC<ModerationModalProps> = ({ isOpen, onClos
             <label className="text-medium mb-2 block">
               {actionType === 'warning' ? (
                 <Trans id="moderation.warning.duration.label">Warning Duration</Trans>
-              ) : (
+              ) : actionType === 'mute' ? (
                 <Trans id="moderation.mute.duration.label">Mute Duration</Trans>
+              ) : (
+                <Trans id="moderation.restriction.duration.label">Duration</Trans>
               )}
             </label>
             
@@ -190,6 +214,10 @@ export const ModerationModal: React.FC<ModerationModalProps> = ({ isOpen, onClos
         <Button variant="primary" disabled={!reason.trim()} onClick={handleSubmit}>
           {actionType === 'warning' ? (
             <Trans id="action.warning">Warn User</Trans>
+          ) : actionType === 'shadowban' ? (
+            <Trans id="action.shadowban">Shadow-ban User</Trans>
+          ) : actionType === 'probation' ? (
+            <Trans id="action.probation">Put on Probation</Trans>
           ) : (
             <Trans id="action.mute">Mute User</Trans>
           )}
@@ -1,6 +1,7 @@
 export { ReportModal } from "./ReportModal"
 export { ReportButton } from "./ReportButton"
 export { ModerationModal } from "./ModerationModal"
+export type { ModerationActionType } from "./ModerationModal"
 export { ModIndicator } from "./ModIndicator"
 export { QueueIndicator } from "./QueueIndicator"
 
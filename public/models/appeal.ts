@@ -0,0 +1,21 @@
+import { User } from "./identity"
+
+export type AppealSanctionType = "warning" | "mute" | "block"
+
+export type AppealStatus = "pending" | "upheld" | "reduced" | "lifted"
+
+export interface Appeal {
+  id: number
+  user: User
+  sanctionType: AppealSanctionType
+  sanctionId?: number
+  sanctionReason: string
+  sanctionExpiresAt?: string
+  text: string
+  status: AppealStatus
+  createdAt: string
+  decidedAt?: string
+  decidedBy?: User
+  decisionNote?: string
+  reducedUntil?: string
+}
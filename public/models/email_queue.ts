@@ -0,0 +1,13 @@
+export type QueuedEmailStatus = "pending" | "sent" | "failed"
+
+export interface QueuedEmail {
+  id: number
+  templateName: string
+  recipient: string
+  status: QueuedEmailStatus
+  attempts: number
+  lastError?: string
+  nextAttemptAt: string
+  createdAt: string
+  sentAt?: string
+}
@@ -0,0 +1,21 @@
+export interface BuiltinEmailTemplate {
+  name: string
+  placeholders: string[]
+  subject: string
+  body: string
+}
+
+export interface EmailTemplate {
+  id: number
+  name: string
+  locale: string
+  subject: string
+  body: string
+  updatedAt: string
+}
+
+export interface EmailTemplatePreviewResult {
+  subject?: string
+  body?: string
+  error?: string
+}
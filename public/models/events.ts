@@ -33,6 +33,7 @@ export interface QueueViewerLeftEvent {
 
 export interface ReportNewEvent {
   reportId: number
+  caseId?: number
   reportedType: string
   reportedId: number
   reason: string
@@ -56,6 +57,7 @@ export interface ReportUnassignedEvent {
 
 export interface ReportResolvedEvent {
   reportId: number
+  caseId?: number
   status: string
 }
 
@@ -70,6 +72,20 @@ export interface ReportViewerLeftEvent {
   userId: number
 }
 
+export interface AppealNewEvent {
+  appealId: number
+  userId: number
+  sanctionType: string
+  status: string
+}
+
+export interface AppealDecidedEvent {
+  appealId: number
+  userId: number
+  sanctionType: string
+  status: string
+}
+
 export type SSEEventMap = {
   "connection.open": Record<string, never>
   "connection.error": Record<string, never>
@@ -87,6 +103,9 @@ export type SSEEventMap = {
   "report.resolved": ReportResolvedEvent
   "report.viewer_joined": ReportViewerJoinedEvent
   "report.viewer_left": ReportViewerLeftEvent
+
+  "appeal.new": AppealNewEvent
+  "appeal.decided": AppealDecidedEvent
 }
 
 export type SSEEventType = keyof SSEEventMap
@@ -24,6 +24,7 @@ export interface Tenant {
     commentingDisabledFor: string[]
     postingGloballyDisabled: boolean
     commentingGloballyDisabled: boolean
+    twoFactorRequiredFor?: string[]
   }
   messageBanner: string
 }
@@ -67,6 +68,123 @@ export interface UserNames {
   name: string
 }
 
+export interface UserSession {
+  id: number
+  ipAddress: string
+  userAgent: string
+  createdAt: string
+  lastSeenAt: string
+  expiresAt: string
+  current: boolean
+}
+
+export type APITokenScope = "read:posts" | "write:posts" | "write:comments" | "moderate" | "admin"
+
+export interface APIToken {
+  id: number
+  name: string
+  prefix: string
+  scopes: APITokenScope[]
+  expiresAt?: string
+  lastUsedAt?: string
+  createdAt: string
+}
+
+export interface SCIMToken {
+  prefix: string
+  lastUsedAt?: string
+  createdAt: string
+}
+
+export interface OAuthClient {
+  id: number
+  clientId: string
+  name: string
+  redirectUris: string[]
+  scopes: APITokenScope[]
+  isConfidential: boolean
+  createdAt: string
+}
+
+export interface OAuth2AuthorizeRequest {
+  responseType: string
+  clientId: string
+  redirectUri: string
+  scope: string
+  state: string
+  codeChallenge: string
+  codeChallengeMethod: string
+}
+
+export interface UserProviderLink {
+  provider: string
+  displayName: string
+  canUnlink: boolean
+}
+
+export interface TwoFactorStatus {
+  enabled: boolean
+  required: boolean
+  recoveryCodesLeft: number
+}
+
+export interface TwoFactorEnrollment {
+  secret: string
+  url: string
+  qrCode: string
+}
+
+export type Permission =
+  | "queue.view"
+  | "tag.assign"
+  | "tag.private"
+  | "tag.manage"
+  | "post.respond"
+  | "post.status"
+  | "post.edit"
+  | "post.delete"
+  | "post.lock"
+  | "post.archive"
+  | "comment.edit"
+  | "comment.delete"
+  | "content.moderate"
+  | "report.view"
+  | "report.resolve"
+  | "response.manage"
+  | "appeal.decide"
+  | "user.moderate"
+  | "user.block"
+
+export interface CustomRole {
+  id: number
+  name: string
+  description: string
+  permissions: Permission[]
+  memberCount: number
+  createdAt: string
+}
+
+export interface UserPasskey {
+  id: number
+  name: string
+  createdAt: string
+  lastUsedAt?: string
+}
+
+export type UserMergeRequestStatus = "pending" | "approved" | "rejected"
+
+export interface UserMergeRequest {
+  id: number
+  user: User
+  owner: User
+  provider: string
+  providerUid: string
+  status: UserMergeRequestStatus
+  createdAt: string
+  decidedAt?: string
+  decidedBy?: User
+}
+
 export enum UserAvatarType {
   Letter = "letter",
   Gravatar = "gravatar",
@@ -117,6 +235,7 @@ export interface CurrentUser {
   isCollaborator: boolean
   isModerator: boolean
   isHelper: boolean
+  permissions: Permission[]
   hasWarning: boolean
   isMuted: boolean
   latestWarningId?: number
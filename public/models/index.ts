@@ -5,6 +5,9 @@ export * from "./billing"
 export * from "./notification"
 export * from "./webhook"
 export * from "./report"
+export * from "./appeal"
 export * from "./events"
 export * from "./page"
 export * from "./navigation"
+export * from "./email_template"
+export * from "./email_queue"
@@ -6,4 +6,6 @@ export interface Notification {
   createdAt: string
   authorName: string
   avatarURL: string
+  actorCount: number
+  latestActors?: string[]
 }
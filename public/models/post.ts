@@ -22,6 +22,7 @@ export interface Post {
   downvotes?: number
   moderationPending?: boolean
   moderationData?: string
+  shadowHidden?: boolean
 }
 
 export function isPostHidden(post: Post): boolean {
@@ -102,6 +103,7 @@ export interface Comment {
   editedBy?: User
   moderationPending?: boolean
   moderationData?: string
+  shadowHidden?: boolean
 }
 
 export function isCommentHidden(comment: Comment): boolean {
@@ -116,6 +118,13 @@ export interface Tag {
   isPublic: boolean
 }
 
+export type TagPreferenceValue = "follow" | "mute"
+
+export interface TagPreference {
+  tagId: number
+  preference: TagPreferenceValue
+}
+
 export interface Vote {
   createdAt: Date
   user: {
@@ -6,13 +6,15 @@ export type ReportStatus = "pending" | "in_review" | "resolved" | "dismissed"
 
 export interface Report {
   id: number
+  caseId?: number
   reportedType: ReportType
   reportedId: number
   reason: string
   details?: string
   status: ReportStatus
   createdAt: string
-  reporter: User
+  reporter?: User
+  reporterScore?: ReportAccuracy
   assignedTo?: User
   assignedAt?: string
   resolvedAt?: string
@@ -22,6 +24,38 @@ export interface Report {
   postSlug?: string
 }
 
+export interface ReportAccuracy {
+  resolved: number
+  dismissed: number
+  score: number
+}
+
+export interface ReportReasonCount {
+  reason: string
+  count: number
+}
+
+export interface ReportCase {
+  id: number
+  reportedType: ReportType
+  reportedId: number
+  status: ReportStatus
+  reportCount: number
+  reporterCount: number
+  leadReportId: number
+  reasons: ReportReasonCount[]
+  details: string[]
+  createdAt: string
+  lastReportedAt: string
+  autoHiddenAt?: string
+  resolvedAt?: string
+  resolvedBy?: User
+  resolutionNote?: string
+  postNumber?: number
+  postSlug?: string
+  reports?: Report[]
+}
+
 export interface ReportReason {
   id: number
   slug: string
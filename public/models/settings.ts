@@ -34,10 +34,17 @@ export const OAuthConfigStatus = {
   Enabled: 2,
 }
 
+export const OAuthConfigType = {
+  OAuth2: 1,
+  OIDC: 2,
+}
+
 export interface OAuthConfig {
   provider: string
   displayName: string
   status: number
+  type: number
+  issuerURL: string
   clientID: string
   clientSecret: string
   authorizeURL: string
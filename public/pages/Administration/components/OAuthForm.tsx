@@ -1,10 +1,13 @@
 import React, { useState } from "react"
-import { OAuthConfig, OAuthConfigStatus, ImageUpload } from "@fider/models"
+import { OAuthConfig, OAuthConfigStatus, OAuthConfigType, ImageUpload } from "@fider/models"
 import { Failure, actions } from "@fider/services"
-import { Form, Button, Input, SocialSignInButton, Field, ImageUploader, Toggle } from "@fider/components"
+import { Form, Button, Input, SocialSignInButton, Field, ImageUploader, Toggle, RadioButton } from "@fider/components"
 import { useFider } from "@fider/hooks"
 import { HStack } from "@fider/components/layout"
 
+const typeOAuth2 = { label: "OAuth 2.0", value: "oauth2" }
+const typeOIDC = { label: "OpenID Connect", value: "oidc" }
+
 interface OAuthFormProps {
   config?: OAuthConfig
   onCancel: () => void
@@ -16,6 +19,8 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
   const [provider] = useState((props.config && props.config.provider) || "")
   const [displayName, setDisplayName] = useState((props.config && props.config.displayName) || "")
   const [enabled, setEnabled] = useState((props.config && props.config.status === OAuthConfigStatus.Enabled) || false)
+  const [type, setType] = useState((props.config && props.config.type) || OAuthConfigType.OAuth2)
+  const [issuerURL, setIssuerURL] = useState((props.config && props.config.issuerURL) || "")
   const [isTrusted, setTrusted] = useState((props.config && props.config.isTrusted) || false)
   const [clientID, setClientID] = useState((props.config && props.config.clientID) || "")
   const [clientSecret, setClientSecret] = useState((props.config && props.config.clientSecret) || "")
@@ -36,6 +41,8 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
     const result = await actions.saveOAuthConfig({
       provider,
       status: enabled ? OAuthConfigStatus.Enabled : OAuthConfigStatus.Disabled,
+      type,
+      issuerURL,
       isTrusted,
       displayName,
       clientID,
@@ -71,6 +78,8 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
     setClientSecretEnabled(true)
   }
 
+  const isOIDC = type === OAuthConfigType.OIDC
+
   const title = props.config ? `OAuth Provider: ${props.config.displayName}` : "New OAuth Provider"
   return (
     <>
@@ -97,6 +106,31 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
           </p>
         </ImageUploader>
 
+        <RadioButton
+          label="Protocol"
+          field="type"
+          defaultOption={isOIDC ? typeOIDC : typeOAuth2}
+          options={[typeOAuth2, typeOIDC]}
+          onSelect={(option) => setType(option === typeOIDC ? OAuthConfigType.OIDC : OAuthConfigType.OAuth2)}
+        />
+
+        {isOIDC && (
+          <Input
+            field="issuerURL"
+            label="Issuer URL"
+            maxLength={300}
+            value={issuerURL}
+            placeholder="https://accounts.example.com"
+            disabled={!fider.session.user.isAdministrator}
+            onChange={setIssuerURL}
+          >
+            <p className="text-muted">
+              The endpoints and signing keys are read from <strong>/.well-known/openid-configuration</strong> of the issuer. Users are identified by the{" "}
+              <strong>sub</strong> claim of the ID token, and their email is only used when the provider has verified it.
+            </p>
+          </Input>
+        )}
+
         <Input field="clientID" label="Client ID" maxLength={100} value={clientID} disabled={!fider.session.user.isAdministrator} onChange={setClientID} />
 
         <Input
@@ -117,85 +151,97 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
             ) : undefined
           }
         />
-        <Input
-          field="authorizeURL"
-          label="Authorize URL"
-          maxLength={300}
-          value={authorizeURL}
-          disabled={!fider.session.user.isAdministrator}
-          onChange={setAuthorizeURL}
-        />
-        <Input field="tokenURL" label="Token URL" maxLength={300} value={tokenURL} disabled={!fider.session.user.isAdministrator} onChange={setTokenURL} />
+        {!isOIDC && (
+          <>
+            <Input
+              field="authorizeURL"
+              label="Authorize URL"
+              maxLength={300}
+              value={authorizeURL}
+              disabled={!fider.session.user.isAdministrator}
+              onChange={setAuthorizeURL}
+            />
+            <Input field="tokenURL" label="Token URL" maxLength={300} value={tokenURL} disabled={!fider.session.user.isAdministrator} onChange={setTokenURL} />
+          </>
+        )}
 
         <Input field="scope" label="Scope" maxLength={100} value={scope} disabled={!fider.session.user.isAdministrator} onChange={setScope}>
           <p className="text-muted">
             It is recommended to only request the minimum scopes we need to fetch the user <strong>id</strong>, <strong>name</strong> and <strong>email</strong>
             . Multiple scopes must be separated by space.
+            {isOIDC && (
+              <>
+                {" "}
+                The <strong>openid</strong> scope is always requested, <strong>openid profile email</strong> is used when empty.
+              </>
+            )}
           </p>
         </Input>
 
-        <h3 className="text-title mt-8 mb-2">User Profile</h3>
-        <p className="text-muted">This section is used to configure how Fider will fetch user after the authentication process.</p>
+        {!isOIDC && (
+          <>
+            <h3 className="text-title mt-8 mb-2">User Profile</h3>
+            <p className="text-muted">This section is used to configure how Fider will fetch user after the authentication process.</p>
 
-        <Input
-          field="profileURL"
-          label="Profile API URL"
-          maxLength={300}
-          value={profileURL}
-          disabled={!fider.session.user.isAdministrator}
-          onChange={setProfileURL}
-        >
-          <p className="text-muted">The URL to fetch the authenticated user info. If empty, Fider will try to parse the user info from the Access Token.</p>
-        </Input>
+            <Input
+              field="profileURL"
+              label="Profile API URL"
+              maxLength={300}
+              value={profileURL}
+              disabled={!fider.session.user.isAdministrator}
+              onChange={setProfileURL}
+            >
+              <p className="text-muted">The URL to fetch the authenticated user info. If empty, Fider will try to parse the user info from the Access Token.</p>
+            </Input>
 
-        <h3 className="text-title mt-8 mb-2">JSON Path</h3>
+            <h3 className="text-title mt-8 mb-2">JSON Path</h3>
 
-        <div className="grid grid-cols-3 gap-4">
-          <Input
-            field="jsonUserIDPath"
-            label="ID"
-            maxLength={100}
-            value={jsonUserIDPath}
-            disabled={!fider.session.user.isAdministrator}
-            onChange={setJSONUserIDPath}
-          >
-            <p className="text-muted">
-              Path to extract User ID from the JSON. This ID <strong>must</strong> be unique within the provider or unexpected side effects might happen. For
-              example below, the path would be <strong>id</strong>.
-            </p>
-          </Input>
-          <Input
-            field="jsonUserNamePath"
-            label="Name"
-            maxLength={100}
-            value={jsonUserNamePath}
-            disabled={!fider.session.user.isAdministrator}
-            onChange={setJSONUserNamePath}
-          >
-            <p className="text-muted">
-              Path to extract user Display Name from the JSON. This is optional, but <strong>highly</strong> recommended. For the example below, the path would
-              be <strong>profile.name</strong>.
-            </p>
-          </Input>
-          <Input
-            field="jsonUserEmailPath"
-            label="Email"
-            maxLength={100}
-            value={jsonUserEmailPath}
-            disabled={!fider.session.user.isAdministrator}
-            onChange={setJSONUserEmailPath}
-          >
-            <p className="text-muted">
-              Path to extract user Email from the JSON. This is optional, but <strong>highly</strong> recommended. For the example below, the path would be{" "}
-              <strong>profile.emails[0]</strong>.
-            </p>
-          </Input>
-        </div>
+            <div className="grid grid-cols-3 gap-4">
+              <Input
+                field="jsonUserIDPath"
+                label="ID"
+                maxLength={100}
+                value={jsonUserIDPath}
+                disabled={!fider.session.user.isAdministrator}
+                onChange={setJSONUserIDPath}
+              >
+                <p className="text-muted">
+                  Path to extract User ID from the JSON. This ID <strong>must</strong> be unique within the provider or unexpected side effects might happen. For
+                  example below, the path would be <strong>id</strong>.
+                </p>
+              </Input>
+              <Input
+                field="jsonUserNamePath"
+                label="Name"
+                maxLength={100}
+                value={jsonUserNamePath}
+                disabled={!fider.session.user.isAdministrator}
+                onChange={setJSONUserNamePath}
+              >
+                <p className="text-muted">
+                  Path to extract user Display Name from the JSON. This is optional, but <strong>highly</strong> recommended. For the example below, the path would
+                  be <strong>profile.name</strong>.
+                </p>
+              </Input>
+              <Input
+                field="jsonUserEmailPath"
+                label="Email"
+                maxLength={100}
+                value={jsonUserEmailPath}
+                disabled={!fider.session.user.isAdministrator}
+                onChange={setJSONUserEmailPath}
+              >
+                <p className="text-muted">
+                  Path to extract user Email from the JSON. This is optional, but <strong>highly</strong> recommended. For the example below, the path would be{" "}
+                  <strong>profile.emails[0]</strong>.
+                </p>
+              </Input>
+            </div>
 
-        <h3 className="text-title mb-2">Example Response</h3>
+            <h3 className="text-title mb-2">Example Response</h3>
 
-        <pre>
-          {`{ 
+            <pre>
+              {`{ 
   id: "35235"
   title: "Sr. Account Manager",
   profile: {
@@ -206,8 +252,10 @@ export const OAuthForm: React.FC<OAuthFormProps> = (props) => {
     ]
   }
 }
-          `}
-        </pre>
+              `}
+            </pre>
+          </>
+        )}
 
         <Field label="Trusted Source">
           <Toggle field="isTrusted" active={isTrusted} onToggle={setTrusted} label={isTrusted ? "Yes" : "No"} />
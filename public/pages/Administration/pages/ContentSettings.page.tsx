@@ -1,5 +1,5 @@
 import React, { useState } from "react"
-import { Button, ButtonClickEvent, Form, Input, Toggle } from "@fider/components"
+import { Button, ButtonClickEvent, Form, Input, Select, Toggle } from "@fider/components"
 import { actions, Failure, classSet } from "@fider/services"
 import { useFider } from "@fider/hooks"
 import { CollapsiblePanel } from "@fider/components/common/CollapsiblePanel"
@@ -18,6 +18,19 @@ interface LimitSetting {
   hours: number
 }
 
+interface SanctionRule {
+  trigger: "warnings" | "mutes"
+  count: number
+  windowDays?: number
+  action: "mute" | "propose_block"
+  duration?: string
+}
+
+interface SanctionPolicy {
+  enabled: boolean
+  rules: SanctionRule[]
+}
+
 interface ContentSettingsModel {
   titleLengthMin: number
   titleLengthMax: number
@@ -33,6 +46,8 @@ interface ContentSettingsModel {
   commentingGloballyDisabled: boolean
   reportingGloballyDisabled: boolean
   reportLimitsPerDay: number
+  reportAutoHideThreshold: number
+  sanctionPolicy: SanctionPolicy
 }
 
 const ContentSettingsPage: React.FC = () => {
@@ -52,7 +67,16 @@ const ContentSettingsPage: React.FC = () => {
     postingGloballyDisabled: false,
     commentingGloballyDisabled: false,
     reportingGloballyDisabled: false,
-    reportLimitsPerDay: 10
+    reportLimitsPerDay: 10,
+    reportAutoHideThreshold: 0,
+    sanctionPolicy: {
+      enabled: false,
+      rules: [
+        { trigger: "warnings", count: 3, action: "mute", duration: "24h" },
+        { trigger: "mutes", count: 2, windowDays: 30, action: "mute", duration: "7d" },
+        { trigger: "mutes", count: 3, windowDays: 30, action: "propose_block" },
+      ],
+    },
   }
   
   const [settings, setSettings] = useState<ContentSettingsModel>(() => {
@@ -61,12 +85,15 @@ const ContentSettingsPage: React.FC = () => {
     return {
       ...defaultSettings,
       ...stored,
+      sanctionPolicy: stored.sanctionPolicy?.rules ? stored.sanctionPolicy : defaultSettings.sanctionPolicy,
     }
   })
   const [error, setError] = useState<Failure | undefined>(undefined)
-  const [activeTab, setActiveTab] = useState<'global' | 'post' | 'comment' | 'report'>('global')
+  const [activeTab, setActiveTab] = useState<'global' | 'post' | 'comment' | 'report' | 'sanctions'>('global')
 
   const { roles } = useAdminLayout()
+  // "probation" limits apply to users on probation instead of their role limits
+  const limitKeys = [...roles, "probation"]
   
   const canEdit = (fider.session.user.isAdministrator || fider.session.user.isCollaborator)
 
@@ -135,6 +162,23 @@ const ContentSettingsPage: React.FC = () => {
     updateSetting(field, !settings[field])
   }
 
+  const updateSanctionPolicy = (policy: Partial<SanctionPolicy>) => {
+    updateSetting('sanctionPolicy', { ...settings.sanctionPolicy, ...policy })
+  }
+
+  const updateSanctionRule = (index: number, rule: Partial<SanctionRule>) => {
+    const rules = settings.sanctionPolicy.rules.map((r, i) => (i === index ? { ...r, ...rule } : r))
+    updateSanctionPolicy({ rules })
+  }
+
+  const addSanctionRule = () => {
+    updateSanctionPolicy({ rules: [...settings.sanctionPolicy.rules, { trigger: "warnings", count: 1, action: "mute", duration: "24h" }] })
+  }
+
+  const removeSanctionRule = (index: number) => {
+    updateSanctionPolicy({ rules: settings.sanctionPolicy.rules.filter((_, i) => i !== index) })
+  }
+
   const renderTabNav = () => {
     return (
       <div className="border-b border-border mb-2">
@@ -143,7 +187,8 @@ const ContentSettingsPage: React.FC = () => {
             { key: 'global', label: 'Global Controls' },
             { key: 'post', label: 'Post Settings' },
             { key: 'comment', label: 'Comment Settings' },
-            { key: 'report', label: 'Report Settings' }
+            { key: 'report', label: 'Report Settings' },
+            { key: 'sanctions', label: 'Sanctions' }
           ].map(tab => (
             <button 
               key={tab.key}
@@ -154,7 +199,7 @@ const ContentSettingsPage: React.FC = () => {
               })}
               onClick={(e) => {
                 e.preventDefault();
-                setActiveTab(tab.key as 'global' | 'post' | 'comment' | 'report');
+                setActiveTab(tab.key as 'global' | 'post' | 'comment' | 'report' | 'sanctions');
               }}
             >
               <span>{tab.label}</span>
@@ -283,8 +328,9 @@ const ContentSettingsPage: React.FC = () => {
           </CollapsiblePanel>
 
           <CollapsiblePanel title="Post Rate Limits" defaultOpen={false}>            
+            <p className="text-muted text-sm mt-0 mb-2">Users on probation get the probation limit instead of their role limit. When it is not set they are allowed 1 post every 24 hours.</p>
             <div className="grid grid-cols-[repeat(auto-fill,minmax(220px,1fr))] gap-2">
-              {roles.map(role => (
+              {limitKeys.map(role => (
                 <div key={`post-limit-${role}`} className="border border-border rounded p-1.5">
                   <h4 className="m-0 mb-1 text-base font-medium">{role}</h4>
                   <div className="grid grid-cols-2 gap-1.5">
@@ -368,8 +414,9 @@ const ContentSettingsPage: React.FC = () => {
           </CollapsiblePanel>
 
           <CollapsiblePanel title="Comment Rate Limits" defaultOpen={false}>            
+            <p className="text-muted text-sm mt-0 mb-2">Users on probation get the probation limit instead of their role limit. When it is not set they are allowed 10 comments every 24 hours.</p>
             <div className="grid grid-cols-[repeat(auto-fill,minmax(220px,1fr))] gap-2">
-              {roles.map(role => (
+              {limitKeys.map(role => (
                 <div key={`comment-limit-${role}`} className="border border-border rounded p-1.5">
                   <h4 className="m-0 mb-1 text-base font-medium">{role}</h4>
                   <div className="grid grid-cols-2 gap-1.5">
@@ -466,6 +513,114 @@ const ContentSettingsPage: React.FC = () => {
                 Maximum number of reports a single user can submit per day. Set to 0 for unlimited.
               </p>
             </Input>
+            <Input
+              field="reportAutoHideThreshold"
+              label="Auto-hide after Trusted Reporters"
+              type="number"
+              min={0}
+              max={100}
+              value={(settings.reportAutoHideThreshold || 0).toString()}
+              disabled={!canEdit}
+              onChange={(value) => updateSetting('reportAutoHideThreshold', parseInt(value) || 0)}
+            >
+              <p className="text-muted text-sm mt-0.5 mb-0">
+                Hide reported content pending moderation once this many distinct trusted users have reported it. Set to 0 to disable.
+              </p>
+            </Input>
+          </CollapsiblePanel>
+        </div>
+      </div>
+    )
+  }
+
+  const renderSanctionSettings = () => {
+    const policy = settings.sanctionPolicy
+    return (
+      <div className={classSet({
+        "": true,
+        "block": activeTab === 'sanctions',
+        "hidden": activeTab !== 'sanctions'
+      })}>
+        <div className="flex flex-col gap-2">
+          <CollapsiblePanel title="Sanctions Policy" defaultOpen={true}>
+            <div className="mb-2">
+              <Toggle
+                active={policy.enabled}
+                label="Escalate sanctions automatically"
+                onToggle={() => updateSanctionPolicy({ enabled: !policy.enabled })}
+                disabled={!canEdit}
+              />
+              <p className="text-muted text-sm mt-0.5 mb-0">
+                Whenever a user is warned or muted, the harshest step below that the user has reached is applied. Steps go from the mildest to the harshest.
+              </p>
+            </div>
+          </CollapsiblePanel>
+
+          <CollapsiblePanel title="Steps" defaultOpen={true}>
+            {policy.rules.map((rule, index) => (
+              <div key={index} className="p-3 mb-2 bg-tertiary rounded-card">
+                <HStack spacing={2} className="flex-wrap">
+                  <Select
+                    field={`sanctionPolicy.rules.${index}.trigger`}
+                    label="When"
+                    value={rule.trigger}
+                    disabled={!canEdit}
+                    options={[
+                      { value: "warnings", label: "Active warnings reach" },
+                      { value: "mutes", label: "Mutes reach" },
+                    ]}
+                    onChange={(o) => o && updateSanctionRule(index, { trigger: o.value as SanctionRule["trigger"] })}
+                  />
+                  <Input
+                    field={`sanctionPolicy.rules.${index}.count`}
+                    label="Count"
+                    type="number"
+                    min={1}
+                    value={(rule.count || 1).toString()}
+                    disabled={!canEdit}
+                    onChange={(value) => updateSanctionRule(index, { count: parseInt(value) || 1 })}
+                  />
+                  {rule.trigger === "mutes" && (
+                    <Input
+                      field={`sanctionPolicy.rules.${index}.windowDays`}
+                      label="Within days (0 for all time)"
+                      type="number"
+                      min={0}
+                      value={(rule.windowDays || 0).toString()}
+                      disabled={!canEdit}
+                      onChange={(value) => updateSanctionRule(index, { windowDays: parseInt(value) || 0 })}
+                    />
+                  )}
+                  <Select
+                    field={`sanctionPolicy.rules.${index}.action`}
+                    label="Then"
+                    value={rule.action}
+                    disabled={!canEdit}
+                    options={[
+                      { value: "mute", label: "Mute the user" },
+                      { value: "propose_block", label: "Propose a block to staff" },
+                    ]}
+                    onChange={(o) => o && updateSanctionRule(index, { action: o.value as SanctionRule["action"] })}
+                  />
+                  {rule.action === "mute" && (
+                    <Input
+                      field={`sanctionPolicy.rules.${index}.duration`}
+                      label="For"
+                      placeholder="24h"
+                      value={rule.duration || ""}
+                      disabled={!canEdit}
+                      onChange={(value) => updateSanctionRule(index, { duration: value })}
+                    />
+                  )}
+                </HStack>
+                <Button variant="tertiary" size="small" disabled={!canEdit} onClick={() => removeSanctionRule(index)}>
+                  Remove step
+                </Button>
+              </div>
+            ))}
+            <Button variant="secondary" size="small" disabled={!canEdit} onClick={addSanctionRule}>
+              Add step
+            </Button>
           </CollapsiblePanel>
         </div>
       </div>
@@ -482,6 +637,7 @@ const ContentSettingsPage: React.FC = () => {
           {renderPostSettings()}
           {renderCommentSettings()}
           {renderReportSettings()}
+          {renderSanctionSettings()}
         </div>
 
         <div className="settings-actions c-admin-actions">
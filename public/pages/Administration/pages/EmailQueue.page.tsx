@@ -0,0 +1,109 @@
+import React, { useState } from "react"
+import { Button, Moment } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { PageConfig } from "@fider/components/layouts"
+import { QueuedEmail, QueuedEmailStatus } from "@fider/models"
+import { actions, classSet, notify } from "@fider/services"
+import { useFider } from "@fider/hooks"
+
+export const pageConfig: PageConfig = {
+  title: "Email Queue",
+  subtitle: "Follow the delivery of the emails sent by your site",
+  sidebarItem: "emailqueue",
+}
+
+interface EmailQueuePageProps {
+  emails: QueuedEmail[]
+  status: QueuedEmailStatus | ""
+  counts: { [key in QueuedEmailStatus]: number }
+}
+
+const filters: { label: string; value: QueuedEmailStatus | "" }[] = [
+  { label: "All", value: "" },
+  { label: "Pending", value: "pending" },
+  { label: "Sent", value: "sent" },
+  { label: "Failed", value: "failed" },
+]
+
+const statusClassName = (status: QueuedEmailStatus) =>
+  classSet({
+    "text-xs font-semibold px-2 py-0.5 rounded-full": true,
+    "bg-tertiary text-muted": status === "pending",
+    "bg-success-light text-success": status === "sent",
+    "bg-danger-light text-danger": status === "failed",
+  })
+
+const EmailQueuePage: React.FC<EmailQueuePageProps> = (props) => {
+  const fider = useFider()
+  const [emails, setEmails] = useState(props.emails)
+
+  const retry = async (email: QueuedEmail) => {
+    const result = await actions.retryQueuedEmail(email.id)
+    if (result.ok) {
+      setEmails((prev) => prev.map((e) => (e.id === email.id ? { ...e, status: "pending", lastError: undefined } : e)))
+      notify.success("The email will be sent again shortly.")
+    }
+  }
+
+  return (
+    <VStack spacing={4}>
+      <HStack spacing={2}>
+        {filters.map((f) => (
+          <a
+            key={f.value}
+            href={f.value ? `/admin/email-queue?status=${f.value}` : "/admin/email-queue"}
+            className={classSet({
+              "px-3 py-1 rounded-button text-sm border border-surface-alt": true,
+              "bg-tertiary font-semibold": props.status === f.value,
+            })}
+          >
+            {f.label}
+            {f.value && <span className="text-muted ml-1">({props.counts[f.value]})</span>}
+          </a>
+        ))}
+      </HStack>
+
+      {emails.length === 0 ? (
+        <div className="p-8 text-center bg-tertiary rounded-card border border-surface-alt">
+          <p className="text-muted m-0">There aren&apos;t any emails here.</p>
+        </div>
+      ) : (
+        <div className="bg-elevated rounded-card border border-surface-alt overflow-hidden">
+          {emails.map((email) => (
+            <div key={email.id} className="p-4 border-b border-surface-alt last:border-b-0">
+              <HStack justify="between">
+                <div className="min-w-0">
+                  <p className="m-0 font-medium truncate">{email.recipient}</p>
+                  <p className="m-0 text-sm text-muted">
+                    {email.templateName} · queued <Moment locale={fider.currentLocale} date={email.createdAt} />
+                    {email.attempts > 0 && ` · ${email.attempts} attempt(s)`}
+                    {email.status === "pending" && email.attempts > 0 && (
+                      <>
+                        {" "}
+                        · next attempt <Moment locale={fider.currentLocale} date={email.nextAttemptAt} />
+                      </>
+                    )}
+                  </p>
+                </div>
+                <HStack spacing={2}>
+                  <span className={statusClassName(email.status)}>{email.status}</span>
+                  {email.status === "failed" && (
+                    <Button size="small" variant="secondary" onClick={() => retry(email)}>
+                      Retry
+                    </Button>
+                  )}
+                </HStack>
+              </HStack>
+              {email.lastError && <pre className="mt-2 mb-0 p-2 text-xs text-danger bg-tertiary rounded-card whitespace-pre-wrap break-all">{email.lastError}</pre>}
+            </div>
+          ))}
+        </div>
+      )}
+      <p className="text-muted text-sm m-0">
+        Emails are retried a few times when the email provider is unavailable. Sent and failed emails are kept for 30 days.
+      </p>
+    </VStack>
+  )
+}
+
+export default EmailQueuePage
@@ -0,0 +1,186 @@
+import React, { useState, useEffect, useCallback } from "react"
+import { Appeal, AppealStatus, AppealNewEvent, AppealDecidedEvent } from "@fider/models"
+import { actions, appealsEventSource, Failure, notify } from "@fider/services"
+import { Avatar, Button, Form, Input, TextArea, Moment, Loader } from "@fider/components"
+import { PageConfig } from "@fider/components/layouts"
+import { useFider, useRealtimeEvents } from "@fider/hooks"
+import { Trans } from "@lingui/react/macro"
+import { i18n } from "@lingui/core"
+
+export const pageConfig: PageConfig = {
+  title: "Appeals",
+  subtitle: "Review appeals against warnings, mutes and blocks",
+  sidebarItem: "appeals",
+  layoutVariant: "default",
+}
+
+type Decision = "upheld" | "reduced" | "lifted"
+
+const AppealItem: React.FC<{ appeal: Appeal; onDecided: (id: number) => void }> = ({ appeal, onDecided }) => {
+  const fider = useFider()
+  const [decision, setDecision] = useState<Decision | undefined>()
+  const [note, setNote] = useState("")
+  const [duration, setDuration] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const canDecide = appeal.sanctionType !== "block" || fider.session.user.isCollaborator || fider.session.user.isAdministrator
+
+  const submit = async () => {
+    if (!decision) return
+    const result = await actions.decideAppeal(appeal.id, decision, note, decision === "reduced" ? duration : undefined)
+    if (result.ok) {
+      notify.success(i18n._("admin.appeals.decided", { message: "Appeal decided" }))
+      onDecided(appeal.id)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  return (
+    <div className="border border-surface-alt rounded-card bg-elevated p-4">
+      <div className="flex items-start justify-between gap-4">
+        <div className="flex items-center gap-2">
+          <Avatar user={appeal.user} />
+          <a href={`/profile/${appeal.user.id}#standing`} className="font-semibold text-foreground hover:text-primary">
+            {appeal.user.name}
+          </a>
+        </div>
+        <span className="text-xs text-muted">
+          <Moment locale={fider.currentLocale} date={appeal.createdAt} />
+        </span>
+      </div>
+      <div className="mt-3">
+        <span className="text-xs uppercase text-muted">{appeal.sanctionType}</span>
+        {appeal.sanctionReason && <p className="m-0 text-foreground">{appeal.sanctionReason}</p>}
+        {appeal.sanctionExpiresAt && (
+          <p className="m-0 text-xs text-muted">
+            <Trans id="admin.appeals.expires">Expires</Trans> <Moment locale={fider.currentLocale} date={appeal.sanctionExpiresAt} format="full" />
+          </p>
+        )}
+        <p className="m-0 mt-3 whitespace-pre-wrap">{appeal.text}</p>
+      </div>
+
+      {appeal.status !== "pending" ? (
+        <p className="m-0 mt-3 text-sm text-muted">
+          {appeal.status}
+          {appeal.decidedBy && ` · ${appeal.decidedBy.name}`}
+          {appeal.decisionNote && ` · ${appeal.decisionNote}`}
+        </p>
+      ) : (
+        canDecide && (
+          <div className="mt-4">
+            <div className="flex gap-2 mb-3">
+              <Button size="small" variant={decision === "upheld" ? "primary" : "secondary"} onClick={() => setDecision("upheld")}>
+                <Trans id="admin.appeals.uphold">Uphold</Trans>
+              </Button>
+              {appeal.sanctionType !== "block" && (
+                <Button size="small" variant={decision === "reduced" ? "primary" : "secondary"} onClick={() => setDecision("reduced")}>
+                  <Trans id="admin.appeals.reduce">Reduce</Trans>
+                </Button>
+              )}
+              <Button size="small" variant={decision === "lifted" ? "primary" : "secondary"} onClick={() => setDecision("lifted")}>
+                <Trans id="admin.appeals.lift">Lift</Trans>
+              </Button>
+            </div>
+            {decision && (
+              <Form error={error}>
+                {decision === "reduced" && (
+                  <Input
+                    field="duration"
+                    label={i18n._("admin.appeals.duration", { message: "New duration from now (e.g. 2h, 1d)" })}
+                    value={duration}
+                    onChange={setDuration}
+                  />
+                )}
+                <TextArea
+                  field="note"
+                  label={i18n._("admin.appeals.note", { message: "Note to the user" })}
+                  value={note}
+                  maxLength={2000}
+                  onChange={setNote}
+                />
+                <Button variant="primary" size="small" onClick={submit}>
+                  <Trans id="admin.appeals.confirm">Confirm decision</Trans>
+                </Button>
+              </Form>
+            )}
+          </div>
+        )
+      )}
+    </div>
+  )
+}
+
+const ManageAppealsPage: React.FC = () => {
+  const [status, setStatus] = useState<AppealStatus | "pending">("pending")
+  const [appeals, setAppeals] = useState<Appeal[]>([])
+  const [isLoading, setIsLoading] = useState(true)
+  const [hasNew, setHasNew] = useState(false)
+
+  const load = useCallback(async () => {
+    setIsLoading(true)
+    const result = await actions.listAppeals({ status, perPage: 50 })
+    if (result.ok) {
+      setAppeals(result.data.appeals)
+    }
+    setHasNew(false)
+    setIsLoading(false)
+  }, [status])
+
+  useEffect(() => {
+    load()
+  }, [load])
+
+  useRealtimeEvents(appealsEventSource, {
+    "appeal.new": (_: string, payload: unknown) => {
+      const data = payload as AppealNewEvent
+      if (data.status === "pending") {
+        setHasNew(true)
+      }
+    },
+    "appeal.decided": (_: string, payload: unknown) => {
+      const data = payload as AppealDecidedEvent
+      if (status === "pending") {
+        setAppeals((prev) => prev.filter((a) => a.id !== data.appealId))
+      }
+    },
+  })
+
+  const onDecided = (id: number) => setAppeals((prev) => prev.filter((a) => a.id !== id))
+
+  const statuses: AppealStatus[] = ["pending", "upheld", "reduced", "lifted"]
+
+  return (
+    <div>
+      <div className="flex items-center gap-2 mb-4">
+        {statuses.map((s) => (
+          <Button key={s} size="small" variant={status === s ? "primary" : "tertiary"} onClick={() => setStatus(s)}>
+            {s}
+          </Button>
+        ))}
+      </div>
+
+      {hasNew && (
+        <Button className="mb-4" variant="secondary" size="small" onClick={load}>
+          <Trans id="admin.appeals.new">New appeals received, refresh</Trans>
+        </Button>
+      )}
+
+      {isLoading ? (
+        <Loader />
+      ) : appeals.length === 0 ? (
+        <p className="text-muted">
+          <Trans id="admin.appeals.empty">No appeals to show.</Trans>
+        </p>
+      ) : (
+        <div className="flex flex-col gap-3">
+          {appeals.map((appeal) => (
+            <AppealItem key={appeal.id} appeal={appeal} onDecided={onDecided} />
+          ))}
+        </div>
+      )}
+    </div>
+  )
+}
+
+export default ManageAppealsPage
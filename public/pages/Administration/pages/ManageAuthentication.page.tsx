@@ -1,6 +1,6 @@
 import React, { useState } from "react"
-import { Button, OAuthProviderLogo, Icon, Field, Toggle, Form } from "@fider/components"
-import { OAuthConfig, OAuthProviderOption } from "@fider/models"
+import { Button, OAuthProviderLogo, Icon, Field, Toggle, Form, Checkbox, Moment } from "@fider/components"
+import { OAuthConfig, OAuthProviderOption, SCIMToken, UserRole } from "@fider/models"
 import { OAuthForm } from "../components/OAuthForm"
 import { actions, notify, Fider, Failure } from "@fider/services"
 import { heroiconsPlay as IconPlay, heroiconsPencilAlt as IconPencilAlt } from "@fider/icons.generated"
@@ -13,8 +13,16 @@ export const pageConfig: PageConfig = {
   sidebarItem: "authentication",
 }
 
+const twoFactorRoles = [
+  { role: UserRole.Administrator, label: "Administrators" },
+  { role: UserRole.Collaborator, label: "Collaborators" },
+  { role: UserRole.Moderator, label: "Moderators" },
+  { role: UserRole.Helper, label: "Helpers" },
+]
+
 interface ManageAuthenticationPageProps {
   providers: OAuthProviderOption[]
+  scimToken?: SCIMToken
 }
 
 const ManageAuthenticationPage: React.FC<ManageAuthenticationPageProps> = (props) => {
@@ -23,8 +31,11 @@ const ManageAuthenticationPage: React.FC<ManageAuthenticationPageProps> = (props
   const [canDisableEmailAuth] = useState(() => 
     props.providers.map((o) => o.isEnabled).reduce((a, b) => a || b, false)
   )
+  const [twoFactorRequiredFor, setTwoFactorRequiredFor] = useState<string[]>(Fider.session.tenant.generalSettings?.twoFactorRequiredFor || [])
   const [editing, setEditing] = useState<OAuthConfig | undefined>()
   const [error, setError] = useState<Failure | undefined>()
+  const [scimToken, setSCIMToken] = useState<SCIMToken | undefined>(props.scimToken)
+  const [newSCIMToken, setNewSCIMToken] = useState<string>()
 
   const addNew = async () => {
     setIsAdding(true)
@@ -63,6 +74,50 @@ const ManageAuthenticationPage: React.FC<ManageAuthenticationPageProps> = (props
     }
   }
 
+  const toggleTwoFactorRole = async (role: string, required: boolean) => {
+    const previous = twoFactorRequiredFor
+    const roles = required ? [...previous, role] : previous.filter((r) => r !== role)
+    setTwoFactorRequiredFor(roles)
+    const response = await actions.updateTwoFactorPolicy(roles)
+    if (response.ok) {
+      notify.success(`You successfully changed two-factor authentication setting.`)
+    } else {
+      setTwoFactorRequiredFor(previous)
+      setError(response.error)
+      notify.error("Unable to save this setting.")
+    }
+  }
+
+  const generateSCIMToken = async () => {
+    if (scimToken && !window.confirm("Identity providers using the current token will stop working until they are updated. Continue?")) {
+      return
+    }
+
+    const response = await actions.generateSCIMToken()
+    if (response.ok) {
+      const { token, ...created } = response.data
+      setSCIMToken(created)
+      setNewSCIMToken(token)
+    } else {
+      notify.error("Unable to generate a SCIM token.")
+    }
+  }
+
+  const revokeSCIMToken = async () => {
+    if (!window.confirm("Identity providers will no longer be able to provision users. Continue?")) {
+      return
+    }
+
+    const response = await actions.deleteSCIMToken()
+    if (response.ok) {
+      setSCIMToken(undefined)
+      setNewSCIMToken(undefined)
+      notify.success("SCIM token has been revoked.")
+    } else {
+      notify.error("Unable to revoke the SCIM token.")
+    }
+  }
+
   let enabledProvidersCount = 0
   for (const o of props.providers) {
     if (o.isEnabled) {
@@ -104,6 +159,24 @@ const ManageAuthenticationPage: React.FC<ManageAuthenticationPageProps> = (props
             </p>
             <p className="text-muted mt-1">Note: Administrator accounts will still be allowed to sign in using their email.</p>
           </Field>
+          {Fider.session.user.isAdministrator && (
+            <Field label="Require Two-Factor Authentication" className="mt-4">
+              {twoFactorRoles.map((r) => (
+                <Checkbox
+                  key={r.role}
+                  field={`twoFactor_${r.role}`}
+                  checked={twoFactorRequiredFor.includes(r.role)}
+                  onChange={(checked) => toggleTwoFactorRole(r.role, checked)}
+                >
+                  {r.label}
+                </Checkbox>
+              ))}
+              <p className="text-muted my-1">
+                Staff members with the selected roles must enable two-factor authentication on their profile before they can access moderation and
+                administration pages.
+              </p>
+            </Field>
+          )}
         </Form>
       </div>
       <div>
@@ -156,6 +229,55 @@ const ManageAuthenticationPage: React.FC<ManageAuthenticationPageProps> = (props
           </div>
         </VStack>
       </div>
+      {Fider.session.user.isAdministrator && (
+        <div>
+          <h2 className="text-display">User Provisioning (SCIM)</h2>
+          <p>
+            Identity providers such as Okta or Microsoft Entra ID can create, update and deactivate users through SCIM 2.0. Configure your identity
+            provider with the endpoint below and a SCIM token.
+          </p>
+          <p className="text-muted">
+            <strong>Endpoint:</strong> <code>{`${Fider.settings.baseURL}/scim/v2`}</code>
+          </p>
+          <p className="text-muted">
+            New users join as visitors. Adding users to the Administrator, Collaborator, Moderator or Helper groups changes their role, and the Visual
+            Role groups change the badge shown next to their name. Deactivating or deleting a user blocks them and signs them out everywhere.
+          </p>
+          {scimToken ? (
+            <p className="text-muted">
+              <strong>Token:</strong> <code>{scimToken.prefix}…</code> · Created <Moment locale={Fider.currentLocale} date={scimToken.createdAt} /> ·{" "}
+              {scimToken.lastUsedAt ? (
+                <>
+                  Last used <Moment locale={Fider.currentLocale} date={scimToken.lastUsedAt} />
+                </>
+              ) : (
+                "Never used"
+              )}
+            </p>
+          ) : (
+            <p className="text-muted">SCIM provisioning is disabled until you generate a token.</p>
+          )}
+          {newSCIMToken && (
+            <div className="mt-4 p-4 bg-success-light border border-success-light rounded-card">
+              <p className="text-success font-medium mb-2">Your new SCIM token is:</p>
+              <code className="block p-3 bg-elevated border border-surface-alt rounded text-sm font-mono break-all">{newSCIMToken}</code>
+              <p className="text-muted text-sm mt-3">It won&apos;t be shown again. Copy it into your identity provider now.</p>
+            </div>
+          )}
+          <div className="c-admin-actions">
+            <HStack>
+              <Button variant="primary" onClick={generateSCIMToken}>
+                {scimToken ? "Regenerate token" : "Generate token"}
+              </Button>
+              {scimToken && (
+                <Button variant="danger" onClick={revokeSCIMToken}>
+                  Revoke
+                </Button>
+              )}
+            </HStack>
+          </div>
+        </div>
+      )}
     </VStack>
   )
 }
@@ -0,0 +1,141 @@
+import React, { useState } from "react"
+import { Button, Form, Input, Select, TextArea, Field } from "@fider/components"
+import { HStack } from "@fider/components/layout"
+import { BuiltinEmailTemplate, EmailTemplate, EmailTemplatePreviewResult } from "@fider/models"
+import { actions, Failure, notify } from "@fider/services"
+import { PageConfig } from "@fider/components/layouts"
+import locales from "@locale/locales"
+
+export const pageConfig: PageConfig = {
+  title: "Email Templates",
+  subtitle: "Customize the emails sent by your site",
+  sidebarItem: "emailtemplates",
+}
+
+interface ManageEmailTemplatesPageProps {
+  templates: BuiltinEmailTemplate[]
+  overrides: EmailTemplate[]
+  locale: string
+}
+
+const findOverride = (overrides: EmailTemplate[], name: string, locale: string) => overrides.find((o) => o.name === name && o.locale === locale)
+
+const ManageEmailTemplatesPage: React.FC<ManageEmailTemplatesPageProps> = (props) => {
+  const [overrides, setOverrides] = useState(props.overrides)
+  const [name, setName] = useState(props.templates[0].name)
+  const [locale, setLocale] = useState(props.locale)
+  const [subject, setSubject] = useState("")
+  const [body, setBody] = useState("")
+  const [preview, setPreview] = useState<EmailTemplatePreviewResult | undefined>()
+  const [error, setError] = useState<Failure | undefined>()
+
+  const template = props.templates.find((t) => t.name === name) || props.templates[0]
+  const override = findOverride(overrides, name, locale)
+
+  React.useEffect(() => {
+    const current = findOverride(overrides, name, locale)
+    setSubject(current ? current.subject : template.subject)
+    setBody(current ? current.body : template.body)
+    setPreview(undefined)
+    setError(undefined)
+  }, [name, locale])
+
+  const showPreview = async () => {
+    const result = await actions.previewEmailTemplate(name, locale, subject, body)
+    if (result.ok) {
+      setPreview(result.data)
+      setError(undefined)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const save = async () => {
+    const result = await actions.saveEmailTemplate(name, locale, subject, body)
+    if (result.ok) {
+      setOverrides((prev) => [...prev.filter((o) => o.name !== name || o.locale !== locale), result.data])
+      setError(undefined)
+      notify.success("The email template has been saved.")
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const reset = async () => {
+    const result = await actions.deleteEmailTemplate(name, locale)
+    if (result.ok) {
+      setOverrides((prev) => prev.filter((o) => o.name !== name || o.locale !== locale))
+      setSubject(template.subject)
+      setBody(template.body)
+      setPreview(undefined)
+      setError(undefined)
+      notify.success("The built-in email template has been restored.")
+    }
+  }
+
+  return (
+    <Form error={error}>
+      <div className="grid grid-cols-1 md:grid-cols-2 gap-4">
+        <Select
+          label="Template"
+          field="name"
+          value={name}
+          options={props.templates.map((t) => ({
+            value: t.name,
+            label: findOverride(overrides, t.name, locale) ? `${t.name} (customized)` : t.name,
+          }))}
+          onChange={(o) => setName(o?.value || props.templates[0].name)}
+        />
+        <Select
+          label="Locale"
+          field="locale"
+          value={locale}
+          options={Object.entries(locales).map(([k, v]) => ({
+            value: k,
+            label: v.text,
+          }))}
+          onChange={(o) => setLocale(o?.value || props.locale)}
+        />
+      </div>
+      <Input field="subject" label="Subject" value={subject} maxLength={200} onChange={setSubject} />
+      <TextArea field="body" label="Body" value={body} onChange={setBody} minRows={12} className="font-mono text-sm" />
+      <p className="text-muted text-sm mb-4">
+        Templates use Go template formatting. This template must keep the following placeholders:{" "}
+        {template.placeholders.map((p) => (
+          <code key={p} className="mr-2">{`{{ .${p} }}`}</code>
+        ))}
+        If a customized template fails to render, the built-in one is sent instead.
+      </p>
+      <HStack spacing={2}>
+        <Button variant="primary" onClick={save}>
+          Save
+        </Button>
+        <Button variant="secondary" onClick={showPreview}>
+          Preview
+        </Button>
+        {override && (
+          <Button variant="danger" onClick={reset}>
+            Restore built-in
+          </Button>
+        )}
+      </HStack>
+      {preview && (
+        <Field label="Preview">
+          {preview.error ? (
+            <div className="p-4 bg-danger-light border border-danger-light rounded-card text-danger">{preview.error}</div>
+          ) : (
+            <div className="bg-tertiary rounded-card border border-surface-alt overflow-hidden">
+              <div className="p-4 border-b border-surface-alt">
+                <h3 className="text-sm font-semibold text-foreground mb-2">Subject</h3>
+                <p className="m-0">{preview.subject}</p>
+              </div>
+              <iframe title="Email preview" className="w-full h-[600px] bg-white border-0" sandbox="" srcDoc={preview.body} />
+            </div>
+          )}
+        </Field>
+      )}
+    </Form>
+  )
+}
+
+export default ManageEmailTemplatesPage
@@ -0,0 +1,109 @@
+import React, { useState } from "react"
+import { OAuthProviderOption, UserMergeRequest } from "@fider/models"
+import { actions, notify } from "@fider/services"
+import { Avatar, Button, Moment } from "@fider/components"
+import { PageConfig } from "@fider/components/layouts"
+import { useFider } from "@fider/hooks"
+import { Trans } from "@lingui/react/macro"
+import { i18n } from "@lingui/core"
+
+export const pageConfig: PageConfig = {
+  title: "Merge Requests",
+  subtitle: "Review sign-in accounts that members have claimed from another member",
+  sidebarItem: "mergerequests",
+  layoutVariant: "default",
+}
+
+interface ManageMergeRequestsPageProps {
+  requests: UserMergeRequest[]
+  providers: OAuthProviderOption[]
+}
+
+const MergeRequestItem: React.FC<{ request: UserMergeRequest; providerName: string; onDecided: (id: number) => void }> = ({
+  request,
+  providerName,
+  onDecided,
+}) => {
+  const fider = useFider()
+  const isInvolved = request.user.id === fider.session.user.id || request.owner.id === fider.session.user.id
+
+  const decide = async (status: "approved" | "rejected") => {
+    const result = await actions.decideUserMergeRequest(request.id, status)
+    if (result.ok) {
+      notify.success(i18n._("admin.mergerequests.decided", { message: "Merge request decided" }))
+      onDecided(request.id)
+    } else if (result.error && result.error.errors && result.error.errors.length > 0) {
+      notify.error(result.error.errors[0].message)
+    }
+  }
+
+  return (
+    <div className="border border-surface-alt rounded-card bg-elevated p-4">
+      <div className="flex items-start justify-between gap-4">
+        <div className="flex items-center gap-2">
+          <Avatar user={request.user} />
+          <a href={`/profile/${request.user.id}`} className="font-semibold text-foreground hover:text-primary">
+            {request.user.name}
+          </a>
+        </div>
+        <span className="text-xs text-muted">
+          <Moment locale={fider.currentLocale} date={request.createdAt} />
+        </span>
+      </div>
+      <p className="m-0 mt-3">
+        <Trans id="admin.mergerequests.claims">
+          Wants to sign in with the {providerName} account that is linked to
+        </Trans>{" "}
+        <a href={`/profile/${request.owner.id}`} className="font-semibold text-foreground hover:text-primary">
+          {request.owner.name}
+        </a>
+      </p>
+      <p className="m-0 text-xs text-muted">{request.providerUid}</p>
+
+      {!isInvolved && (
+        <div className="flex gap-2 mt-4">
+          <Button size="small" variant="primary" onClick={() => decide("approved")}>
+            <Trans id="admin.mergerequests.approve">Move account</Trans>
+          </Button>
+          <Button size="small" variant="secondary" onClick={() => decide("rejected")}>
+            <Trans id="admin.mergerequests.reject">Reject</Trans>
+          </Button>
+        </div>
+      )}
+    </div>
+  )
+}
+
+const ManageMergeRequestsPage: React.FC<ManageMergeRequestsPageProps> = (props) => {
+  const [requests, setRequests] = useState(props.requests || [])
+
+  const providerName = (provider: string) => {
+    const option = (props.providers || []).find((p) => p.provider === provider)
+    return option ? option.displayName : provider
+  }
+
+  const onDecided = (id: number) => setRequests((prev) => prev.filter((r) => r.id !== id))
+
+  return (
+    <div>
+      <p className="text-muted text-sm mb-4">
+        <Trans id="admin.mergerequests.description">
+          Moving an account lets the member sign in with it from now on. Posts, comments and votes of both members stay where they are.
+        </Trans>
+      </p>
+      {requests.length === 0 ? (
+        <p className="text-muted">
+          <Trans id="admin.mergerequests.empty">No merge requests to review.</Trans>
+        </p>
+      ) : (
+        <div className="flex flex-col gap-3">
+          {requests.map((request) => (
+            <MergeRequestItem key={request.id} request={request} providerName={providerName(request.provider)} onDecided={onDecided} />
+          ))}
+        </div>
+      )}
+    </div>
+  )
+}
+
+export default ManageMergeRequestsPage
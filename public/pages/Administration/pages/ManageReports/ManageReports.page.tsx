@@ -13,7 +13,7 @@ export const pageConfig: PageConfig = {
 }
 
 interface HistoryState extends Record<string, unknown> {
-  reportId?: number
+  caseId?: number
   userId?: number
 }
 
@@ -27,26 +27,26 @@ const ManageReportsPage: React.FC = () => {
 
   const handleStateChange = useCallback(
     (historyState: HistoryState | null) => {
-      if (historyState?.userId && historyState?.reportId) {
-        const report = state.reports.find((r) => r.id === historyState.reportId)
-        if (report) {
-          state.setSelectedReport(report)
+      if (historyState?.userId && historyState?.caseId) {
+        const reportCase = state.cases.find((c) => c.id === historyState.caseId)
+        if (reportCase) {
+          state.setSelectedCase(reportCase)
         }
-      } else if (historyState?.reportId) {
-        const report = state.reports.find((r) => r.id === historyState.reportId)
-        if (report) {
-          state.setSelectedReport(report)
+      } else if (historyState?.caseId) {
+        const reportCase = state.cases.find((c) => c.id === historyState.caseId)
+        if (reportCase) {
+          state.setSelectedCase(reportCase)
           state.setViewingUser(null)
         } else {
-          state.setSelectedReport(null)
+          state.setSelectedCase(null)
           state.setViewingUser(null)
         }
       } else {
-        state.setSelectedReport(null)
+        state.setSelectedCase(null)
         state.setViewingUser(null)
       }
     },
-    [state.reports, state.setSelectedReport, state.setViewingUser]
+    [state.cases, state.setSelectedCase, state.setViewingUser]
   )
 
   const { pushState, isNavigating } = useStackNavigation<HistoryState>({
@@ -55,12 +55,14 @@ const ManageReportsPage: React.FC = () => {
   })
 
   const actions = useReportsActions({
+    selectedCase: state.selectedCase,
     selectedReport: state.selectedReport,
     selectedStatusRef: state.selectedStatusRef,
     resolveAction: state.resolveAction,
     resolutionNote: state.resolutionNote,
     reasons: state.reasons,
-    setReports: state.setReports,
+    setCases: state.setCases,
+    setSelectedCase: state.setSelectedCase,
     setSelectedReport: state.setSelectedReport,
     setSelectedStatus: state.setSelectedStatus,
     setSelectedType: state.setSelectedType,
@@ -73,31 +75,32 @@ const ManageReportsPage: React.FC = () => {
     setResolveAction: state.setResolveAction,
     setResolutionNote: state.setResolutionNote,
     setError: state.setError,
-    loadReports: state.loadReports,
+    loadCases: state.loadCases,
     pushState,
     isNavigating,
   })
 
   useReportsEvents({
-    selectedReportRef: state.selectedReportRef,
+    selectedCaseRef: state.selectedCaseRef,
     selectedStatusRef: state.selectedStatusRef,
-    setReports: state.setReports,
+    setCases: state.setCases,
+    setSelectedCase: state.setSelectedCase,
     setSelectedReport: state.setSelectedReport,
     setNewReportIds: state.setNewReportIds,
   })
 
   useEffect(() => {
-    state.loadReports()
-  }, [state.loadReports])
+    state.loadCases()
+  }, [state.loadCases])
 
   useEffect(() => {
-    if (state.selectedReport) {
-      state.loadPreviewContent(state.selectedReport.id)
-      viewItem(state.selectedReport.id)
+    if (state.selectedCase) {
+      state.loadPreviewContent(state.selectedCase)
+      viewItem(state.selectedCase.leadReportId)
     } else {
       stopViewing()
     }
-  }, [state.selectedReport?.id])
+  }, [state.selectedCase?.id])
 
   useEffect(() => {
     return () => stopViewing()
@@ -110,12 +113,12 @@ const ManageReportsPage: React.FC = () => {
   return (
     <div className="flex flex-col lg:flex-row gap-4 h-[calc(100vh-130px)] min-h-[500px]">
       <ReportsList
-        reports={state.reports}
+        cases={state.cases}
         total={state.total}
         page={state.page}
         perPage={state.perPage}
         isLoading={state.isLoading}
-        selectedReport={state.selectedReport}
+        selectedCase={state.selectedCase}
         selectedStatus={state.selectedStatus}
         selectedType={state.selectedType}
         selectedReason={state.selectedReason}
@@ -124,23 +127,24 @@ const ManageReportsPage: React.FC = () => {
         statusOptions={actions.statusOptions}
         typeOptions={actions.typeOptions}
         reasonOptions={actions.reasonOptions}
-        onSelectReport={actions.handleSelectReport}
+        onSelectCase={actions.handleSelectCase}
         onStatusChange={actions.handleStatusChange}
         onTypeChange={actions.handleTypeChange}
         onReasonChange={actions.handleReasonChange}
-        onRefresh={state.loadReports}
+        onRefresh={state.loadCases}
         onRefreshNewReports={actions.handleRefreshNewReports}
         onPrevPage={actions.handlePrevPage}
         onNextPage={actions.handleNextPage}
       />
       <ReportsPreview
+        selectedCase={state.selectedCase}
         selectedReport={state.selectedReport}
         viewingUser={state.viewingUser}
         profileKey={state.profileKey}
         previewPost={state.previewPost}
         previewComment={state.previewComment}
         isLoadingPreview={state.isLoadingPreview}
-        onDeselectReport={actions.handleDeselectReport}
+        onDeselectCase={actions.handleDeselectCase}
         onCloseUserProfile={actions.handleCloseUserProfile}
         onAssign={actions.handleAssign}
         onUnassign={actions.handleUnassign}
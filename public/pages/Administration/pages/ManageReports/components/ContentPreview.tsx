@@ -7,9 +7,10 @@ import {
   heroiconsX as IconX,
   heroiconsExternalLink as IconExternalLink,
 } from "@fider/icons.generated"
-import { Report, Post, Comment, UserRole, UserStatus } from "@fider/models"
+import { Report, ReportCase, Post, Comment, User, UserRole, UserStatus } from "@fider/models"
 
 export interface ContentPreviewProps {
+  reportCase: ReportCase | null
   report: Report | null
   post: Post | null
   comment: Comment | null
@@ -28,6 +29,7 @@ export interface ContentPreviewProps {
 }
 
 export const ContentPreview: React.FC<ContentPreviewProps> = ({
+  reportCase,
   report,
   post,
   comment,
@@ -38,7 +40,7 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
   currentUserId,
   onUserClick,
 }) => {
-  if (!report) {
+  if (!reportCase) {
     return (
       <div className="bg-elevated rounded-panel min-h-[400px] flex items-center justify-center">
         <div className="text-center p-8">
@@ -48,7 +50,7 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
     )
   }
 
-  if (isLoading) {
+  if (isLoading || !report) {
     return (
       <div className="bg-elevated rounded-panel min-h-[400px] flex items-center justify-center">
         <Loader />
@@ -57,20 +59,29 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
   }
 
   const isAssignedToMe = report.assignedTo?.id === currentUserId
-  const canAction = report.status === "pending" || report.status === "in_review"
+  const canAction = reportCase.status === "pending" || reportCase.status === "in_review"
 
   const getTargetLink = () => {
-    if (!report.postNumber || !report.postSlug) return "#"
-    if (report.reportedType === "post") {
-      return `/posts/${report.postNumber}/${report.postSlug}`
+    if (!reportCase.postNumber || !reportCase.postSlug) return "#"
+    if (reportCase.reportedType === "post") {
+      return `/posts/${reportCase.postNumber}/${reportCase.postSlug}`
     }
-    if (report.reportedType === "comment") {
-      return `/posts/${report.postNumber}/${report.postSlug}#comment-${report.reportedId}`
+    if (reportCase.reportedType === "comment") {
+      return `/posts/${reportCase.postNumber}/${reportCase.postSlug}#comment-${reportCase.reportedId}`
     }
     return "#"
   }
 
-  const reportedUser = report.reportedType === "post" ? post?.user : comment?.user
+  const viewUser = (user: User) =>
+    onUserClick?.({
+      id: user.id,
+      name: user.name,
+      avatarURL: user.avatarURL,
+      role: user.role,
+      status: user.status,
+    })
+
+  const reportedUser = reportCase.reportedType === "post" ? post?.user : comment?.user
 
   return (
     <div className="bg-elevated rounded-panel min-h-[400px]">
@@ -127,63 +138,71 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
           <span
             className={classSet({
               "px-3 py-1 rounded-full text-sm font-medium": true,
-              "bg-success-medium text-success": report.status === "resolved",
-              "bg-surface-alt text-muted": report.status === "dismissed",
+              "bg-success-medium text-success": reportCase.status === "resolved",
+              "bg-surface-alt text-muted": reportCase.status === "dismissed",
             })}
           >
-            {report.status === "resolved" ? "Resolved" : "Dismissed"}
+            {reportCase.status === "resolved" ? "Resolved" : "Dismissed"}
           </span>
         )}
       </div>
 
       <div className="p-4 px-5 max-lg:p-3 max-lg:px-4 border-b border-surface-alt">
-        <h4 className="text-base font-semibold text-foreground m-0 mb-3">Report Information</h4>
-        <div className="grid grid-cols-2 gap-4 max-lg:grid-cols-1">
-          <div className="flex flex-col gap-1">
-            <label className="text-xs text-muted uppercase tracking-wide">Reason</label>
-            <span className="inline-block px-2 py-1 rounded bg-warning/10 text-warning text-sm font-medium w-fit">{report.reason}</span>
-          </div>
-          <div className="flex flex-col gap-1">
-            <label className="text-xs text-muted uppercase tracking-wide">Reported by</label>
-            <div
-              className="p-3 bg-tertiary rounded-card cursor-pointer hover:bg-surface-alt transition-colors group relative"
-              onClick={() =>
-                onUserClick?.({
-                  id: report.reporter.id,
-                  name: report.reporter.name,
-                  avatarURL: report.reporter.avatarURL,
-                  role: report.reporter.role,
-                  status: report.reporter.status,
-                })
-              }
-              role="button"
-              tabIndex={0}
-            >
-              <HStack spacing={2}>
-                <Avatar user={report.reporter} clickable={false} />
-                <div>
-                  <div className="font-medium">{report.reporter.name}</div>
-                  <div className="text-xs text-muted">
-                    <Moment locale={Fider.currentLocale} date={report.createdAt} />
+        <h4 className="text-base font-semibold text-foreground m-0 mb-3">
+          {reportCase.reportCount} report{reportCase.reportCount !== 1 ? "s" : ""} from {reportCase.reporterCount} user
+          {reportCase.reporterCount !== 1 ? "s" : ""}
+        </h4>
+        <div className="flex flex-wrap gap-2 mb-4">
+          {reportCase.reasons.map((r) => (
+            <span key={r.reason} className="inline-block px-2 py-1 rounded bg-warning/10 text-warning text-sm font-medium">
+              {r.reason} ×{r.count}
+            </span>
+          ))}
+          {reportCase.autoHiddenAt && (
+            <span className="inline-block px-2 py-1 rounded bg-surface-alt text-muted text-sm">
+              Auto-hidden <Moment locale={Fider.currentLocale} date={reportCase.autoHiddenAt} />
+            </span>
+          )}
+        </div>
+        <div className="flex flex-col gap-2">
+          {(reportCase.reports || []).map((r) => (
+            <div key={r.id} className="p-3 bg-tertiary rounded-card">
+              <div className="flex items-center justify-between gap-2">
+                {r.reporter ? (
+                  <div
+                    className="cursor-pointer hover:text-primary transition-colors"
+                    onClick={() => viewUser(r.reporter!)}
+                    role="button"
+                    tabIndex={0}
+                  >
+                    <HStack spacing={2}>
+                      <Avatar user={r.reporter} clickable={false} />
+                      <span className="font-medium">{r.reporter.name}</span>
+                    </HStack>
                   </div>
+                ) : (
+                  <span className="text-sm">Automated moderation</span>
+                )}
+                <span className="text-xs text-muted">
+                  <Moment locale={Fider.currentLocale} date={r.createdAt} />
+                </span>
+              </div>
+              <div className="mt-2 text-sm font-medium">{r.reason}</div>
+              {r.details && <div className="mt-1 text-sm text-muted">{r.details}</div>}
+              {r.reporterScore && (
+                <div className="mt-1 text-xs text-muted">
+                  {Math.round(r.reporterScore.score * 100)}% accuracy ({r.reporterScore.resolved} actioned, {r.reporterScore.dismissed} dismissed)
                 </div>
-              </HStack>
-              <span className="text-xs text-primary opacity-0 group-hover:opacity-100 transition-opacity absolute right-3 top-1/2 -translate-y-1/2">View profile</span>
+              )}
             </div>
-          </div>
+          ))}
         </div>
-        {report.details && (
-          <div className="mt-4">
-            <label className="text-xs text-muted uppercase tracking-wide block mb-1">Additional Details</label>
-            <div className="p-3 bg-tertiary rounded-card text-sm">{report.details}</div>
-          </div>
-        )}
       </div>
 
       <div className="p-4 px-5 max-lg:p-3 max-lg:px-4 border-b border-surface-alt">
         <div className="flex items-center justify-between mb-3">
           <h4 className="text-base font-semibold text-foreground m-0">
-            Reported {report.reportedType === "post" ? "Post" : "Comment"}
+            Reported {reportCase.reportedType === "post" ? "Post" : "Comment"}
           </h4>
           <a
             href={getTargetLink()}
@@ -199,15 +218,7 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
         {reportedUser && (
           <div
             className="p-3 mb-4 bg-tertiary rounded-card cursor-pointer hover:bg-surface-alt transition-colors group relative"
-            onClick={() =>
-              onUserClick?.({
-                id: reportedUser.id,
-                name: reportedUser.name,
-                avatarURL: reportedUser.avatarURL,
-                role: reportedUser.role,
-                status: reportedUser.status,
-              })
-            }
+            onClick={() => viewUser(reportedUser)}
             role="button"
             tabIndex={0}
           >
@@ -216,10 +227,10 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
               <div>
                 <div className="font-medium">{reportedUser.name}</div>
                 <div className="text-xs text-muted">
-                  {report.reportedType === "post" && post && (
+                  {reportCase.reportedType === "post" && post && (
                     <Moment locale={Fider.currentLocale} date={post.createdAt} />
                   )}
-                  {report.reportedType === "comment" && comment && (
+                  {reportCase.reportedType === "comment" && comment && (
                     <Moment locale={Fider.currentLocale} date={comment.createdAt} />
                   )}
                 </div>
@@ -230,7 +241,7 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
         )}
 
         <div className="bg-elevated border border-surface-alt rounded-card p-4">
-          {report.reportedType === "post" && post && (
+          {reportCase.reportedType === "post" && post && (
             <>
               <h5 className="text-lg font-semibold text-foreground m-0 mb-3 break-words">{post.title}</h5>
               {post.description && (
@@ -241,7 +252,7 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
             </>
           )}
 
-          {report.reportedType === "comment" && comment && (
+          {reportCase.reportedType === "comment" && comment && (
             <>
               <div className="text-muted leading-relaxed [&_.c-markdown]:text-sm">
                 <Markdown text={comment.content} style="full" />
@@ -281,43 +292,43 @@ export const ContentPreview: React.FC<ContentPreviewProps> = ({
         </div>
       )}
 
-      {(report.status === "resolved" || report.status === "dismissed") && (
+      {(reportCase.status === "resolved" || reportCase.status === "dismissed") && (
         <div className={classSet({
           "p-4 px-5 max-lg:p-3 max-lg:px-4 border-b border-surface-alt last:border-b-0": true,
-          "bg-success-light": report.status === "resolved",
-          "bg-surface-alt": report.status === "dismissed",
+          "bg-success-light": reportCase.status === "resolved",
+          "bg-surface-alt": reportCase.status === "dismissed",
         })}>
           <h4 className="text-base font-semibold text-foreground m-0 mb-3">
-            {report.status === "resolved" ? "Resolution Details" : "Dismissal Details"}
+            {reportCase.status === "resolved" ? "Resolution Details" : "Dismissal Details"}
           </h4>
           <div className="grid grid-cols-2 gap-4 max-lg:grid-cols-1">
-            {report.resolvedBy && (
+            {reportCase.resolvedBy && (
               <div className="flex flex-col gap-1">
-                <label className="text-xs text-muted uppercase tracking-wide">{report.status === "resolved" ? "Resolved by" : "Dismissed by"}</label>
+                <label className="text-xs text-muted uppercase tracking-wide">{reportCase.status === "resolved" ? "Resolved by" : "Dismissed by"}</label>
                 <HStack spacing={2}>
-                  <Avatar user={report.resolvedBy} clickable={false} />
-                  <span className="font-medium">{report.resolvedBy.name}</span>
+                  <Avatar user={reportCase.resolvedBy} clickable={false} />
+                  <span className="font-medium">{reportCase.resolvedBy.name}</span>
                 </HStack>
               </div>
             )}
-            {report.resolvedAt && (
+            {reportCase.resolvedAt && (
               <div className="flex flex-col gap-1">
-                <label className="text-xs text-muted uppercase tracking-wide">{report.status === "resolved" ? "Resolved at" : "Dismissed at"}</label>
+                <label className="text-xs text-muted uppercase tracking-wide">{reportCase.status === "resolved" ? "Resolved at" : "Dismissed at"}</label>
                 <span className="text-sm">
-                  <Moment locale={Fider.currentLocale} date={report.resolvedAt} />
+                  <Moment locale={Fider.currentLocale} date={reportCase.resolvedAt} />
                 </span>
               </div>
             )}
           </div>
-          {report.resolutionNote && (
+          {reportCase.resolutionNote && (
             <div className="mt-4">
               <label className="text-xs text-muted uppercase tracking-wide block mb-1">Notes</label>
               <div className="p-3 bg-elevated rounded-card text-sm">
-                {report.resolutionNote}
+                {reportCase.resolutionNote}
               </div>
             </div>
           )}
-          {!report.resolutionNote && (
+          {!reportCase.resolutionNote && (
             <div className="mt-4 text-sm text-muted italic">
               No notes provided
             </div>
@@ -1,9 +1,9 @@
 import React, { useCallback, useMemo, memo } from "react"
-import { Icon, Avatar, Moment } from "@fider/components"
+import { Icon, Moment } from "@fider/components"
 import { HStack } from "@fider/components/layout"
 import { Fider, classSet } from "@fider/services"
 import { heroiconsEye as IconEye } from "@fider/icons.generated"
-import { Report, getReportTypeLabel, ViewerInfo, ReportStatus } from "@fider/models"
+import { ReportCase, getReportTypeLabel, ViewerInfo, ReportStatus } from "@fider/models"
 
 const getStatusClasses = (status: ReportStatus): string => {
   switch (status) {
@@ -19,28 +19,28 @@ const getStatusClasses = (status: ReportStatus): string => {
   }
 }
 
-export interface ReportListItemProps {
-  report: Report
+export interface ReportCaseListItemProps {
+  reportCase: ReportCase
   isSelected: boolean
-  onClick: (report: Report) => void
+  onClick: (reportCase: ReportCase) => void
   viewers: ViewerInfo[]
 }
 
-export const ReportListItem: React.FC<ReportListItemProps> = memo(({
-  report,
+export const ReportCaseListItem: React.FC<ReportCaseListItemProps> = memo(({
+  reportCase,
   isSelected,
   onClick,
   viewers,
 }) => {
   const handleClick = useCallback(() => {
-    onClick(report)
-  }, [onClick, report])
+    onClick(reportCase)
+  }, [onClick, reportCase])
 
   const className = classSet({
     "p-3 cursor-pointer transition-colors border-l-[3px] border-l-transparent hover:bg-surface-alt": true,
     "bg-info-light border-l-primary hover:bg-info-light": isSelected,
-    "border-l-warning": report.status === "pending" && !isSelected,
-    "border-l-info": report.status === "in_review" && !isSelected,
+    "border-l-warning": reportCase.status === "pending" && !isSelected,
+    "border-l-info": reportCase.status === "in_review" && !isSelected,
   })
 
   const otherViewers = useMemo(() => 
@@ -52,7 +52,7 @@ export const ReportListItem: React.FC<ReportListItemProps> = memo(({
     <div className={className} onClick={handleClick}>
       <div className="flex items-center justify-between mb-1">
         <span className="text-xs font-semibold uppercase text-muted">
-          {getReportTypeLabel(report.reportedType)}
+          {getReportTypeLabel(reportCase.reportedType)}
         </span>
         <HStack spacing={1}>
           {otherViewers.length > 0 && (
@@ -66,20 +66,26 @@ export const ReportListItem: React.FC<ReportListItemProps> = memo(({
           )}
           <span className={classSet({
               "text-xs px-1.5 py-0.5 rounded capitalize": true,
-              [getStatusClasses(report.status)]: true,
+              [getStatusClasses(reportCase.status)]: true,
             })}>
-            {report.status.replace("_", " ")}
+            {reportCase.status.replace("_", " ")}
           </span>
         </HStack>
       </div>
-      <div className="text-sm text-foreground font-medium mb-1 whitespace-nowrap overflow-hidden text-ellipsis">{report.reason}</div>
-      <div className="flex items-center gap-1 text-xs text-muted [&_.c-avatar]:w-5 [&_.c-avatar]:h-5">
-        <Avatar user={report.reporter} clickable={false} />
-        <span>{report.reporter.name}</span>
-        <Moment locale={Fider.currentLocale} date={report.createdAt} />
+      <div className="text-sm text-foreground font-medium mb-1 whitespace-nowrap overflow-hidden text-ellipsis">
+        {reportCase.reasons.map((r) => (r.count > 1 ? `${r.reason} ×${r.count}` : r.reason)).join(", ")}
+      </div>
+      <div className="flex items-center gap-1 text-xs text-muted">
+        <span>
+          {reportCase.reportCount} report{reportCase.reportCount !== 1 ? "s" : ""} from {reportCase.reporterCount} user
+          {reportCase.reporterCount !== 1 ? "s" : ""}
+        </span>
+        <span>·</span>
+        <Moment locale={Fider.currentLocale} date={reportCase.lastReportedAt} />
+        {reportCase.autoHiddenAt && <span className="ml-auto text-warning">Auto-hidden</span>}
       </div>
     </div>
   )
 })
 
-ReportListItem.displayName = "ReportListItem"
+ReportCaseListItem.displayName = "ReportCaseListItem"
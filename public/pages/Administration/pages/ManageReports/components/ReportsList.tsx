@@ -2,17 +2,17 @@ import React from "react"
 import { Button, Loader, Select, SelectOption, Icon } from "@fider/components"
 import { VStack } from "@fider/components/layout"
 import { heroiconsRefresh as IconRefresh } from "@fider/icons.generated"
-import { Report, ReportStatus, ReportType, ViewerInfo } from "@fider/models"
+import { ReportCase, ReportStatus, ReportType, ViewerInfo } from "@fider/models"
 import { Trans } from "@lingui/react/macro"
-import { ReportListItem } from "./ReportListItem"
+import { ReportCaseListItem } from "./ReportCaseListItem"
 
 interface ReportsListProps {
-  reports: Report[]
+  cases: ReportCase[]
   total: number
   page: number
   perPage: number
   isLoading: boolean
-  selectedReport: Report | null
+  selectedCase: ReportCase | null
   selectedStatus: ReportStatus | "active"
   selectedType: ReportType | ""
   selectedReason: string
@@ -21,7 +21,7 @@ interface ReportsListProps {
   statusOptions: SelectOption[]
   typeOptions: SelectOption[]
   reasonOptions: SelectOption[]
-  onSelectReport: (report: Report) => void
+  onSelectCase: (reportCase: ReportCase) => void
   onStatusChange: (option?: SelectOption) => void
   onTypeChange: (option?: SelectOption) => void
   onReasonChange: (option?: SelectOption) => void
@@ -32,12 +32,12 @@ interface ReportsListProps {
 }
 
 export const ReportsList: React.FC<ReportsListProps> = ({
-  reports,
+  cases,
   total,
   page,
   perPage,
   isLoading,
-  selectedReport,
+  selectedCase,
   selectedStatus,
   selectedType,
   selectedReason,
@@ -46,7 +46,7 @@ export const ReportsList: React.FC<ReportsListProps> = ({
   statusOptions,
   typeOptions,
   reasonOptions,
-  onSelectReport,
+  onSelectCase,
   onStatusChange,
   onTypeChange,
   onReasonChange,
@@ -55,8 +55,8 @@ export const ReportsList: React.FC<ReportsListProps> = ({
   onPrevPage,
   onNextPage,
 }) => {
-  const getViewersForReport = (reportId: number): ViewerInfo[] => {
-    return viewers.get(reportId) || []
+  const getViewersForCase = (reportCase: ReportCase): ViewerInfo[] => {
+    return viewers.get(reportCase.leadReportId) || []
   }
 
   return (
@@ -105,32 +105,32 @@ export const ReportsList: React.FC<ReportsListProps> = ({
           <div className="py-8 text-center">
             <Loader />
           </div>
-        ) : reports.length === 0 ? (
+        ) : cases.length === 0 ? (
           <div className="py-8 text-center text-muted">
             <Trans id="reports.empty">No reports found.</Trans>
           </div>
         ) : (
           <VStack spacing={0} divide>
-            {reports.map((report) => (
-              <ReportListItem
-                key={report.id}
-                report={report}
-                isSelected={selectedReport?.id === report.id}
-                onClick={onSelectReport}
-                viewers={getViewersForReport(report.id)}
+            {cases.map((reportCase) => (
+              <ReportCaseListItem
+                key={reportCase.id}
+                reportCase={reportCase}
+                isSelected={selectedCase?.id === reportCase.id}
+                onClick={onSelectCase}
+                viewers={getViewersForCase(reportCase)}
               />
             ))}
           </VStack>
         )}
       </div>
 
-      {total > reports.length && (
+      {total > cases.length && (
         <div className="flex items-center justify-between py-2 px-3 border-t border-surface-alt bg-tertiary shrink-0">
           <Button size="small" variant="tertiary" disabled={page === 1} onClick={onPrevPage}>
             Prev
           </Button>
           <span className="text-muted text-sm">Page {page}</span>
-          <Button size="small" variant="tertiary" disabled={reports.length < perPage} onClick={onNextPage}>
+          <Button size="small" variant="tertiary" disabled={cases.length < perPage} onClick={onNextPage}>
             Next
           </Button>
         </div>
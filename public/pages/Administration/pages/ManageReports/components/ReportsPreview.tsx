@@ -5,19 +5,20 @@ import {
   heroiconsChevronUp as IconChevronUp,
   heroiconsArrowLeft as IconArrowLeft,
 } from "@fider/icons.generated"
-import { Report, Post, Comment, User } from "@fider/models"
+import { Report, ReportCase, Post, Comment, User } from "@fider/models"
 import { UserProfile } from "@fider/components/UserProfile"
 import { ContentPreview } from "./ContentPreview"
 import { ViewingUserType } from "../hooks/useReportsState"
 
 interface ReportsPreviewProps {
+  selectedCase: ReportCase | null
   selectedReport: Report | null
   viewingUser: ViewingUserType | null
   profileKey: number
   previewPost: Post | null
   previewComment: Comment | null
   isLoadingPreview: boolean
-  onDeselectReport: () => void
+  onDeselectCase: () => void
   onCloseUserProfile: () => void
   onAssign: () => Promise<void>
   onUnassign: () => Promise<void>
@@ -27,13 +28,14 @@ interface ReportsPreviewProps {
 }
 
 export const ReportsPreview: React.FC<ReportsPreviewProps> = ({
+  selectedCase,
   selectedReport,
   viewingUser,
   profileKey,
   previewPost,
   previewComment,
   isLoadingPreview,
-  onDeselectReport,
+  onDeselectCase,
   onCloseUserProfile,
   onAssign,
   onUnassign,
@@ -45,8 +47,8 @@ export const ReportsPreview: React.FC<ReportsPreviewProps> = ({
     <div
       className={classSet({
         "flex-1 min-w-0 h-full min-h-full overflow-y-auto bg-tertiary rounded-panel border border-surface-alt relative lg:max-h-[90vh]": true,
-        "max-lg:hidden": selectedReport === null && viewingUser === null,
-        "max-lg:fixed max-lg:inset-0 max-lg:z-modal max-lg:overflow-y-auto max-lg:p-4": selectedReport !== null || viewingUser !== null,
+        "max-lg:hidden": selectedCase === null && viewingUser === null,
+        "max-lg:fixed max-lg:inset-0 max-lg:z-modal max-lg:overflow-y-auto max-lg:p-4": selectedCase !== null || viewingUser !== null,
       })}
     >
       {viewingUser ? (
@@ -79,18 +81,19 @@ export const ReportsPreview: React.FC<ReportsPreviewProps> = ({
         </>
       ) : (
         <>
-          {selectedReport && (
+          {selectedCase && (
             <Button
               variant="tertiary"
               size="small"
               className="hidden max-lg:flex mb-3"
-              onClick={onDeselectReport}
+              onClick={onDeselectCase}
             >
               <Icon sprite={IconChevronUp} className="-rotate-90 w-4 h-4" />
               <span>Back to list</span>
             </Button>
           )}
           <ContentPreview
+            reportCase={selectedCase}
             report={selectedReport}
             post={previewPost}
             comment={previewComment}
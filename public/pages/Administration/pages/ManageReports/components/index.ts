@@ -1,6 +1,6 @@
 export { ReportsList } from "./ReportsList"
 export { ReportsPreview } from "./ReportsPreview"
 export { ResolveModal } from "./ResolveModal"
-export { ReportListItem } from "./ReportListItem"
+export { ReportCaseListItem } from "./ReportCaseListItem"
 export { ContentPreview } from "./ContentPreview"
 
@@ -1,17 +1,19 @@
 import { useCallback, useMemo } from "react"
-import { Report, ReportStatus, ReportType, ReportReason } from "@fider/models"
+import { Report, ReportCase, ReportStatus, ReportType, ReportReason } from "@fider/models"
 import { actions, Fider, Failure } from "@fider/services"
 import { SelectOption } from "@fider/components"
 import { i18n } from "@lingui/core"
 import { ViewingUserType } from "./useReportsState"
 
 interface UseReportsActionsConfig {
+  selectedCase: ReportCase | null
   selectedReport: Report | null
   selectedStatusRef: React.MutableRefObject<ReportStatus | "active">
   resolveAction: "resolved" | "dismissed"
   resolutionNote: string
   reasons: ReportReason[]
-  setReports: React.Dispatch<React.SetStateAction<Report[]>>
+  setCases: React.Dispatch<React.SetStateAction<ReportCase[]>>
+  setSelectedCase: React.Dispatch<React.SetStateAction<ReportCase | null>>
   setSelectedReport: React.Dispatch<React.SetStateAction<Report | null>>
   setSelectedStatus: React.Dispatch<React.SetStateAction<ReportStatus | "active">>
   setSelectedType: React.Dispatch<React.SetStateAction<ReportType | "">>
@@ -24,7 +26,7 @@ interface UseReportsActionsConfig {
   setResolveAction: React.Dispatch<React.SetStateAction<"resolved" | "dismissed">>
   setResolutionNote: React.Dispatch<React.SetStateAction<string>>
   setError: React.Dispatch<React.SetStateAction<Failure | undefined>>
-  loadReports: () => Promise<void>
+  loadCases: () => Promise<void>
   pushState: (state: Record<string, unknown>) => void
   isNavigating: React.MutableRefObject<boolean>
 }
@@ -36,8 +38,8 @@ interface UseReportsActionsResult {
   handleStatusChange: (option?: SelectOption) => void
   handleTypeChange: (option?: SelectOption) => void
   handleReasonChange: (option?: SelectOption) => void
-  handleSelectReport: (report: Report) => void
-  handleDeselectReport: () => void
+  handleSelectCase: (reportCase: ReportCase) => void
+  handleDeselectCase: () => void
   handleViewUser: (user: ViewingUserType) => void
   handleCloseUserProfile: () => void
   handleAssign: () => Promise<void>
@@ -52,12 +54,14 @@ interface UseReportsActionsResult {
 
 export const useReportsActions = (config: UseReportsActionsConfig): UseReportsActionsResult => {
   const {
+    selectedCase,
     selectedReport,
     selectedStatusRef,
     resolveAction,
     resolutionNote,
     reasons,
-    setReports,
+    setCases,
+    setSelectedCase,
     setSelectedReport,
     setSelectedStatus,
     setSelectedType,
@@ -70,7 +74,7 @@ export const useReportsActions = (config: UseReportsActionsConfig): UseReportsAc
     setResolveAction,
     setResolutionNote,
     setError,
-    loadReports,
+    loadCases,
     pushState,
     isNavigating,
   } = config
@@ -110,84 +114,80 @@ export const useReportsActions = (config: UseReportsActionsConfig): UseReportsAc
     setPage(1)
   }, [setSelectedReason, setPage])
 
-  const handleSelectReport = useCallback((report: Report) => {
+  const handleSelectCase = useCallback((reportCase: ReportCase) => {
     if (!isNavigating.current) {
-      pushState({ reportId: report.id })
+      pushState({ caseId: reportCase.id })
     }
-    setSelectedReport(report)
+    setSelectedCase(reportCase)
+    setSelectedReport(null)
     setViewingUser(null)
-    setNewReportIds((prev) => {
-      const newSet = new Set(prev)
-      newSet.delete(report.id)
-      return newSet
-    })
-  }, [isNavigating, pushState, setSelectedReport, setViewingUser, setNewReportIds])
+  }, [isNavigating, pushState, setSelectedCase, setSelectedReport, setViewingUser])
 
-  const handleDeselectReport = useCallback(() => {
+  const handleDeselectCase = useCallback(() => {
     if (!isNavigating.current) {
       pushState({})
     }
+    setSelectedCase(null)
     setSelectedReport(null)
     setViewingUser(null)
-  }, [isNavigating, pushState, setSelectedReport, setViewingUser])
+  }, [isNavigating, pushState, setSelectedCase, setSelectedReport, setViewingUser])
 
   const handleViewUser = useCallback((user: ViewingUserType) => {
-    if (!isNavigating.current && selectedReport) {
-      pushState({ reportId: selectedReport.id, userId: user.id })
+    if (!isNavigating.current && selectedCase) {
+      pushState({ caseId: selectedCase.id, userId: user.id })
     }
     setViewingUser(user)
     setProfileKey((prev) => prev + 1)
-  }, [isNavigating, pushState, selectedReport, setViewingUser, setProfileKey])
+  }, [isNavigating, pushState, selectedCase, setViewingUser, setProfileKey])
 
   const handleCloseUserProfile = useCallback(() => {
-    if (!isNavigating.current && selectedReport) {
-      pushState({ reportId: selectedReport.id })
+    if (!isNavigating.current && selectedCase) {
+      pushState({ caseId: selectedCase.id })
     }
     setViewingUser(null)
-  }, [isNavigating, pushState, selectedReport, setViewingUser])
+  }, [isNavigating, pushState, selectedCase, setViewingUser])
+
+  const updateCaseStatus = useCallback((caseId: number, status: ReportStatus) => {
+    setCases((prev) => prev.map((c) => (c.id === caseId ? { ...c, status } : c)))
+    setSelectedCase((prev) => (prev?.id === caseId ? { ...prev, status } : prev))
+  }, [setCases, setSelectedCase])
 
+  // claiming a case claims its lead report, which moves the whole case in review
   const handleAssign = useCallback(async () => {
-    if (!selectedReport) return
+    if (!selectedCase || !selectedReport) return
     const result = await actions.assignReport(selectedReport.id)
     if (result.ok) {
-      const updatedReport = {
-        ...selectedReport,
-        status: "in_review" as ReportStatus,
-        assignedTo: Fider.session.user,
-      }
-      setSelectedReport(updatedReport)
-      setReports((prev) => prev.map((r) => (r.id === selectedReport.id ? updatedReport : r)))
+      setSelectedReport({ ...selectedReport, status: "in_review", assignedTo: Fider.session.user })
+      updateCaseStatus(selectedCase.id, "in_review")
     }
-  }, [selectedReport, setSelectedReport, setReports])
+  }, [selectedCase, selectedReport, setSelectedReport, updateCaseStatus])
 
   const handleUnassign = useCallback(async () => {
-    if (!selectedReport) return
+    if (!selectedCase || !selectedReport) return
     const result = await actions.unassignReport(selectedReport.id)
     if (result.ok) {
-      const updatedReport = {
-        ...selectedReport,
-        status: "pending" as ReportStatus,
-        assignedTo: undefined,
-      }
-      setSelectedReport(updatedReport)
-      setReports((prev) => prev.map((r) => (r.id === selectedReport.id ? updatedReport : r)))
+      setSelectedReport({ ...selectedReport, status: "pending", assignedTo: undefined })
+      updateCaseStatus(selectedCase.id, "pending")
     }
-  }, [selectedReport, setSelectedReport, setReports])
+  }, [selectedCase, selectedReport, setSelectedReport, updateCaseStatus])
+
+  const removeResolvedCase = useCallback((caseId: number, status: "resolved" | "dismissed") => {
+    if (selectedStatusRef.current === "active") {
+      setCases((prev) => prev.filter((c) => c.id !== caseId))
+    } else {
+      setCases((prev) => prev.map((c) => (c.id === caseId ? { ...c, status } : c)))
+    }
+    setSelectedCase(null)
+    setSelectedReport(null)
+  }, [selectedStatusRef, setCases, setSelectedCase, setSelectedReport])
 
   const handleResolveClick = useCallback(async (status: "resolved" | "dismissed", shiftKey: boolean) => {
-    if (!selectedReport) return
+    if (!selectedCase) return
 
     if (shiftKey) {
-      const result = await actions.resolveReport(selectedReport.id, status)
+      const result = await actions.resolveReportCase(selectedCase.id, status)
       if (result.ok) {
-        if (selectedStatusRef.current === "active") {
-          setReports((prev) => prev.filter((r) => r.id !== selectedReport.id))
-        } else {
-          setReports((prev) =>
-            prev.map((r) => (r.id === selectedReport.id ? { ...r, status: status as ReportStatus } : r))
-          )
-        }
-        setSelectedReport(null)
+        removeResolvedCase(selectedCase.id, status)
       }
       return
     }
@@ -195,29 +195,20 @@ export const useReportsActions = (config: UseReportsActionsConfig): UseReportsAc
     setResolveAction(status)
     setResolutionNote("")
     setShowResolveModal(true)
-  }, [selectedReport, selectedStatusRef, setReports, setSelectedReport, setResolveAction, setResolutionNote, setShowResolveModal])
+  }, [selectedCase, removeResolvedCase, setResolveAction, setResolutionNote, setShowResolveModal])
 
   const handleResolveSubmit = useCallback(async () => {
-    if (!selectedReport) return
+    if (!selectedCase) return
 
     setError(undefined)
-    const result = await actions.resolveReport(selectedReport.id, resolveAction, resolutionNote)
+    const result = await actions.resolveReportCase(selectedCase.id, resolveAction, resolutionNote)
     if (result.ok) {
       setShowResolveModal(false)
-      if (selectedStatusRef.current === "active") {
-        setReports((prev) => prev.filter((r) => r.id !== selectedReport.id))
-      } else {
-        setReports((prev) =>
-          prev.map((r) =>
-            r.id === selectedReport.id ? { ...r, status: resolveAction as ReportStatus } : r
-          )
-        )
-      }
-      setSelectedReport(null)
+      removeResolvedCase(selectedCase.id, resolveAction)
     } else {
       setError(result.error)
     }
-  }, [selectedReport, resolveAction, resolutionNote, selectedStatusRef, setReports, setSelectedReport, setShowResolveModal, setError])
+  }, [selectedCase, resolveAction, resolutionNote, removeResolvedCase, setShowResolveModal, setError])
 
   const handleCloseResolveModal = useCallback(() => {
     setShowResolveModal(false)
@@ -225,8 +216,8 @@ export const useReportsActions = (config: UseReportsActionsConfig): UseReportsAc
 
   const handleRefreshNewReports = useCallback(() => {
     setNewReportIds(new Set())
-    loadReports()
-  }, [setNewReportIds, loadReports])
+    loadCases()
+  }, [setNewReportIds, loadCases])
 
   const handlePrevPage = useCallback(() => {
     setPage((p) => p - 1)
@@ -243,8 +234,8 @@ export const useReportsActions = (config: UseReportsActionsConfig): UseReportsAc
     handleStatusChange,
     handleTypeChange,
     handleReasonChange,
-    handleSelectReport,
-    handleDeselectReport,
+    handleSelectCase,
+    handleDeselectCase,
     handleViewUser,
     handleCloseUserProfile,
     handleAssign,
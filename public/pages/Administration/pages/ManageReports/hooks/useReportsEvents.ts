@@ -1,20 +1,30 @@
 import { useEffect } from "react"
-import { Report, ReportStatus, ReportNewEvent, ReportAssignedEvent, ReportUnassignedEvent, ReportResolvedEvent } from "@fider/models"
+import {
+  Report,
+  ReportCase,
+  ReportStatus,
+  ReportNewEvent,
+  ReportAssignedEvent,
+  ReportUnassignedEvent,
+  ReportResolvedEvent,
+} from "@fider/models"
 import { reportsEventSource } from "@fider/services"
 
 interface UseReportsEventsConfig {
-  selectedReportRef: React.MutableRefObject<Report | null>
+  selectedCaseRef: React.MutableRefObject<ReportCase | null>
   selectedStatusRef: React.MutableRefObject<ReportStatus | "active">
-  setReports: React.Dispatch<React.SetStateAction<Report[]>>
+  setCases: React.Dispatch<React.SetStateAction<ReportCase[]>>
+  setSelectedCase: React.Dispatch<React.SetStateAction<ReportCase | null>>
   setSelectedReport: React.Dispatch<React.SetStateAction<Report | null>>
   setNewReportIds: React.Dispatch<React.SetStateAction<Set<number>>>
 }
 
 export const useReportsEvents = (config: UseReportsEventsConfig): void => {
   const {
-    selectedReportRef,
+    selectedCaseRef,
     selectedStatusRef,
-    setReports,
+    setCases,
+    setSelectedCase,
     setSelectedReport,
     setNewReportIds,
   } = config
@@ -29,6 +39,7 @@ export const useReportsEvents = (config: UseReportsEventsConfig): void => {
       }
     })
 
+    // cases are claimed through their lead report
     const unsubAssigned = reportsEventSource.on("report.assigned", (_, payload) => {
       const data = payload as ReportAssignedEvent
       const assignedUser = {
@@ -40,14 +51,11 @@ export const useReportsEvents = (config: UseReportsEventsConfig): void => {
         status: data.assignedTo.status,
       } as Report["assignedTo"]
 
-      setReports((prev) =>
-        prev.map((r) =>
-          r.id === data.reportId
-            ? { ...r, status: "in_review" as ReportStatus, assignedTo: assignedUser }
-            : r
-        )
+      setCases((prev) =>
+        prev.map((c) => (c.leadReportId === data.reportId ? { ...c, status: "in_review" as ReportStatus } : c))
       )
-      if (selectedReportRef.current?.id === data.reportId) {
+      if (selectedCaseRef.current?.leadReportId === data.reportId) {
+        setSelectedCase((prev) => (prev ? { ...prev, status: "in_review" as ReportStatus } : prev))
         setSelectedReport((prev) =>
           prev ? { ...prev, status: "in_review" as ReportStatus, assignedTo: assignedUser } : prev
         )
@@ -56,14 +64,11 @@ export const useReportsEvents = (config: UseReportsEventsConfig): void => {
 
     const unsubUnassigned = reportsEventSource.on("report.unassigned", (_, payload) => {
       const data = payload as ReportUnassignedEvent
-      setReports((prev) =>
-        prev.map((r) =>
-          r.id === data.reportId
-            ? { ...r, status: "pending" as ReportStatus, assignedTo: undefined }
-            : r
-        )
+      setCases((prev) =>
+        prev.map((c) => (c.leadReportId === data.reportId ? { ...c, status: "pending" as ReportStatus } : c))
       )
-      if (selectedReportRef.current?.id === data.reportId) {
+      if (selectedCaseRef.current?.leadReportId === data.reportId) {
+        setSelectedCase((prev) => (prev ? { ...prev, status: "pending" as ReportStatus } : prev))
         setSelectedReport((prev) =>
           prev ? { ...prev, status: "pending" as ReportStatus, assignedTo: undefined } : prev
         )
@@ -72,15 +77,15 @@ export const useReportsEvents = (config: UseReportsEventsConfig): void => {
 
     const unsubResolved = reportsEventSource.on("report.resolved", (_, payload) => {
       const data = payload as ReportResolvedEvent
+      const isResolvedCase = (c: ReportCase) => (data.caseId ? c.id === data.caseId : c.leadReportId === data.reportId)
       if (selectedStatusRef.current === "active") {
-        setReports((prev) => prev.filter((r) => r.id !== data.reportId))
-        if (selectedReportRef.current?.id === data.reportId) {
+        setCases((prev) => prev.filter((c) => !isResolvedCase(c)))
+        if (selectedCaseRef.current && isResolvedCase(selectedCaseRef.current)) {
+          setSelectedCase(null)
           setSelectedReport(null)
         }
       } else {
-        setReports((prev) =>
-          prev.map((r) => (r.id === data.reportId ? { ...r, status: data.status as ReportStatus } : r))
-        )
+        setCases((prev) => prev.map((c) => (isResolvedCase(c) ? { ...c, status: data.status as ReportStatus } : c)))
       }
     })
 
@@ -93,4 +98,3 @@ export const useReportsEvents = (config: UseReportsEventsConfig): void => {
     }
   }, [])
 }
-
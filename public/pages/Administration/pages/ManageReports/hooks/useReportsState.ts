@@ -1,5 +1,5 @@
 import { useState, useCallback, useRef, useEffect } from "react"
-import { Report, ReportStatus, ReportType, ReportReason, Post, Comment, UserRole, UserStatus } from "@fider/models"
+import { Report, ReportCase, ReportStatus, ReportType, ReportReason, Post, Comment, UserRole, UserStatus } from "@fider/models"
 import { actions, Failure, PAGINATION } from "@fider/services"
 
 export interface ViewingUserType {
@@ -11,8 +11,8 @@ export interface ViewingUserType {
 }
 
 interface UseReportsStateResult {
-  reports: Report[]
-  setReports: React.Dispatch<React.SetStateAction<Report[]>>
+  cases: ReportCase[]
+  setCases: React.Dispatch<React.SetStateAction<ReportCase[]>>
   total: number
   setTotal: React.Dispatch<React.SetStateAction<number>>
   page: number
@@ -26,9 +26,11 @@ interface UseReportsStateResult {
   selectedReason: string
   setSelectedReason: React.Dispatch<React.SetStateAction<string>>
   reasons: ReportReason[]
+  selectedCase: ReportCase | null
+  setSelectedCase: React.Dispatch<React.SetStateAction<ReportCase | null>>
+  selectedCaseRef: React.MutableRefObject<ReportCase | null>
   selectedReport: Report | null
   setSelectedReport: React.Dispatch<React.SetStateAction<Report | null>>
-  selectedReportRef: React.MutableRefObject<Report | null>
   selectedStatusRef: React.MutableRefObject<ReportStatus | "active">
   previewPost: Post | null
   previewComment: Comment | null
@@ -47,14 +49,14 @@ interface UseReportsStateResult {
   setViewingUser: React.Dispatch<React.SetStateAction<ViewingUserType | null>>
   profileKey: number
   setProfileKey: React.Dispatch<React.SetStateAction<number>>
-  loadReports: () => Promise<void>
-  loadPreviewContent: (reportId: number) => Promise<void>
+  loadCases: () => Promise<void>
+  loadPreviewContent: (reportCase: ReportCase) => Promise<void>
 }
 
 export const useReportsState = (): UseReportsStateResult => {
   const perPage = PAGINATION.REPORTS_LIMIT
 
-  const [reports, setReports] = useState<Report[]>([])
+  const [cases, setCases] = useState<ReportCase[]>([])
   const [total, setTotal] = useState(0)
   const [page, setPage] = useState(1)
   const [isLoading, setIsLoading] = useState(true)
@@ -62,6 +64,7 @@ export const useReportsState = (): UseReportsStateResult => {
   const [selectedType, setSelectedType] = useState<ReportType | "">("")
   const [selectedReason, setSelectedReason] = useState<string>("")
   const [reasons, setReasons] = useState<ReportReason[]>([])
+  const [selectedCase, setSelectedCase] = useState<ReportCase | null>(null)
   const [selectedReport, setSelectedReport] = useState<Report | null>(null)
   const [previewPost, setPreviewPost] = useState<Post | null>(null)
   const [previewComment, setPreviewComment] = useState<Comment | null>(null)
@@ -74,12 +77,12 @@ export const useReportsState = (): UseReportsStateResult => {
   const [viewingUser, setViewingUser] = useState<ViewingUserType | null>(null)
   const [profileKey, setProfileKey] = useState(0)
 
-  const selectedReportRef = useRef<Report | null>(null)
+  const selectedCaseRef = useRef<ReportCase | null>(null)
   const selectedStatusRef = useRef<ReportStatus | "active">("active")
 
   useEffect(() => {
-    selectedReportRef.current = selectedReport
-  }, [selectedReport])
+    selectedCaseRef.current = selectedCase
+  }, [selectedCase])
 
   useEffect(() => {
     selectedStatusRef.current = selectedStatus
@@ -95,7 +98,7 @@ export const useReportsState = (): UseReportsStateResult => {
     loadReasons()
   }, [])
 
-  const loadReports = useCallback(async () => {
+  const loadCases = useCallback(async () => {
     setIsLoading(true)
     const params: {
       page: number
@@ -115,46 +118,51 @@ export const useReportsState = (): UseReportsStateResult => {
     if (selectedType) params.type = selectedType as ReportType
     if (selectedReason) params.reason = selectedReason
 
-    const result = await actions.listReports(params)
+    const result = await actions.listReportCases(params)
     if (result.ok) {
-      setReports(result.data.reports || [])
+      setCases(result.data.cases || [])
       setTotal(result.data.total)
     }
     setIsLoading(false)
   }, [page, selectedStatus, selectedType, selectedReason, perPage])
 
-  const loadPreviewContent = useCallback(async (reportId: number) => {
+  // the case brings its member reports, the details of its lead report bring the reported content
+  const loadPreviewContent = useCallback(async (reportCase: ReportCase) => {
+    const caseId = reportCase.id
     const loadingTimeout = setTimeout(() => {
-      if (selectedReportRef.current?.id === reportId) {
+      if (selectedCaseRef.current?.id === caseId) {
         setIsLoadingPreview(true)
       }
     }, 150)
 
     try {
-      const result = await actions.getReportDetails(reportId)
-      if (selectedReportRef.current?.id !== reportId) return
+      const [caseResult, detailsResult] = await Promise.all([
+        actions.getReportCase(caseId),
+        actions.getReportDetails(reportCase.leadReportId),
+      ])
+      if (selectedCaseRef.current?.id !== caseId) return
 
-      if (result.ok) {
-        const freshReport = result.data.report
-        const current = selectedReportRef.current
-        if (current.status !== freshReport.status || current.assignedTo?.id !== freshReport.assignedTo?.id) {
-          setSelectedReport(freshReport)
-          setReports((prev) => prev.map((r) => (r.id === reportId ? freshReport : r)))
-        }
-        setPreviewPost(result.data.post || null)
-        setPreviewComment(result.data.comment || null)
+      if (caseResult.ok) {
+        const freshCase = caseResult.data
+        setSelectedCase(freshCase)
+        setCases((prev) => prev.map((c) => (c.id === caseId ? { ...freshCase, reports: undefined } : c)))
+      }
+      if (detailsResult.ok) {
+        setSelectedReport(detailsResult.data.report)
+        setPreviewPost(detailsResult.data.post || null)
+        setPreviewComment(detailsResult.data.comment || null)
       }
     } finally {
       clearTimeout(loadingTimeout)
-      if (selectedReportRef.current?.id === reportId) {
+      if (selectedCaseRef.current?.id === caseId) {
         setIsLoadingPreview(false)
       }
     }
   }, [])
 
   return {
-    reports,
-    setReports,
+    cases,
+    setCases,
     total,
     setTotal,
     page,
@@ -168,9 +176,11 @@ export const useReportsState = (): UseReportsStateResult => {
     selectedReason,
     setSelectedReason,
     reasons,
+    selectedCase,
+    setSelectedCase,
+    selectedCaseRef,
     selectedReport,
     setSelectedReport,
-    selectedReportRef,
     selectedStatusRef,
     previewPost,
     previewComment,
@@ -189,7 +199,7 @@ export const useReportsState = (): UseReportsStateResult => {
     setViewingUser,
     profileKey,
     setProfileKey,
-    loadReports,
+    loadCases,
     loadPreviewContent,
   }
 }
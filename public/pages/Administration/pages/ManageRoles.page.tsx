@@ -0,0 +1,238 @@
+import React, { useState } from "react"
+import { Avatar, Button, Checkbox, Form, Input, UserName } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { PageConfig } from "@fider/components/layouts"
+import { CustomRole, Permission, User, UserRole } from "@fider/models"
+import { actions, Failure } from "@fider/services"
+
+export const pageConfig: PageConfig = {
+  title: "Roles",
+  subtitle: "Compose roles from individual permissions",
+  sidebarItem: "roles",
+}
+
+interface RolePreset {
+  role: UserRole
+  permissions: Permission[]
+}
+
+interface ManageRolesPageProps {
+  customRoles: CustomRole[]
+  presets: RolePreset[]
+  permissions: Permission[]
+}
+
+const permissionLabels: { [key in Permission]: string } = {
+  "queue.view": "Work through the post queue",
+  "tag.assign": "Assign tags to posts",
+  "tag.private": "Assign private tags and tag posts older than a week",
+  "tag.manage": "Create, edit and delete tags",
+  "post.respond": "Mark posts as duplicates",
+  "post.status": "Change posts to any status, together with marking duplicates",
+  "post.edit": "Edit posts of other users",
+  "post.delete": "Delete posts of other users",
+  "post.lock": "Lock and unlock posts",
+  "post.archive": "Archive and unarchive posts",
+  "comment.edit": "Edit comments of other users",
+  "comment.delete": "Delete comments of other users",
+  "content.moderate": "Approve and hide content waiting for moderation",
+  "report.view": "View and claim reports",
+  "report.resolve": "Resolve reports",
+  "response.manage": "Manage canned responses and report reasons",
+  "appeal.decide": "Decide on appeals",
+  "user.moderate": "Warn, mute and restrict users",
+  "user.block": "Block and unblock users",
+}
+
+const ManageRolesPage: React.FC<ManageRolesPageProps> = (props) => {
+  const [roles, setRoles] = useState(props.customRoles)
+  const [editing, setEditing] = useState<CustomRole>()
+  const [name, setName] = useState("")
+  const [description, setDescription] = useState("")
+  const [selectedPermissions, setSelectedPermissions] = useState<Permission[]>([])
+  const [expanded, setExpanded] = useState<number>()
+  const [members, setMembers] = useState<User[]>([])
+  const [error, setError] = useState<Failure>()
+
+  const togglePermission = (permission: Permission, checked: boolean) => {
+    const others = selectedPermissions.filter((p) => p !== permission)
+    setSelectedPermissions(checked ? props.permissions.filter((p) => p === permission || others.includes(p)) : others)
+  }
+
+  const resetForm = () => {
+    setEditing(undefined)
+    setName("")
+    setDescription("")
+    setSelectedPermissions([])
+    setError(undefined)
+  }
+
+  const applyPreset = (preset: RolePreset) => {
+    resetForm()
+    setSelectedPermissions(preset.permissions)
+  }
+
+  const edit = (role: CustomRole) => {
+    setEditing(role)
+    setName(role.name)
+    setDescription(role.description)
+    setSelectedPermissions(role.permissions)
+    setError(undefined)
+  }
+
+  const save = async () => {
+    const input = { name, description, permissions: selectedPermissions }
+    const result = editing ? await actions.updateCustomRole(editing.id, input) : await actions.createCustomRole(input)
+    if (result.ok) {
+      const others = roles.filter((r) => r.id !== result.data.id)
+      const saved = { ...result.data, memberCount: editing ? editing.memberCount : 0 }
+      setRoles([...others, saved].sort((a, b) => a.name.localeCompare(b.name)))
+      resetForm()
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const remove = async (role: CustomRole) => {
+    if (!window.confirm(`Delete ${role.name}? Its members will keep only the permissions of their built-in role.`)) {
+      return
+    }
+
+    const result = await actions.deleteCustomRole(role.id)
+    if (result.ok) {
+      setRoles(roles.filter((r) => r.id !== role.id))
+      if (editing && editing.id === role.id) {
+        resetForm()
+      }
+    }
+  }
+
+  const toggleMembers = async (role: CustomRole) => {
+    if (expanded === role.id) {
+      setExpanded(undefined)
+      return
+    }
+
+    const result = await actions.listCustomRoleMembers(role.id)
+    if (result.ok) {
+      setMembers(result.data)
+      setExpanded(role.id)
+    }
+  }
+
+  const removeMember = async (role: CustomRole, user: User) => {
+    const result = await actions.setUserCustomRole(user.id, 0)
+    if (result.ok) {
+      setMembers(members.filter((m) => m.id !== user.id))
+      setRoles(roles.map((r) => (r.id === role.id ? { ...r, memberCount: r.memberCount - 1 } : r)))
+    }
+  }
+
+  return (
+    <VStack spacing={4}>
+      <div className="bg-elevated rounded-card border border-surface-alt overflow-hidden">
+        {props.presets.map((preset) => (
+          <div key={preset.role} className="p-4 border-b border-surface-alt last:border-b-0">
+            <HStack justify="between">
+              <div className="min-w-0">
+                <p className="m-0 font-medium capitalize">
+                  {preset.role} <span className="text-xs text-muted normal-case">built-in</span>
+                </p>
+                <p className="m-0 text-sm text-muted">{preset.permissions.join(", ")}</p>
+              </div>
+              <Button size="small" variant="tertiary" onClick={() => applyPreset(preset)}>
+                Use as template
+              </Button>
+            </HStack>
+          </div>
+        ))}
+      </div>
+
+      {roles.length === 0 ? (
+        <div className="p-8 text-center bg-tertiary rounded-card border border-surface-alt">
+          <p className="text-muted m-0">There aren&apos;t any custom roles yet.</p>
+        </div>
+      ) : (
+        <div className="bg-elevated rounded-card border border-surface-alt overflow-hidden">
+          {roles.map((role) => (
+            <div key={role.id} className="p-4 border-b border-surface-alt last:border-b-0">
+              <HStack justify="between">
+                <div className="min-w-0">
+                  <p className="m-0 font-medium truncate">{role.name}</p>
+                  {role.description && <p className="m-0 text-sm">{role.description}</p>}
+                  <p className="m-0 text-sm text-muted">{role.permissions.join(", ")}</p>
+                </div>
+                <HStack>
+                  <Button size="small" variant="tertiary" onClick={() => toggleMembers(role)}>
+                    {role.memberCount === 1 ? "1 member" : `${role.memberCount} members`}
+                  </Button>
+                  <Button size="small" variant="secondary" onClick={() => edit(role)}>
+                    Edit
+                  </Button>
+                  <Button size="small" variant="secondary" onClick={() => remove(role)}>
+                    Delete
+                  </Button>
+                </HStack>
+              </HStack>
+              {expanded === role.id && (
+                <div className="mt-3">
+                  {members.length === 0 ? (
+                    <p className="m-0 text-sm text-muted">Nobody has this role. Assign it from a user&apos;s profile.</p>
+                  ) : (
+                    members.map((user) => (
+                      <HStack key={user.id} justify="between" className="py-1">
+                        <HStack>
+                          <Avatar user={user} size="small" />
+                          <UserName user={user} />
+                        </HStack>
+                        <Button size="small" variant="tertiary" onClick={() => removeMember(role, user)}>
+                          Remove
+                        </Button>
+                      </HStack>
+                    ))
+                  )}
+                </div>
+              )}
+            </div>
+          ))}
+        </div>
+      )}
+
+      <div className="bg-elevated rounded-card border border-surface-alt p-4">
+        <h2 className="text-lg font-semibold text-foreground mb-4">{editing ? `Edit ${editing.name}` : "Create a role"}</h2>
+        <Form error={error}>
+          <Input field="name" label="Name" maxLength={50} value={name} placeholder="Shown to administrators only" onChange={setName} />
+          <Input field="description" label="Description" maxLength={200} value={description} onChange={setDescription} />
+          <div className="mb-4">
+            {props.permissions.map((permission) => (
+              <Checkbox
+                key={permission}
+                field={`permission_${permission}`}
+                checked={selectedPermissions.includes(permission)}
+                onChange={(checked) => togglePermission(permission, checked)}
+              >
+                {permissionLabels[permission]} <code className="text-xs text-muted">{permission}</code>
+              </Checkbox>
+            ))}
+          </div>
+          <HStack>
+            <Button variant="primary" size="small" onClick={save}>
+              {editing ? "Save" : "Create"}
+            </Button>
+            {editing && (
+              <Button variant="tertiary" size="small" onClick={resetForm}>
+                Cancel
+              </Button>
+            )}
+          </HStack>
+        </Form>
+      </div>
+
+      <p className="text-muted text-sm m-0">
+        A custom role adds its permissions to the user&apos;s built-in role. Assign custom roles from the user&apos;s profile.
+      </p>
+    </VStack>
+  )
+}
+
+export default ManageRolesPage
@@ -0,0 +1,152 @@
+import React, { useState } from "react"
+import { Button, Checkbox, Form, Input, Moment, TextArea } from "@fider/components"
+import { HStack, VStack } from "@fider/components/layout"
+import { PageConfig } from "@fider/components/layouts"
+import { APITokenScope, OAuthClient } from "@fider/models"
+import { actions, Failure } from "@fider/services"
+import { useFider } from "@fider/hooks"
+
+export const pageConfig: PageConfig = {
+  title: "OAuth Apps",
+  subtitle: "Let third-party apps use the API on behalf of your users",
+  sidebarItem: "oauthclients",
+}
+
+interface OAuthClientsPageProps {
+  clients: OAuthClient[]
+}
+
+const scopes: { value: APITokenScope; label: string }[] = [
+  { value: "read:posts", label: "Read posts and comments" },
+  { value: "write:posts", label: "Create, edit and vote on posts" },
+  { value: "write:comments", label: "Comment and react" },
+  { value: "moderate", label: "Moderate" },
+  { value: "admin", label: "Administer (full access)" },
+]
+
+const OAuthClientsPage: React.FC<OAuthClientsPageProps> = (props) => {
+  const fider = useFider()
+  const [clients, setClients] = useState(props.clients)
+  const [name, setName] = useState("")
+  const [redirectUris, setRedirectUris] = useState("")
+  const [selectedScopes, setSelectedScopes] = useState<APITokenScope[]>(["read:posts"])
+  const [isConfidential, setIsConfidential] = useState(false)
+  const [created, setCreated] = useState<OAuthClient & { clientSecret?: string }>()
+  const [error, setError] = useState<Failure>()
+
+  const toggleScope = (scope: APITokenScope, checked: boolean) => {
+    setSelectedScopes(checked ? [...selectedScopes.filter((s) => s !== scope), scope] : selectedScopes.filter((s) => s !== scope))
+  }
+
+  const create = async () => {
+    const result = await actions.createOAuthClient({
+      name,
+      redirectUris: redirectUris.split("\n"),
+      scopes: selectedScopes,
+      isConfidential,
+    })
+    if (result.ok) {
+      const { clientSecret, ...client } = result.data
+      setClients([client, ...clients])
+      setCreated({ ...client, clientSecret })
+      setName("")
+      setRedirectUris("")
+      setError(undefined)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const remove = async (client: OAuthClient) => {
+    if (!window.confirm(`Delete ${client.name}? Users who authorized it will be signed out of it immediately.`)) {
+      return
+    }
+
+    const result = await actions.deleteOAuthClient(client.id)
+    if (result.ok) {
+      setClients(clients.filter((c) => c.id !== client.id))
+    }
+  }
+
+  return (
+    <VStack spacing={4}>
+      {clients.length === 0 ? (
+        <div className="p-8 text-center bg-tertiary rounded-card border border-surface-alt">
+          <p className="text-muted m-0">There aren&apos;t any apps yet.</p>
+        </div>
+      ) : (
+        <div className="bg-elevated rounded-card border border-surface-alt overflow-hidden">
+          {clients.map((client) => (
+            <div key={client.id} className="p-4 border-b border-surface-alt last:border-b-0">
+              <HStack justify="between">
+                <div className="min-w-0">
+                  <p className="m-0 font-medium truncate">
+                    {client.name} <span className="text-xs text-muted">{client.isConfidential ? "confidential" : "public"}</span>
+                  </p>
+                  <p className="m-0 text-sm text-muted">
+                    <code>{client.clientId}</code> · {client.scopes.join(", ")} · created <Moment locale={fider.currentLocale} date={client.createdAt} />
+                  </p>
+                  <p className="m-0 text-xs text-muted break-all">{client.redirectUris.join(", ")}</p>
+                </div>
+                <Button size="small" variant="secondary" onClick={() => remove(client)}>
+                  Delete
+                </Button>
+              </HStack>
+            </div>
+          ))}
+        </div>
+      )}
+
+      {created && (
+        <div className="p-4 bg-success-light border border-success-light rounded-card">
+          <p className="text-success font-medium mb-2">{created.name} has been registered.</p>
+          <p className="text-sm m-0">Client ID:</p>
+          <code className="block p-3 mb-2 bg-elevated border border-surface-alt rounded text-sm font-mono break-all">{created.clientId}</code>
+          {created.clientSecret && (
+            <>
+              <p className="text-sm m-0">Client Secret:</p>
+              <code className="block p-3 bg-elevated border border-surface-alt rounded text-sm font-mono break-all">{created.clientSecret}</code>
+              <p className="text-muted text-sm mt-3 mb-0">The secret won&apos;t be shown again. Only give it to apps that run on a server.</p>
+            </>
+          )}
+        </div>
+      )}
+
+      <div className="bg-elevated rounded-card border border-surface-alt p-4">
+        <h2 className="text-lg font-semibold text-foreground mb-4">Register an app</h2>
+        <Form error={error}>
+          <Input field="name" label="Name" maxLength={100} value={name} placeholder="Shown to users when they authorize the app" onChange={setName} />
+          <TextArea
+            field="redirectUris"
+            label="Redirect URIs"
+            value={redirectUris}
+            minRows={2}
+            placeholder="https://app.example.com/callback"
+            onChange={setRedirectUris}
+          >
+            <p className="text-muted text-sm">One per line. Use https, http on localhost, or a custom scheme for native apps.</p>
+          </TextArea>
+          <div className="mb-4">
+            {scopes.map((scope) => (
+              <Checkbox key={scope.value} field={`scope_${scope.value}`} checked={selectedScopes.includes(scope.value)} onChange={(checked) => toggleScope(scope.value, checked)}>
+                {scope.label}
+              </Checkbox>
+            ))}
+          </div>
+          <Checkbox field="isConfidential" checked={isConfidential} onChange={setIsConfidential}>
+            Confidential client (has a secret, for apps that run on a server)
+          </Checkbox>
+          <Button variant="primary" size="small" onClick={create}>
+            Register
+          </Button>
+        </Form>
+      </div>
+
+      <p className="text-muted text-sm m-0">
+        Apps send users to <code>/oauth2/authorize</code> with PKCE (S256) and exchange the code at <code>/oauth2/token</code>. Access tokens expire after an hour, refresh tokens after 30 days without use.
+      </p>
+    </VStack>
+  )
+}
+
+export default OAuthClientsPage
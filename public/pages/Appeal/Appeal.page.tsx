@@ -0,0 +1,174 @@
+import React, { useState } from "react"
+import { Appeal, AppealSanctionType } from "@fider/models"
+import { Button, Form, TextArea, Moment } from "@fider/components"
+import { actions, Failure, notify } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { Trans } from "@lingui/react/macro"
+import { i18n } from "@lingui/core"
+
+interface Sanction {
+  id: number
+  reason: string
+  createdAt: string
+  expiresAt?: string
+}
+
+interface AppealPageProps {
+  appeals: Appeal[]
+  warnings: Sanction[]
+  mutes: Sanction[]
+  blocked: boolean
+}
+
+interface AppealTarget {
+  type: AppealSanctionType
+  id?: number
+}
+
+const isActive = (s: Sanction) => !s.expiresAt || new Date(s.expiresAt) > new Date()
+
+const AppealStatusBadge: React.FC<{ appeal: Appeal }> = ({ appeal }) => {
+  const className = {
+    pending: "bg-info-light text-info",
+    upheld: "bg-danger-light text-danger",
+    reduced: "bg-warning-light text-warning",
+    lifted: "bg-success-light text-success",
+  }[appeal.status]
+
+  return <span className={`px-2 py-1 rounded text-xs font-medium ${className}`}>{appeal.status}</span>
+}
+
+const AppealForm: React.FC<{ target: AppealTarget; onSubmitted: () => void }> = ({ target, onSubmitted }) => {
+  const [text, setText] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const submit = async () => {
+    const result = await actions.createAppeal(target.type, target.id, text)
+    if (result.ok) {
+      notify.success(i18n._("appeal.submitted", { message: "Your appeal has been submitted" }))
+      onSubmitted()
+    } else {
+      setError(result.error)
+    }
+  }
+
+  return (
+    <Form error={error} className="mt-3">
+      <TextArea
+        field="text"
+        value={text}
+        minRows={4}
+        maxLength={2000}
+        onChange={setText}
+        placeholder={i18n._("appeal.text.placeholder", { message: "Explain why this sanction should be reviewed" })}
+      />
+      <Button variant="primary" size="small" onClick={submit}>
+        <Trans id="appeal.submit">Submit appeal</Trans>
+      </Button>
+    </Form>
+  )
+}
+
+const AppealPage: React.FC<AppealPageProps> = (props) => {
+  const fider = useFider()
+  const [target, setTarget] = useState<AppealTarget | undefined>()
+
+  const appealed = (type: AppealSanctionType, id?: number) =>
+    props.appeals.some((a) => a.sanctionType === type && (type === "block" ? a.status !== "lifted" : a.sanctionId === id))
+
+  const onSubmitted = () => location.reload()
+
+  const sanctions: Array<{ type: AppealSanctionType; item: Sanction }> = [
+    ...props.warnings.filter(isActive).map((item) => ({ type: "warning" as AppealSanctionType, item })),
+    ...props.mutes.filter(isActive).map((item) => ({ type: "mute" as AppealSanctionType, item })),
+  ]
+
+  return (
+    <div id="p-appeal" className="page container w-max-10xl py-[50px] pb-[120px] max-md:pb-[60px]">
+      <h1 className="text-2xl font-bold tracking-tight mb-6">
+        <Trans id="appeal.title">Appeals</Trans>
+      </h1>
+
+      {props.blocked && (
+        <div className="bg-elevated rounded-card shadow-sm p-4 mb-6">
+          <p className="m-0 text-danger font-medium">
+            <Trans id="appeal.blocked">Your account has been blocked.</Trans>
+          </p>
+          {appealed("block") ? (
+            <p className="m-0 mt-2 text-muted text-sm">
+              <Trans id="appeal.blocked.appealed">You have already appealed this block.</Trans>
+            </p>
+          ) : target?.type === "block" ? (
+            <AppealForm target={target} onSubmitted={onSubmitted} />
+          ) : (
+            <Button className="mt-3" variant="secondary" size="small" onClick={() => setTarget({ type: "block" })}>
+              <Trans id="appeal.action">Appeal</Trans>
+            </Button>
+          )}
+        </div>
+      )}
+
+      {sanctions.length > 0 && (
+        <div className="bg-elevated rounded-card shadow-sm overflow-hidden mb-6">
+          <h3 className="m-0 p-4 font-semibold">
+            <Trans id="appeal.active">Active sanctions</Trans>
+          </h3>
+          <div className="divide-y divide-surface-alt">
+            {sanctions.map(({ type, item }) => (
+              <div key={`${type}-${item.id}`} className="p-4">
+                <div className="flex items-start justify-between gap-4">
+                  <div className="flex-1 min-w-0">
+                    <span className="text-xs uppercase text-muted">{type}</span>
+                    <p className="m-0 text-foreground">{item.reason}</p>
+                  </div>
+                  {!appealed(type, item.id) && !(target?.type === type && target.id === item.id) && (
+                    <Button variant="secondary" size="small" onClick={() => setTarget({ type, id: item.id })}>
+                      <Trans id="appeal.action">Appeal</Trans>
+                    </Button>
+                  )}
+                </div>
+                {target?.type === type && target.id === item.id && <AppealForm target={target} onSubmitted={onSubmitted} />}
+              </div>
+            ))}
+          </div>
+        </div>
+      )}
+
+      <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+        <h3 className="m-0 p-4 font-semibold">
+          <Trans id="appeal.history">Your appeals</Trans>
+        </h3>
+        {props.appeals.length === 0 ? (
+          <p className="p-4 text-muted">
+            <Trans id="appeal.history.empty">You have not filed any appeals.</Trans>
+          </p>
+        ) : (
+          <div className="divide-y divide-surface-alt">
+            {props.appeals.map((appeal) => (
+              <div key={appeal.id} className="p-4">
+                <div className="flex items-start justify-between gap-4">
+                  <div className="flex-1 min-w-0">
+                    <span className="text-xs uppercase text-muted">{appeal.sanctionType}</span>
+                    {appeal.sanctionReason && <p className="m-0 text-foreground">{appeal.sanctionReason}</p>}
+                    <p className="m-0 mt-2 text-muted text-sm whitespace-pre-wrap">{appeal.text}</p>
+                  </div>
+                  <AppealStatusBadge appeal={appeal} />
+                </div>
+                {appeal.decisionNote && (
+                  <p className="m-0 mt-2 text-sm">
+                    <Trans id="appeal.decision.note">Staff note:</Trans> {appeal.decisionNote}
+                  </p>
+                )}
+                <span className="text-xs text-muted">
+                  <Moment locale={fider.currentLocale} date={appeal.decidedAt || appeal.createdAt} />
+                </span>
+              </div>
+            ))}
+          </div>
+        )}
+      </div>
+    </div>
+  )
+}
+
+export default AppealPage
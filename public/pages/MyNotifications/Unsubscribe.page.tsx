@@ -0,0 +1,48 @@
+import React, { useState } from "react"
+import { Trans } from "@lingui/react/macro"
+import { Button } from "@fider/components"
+import { actions } from "@fider/services"
+
+interface UnsubscribePageProps {
+  token: string
+  digest: boolean
+}
+
+const UnsubscribePage = (props: UnsubscribePageProps) => {
+  const [done, setDone] = useState(false)
+
+  const confirm = async () => {
+    const result = await actions.unsubscribe(props.token)
+    if (result.ok) {
+      setDone(true)
+    }
+  }
+
+  return (
+    <div id="p-unsubscribe" className="container page text-center">
+      <h1 className="text-display">
+        <Trans id="unsubscribe.title">Unsubscribe</Trans>
+      </h1>
+      {done ? (
+        <p>
+          <Trans id="unsubscribe.done">You will no longer receive these emails. You can change this at any time in your notification settings.</Trans>
+        </p>
+      ) : (
+        <>
+          <p>
+            {props.digest ? (
+              <Trans id="unsubscribe.text.digest">Do you want to stop receiving digest emails?</Trans>
+            ) : (
+              <Trans id="unsubscribe.text">Do you want to stop receiving emails about this kind of notification?</Trans>
+            )}
+          </p>
+          <Button variant="primary" onClick={confirm}>
+            <Trans id="unsubscribe.confirm">Unsubscribe</Trans>
+          </Button>
+        </>
+      )}
+    </div>
+  )
+}
+
+export default UnsubscribePage
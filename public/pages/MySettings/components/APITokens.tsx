@@ -0,0 +1,170 @@
+import React, { useEffect, useState } from "react"
+import { APIToken, APITokenScope } from "@fider/models"
+import { Button, Checkbox, Form, Icon, Input, Moment, Select } from "@fider/components"
+import { heroiconsKey as IconKey } from "@fider/icons.generated"
+import { actions, Failure } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+const scopeLabels = (): { [key in APITokenScope]: string } => ({
+  "read:posts": i18n._("mysettings.apitokens.scope.readposts", { message: "Read posts and comments" }),
+  "write:posts": i18n._("mysettings.apitokens.scope.writeposts", { message: "Create, edit and vote on posts" }),
+  "write:comments": i18n._("mysettings.apitokens.scope.writecomments", { message: "Comment and react" }),
+  moderate: i18n._("mysettings.apitokens.scope.moderate", { message: "Moderate" }),
+  admin: i18n._("mysettings.apitokens.scope.admin", { message: "Administer (full access)" }),
+})
+
+export const APITokens = () => {
+  const fider = useFider()
+  const user = fider.session.user
+  const [tokens, setTokens] = useState<APIToken[]>([])
+  const [name, setName] = useState("")
+  const [scopes, setScopes] = useState<APITokenScope[]>(["read:posts"])
+  const [expiresInDays, setExpiresInDays] = useState("30")
+  const [newToken, setNewToken] = useState<string>()
+  const [error, setError] = useState<Failure>()
+
+  const availableScopes: APITokenScope[] = ["read:posts", "write:posts", "write:comments"]
+  if (user.isCollaborator || user.isModerator || user.isHelper) {
+    availableScopes.push("moderate")
+  }
+  if (user.isCollaborator) {
+    availableScopes.push("admin")
+  }
+
+  const expirationOptions = [
+    { value: "7", label: i18n._("mysettings.apitokens.expires.days", { message: "{days} days", days: 7 }) },
+    { value: "30", label: i18n._("mysettings.apitokens.expires.days", { message: "{days} days", days: 30 }) },
+    { value: "90", label: i18n._("mysettings.apitokens.expires.days", { message: "{days} days", days: 90 }) },
+    { value: "365", label: i18n._("mysettings.apitokens.expires.days", { message: "{days} days", days: 365 }) },
+    { value: "0", label: i18n._("mysettings.apitokens.expires.never", { message: "Never" }) },
+  ]
+
+  useEffect(() => {
+    const load = async () => {
+      const result = await actions.listAPITokens()
+      if (result.ok) {
+        setTokens(result.data)
+      }
+    }
+    load()
+  }, [])
+
+  const toggleScope = (scope: APITokenScope, checked: boolean) => {
+    setScopes(checked ? [...scopes.filter((s) => s !== scope), scope] : scopes.filter((s) => s !== scope))
+  }
+
+  const create = async () => {
+    const result = await actions.createAPIToken(name, scopes, parseInt(expiresInDays, 10))
+    if (result.ok) {
+      const { token, ...created } = result.data
+      setTokens([created, ...tokens])
+      setNewToken(token)
+      setName("")
+      setError(undefined)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  const remove = async (token: APIToken) => {
+    const result = await actions.deleteAPIToken(token.id)
+    if (result.ok) {
+      setTokens(tokens.filter((t) => t.id !== token.id))
+    }
+  }
+
+  const labels = scopeLabels()
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconKey} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.apitokens.title">API Tokens</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.apitokens.description">
+            Personal API tokens let bots and integrations use the API on your behalf. Only give them the scopes they need.
+          </Trans>
+        </p>
+
+        {tokens.length > 0 && (
+          <div className="divide-y divide-surface-alt border border-surface-alt rounded-card overflow-hidden mb-4">
+            {tokens.map((token) => (
+              <div key={token.id} className="p-3 bg-elevated flex items-center justify-between gap-4">
+                <div className="min-w-0">
+                  <p className="m-0 text-sm font-medium truncate">
+                    {token.name} <code className="text-xs text-muted">{token.prefix}…</code>
+                  </p>
+                  <p className="m-0 text-xs text-muted">
+                    {token.scopes.join(", ")} ·{" "}
+                    {token.lastUsedAt ? (
+                      <>
+                        <Trans id="mysettings.apitokens.lastused">Last used</Trans> <Moment locale={fider.currentLocale} date={token.lastUsedAt} />
+                      </>
+                    ) : (
+                      <Trans id="mysettings.apitokens.neverused">Never used</Trans>
+                    )}
+                    {token.expiresAt && (
+                      <>
+                        {" "}
+                        · <Trans id="mysettings.apitokens.expires">Expires</Trans> <Moment locale={fider.currentLocale} date={token.expiresAt} />
+                      </>
+                    )}
+                  </p>
+                </div>
+                <Button size="small" variant="secondary" onClick={() => remove(token)}>
+                  <Trans id="action.delete">Delete</Trans>
+                </Button>
+              </div>
+            ))}
+          </div>
+        )}
+
+        <Form error={error}>
+          <Input
+            field="name"
+            label={i18n._("mysettings.apitokens.name", { message: "Name" })}
+            maxLength={100}
+            value={name}
+            placeholder={i18n._("mysettings.apitokens.name.placeholder", { message: "What is this token for?" })}
+            onChange={setName}
+          />
+          <div className="mb-4">
+            {availableScopes.map((scope) => (
+              <Checkbox key={scope} field={`scope_${scope}`} checked={scopes.includes(scope)} onChange={(checked) => toggleScope(scope, checked)}>
+                {labels[scope]}
+              </Checkbox>
+            ))}
+          </div>
+          <Select
+            field="expiresInDays"
+            label={i18n._("mysettings.apitokens.expiration", { message: "Expiration" })}
+            value={expiresInDays}
+            options={expirationOptions}
+            onChange={(o) => setExpiresInDays(o?.value || "0")}
+          />
+          <Button variant="primary" size="small" onClick={create}>
+            <Trans id="mysettings.apitokens.create">Create token</Trans>
+          </Button>
+        </Form>
+
+        {newToken && (
+          <div className="mt-4 p-4 bg-success-light border border-success-light rounded-card">
+            <p className="text-success font-medium mb-2">
+              <Trans id="mysettings.apitokens.newtoken">Your new API Token is:</Trans>
+            </p>
+            <code className="block p-3 bg-elevated border border-surface-alt rounded text-sm font-mono break-all">{newToken}</code>
+            <p className="text-muted text-sm mt-3">
+              <Trans id="mysettings.apitokens.newtokennotice">It won&apos;t be shown again. Store it securely and never use it in the client side of your app.</Trans>
+            </p>
+          </div>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,50 @@
+import React, { useState } from "react"
+import { Button, Form, Icon } from "@fider/components"
+import { heroiconsDownload as IconDownload } from "@fider/icons.generated"
+import { actions, Failure } from "@fider/services"
+import { Trans } from "@lingui/react/macro"
+
+export const DataExport = () => {
+  const [requested, setRequested] = useState(false)
+  const [error, setError] = useState<Failure>()
+
+  const request = async () => {
+    const result = await actions.requestUserDataExport()
+    if (result.ok) {
+      setRequested(true)
+      setError(undefined)
+    } else {
+      setError(result.error)
+    }
+  }
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconDownload} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.dataexport.title">Download Your Data</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.dataexport.description">
+            Get a copy of your profile, settings, posts, comments, votes, reactions, reports, notifications and uploaded files as a ZIP file. We will email
+            you a download link once it is ready. The link expires after 7 days.
+          </Trans>
+        </p>
+        {requested ? (
+          <p className="text-success text-sm m-0">
+            <Trans id="mysettings.dataexport.requested">Your export is being prepared. Check your email in a few minutes.</Trans>
+          </p>
+        ) : (
+          <Form error={error}>
+            <Button variant="secondary" size="small" onClick={request}>
+              <Trans id="mysettings.dataexport.request">Request data export</Trans>
+            </Button>
+          </Form>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,114 @@
+import React, { useEffect, useState } from "react"
+import { UserProviderLink } from "@fider/models"
+import { Button, Icon } from "@fider/components"
+import { heroiconsLink as IconLink } from "@fider/icons.generated"
+import { actions, notify, querystring } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+const showLinkOutcome = (outcome: string, provider: string) => {
+  switch (outcome) {
+    case "linked":
+      notify.success(i18n._("mysettings.linkedaccounts.outcome.linked", { message: "{provider} is now linked to your account.", provider }))
+      break
+    case "already":
+      notify.success(i18n._("mysettings.linkedaccounts.outcome.already", { message: "{provider} was already linked to your account.", provider }))
+      break
+    case "conflict":
+      notify.error(
+        i18n._("mysettings.linkedaccounts.outcome.conflict", {
+          message: "Another {provider} account is already linked to yours. Unlink it first.",
+          provider,
+        })
+      )
+      break
+    case "merge":
+      notify.success(
+        i18n._("mysettings.linkedaccounts.outcome.merge", {
+          message: "This {provider} account belongs to another member. The staff has been asked to review it.",
+          provider,
+        })
+      )
+      break
+  }
+}
+
+export const LinkedAccounts = () => {
+  const fider = useFider()
+  const [links, setLinks] = useState<UserProviderLink[]>([])
+
+  const load = async () => {
+    const result = await actions.listUserProviders()
+    if (result.ok) {
+      setLinks(result.data)
+    }
+  }
+
+  useEffect(() => {
+    load()
+
+    const outcome = querystring.get("link")
+    if (outcome) {
+      const provider = querystring.get("provider")
+      const option = fider.settings.oauth.find((o) => o.provider === provider)
+      showLinkOutcome(outcome, option ? option.displayName : provider)
+      window.history.replaceState({}, "", `${window.location.pathname}${window.location.hash}`)
+    }
+  }, [])
+
+  const unlink = async (link: UserProviderLink) => {
+    const result = await actions.unlinkUserProvider(link.provider)
+    if (result.ok) {
+      // whether the remaining ones can be unlinked depends on what is left
+      load()
+    } else if (result.error && result.error.errors && result.error.errors.length > 0) {
+      notify.error(result.error.errors[0].message)
+    }
+  }
+
+  const linkURL = (provider: string) => {
+    const redirect = `${fider.settings.baseURL}/oauth/${provider}/link`
+    return `/oauth/${provider}?redirect=${encodeURIComponent(redirect)}`
+  }
+
+  const available = fider.settings.oauth.filter((o) => !links.some((l) => l.provider === o.provider))
+
+  if (links.length === 0 && available.length === 0) {
+    return null
+  }
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconLink} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.linkedaccounts.title">Linked Accounts</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.linkedaccounts.description">Sign in with any of the accounts linked here. You can&apos;t unlink the last way you have to sign in.</Trans>
+        </p>
+        <div className="divide-y divide-surface-alt border border-surface-alt rounded-card overflow-hidden">
+          {links.map((link) => (
+            <div key={link.provider} className="p-3 bg-elevated flex items-center justify-between gap-4">
+              <p className="m-0 text-sm font-medium">{link.displayName}</p>
+              <Button size="small" variant="secondary" disabled={!link.canUnlink} onClick={() => unlink(link)}>
+                <Trans id="mysettings.linkedaccounts.unlink">Unlink</Trans>
+              </Button>
+            </div>
+          ))}
+          {available.map((option) => (
+            <div key={option.provider} className="p-3 bg-elevated flex items-center justify-between gap-4">
+              <p className="m-0 text-sm text-muted">{option.displayName}</p>
+              <Button size="small" variant="tertiary" href={linkURL(option.provider)}>
+                <Trans id="mysettings.linkedaccounts.link">Link</Trans>
+              </Button>
+            </div>
+          ))}
+        </div>
+      </div>
+    </div>
+  )
+}
@@ -1,7 +1,7 @@
 import React, { useState, useEffect, useCallback } from "react"
 
 import { UserSettings } from "@fider/models"
-import { Toggle, Field, Button } from "@fider/components"
+import { Toggle, Field, Button, Select, Input } from "@fider/components"
 import { useFider } from "@fider/hooks"
 import { HStack } from "@fider/components/layout"
 import { i18n } from "@lingui/core"
@@ -12,6 +12,19 @@ type Channel = number
 const WebChannel: Channel = 1
 const EmailChannel: Channel = 2
 const PushChannel: Channel = 4
+const DigestChannel: Channel = 8
+
+const DigestFrequencyKey = "digest_frequency"
+const TimezoneKey = "timezone"
+const QuietHoursStartKey = "quiet_hours_start"
+const QuietHoursEndKey = "quiet_hours_end"
+
+const browserTimezone = (): string => Intl.DateTimeFormat().resolvedOptions().timeZone || "UTC"
+
+const allTimezones = (): string[] => {
+  const supportedValuesOf = (Intl as any).supportedValuesOf
+  return supportedValuesOf ? supportedValuesOf("timeZone") : [browserTimezone()]
+}
 
 interface NotificationSettingsProps {
   userSettings: UserSettings
@@ -71,9 +84,38 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
   }
 
   const toggle = async (settingsKey: string, channel: Channel) => {
+    let value = parseInt(userSettings[settingsKey], 10) ^ channel
+    // email and digest are alternatives, enabling one turns the other off
+    if ((value & channel) > 0 && channel === EmailChannel) {
+      value &= ~DigestChannel
+    } else if ((value & channel) > 0 && channel === DigestChannel) {
+      value &= ~EmailChannel
+    }
+    const nextSettings = {
+      ...userSettings,
+      [settingsKey]: value.toString(),
+    }
+    setUserSettings(nextSettings)
+    props.settingsChanged(nextSettings)
+  }
+
+  const changeDigestFrequency = (frequency: string) => {
+    const nextSettings = {
+      ...userSettings,
+      [DigestFrequencyKey]: frequency,
+    }
+    setUserSettings(nextSettings)
+    props.settingsChanged(nextSettings)
+  }
+
+  const changeQuietHours = (key: string, value: string) => {
     const nextSettings = {
       ...userSettings,
-      [settingsKey]: (parseInt(userSettings[settingsKey], 10) ^ channel).toString(),
+      [key]: value,
+    }
+    // quiet hours are kept in the timezone of the browser they were first set on until changed
+    if (value && !nextSettings[TimezoneKey]) {
+      nextSettings[TimezoneKey] = browserTimezone()
     }
     setUserSettings(nextSettings)
     props.settingsChanged(nextSettings)
@@ -82,6 +124,7 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
   const labelWeb = i18n._("mysettings.notification.channelweb", { message: "Web" })
   const labelEmail = i18n._("mysettings.notification.channelemail", { message: "Email" })
   const labelPush = i18n._("mysettings.notification.channelpush", { message: "Push" })
+  const labelDigest = i18n._("mysettings.notification.channeldigest", { message: "Digest" })
 
   const icon = (settingsKey: string, channel: Channel) => {
     const active = isEnabled(settingsKey, channel)
@@ -90,6 +133,8 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
       label = labelWeb
     } else if (channel === EmailChannel) {
       label = labelEmail
+    } else if (channel === DigestChannel) {
+      label = labelDigest
     } else {
       label = labelPush
     }
@@ -188,6 +233,7 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
           <HStack spacing={6}>
             {icon("event_notification_new_post", WebChannel)}
             {fider.session.user.isAdministrator && icon("event_notification_new_post", EmailChannel)}
+            {fider.session.user.isAdministrator && icon("event_notification_new_post", DigestChannel)}
             {pushSubscribed && icon("event_notification_new_post", PushChannel)}
           </HStack>
         </div>
@@ -203,6 +249,7 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
           <HStack spacing={6}>
             {icon("event_notification_new_comment", WebChannel)}
             {fider.session.user.isAdministrator && icon("event_notification_new_comment", EmailChannel)}
+            {fider.session.user.isAdministrator && icon("event_notification_new_comment", DigestChannel)}
             {pushSubscribed && icon("event_notification_new_comment", PushChannel)}
           </HStack>
         </div>
@@ -236,6 +283,148 @@ export const NotificationSettings = (props: NotificationSettingsProps) => {
             {pushSubscribed && icon("event_notification_change_status", PushChannel)}
           </HStack>
         </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.reportoutcome">Report Outcome</Trans>
+          </div>
+          {info(
+            "event_notification_report_outcome",
+            t({ id: "mysettings.notification.event.reportoutcome.description", message: "whether the reports you filed were actioned or dismissed" }),
+            t({ id: "mysettings.notification.event.reportoutcome.description", message: "whether the reports you filed were actioned or dismissed" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_report_outcome", WebChannel)}
+            {icon("event_notification_report_outcome", EmailChannel)}
+            {pushSubscribed && icon("event_notification_report_outcome", PushChannel)}
+          </HStack>
+        </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.postlocked">Post Locked</Trans>
+          </div>
+          {info(
+            "event_notification_post_locked",
+            t({ id: "mysettings.notification.event.postlocked.description", message: "when staff lock a post you created" }),
+            t({ id: "mysettings.notification.event.postlocked.description", message: "when staff lock a post you created" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_post_locked", WebChannel)}
+            {icon("event_notification_post_locked", EmailChannel)}
+            {pushSubscribed && icon("event_notification_post_locked", PushChannel)}
+          </HStack>
+        </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.postarchived">Post Archived</Trans>
+          </div>
+          {info(
+            "event_notification_post_archived",
+            t({ id: "mysettings.notification.event.postarchived.description", message: "when staff archive a post you created" }),
+            t({ id: "mysettings.notification.event.postarchived.description", message: "when staff archive a post you created" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_post_archived", WebChannel)}
+            {icon("event_notification_post_archived", EmailChannel)}
+            {pushSubscribed && icon("event_notification_post_archived", PushChannel)}
+          </HStack>
+        </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.posttagged">Post Tagged</Trans>
+          </div>
+          {info(
+            "event_notification_post_tagged",
+            t({ id: "mysettings.notification.event.posttagged.description", message: "when staff tag a post you created" }),
+            t({ id: "mysettings.notification.event.posttagged.description", message: "when staff tag a post you created" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_post_tagged", WebChannel)}
+            {icon("event_notification_post_tagged", EmailChannel)}
+            {pushSubscribed && icon("event_notification_post_tagged", PushChannel)}
+          </HStack>
+        </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.contenthidden">Content Hidden</Trans>
+          </div>
+          {info(
+            "event_notification_content_hidden",
+            t({ id: "mysettings.notification.event.contenthidden.description", message: "when staff hide your post or comment for review" }),
+            t({ id: "mysettings.notification.event.contenthidden.description", message: "when staff hide your post or comment for review" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_content_hidden", WebChannel)}
+            {icon("event_notification_content_hidden", EmailChannel)}
+            {pushSubscribed && icon("event_notification_content_hidden", PushChannel)}
+          </HStack>
+        </div>
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.event.contentapproved">Content Approved</Trans>
+          </div>
+          {info(
+            "event_notification_content_approved",
+            t({ id: "mysettings.notification.event.contentapproved.description", message: "when your post or comment is approved by staff" }),
+            t({ id: "mysettings.notification.event.contentapproved.description", message: "when your post or comment is approved by staff" })
+          )}
+          <HStack spacing={6}>
+            {icon("event_notification_content_approved", WebChannel)}
+            {icon("event_notification_content_approved", EmailChannel)}
+            {pushSubscribed && icon("event_notification_content_approved", PushChannel)}
+          </HStack>
+        </div>
+        {fider.session.user.isAdministrator && (
+          <div className="p-4 bg-elevated">
+            <div className="font-medium mb-1">
+              <Trans id="mysettings.notification.digest.title">Digest Emails</Trans>
+            </div>
+            <p className="text-muted text-sm mt-1 mb-2">
+              <Trans id="mysettings.notification.digest.description">Events sent to the digest are grouped into a single email instead of one email each.</Trans>
+            </p>
+            <Select
+              field="digestFrequency"
+              value={userSettings[DigestFrequencyKey] || "daily"}
+              options={[
+                { value: "daily", label: i18n._("mysettings.notification.digest.daily", { message: "Daily" }) },
+                { value: "weekly", label: i18n._("mysettings.notification.digest.weekly", { message: "Weekly" }) },
+              ]}
+              onChange={(option) => option && changeDigestFrequency(option.value)}
+            />
+          </div>
+        )}
+        <div className="p-4 bg-elevated">
+          <div className="font-medium mb-1">
+            <Trans id="mysettings.notification.quiethours.title">Quiet Hours</Trans>
+          </div>
+          <p className="text-muted text-sm mt-1 mb-2">
+            <Trans id="mysettings.notification.quiethours.description">
+              Push and email notifications sent during this time are held back until it ends. Notifications on the site are not affected.
+            </Trans>
+          </p>
+          <HStack spacing={4}>
+            <Input
+              field="quietHoursStart"
+              type="time"
+              label={i18n._("mysettings.notification.quiethours.start", { message: "From" })}
+              value={userSettings[QuietHoursStartKey] || ""}
+              onChange={(value) => changeQuietHours(QuietHoursStartKey, value)}
+            />
+            <Input
+              field="quietHoursEnd"
+              type="time"
+              label={i18n._("mysettings.notification.quiethours.end", { message: "To" })}
+              value={userSettings[QuietHoursEndKey] || ""}
+              onChange={(value) => changeQuietHours(QuietHoursEndKey, value)}
+            />
+          </HStack>
+          <Select
+            field="timezone"
+            label={i18n._("mysettings.notification.quiethours.timezone", { message: "Timezone" })}
+            value={userSettings[TimezoneKey] || browserTimezone()}
+            options={allTimezones().map((tz) => ({ value: tz, label: tz }))}
+            onChange={(option) => option && changeQuietHours(TimezoneKey, option.value)}
+          />
+        </div>
       </div>
     </Field>
   )
@@ -0,0 +1,124 @@
+import React, { useEffect, useState } from "react"
+import { UserPasskey } from "@fider/models"
+import { Button, Form, Icon, Input, Moment } from "@fider/components"
+import { heroiconsKey as IconKey } from "@fider/icons.generated"
+import { actions, Failure, notify, passkey } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+export const Passkeys = () => {
+  const fider = useFider()
+  const [passkeys, setPasskeys] = useState<UserPasskey[]>([])
+  const [adding, setAdding] = useState(false)
+  const [name, setName] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const load = async () => {
+    const result = await actions.listUserPasskeys()
+    if (result.ok) {
+      setPasskeys(result.data)
+    }
+  }
+
+  useEffect(() => {
+    load()
+  }, [])
+
+  const cancel = () => {
+    setAdding(false)
+    setName("")
+    setError(undefined)
+  }
+
+  const add = async () => {
+    try {
+      const result = await passkey.register(name)
+      if (result && result.ok) {
+        cancel()
+        load()
+      } else if (result && result.error) {
+        setError(result.error)
+      }
+    } catch {
+      // the browser prompt was dismissed
+    }
+  }
+
+  const remove = async (item: UserPasskey) => {
+    const result = await actions.deleteUserPasskey(item.id)
+    if (result.ok) {
+      setPasskeys(passkeys.filter((p) => p.id !== item.id))
+    } else if (result.error && result.error.errors && result.error.errors.length > 0) {
+      notify.error(result.error.errors[0].message)
+    }
+  }
+
+  if (!passkey.isPasskeySupported()) {
+    return null
+  }
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconKey} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.passkeys.title">Passkeys</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.passkeys.description">Sign in with your fingerprint, face or device PIN instead of an email link.</Trans>
+        </p>
+        {passkeys.length > 0 && (
+          <div className="divide-y divide-surface-alt border border-surface-alt rounded-card overflow-hidden mb-3">
+            {passkeys.map((item) => (
+              <div key={item.id} className="p-3 bg-elevated flex items-center justify-between gap-4">
+                <div className="min-w-0">
+                  <p className="m-0 text-sm font-medium truncate">{item.name}</p>
+                  <p className="m-0 text-xs text-muted">
+                    {item.lastUsedAt ? (
+                      <>
+                        <Trans id="mysettings.passkeys.lastused">Last used</Trans> <Moment locale={fider.currentLocale} date={item.lastUsedAt} />
+                      </>
+                    ) : (
+                      <>
+                        <Trans id="mysettings.passkeys.added">Added</Trans> <Moment locale={fider.currentLocale} date={item.createdAt} />
+                      </>
+                    )}
+                  </p>
+                </div>
+                <Button size="small" variant="secondary" onClick={() => remove(item)}>
+                  <Trans id="action.delete">Delete</Trans>
+                </Button>
+              </div>
+            ))}
+          </div>
+        )}
+        {adding ? (
+          <Form error={error}>
+            <Input
+              field="name"
+              label={i18n._("mysettings.passkeys.name.label", { message: "Passkey name" })}
+              placeholder={i18n._("mysettings.passkeys.name.placeholder", { message: "e.g. My laptop" })}
+              onChange={setName}
+              maxLength={50}
+            />
+            <div className="flex gap-2">
+              <Button type="submit" size="small" variant="primary" disabled={name === ""} onClick={add}>
+                <Trans id="mysettings.passkeys.create">Create passkey</Trans>
+              </Button>
+              <Button size="small" variant="tertiary" onClick={cancel}>
+                <Trans id="action.cancel">Cancel</Trans>
+              </Button>
+            </div>
+          </Form>
+        ) : (
+          <Button size="small" variant="primary" onClick={() => setAdding(true)}>
+            <Trans id="mysettings.passkeys.add">Add a passkey</Trans>
+          </Button>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,77 @@
+import React, { useEffect, useState } from "react"
+import { Tag, TagPreferenceValue } from "@fider/models"
+import { Icon, Select, ShowTag } from "@fider/components"
+import { heroiconsTag as IconTag } from "@fider/icons.generated"
+import { actions } from "@fider/services"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+export const TagPreferences = () => {
+  const [tags, setTags] = useState<Tag[]>([])
+  const [preferences, setPreferences] = useState<{ [tagId: number]: TagPreferenceValue }>({})
+
+  useEffect(() => {
+    const load = async () => {
+      const [tagsResult, preferencesResult] = await Promise.all([actions.listTags(), actions.getTagPreferences()])
+      if (tagsResult.ok) {
+        setTags(tagsResult.data)
+      }
+      if (preferencesResult.ok) {
+        const map: { [tagId: number]: TagPreferenceValue } = {}
+        preferencesResult.data.forEach((p) => (map[p.tagId] = p.preference))
+        setPreferences(map)
+      }
+    }
+    load()
+  }, [])
+
+  const change = async (tag: Tag, value: string) => {
+    const preference = value === "follow" || value === "mute" ? value : ""
+    const result = await actions.setTagPreference(tag.slug, preference)
+    if (result.ok) {
+      const next = { ...preferences }
+      if (preference) {
+        next[tag.id] = preference
+      } else {
+        delete next[tag.id]
+      }
+      setPreferences(next)
+    }
+  }
+
+  if (tags.length === 0) {
+    return null
+  }
+
+  const options = [
+    { value: "none", label: i18n._("mysettings.tags.none", { message: "Default" }) },
+    { value: "follow", label: i18n._("mysettings.tags.follow", { message: "Follow" }) },
+    { value: "mute", label: i18n._("mysettings.tags.mute", { message: "Mute" }) },
+  ]
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconTag} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.tags.title">Tags</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.tags.description">
+            Follow tags to only hear about new posts in them and get updates on their posts. Muted tags are silenced unless you follow the post itself.
+          </Trans>
+        </p>
+        <div className="divide-y divide-surface-alt border border-surface-alt rounded-card overflow-hidden">
+          {tags.map((tag) => (
+            <div key={tag.id} className="p-3 bg-elevated flex items-center justify-between gap-4">
+              <ShowTag tag={tag} />
+              <Select field={`tag_${tag.slug}`} value={preferences[tag.id] || "none"} options={options} onChange={(o) => change(tag, o?.value || "")} />
+            </div>
+          ))}
+        </div>
+      </div>
+    </div>
+  )
+}
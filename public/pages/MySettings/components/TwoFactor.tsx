@@ -0,0 +1,169 @@
+import React, { useEffect, useState } from "react"
+import { TwoFactorStatus, TwoFactorEnrollment } from "@fider/models"
+import { Button, Form, Icon, Input } from "@fider/components"
+import { heroiconsShieldcheck as IconShieldCheck } from "@fider/icons.generated"
+import { actions, Failure, notify, querystring } from "@fider/services"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+type Mode = "idle" | "enroll" | "regenerate" | "disable"
+
+const RecoveryCodes = (props: { codes: string[]; onDone: () => void }) => {
+  return (
+    <div>
+      <p className="text-sm mb-2">
+        <Trans id="mysettings.twofactor.recoverycodes.text">
+          Save these recovery codes somewhere safe. Each one can be used once to sign in if you lose access to your authenticator app. They won&apos;t be
+          shown again.
+        </Trans>
+      </p>
+      <div className="grid grid-cols-2 gap-2 p-3 mb-4 bg-surface-alt rounded-card font-mono text-sm">
+        {props.codes.map((code) => (
+          <span key={code}>{code}</span>
+        ))}
+      </div>
+      <Button variant="primary" size="small" onClick={props.onDone}>
+        <Trans id="mysettings.twofactor.recoverycodes.saved">I saved them</Trans>
+      </Button>
+    </div>
+  )
+}
+
+export const TwoFactor = () => {
+  const [status, setStatus] = useState<TwoFactorStatus | undefined>()
+  const [mode, setMode] = useState<Mode>("idle")
+  const [enrollment, setEnrollment] = useState<TwoFactorEnrollment | undefined>()
+  const [recoveryCodes, setRecoveryCodes] = useState<string[]>([])
+  const [code, setCode] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const load = async () => {
+    const result = await actions.getTwoFactorStatus()
+    if (result.ok) {
+      setStatus(result.data)
+    }
+  }
+
+  useEffect(() => {
+    load()
+
+    if (querystring.get("twofactor") === "required") {
+      notify.error(i18n._("mysettings.twofactor.required", { message: "You must enable two-factor authentication before you can access staff pages." }))
+      window.history.replaceState({}, "", `${window.location.pathname}${window.location.hash}`)
+    }
+  }, [])
+
+  const reset = () => {
+    setMode("idle")
+    setEnrollment(undefined)
+    setCode("")
+    setError(undefined)
+  }
+
+  const startEnrollment = async () => {
+    const result = await actions.startTwoFactorEnrollment()
+    if (result.ok) {
+      setEnrollment(result.data)
+      setMode("enroll")
+    }
+  }
+
+  const submit = async () => {
+    let result
+    if (mode === "enroll") {
+      result = await actions.enableTwoFactor(code)
+    } else if (mode === "regenerate") {
+      result = await actions.regenerateRecoveryCodes(code)
+    } else {
+      result = await actions.disableTwoFactor(code)
+    }
+
+    if (result.ok) {
+      const data = result.data as { recoveryCodes?: string[] } | undefined
+      setRecoveryCodes(data?.recoveryCodes || [])
+      reset()
+      load()
+    } else if (result.error) {
+      setError(result.error)
+    }
+  }
+
+  if (!status) {
+    return null
+  }
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconShieldCheck} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.twofactor.title">Two-Factor Authentication</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        {recoveryCodes.length > 0 ? (
+          <RecoveryCodes codes={recoveryCodes} onDone={() => setRecoveryCodes([])} />
+        ) : mode === "idle" ? (
+          <>
+            <p className="text-muted text-sm mb-4">
+              {status.enabled ? (
+                <Trans id="mysettings.twofactor.enabled">
+                  Two-factor authentication is enabled. You have {status.recoveryCodesLeft} unused recovery codes left.
+                </Trans>
+              ) : (
+                <Trans id="mysettings.twofactor.description">
+                  Protect your account with a code from an authenticator app in addition to your usual way to sign in.
+                </Trans>
+              )}
+            </p>
+            {status.enabled ? (
+              <div className="flex gap-2">
+                <Button size="small" variant="secondary" onClick={() => setMode("regenerate")}>
+                  <Trans id="mysettings.twofactor.regenerate">New recovery codes</Trans>
+                </Button>
+                {!status.required && (
+                  <Button size="small" variant="danger" onClick={() => setMode("disable")}>
+                    <Trans id="mysettings.twofactor.disable">Disable</Trans>
+                  </Button>
+                )}
+              </div>
+            ) : (
+              <Button size="small" variant="primary" onClick={startEnrollment}>
+                <Trans id="mysettings.twofactor.enable">Enable</Trans>
+              </Button>
+            )}
+          </>
+        ) : (
+          <>
+            {mode === "enroll" && enrollment && (
+              <div className="mb-4">
+                <p className="text-sm mb-2">
+                  <Trans id="mysettings.twofactor.enroll.text">Scan this QR code with your authenticator app, or type the secret key in it.</Trans>
+                </p>
+                <img src={enrollment.qrCode} alt={enrollment.url} width={192} height={192} />
+                <p className="text-sm font-mono break-all">{enrollment.secret}</p>
+              </div>
+            )}
+            <Form error={error}>
+              <Input
+                field="code"
+                label={i18n._("mysettings.twofactor.code.label", { message: "Code from your authenticator app" })}
+                onChange={setCode}
+                maxLength={20}
+                autoComplete="one-time-code"
+              />
+              <div className="flex gap-2">
+                <Button type="submit" size="small" variant="primary" disabled={code === ""} onClick={submit}>
+                  <Trans id="action.confirm">Confirm</Trans>
+                </Button>
+                <Button size="small" variant="tertiary" onClick={reset}>
+                  <Trans id="action.cancel">Cancel</Trans>
+                </Button>
+              </div>
+            </Form>
+          </>
+        )}
+      </div>
+    </div>
+  )
+}
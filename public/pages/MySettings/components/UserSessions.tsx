@@ -0,0 +1,88 @@
+import React, { useEffect, useState } from "react"
+import { UserSession } from "@fider/models"
+import { Button, Icon, Moment } from "@fider/components"
+import { heroiconsLock as IconLock } from "@fider/icons.generated"
+import { actions } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+export const UserSessions = () => {
+  const fider = useFider()
+  const [sessions, setSessions] = useState<UserSession[]>([])
+
+  useEffect(() => {
+    const load = async () => {
+      const result = await actions.listUserSessions()
+      if (result.ok) {
+        setSessions(result.data)
+      }
+    }
+    load()
+  }, [])
+
+  const revoke = async (session: UserSession) => {
+    const result = await actions.revokeUserSession(session.id)
+    if (result.ok) {
+      if (session.current) {
+        location.href = "/"
+        return
+      }
+      setSessions(sessions.filter((s) => s.id !== session.id))
+    }
+  }
+
+  const revokeOthers = async () => {
+    const result = await actions.revokeOtherUserSessions()
+    if (result.ok) {
+      setSessions(sessions.filter((s) => s.current))
+    }
+  }
+
+  if (sessions.length === 0) {
+    return null
+  }
+
+  return (
+    <div className="bg-elevated rounded-card shadow-sm overflow-hidden">
+      <div className="flex items-center gap-2 p-4 border-b border-surface-alt">
+        <Icon sprite={IconLock} className="h-5 w-5 text-primary" />
+        <h3 className="m-0 font-semibold">
+          <Trans id="mysettings.sessions.title">Sessions</Trans>
+        </h3>
+      </div>
+      <div className="p-4">
+        <p className="text-muted text-sm mb-4">
+          <Trans id="mysettings.sessions.description">These are the browsers you are signed in on. Sign out of the ones you don&apos;t recognize.</Trans>
+        </p>
+        <div className="divide-y divide-surface-alt border border-surface-alt rounded-card overflow-hidden">
+          {sessions.map((session) => (
+            <div key={session.id} className="p-3 bg-elevated flex items-center justify-between gap-4">
+              <div className="min-w-0">
+                <p className="m-0 text-sm font-medium truncate">{session.userAgent || i18n._("mysettings.sessions.unknownbrowser", { message: "Unknown browser" })}</p>
+                <p className="m-0 text-xs text-muted">
+                  {session.ipAddress && <>{session.ipAddress} · </>}
+                  {session.current ? (
+                    <Trans id="mysettings.sessions.current">This browser</Trans>
+                  ) : (
+                    <>
+                      <Trans id="mysettings.sessions.lastseen">Last seen</Trans> <Moment locale={fider.currentLocale} date={session.lastSeenAt} />
+                    </>
+                  )}
+                </p>
+              </div>
+              <Button size="small" variant="secondary" onClick={() => revoke(session)}>
+                <Trans id="mysettings.sessions.signout">Sign out</Trans>
+              </Button>
+            </div>
+          ))}
+        </div>
+        {sessions.length > 1 && (
+          <Button className="mt-3" size="small" variant="danger" onClick={revokeOthers}>
+            <Trans id="mysettings.sessions.signoutothers">Sign out of all other browsers</Trans>
+          </Button>
+        )}
+      </div>
+    </div>
+  )
+}
@@ -0,0 +1,100 @@
+import React, { useState } from "react"
+import { APITokenScope, OAuth2AuthorizeRequest } from "@fider/models"
+import { Button, SignInControl } from "@fider/components"
+import { HStack } from "@fider/components/layout"
+import { actions } from "@fider/services"
+import { useFider } from "@fider/hooks"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+interface AuthorizePageProps {
+  error?: string
+  client: { name: string }
+  scopes: APITokenScope[]
+  request: OAuth2AuthorizeRequest
+}
+
+const scopeDescriptions = (): { [key in APITokenScope]: string } => ({
+  "read:posts": i18n._("oauth2.authorize.scope.readposts", { message: "Read posts and comments" }),
+  "write:posts": i18n._("oauth2.authorize.scope.writeposts", { message: "Create, edit and vote on posts" }),
+  "write:comments": i18n._("oauth2.authorize.scope.writecomments", { message: "Comment and react" }),
+  moderate: i18n._("oauth2.authorize.scope.moderate", { message: "Moderate posts, comments and members" }),
+  admin: i18n._("oauth2.authorize.scope.admin", { message: "Administer this site, with full access to your account" }),
+})
+
+export const AuthorizePage = (props: AuthorizePageProps) => {
+  const fider = useFider()
+  const [isSubmitting, setIsSubmitting] = useState(false)
+
+  const respond = async (approve: boolean) => {
+    setIsSubmitting(true)
+    const result = await actions.authorizeOAuthClient(props.request, approve)
+    if (result.ok) {
+      location.href = result.data.redirectUrl
+    } else {
+      setIsSubmitting(false)
+    }
+  }
+
+  if (props.error) {
+    return (
+      <div id="p-oauth2-authorize" className="page container w-max-xl py-[50px]">
+        <div className="bg-elevated rounded-card shadow-sm p-4">
+          <h1 className="text-xl font-semibold mb-2">
+            <Trans id="oauth2.authorize.error.title">This app can&apos;t be authorized</Trans>
+          </h1>
+          <p className="text-danger m-0">{props.error}</p>
+        </div>
+      </div>
+    )
+  }
+
+  const descriptions = scopeDescriptions()
+
+  return (
+    <div id="p-oauth2-authorize" className="page container w-max-xl py-[50px]">
+      <div className="bg-elevated rounded-card shadow-sm p-4">
+        <h1 className="text-xl font-semibold mb-2">
+          <Trans id="oauth2.authorize.title">
+            <strong>{props.client.name}</strong> wants to access your {fider.session.tenant.name} account
+          </Trans>
+        </h1>
+
+        {!fider.session.isAuthenticated ? (
+          <>
+            <p className="text-muted">
+              <Trans id="oauth2.authorize.signin">Sign in to continue.</Trans>
+            </p>
+            <SignInControl useEmail={true} redirectTo={typeof window !== "undefined" ? window.location.href : undefined} />
+          </>
+        ) : (
+          <>
+            <p className="text-muted">
+              <Trans id="oauth2.authorize.scopes">If you allow it, this app will be able to:</Trans>
+            </p>
+            <ul className="mb-4">
+              {props.scopes.map((scope) => (
+                <li key={scope}>{descriptions[scope]}</li>
+              ))}
+            </ul>
+            <p className="text-muted text-sm">
+              <Trans id="oauth2.authorize.signedinas">
+                You are signed in as <strong>{fider.session.user.name}</strong>. Only allow apps you trust.
+              </Trans>
+            </p>
+            <HStack spacing={2}>
+              <Button variant="primary" disabled={isSubmitting} onClick={() => respond(true)}>
+                <Trans id="oauth2.authorize.approve">Allow</Trans>
+              </Button>
+              <Button variant="secondary" disabled={isSubmitting} onClick={() => respond(false)}>
+                <Trans id="oauth2.authorize.deny">Deny</Trans>
+              </Button>
+            </HStack>
+          </>
+        )}
+      </div>
+    </div>
+  )
+}
+
+export default AuthorizePage
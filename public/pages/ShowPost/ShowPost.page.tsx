@@ -47,6 +47,7 @@ interface ReportStatus {
 interface ShowPostPageProps {
   post: Post
   subscribed: boolean
+  muted: boolean
   comments: Comment[]
   tags: Tag[]
   votes: Vote[]
@@ -321,7 +322,7 @@ const ShowPostPage: React.FC<ShowPostPageProps> = (props) => {
                       <h1 className="text-large">{props.post.title}</h1>
                       {isPostLocked(props.post) && <LockStatus post={props.post} />}
                       {isPostArchived(props.post) && <ArchiveStatus post={props.post} />}
-                      {isPostHidden(props.post) && <HiddenStatus post={props.post} />}
+                      {(isPostHidden(props.post) || props.post.shadowHidden) && <HiddenStatus post={props.post} />}
                     </>
                   )}
                 </div>
@@ -391,6 +392,7 @@ const ShowPostPage: React.FC<ShowPostPageProps> = (props) => {
                 comments={props.comments}
                 highlightedComment={state.highlightedComment}
                 subscribed={props.subscribed}
+                muted={props.muted}
                 reportedCommentIds={props.reportStatus?.reportedCommentIds ?? []}
                 dailyLimitReached={props.reportStatus?.dailyLimitReached ?? false}
                 reportReasons={props.reportReasons}
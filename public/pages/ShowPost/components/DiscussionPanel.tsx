@@ -12,6 +12,7 @@ interface DiscussionPanelProps {
   comments: Comment[]
   highlightedComment?: number
   subscribed: boolean
+  muted?: boolean
   reportedCommentIds: number[]
   dailyLimitReached: boolean
   reportReasons?: ReportReason[]
@@ -35,7 +36,7 @@ export const DiscussionPanel = (props: DiscussionPanelProps) => {
           <span className="text-category">
             <Trans id="label.discussion">Discussion</Trans>
           </span>
-          <FollowButton post={props.post} subscribed={props.subscribed} />
+          <FollowButton post={props.post} subscribed={props.subscribed} muted={props.muted} />
         </HStack>
         <VStack spacing={4} className="c-comment-list">
           {comments.map((c) => (
@@ -2,19 +2,21 @@ import React, { useState } from "react"
 import { Button, Icon } from "@fider/components"
 import { actions } from "@fider/services"
 import { useFider } from "@fider/hooks"
-import { heroiconsPlus as IconPlus, heroiconsCheck as IconCheck } from "@fider/icons.generated"
-import { VStack } from "@fider/components/layout"
+import { heroiconsPlus as IconPlus, heroiconsCheck as IconCheck, heroiconsVolumeOff as IconVolumeOff } from "@fider/icons.generated"
+import { HStack } from "@fider/components/layout"
 import { Trans } from "@lingui/macro"
 import { Post, isPostLocked } from "@fider/models"
 
 export interface NotificationsPanelProps {
   post: Post
   subscribed: boolean
+  muted?: boolean
 }
 
 export const FollowButton = (props: NotificationsPanelProps) => {
   const fider = useFider()
   const [subscribed, setSubscribed] = useState(props.subscribed)
+  const [muted, setMuted] = useState(!!props.muted)
   const isLocked = isPostLocked(props.post) || fider.isReadOnly
 
   const subscribeOrUnsubscribe = async () => {
@@ -23,6 +25,17 @@ export const FollowButton = (props: NotificationsPanelProps) => {
     const response = await action(props.post.number)
     if (response.ok) {
       setSubscribed(!subscribed)
+      setMuted(false)
+    }
+  }
+
+  const muteOrUnmute = async () => {
+    const action = muted ? actions.unsubscribe : actions.mutePost
+
+    const response = await action(props.post.number)
+    if (response.ok) {
+      setMuted(!muted)
+      setSubscribed(false)
     }
   }
 
@@ -56,5 +69,17 @@ export const FollowButton = (props: NotificationsPanelProps) => {
     </Button>
   )
 
-  return <VStack>{button}</VStack>
-}
\ No newline at end of file
+  const muteButton = (
+    <Button variant={muted ? "primary" : "tertiary"} className="no-focus" onClick={muteOrUnmute} disabled={isLocked}>
+      <Icon sprite={IconVolumeOff} />
+      <span>{muted ? <Trans id="label.muted">Muted</Trans> : <Trans id="label.mute">Mute</Trans>}</span>
+    </Button>
+  )
+
+  return (
+    <HStack>
+      {button}
+      {muteButton}
+    </HStack>
+  )
+}
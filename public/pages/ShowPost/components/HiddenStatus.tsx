@@ -11,7 +11,7 @@ interface HiddenStatusProps {
 export const HiddenStatus = (props: HiddenStatusProps) => {
   const { post } = props
 
-  if (!post.moderationPending) {
+  if (!post.moderationPending && !post.shadowHidden) {
     return null
   }
 
@@ -27,9 +27,15 @@ export const HiddenStatus = (props: HiddenStatusProps) => {
       </div>
       
       <div className="px-4 py-3 text-sm text-foreground">
-        <Trans id="showpost.hidden.description">
-          This content has been flagged and is only visible to moderators.
-        </Trans>
+        {post.moderationPending ? (
+          <Trans id="showpost.hidden.description">
+            This content has been flagged and is only visible to moderators.
+          </Trans>
+        ) : (
+          <Trans id="showpost.hidden.shadowban">
+            The author was shadow-banned when this was posted. Only they and moderators can see it.
+          </Trans>
+        )}
       </div>
     </div>
   )
@@ -255,6 +255,11 @@ export const ShowComment = (props: ShowCommentProps) => {
             <Trans id="showcomment.hidden.label">Hidden from public view</Trans>
           </div>
         )}
+        {!isCommentHidden(props.comment) && props.comment.shadowHidden && (
+          <div className="text-xs font-medium text-muted mb-2">
+            <Trans id="showcomment.shadowhidden.label">Shadow-banned author, only visible to them and moderators</Trans>
+          </div>
+        )}
         <div className="flex items-start justify-between gap-2 mb-2">
           <div className="flex items-center gap-2 min-w-0 flex-wrap">
             <Avatar user={comment.user} size="small" />
@@ -0,0 +1,51 @@
+import React, { useState } from "react"
+import { Button, Form, Input, LegalFooter } from "@fider/components"
+import { actions, Failure } from "@fider/services"
+import { i18n } from "@lingui/core"
+import { Trans } from "@lingui/react/macro"
+
+const TwoFactorSignInPage = () => {
+  const [code, setCode] = useState("")
+  const [error, setError] = useState<Failure | undefined>()
+
+  const submit = async () => {
+    const result = await actions.verifyTwoFactorSignIn(code)
+    if (result.ok) {
+      location.href = result.data.redirect || "/"
+    } else if (result.error) {
+      setError(result.error)
+    }
+  }
+
+  return (
+    <div id="p-two-factor-signin">
+      <p className="text-title">
+        <Trans id="signin.twofactor.header">Two-factor authentication</Trans>
+      </p>
+
+      <p>
+        <Trans id="signin.twofactor.text">
+          Enter the code from your authenticator app. If you lost access to it, you can use one of your recovery codes instead.
+        </Trans>
+      </p>
+      <Form error={error}>
+        <Input
+          field="code"
+          onChange={setCode}
+          maxLength={20}
+          autoComplete="one-time-code"
+          placeholder={i18n._("signin.twofactor.code.placeholder", { message: "123456" })}
+          suffix={
+            <Button type="submit" onClick={submit} variant="primary" disabled={code === ""}>
+              <Trans id="action.submit">Submit</Trans>
+            </Button>
+          }
+        />
+      </Form>
+
+      <LegalFooter />
+    </div>
+  )
+}
+
+export default TwoFactorSignInPage
@@ -1,2 +1,3 @@
 export * from "./SignIn.page"
 export * from "./CompleteSignInProfile.page"
+export * from "./TwoFactorSignIn.page"
@@ -0,0 +1,62 @@
+import { http, Result, querystring } from "@fider/services"
+import { Appeal, AppealSanctionType, AppealStatus } from "@fider/models"
+
+interface CreateAppealResponse {
+  id: number
+}
+
+export const createAppeal = async (
+  sanctionType: AppealSanctionType,
+  sanctionId: number | undefined,
+  text: string
+): Promise<Result<CreateAppealResponse>> => {
+  return http.post<CreateAppealResponse>("/api/v1/user/appeals", {
+    sanctionType,
+    sanctionId,
+    text,
+  })
+}
+
+export const listMyAppeals = async (): Promise<Result<Appeal[]>> => {
+  return http.get<Appeal[]>("/api/v1/user/appeals")
+}
+
+export interface ListAppealsParams {
+  page?: number
+  perPage?: number
+  status?: AppealStatus | AppealStatus[]
+}
+
+export interface ListAppealsResponse {
+  appeals: Appeal[]
+  total: number
+  page: number
+  perPage: number
+}
+
+export const listAppeals = async (params: ListAppealsParams): Promise<Result<ListAppealsResponse>> => {
+  const queryParams: Record<string, string | number | undefined> = {
+    page: params.page,
+    perPage: params.perPage,
+  }
+  if (Array.isArray(params.status)) {
+    queryParams.status = params.status.join(",")
+  } else if (params.status) {
+    queryParams.status = params.status
+  }
+  const qs = querystring.stringify(queryParams)
+  return http.get<ListAppealsResponse>(`/api/v1/appeals${qs}`)
+}
+
+export const decideAppeal = async (
+  appealId: number,
+  status: "upheld" | "reduced" | "lifted",
+  note: string,
+  duration?: string
+): Promise<Result> => {
+  return http.put(`/api/v1/appeals/${appealId}/decide`, {
+    status,
+    note,
+    duration,
+  })
+}
@@ -0,0 +1,5 @@
+import { http, Result } from "@fider/services"
+
+export const retryQueuedEmail = async (id: number): Promise<Result> => {
+  return await http.post(`/_api/admin/email-queue/${id}/retry`)
+}
@@ -0,0 +1,14 @@
+import { http, Result } from "@fider/services"
+import { EmailTemplate, EmailTemplatePreviewResult } from "@fider/models"
+
+export const saveEmailTemplate = async (name: string, locale: string, subject: string, body: string): Promise<Result<EmailTemplate>> => {
+  return await http.put(`/_api/admin/email-templates/${name}`, { locale, subject, body })
+}
+
+export const deleteEmailTemplate = async (name: string, locale: string): Promise<Result> => {
+  return await http.delete(`/_api/admin/email-templates/${name}?locale=${encodeURIComponent(locale)}`)
+}
+
+export const previewEmailTemplate = async (name: string, locale: string, subject: string, body: string): Promise<Result<EmailTemplatePreviewResult>> => {
+  return await http.post(`/_api/admin/email-templates/${name}/preview`, { locale, subject, body })
+}
@@ -9,4 +9,8 @@ export * from "./webhook"
 export * from "./billing"
 export * from "./file"
 export * from "./response"
-export * from "./report"
\ No newline at end of file
+export * from "./email_template"
+export * from "./email_queue"
+export * from "./report"
+export * from "./appeal"
+export * from "./oauth"
@@ -36,4 +36,25 @@ export const markAllAsRead = async (): Promise<Result> => {
 
 export const purgeReadNotifications = async (): Promise<Result<{purgedCount: number}>> => {
   return await http.post("/_api/notifications/purge-read")
-}
\ No newline at end of file
+}
+export interface NotificationPreference {
+  event: string
+  channels: string[]
+  editable: string[]
+  enabled: string[]
+  requiresSubscription: boolean
+}
+
+export const getNotificationPreferences = async (): Promise<Result<NotificationPreference[]>> => {
+  return http.get<NotificationPreference[]>("/api/v1/user/notification-preferences")
+}
+
+export const updateNotificationPreferences = async (
+  preferences: { event: string; channels: string[] }[]
+): Promise<Result<NotificationPreference[]>> => {
+  return http.put<NotificationPreference[]>("/api/v1/user/notification-preferences", { preferences })
+}
+
+export const unsubscribe = async (token: string): Promise<Result> => {
+  return await http.post(`/unsubscribe?token=${encodeURIComponent(token)}`)
+}
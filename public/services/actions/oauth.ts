@@ -0,0 +1,21 @@
+import { http, Result } from "@fider/services"
+import { APITokenScope, OAuthClient, OAuth2AuthorizeRequest } from "@fider/models"
+
+export interface OAuthClientData {
+  name: string
+  redirectUris: string[]
+  scopes: APITokenScope[]
+  isConfidential: boolean
+}
+
+export const createOAuthClient = async (data: OAuthClientData): Promise<Result<OAuthClient & { clientSecret?: string }>> => {
+  return await http.post<OAuthClient & { clientSecret?: string }>("/api/v1/admin/oauth-clients", data)
+}
+
+export const deleteOAuthClient = async (id: number): Promise<Result> => {
+  return await http.delete(`/api/v1/admin/oauth-clients/${id}`)
+}
+
+export const authorizeOAuthClient = async (request: OAuth2AuthorizeRequest, approve: boolean): Promise<Result<{ redirectUrl: string }>> => {
+  return await http.post<{ redirectUrl: string }>("/_api/oauth2/authorize", { ...request, approve })
+}
@@ -91,6 +91,10 @@ export const unsubscribe = async (postNumber: number): Promise<Result> => {
   return http.delete(`/api/v1/posts/${postNumber}/subscription`).then(http.event("post", "unsubscribe"))
 }
 
+export const mutePost = async (postNumber: number): Promise<Result> => {
+  return http.post(`/api/v1/posts/${postNumber}/mute`).then(http.event("post", "mute"))
+}
+
 export const listVotes = async (postNumber: number): Promise<Result<Vote[]>> => {
   return http.get<Vote[]>(`/api/v1/posts/${postNumber}/votes`)
 }
@@ -1,5 +1,5 @@
 import { http, Result, querystring } from "@fider/services"
-import { Report, ReportReason, ReportType, ReportStatus, Post, Comment } from "@fider/models"
+import { Report, ReportCase, ReportReason, ReportType, ReportStatus, Post, Comment } from "@fider/models"
 
 interface CreateReportResponse {
   id: number
@@ -54,7 +54,7 @@ export interface ListReportsResponse {
   viewers: ReportViewers[]
 }
 
-export const listReports = async (params: ListReportsParams): Promise<Result<ListReportsResponse>> => {
+const reportsQueryString = (params: ListReportsParams): string => {
   const queryParams: Record<string, string | number | undefined> = {
     page: params.page,
     perPage: params.perPage,
@@ -66,8 +66,37 @@ export const listReports = async (params: ListReportsParams): Promise<Result<Lis
   } else if (params.status) {
     queryParams.status = params.status
   }
-  const qs = querystring.stringify(queryParams)
-  return http.get<ListReportsResponse>(`/api/v1/reports${qs}`)
+  return querystring.stringify(queryParams)
+}
+
+export const listReports = async (params: ListReportsParams): Promise<Result<ListReportsResponse>> => {
+  return http.get<ListReportsResponse>(`/api/v1/reports${reportsQueryString(params)}`)
+}
+
+export interface ListReportCasesResponse {
+  cases: ReportCase[]
+  total: number
+  page: number
+  perPage: number
+}
+
+export const listReportCases = async (params: ListReportsParams): Promise<Result<ListReportCasesResponse>> => {
+  return http.get<ListReportCasesResponse>(`/api/v1/report-cases${reportsQueryString(params)}`)
+}
+
+export const getReportCase = async (caseId: number): Promise<Result<ReportCase>> => {
+  return http.get<ReportCase>(`/api/v1/report-cases/${caseId}`)
+}
+
+export const resolveReportCase = async (
+  caseId: number,
+  status: "resolved" | "dismissed",
+  resolutionNote?: string
+): Promise<Result> => {
+  return http.put(`/api/v1/report-cases/${caseId}/resolve`, {
+    status,
+    resolutionNote,
+  })
 }
 
 export const getReport = async (reportId: number): Promise<Result<Report>> => {
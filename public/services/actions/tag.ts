@@ -1,5 +1,5 @@
 import { http, Result } from "@fider/services/http"
-import { Tag } from "@fider/models"
+import { Tag, TagPreference, TagPreferenceValue } from "@fider/models"
 
 export const createTag = async (name: string, color: string, isPublic: boolean): Promise<Result<Tag>> => {
   return http.post<Tag>(`/api/v1/tags`, { name, color, isPublic }).then(http.event("tag", "create"))
@@ -20,3 +20,15 @@ export const assignTag = async (slug: string, postNumber: number): Promise<Resul
 export const unassignTag = async (slug: string, postNumber: number): Promise<Result> => {
   return http.delete(`/api/v1/posts/${postNumber}/tags/${slug}`).then(http.event("tag", "unassign"))
 }
+
+export const listTags = async (): Promise<Result<Tag[]>> => {
+  return http.get<Tag[]>(`/api/v1/tags`)
+}
+
+export const getTagPreferences = async (): Promise<Result<TagPreference[]>> => {
+  return http.get<TagPreference[]>(`/api/v1/tag-preferences`)
+}
+
+export const setTagPreference = async (slug: string, preference: TagPreferenceValue | ""): Promise<Result> => {
+  return http.put(`/api/v1/tag-preferences/${slug}`, { preference }).then(http.event("tag", "preference"))
+}
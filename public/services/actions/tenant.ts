@@ -1,5 +1,5 @@
 import { http, Result } from "@fider/services/http"
-import { UserRole, OAuthConfig, ImageUpload, EmailVerificationKind } from "@fider/models"
+import { UserRole, OAuthConfig, ImageUpload, EmailVerificationKind, CustomRole, Permission, SCIMToken, User } from "@fider/models"
 
 export interface CheckAvailabilityResponse {
   message: string
@@ -69,6 +69,20 @@ export const updateTenantEmailAuthAllowed = async (isEmailAuthAllowed: boolean):
   })
 }
 
+export const updateTwoFactorPolicy = async (roles: string[]): Promise<Result> => {
+  return await http.post("/_api/admin/settings/twofactor", {
+    roles,
+  })
+}
+
+export const generateSCIMToken = async (): Promise<Result<SCIMToken & { token: string }>> => {
+  return await http.post<SCIMToken & { token: string }>("/_api/admin/scim/token")
+}
+
+export const deleteSCIMToken = async (): Promise<Result> => {
+  return await http.delete("/_api/admin/scim/token")
+}
+
 export const checkAvailability = async (subdomain: string): Promise<Result<CheckAvailabilityResponse>> => {
   return await http.get<CheckAvailabilityResponse>(`/_api/tenants/${subdomain}/availability`)
 }
@@ -87,20 +101,58 @@ export const completeProfile = async (kind: EmailVerificationKind, key: string,
   })
 }
 
+export interface CustomRoleInput {
+  name: string
+  description: string
+  permissions: Permission[]
+}
+
+export const listCustomRoles = async (): Promise<Result<CustomRole[]>> => {
+  return await http.get<CustomRole[]>("/api/v1/admin/roles")
+}
+
+export const createCustomRole = async (input: CustomRoleInput): Promise<Result<CustomRole>> => {
+  return await http.post<CustomRole>("/api/v1/admin/roles", input)
+}
+
+export const updateCustomRole = async (id: number, input: CustomRoleInput): Promise<Result<CustomRole>> => {
+  return await http.put<CustomRole>(`/api/v1/admin/roles/${id}`, input)
+}
+
+export const deleteCustomRole = async (id: number): Promise<Result> => {
+  return await http.delete(`/api/v1/admin/roles/${id}`)
+}
+
+export const listCustomRoleMembers = async (id: number): Promise<Result<User[]>> => {
+  return await http.get<User[]>(`/api/v1/admin/roles/${id}/members`)
+}
+
+export const getUserCustomRole = async (userID: number): Promise<Result<CustomRole | null>> => {
+  return await http.get<CustomRole | null>(`/api/v1/admin/users/${userID}/custom-role`)
+}
+
+export const setUserCustomRole = async (userID: number, customRoleID: number): Promise<Result> => {
+  return await http.put(`/api/v1/admin/users/${userID}/custom-role`, { customRoleID })
+}
+
 export const changeUserRole = async (userID: number, role: UserRole): Promise<Result> => {
   return await http.post(`/_api/admin/roles/${role}/users`, {
     userID,
   })
 }
 
-export const blockUser = async (userID: number): Promise<Result> => {
-  return await http.put(`/_api/admin/users/${userID}/block`)
+export const blockUser = async (userID: number, revokeSessions = false): Promise<Result> => {
+  return await http.put(`/_api/admin/users/${userID}/block${revokeSessions ? "?revokeSessions=true" : ""}`)
 }
 
 export const unblockUser = async (userID: number): Promise<Result> => {
   return await http.delete(`/_api/admin/users/${userID}/block`)
 }
 
+export const dismissBlockProposal = async (userID: number): Promise<Result> => {
+  return await http.delete(`/_api/admin/users/${userID}/block-proposal`)
+}
+
 export const getOAuthConfig = async (provider: string): Promise<Result<OAuthConfig>> => {
   return await http.get<OAuthConfig>(`/_api/admin/oauth/${provider}`)
 }
@@ -108,6 +160,8 @@ export const getOAuthConfig = async (provider: string): Promise<Result<OAuthConf
 export interface CreateEditOAuthConfigRequest {
   provider: string
   status: number
+  type: number
+  issuerURL: string
   displayName: string
   clientID: string
   clientSecret: string
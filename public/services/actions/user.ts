@@ -1,5 +1,16 @@
 import { http, Result } from "@fider/services/http"
-import { UserSettings, UserAvatarType, ImageUpload } from "@fider/models"
+import {
+  UserSettings,
+  UserAvatarType,
+  ImageUpload,
+  UserSession,
+  APIToken,
+  APITokenScope,
+  UserProviderLink,
+  TwoFactorStatus,
+  TwoFactorEnrollment,
+  UserPasskey,
+} from "@fider/models"
 import { Fider } from "@fider/services"
 
 interface UserProfileStats {
@@ -21,6 +32,23 @@ interface UserProfileStanding {
     createdAt: string
     expiresAt?: string
   }>
+  nextSanction?: {
+    trigger: "warnings" | "mutes"
+    remaining: number
+    windowDays?: number
+    action: "mute" | "propose_block"
+    duration?: string
+  }
+  restrictions?: UserRestriction[]
+  blockProposed?: boolean
+}
+
+export interface UserRestriction {
+  id: number
+  kind: "shadowban" | "probation"
+  reason: string
+  createdAt: string
+  expiresAt?: string
 }
 
 interface UserProfileContent {
@@ -54,6 +82,12 @@ interface WarnUserRequest {
   duration: string
 }
 
+interface RestrictUserRequest {
+  kind: "shadowban" | "probation"
+  reason: string
+  duration: string
+}
+
 export const updateUserSettings = async (data: {
   settings: UserSettings
 }): Promise<Result<void>> => {
@@ -106,6 +140,101 @@ export const regenerateAPIKey = async (): Promise<Result<{ apiKey: string }>> =>
   return await http.post<{ apiKey: string }>("/_api/user/regenerate-apikey")
 }
 
+export const listUserSessions = async (): Promise<Result<UserSession[]>> => {
+  return await http.get<UserSession[]>("/api/v1/user/sessions")
+}
+
+export const revokeUserSession = async (id: number): Promise<Result<void>> => {
+  return await http.delete(`/api/v1/user/sessions/${id}`)
+}
+
+export const revokeOtherUserSessions = async (): Promise<Result<void>> => {
+  return await http.delete("/api/v1/user/sessions")
+}
+
+export const revokeAllSessionsOfUser = async (userID: number): Promise<Result<void>> => {
+  return await http.delete(`/_api/admin/users/${userID}/sessions`)
+}
+
+export const listUserProviders = async (): Promise<Result<UserProviderLink[]>> => {
+  return await http.get<UserProviderLink[]>("/api/v1/user/providers")
+}
+
+export const unlinkUserProvider = async (provider: string): Promise<Result<void>> => {
+  return await http.delete(`/api/v1/user/providers/${provider}`)
+}
+
+export const decideUserMergeRequest = async (id: number, status: "approved" | "rejected"): Promise<Result> => {
+  return await http.put(`/api/v1/admin/merge-requests/${id}`, { status })
+}
+
+export const getTwoFactorStatus = async (): Promise<Result<TwoFactorStatus>> => {
+  return await http.get<TwoFactorStatus>("/api/v1/user/2fa")
+}
+
+export const startTwoFactorEnrollment = async (): Promise<Result<TwoFactorEnrollment>> => {
+  return await http.post<TwoFactorEnrollment>("/api/v1/user/2fa/enroll")
+}
+
+export const enableTwoFactor = async (code: string): Promise<Result<{ recoveryCodes: string[] }>> => {
+  return await http.post<{ recoveryCodes: string[] }>("/api/v1/user/2fa/enable", { code })
+}
+
+export const regenerateRecoveryCodes = async (code: string): Promise<Result<{ recoveryCodes: string[] }>> => {
+  return await http.post<{ recoveryCodes: string[] }>("/api/v1/user/2fa/recovery-codes", { code })
+}
+
+export const disableTwoFactor = async (code: string): Promise<Result> => {
+  return await http.delete("/api/v1/user/2fa", { code })
+}
+
+export const verifyTwoFactorSignIn = async (code: string): Promise<Result<{ redirect: string }>> => {
+  return await http.post<{ redirect: string }>("/_api/signin/2fa", { code })
+}
+
+// eslint-disable-next-line @typescript-eslint/no-explicit-any
+type PasskeyOptions = { publicKey: any }
+
+export const listUserPasskeys = async (): Promise<Result<UserPasskey[]>> => {
+  return await http.get<UserPasskey[]>("/api/v1/user/passkeys")
+}
+
+export const beginPasskeyRegistration = async (): Promise<Result<PasskeyOptions>> => {
+  return await http.post<PasskeyOptions>("/api/v1/user/passkeys/begin")
+}
+
+export const finishPasskeyRegistration = async (name: string, credential: object): Promise<Result<UserPasskey>> => {
+  return await http.post<UserPasskey>("/api/v1/user/passkeys", { name, credential })
+}
+
+export const deleteUserPasskey = async (id: number): Promise<Result> => {
+  return await http.delete(`/api/v1/user/passkeys/${id}`)
+}
+
+export const beginPasskeySignIn = async (): Promise<Result<PasskeyOptions>> => {
+  return await http.post<PasskeyOptions>("/_api/signin/passkey/begin")
+}
+
+export const finishPasskeySignIn = async (credential: object): Promise<Result> => {
+  return await http.post("/_api/signin/passkey/finish", credential)
+}
+
+export const listAPITokens = async (): Promise<Result<APIToken[]>> => {
+  return await http.get<APIToken[]>("/api/v1/user/tokens")
+}
+
+export const createAPIToken = async (name: string, scopes: APITokenScope[], expiresInDays: number): Promise<Result<APIToken & { token: string }>> => {
+  return await http.post<APIToken & { token: string }>("/api/v1/user/tokens", { name, scopes, expiresInDays })
+}
+
+export const deleteAPIToken = async (id: number): Promise<Result<void>> => {
+  return await http.delete(`/api/v1/user/tokens/${id}`)
+}
+
+export const requestUserDataExport = async (): Promise<Result> => {
+  return await http.post("/api/v1/user/export", {})
+}
+
 export const getUserProfileStats = async (userID: number): Promise<Result<UserProfileStats>> => {
   return await http.get<UserProfileStats>(`/api/v1/user/profile/${userID}/stats`)
 }
@@ -187,3 +316,12 @@ export const expireMute = async (userID: number, muteID: number): Promise<Result
   const response = await http.post(`/_api/admin/users/${userID}/mutes/${muteID}/expire`)
   return response
 }
+
+export const restrictUser = async (userID: number, data: RestrictUserRequest): Promise<Result<{ id: number }>> => {
+  return await http.post<{ id: number }>(`/_api/admin/users/${userID}/restrictions`, data)
+}
+
+export const liftRestriction = async (userID: number, restrictionID: number): Promise<Result<void>> => {
+  const response = await http.post(`/_api/admin/users/${userID}/restrictions/${restrictionID}/expire`)
+  return response
+}
@@ -277,3 +277,4 @@ const createQueueEventSource = (): QueueEventSource => {
 
 export const reportsEventSource = createReportsEventSource()
 export const queueEventSource = createQueueEventSource()
+export const appealsEventSource = createEventSource({ endpoint: "/api/mod/appeal-events" })
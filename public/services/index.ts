@@ -17,5 +17,6 @@ import * as querystring from "./querystring"
 import * as device from "./device"
 import * as actions from "./actions"
 import * as push from "./push"
+import * as passkey from "./passkey"
 import navigator from "./navigator"
-export { actions, querystring, navigator, device, notify, markdown, push }
+export { actions, querystring, navigator, device, notify, markdown, push, passkey }
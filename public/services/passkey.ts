@@ -0,0 +1,92 @@
+import { Result } from "./http"
+import * as actions from "./actions"
+
+const toBase64URL = (buffer: ArrayBuffer): string => {
+  const bytes = new Uint8Array(buffer)
+  let binary = ""
+  bytes.forEach((b) => (binary += String.fromCharCode(b)))
+  return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "")
+}
+
+const fromBase64URL = (value: string): ArrayBuffer => {
+  const base64 = value.replace(/-/g, "+").replace(/_/g, "/")
+  const binary = atob(base64.padEnd(base64.length + ((4 - (base64.length % 4)) % 4), "="))
+  const bytes = new Uint8Array(binary.length)
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i)
+  }
+  return bytes.buffer
+}
+
+// eslint-disable-next-line @typescript-eslint/no-explicit-any
+const decodeDescriptors = (descriptors?: any[]): PublicKeyCredentialDescriptor[] | undefined => {
+  return descriptors?.map((d) => ({ ...d, id: fromBase64URL(d.id) }))
+}
+
+export const isPasskeySupported = (): boolean => {
+  return typeof window !== "undefined" && "PublicKeyCredential" in window && !!window.navigator.credentials
+}
+
+export const register = async (name: string): Promise<Result | undefined> => {
+  const begin = await actions.beginPasskeyRegistration()
+  if (!begin.ok) {
+    return begin
+  }
+
+  const options = begin.data.publicKey
+  const credential = (await window.navigator.credentials.create({
+    publicKey: {
+      ...options,
+      challenge: fromBase64URL(options.challenge),
+      user: { ...options.user, id: fromBase64URL(options.user.id) },
+      excludeCredentials: decodeDescriptors(options.excludeCredentials),
+    },
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    return undefined
+  }
+
+  const response = credential.response as AuthenticatorAttestationResponse
+  return await actions.finishPasskeyRegistration(name, {
+    id: credential.id,
+    rawId: toBase64URL(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64URL(response.clientDataJSON),
+      attestationObject: toBase64URL(response.attestationObject),
+      transports: response.getTransports ? response.getTransports() : [],
+    },
+  })
+}
+
+export const signIn = async (): Promise<Result | undefined> => {
+  const begin = await actions.beginPasskeySignIn()
+  if (!begin.ok) {
+    return begin
+  }
+
+  const options = begin.data.publicKey
+  const credential = (await window.navigator.credentials.get({
+    publicKey: {
+      ...options,
+      challenge: fromBase64URL(options.challenge),
+      allowCredentials: decodeDescriptors(options.allowCredentials),
+    },
+  })) as PublicKeyCredential | null
+  if (!credential) {
+    return undefined
+  }
+
+  const response = credential.response as AuthenticatorAssertionResponse
+  return await actions.finishPasskeySignIn({
+    id: credential.id,
+    rawId: toBase64URL(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64URL(response.clientDataJSON),
+      authenticatorData: toBase64URL(response.authenticatorData),
+      signature: toBase64URL(response.signature),
+      userHandle: response.userHandle ? toBase64URL(response.userHandle) : undefined,
+    },
+  })
+}
@@ -1,4 +1,4 @@
-import { CurrentUser, UserRole } from "@fider/models"
+import { CurrentUser, Permission, UserRole } from "@fider/models"
 import { Fider } from "./fider"
 import { TIME } from "./constants"
 
@@ -40,6 +40,13 @@ const getCurrentUser = (): CurrentUser | undefined => {
   return Fider.session.user
 }
 
+// hasPermission returns true if the role or the custom role of the user grants given permission
+export const hasPermission = (permission: Permission, user?: CurrentUser): boolean => {
+  const currentUser = user ?? getCurrentUser()
+  if (!currentUser) return false
+  return (currentUser.permissions || []).includes(permission)
+}
+
 interface UserLike {
   id: number
   role: RoleValue
@@ -61,37 +68,34 @@ const timeAgo = (date: string | Date): number => {
   return (new Date().getTime() - d.getTime()) / 1000
 }
 
+// canModerateContentOf mirrors the server: collaborators and administrators act on content of anyone,
+// other roles only on content of visitors and helpers
+const canModerateContentOf = (currentUser: CurrentUser, author: UserLike, permission: Permission): boolean => {
+  if (!hasPermission(permission, currentUser)) return false
+  if (currentUser.isCollaborator || currentUser.isAdministrator) return true
+  return hasRole(author.role, UserRole.Visitor) || hasRole(author.role, UserRole.Helper)
+}
+
 export const postPermissions = {
   canEdit: (post: PostLike, user?: CurrentUser): boolean => {
     const currentUser = user ?? getCurrentUser()
     if (!currentUser) return false
-    if (currentUser.isCollaborator || currentUser.isAdministrator) return true
-    if (currentUser.isModerator) {
-      return hasRole(post.user.role, UserRole.Visitor)
-    }
+    if (canModerateContentOf(currentUser, post.user, "post.edit")) return true
     return currentUser.id === post.user.id && timeAgo(post.createdAt) <= TIME.ONE_HOUR_SECONDS
   },
 
   canDelete: (post: PostLike, user?: CurrentUser): boolean => {
     const currentUser = user ?? getCurrentUser()
     if (!currentUser) return false
-    if (currentUser.isCollaborator || currentUser.isAdministrator) return true
-    if (currentUser.isModerator) {
-      return hasRole(post.user.role, UserRole.Visitor)
-    }
-    return false
+    return canModerateContentOf(currentUser, post.user, "post.delete")
   },
 
   canRespond: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isAdministrator
+    return hasPermission("post.respond", user) && hasPermission("post.status", user)
   },
 
   canRespondDuplicateOnly: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isModerator && !currentUser.isCollaborator && !currentUser.isAdministrator
+    return hasPermission("post.respond", user) && !hasPermission("post.status", user)
   },
 
   canRespondAny: (user?: CurrentUser): boolean => {
@@ -99,27 +103,19 @@ export const postPermissions = {
   },
 
   canLock: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isAdministrator
+    return hasPermission("post.lock", user)
   },
 
   canArchive: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isAdministrator
+    return hasPermission("post.archive", user)
   },
 
   canTag: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isModerator || currentUser.isAdministrator || currentUser.isHelper
+    return hasPermission("tag.assign", user)
   },
 
   canHide: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isModerator || currentUser.isAdministrator
+    return hasPermission("content.moderate", user)
   },
 }
 
@@ -127,20 +123,14 @@ export const commentPermissions = {
   canEdit: (comment: CommentLike, user?: CurrentUser): boolean => {
     const currentUser = user ?? getCurrentUser()
     if (!currentUser) return false
-    if (currentUser.isCollaborator || currentUser.isAdministrator) return true
-    if (currentUser.isModerator) {
-      return hasRole(comment.user.role, UserRole.Visitor)
-    }
+    if (canModerateContentOf(currentUser, comment.user, "comment.edit")) return true
     return currentUser.id === comment.user.id && timeAgo(comment.createdAt) <= TIME.ONE_HOUR_SECONDS
   },
 
   canDelete: (comment: CommentLike, user?: CurrentUser): boolean => {
     const currentUser = user ?? getCurrentUser()
     if (!currentUser) return false
-    if (currentUser.isCollaborator || currentUser.isAdministrator) return true
-    if (currentUser.isModerator) {
-      return hasRole(comment.user.role, UserRole.Visitor)
-    }
+    if (canModerateContentOf(currentUser, comment.user, "comment.delete")) return true
     return currentUser.id === comment.user.id
   },
 }
@@ -223,15 +213,11 @@ export const userPermissions = {
 
 export const reportPermissions = {
   canAssign: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isModerator || currentUser.isAdministrator
+    return hasPermission("report.view", user)
   },
 
   canResolve: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isModerator || currentUser.isAdministrator
+    return hasPermission("report.resolve", user)
   },
 
   canDismiss: (user?: CurrentUser): boolean => {
@@ -243,7 +229,7 @@ export const adminPermissions = {
   canAccessAdmin: (user?: CurrentUser): boolean => {
     const currentUser = user ?? getCurrentUser()
     if (!currentUser) return false
-    return isStaff(currentUser.role)
+    return isStaff(currentUser.role) || (currentUser.permissions || []).length > 0
   },
 
   canManageSettings: (user?: CurrentUser): boolean => {
@@ -259,9 +245,11 @@ export const adminPermissions = {
   },
 
   canManageTags: (user?: CurrentUser): boolean => {
-    const currentUser = user ?? getCurrentUser()
-    if (!currentUser) return false
-    return currentUser.isCollaborator || currentUser.isAdministrator
+    return hasPermission("tag.manage", user)
+  },
+
+  canManageResponses: (user?: CurrentUser): boolean => {
+    return hasPermission("response.manage", user)
   },
 }
 
@@ -271,6 +259,7 @@ export const permissions = {
   user: userPermissions,
   report: reportPermissions,
   admin: adminPermissions,
+  hasPermission,
   normalizeRole,
   hasRole,
   isStaff,
@@ -23,6 +23,7 @@ self.addEventListener('push', function(event) {
     icon: data.icon || '/static/favicon?size=200',
     badge: data.badge || '/static/favicon?size=64',
     tag: data.tag || 'default',
+    renotify: !!data.tag,
     data: { url: data.url || '/' },
     requireInteraction: false,
     silent: false